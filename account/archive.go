@@ -0,0 +1,109 @@
+package account
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"coscup2025/auth"
+	"coscup2025/media"
+	"coscup2025/proto/account"
+	"path"
+	"sort"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// buildArchive zips profile.json, videos.json and, with includeMedia, the
+// video files under media/<video_id>/.
+func buildArchive(ctx context.Context, user *auth.UserData, consent *account.Consent, videos map[string]*media.VideoInfo, includeMedia bool) ([]byte, error) {
+	profile := &account.Profile{
+		UserId:    user.ID,
+		Username:  user.Username,
+		CreatedAt: user.CreatedAt.Unix(),
+		Consent:   consent,
+	}
+	if user.TermsVersion != "" {
+		profile.TermsVersion = user.TermsVersion
+		profile.TermsAcceptedAt = user.TermsAcceptedAt.Unix()
+	}
+	for _, l := range user.Logins {
+		profile.Logins = append(profile.Logins, &account.Login{
+			At:        l.At.Unix(),
+			Address:   l.Address,
+			UserAgent: l.UserAgent,
+		})
+	}
+
+	ids := make([]string, 0, len(videos))
+	for id := range videos {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	index := &account.Videos{}
+	for _, id := range ids {
+		v := &account.ExportedVideo{VideoId: id, Metadata: videos[id].Metadata}
+		if includeMedia {
+			v.MediaPath = mediaPath(id, v.Metadata.FileName)
+		}
+		index.Videos = append(index.Videos, v)
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	if err := writeJSON(zw, "profile.json", profile); err != nil {
+		return nil, err
+	}
+	if err := writeJSON(zw, "videos.json", index); err != nil {
+		return nil, err
+	}
+	for _, v := range index.Videos {
+		if v.MediaPath == "" {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		// Videos are compressed already
+		w, err := zw.CreateHeader(&zip.FileHeader{
+			Name:     v.MediaPath,
+			Method:   zip.Store,
+			Modified: time.Unix(v.Metadata.UploadTimestamp, 0),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(videos[v.VideoId].Data); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// mediaPath is where a video file goes in the archive. Uploaded file names
+// are reduced to their base name so they cannot escape media/<video_id>/.
+func mediaPath(videoID, fileName string) string {
+	name := path.Base(path.Clean("/" + fileName))
+	if name == "/" || name == "." {
+		name = "video"
+	}
+	return path.Join("media", path.Base(path.Clean("/"+videoID)), name)
+}
+
+func writeJSON(zw *zip.Writer, name string, m proto.Message) error {
+	data, err := protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(m)
+	if err != nil {
+		return err
+	}
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
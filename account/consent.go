@@ -0,0 +1,73 @@
+package account
+
+import (
+	"context"
+	"coscup2025/auth"
+	"coscup2025/proto/account"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// defaultConsent applies to users who never changed their consent.
+func defaultConsent() *account.Consent {
+	return &account.Consent{PublicRecordings: true}
+}
+
+// consent returns the consent of userID. The caller must hold s.mu.
+func (s *accountServer) consent(userID string) *account.Consent {
+	if c, ok := s.consents[userID]; ok {
+		return c
+	}
+	return defaultConsent()
+}
+
+// Consented reports whether userID agreed to purpose. Subsystems acting on a
+// user's data, such as the public gallery, must check it first. Nobody
+// consents to the retired purposes, analytics and email.
+func (s *accountServer) Consented(userID string, purpose account.ConsentPurpose) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return purpose == account.ConsentPurpose_CONSENT_PURPOSE_PUBLIC_RECORDINGS && s.consent(userID).PublicRecordings
+}
+
+func (s *accountServer) GetConsent(ctx context.Context, req *account.GetConsentRequest) (*account.GetConsentResponse, error) {
+	id, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unknown caller")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return &account.GetConsentResponse{Consent: s.consent(id.UserID)}, nil
+}
+
+func (s *accountServer) SetConsent(ctx context.Context, req *account.SetConsentRequest) (*account.SetConsentResponse, error) {
+	id, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unknown caller")
+	}
+
+	// Nothing would honour these, so agreeing to them would mislead the user.
+	if req.GetAnalytics() || req.GetEmailNotifications() {
+		return nil, status.Error(codes.InvalidArgument, "the server does no analytics and sends no email to users")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Consents are shared with earlier responses, so replace them instead of
+	// modifying them in place.
+	c := proto.Clone(s.consent(id.UserID)).(*account.Consent)
+	if req.PublicRecordings != nil {
+		c.PublicRecordings = *req.PublicRecordings
+	}
+	c.UpdatedAt = time.Now().Unix()
+	s.consents[id.UserID] = c
+
+	return &account.SetConsentResponse{Consent: c}, nil
+}
@@ -0,0 +1,62 @@
+package account_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"coscup2025/env"
+	pbAccount "coscup2025/proto/account"
+	pbMedia "coscup2025/proto/media"
+)
+
+func TestConsentHidesRecordingsFromGallery(t *testing.T) {
+	conn, signIn := setup(t, env.DefaultConfig())
+	speaker := signIn("speaker")
+
+	mediaClient := pbMedia.NewMediaServiceClient(conn)
+	stream, err := mediaClient.UploadVideo(speaker)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&pbMedia.UploadVideoRequest{VideoId: "talk", Data: []byte("video"), Sequence: 1}))
+	_, err = stream.CloseAndRecv()
+	require.NoError(t, err)
+
+	client := pbAccount.NewAccountServiceClient(conn)
+	got, err := client.GetConsent(speaker, &pbAccount.GetConsentRequest{})
+	require.NoError(t, err)
+	assert.False(t, got.Consent.Analytics)
+	assert.True(t, got.Consent.PublicRecordings)
+
+	gallery, err := mediaClient.ListPublicVideos(context.Background(), &pbMedia.ListPublicVideosRequest{})
+	require.NoError(t, err)
+	assert.Len(t, gallery.Videos, 1)
+
+	set, err := client.SetConsent(speaker, &pbAccount.SetConsentRequest{PublicRecordings: proto.Bool(false)})
+	require.NoError(t, err)
+	assert.False(t, set.Consent.PublicRecordings)
+	set, err = client.SetConsent(speaker, &pbAccount.SetConsentRequest{Analytics: proto.Bool(false)})
+	require.NoError(t, err)
+	assert.False(t, set.Consent.PublicRecordings, "unset fields are kept")
+
+	// Nothing does analytics or emails users, so they cannot be agreed to
+	for _, req := range []*pbAccount.SetConsentRequest{
+		{Analytics: proto.Bool(true)},
+		{EmailNotifications: proto.Bool(true)},
+	} {
+		_, err = client.SetConsent(speaker, req)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+	got, err = client.GetConsent(speaker, &pbAccount.GetConsentRequest{})
+	require.NoError(t, err)
+	assert.False(t, got.Consent.Analytics)
+	assert.False(t, got.Consent.EmailNotifications)
+
+	gallery, err = mediaClient.ListPublicVideos(context.Background(), &pbMedia.ListPublicVideosRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, gallery.Videos)
+}
@@ -0,0 +1,177 @@
+package account
+
+import (
+	"bytes"
+	"coscup2025/proto/account"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+)
+
+// deletion is a scheduled erasure of an account. Fields are guarded by
+// accountServer.mu.
+type deletion struct {
+	userID      string
+	receiptID   string
+	requestedAt time.Time
+	scheduledAt time.Time
+	timer       *time.Timer
+}
+
+func (d *deletion) proto() *account.Deletion {
+	return &account.Deletion{
+		ReceiptId:   d.receiptID,
+		RequestedAt: d.requestedAt.Unix(),
+		ScheduledAt: d.scheduledAt.Unix(),
+	}
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// scheduleDeletion erases userID after the grace period, unless cancelled.
+// The caller must hold s.mu.
+func (s *accountServer) scheduleDeletion(userID string, now time.Time) (*deletion, error) {
+	d := &deletion{
+		userID: userID,
+		// Receipts are served without a token, so their IDs must not be
+		// guessable.
+		receiptID:   randomHex(16),
+		requestedAt: now,
+		scheduledAt: now.Add(s.grace),
+	}
+	if err := s.deletionStore.save(d); err != nil {
+		return nil, err
+	}
+	s.startDeletion(d)
+	return d, nil
+}
+
+// startDeletion erases the user of d when it is scheduled. The caller must
+// hold s.mu.
+func (s *accountServer) startDeletion(d *deletion) {
+	d.timer = time.AfterFunc(time.Until(d.scheduledAt), func() { s.erase(d) })
+	s.deletions[d.userID] = d
+}
+
+// erase irreversibly removes everything stored about the user of d and
+// stores a signed receipt.
+func (s *accountServer) erase(d *deletion) {
+	s.mu.Lock()
+	if s.deletions[d.userID] != d {
+		// Cancelled in the meantime
+		s.mu.Unlock()
+		return
+	}
+	// Left in the store until the receipt is, so that an erasure cut short
+	// by a restart runs again
+	delete(s.deletions, d.userID)
+	delete(s.exports, d.userID)
+	delete(s.consents, d.userID)
+	s.mu.Unlock()
+
+	var erased []string
+	username, ok := s.users.DeleteUser(d.userID)
+	if ok {
+		erased = append(erased, "account", "tokens")
+	}
+	videos, size := s.videos.DeleteUserData(d.userID)
+	erased = append(erased, "media")
+	s.jobs.DeleteOwner(d.userID)
+	erased = append(erased, "exports")
+
+	// A random pseudonym keeps the user's events apart in the audit log
+	// without any way to link them back to the user.
+	subject := "erased_" + randomHex(8)
+	for _, e := range s.erasers {
+		erased = append(erased, e.EraseUser(d.userID, subject))
+	}
+	if s.audit != nil {
+		if err := s.audit.Pseudonymize(d.userID, username, subject); err != nil {
+			log.Printf("Failed to pseudonymize erased user in the audit log: %v", err)
+		} else {
+			erased = append(erased, "audit identifiers")
+		}
+	}
+
+	receipt := &account.DeletionReceipt{
+		ReceiptId:     d.receiptID,
+		Subject:       subject,
+		RequestedAt:   d.requestedAt.Unix(),
+		ErasedAt:      time.Now().Unix(),
+		VideosDeleted: int32(videos),
+		BytesDeleted:  size,
+		Erased:        erased,
+	}
+	if s.receiptKey != nil {
+		receipt.Signature = ed25519.Sign(s.receiptKey, receiptPayload(receipt))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.receipts[d.receiptID] = receipt
+	if err := s.deletionStore.saveReceipt(receipt); err != nil {
+		log.Printf("Failed to store deletion receipt %s: %v", d.receiptID, err)
+		return
+	}
+	s.deletionStore.remove(d.userID)
+}
+
+// ReadReceiptKey returns the Ed25519 private key in the PEM file at path, as
+// written by "openssl genpkey -algorithm ed25519".
+func ReadReceiptKey(path string) (ed25519.PrivateKey, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, fmt.Errorf("%s holds no PEM block", path)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	ed, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%s holds no Ed25519 key", path)
+	}
+	return ed, nil
+}
+
+// SetReceiptKey makes erasures sign their receipts with key. Its public half
+// must be published elsewhere, since a receipt cannot vouch for the key that
+// signed it.
+func (s *accountServer) SetReceiptKey(key ed25519.PrivateKey) {
+	s.receiptKey = key
+}
+
+// receiptPayload is what the signature of a receipt covers, as documented on
+// DeletionReceipt.
+func receiptPayload(r *account.DeletionReceipt) []byte {
+	var b bytes.Buffer
+	for _, field := range []string{
+		"coscup-deletion-receipt/v1",
+		r.ReceiptId,
+		r.Subject,
+		fmt.Sprint(r.RequestedAt),
+		fmt.Sprint(r.ErasedAt),
+		fmt.Sprint(r.VideosDeleted),
+		fmt.Sprint(r.BytesDeleted),
+		strings.Join(r.Erased, ","),
+	} {
+		b.WriteString(field)
+		b.WriteByte('\n')
+	}
+	return b.Bytes()
+}
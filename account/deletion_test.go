@@ -0,0 +1,164 @@
+package account_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"coscup2025/env"
+	pbAccount "coscup2025/proto/account"
+	pbAdmin "coscup2025/proto/admin"
+	pbAuth "coscup2025/proto/auth"
+	pbComment "coscup2025/proto/comment"
+	pbMedia "coscup2025/proto/media"
+	pbModeration "coscup2025/proto/moderation"
+)
+
+func TestCancelDeletion(t *testing.T) {
+	cfg := env.DefaultConfig()
+	cfg.DeletionDir = t.TempDir()
+	conn, signIn := setup(t, cfg)
+	speaker := signIn("speaker")
+	stored := func() []string {
+		matches, err := filepath.Glob(filepath.Join(cfg.DeletionDir, "deletion-*.json"))
+		require.NoError(t, err)
+		return matches
+	}
+
+	client := pbAccount.NewAccountServiceClient(conn)
+	requested, err := client.RequestDeletion(speaker, &pbAccount.RequestDeletionRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(7*24*time.Hour/time.Second), requested.Deletion.ScheduledAt-requested.Deletion.RequestedAt)
+	assert.Len(t, stored(), 1)
+
+	// Asking again keeps the schedule
+	again, err := client.RequestDeletion(speaker, &pbAccount.RequestDeletionRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, requested.Deletion.ReceiptId, again.Deletion.ReceiptId)
+
+	_, err = client.CancelDeletion(speaker, &pbAccount.CancelDeletionRequest{})
+	require.NoError(t, err)
+	_, err = client.GetDeletion(speaker, &pbAccount.GetDeletionRequest{})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Empty(t, stored(), "a cancelled deletion must not run after a restart")
+}
+
+// writeReceiptKey writes a new receipt key to a file, as openssl genpkey
+// does, and returns the file and the public key to verify receipts with.
+func writeReceiptKey(t *testing.T) (string, ed25519.PublicKey) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "receipt.pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600))
+	return path, pub
+}
+
+func upload(t *testing.T, conn *grpc.ClientConn, ctx context.Context, videoID string) {
+	stream, err := pbMedia.NewMediaServiceClient(conn).UploadVideo(ctx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&pbMedia.UploadVideoRequest{VideoId: videoID, Data: []byte("video"), Sequence: 1}))
+	_, err = stream.CloseAndRecv()
+	require.NoError(t, err)
+}
+
+func TestDeletionErasesAccount(t *testing.T) {
+	cfg := env.DefaultConfig()
+	cfg.DeletionGracePeriod = 0
+	cfg.AdminUsers = []string{"mod"}
+	var publicKey ed25519.PublicKey
+	cfg.DeletionReceiptKeyFile, publicKey = writeReceiptKey(t)
+	conn, signIn := setup(t, cfg)
+	speaker, alice, mod := signIn("speaker"), signIn("alice"), signIn("mod")
+	upload(t, conn, speaker, "talk")
+	upload(t, conn, alice, "keynote")
+
+	// What the speaker left on alice's video, and a report about theirs
+	comments := pbComment.NewCommentServiceClient(conn)
+	for _, ctx := range []context.Context{speaker, alice} {
+		_, err := comments.PostComment(ctx, &pbComment.PostCommentRequest{VideoId: "keynote", Body: "great talk"})
+		require.NoError(t, err)
+	}
+	reports := pbModeration.NewModerationServiceClient(conn)
+	_, err := reports.ReportVideo(speaker, &pbModeration.ReportVideoRequest{VideoId: "keynote", Reason: pbModeration.ReportReason_REPORT_REASON_SPAM})
+	require.NoError(t, err)
+	_, err = reports.ReportVideo(alice, &pbModeration.ReportVideoRequest{VideoId: "talk", Reason: pbModeration.ReportReason_REPORT_REASON_SPAM})
+	require.NoError(t, err)
+
+	mediaClient := pbMedia.NewMediaServiceClient(conn)
+	client := pbAccount.NewAccountServiceClient(conn)
+	requested, err := client.RequestDeletion(speaker, &pbAccount.RequestDeletionRequest{})
+	require.NoError(t, err)
+
+	var receipt *pbAccount.DeletionReceipt
+	require.Eventually(t, func() bool {
+		resp, err := client.GetDeletionReceipt(context.Background(), &pbAccount.GetDeletionReceiptRequest{ReceiptId: requested.Deletion.ReceiptId})
+		if err != nil {
+			return false
+		}
+		receipt = resp.Receipt
+		return true
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, int32(1), receipt.VideosDeleted)
+	assert.Equal(t, int64(5), receipt.BytesDeleted)
+	assert.Contains(t, receipt.Erased, "account")
+	assert.Contains(t, receipt.Erased, "comments")
+	assert.Contains(t, receipt.Erased, "moderation reports")
+	assert.Contains(t, receipt.Erased, "notifications")
+	payload := fmt.Sprintf("coscup-deletion-receipt/v1\n%s\n%s\n%d\n%d\n%d\n%d\n%s\n",
+		receipt.ReceiptId, receipt.Subject, receipt.RequestedAt, receipt.ErasedAt,
+		receipt.VideosDeleted, receipt.BytesDeleted, strings.Join(receipt.Erased, ","))
+	assert.True(t, ed25519.Verify(publicKey, []byte(payload), receipt.Signature))
+	assert.Empty(t, receipt.PublicKey, "receipts must not vouch for their own key")
+
+	_, err = client.GetDeletion(speaker, &pbAccount.GetDeletionRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = pbAuth.NewAuthServiceClient(conn).SignIn(context.Background(), &pbAuth.SignInRequest{Username: "speaker", Password: "testpass"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = mediaClient.GetVideoMetadata(alice, &pbMedia.GetVideoMetadataRequest{VideoId: "talk"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	listed, err := comments.ListComments(alice, &pbComment.ListCommentsRequest{VideoId: "keynote"})
+	require.NoError(t, err)
+	require.Len(t, listed.Comments, 1)
+	assert.Equal(t, "alice", listed.Comments[0].AuthorName)
+
+	// The case about the erased video is gone, and the speaker's report
+	// only shows as a step by their pseudonym
+	cases, err := pbAdmin.NewAdminServiceClient(conn).ListCases(mod, &pbAdmin.ListCasesRequest{})
+	require.NoError(t, err)
+	require.Len(t, cases.Cases, 1)
+	assert.Equal(t, "keynote", cases.Cases[0].VideoId)
+	assert.Empty(t, cases.Cases[0].Reports)
+	require.NotEmpty(t, cases.Cases[0].History)
+	assert.Equal(t, receipt.Subject, cases.Cases[0].History[0].ActorId)
+}
+
+func TestUnsignedDeletionReceipts(t *testing.T) {
+	cfg := env.DefaultConfig()
+	cfg.DeletionGracePeriod = 0
+	conn, signIn := setup(t, cfg)
+
+	client := pbAccount.NewAccountServiceClient(conn)
+	requested, err := client.RequestDeletion(signIn("speaker"), &pbAccount.RequestDeletionRequest{})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		resp, err := client.GetDeletionReceipt(context.Background(), &pbAccount.GetDeletionReceiptRequest{ReceiptId: requested.Deletion.ReceiptId})
+		return err == nil && len(resp.Receipt.Signature) == 0 && len(resp.Receipt.PublicKey) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,180 @@
+package account
+
+import (
+	"coscup2025/proto/account"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// deletionStore keeps scheduled deletions and receipts on disk so that they
+// survive a restart. A deletion is stored as deletion-<user ID>.json and a
+// receipt as receipt-<receipt ID>.json. A nil store keeps nothing.
+type deletionStore struct {
+	dir string
+}
+
+// deletionState is the JSON form of a deletion.
+type deletionState struct {
+	UserID      string    `json:"user_id"`
+	ReceiptID   string    `json:"receipt_id"`
+	RequestedAt time.Time `json:"requested_at"`
+	ScheduledAt time.Time `json:"scheduled_at"`
+}
+
+func (st *deletionStore) deletionPath(userID string) string {
+	return filepath.Join(st.dir, "deletion-"+userID+".json")
+}
+
+func (st *deletionStore) receiptPath(receiptID string) string {
+	return filepath.Join(st.dir, "receipt-"+receiptID+".json")
+}
+
+// save stores d, replacing the file atomically.
+func (st *deletionStore) save(d *deletion) error {
+	if st == nil {
+		return nil
+	}
+	b, err := json.Marshal(deletionState{
+		UserID:      d.userID,
+		ReceiptID:   d.receiptID,
+		RequestedAt: d.requestedAt,
+		ScheduledAt: d.scheduledAt,
+	})
+	if err != nil {
+		return err
+	}
+	return writeFile(st.deletionPath(d.userID), b)
+}
+
+// remove deletes the deletion of userID, once cancelled or carried out.
+func (st *deletionStore) remove(userID string) {
+	if st == nil {
+		return
+	}
+	if err := os.Remove(st.deletionPath(userID)); err != nil && !os.IsNotExist(err) {
+		log.Printf("Failed to remove scheduled deletion file: %v", err)
+	}
+}
+
+// saveReceipt stores r.
+func (st *deletionStore) saveReceipt(r *account.DeletionReceipt) error {
+	if st == nil {
+		return nil
+	}
+	b, err := protojson.Marshal(r)
+	if err != nil {
+		return err
+	}
+	return writeFile(st.receiptPath(r.ReceiptId), b)
+}
+
+func writeFile(path string, b []byte) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// load reads the deletions and receipts in the directory. Damaged files are
+// logged and skipped, but kept for an operator to look at.
+func (st *deletionStore) load() ([]*deletion, map[string]*account.DeletionReceipt, error) {
+	entries, err := os.ReadDir(st.dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var deletions []*deletion
+	receipts := make(map[string]*account.DeletionReceipt)
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), ".json")
+		if !ok {
+			continue
+		}
+		if userID, ok := strings.CutPrefix(name, "deletion-"); ok {
+			d, err := st.loadDeletion(userID)
+			if err != nil {
+				log.Printf("Skipping scheduled deletion of %s: %v", userID, err)
+				continue
+			}
+			deletions = append(deletions, d)
+		} else if receiptID, ok := strings.CutPrefix(name, "receipt-"); ok {
+			r, err := st.loadReceipt(receiptID)
+			if err != nil {
+				log.Printf("Skipping deletion receipt %s: %v", receiptID, err)
+				continue
+			}
+			receipts[receiptID] = r
+		}
+	}
+	return deletions, receipts, nil
+}
+
+func (st *deletionStore) loadDeletion(userID string) (*deletion, error) {
+	b, err := os.ReadFile(st.deletionPath(userID))
+	if err != nil {
+		return nil, err
+	}
+	var state deletionState
+	if err := json.Unmarshal(b, &state); err != nil {
+		return nil, fmt.Errorf("invalid state: %v", err)
+	}
+	if state.UserID != userID {
+		return nil, fmt.Errorf("state belongs to user %s", state.UserID)
+	}
+	return &deletion{
+		userID:      state.UserID,
+		receiptID:   state.ReceiptID,
+		requestedAt: state.RequestedAt,
+		scheduledAt: state.ScheduledAt,
+	}, nil
+}
+
+func (st *deletionStore) loadReceipt(receiptID string) (*account.DeletionReceipt, error) {
+	b, err := os.ReadFile(st.receiptPath(receiptID))
+	if err != nil {
+		return nil, err
+	}
+	r := &account.DeletionReceipt{}
+	if err := protojson.Unmarshal(b, r); err != nil {
+		return nil, fmt.Errorf("invalid receipt: %v", err)
+	}
+	if r.ReceiptId != receiptID {
+		return nil, fmt.Errorf("receipt is %s", r.ReceiptId)
+	}
+	return r, nil
+}
+
+// PersistDeletions keeps scheduled deletions and receipts in dir from now
+// on, and restores those a previous run left there. Deletions that fell due
+// while the server was down are carried out right away. It returns how many
+// deletions were restored.
+func (s *accountServer) PersistDeletions(dir string) (int, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return 0, err
+	}
+	st := &deletionStore{dir: dir}
+	deletions, receipts, err := st.load()
+	if err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, r := range receipts {
+		s.receipts[id] = r
+	}
+	for _, d := range deletions {
+		s.startDeletion(d)
+	}
+	s.deletionStore = st
+	return len(deletions), nil
+}
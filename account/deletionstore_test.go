@@ -0,0 +1,88 @@
+package account
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"coscup2025/auth"
+	"coscup2025/env"
+	"coscup2025/jobs"
+	"coscup2025/media"
+)
+
+// fakeUsers records which accounts were deleted.
+type fakeUsers struct {
+	mu      sync.Mutex
+	deleted []string
+}
+
+func (u *fakeUsers) UserData(userID string) (*auth.UserData, bool) {
+	return nil, false
+}
+
+func (u *fakeUsers) DeleteUser(userID string) (string, bool) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.deleted = append(u.deleted, userID)
+	return "speaker", true
+}
+
+func (u *fakeUsers) deletedUsers() []string {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return append([]string(nil), u.deleted...)
+}
+
+type noVideos struct{}
+
+func (noVideos) UploadedVideos(userID string) (map[string]*media.VideoInfo, error) {
+	return nil, nil
+}
+
+func (noVideos) DeleteUserData(userID string) (int, int64) {
+	return 0, 0
+}
+
+func TestDeletionsSurviveRestart(t *testing.T) {
+	dir := t.TempDir()
+	cfg := env.DefaultConfig()
+	cfg.DeletionGracePeriod = 100 * time.Millisecond
+	runner := jobs.NewRunner(1, time.Hour)
+	t.Cleanup(runner.Close)
+
+	// The first run schedules the deletion and stops before carrying it out.
+	users := &fakeUsers{}
+	first := NewAccountServer(cfg, users, noVideos{}, runner)
+	_, err := first.PersistDeletions(dir)
+	require.NoError(t, err)
+	first.mu.Lock()
+	d, err := first.scheduleDeletion("user_1", time.Now())
+	require.NoError(t, err)
+	require.True(t, d.timer.Stop())
+	first.mu.Unlock()
+
+	second := NewAccountServer(cfg, users, noVideos{}, runner)
+	restored, err := second.PersistDeletions(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 1, restored)
+	require.Eventually(t, func() bool {
+		second.mu.Lock()
+		defer second.mu.Unlock()
+		return second.receipts[d.receiptID] != nil
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"user_1"}, users.deletedUsers())
+
+	// The erasure is done once, and its receipt is kept.
+	third := NewAccountServer(cfg, users, noVideos{}, runner)
+	restored, err = third.PersistDeletions(dir)
+	require.NoError(t, err)
+	assert.Zero(t, restored)
+	third.mu.Lock()
+	assert.NotNil(t, third.receipts[d.receiptID])
+	third.mu.Unlock()
+	assert.Equal(t, []string{"user_1"}, users.deletedUsers())
+}
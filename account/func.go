@@ -0,0 +1,209 @@
+package account
+
+import (
+	"context"
+	"coscup2025/auth"
+	"coscup2025/jobs"
+	"coscup2025/proto/account"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	exportJobKind   = "export"
+	exportChunkSize = 1024 * 1024
+)
+
+func (s *accountServer) ExportMyData(ctx context.Context, req *account.ExportMyDataRequest) (*account.ExportMyDataResponse, error) {
+	id, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unknown caller")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// An export still in progress is returned instead of queueing another
+	if j, ok := s.jobs.Get(s.exports[id.UserID]); ok && (j.State == jobs.Pending || j.State == jobs.Running) {
+		return &account.ExportMyDataResponse{Export: exportToProto(j)}, nil
+	}
+
+	j, err := s.jobs.Submit(id.UserID, exportJobKind, s.export(id.UserID, req.IncludeMedia))
+	if errors.Is(err, jobs.ErrQueueFull) {
+		return nil, status.Error(codes.ResourceExhausted, "too many exports in progress, try again later")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to start export: %v", err)
+	}
+	s.exports[id.UserID] = j.ID
+
+	return &account.ExportMyDataResponse{Export: exportToProto(j)}, nil
+}
+
+// export returns the job assembling the archive of userID. Data is collected
+// when the job runs, not when it is submitted.
+func (s *accountServer) export(userID string, includeMedia bool) jobs.Func {
+	return func(ctx context.Context) (*jobs.Result, error) {
+		user, ok := s.users.UserData(userID)
+		if !ok {
+			return nil, fmt.Errorf("user %s not found", userID)
+		}
+		s.mu.Lock()
+		consent := s.consent(userID)
+		s.mu.Unlock()
+		videos, err := s.videos.UploadedVideos(userID)
+		if err != nil {
+			return nil, err
+		}
+		data, err := buildArchive(ctx, user, consent, videos, includeMedia)
+		if err != nil {
+			return nil, err
+		}
+		return &jobs.Result{
+			Name:        fmt.Sprintf("coscup-export-%s-%s.zip", user.Username, time.Now().UTC().Format("20060102")),
+			ContentType: "application/zip",
+			Data:        data,
+		}, nil
+	}
+}
+
+// callerExport returns the export with the given ID if it belongs to the
+// caller of ctx.
+func (s *accountServer) callerExport(ctx context.Context, exportID string) (jobs.Job, error) {
+	id, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		return jobs.Job{}, status.Error(codes.Unauthenticated, "unknown caller")
+	}
+	j, ok := s.jobs.Get(exportID)
+	if !ok || j.Owner != id.UserID || j.Kind != exportJobKind {
+		return jobs.Job{}, status.Error(codes.NotFound, "export not found")
+	}
+	return j, nil
+}
+
+func (s *accountServer) GetExport(ctx context.Context, req *account.GetExportRequest) (*account.GetExportResponse, error) {
+	j, err := s.callerExport(ctx, req.ExportId)
+	if err != nil {
+		return nil, err
+	}
+	return &account.GetExportResponse{Export: exportToProto(j)}, nil
+}
+
+func (s *accountServer) DownloadExport(req *account.DownloadExportRequest, stream account.AccountService_DownloadExportServer) error {
+	j, err := s.callerExport(stream.Context(), req.ExportId)
+	if err != nil {
+		return err
+	}
+	if j.State != jobs.Done {
+		return status.Error(codes.FailedPrecondition, "export is not ready")
+	}
+
+	data := j.Result.Data
+	first := &account.DownloadExportResponse{FileName: j.Result.Name, Size: int64(len(data))}
+	if len(data) == 0 {
+		return stream.Send(first)
+	}
+	for i := 0; i < len(data); i += exportChunkSize {
+		resp := &account.DownloadExportResponse{}
+		if i == 0 {
+			resp = first
+		}
+		resp.Data = data[i:min(i+exportChunkSize, len(data))]
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func exportToProto(j jobs.Job) *account.Export {
+	e := &account.Export{
+		ExportId:  j.ID,
+		CreatedAt: j.Created.Unix(),
+		Error:     j.Err,
+	}
+	switch j.State {
+	case jobs.Pending:
+		e.State = account.ExportState_EXPORT_STATE_PENDING
+	case jobs.Running:
+		e.State = account.ExportState_EXPORT_STATE_RUNNING
+	case jobs.Done:
+		e.State = account.ExportState_EXPORT_STATE_READY
+		e.Size = int64(len(j.Result.Data))
+	case jobs.Failed:
+		e.State = account.ExportState_EXPORT_STATE_FAILED
+	}
+	if !j.Expires.IsZero() {
+		e.ExpiresAt = j.Expires.Unix()
+	}
+	return e
+}
+
+func (s *accountServer) RequestDeletion(ctx context.Context, req *account.RequestDeletionRequest) (*account.RequestDeletionResponse, error) {
+	id, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unknown caller")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d, ok := s.deletions[id.UserID]
+	if !ok {
+		var err error
+		if d, err = s.scheduleDeletion(id.UserID, time.Now()); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to schedule deletion: %v", err)
+		}
+	}
+	return &account.RequestDeletionResponse{Deletion: d.proto()}, nil
+}
+
+func (s *accountServer) GetDeletion(ctx context.Context, req *account.GetDeletionRequest) (*account.GetDeletionResponse, error) {
+	id, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unknown caller")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d, ok := s.deletions[id.UserID]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "no deletion scheduled")
+	}
+	return &account.GetDeletionResponse{Deletion: d.proto()}, nil
+}
+
+func (s *accountServer) CancelDeletion(ctx context.Context, req *account.CancelDeletionRequest) (*account.CancelDeletionResponse, error) {
+	id, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unknown caller")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d, ok := s.deletions[id.UserID]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "no deletion scheduled")
+	}
+	d.timer.Stop()
+	delete(s.deletions, id.UserID)
+	s.deletionStore.remove(id.UserID)
+	return &account.CancelDeletionResponse{}, nil
+}
+
+func (s *accountServer) GetDeletionReceipt(ctx context.Context, req *account.GetDeletionReceiptRequest) (*account.GetDeletionReceiptResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	receipt, ok := s.receipts[req.ReceiptId]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "receipt not found")
+	}
+	return &account.GetDeletionReceiptResponse{Receipt: receipt}, nil
+}
@@ -0,0 +1,88 @@
+package account_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"coscup2025/env"
+	pbAccount "coscup2025/proto/account"
+	pbMedia "coscup2025/proto/media"
+	"coscup2025/servertest"
+)
+
+func setup(t *testing.T, cfg *env.Config) (*grpc.ClientConn, func(username string) context.Context) {
+	srv := servertest.New(t, cfg)
+	signIn := func(username string) context.Context {
+		return servertest.Context(srv.CreateUser(t, username, "testpass"))
+	}
+	return srv.Conn, signIn
+}
+
+func TestExportMyData(t *testing.T) {
+	conn, signIn := setup(t, env.DefaultConfig())
+	speaker, alice := signIn("speaker"), signIn("alice")
+
+	stream, err := pbMedia.NewMediaServiceClient(conn).UploadVideo(speaker)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&pbMedia.UploadVideoRequest{VideoId: "talk", Data: []byte("video"), Sequence: 1}))
+	_, err = stream.CloseAndRecv()
+	require.NoError(t, err)
+
+	client := pbAccount.NewAccountServiceClient(conn)
+	started, err := client.ExportMyData(speaker, &pbAccount.ExportMyDataRequest{IncludeMedia: true})
+	require.NoError(t, err)
+	exportID := started.Export.ExportId
+
+	_, err = client.GetExport(alice, &pbAccount.GetExportRequest{ExportId: exportID})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	require.Eventually(t, func() bool {
+		resp, err := client.GetExport(speaker, &pbAccount.GetExportRequest{ExportId: exportID})
+		return err == nil && resp.Export.State == pbAccount.ExportState_EXPORT_STATE_READY
+	}, 5*time.Second, 10*time.Millisecond)
+
+	download, err := client.DownloadExport(speaker, &pbAccount.DownloadExportRequest{ExportId: exportID})
+	require.NoError(t, err)
+	var archive bytes.Buffer
+	for {
+		chunk, err := download.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		archive.Write(chunk.Data)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(archive.Bytes()), int64(archive.Len()))
+	require.NoError(t, err)
+	files := make(map[string][]byte)
+	for _, f := range zr.File {
+		r, err := f.Open()
+		require.NoError(t, err)
+		files[f.Name], err = io.ReadAll(r)
+		require.NoError(t, err)
+		r.Close()
+	}
+
+	var profile pbAccount.Profile
+	require.NoError(t, protojson.Unmarshal(files["profile.json"], &profile))
+	assert.Equal(t, "speaker", profile.Username)
+	assert.Len(t, profile.Logins, 1)
+
+	var videos pbAccount.Videos
+	require.NoError(t, protojson.Unmarshal(files["videos.json"], &videos))
+	require.Len(t, videos.Videos, 1)
+	assert.Equal(t, "media/talk/talk", videos.Videos[0].MediaPath)
+	assert.Equal(t, []byte("video"), files["media/talk/talk"])
+}
@@ -0,0 +1,79 @@
+package account
+
+import (
+	"coscup2025/audit"
+	"coscup2025/auth"
+	"coscup2025/env"
+	"coscup2025/jobs"
+	"coscup2025/media"
+	"coscup2025/proto/account"
+	"crypto/ed25519"
+	"sync"
+	"time"
+)
+
+// Users gives the account service access to what is stored about users.
+type Users interface {
+	UserData(userID string) (*auth.UserData, bool)
+	// DeleteUser erases an account and its tokens, returning its username.
+	DeleteUser(userID string) (string, bool)
+}
+
+// Videos gives the account service access to the videos users uploaded.
+type Videos interface {
+	UploadedVideos(userID string) (map[string]*media.VideoInfo, error)
+	// DeleteUserData erases a user's videos, returning how many videos and
+	// bytes were removed.
+	DeleteUserData(userID string) (int, int64)
+}
+
+// Eraser is a service storing data about users besides their account and
+// videos, such as comments.
+type Eraser interface {
+	// EraseUser deletes what is stored about userID, or replaces their ID
+	// with pseudonym where a record must stay. It returns what it erased for
+	// the receipt, e.g. "comments".
+	EraseUser(userID, pseudonym string) string
+}
+
+type accountServer struct {
+	account.UnimplementedAccountServiceServer
+	users   Users
+	videos  Videos
+	jobs    *jobs.Runner
+	audit   audit.Pseudonymizer
+	erasers []Eraser
+
+	grace         time.Duration
+	receiptKey    ed25519.PrivateKey // nil leaves receipts unsigned
+	deletionStore *deletionStore
+
+	mu        sync.Mutex
+	exports   map[string]string    // latest export job by user ID
+	deletions map[string]*deletion // scheduled, by user ID
+	receipts  map[string]*account.DeletionReceipt
+	consents  map[string]*account.Consent // by user ID, once changed
+}
+
+func NewAccountServer(cfg *env.Config, users Users, videos Videos, runner *jobs.Runner) *accountServer {
+	return &accountServer{
+		users:     users,
+		videos:    videos,
+		jobs:      runner,
+		grace:     cfg.DeletionGracePeriod,
+		exports:   make(map[string]string),
+		deletions: make(map[string]*deletion),
+		receipts:  make(map[string]*account.DeletionReceipt),
+		consents:  make(map[string]*account.Consent),
+	}
+}
+
+// SetAuditLog makes erasures pseudonymize the user's events in log.
+func (s *accountServer) SetAuditLog(log audit.Pseudonymizer) {
+	s.audit = log
+}
+
+// AddEraser makes erasures erase what e stores about the user too.
+func (s *accountServer) AddEraser(e Eraser) {
+	s.erasers = append(s.erasers, e)
+}
@@ -0,0 +1,154 @@
+// Package acl restricts admin endpoints to configured networks, separately
+// from the token checks that guard user-facing calls.
+package acl
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// List decides which client addresses may reach admin endpoints. Denied
+// networks win over allowed ones; an empty allow list allows every address
+// that is not denied.
+type List struct {
+	allow []netip.Prefix
+	deny  []netip.Prefix
+}
+
+// Parse builds a List from CIDRs such as "10.0.0.0/8". Bare addresses are
+// taken as single hosts.
+func Parse(allow, deny []string) (*List, error) {
+	l := &List{}
+	var err error
+	if l.allow, err = parsePrefixes(allow); err != nil {
+		return nil, err
+	}
+	if l.deny, err = parsePrefixes(deny); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func parsePrefixes(cidrs []string) ([]netip.Prefix, error) {
+	var prefixes []netip.Prefix
+	for _, cidr := range cidrs {
+		cidr = strings.TrimSpace(cidr)
+		if cidr == "" {
+			continue
+		}
+		if !strings.Contains(cidr, "/") {
+			addr, err := netip.ParseAddr(cidr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid address %q: %v", cidr, err)
+			}
+			prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR %q: %v", cidr, err)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes, nil
+}
+
+// Allows reports whether addr may reach admin endpoints.
+func (l *List) Allows(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	for _, p := range l.deny {
+		if p.Contains(addr) {
+			return false
+		}
+	}
+	if len(l.allow) == 0 {
+		return true
+	}
+	for _, p := range l.allow {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsHostPort is Allows for a "host:port" remote address. Unparsable
+// addresses are refused.
+func (l *List) allowsHostPort(hostport string) bool {
+	host, _, err := net.SplitHostPort(hostport)
+	if err != nil {
+		return false
+	}
+	addr, err := netip.ParseAddr(host)
+	return err == nil && l.Allows(addr)
+}
+
+// Handler wraps h so that requests from other networks get 403 Forbidden.
+func (l *List) Handler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !l.allowsHostPort(r.RemoteAddr) {
+			slog.WarnContext(r.Context(), "admin request refused", "remote", r.RemoteAddr, "path", r.URL.Path)
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// Methods restricts the gRPC methods under the given prefixes, e.g.
+// "/admin.AdminService/", to the networks of a List.
+type Methods struct {
+	List     *List
+	Prefixes []string
+}
+
+func (m Methods) restricted(fullMethod string) bool {
+	for _, prefix := range m.Prefixes {
+		if strings.HasPrefix(fullMethod, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m Methods) check(ctx context.Context, fullMethod string) error {
+	if !m.restricted(fullMethod) {
+		return nil
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil || !m.List.allowsHostPort(p.Addr.String()) {
+		remote := "unknown"
+		if ok && p.Addr != nil {
+			remote = p.Addr.String()
+		}
+		slog.WarnContext(ctx, "admin call refused", "remote", remote, "method", fullMethod)
+		return status.Error(codes.PermissionDenied, "admin calls are not allowed from this network")
+	}
+	return nil
+}
+
+// UnaryServerInterceptor refuses restricted unary calls from other networks.
+func (m Methods) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := m.check(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamServerInterceptor refuses restricted streaming calls from other networks.
+func (m Methods) StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := m.check(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
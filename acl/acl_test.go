@@ -0,0 +1,73 @@
+package acl
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestAllows(t *testing.T) {
+	l, err := Parse([]string{"10.0.0.0/8", "192.168.1.5"}, []string{"10.0.13.0/24"})
+	require.NoError(t, err)
+
+	for addr, want := range map[string]bool{
+		"10.1.2.3":         true,
+		"10.0.13.7":        false, // denied wins
+		"192.168.1.5":      true,
+		"192.168.1.6":      false,
+		"::ffff:10.1.2.3":  true,
+		"2001:db8::1":      false,
+		"127.0.0.1":        false,
+		"::ffff:10.0.13.1": false,
+	} {
+		assert.Equal(t, want, l.Allows(netip.MustParseAddr(addr)), addr)
+	}
+
+	_, err = Parse([]string{"10.0.0.0/33"}, nil)
+	assert.Error(t, err)
+}
+
+func TestHandler(t *testing.T) {
+	l, err := Parse([]string{"127.0.0.1"}, nil)
+	require.NoError(t, err)
+	h := l.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	for remote, want := range map[string]int{
+		"127.0.0.1:5000": http.StatusOK,
+		"10.0.0.1:5000":  http.StatusForbidden,
+		"garbage":        http.StatusForbidden,
+	} {
+		r := httptest.NewRequest("GET", "/metrics", nil)
+		r.RemoteAddr = remote
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		assert.Equal(t, want, w.Code, remote)
+	}
+}
+
+func TestMethods(t *testing.T) {
+	l, err := Parse([]string{"127.0.0.1"}, nil)
+	require.NoError(t, err)
+	m := Methods{List: l, Prefixes: []string{"/admin."}}
+	handler := func(context.Context, interface{}) (interface{}, error) { return "ok", nil }
+
+	call := func(remote, method string) error {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(remote), Port: 5000}})
+		_, err := m.UnaryServerInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return err
+	}
+	assert.NoError(t, call("127.0.0.1", "/admin.AdminService/Takedown"))
+	assert.Equal(t, codes.PermissionDenied, status.Code(call("10.0.0.1", "/admin.AdminService/Takedown")))
+	// User-facing calls are not restricted.
+	assert.NoError(t, call("10.0.0.1", "/media.MediaService/ListVideos"))
+}
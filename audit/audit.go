@@ -0,0 +1,51 @@
+// Package audit records security-relevant calls, such as sign-ins, uploads
+// and deletions, to append-only sinks.
+package audit
+
+import (
+	"errors"
+	"time"
+)
+
+// Event is one audited call.
+type Event struct {
+	Time     time.Time `json:"time"`
+	Method   string    `json:"method"`
+	UserID   string    `json:"user_id,omitempty"`
+	Username string    `json:"username,omitempty"`
+	VideoID  string    `json:"video_id,omitempty"`
+	Peer     string    `json:"peer,omitempty"`
+	MeshPeer string    `json:"mesh_peer,omitempty"` // the calling workload, see package mesh
+	Code     string    `json:"code"`
+	TraceID  string    `json:"trace_id,omitempty"`
+}
+
+// Sink stores audit events. Write must be safe for concurrent use.
+type Sink interface {
+	Write(e *Event) error
+	Close() error
+}
+
+// multiSink writes every event to all of its sinks.
+type multiSink []Sink
+
+// Multi returns a sink writing to each of sinks, e.g. a file and a database.
+func Multi(sinks ...Sink) Sink {
+	return multiSink(sinks)
+}
+
+func (m multiSink) Write(e *Event) error {
+	var errs []error
+	for _, s := range m {
+		errs = append(errs, s.Write(e))
+	}
+	return errors.Join(errs...)
+}
+
+func (m multiSink) Close() error {
+	var errs []error
+	for _, s := range m {
+		errs = append(errs, s.Close())
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,259 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// line is the on-disk form of an event. MAC is the HMAC-SHA256 of the previous
+// line's MAC followed by Event, so removing, reordering or editing a line
+// breaks every MAC after it. The first line of a file continuing the chain of
+// a rotated one repeats that file's last MAC in Prev.
+//
+// A seal line has no event and chains sealEvent instead. It ends every file
+// that was closed or rotated, so cutting lines off the end of a file removes
+// its seal, and only the key holder can write a new one.
+type line struct {
+	Prev  string          `json:"prev,omitempty"`
+	MAC   string          `json:"mac"`
+	Event json.RawMessage `json:"event,omitempty"`
+	Seal  bool            `json:"seal,omitempty"`
+}
+
+// sealEvent is what the MAC of a seal line covers. It is not a JSON object,
+// so no event shares it.
+var sealEvent = []byte("seal")
+
+// ErrUnsealed is returned by Verify for a log that does not end with a seal:
+// it is still being written, the server stopped without closing it, or lines
+// were cut off its end.
+var ErrUnsealed = errors.New("not sealed, lines may have been cut off its end")
+
+// FileSink appends events as JSON lines to a file, rotating it by size and
+// age. Rotated files are sealed, renamed to <path>.<UTC timestamp> and the
+// chain of MACs continues into the new file.
+type FileSink struct {
+	path    string
+	key     []byte
+	maxSize int64
+	maxAge  time.Duration
+
+	mu      sync.Mutex
+	file    *os.File
+	size    int64
+	opened  time.Time
+	lastMAC []byte
+}
+
+// NewFileSink opens or creates the audit log at path. A zero maxSize or
+// maxAge disables that rotation trigger.
+func NewFileSink(path string, key []byte, maxSize int64, maxAge time.Duration) (*FileSink, error) {
+	if len(key) == 0 {
+		return nil, errors.New("audit log needs an HMAC key")
+	}
+	s := &FileSink{path: path, key: key, maxSize: maxSize, maxAge: maxAge}
+	if err := s.resume(); err != nil {
+		return nil, err
+	}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// resume continues the chain from the last line already on disk. That is in
+// the newest rotated file when the server stopped right after rotating, before
+// writing to the new one.
+func (s *FileSink) resume() error {
+	rotated, err := filepath.Glob(s.path + ".*")
+	if err != nil {
+		return err
+	}
+	sort.Strings(rotated)
+	files := append(rotated, s.path)
+	for i := len(files) - 1; i >= 0; i-- {
+		f, err := os.Open(files[i])
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return fmt.Errorf("existing audit log %s: %v", files[i], err)
+		}
+		// A server that stopped without closing the log left it unsealed.
+		last, err := Verify(f, s.key, nil)
+		f.Close()
+		if err != nil && !errors.Is(err, ErrUnsealed) {
+			return fmt.Errorf("existing audit log %s: %v", files[i], err)
+		}
+		if last != nil {
+			s.lastMAC = last
+			return nil
+		}
+	}
+	return nil
+}
+
+func (s *FileSink) open() error {
+	f, err := os.OpenFile(s.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %v", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("failed to stat audit log: %v", err)
+	}
+	s.file = f
+	s.size = info.Size()
+	s.opened = time.Now()
+	return nil
+}
+
+// rotate seals the current file, renames it aside and starts a new one. The
+// caller must hold s.mu.
+func (s *FileSink) rotate() error {
+	if err := s.append(sealEvent, true); err != nil {
+		return err
+	}
+	if err := s.file.Close(); err != nil {
+		return fmt.Errorf("failed to close audit log: %v", err)
+	}
+	rotated := fmt.Sprintf("%s.%s", s.path, time.Now().UTC().Format("20060102T150405.000000000Z"))
+	if err := os.Rename(s.path, rotated); err != nil {
+		return fmt.Errorf("failed to rotate audit log: %v", err)
+	}
+	return s.open()
+}
+
+func (s *FileSink) Write(e *Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit event: %v", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.size > 0 && ((s.maxSize > 0 && s.size+int64(len(data)) > s.maxSize) ||
+		(s.maxAge > 0 && time.Since(s.opened) > s.maxAge)) {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+	return s.append(data, false)
+}
+
+// append writes the line of an event, or a seal line. The caller must hold
+// s.mu.
+func (s *FileSink) append(data []byte, seal bool) error {
+	mac := chainMAC(s.key, s.lastMAC, data)
+	l := line{MAC: hex.EncodeToString(mac), Event: data}
+	if seal {
+		l = line{MAC: hex.EncodeToString(mac), Seal: true}
+	}
+	if s.size == 0 && s.lastMAC != nil {
+		l.Prev = hex.EncodeToString(s.lastMAC)
+	}
+	out, err := json.Marshal(l)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit event: %v", err)
+	}
+	out = append(out, '\n')
+
+	n, err := s.file.Write(out)
+	s.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("failed to write audit event: %v", err)
+	}
+	s.lastMAC = mac
+	return nil
+}
+
+// Close seals the log and closes it.
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return errors.Join(s.append(sealEvent, true), s.file.Close())
+}
+
+func chainMAC(key, prev, data []byte) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write(prev)
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+// Verify checks the MAC chain of an audit log. prev is the last MAC of the
+// preceding file; when nil, a file continuing a rotated one is checked from
+// the MAC it records. It returns the last MAC, to verify the next file with,
+// along with ErrUnsealed if the chain holds but the log does not end with a
+// seal.
+func Verify(r io.Reader, key, prev []byte) ([]byte, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	sealed := false
+	for n := 1; scanner.Scan(); n++ {
+		var l line
+		if err := json.Unmarshal(scanner.Bytes(), &l); err != nil {
+			return nil, fmt.Errorf("line %d: %v", n, err)
+		}
+		if n == 1 && l.Prev != "" {
+			recorded, err := hex.DecodeString(l.Prev)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid prev", n)
+			}
+			if prev != nil && !hmac.Equal(prev, recorded) {
+				return nil, fmt.Errorf("line %d: does not continue the previous file", n)
+			}
+			prev = recorded
+		}
+		got, err := hex.DecodeString(l.MAC)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid mac", n)
+		}
+		event, err := chainedEvent(l)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", n, err)
+		}
+		want := chainMAC(key, prev, event)
+		if !hmac.Equal(got, want) {
+			return nil, fmt.Errorf("line %d: mac mismatch, the log was modified", n)
+		}
+		prev = want
+		sealed = l.Seal
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if !sealed {
+		return prev, ErrUnsealed
+	}
+	return prev, nil
+}
+
+// chainedEvent returns what the MAC of a line covers.
+func chainedEvent(l line) ([]byte, error) {
+	if l.Seal {
+		if len(l.Event) > 0 {
+			return nil, errors.New("seal with an event")
+		}
+		return sealEvent, nil
+	}
+	// Marshaling compacts the raw event, so compact it here as well.
+	var event bytes.Buffer
+	if err := json.Compact(&event, l.Event); err != nil {
+		return nil, err
+	}
+	return event.Bytes(), nil
+}
@@ -0,0 +1,169 @@
+package audit
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeEvents(t *testing.T, s *FileSink, n int) {
+	for i := 0; i < n; i++ {
+		require.NoError(t, s.Write(&Event{Time: time.Now(), Method: "/media.MediaService/DeleteVideo", VideoID: "v", Code: "OK"}))
+	}
+}
+
+// logFiles returns the rotated files oldest first, then the current one.
+func logFiles(t *testing.T, path string) []string {
+	rotated, err := filepath.Glob(path + ".*")
+	require.NoError(t, err)
+	sort.Strings(rotated)
+	return append(rotated, path)
+}
+
+func verifyAll(key []byte, files []string) error {
+	var prev []byte
+	for _, path := range files {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if prev, err = Verify(bytes.NewReader(data), key, prev); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestFileSinkRotatesAndChains(t *testing.T) {
+	key := []byte("key")
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	s, err := NewFileSink(path, key, 500, 0)
+	require.NoError(t, err)
+	writeEvents(t, s, 10)
+	require.NoError(t, s.Close())
+
+	// Reopening continues the chain of the current file.
+	s, err = NewFileSink(path, key, 500, 0)
+	require.NoError(t, err)
+	writeEvents(t, s, 3)
+	require.NoError(t, s.Close())
+
+	files := logFiles(t, path)
+	assert.Greater(t, len(files), 2)
+	assert.NoError(t, verifyAll(key, files))
+	assert.Error(t, verifyAll([]byte("other"), files))
+
+	// Dropping a rotated file breaks the chain.
+	assert.Error(t, verifyAll(key, append(files[:1:1], files[2:]...)))
+}
+
+func TestFileSinkResumesAfterRotating(t *testing.T) {
+	key := []byte("key")
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	s, err := NewFileSink(path, key, 0, 0)
+	require.NoError(t, err)
+	writeEvents(t, s, 3)
+	// The server stops right after rotating, leaving the new file empty.
+	s.mu.Lock()
+	require.NoError(t, s.rotate())
+	s.mu.Unlock()
+	require.NoError(t, s.file.Close())
+
+	s, err = NewFileSink(path, key, 0, 0)
+	require.NoError(t, err)
+	writeEvents(t, s, 3)
+	require.NoError(t, s.Close())
+
+	files := logFiles(t, path)
+	require.Len(t, files, 2)
+	assert.NoError(t, verifyAll(key, files))
+}
+
+func TestVerifyDetectsTruncation(t *testing.T) {
+	key := []byte("key")
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	s, err := NewFileSink(path, key, 0, 0)
+	require.NoError(t, err)
+	writeEvents(t, s, 3)
+	require.NoError(t, s.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	_, err = Verify(bytes.NewReader(data), key, nil)
+	require.NoError(t, err)
+
+	// Cutting off the seal, or lines and the seal, leaves the log unsealed.
+	lines := bytes.SplitAfter(data, []byte("\n"))
+	for _, n := range []int{3, 2} {
+		_, err = Verify(bytes.NewReader(bytes.Join(lines[:n], nil)), key, nil)
+		assert.ErrorIs(t, err, ErrUnsealed)
+	}
+
+	// A server that stopped without closing the log is resumed, and the
+	// log is sealed again when closed.
+	require.NoError(t, os.WriteFile(path, bytes.Join(lines[:3], nil), 0o600))
+	s, err = NewFileSink(path, key, 0, 0)
+	require.NoError(t, err)
+	writeEvents(t, s, 1)
+	require.NoError(t, s.Close())
+	assert.NoError(t, verifyAll(key, []string{path}))
+}
+
+func TestVerifyDetectsTampering(t *testing.T) {
+	key := []byte("key")
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	s, err := NewFileSink(path, key, 0, 0)
+	require.NoError(t, err)
+	writeEvents(t, s, 3)
+	require.NoError(t, s.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	tampered := bytes.Replace(data, []byte(`"code":"OK"`), []byte(`"code":"PermissionDenied"`), 1)
+	_, err = Verify(bytes.NewReader(tampered), key, nil)
+	assert.ErrorContains(t, err, "line 1")
+
+	lines := bytes.SplitAfter(data, []byte("\n"))
+	_, err = Verify(bytes.NewReader(append(lines[0], lines[2]...)), key, nil)
+	assert.ErrorContains(t, err, "line 2")
+}
+
+func TestFileSinkPseudonymize(t *testing.T) {
+	key := []byte("key")
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	s, err := NewFileSink(path, key, 500, 0)
+	require.NoError(t, err)
+	writeEvents(t, s, 3)
+	require.NoError(t, s.Write(&Event{Time: time.Now(), Method: "/auth.AuthService/SignIn", Username: "alice", Code: "Unauthenticated"}))
+	require.NoError(t, s.Write(&Event{Time: time.Now(), Method: "/media.MediaService/UploadVideo", UserID: "user_1", Username: "alice", Code: "OK"}))
+	writeEvents(t, s, 3)
+
+	require.NoError(t, s.Pseudonymize("user_1", "alice", "erased_1"))
+	// Appending continues the rewritten chain
+	writeEvents(t, s, 1)
+	require.NoError(t, s.Close())
+
+	files := logFiles(t, path)
+	assert.Greater(t, len(files), 1)
+	assert.NoError(t, verifyAll(key, files))
+	var all []byte
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		require.NoError(t, err)
+		all = append(all, data...)
+	}
+	assert.NotContains(t, string(all), "alice")
+	assert.NotContains(t, string(all), "user_1")
+	assert.Equal(t, 2, bytes.Count(all, []byte(`"user_id":"erased_1"`)))
+}
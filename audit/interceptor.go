@@ -0,0 +1,119 @@
+package audit
+
+import (
+	"context"
+	"coscup2025/auth"
+	"coscup2025/mesh"
+	"log"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// audited lists the recorded calls; listings and polling are left out.
+var audited = map[string]bool{
+	"/auth.AuthService/SignUp":                true,
+	"/auth.AuthService/SignIn":                true,
+	"/auth.AuthService/RefreshToken":          true,
+	"/auth.AuthService/AcceptInvitation":      true,
+	"/admin.AdminService/ImportUsers":         true,
+	"/admin.AdminService/RevokeUserSessions":  true,
+	"/admin.AdminService/RevokeAllSessions":   true,
+	"/media.MediaService/UploadVideo":         true,
+	"/media.MediaService/CreateUploadSession": true,
+	"/media.MediaService/DownloadVideo":       true,
+	"/media.MediaService/DeleteVideo":         true,
+	"/account.AccountService/ExportMyData":    true,
+	"/account.AccountService/RequestDeletion": true,
+	"/account.AccountService/CancelDeletion":  true,
+}
+
+// Auditor writes an event for every audited call to its sink. Its
+// interceptors must run after the auth interceptors to see the caller.
+type Auditor struct {
+	sink Sink
+}
+
+func NewAuditor(sink Sink) *Auditor {
+	return &Auditor{sink: sink}
+}
+
+func (a *Auditor) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	resp, err := handler(ctx, req)
+	if audited[info.FullMethod] {
+		a.record(ctx, info.FullMethod, req, err)
+	}
+	return resp, err
+}
+
+func (a *Auditor) StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if !audited[info.FullMethod] {
+		return handler(srv, ss)
+	}
+	fs := &firstMessageStream{ServerStream: ss}
+	err := handler(srv, fs)
+	a.record(ss.Context(), info.FullMethod, fs.first, err)
+	return err
+}
+
+func (a *Auditor) record(ctx context.Context, method string, req interface{}, err error) {
+	e := &Event{
+		Time:   time.Now().UTC(),
+		Method: method,
+		Code:   status.Code(err).String(),
+	}
+	if id, ok := auth.IdentityFromContext(ctx); ok {
+		e.UserID = id.UserID
+		e.Username = id.Username
+	} else if r, ok := req.(interface{ GetUsername() string }); ok {
+		// Sign-ins have no identity yet; record who they claimed to be.
+		e.Username = r.GetUsername()
+	}
+	if r, ok := req.(interface{ GetVideoId() string }); ok {
+		e.VideoID = r.GetVideoId()
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		e.Peer = p.Addr.String()
+	}
+	if p, ok := mesh.PeerFromContext(ctx); ok {
+		e.MeshPeer = p.ID()
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		e.TraceID = sc.TraceID().String()
+	}
+
+	if err := a.sink.Write(e); err != nil {
+		log.Printf("failed to write audit event: %v", err)
+	}
+}
+
+// SecretRotationMethod is the method of the events recording a rotation of
+// the JWT secret, which is not a call.
+const SecretRotationMethod = "jwt-secret-rotation"
+
+// SecretRotated records that the JWT secret was rotated.
+func (a *Auditor) SecretRotated() {
+	e := &Event{Time: time.Now().UTC(), Method: SecretRotationMethod, Code: codes.OK.String()}
+	if err := a.sink.Write(e); err != nil {
+		log.Printf("failed to write audit event: %v", err)
+	}
+}
+
+// firstMessageStream keeps the first message received, which carries the
+// video ID of uploads and downloads.
+type firstMessageStream struct {
+	grpc.ServerStream
+	first interface{}
+}
+
+func (s *firstMessageStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil && s.first == nil {
+		s.first = m
+	}
+	return err
+}
@@ -0,0 +1,141 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// Pseudonymizer replaces a user's identifiers in stored events, for erasing
+// the user without losing what happened.
+type Pseudonymizer interface {
+	Pseudonymize(userID, username, pseudonym string) error
+}
+
+func (m multiSink) Pseudonymize(userID, username, pseudonym string) error {
+	var errs []error
+	for _, s := range m {
+		if p, ok := s.(Pseudonymizer); ok {
+			errs = append(errs, p.Pseudonymize(userID, username, pseudonym))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Pseudonymize replaces userID and username with pseudonym in the current
+// and rotated files. Lines after the first changed one get new MACs, so the
+// log still verifies, but only with the key: the chain can no longer prove
+// those lines were not edited by someone holding it.
+func (s *FileSink) Pseudonymize(userID, username, pseudonym string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rotated, err := filepath.Glob(s.path + ".*")
+	if err != nil {
+		return err
+	}
+	sort.Strings(rotated)
+
+	if err := s.file.Close(); err != nil {
+		return fmt.Errorf("failed to close audit log: %v", err)
+	}
+	var prev []byte
+	changed := false
+	for _, path := range append(rotated, s.path) {
+		if prev, changed, err = s.rechain(path, prev, changed, userID, username, pseudonym); err != nil {
+			break
+		}
+	}
+	// Keep appending to the current file even if rewriting failed
+	if openErr := s.open(); openErr != nil {
+		return errors.Join(err, openErr)
+	}
+	if err != nil {
+		return err
+	}
+	s.lastMAC = prev
+	return nil
+}
+
+// rechain rewrites one file with the identifiers replaced, continuing the
+// chain from prev. Once changed, every later line is rewritten as well.
+func (s *FileSink) rechain(path string, prev []byte, changed bool, userID, username, pseudonym string) ([]byte, bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, changed, err
+	}
+
+	var out bytes.Buffer
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		var l line
+		if err := json.Unmarshal(scanner.Bytes(), &l); err != nil {
+			return nil, changed, fmt.Errorf("%s line %d: %v", path, n, err)
+		}
+		if n == 1 && l.Prev != "" && prev == nil {
+			if prev, err = hex.DecodeString(l.Prev); err != nil {
+				return nil, changed, fmt.Errorf("%s line %d: invalid prev", path, n)
+			}
+		}
+
+		event, err := chainedEvent(l)
+		if err != nil {
+			return nil, changed, fmt.Errorf("%s line %d: %v", path, n, err)
+		}
+		if !l.Seal {
+			var e Event
+			if err := json.Unmarshal(event, &e); err != nil {
+				return nil, changed, fmt.Errorf("%s line %d: %v", path, n, err)
+			}
+			if (userID != "" && e.UserID == userID) || (username != "" && e.Username == username) {
+				e.UserID = pseudonym
+				e.Username = ""
+				if event, err = json.Marshal(e); err != nil {
+					return nil, changed, err
+				}
+				changed = true
+			}
+			l.Event = json.RawMessage(event)
+		}
+
+		if n == 1 && l.Prev != "" {
+			l.Prev = hex.EncodeToString(prev)
+		}
+		mac := chainMAC(s.key, prev, event)
+		l.MAC = hex.EncodeToString(mac)
+		encoded, err := json.Marshal(l)
+		if err != nil {
+			return nil, changed, err
+		}
+		out.Write(encoded)
+		out.WriteByte('\n')
+		prev = mac
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, changed, err
+	}
+	if !changed {
+		return prev, changed, nil
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return nil, changed, err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(out.Bytes()); err != nil {
+		tmp.Close()
+		return nil, changed, err
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, changed, err
+	}
+	return prev, changed, os.Rename(tmp.Name(), path)
+}
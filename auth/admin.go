@@ -0,0 +1,40 @@
+package auth
+
+// bindAdminLocked makes u an admin if its username is one of the configured
+// admin usernames and no account was created with it before. Admin rights
+// stay with that account's ID, so that a later account of the same name,
+// e.g. after the first one was erased, does not get them. The caller must
+// hold s.mu for writing.
+func (s *authServer) bindAdminLocked(u user) {
+	if id, ok := s.admins[u.Username]; ok && id == "" {
+		s.admins[u.Username] = u.ID
+	}
+}
+
+// isAdmin reports whether userID is the account of an admin user.
+func (s *authServer) isAdmin(userID string) bool {
+	if userID == "" {
+		return false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, id := range s.admins {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// AdminIDs returns the IDs of the admin users who have an account.
+func (s *authServer) AdminIDs() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var ids []string
+	for _, id := range s.admins {
+		if id != "" && !s.erased[id] {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"coscup2025/env"
+	pbAuth "coscup2025/proto/auth"
+)
+
+func TestSignUpKeepsExistingAccounts(t *testing.T) {
+	cfg := env.DefaultConfig()
+	cfg.AdminUsers = []string{"admin"}
+	authSrv, client := serve(t, cfg)
+	ctx := context.Background()
+
+	// isAdmin tells whether the token of username has admin rights.
+	isAdmin := func(username, password string) bool {
+		resp, err := client.SignIn(ctx, &pbAuth.SignInRequest{Username: username, Password: password})
+		require.NoError(t, err)
+		incoming := metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+resp.Token))
+		authenticated, err := authSrv.authenticate(incoming, "UnaryInterceptor", "/admin.AdminService/ListCases")
+		require.NoError(t, err)
+		id, ok := IdentityFromContext(authenticated)
+		require.True(t, ok)
+		return id.Admin
+	}
+
+	// Step 1: signing up for a taken username fails and leaves the account
+	// as it was
+	_, err := client.SignUp(ctx, &pbAuth.SignUpRequest{Username: "alice", Password: "alice-pass"})
+	require.NoError(t, err)
+	_, err = client.SignUp(ctx, &pbAuth.SignUpRequest{Username: "alice", Password: "attacker-pass"})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	_, err = client.SignIn(ctx, &pbAuth.SignInRequest{Username: "alice", Password: "attacker-pass"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.False(t, isAdmin("alice", "alice-pass"))
+
+	// Step 2: so does signing up as an admin
+	admin, err := client.SignUp(ctx, &pbAuth.SignUpRequest{Username: "admin", Password: "admin-pass"})
+	require.NoError(t, err)
+	_, err = client.SignUp(ctx, &pbAuth.SignUpRequest{Username: "admin", Password: "attacker-pass"})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	assert.True(t, isAdmin("admin", "admin-pass"))
+
+	// Step 3: admin rights stay with the account, not its username
+	_, ok := authSrv.DeleteUser(admin.UserId)
+	require.True(t, ok)
+	_, err = client.SignUp(ctx, &pbAuth.SignUpRequest{Username: "admin", Password: "attacker-pass"})
+	require.NoError(t, err)
+	assert.False(t, isAdmin("admin", "attacker-pass"))
+	assert.Empty(t, authSrv.AdminIDs())
+}
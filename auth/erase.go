@@ -0,0 +1,38 @@
+package auth
+
+// DeleteUser erases the account with the given ID together with its refresh
+// tokens and invitations. Access tokens issued to it are refused from now on. It returns the
+// username the account had.
+func (s *authServer) DeleteUser(userID string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for username, u := range s.users {
+		if u.ID != userID {
+			continue
+		}
+		delete(s.users, username)
+		for token, refresh := range s.refreshTokens {
+			if refresh.Username == username {
+				delete(s.refreshTokens, token)
+			}
+		}
+		for token, inv := range s.invitations {
+			if inv.Username == username {
+				delete(s.invitations, token)
+			}
+		}
+		s.erased[userID] = true
+		return username, true
+	}
+	return "", false
+}
+
+func (s *authServer) isErased(userID string) bool {
+	if userID == "" {
+		return false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.erased[userID]
+}
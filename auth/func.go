@@ -3,11 +3,15 @@ package auth
 import (
 	"context"
 	"coscup2025/proto/auth"
+	"crypto/rand"
+	"encoding/base64"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/crypto/bcrypt"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -16,103 +20,237 @@ import (
 )
 
 func (s *authServer) SignUp(ctx context.Context, req *auth.SignUpRequest) (*auth.SignUpResponse, error) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+	_, span := s.startSpan(ctx, "SignUp")
+	defer span.End()
 
-	if req.Username == "" || req.Password == "" {
-		return nil, status.Error(codes.InvalidArgument, "username and password are required")
+	s.mu.RLock()
+	err := s.checkSignUpLocked(req)
+	s.mu.RUnlock()
+	if err != nil {
+		return nil, endSpan(span, signUpOutcome(err), err)
 	}
 
+	// Hashing is slow on purpose, so it must not hold up everyone else
 	bcryptPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
 	if err != nil {
-		return nil, status.Error(codes.Internal, "failed to hash password")
+		return nil, endSpan(span, outcomeError, status.Error(codes.Internal, "failed to hash password"))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Someone may have taken the username while the password was hashed
+	if err := s.checkSignUpLocked(req); err != nil {
+		return nil, endSpan(span, signUpOutcome(err), err)
 	}
 
-	userID := fmt.Sprintf("user_%d", len(s.users)+1)
-	s.users[req.Username] = user{
-		ID:       userID,
-		Username: req.Username,
-		Password: string(bcryptPassword),
+	// IDs are never reused, not even those of erased users
+	s.nextUserID++
+	userID := fmt.Sprintf("user_%d", s.nextUserID)
+	u := user{
+		ID:        userID,
+		Username:  req.Username,
+		Password:  string(bcryptPassword),
+		CreatedAt: time.Now(),
 	}
+	if s.termsVersion != "" {
+		u.TermsVersion = s.termsVersion
+		u.TermsAcceptedAt = u.CreatedAt
+	}
+	s.users[req.Username] = u
+	s.bindAdminLocked(u)
 
+	span.SetAttributes(attribute.String("enduser.id", userID))
+	endSpan(span, outcomeOK, nil)
 	return &auth.SignUpResponse{UserId: userID}, nil
 }
 
+// checkSignUpLocked checks that req may sign up. The caller holds s.mu.
+func (s *authServer) checkSignUpLocked(req *auth.SignUpRequest) error {
+	if s.termsVersion != "" && req.AcceptedTermsVersion != s.termsVersion {
+		return s.termsError()
+	}
+	// Not even an imported account waiting for its invitation to be
+	// accepted may be signed up for again, or anyone could take it over
+	if _, exists := s.users[req.Username]; exists {
+		return status.Error(codes.AlreadyExists, "username is taken")
+	}
+	return nil
+}
+
+// signUpOutcome is the span outcome of an error from checkSignUpLocked.
+func signUpOutcome(err error) string {
+	if status.Code(err) == codes.AlreadyExists {
+		return outcomeDenied
+	}
+	return outcomeTermsRequired
+}
+
 func (s *authServer) SignIn(ctx context.Context, req *auth.SignInRequest) (*auth.SignInResponse, error) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+	_, span := s.startSpan(ctx, "SignIn")
+	defer span.End()
 
+	s.mu.RLock()
 	user, exists := s.users[req.Username]
+	s.mu.RUnlock()
+
 	if !exists || bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)) != nil {
-		return nil, status.Error(codes.Unauthenticated, "invalid credentials")
+		return nil, endSpan(span, outcomeDenied, status.Error(codes.Unauthenticated, "invalid credentials"))
 	}
+	span.SetAttributes(attribute.String("enduser.id", user.ID))
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"user_id": user.ID,
-		"sub":     user.Username,
-		"iat":     time.Now().Unix(),
-		"exp":     time.Now().Add(time.Hour * 24).Unix(),
-	})
-	tokenString, err := token.SignedString(s.secret)
+	tokenString, expiresAt, err := s.signToken(user)
 	if err != nil {
-		return nil, status.Error(codes.Internal, "failed to generate token")
+		return nil, endSpan(span, outcomeError, status.Error(codes.Internal, "failed to generate token"))
 	}
 
 	if err := grpc.SetHeader(ctx, metadata.Pairs("x-auth-token", tokenString)); err != nil {
-		return nil, err
+		return nil, endSpan(span, outcomeError, err)
+	}
+
+	s.mu.Lock()
+	refresh := s.issueRefreshToken(user.Username)
+	s.recordLogin(user.Username, newLogin(ctx, time.Now()))
+	s.mu.Unlock()
+
+	endSpan(span, outcomeOK, nil)
+	return &auth.SignInResponse{
+		Token:        tokenString,
+		RefreshToken: refresh,
+		ExpiresAt:    expiresAt.Unix(),
+	}, nil
+}
+
+func (s *authServer) RefreshToken(ctx context.Context, req *auth.RefreshTokenRequest) (*auth.RefreshTokenResponse, error) {
+	_, span := s.startSpan(ctx, "RefreshToken")
+	defer span.End()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Refresh tokens are single use: the presented one is always consumed.
+	refresh, exists := s.refreshTokens[req.RefreshToken]
+	delete(s.refreshTokens, req.RefreshToken)
+	if !exists || time.Now().After(refresh.ExpiresAt) {
+		return nil, endSpan(span, outcomeInvalidToken, status.Error(codes.Unauthenticated, "invalid refresh token"))
+	}
+
+	user, exists := s.users[refresh.Username]
+	if !exists {
+		return nil, endSpan(span, outcomeDenied, status.Error(codes.Unauthenticated, "user not found"))
+	}
+	span.SetAttributes(attribute.String("enduser.id", user.ID))
+
+	tokenString, expiresAt, err := s.signToken(user)
+	if err != nil {
+		return nil, endSpan(span, outcomeError, status.Error(codes.Internal, "failed to generate token"))
 	}
 
-	return &auth.SignInResponse{Token: tokenString}, nil
+	endSpan(span, outcomeOK, nil)
+	return &auth.RefreshTokenResponse{
+		Token:        tokenString,
+		RefreshToken: s.issueRefreshToken(user.Username),
+		ExpiresAt:    expiresAt.Unix(),
+	}, nil
 }
 
 func (s *authServer) GetUserProfile(ctx context.Context, req *auth.GetUserProfileRequest) (*auth.GetUserProfileResponse, error) {
+	_, span := s.startSpan(ctx, "GetUserProfile")
+	defer span.End()
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	// Extract user_id from JWT claims
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
-		return nil, status.Error(codes.Unauthenticated, "no metadata provided")
+		return nil, endSpan(span, outcomeMissingToken, status.Error(codes.Unauthenticated, "no metadata provided"))
 	}
 
 	authToken, ok := md["authorization"]
 	if !ok || len(authToken) == 0 {
-		return nil, status.Error(codes.Unauthenticated, "authorization token missing")
+		return nil, endSpan(span, outcomeMissingToken, status.Error(codes.Unauthenticated, "authorization token missing"))
 	}
 
 	tokenString := strings.TrimPrefix(authToken[0], "Bearer ")
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return s.secret, nil
-	})
+	token, err := s.parseToken(tokenString)
 	if err != nil || !token.Valid {
-		return nil, status.Error(codes.Unauthenticated, "invalid token")
+		return nil, endSpan(span, outcomeInvalidToken, status.Error(codes.Unauthenticated, "invalid token"))
 	}
 
 	claims, ok := token.Claims.(jwt.MapClaims)
 	if !ok {
-		return nil, status.Error(codes.Unauthenticated, "invalid token claims")
+		return nil, endSpan(span, outcomeInvalidToken, status.Error(codes.Unauthenticated, "invalid token claims"))
 	}
 
 	userID, ok := claims["user_id"].(string)
 	if !ok {
-		return nil, status.Error(codes.Unauthenticated, "invalid user_id in token")
+		return nil, endSpan(span, outcomeInvalidToken, status.Error(codes.Unauthenticated, "invalid user_id in token"))
 	}
 
 	username, ok := claims["sub"].(string)
 	if !ok {
-		return nil, status.Error(codes.Unauthenticated, "invalid username in token")
+		return nil, endSpan(span, outcomeInvalidToken, status.Error(codes.Unauthenticated, "invalid username in token"))
 	}
 
 	user, exists := s.users[username]
 	if !exists || user.ID != userID {
-		return nil, status.Error(codes.Unauthenticated, "user not found")
+		return nil, endSpan(span, outcomeDenied, status.Error(codes.Unauthenticated, "user not found"))
 	}
 
+	span.SetAttributes(attribute.String("enduser.id", userID))
+	endSpan(span, outcomeOK, nil)
 	return &auth.GetUserProfileResponse{
 		UserId:   userID,
 		Username: username,
 	}, nil
 }
+
+// signToken issues an access token for user
+func (s *authServer) signToken(user user) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(accessTokenTTL)
+	claims := jwt.MapClaims{
+		"user_id": user.ID,
+		"sub":     user.Username,
+		"iat":     now.Unix(),
+		"exp":     expiresAt.Unix(),
+	}
+	if s.tenant != "" {
+		claims["tenant"] = s.tenant
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	tokenString, err := token.SignedString(s.signingKey())
+	return tokenString, expiresAt, err
+}
+
+// issueRefreshToken stores a new opaque refresh token, dropping the expired
+// ones that were never used. The caller must hold s.mu.
+func (s *authServer) issueRefreshToken(username string) string {
+	now := time.Now()
+	for token, refresh := range s.refreshTokens {
+		if now.After(refresh.ExpiresAt) {
+			delete(s.refreshTokens, token)
+		}
+	}
+
+	b := make([]byte, 32)
+	rand.Read(b)
+	token := base64.RawURLEncoding.EncodeToString(b)
+	s.refreshTokens[token] = refreshToken{
+		Username:  username,
+		ExpiresAt: now.Add(refreshTokenTTL),
+	}
+	return token
+}
+
+// startSpan starts the span of an AuthService handler
+func (s *authServer) startSpan(ctx context.Context, method string) (context.Context, trace.Span) {
+	ctx, span := s.tracer.Start(ctx, method)
+	span.SetAttributes(
+		attribute.String("service.name", "auth-service"),
+		attribute.String("rpc.method", method),
+		attribute.String("rpc.service", "AuthService"),
+	)
+	return ctx, span
+}
@@ -3,12 +3,16 @@ package auth
 import (
 	"context"
 	"coscup2025/env"
+	"coscup2025/keyset"
 	"coscup2025/proto/auth"
-	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/golang-jwt/jwt"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
@@ -17,43 +21,89 @@ import (
 
 type authServer struct {
 	auth.UnimplementedAuthServiceServer
-	users  map[string]user
-	mu     sync.RWMutex
-	secret []byte
+	users         map[string]user
+	refreshTokens map[string]refreshToken
+	erased        map[string]bool // IDs of erased users, whose tokens are refused
+	invitations   map[string]invitation
+	// admins maps the configured admin usernames to the ID of the account
+	// that holds the admin rights, "" until one is created
+	admins        map[string]string
+	revokedBefore map[string]time.Time // by user ID, tokens issued until then are refused
+	epoch         time.Time            // tokens issued until then are refused
+	nextUserID    int
+	mu            sync.RWMutex
+	keys          *keyset.Set
+	tenant        string
+	termsVersion  string
+	termsURL      string
+	invitationTTL time.Duration
+	invitationURL string
+	tracer        trace.Tracer
 }
 
-func NewAuthServer() *authServer {
+// NewAuthServer returns the auth service, signing tokens with keys, which
+// rotate with the secret together with the keys derived from it elsewhere.
+func NewAuthServer(cfg *env.Config, keys *keyset.Set) *authServer {
+	admins := make(map[string]string, len(cfg.AdminUsers))
+	for _, name := range cfg.AdminUsers {
+		admins[name] = ""
+	}
 	return &authServer{
-		users:  make(map[string]user),
-		secret: []byte(env.DefaultConfig().JWTSecret),
+		users:         make(map[string]user),
+		refreshTokens: make(map[string]refreshToken),
+		erased:        make(map[string]bool),
+		revokedBefore: make(map[string]time.Time),
+		invitations:   make(map[string]invitation),
+		admins:        admins,
+		keys:          keys,
+		tenant:        cfg.Tenant,
+		termsVersion:  cfg.TermsVersion,
+		termsURL:      cfg.TermsURL,
+		invitationTTL: cfg.InvitationTTL,
+		invitationURL: cfg.InvitationURL,
+		tracer:        otel.Tracer("auth-service"),
 	}
 }
 
+// publicMethods are the unary calls served without a token
+var publicMethods = map[string]bool{
+	"/auth.AuthService/SignUp":                   true,
+	"/auth.AuthService/SignIn":                   true,
+	"/auth.AuthService/RefreshToken":             true,
+	"/auth.AuthService/GetTerms":                 true,
+	"/media.MediaService/ListPublicChannels":     true,
+	"/media.MediaService/ResolveShareLink":       true,
+	"/media.MediaService/GetThumbnail":           true,
+	"/media.MediaService/GetAvatar":              true,
+	"/media.MediaService/ListPublicVideos":       true,
+	"/media.MediaService/GetHLSSegment":          true,
+	"/account.AccountService/GetDeletionReceipt": true,
+	"/grpc.health.v1.Health/Check":               true,
+	"/media.MediaService/ReportTranscode":        true, // signed with the webhook secret
+	"/auth.AuthService/AcceptInvitation":         true,
+}
+
+// optionalAuthMethods are the unary calls served without a token, which still
+// validate a token if one is sent
+var optionalAuthMethods = map[string]bool{
+	"/media.MediaService/GetEmbed":       true,
+	"/media.MediaService/GetHLSPlaylist": true,
+}
+
 // UnaryInterceptor for JWT validation
 func (s *authServer) UnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-	if info.FullMethod == "/auth.AuthService/SignUp" || info.FullMethod == "/auth.AuthService/SignIn" {
+	if publicMethods[info.FullMethod] {
+		s.traceExempt(ctx, "UnaryInterceptor", info.FullMethod)
 		return handler(ctx, req)
 	}
-
-	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		return nil, status.Error(codes.Unauthenticated, "no metadata provided")
-	}
-
-	auth, ok := md["authorization"]
-	if !ok || len(auth) == 0 {
-		return nil, status.Error(codes.Unauthenticated, "authorization token missing")
+	if optionalAuthMethods[info.FullMethod] && !hasToken(ctx) {
+		s.traceExempt(ctx, "UnaryInterceptor", info.FullMethod)
+		return handler(ctx, req)
 	}
 
-	tokenString := strings.TrimPrefix(auth[0], "Bearer ")
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return s.secret, nil
-	})
-	if err != nil || !token.Valid {
-		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	ctx, err := s.authenticate(ctx, "UnaryInterceptor", info.FullMethod)
+	if err != nil {
+		return nil, err
 	}
 
 	return handler(ctx, req)
@@ -63,41 +113,114 @@ func (s *authServer) UnaryInterceptor(ctx context.Context, req interface{}, info
 func (s *authServer) StreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
 	// Allow auth service streaming calls (if any) without authentication
 	if strings.HasPrefix(info.FullMethod, "/auth.AuthService/") {
+		s.traceExempt(ss.Context(), "StreamInterceptor", info.FullMethod)
 		return handler(srv, ss)
 	}
 
 	// For media service, require authentication
-	ctx := ss.Context()
+	ctx, err := s.authenticate(ss.Context(), "StreamInterceptor", info.FullMethod)
+	if err != nil {
+		return err
+	}
+
+	// Add user info to context for downstream use
+	ss = &ServerCtxStream{ServerStream: ss, ctx: ctx}
+
+	return handler(srv, ss)
+}
+
+// authenticate validates the bearer token of an incoming call and returns ctx
+// carrying the caller's identity. The check is recorded as its own span.
+func (s *authServer) authenticate(ctx context.Context, interceptor, fullMethod string) (context.Context, error) {
+	_, span := s.tracer.Start(ctx, interceptor)
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("service.name", "auth-service"),
+		attribute.String("rpc.method", interceptor),
+		attribute.String("auth.target", fullMethod),
+	)
+
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
-		return status.Error(codes.Unauthenticated, "no metadata provided")
+		return nil, endSpan(span, outcomeMissingToken, status.Error(codes.Unauthenticated, "no metadata provided"))
 	}
 
 	auth, ok := md["authorization"]
 	if !ok || len(auth) == 0 {
-		return status.Error(codes.Unauthenticated, "authorization token missing")
+		return nil, endSpan(span, outcomeMissingToken, status.Error(codes.Unauthenticated, "authorization token missing"))
 	}
 
 	tokenString := strings.TrimPrefix(auth[0], "Bearer ")
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return s.secret, nil
-	})
+	token, err := s.parseToken(tokenString)
 	if err != nil || !token.Valid {
-		return status.Error(codes.Unauthenticated, "invalid token")
+		return nil, endSpan(span, outcomeInvalidToken, status.Error(codes.Unauthenticated, "invalid token"))
 	}
 
-	// Add user info to context for downstream use
-	if claims, ok := token.Claims.(jwt.MapClaims); ok {
-		if username, exists := claims["username"]; exists {
-			ctx = metadata.AppendToOutgoingContext(ctx, "x-user-id", username.(string))
-			ss = &ServerCtxStream{ServerStream: ss, ctx: ctx}
+	ctx = withIdentity(ctx, token)
+	if id, ok := IdentityFromContext(ctx); ok {
+		if s.isErased(id.UserID) {
+			return nil, endSpan(span, outcomeInvalidToken, status.Error(codes.Unauthenticated, "account was deleted"))
+		}
+		if s.isRevoked(id) {
+			return nil, endSpan(span, outcomeRevoked, status.Error(codes.Unauthenticated, "session was revoked, sign in again"))
+		}
+		if !termsExemptMethods[fullMethod] && s.needsTerms(id) {
+			return nil, endSpan(span, outcomeTermsRequired, s.termsError())
 		}
+		if id.VideoID != "" && !videoTokenMethods[fullMethod] {
+			return nil, endSpan(span, outcomeDenied, status.Error(codes.PermissionDenied, "token is limited to a single video"))
+		}
+		// Video tokens act for their signer on a single video, without
+		// their admin rights
+		id.Admin = id.VideoID == "" && s.isAdmin(id.UserID)
+		// Downstream spans pick the identity up from baggage; the server span
+		// already exists, so it is labelled here.
+		ctx = withIdentityBaggage(ctx, id)
+		span.SetAttributes(id.attributes()...)
+		trace.SpanFromContext(ctx).SetAttributes(id.attributes()...)
+	}
+	endSpan(span, outcomeAuthenticated, nil)
+	return ctx, nil
+}
+
+// hasToken reports whether the incoming call carries an authorization token.
+func hasToken(ctx context.Context) bool {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return len(md["authorization"]) > 0
+}
+
+// traceExempt records that fullMethod skipped token validation.
+func (s *authServer) traceExempt(ctx context.Context, interceptor, fullMethod string) {
+	_, span := s.tracer.Start(ctx, interceptor)
+	span.SetAttributes(
+		attribute.String("service.name", "auth-service"),
+		attribute.String("rpc.method", interceptor),
+		attribute.String("auth.target", fullMethod),
+	)
+	endSpan(span, outcomeExempt, nil)
+	span.End()
+}
+
+type identityKey struct{}
+
+// withIdentity attaches the caller described by the token claims to ctx
+func withIdentity(ctx context.Context, token *jwt.Token) context.Context {
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return ctx
 	}
+	userID, _ := claims["user_id"].(string)
+	username, _ := claims["sub"].(string)
+	tenant, _ := claims["tenant"].(string)
+	videoID, _ := claims["video_id"].(string)
+	return context.WithValue(ctx, identityKey{}, &Identity{UserID: userID, Username: username, Tenant: tenant, VideoID: videoID, Claims: claims})
+}
 
-	return handler(srv, ss)
+// IdentityFromContext returns the authenticated caller set by the interceptors
+func IdentityFromContext(ctx context.Context) (*Identity, bool) {
+	id, ok := ctx.Value(identityKey{}).(*Identity)
+	return id, ok
 }
 
 // ServerCtxStream wraps grpc.ServerStream to override Context()
@@ -0,0 +1,139 @@
+package auth
+
+import (
+	"context"
+	"coscup2025/proto/auth"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrUserExists is returned by ImportUser when the username is taken.
+var ErrUserExists = errors.New("username is taken")
+
+// invitation lets the user of an imported account choose its password.
+type invitation struct {
+	Username  string
+	ExpiresAt time.Time
+}
+
+// ImportedUser is an account created on behalf of an admin.
+type ImportedUser struct {
+	UserID string
+	// Password is the generated temporary password, unless the user was
+	// invited.
+	Password string
+	// InvitationURL lets the user choose a password until
+	// InvitationExpiresAt.
+	InvitationURL       string
+	InvitationExpiresAt time.Time
+}
+
+// randomToken returns n random bytes, encoded for URLs.
+func randomToken(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// ImportUser creates the account of username on behalf of an admin, with a
+// generated temporary password or, if invite, a single-use invitation to
+// choose one. Like everyone else, the user accepts the terms when they first
+// sign in.
+func (s *authServer) ImportUser(username string, invite bool) (*ImportedUser, error) {
+	now := time.Now()
+	u := user{Username: username, CreatedAt: now}
+	var imported ImportedUser
+	if !invite {
+		// Hashed before taking the lock, which large imports would hold for
+		// long otherwise
+		imported.Password = randomToken(12)
+		bcryptPassword, err := bcrypt.GenerateFromPassword([]byte(imported.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash password: %v", err)
+		}
+		u.Password = string(bcryptPassword)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.users[username]; exists {
+		return nil, ErrUserExists
+	}
+	s.nextUserID++
+	u.ID = fmt.Sprintf("user_%d", s.nextUserID)
+	s.users[username] = u
+	s.bindAdminLocked(u)
+	imported.UserID = u.ID
+
+	if invite {
+		// Without a password the account cannot be signed in to until the
+		// invitation is accepted
+		token := randomToken(32)
+		s.invitations[token] = invitation{Username: username, ExpiresAt: now.Add(s.invitationTTL)}
+		imported.InvitationURL = strings.ReplaceAll(s.invitationURL, "{token}", url.PathEscape(token))
+		imported.InvitationExpiresAt = now.Add(s.invitationTTL)
+	}
+	return &imported, nil
+}
+
+func (s *authServer) AcceptInvitation(ctx context.Context, req *auth.AcceptInvitationRequest) (*auth.AcceptInvitationResponse, error) {
+	_, span := s.startSpan(ctx, "AcceptInvitation")
+	defer span.End()
+
+	// Checked before the invitation is used up, so it can be retried
+	if s.termsVersion != "" && req.AcceptedTermsVersion != s.termsVersion {
+		return nil, endSpan(span, outcomeTermsRequired, s.termsError())
+	}
+	bcryptPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, endSpan(span, outcomeError, status.Error(codes.Internal, "failed to hash password"))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	inv, exists := s.invitations[req.Invitation]
+	delete(s.invitations, req.Invitation)
+	if !exists || time.Now().After(inv.ExpiresAt) {
+		return nil, endSpan(span, outcomeInvalidToken, status.Error(codes.Unauthenticated, "invalid invitation"))
+	}
+	u, exists := s.users[inv.Username]
+	if !exists {
+		return nil, endSpan(span, outcomeDenied, status.Error(codes.Unauthenticated, "user not found"))
+	}
+	span.SetAttributes(attribute.String("enduser.id", u.ID))
+
+	now := time.Now()
+	u.Password = string(bcryptPassword)
+	if s.termsVersion != "" {
+		u.TermsVersion = s.termsVersion
+		u.TermsAcceptedAt = now
+	}
+	s.users[u.Username] = u
+
+	tokenString, expiresAt, err := s.signToken(u)
+	if err != nil {
+		return nil, endSpan(span, outcomeError, status.Error(codes.Internal, "failed to generate token"))
+	}
+	refresh := s.issueRefreshToken(u.Username)
+	s.recordLogin(u.Username, newLogin(ctx, now))
+
+	endSpan(span, outcomeOK, nil)
+	return &auth.AcceptInvitationResponse{
+		UserId:       u.ID,
+		Token:        tokenString,
+		RefreshToken: refresh,
+		ExpiresAt:    expiresAt.Unix(),
+	}, nil
+}
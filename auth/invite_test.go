@@ -0,0 +1,41 @@
+package auth
+
+import (
+	"context"
+	"net/url"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"coscup2025/env"
+	pbAuth "coscup2025/proto/auth"
+)
+
+func TestInvitedAccountCannotBeSignedUpFor(t *testing.T) {
+	cfg := env.DefaultConfig()
+	cfg.InvitationURL = "https://coscup.org/invite/{token}"
+	authSrv, client := serve(t, cfg)
+	ctx := context.Background()
+
+	imported, err := authSrv.ImportUser("speaker", true)
+	require.NoError(t, err)
+	link, err := url.Parse(imported.InvitationURL)
+	require.NoError(t, err)
+
+	// Step 1: the account has no password yet, but its username is taken
+	_, err = client.SignUp(ctx, &pbAuth.SignUpRequest{Username: "speaker", Password: "attacker-pass"})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	_, err = client.SignIn(ctx, &pbAuth.SignInRequest{Username: "speaker", Password: "attacker-pass"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// Step 2: the invitation still works
+	accepted, err := client.AcceptInvitation(ctx, &pbAuth.AcceptInvitationRequest{Invitation: path.Base(link.Path), Password: "speaker-pass"})
+	require.NoError(t, err)
+	assert.Equal(t, imported.UserID, accepted.UserId)
+	_, err = client.SignIn(ctx, &pbAuth.SignInRequest{Username: "speaker", Password: "speaker-pass"})
+	require.NoError(t, err)
+}
@@ -0,0 +1,99 @@
+package auth
+
+import (
+	"context"
+	"net/netip"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// maxLogins bounds the sign-ins kept per user; older ones are dropped first.
+const maxLogins = 50
+
+// Login is a successful sign-in.
+type Login struct {
+	At        time.Time
+	Address   string
+	UserAgent string
+}
+
+// UserData is everything stored about a user, for exporting it.
+type UserData struct {
+	ID        string
+	Username  string
+	CreatedAt time.Time
+	Logins    []Login // oldest first
+
+	TermsVersion    string
+	TermsAcceptedAt time.Time
+}
+
+// newLogin describes the sign-in of the caller of ctx.
+func newLogin(ctx context.Context, at time.Time) Login {
+	l := Login{At: at}
+	if p, ok := peer.FromContext(ctx); ok {
+		l.Address = p.Addr.String()
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	// The gateway dials in from loopback and forwards the browser's address
+	// and user agent; only trust them from there.
+	if addr, err := netip.ParseAddrPort(l.Address); err == nil && addr.Addr().IsLoopback() {
+		if v := md.Get("x-forwarded-for"); len(v) > 0 {
+			l.Address = strings.TrimSpace(strings.Split(v[0], ",")[0])
+		}
+		if v := md.Get("grpcgateway-user-agent"); len(v) > 0 {
+			l.UserAgent = v[0]
+		}
+	}
+	if v := md.Get("user-agent"); l.UserAgent == "" && len(v) > 0 {
+		l.UserAgent = v[0]
+	}
+	return l
+}
+
+// recordLogin appends l to the history of username. The caller must hold
+// s.mu.
+func (s *authServer) recordLogin(username string, l Login) {
+	u, ok := s.users[username]
+	if !ok {
+		return
+	}
+	u.Logins = append(u.Logins, l)
+	if len(u.Logins) > maxLogins {
+		u.Logins = u.Logins[len(u.Logins)-maxLogins:]
+	}
+	s.users[username] = u
+}
+
+// UserID returns the ID of the user with the given username.
+func (s *authServer) UserID(username string) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	u, ok := s.users[username]
+	return u.ID, ok
+}
+
+// UserData returns what is stored about the user with the given ID.
+func (s *authServer) UserData(userID string) (*UserData, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, u := range s.users {
+		if u.ID == userID {
+			return &UserData{
+				ID:        u.ID,
+				Username:  u.Username,
+				CreatedAt: u.CreatedAt,
+				Logins:    append([]Login(nil), u.Logins...),
+
+				TermsVersion:    u.TermsVersion,
+				TermsAcceptedAt: u.TermsAcceptedAt,
+			}, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,19 @@
+package auth
+
+import (
+	"coscup2025/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// authFailures counts rejected sign-ins, refreshes and tokens by the same
+// outcome recorded on their spans.
+var authFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "coscup",
+	Name:      "auth_failures_total",
+	Help:      "Failed authentications by outcome, e.g. denied or invalid_token.",
+}, []string{"outcome"})
+
+func init() {
+	metrics.Registry.MustRegister(authFailures)
+}
@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"coscup2025/env"
+	pbAuth "coscup2025/proto/auth"
+)
+
+func TestExpiredRefreshTokensArePruned(t *testing.T) {
+	authSrv, client := serve(t, env.DefaultConfig())
+	ctx := context.Background()
+	_, err := client.SignUp(ctx, &pbAuth.SignUpRequest{Username: "alice", Password: "pass"})
+	require.NoError(t, err)
+	expired, err := client.SignIn(ctx, &pbAuth.SignInRequest{Username: "alice", Password: "pass"})
+	require.NoError(t, err)
+	kept, err := client.SignIn(ctx, &pbAuth.SignInRequest{Username: "alice", Password: "pass"})
+	require.NoError(t, err)
+
+	authSrv.mu.Lock()
+	refresh := authSrv.refreshTokens[expired.RefreshToken]
+	refresh.ExpiresAt = time.Now().Add(-time.Second)
+	authSrv.refreshTokens[expired.RefreshToken] = refresh
+	authSrv.mu.Unlock()
+
+	// Issuing a token drops the expired one, which was never used
+	latest, err := client.SignIn(ctx, &pbAuth.SignInRequest{Username: "alice", Password: "pass"})
+	require.NoError(t, err)
+	authSrv.mu.RLock()
+	defer authSrv.mu.RUnlock()
+	assert.NotContains(t, authSrv.refreshTokens, expired.RefreshToken)
+	assert.Contains(t, authSrv.refreshTokens, kept.RefreshToken)
+	assert.Contains(t, authSrv.refreshTokens, latest.RefreshToken)
+}
@@ -0,0 +1,64 @@
+package auth
+
+import "time"
+
+// RevokeSessions signs the user with the given ID out everywhere: it drops
+// their refresh tokens and refuses the access and video tokens issued to them
+// until now. It returns how many refresh tokens it dropped, and false when
+// there is no such user.
+func (s *authServer) RevokeSessions(userID string) (int, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for username, u := range s.users {
+		if u.ID != userID {
+			continue
+		}
+		s.revokedBefore[userID] = time.Now()
+		return s.dropRefreshTokens(func(r refreshToken) bool { return r.Username == username }), true
+	}
+	return 0, false
+}
+
+// RevokeAllSessions signs everyone out, e.g. when the JWT secret may have
+// leaked: it drops every refresh token and refuses every token issued until
+// now. It also ends the grace period of the previous secret, so once the
+// leaked secret is rotated out, tokens forged with it are refused even when
+// they claim to be newer. It returns how many refresh tokens it dropped and
+// the new epoch.
+func (s *authServer) RevokeAllSessions() (int, time.Time) {
+	s.keys.EndGrace()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.epoch = time.Now()
+	return s.dropRefreshTokens(func(refreshToken) bool { return true }), s.epoch
+}
+
+// dropRefreshTokens removes the refresh tokens matching drop and returns how
+// many it removed. The caller must hold s.mu.
+func (s *authServer) dropRefreshTokens(drop func(refreshToken) bool) int {
+	n := 0
+	for token, refresh := range s.refreshTokens {
+		if drop(refresh) {
+			delete(s.refreshTokens, token)
+			n++
+		}
+	}
+	return n
+}
+
+// isRevoked reports whether the token of id was issued before its user's
+// sessions, or everyone's, were revoked. Token times are in whole seconds, so
+// tokens issued within the second of a revocation are refused too.
+func (s *authServer) isRevoked(id *Identity) bool {
+	iat, _ := id.Claims["iat"].(float64)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	cutoff := s.epoch
+	if t, ok := s.revokedBefore[id.UserID]; ok && t.After(cutoff) {
+		cutoff = t
+	}
+	return !cutoff.IsZero() && int64(iat) <= cutoff.Unix()
+}
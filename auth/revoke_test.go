@@ -0,0 +1,48 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"coscup2025/env"
+	pbAuth "coscup2025/proto/auth"
+)
+
+func TestRevokeAllSessionsEndsSecretGrace(t *testing.T) {
+	cfg := env.DefaultConfig()
+	cfg.JWTSecret = "leaked-secret"
+	authSrv, client := serve(t, cfg)
+	signedUp, err := client.SignUp(context.Background(), &pbAuth.SignUpRequest{Username: "alice", Password: "pass"})
+	require.NoError(t, err)
+	_, err = client.SignIn(context.Background(), &pbAuth.SignInRequest{Username: "alice", Password: "pass"})
+	require.NoError(t, err)
+
+	assert.True(t, authSrv.RotateSecret([]byte("new-secret"), time.Hour))
+	n, epoch := authSrv.RevokeAllSessions()
+	assert.Equal(t, 1, n)
+	// Wait for tokens to be issued after the epoch
+	time.Sleep(time.Until(epoch.Truncate(time.Second).Add(time.Second)))
+
+	// A token forged with the leaked secret is refused although it is newer
+	forged, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id": signedUp.UserId,
+		"sub":     "alice",
+		"iat":     time.Now().Unix(),
+		"exp":     time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte("leaked-secret"))
+	require.NoError(t, err)
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+forged))
+	_, err = client.GetUserProfile(ctx, &pbAuth.GetUserProfileRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = client.GetUserProfile(signIn(t, client, "alice"), &pbAuth.GetUserProfileRequest{})
+	require.NoError(t, err)
+}
@@ -0,0 +1,111 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/golang-jwt/jwt"
+)
+
+// signingKey returns the secret that signs new tokens.
+func (s *authServer) signingKey() []byte {
+	return s.keys.Current()
+}
+
+// parseToken parses a token and checks its signature with the current
+// secret, or the previous one during its grace period.
+func (s *authServer) parseToken(tokenString string) (*jwt.Token, error) {
+	var token *jwt.Token
+	var err error
+	for _, key := range s.keys.Valid() {
+		token, err = jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+			}
+			return key, nil
+		})
+		// Only a signature made with another secret is worth a second try
+		var ve *jwt.ValidationError
+		if err == nil || !errors.As(err, &ve) || ve.Errors&jwt.ValidationErrorSignatureInvalid == 0 {
+			return token, err
+		}
+	}
+	return token, err
+}
+
+// RotateSecret makes secret sign new tokens, and the keys derived from it.
+// Tokens signed with the secret it replaces stay valid for grace. It reports
+// whether the secret changed.
+func (s *authServer) RotateSecret(secret []byte, grace time.Duration) bool {
+	return s.keys.Rotate(secret, grace)
+}
+
+// ReadSecretFile returns the secret stored in the file at path, without
+// surrounding whitespace.
+func ReadSecretFile(path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	secret := strings.TrimSpace(string(b))
+	if secret == "" {
+		return "", fmt.Errorf("%s is empty", path)
+	}
+	return secret, nil
+}
+
+// WatchSecretFile rotates the secret whenever the file at path changes, e.g.
+// when a secret manager or a Vault agent writes a new one, until ctx is done.
+// Tokens signed with the replaced secret stay valid for grace. rotated, if
+// set, is called after each rotation.
+func (s *authServer) WatchSecretFile(ctx context.Context, path string, grace time.Duration, rotated func()) error {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	// Watch the directory: secrets are usually replaced by renaming a file or
+	// swapping a symlink over the old one, which ends a watch on the file.
+	if err := w.Add(filepath.Dir(path)); err != nil {
+		w.Close()
+		return err
+	}
+
+	go func() {
+		defer w.Close()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case err, ok := <-w.Errors:
+				if !ok {
+					return
+				}
+				log.Printf("failed to watch JWT secret file: %v", err)
+			case _, ok := <-w.Events:
+				if !ok {
+					return
+				}
+				secret, err := ReadSecretFile(path)
+				if err != nil {
+					// Missing or empty while it is replaced; the write that
+					// completes it is another event.
+					continue
+				}
+				if s.RotateSecret([]byte(secret), grace) {
+					log.Printf("Rotated the JWT secret from %s, accepting the previous one for %s", path, grace)
+					if rotated != nil {
+						rotated()
+					}
+				}
+			}
+		}
+	}()
+	return nil
+}
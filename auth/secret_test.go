@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"coscup2025/env"
+	pbAuth "coscup2025/proto/auth"
+)
+
+// signIn returns a context carrying a fresh token of username.
+func signIn(t *testing.T, client pbAuth.AuthServiceClient, username string) context.Context {
+	resp, err := client.SignIn(context.Background(), &pbAuth.SignInRequest{Username: username, Password: "pass"})
+	require.NoError(t, err)
+	return metadata.NewOutgoingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+resp.Token))
+}
+
+func TestSecretFileRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jwt-secret")
+	require.NoError(t, os.WriteFile(path, []byte("first-secret\n"), 0o600))
+	secret, err := ReadSecretFile(path)
+	require.NoError(t, err)
+	cfg := env.DefaultConfig()
+	cfg.JWTSecret = secret
+	authSrv, client := serve(t, cfg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	rotated := make(chan struct{}, 1)
+	require.NoError(t, authSrv.WatchSecretFile(ctx, path, time.Hour, func() { rotated <- struct{}{} }))
+
+	_, err = client.SignUp(context.Background(), &pbAuth.SignUpRequest{Username: "alice", Password: "pass"})
+	require.NoError(t, err)
+	before := signIn(t, client, "alice")
+
+	// Step 1: a new secret is picked up without a restart
+	require.NoError(t, os.WriteFile(path+".new", []byte("second-secret\n"), 0o600))
+	require.NoError(t, os.Rename(path+".new", path))
+	select {
+	case <-rotated:
+	case <-time.After(5 * time.Second):
+		t.Fatal("secret was not rotated")
+	}
+	assert.Equal(t, []byte("second-secret"), authSrv.signingKey())
+
+	// Step 2: tokens of both secrets are accepted during the grace period
+	after := signIn(t, client, "alice")
+	_, err = client.GetUserProfile(after, &pbAuth.GetUserProfileRequest{})
+	require.NoError(t, err)
+	_, err = client.GetUserProfile(before, &pbAuth.GetUserProfileRequest{})
+	require.NoError(t, err)
+
+	// Step 3: once it ends, only the new secret is
+	assert.True(t, authSrv.RotateSecret([]byte("third-secret"), 0))
+	_, err = client.GetUserProfile(after, &pbAuth.GetUserProfileRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = client.GetUserProfile(signIn(t, client, "alice"), &pbAuth.GetUserProfileRequest{})
+	require.NoError(t, err)
+
+	assert.False(t, authSrv.RotateSecret([]byte("third-secret"), time.Hour), "unchanged secret")
+}
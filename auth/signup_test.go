@@ -0,0 +1,41 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"coscup2025/env"
+	pbAuth "coscup2025/proto/auth"
+)
+
+func TestConcurrentSignUpsTakeAUsernameOnce(t *testing.T) {
+	_, client := serve(t, env.DefaultConfig())
+
+	// Passwords are hashed outside the lock, so several requests may get
+	// past the first check before any of them stores the user
+	var wg sync.WaitGroup
+	errs := make([]error, 8)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = client.SignUp(context.Background(), &pbAuth.SignUpRequest{Username: "alice", Password: "pass"})
+		}()
+	}
+	wg.Wait()
+
+	var created int
+	for _, err := range errs {
+		if err == nil {
+			created++
+		} else {
+			assert.Equal(t, codes.AlreadyExists, status.Code(err))
+		}
+	}
+	assert.Equal(t, 1, created)
+}
@@ -1,7 +1,37 @@
 package auth
 
+import "time"
+
+const (
+	accessTokenTTL  = 24 * time.Hour
+	refreshTokenTTL = 30 * 24 * time.Hour
+)
+
 type user struct {
-	ID       string
+	ID        string
+	Username  string
+	Password  string
+	CreatedAt time.Time
+	Logins    []Login // oldest first, at most maxLogins
+	// TermsVersion is the version of the terms the user last accepted
+	TermsVersion    string
+	TermsAcceptedAt time.Time
+}
+
+type refreshToken struct {
+	Username  string
+	ExpiresAt time.Time
+}
+
+// Identity is the authenticated caller of a request
+type Identity struct {
+	UserID   string
 	Username string
-	Password string
+	Tenant   string
+	// VideoID is set for video tokens, which only grant access to this video
+	VideoID string
+	// Claims are all claims of the token, for authorization policies
+	Claims map[string]any
+	// Admin is set for the accounts of the configured admin users
+	Admin bool
 }
@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"context"
+	"coscup2025/proto/auth"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// termsExemptMethods stay available to users who have not accepted the
+// current terms, so they can accept them, or take their data and leave.
+var termsExemptMethods = map[string]bool{
+	"/auth.AuthService/AcceptTerms":           true,
+	"/account.AccountService/ExportMyData":    true,
+	"/account.AccountService/GetExport":       true,
+	"/account.AccountService/DownloadExport":  true,
+	"/account.AccountService/RequestDeletion": true,
+	"/account.AccountService/GetDeletion":     true,
+	"/account.AccountService/CancelDeletion":  true,
+}
+
+// termsError asks the caller to accept the current terms. Its details say
+// which version, for clients to show the right document.
+func (s *authServer) termsError() error {
+	st := status.New(codes.FailedPrecondition, "the terms of service have changed and must be accepted")
+	st, err := st.WithDetails(&errdetails.PreconditionFailure{
+		Violations: []*errdetails.PreconditionFailure_Violation{{
+			Type:        "TERMS_OF_SERVICE",
+			Subject:     s.termsVersion,
+			Description: "accept the terms at " + s.termsURL + " with AcceptTerms",
+		}},
+	})
+	if err != nil {
+		return status.Error(codes.FailedPrecondition, "the terms of service have changed and must be accepted")
+	}
+	return st.Err()
+}
+
+// needsTerms reports whether the caller has yet to accept the current terms.
+// Anonymous viewers and video tokens act for no one who could accept them.
+func (s *authServer) needsTerms(id *Identity) bool {
+	if s.termsVersion == "" || id.UserID == "" || id.VideoID != "" {
+		return false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	u, ok := s.users[id.Username]
+	return ok && u.ID == id.UserID && u.TermsVersion != s.termsVersion
+}
+
+func (s *authServer) GetTerms(ctx context.Context, req *auth.GetTermsRequest) (*auth.GetTermsResponse, error) {
+	return &auth.GetTermsResponse{Version: s.termsVersion, Url: s.termsURL}, nil
+}
+
+func (s *authServer) AcceptTerms(ctx context.Context, req *auth.AcceptTermsRequest) (*auth.AcceptTermsResponse, error) {
+	_, span := s.startSpan(ctx, "AcceptTerms")
+	defer span.End()
+
+	id, ok := IdentityFromContext(ctx)
+	if !ok {
+		return nil, endSpan(span, outcomeMissingToken, status.Error(codes.Unauthenticated, "unknown caller"))
+	}
+	if s.termsVersion == "" {
+		return nil, endSpan(span, outcomeInvalidRequest, status.Error(codes.FailedPrecondition, "no terms are configured"))
+	}
+	if req.Version != s.termsVersion {
+		return nil, endSpan(span, outcomeInvalidRequest, status.Errorf(codes.InvalidArgument, "the current terms are version %q", s.termsVersion))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, ok := s.users[id.Username]
+	if !ok || u.ID != id.UserID {
+		return nil, endSpan(span, outcomeDenied, status.Error(codes.Unauthenticated, "user not found"))
+	}
+	u.TermsVersion = req.Version
+	u.TermsAcceptedAt = time.Now()
+	s.users[id.Username] = u
+
+	endSpan(span, outcomeOK, nil)
+	return &auth.AcceptTermsResponse{Version: u.TermsVersion, AcceptedAt: u.TermsAcceptedAt.Unix()}, nil
+}
@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"coscup2025/env"
+	"coscup2025/keyset"
+	pbAuth "coscup2025/proto/auth"
+)
+
+// serve starts an auth server with cfg and returns it with a client of it.
+// The tests of this package reach into the server, which servertest, itself
+// importing this package, cannot hand them, so they serve it on their own.
+func serve(t *testing.T, cfg *env.Config) (*authServer, pbAuth.AuthServiceClient) {
+	lis := bufconn.Listen(1024 * 1024)
+	authSrv := NewAuthServer(cfg, keyset.New([]byte(cfg.JWTSecret)))
+	server := grpc.NewServer(grpc.UnaryInterceptor(authSrv.UnaryInterceptor))
+	pbAuth.RegisterAuthServiceServer(server, authSrv)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return authSrv, pbAuth.NewAuthServiceClient(conn)
+}
+
+func TestTermsMustBeAccepted(t *testing.T) {
+	cfg := env.DefaultConfig()
+	cfg.TermsVersion = "2025-07"
+	cfg.TermsURL = "https://coscup.org/terms"
+	authSrv, client := serve(t, cfg)
+	ctx := context.Background()
+
+	_, err := client.SignUp(ctx, &pbAuth.SignUpRequest{Username: "alice", Password: "pass"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = client.SignUp(ctx, &pbAuth.SignUpRequest{Username: "alice", Password: "pass", AcceptedTermsVersion: "2025-07"})
+	require.NoError(t, err)
+	resp, err := client.SignIn(ctx, &pbAuth.SignInRequest{Username: "alice", Password: "pass"})
+	require.NoError(t, err)
+	alice := metadata.NewOutgoingContext(ctx, metadata.Pairs("authorization", "Bearer "+resp.Token))
+	_, err = client.GetUserProfile(alice, &pbAuth.GetUserProfileRequest{})
+	require.NoError(t, err)
+
+	// Publishing a new version locks alice out of everything but accepting it
+	authSrv.termsVersion = "2025-08"
+	_, err = client.GetUserProfile(alice, &pbAuth.GetUserProfileRequest{})
+	st := status.Convert(err)
+	require.Equal(t, codes.FailedPrecondition, st.Code())
+	require.Len(t, st.Details(), 1)
+	violation := st.Details()[0].(*errdetails.PreconditionFailure).Violations[0]
+	assert.Equal(t, "2025-08", violation.Subject)
+	assert.Contains(t, violation.Description, "https://coscup.org/terms")
+
+	_, err = client.AcceptTerms(alice, &pbAuth.AcceptTermsRequest{Version: "2025-07"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = client.AcceptTerms(alice, &pbAuth.AcceptTermsRequest{Version: "2025-08"})
+	require.NoError(t, err)
+	_, err = client.GetUserProfile(alice, &pbAuth.GetUserProfileRequest{})
+	assert.NoError(t, err)
+}
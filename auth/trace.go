@@ -0,0 +1,85 @@
+package auth
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Values of the auth.outcome span attribute. Tokens themselves are never
+// recorded.
+const (
+	outcomeExempt         = "exempt"
+	outcomeAuthenticated  = "authenticated"
+	outcomeMissingToken   = "missing_token"
+	outcomeInvalidToken   = "invalid_token"
+	outcomeRevoked        = "revoked"
+	outcomeInvalidRequest = "invalid_request"
+	outcomeDenied         = "denied"
+	outcomeTermsRequired  = "terms_required"
+	outcomeError          = "error"
+	outcomeOK             = "ok"
+)
+
+// endSpan records the auth outcome on span and, when err is set, marks the
+// span as failed and counts the failure. It returns err so callers can return
+// through it.
+func endSpan(span trace.Span, outcome string, err error) error {
+	span.SetAttributes(attribute.String("auth.outcome", outcome))
+	if err != nil {
+		authFailures.WithLabelValues(outcome).Inc()
+		span.RecordError(err)
+		span.SetStatus(codes.Error, outcome)
+	}
+	return err
+}
+
+// Baggage keys carrying the authenticated caller to downstream spans and
+// services.
+const (
+	BaggageUserID = "user_id"
+	BaggageTenant = "tenant"
+)
+
+func (id *Identity) attributes() []attribute.KeyValue {
+	attrs := []attribute.KeyValue{attribute.String("enduser.id", id.UserID)}
+	if id.Tenant != "" {
+		attrs = append(attrs, attribute.String("tenant.id", id.Tenant))
+	}
+	return attrs
+}
+
+// withIdentityBaggage adds the caller's user ID and tenant to the baggage of ctx
+func withIdentityBaggage(ctx context.Context, id *Identity) context.Context {
+	bag := baggage.FromContext(ctx)
+	for key, value := range map[string]string{BaggageUserID: id.UserID, BaggageTenant: id.Tenant} {
+		if value == "" {
+			continue
+		}
+		member, err := baggage.NewMemberRaw(key, value)
+		if err != nil {
+			continue
+		}
+		if b, err := bag.SetMember(member); err == nil {
+			bag = b
+		}
+	}
+	return baggage.ContextWithBaggage(ctx, bag)
+}
+
+// IdentityAttributes returns span attributes for the caller identity found in
+// the baggage of ctx, for span processors that label every span of a request.
+func IdentityAttributes(ctx context.Context) []attribute.KeyValue {
+	bag := baggage.FromContext(ctx)
+	var attrs []attribute.KeyValue
+	if v := bag.Member(BaggageUserID).Value(); v != "" {
+		attrs = append(attrs, attribute.String("enduser.id", v))
+	}
+	if v := bag.Member(BaggageTenant).Value(); v != "" {
+		attrs = append(attrs, attribute.String("tenant.id", v))
+	}
+	return attrs
+}
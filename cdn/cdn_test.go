@@ -0,0 +1,57 @@
+package cdn
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloudFrontSign(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	c, err := NewCloudFront("https://d111.cloudfront.net/", "K2JCJMDEHXQW5F", keyPEM)
+	require.NoError(t, err)
+	expires := time.Unix(1800000000, 0)
+	signed, err := c.Sign("blobs/ab/cd/abcd", expires)
+	require.NoError(t, err)
+
+	u, err := url.Parse(signed)
+	require.NoError(t, err)
+	assert.Equal(t, "/blobs/ab/cd/abcd", u.Path)
+	q := u.Query()
+	assert.Equal(t, "1800000000", q.Get("Expires"))
+	assert.Equal(t, "K2JCJMDEHXQW5F", q.Get("Key-Pair-Id"))
+
+	sig, err := base64.StdEncoding.DecodeString(strings.NewReplacer("-", "+", "_", "=", "~", "/").Replace(q.Get("Signature")))
+	require.NoError(t, err)
+	policy := `{"Statement":[{"Resource":"https://d111.cloudfront.net/blobs/ab/cd/abcd","Condition":{"DateLessThan":{"AWS:EpochTime":1800000000}}}]}`
+	digest := sha1.Sum([]byte(policy))
+	assert.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA1, digest[:], sig))
+}
+
+func TestFastlySign(t *testing.T) {
+	f, err := NewFastly("https://cdn.example.com", []byte("secret"))
+	require.NoError(t, err)
+	signed, err := f.Sign("blobs/ab/cd/abcd", time.Unix(1800000000, 0))
+	require.NoError(t, err)
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte("/blobs/ab/cd/abcd1800000000"))
+	assert.Equal(t, "https://cdn.example.com/blobs/ab/cd/abcd?token=1800000000_"+hex.EncodeToString(mac.Sum(nil)), signed)
+}
@@ -0,0 +1,163 @@
+// Package chaos injects failures into gRPC calls, so that the retry and
+// resume logic of clients can be tested against a real server. It is meant
+// for test deployments only.
+package chaos
+
+import (
+	"context"
+	"math/rand/v2"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"coscup2025/env"
+)
+
+// Faults are the failures to inject. The zero value injects none.
+type Faults struct {
+	// Latency delays every call by up to Latency, picked uniformly.
+	Latency time.Duration
+	// UnavailableRate is the probability that a call fails with Unavailable
+	// before reaching its handler.
+	UnavailableRate float64
+	// AbortRate is the probability, for every message a stream sends or
+	// receives, that the stream is aborted there with Unavailable.
+	AbortRate float64
+	// Methods limits the faults to the methods starting with one of these
+	// prefixes, e.g. "/media.MediaService/". Empty means every method.
+	Methods []string
+}
+
+// New returns the faults configured in cfg.
+func New(cfg *env.Config) Faults {
+	return Faults{
+		Latency:         cfg.ChaosLatency,
+		UnavailableRate: cfg.ChaosUnavailableRate,
+		AbortRate:       cfg.ChaosAbortRate,
+		Methods:         cfg.ChaosMethods,
+	}
+}
+
+// Enabled reports whether f injects anything.
+func (f Faults) Enabled() bool {
+	return f.Latency > 0 || f.UnavailableRate > 0 || f.AbortRate > 0
+}
+
+func (f Faults) applies(method string) bool {
+	if len(f.Methods) == 0 {
+		return true
+	}
+	for _, p := range f.Methods {
+		if strings.HasPrefix(method, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// before delays the call and decides whether it fails outright.
+func (f Faults) before(ctx context.Context) error {
+	if f.Latency > 0 {
+		t := time.NewTimer(rand.N(f.Latency))
+		defer t.Stop()
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		}
+	}
+	if rand.Float64() < f.UnavailableRate {
+		return inject(ctx, "unavailable")
+	}
+	return nil
+}
+
+// inject marks the call's span and returns the injected error.
+func inject(ctx context.Context, kind string) error {
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("chaos", kind))
+	return status.Errorf(codes.Unavailable, "chaos: injected %s", kind)
+}
+
+// UnaryServerInterceptor delays and fails unary calls.
+func (f Faults) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if !f.applies(info.FullMethod) {
+		return handler(ctx, req)
+	}
+	if err := f.before(ctx); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamServerInterceptor delays and fails streaming calls, and aborts them
+// mid-transfer.
+func (f Faults) StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if !f.applies(info.FullMethod) {
+		return handler(srv, ss)
+	}
+	if err := f.before(ss.Context()); err != nil {
+		return err
+	}
+	if f.AbortRate <= 0 {
+		return handler(srv, ss)
+	}
+
+	ctx, cancel := context.WithCancel(ss.Context())
+	defer cancel()
+	as := &abortingStream{ServerStream: ss, ctx: ctx, cancel: cancel, rate: f.AbortRate}
+	err := handler(srv, as)
+	// Whatever the handler made of the abort, the client sees it as a
+	// dropped stream.
+	as.mu.Lock()
+	defer as.mu.Unlock()
+	if as.aborted != nil {
+		return as.aborted
+	}
+	return err
+}
+
+// abortingStream fails a message at the abort rate, and every message after
+// it. Its context is cancelled on abort, as when the connection drops.
+type abortingStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	cancel context.CancelFunc
+	rate   float64
+
+	mu      sync.Mutex // SendMsg and RecvMsg may run concurrently
+	aborted error
+}
+
+func (s *abortingStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *abortingStream) roll() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.aborted == nil && rand.Float64() < s.rate {
+		s.aborted = inject(s.ServerStream.Context(), "abort")
+		s.cancel()
+	}
+	return s.aborted
+}
+
+func (s *abortingStream) SendMsg(m interface{}) error {
+	if err := s.roll(); err != nil {
+		return err
+	}
+	return s.ServerStream.SendMsg(m)
+}
+
+func (s *abortingStream) RecvMsg(m interface{}) error {
+	if err := s.roll(); err != nil {
+		return err
+	}
+	return s.ServerStream.RecvMsg(m)
+}
@@ -0,0 +1,66 @@
+package chaos_test
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"coscup2025/chaos"
+	"coscup2025/client"
+	"coscup2025/env"
+	"coscup2025/servertest"
+	"coscup2025/synthmedia"
+)
+
+func TestFaultsOnlyHitListedMethods(t *testing.T) {
+	f := chaos.Faults{UnavailableRate: 1, Methods: []string{"/media."}}
+	handler := func(context.Context, interface{}) (interface{}, error) { return "ok", nil }
+
+	resp, err := f.UnaryServerInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/auth.AuthService/SignIn"}, handler)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+
+	_, err = f.UnaryServerInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/media.MediaService/GetVideoMetadata"}, handler)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+// TestClientSurvivesChaos checks that the client resumes uploads and
+// downloads through dropped calls and streams.
+func TestClientSurvivesChaos(t *testing.T) {
+	cfg := env.DefaultConfig()
+	cfg.ChaosLatency = time.Millisecond
+	cfg.ChaosUnavailableRate = 0.1
+	cfg.ChaosAbortRate = 0.1
+	cfg.ChaosMethods = []string{"/media."}
+	srv := servertest.New(t, cfg)
+	token := srv.CreateUser(t, "speaker", "secret")
+
+	c, err := client.New(servertest.Target,
+		client.WithDialOptions(srv.DialOption()),
+		client.WithToken(client.Token{AccessToken: token}),
+		client.WithRetryPolicy(client.RetryPolicy{MaxAttempts: 200, InitialBackoff: time.Millisecond}),
+	)
+	require.NoError(t, err)
+	defer c.Close()
+
+	const size = 4*synthmedia.BlockSize + 100
+	ctx := context.Background()
+	_, err = c.Upload(ctx, "talk", synthmedia.New(3, size), size, nil)
+	require.NoError(t, err)
+
+	pr, pw := io.Pipe()
+	defer pr.Close()
+	go func() {
+		_, err := c.Download(ctx, "talk", pw, nil)
+		pw.CloseWithError(err)
+	}()
+	_, err = synthmedia.Verify(pr)
+	require.NoError(t, err)
+}
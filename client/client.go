@@ -0,0 +1,242 @@
+// Package client is a Go client for the COSCUP 2025 auth and media services.
+// It signs in, keeps the access token fresh, and retries transient failures,
+// resuming interrupted uploads and downloads where they stopped.
+package client
+
+import (
+	"context"
+	"coscup2025/proto/auth"
+	"coscup2025/proto/media"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+// refreshMargin is how long before expiry the access token is refreshed.
+const refreshMargin = 5 * time.Minute
+
+// Token is an access token with the refresh token that renews it.
+type Token struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    time.Time
+}
+
+// Client talks to one server. It is safe for concurrent use.
+type Client struct {
+	conn  *grpc.ClientConn
+	auth  auth.AuthServiceClient
+	media media.MediaServiceClient
+	retry RetryPolicy
+
+	mu        sync.Mutex
+	token     Token
+	onRefresh func(Token)
+}
+
+type config struct {
+	creds       credentials.TransportCredentials
+	dialOptions []grpc.DialOption
+	retry       RetryPolicy
+	token       Token
+	onRefresh   func(Token)
+}
+
+// Option configures a Client.
+type Option func(*config)
+
+// WithTransportCredentials sets the transport security; without it the
+// connection is plaintext.
+func WithTransportCredentials(creds credentials.TransportCredentials) Option {
+	return func(c *config) { c.creds = creds }
+}
+
+// WithDialOptions adds grpc dial options, e.g. a custom dialer.
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(c *config) { c.dialOptions = append(c.dialOptions, opts...) }
+}
+
+// WithRetryPolicy replaces DefaultRetryPolicy.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *config) { c.retry = p }
+}
+
+// WithToken starts the client signed in, e.g. with a token saved earlier.
+// A bare JWT can be given as Token{AccessToken: jwt}.
+func WithToken(t Token) Option {
+	return func(c *config) { c.token = t }
+}
+
+// WithTokenRefreshHandler is called with every new token pair, so that it
+// can be persisted; refresh tokens are single use.
+func WithTokenRefreshHandler(f func(Token)) Option {
+	return func(c *config) { c.onRefresh = f }
+}
+
+// New creates a client for the gRPC server at target, e.g. "localhost:50051".
+func New(target string, opts ...Option) (*Client, error) {
+	cfg := &config{creds: insecure.NewCredentials(), retry: DefaultRetryPolicy}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	c := &Client{retry: cfg.retry, token: cfg.token, onRefresh: cfg.onRefresh}
+
+	dialOptions := append([]grpc.DialOption{
+		grpc.WithTransportCredentials(cfg.creds),
+		grpc.WithDefaultServiceConfig(cfg.retry.ServiceConfig()),
+		grpc.WithChainUnaryInterceptor(c.unaryInterceptor),
+		grpc.WithChainStreamInterceptor(c.streamInterceptor),
+	}, cfg.dialOptions...)
+
+	conn, err := grpc.NewClient(target, dialOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	c.conn = conn
+	c.auth = auth.NewAuthServiceClient(conn)
+	c.media = media.NewMediaServiceClient(conn)
+	return c, nil
+}
+
+// NewFromConn creates a client on a connection the caller dialled and
+// closes, e.g. one of a tool with its own dial options. Calls on conn are made
+// as is: the client neither signs them in nor refreshes tokens, so the caller
+// attaches its token to their contexts.
+func NewFromConn(conn grpc.ClientConnInterface, retry RetryPolicy) *Client {
+	return &Client{
+		retry: retry,
+		auth:  auth.NewAuthServiceClient(conn),
+		media: media.NewMediaServiceClient(conn),
+	}
+}
+
+// Close closes the connection, unless it belongs to the caller of
+// NewFromConn.
+func (c *Client) Close() error {
+	if c.conn == nil {
+		return nil
+	}
+	return c.conn.Close()
+}
+
+// Token returns the current token pair.
+func (c *Client) Token() Token {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.token
+}
+
+// SetToken replaces the token pair.
+func (c *Client) SetToken(t Token) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = t
+}
+
+// unauthenticatedMethods are called without a bearer token.
+var unauthenticatedMethods = map[string]bool{
+	"/auth.AuthService/SignUp":       true,
+	"/auth.AuthService/SignIn":       true,
+	"/auth.AuthService/RefreshToken": true,
+	"/auth.AuthService/GetTerms":     true,
+}
+
+func (c *Client) unaryInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	ctx, err := c.authorize(ctx, method)
+	if err != nil {
+		return err
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+func (c *Client) streamInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	ctx, err := c.authorize(ctx, method)
+	if err != nil {
+		return nil, err
+	}
+	return streamer(ctx, desc, cc, method, opts...)
+}
+
+// authorize attaches the access token, refreshing it first when it is about
+// to expire.
+func (c *Client) authorize(ctx context.Context, method string) (context.Context, error) {
+	if unauthenticatedMethods[method] {
+		return ctx, nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token.RefreshToken != "" && time.Until(c.token.ExpiresAt) < refreshMargin {
+		if err := c.refreshLocked(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if c.token.AccessToken == "" {
+		return ctx, nil
+	}
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+c.token.AccessToken), nil
+}
+
+// Refresh exchanges the refresh token for a new token pair.
+func (c *Client) Refresh(ctx context.Context) (Token, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.refreshLocked(ctx); err != nil {
+		return Token{}, err
+	}
+	return c.token, nil
+}
+
+// refreshLocked renews the token pair. The caller must hold c.mu.
+func (c *Client) refreshLocked(ctx context.Context) error {
+	resp, err := c.auth.RefreshToken(ctx, &auth.RefreshTokenRequest{RefreshToken: c.token.RefreshToken})
+	if err != nil {
+		return err
+	}
+	c.token = Token{
+		AccessToken:  resp.Token,
+		RefreshToken: resp.RefreshToken,
+		ExpiresAt:    time.Unix(resp.ExpiresAt, 0),
+	}
+	if c.onRefresh != nil {
+		c.onRefresh(c.token)
+	}
+	return nil
+}
+
+// SignUp creates an account and returns its user ID.
+func (c *Client) SignUp(ctx context.Context, username, password string) (string, error) {
+	resp, err := c.auth.SignUp(ctx, &auth.SignUpRequest{Username: username, Password: password})
+	if err != nil {
+		return "", err
+	}
+	return resp.UserId, nil
+}
+
+// Login signs in and uses the returned token for later calls.
+func (c *Client) Login(ctx context.Context, username, password string) (Token, error) {
+	resp, err := c.auth.SignIn(ctx, &auth.SignInRequest{Username: username, Password: password})
+	if err != nil {
+		return Token{}, err
+	}
+
+	t := Token{
+		AccessToken:  resp.Token,
+		RefreshToken: resp.RefreshToken,
+		ExpiresAt:    time.Unix(resp.ExpiresAt, 0),
+	}
+	c.SetToken(t)
+	return t, nil
+}
+
+// Profile returns the signed-in user.
+func (c *Client) Profile(ctx context.Context) (*auth.GetUserProfileResponse, error) {
+	return c.auth.GetUserProfile(ctx, &auth.GetUserProfileRequest{})
+}
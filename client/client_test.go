@@ -0,0 +1,232 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"coscup2025/client"
+	"coscup2025/env"
+	"coscup2025/proto/media"
+	"coscup2025/servertest"
+)
+
+func setupClient(t *testing.T) *client.Client {
+	srv := servertest.New(t, nil)
+	c, err := client.New(servertest.Target, client.WithDialOptions(srv.DialOption()))
+	require.NoError(t, err)
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func TestUploadAndDownload(t *testing.T) {
+	c := setupClient(t)
+	ctx := context.Background()
+
+	_, err := c.SignUp(ctx, "testuser", "testpass")
+	require.NoError(t, err)
+	_, err = c.Login(ctx, "testuser", "testpass")
+	require.NoError(t, err)
+
+	// A reader without Seek goes through the replay buffer.
+	video := bytes.Repeat([]byte("coscup"), 500000)
+	resp, err := c.Upload(ctx, "talk", io.MultiReader(bytes.NewReader(video)), int64(len(video)), &client.UploadOptions{Tags: []string{"room-a"}})
+	require.NoError(t, err)
+	require.Equal(t, int64(len(video)), resp.TotalBytes)
+
+	videos, err := c.List(ctx, client.ListOptions{Tag: "room-a"})
+	require.NoError(t, err)
+	require.Len(t, videos, 1)
+
+	var out bytes.Buffer
+	md, err := c.Download(ctx, "talk", &out, nil)
+	require.NoError(t, err)
+	require.Equal(t, video, out.Bytes())
+	require.Equal(t, "testuser", md.UploaderName)
+
+	require.NoError(t, c.Delete(ctx, "talk"))
+	_, err = c.Metadata(ctx, "talk")
+	require.Error(t, err)
+}
+
+func TestRefreshBeforeExpiry(t *testing.T) {
+	c := setupClient(t)
+	ctx := context.Background()
+
+	_, err := c.SignUp(ctx, "testuser", "testpass")
+	require.NoError(t, err)
+	token, err := c.Login(ctx, "testuser", "testpass")
+	require.NoError(t, err)
+
+	// Pretend the token is about to expire; the next call renews it.
+	token.ExpiresAt = time.Now()
+	c.SetToken(token)
+
+	profile, err := c.Profile(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "testuser", profile.Username)
+	require.NotEqual(t, token.RefreshToken, c.Token().RefreshToken)
+}
+
+func TestFailedUploadAbortsSession(t *testing.T) {
+	cfg := env.DefaultConfig()
+	cfg.MemoryBudget = 4 << 20
+	srv := servertest.New(t, cfg)
+	token := srv.CreateUser(t, "speaker", "secret")
+	c, err := client.New(servertest.Target, client.WithDialOptions(srv.DialOption()), client.WithToken(client.Token{AccessToken: token}))
+	require.NoError(t, err)
+	defer c.Close()
+
+	// The reader runs dry halfway, so the upload fails for good.
+	_, err = c.Upload(context.Background(), "talk", bytes.NewReader(make([]byte, 2<<20)), 3<<20, nil)
+	require.Error(t, err)
+
+	// Its session no longer holds 3 MiB of the budget.
+	_, err = c.Upload(context.Background(), "other", bytes.NewReader(make([]byte, 3<<20)), 3<<20, nil)
+	require.NoError(t, err)
+}
+
+func TestUploadFromPipe(t *testing.T) {
+	srv := servertest.New(t, nil)
+	token := srv.CreateUser(t, "speaker", "secret")
+	c, err := client.New(servertest.Target, client.WithDialOptions(srv.DialOption()), client.WithToken(client.Token{AccessToken: token}))
+	require.NoError(t, err)
+	defer c.Close()
+
+	// An *os.File has a Seek method, which fails on a pipe such as stdin.
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	video := bytes.Repeat([]byte("coscup"), 300000)
+	go func() {
+		w.Write(video)
+		w.Close()
+	}()
+	resp, err := c.Upload(context.Background(), "talk", r, int64(len(video)), nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(len(video)), resp.TotalBytes)
+}
+
+// cutUpload fails the first upload stream of a client with UNAVAILABLE once
+// the server has every byte: before the video is stored, or after, with the
+// response lost.
+type cutUpload struct {
+	stored bool
+	cut    atomic.Bool
+}
+
+func (c *cutUpload) intercept(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	if method != "/media.MediaService/UploadVideo" || !c.cut.CompareAndSwap(false, true) {
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	s, err := streamer(ctx, desc, cc, method, opts...)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return &cutStream{ClientStream: s, ctx: ctx, cancel: cancel, cc: cc, stored: c.stored}, nil
+}
+
+type cutStream struct {
+	grpc.ClientStream
+	ctx      context.Context
+	cancel   context.CancelFunc
+	cc       *grpc.ClientConn
+	stored   bool
+	uploadID string
+}
+
+func (s *cutStream) SendMsg(m any) error {
+	s.uploadID = m.(*media.UploadVideoRequest).UploadId
+	return s.ClientStream.SendMsg(m)
+}
+
+func (s *cutStream) CloseSend() error {
+	if s.stored {
+		return s.ClientStream.CloseSend()
+	}
+	// Cut the stream once the server committed its last chunk.
+	for {
+		resp, err := media.NewMediaServiceClient(s.cc).GetUploadSession(s.ctx, &media.GetUploadSessionRequest{UploadId: s.uploadID})
+		if err != nil {
+			return err
+		}
+		if resp.Session.CommittedBytes == resp.Session.TotalSize {
+			s.cancel()
+			return nil
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func (s *cutStream) RecvMsg(m any) error {
+	defer s.cancel()
+	if s.stored {
+		if err := s.ClientStream.RecvMsg(m); err != nil {
+			return err
+		}
+	}
+	return status.Error(codes.Unavailable, "stream cut")
+}
+
+func TestUploadSurvivesCutAfterLastChunk(t *testing.T) {
+	for name, stored := range map[string]bool{
+		"before the video is stored": false,
+		"after the video is stored":  true,
+	} {
+		t.Run(name, func(t *testing.T) {
+			srv := servertest.New(t, nil)
+			token := srv.CreateUser(t, "speaker", "secret")
+			cut := &cutUpload{stored: stored}
+			c, err := client.New(servertest.Target,
+				client.WithDialOptions(srv.DialOption(), grpc.WithChainStreamInterceptor(cut.intercept)),
+				client.WithToken(client.Token{AccessToken: token}),
+				client.WithRetryPolicy(client.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond}),
+			)
+			require.NoError(t, err)
+			defer c.Close()
+
+			video := bytes.Repeat([]byte("coscup"), 300000)
+			resp, err := c.Upload(context.Background(), "talk", bytes.NewReader(video), int64(len(video)), nil)
+			require.NoError(t, err)
+			require.True(t, cut.cut.Load())
+			require.Equal(t, int64(len(video)), resp.TotalBytes)
+
+			var out bytes.Buffer
+			_, err = c.Download(context.Background(), "talk", &out, nil)
+			require.NoError(t, err)
+			require.Equal(t, video, out.Bytes())
+		})
+	}
+}
+
+func TestServiceConfigRetriesOnlyIdempotentCalls(t *testing.T) {
+	var cfg struct {
+		MethodConfig []struct {
+			Name        []struct{ Service, Method string }
+			RetryPolicy json.RawMessage
+		}
+	}
+	require.NoError(t, json.Unmarshal([]byte(client.DefaultRetryPolicy.ServiceConfig()), &cfg))
+
+	retried := map[string]bool{}
+	for _, mc := range cfg.MethodConfig {
+		for _, name := range mc.Name {
+			retried[name.Service+"/"+name.Method] = mc.RetryPolicy != nil
+		}
+	}
+	// grpc-go applies the config of a method before that of its service.
+	require.True(t, retried["auth.AuthService/"])
+	require.False(t, retried["auth.AuthService/RefreshToken"])
+	require.False(t, retried["media.MediaService/UploadVideo"])
+}
@@ -0,0 +1,50 @@
+package client
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// HeartbeatInterval is how often an upload waiting for input tells the
+// server it is alive, well within the server's default stall timeout of two
+// minutes.
+const HeartbeatInterval = 30 * time.Second
+
+type readResult struct {
+	n   int
+	err error
+}
+
+// ReadFullWithHeartbeat is io.ReadFull, calling heartbeat every
+// HeartbeatInterval while the read blocks, so that a stream waiting on slow
+// input, such as a pipe from an encoder, is not aborted as stalled. After a
+// failed heartbeat it still waits for the read, so that r is not read
+// concurrently by a retry, and returns the heartbeat's error. It returns as
+// soon as ctx is done, leaving the read behind.
+func ReadFullWithHeartbeat(ctx context.Context, r io.Reader, buf []byte, heartbeat func() error) (int, error) {
+	done := make(chan readResult, 1)
+	go func() {
+		n, err := io.ReadFull(r, buf)
+		done <- readResult{n, err}
+	}()
+
+	ticker := time.NewTicker(HeartbeatInterval)
+	defer ticker.Stop()
+	var heartbeatErr error
+	for {
+		select {
+		case res := <-done:
+			if heartbeatErr != nil {
+				return res.n, heartbeatErr
+			}
+			return res.n, res.err
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-ticker.C:
+			if heartbeatErr == nil {
+				heartbeatErr = heartbeat()
+			}
+		}
+	}
+}
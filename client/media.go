@@ -0,0 +1,419 @@
+package client
+
+import (
+	"context"
+	"coscup2025/proto/media"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ChunkSize is the size of the chunks sent by Upload.
+const ChunkSize = 1024 * 1024
+
+var (
+	// ErrChecksumMismatch is returned by Download when the received bytes do
+	// not match the SHA-256 reported by the server.
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+	// ErrSizeMismatch is returned by Download when the number of received
+	// bytes differs from the size reported by the server.
+	ErrSizeMismatch = errors.New("size mismatch")
+)
+
+// ListOptions filters List. Zero values match every video.
+type ListOptions struct {
+	Uploader string // user ID or name
+	Since    time.Time
+	Tag      string
+}
+
+// List returns the stored videos sorted by ID, following every page.
+func (c *Client) List(ctx context.Context, opts ListOptions) ([]*media.VideoSummary, error) {
+	req := &media.ListVideosRequest{Uploader: opts.Uploader, Tag: opts.Tag}
+	if !opts.Since.IsZero() {
+		req.Since = opts.Since.Unix()
+	}
+
+	var videos []*media.VideoSummary
+	for {
+		resp, err := c.media.ListVideos(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		videos = append(videos, resp.Videos...)
+		if resp.NextPageToken == "" {
+			return videos, nil
+		}
+		req.PageToken = resp.NextPageToken
+	}
+}
+
+// Metadata returns the metadata of a video.
+func (c *Client) Metadata(ctx context.Context, videoID string) (*media.VideoMetadata, error) {
+	resp, err := c.media.GetVideoMetadata(ctx, &media.GetVideoMetadataRequest{VideoId: videoID})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Metadata, nil
+}
+
+// Delete removes a video uploaded by the signed-in user.
+func (c *Client) Delete(ctx context.Context, videoID string) error {
+	_, err := c.media.DeleteVideo(ctx, &media.DeleteVideoRequest{VideoId: videoID})
+	return err
+}
+
+// UploadOptions are optional settings for Upload.
+type UploadOptions struct {
+	Tags []string
+	// Overwrite replaces a video of the caller already stored as videoID,
+	// which otherwise fails the upload unless the server keeps versions.
+	Overwrite bool
+	// FileName is the name downloads of the video are saved under, e.g.
+	// that of the uploaded file. It defaults to videoID.
+	FileName string
+	// StreamDeadline, if set, asks the server for the deadline of every
+	// stream of the upload, e.g. one fit for the size of a large file. The
+	// server grants at most its limit, and each stream then runs under the
+	// granted deadline rather than only under that of ctx.
+	StreamDeadline time.Duration
+	// AttemptTimeout, if set, bounds each stream of the upload on the
+	// client side, whatever deadline the server granted.
+	AttemptTimeout time.Duration
+	// Wait, if set, is called before each chunk of n bytes is sent, e.g. to
+	// cap the bandwidth of several uploads.
+	Wait func(ctx context.Context, n int) error
+	// Progress, if set, is called with the number of bytes the server has
+	// so far.
+	Progress func(sent int64)
+	// Retrying, if set, is called before each retry of a transient failure
+	// with the attempt about to start and the delay before it.
+	Retrying func(err error, attempt int, delay time.Duration)
+}
+
+// Upload stores exactly size bytes from r as videoID. It uses a resumable
+// upload session, so transient failures resume from the bytes the server
+// committed. Readers that cannot seek are replayed from the last
+// DefaultReplayWindow bytes. When the upload fails for good, including when
+// ctx is cancelled, the session is aborted so the server frees its bytes.
+func (c *Client) Upload(ctx context.Context, videoID string, r io.Reader, size int64, opts *UploadOptions) (_ *media.UploadVideoResponse, err error) {
+	if opts == nil {
+		opts = &UploadOptions{}
+	}
+
+	// Pipes such as os.Stdin have a Seek method that always fails.
+	src, ok := r.(io.ReadSeeker)
+	if ok {
+		_, err := src.Seek(0, io.SeekCurrent)
+		ok = err == nil
+	}
+	if !ok {
+		src = NewReplayReader(io.LimitReader(r, size), DefaultReplayWindow)
+	}
+
+	created, err := c.media.CreateUploadSession(ctx, &media.CreateUploadSessionRequest{
+		VideoId:               videoID,
+		TotalSize:             size,
+		Tags:                  opts.Tags,
+		Overwrite:             opts.Overwrite,
+		FileName:              opts.FileName,
+		StreamDeadlineSeconds: int64(opts.StreamDeadline.Seconds()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create upload session: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			c.abortUpload(ctx, created.Session.UploadId)
+		}
+	}()
+	return c.Resume(ctx, created.Session, src, opts)
+}
+
+// Resume sends src to an upload session created earlier, e.g. by a run that
+// saved its ID, starting at the bytes the server committed. It retries like
+// Upload, but leaves the session on the server when it fails for good, so
+// that it can be resumed again. The options that create a session are
+// ignored.
+func (c *Client) Resume(ctx context.Context, session *media.UploadSession, src io.ReadSeeker, opts *UploadOptions) (*media.UploadVideoResponse, error) {
+	if opts == nil {
+		opts = &UploadOptions{}
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.sendFrom(ctx, session, src, opts)
+		if err == nil {
+			return resp, nil
+		}
+		if attempt >= c.retry.MaxAttempts || !IsTransient(err) || ctx.Err() != nil {
+			return nil, err
+		}
+		delay := c.retry.Backoff(attempt)
+		if opts.Retrying != nil {
+			opts.Retrying(err, attempt+1, delay)
+		}
+		if err := SleepContext(ctx, delay); err != nil {
+			return nil, err
+		}
+
+		got, err := c.media.GetUploadSession(ctx, &media.GetUploadSessionRequest{UploadId: session.UploadId})
+		if status.Code(err) == codes.NotFound {
+			return c.storedUpload(ctx, session, fmt.Errorf("failed to query upload session: %w", err))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to query upload session: %w", err)
+		}
+		session = got.Session
+		if opts.Progress != nil {
+			opts.Progress(session.CommittedBytes)
+		}
+	}
+}
+
+// storedUpload handles a session that vanished after a failed attempt. The
+// server drops a session once its video is stored, so the attempt may have
+// completed with only its response lost. If the video is there with the
+// session's size, storedUpload returns it, otherwise err.
+func (c *Client) storedUpload(ctx context.Context, session *media.UploadSession, err error) (*media.UploadVideoResponse, error) {
+	md, mdErr := c.Metadata(ctx, session.VideoId)
+	if mdErr != nil || md.FileSize != session.TotalSize {
+		return nil, err
+	}
+	return &media.UploadVideoResponse{VideoId: session.VideoId, TotalBytes: md.FileSize, Metadata: md}, nil
+}
+
+// abortUpload frees a session nothing will resume, on a best-effort basis:
+// the server drops it after a day anyway.
+func (c *Client) abortUpload(ctx context.Context, uploadID string) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+	c.media.AbortUpload(ctx, &media.AbortUploadRequest{UploadId: uploadID})
+}
+
+// sendFrom streams src to the session starting at its committed offset,
+// within the stream deadline the server granted and opts.AttemptTimeout, if
+// any. A stream that runs out of them fails with DeadlineExceeded, so Resume
+// continues on a new one.
+func (c *Client) sendFrom(ctx context.Context, session *media.UploadSession, src io.ReadSeeker, opts *UploadOptions) (*media.UploadVideoResponse, error) {
+	if session.StreamDeadlineSeconds > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(session.StreamDeadlineSeconds)*time.Second)
+		defer cancel()
+	}
+	if opts.AttemptTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.AttemptTimeout)
+		defer cancel()
+	}
+	offset := session.CommittedBytes
+	if _, err := src.Seek(offset, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("failed to seek input: %w", err)
+	}
+
+	stream, err := c.media.UploadVideo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create upload stream: %w", err)
+	}
+
+	buffer := make([]byte, ChunkSize)
+	sequence := offset/ChunkSize + 1
+
+	for sent := false; ; sent = true {
+		n, err := ReadFullWithHeartbeat(ctx, src, buffer, func() error {
+			return stream.Send(&media.UploadVideoRequest{VideoId: session.VideoId, UploadId: session.UploadId, Offset: offset, Heartbeat: true})
+		})
+		// When the session already has every byte, an empty chunk still
+		// names it, so that the server stores the video.
+		if err == io.EOF && sent {
+			break
+		}
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return nil, fmt.Errorf("failed to read input: %w", err)
+		}
+		if opts.Wait != nil {
+			if err := opts.Wait(ctx, n); err != nil {
+				return nil, err
+			}
+		}
+
+		err = stream.Send(&media.UploadVideoRequest{
+			VideoId:  session.VideoId,
+			Data:     buffer[:n],
+			Sequence: sequence,
+			UploadId: session.UploadId,
+			Offset:   offset,
+		})
+		if errors.Is(err, io.EOF) {
+			// The server ended the stream; its status comes from CloseAndRecv.
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to send chunk %d: %w", sequence, err)
+		}
+
+		offset += int64(n)
+		sequence++
+		if opts.Progress != nil {
+			opts.Progress(offset)
+		}
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return nil, fmt.Errorf("failed to close stream: %w", err)
+	}
+	return resp, nil
+}
+
+// DownloadOptions are optional settings for Download.
+type DownloadOptions struct {
+	// Offset skips the first Offset bytes of the video, e.g. those of a
+	// partial file that w appends to.
+	Offset int64
+	// Hash, if set, is the SHA-256 hash the video is checked against instead
+	// of a new one, and must already hold the Offset bytes skipped. Without
+	// it, a download resumed at Offset is only checked for its size.
+	Hash hash.Hash
+	// Priority is the share of the server's bandwidth the download asks
+	// for; batch downloads yield to interactive ones.
+	Priority media.DownloadPriority
+	// AttemptTimeout, if set, bounds each stream of the download.
+	AttemptTimeout time.Duration
+	// Wait, if set, is called before each chunk of n bytes is written, e.g.
+	// to cap the bandwidth of several downloads.
+	Wait func(ctx context.Context, n int) error
+	// Progress, if set, is called with the number of bytes written so far,
+	// including Offset.
+	Progress func(written int64)
+	// Metadata, if set, is called with the metadata of the video once the
+	// server sends it, before its bytes are written.
+	Metadata func(*media.VideoMetadata)
+	// Stats, if set, is called with the transfer statistics the server
+	// reports for a stream.
+	Stats func(*media.TransferStats)
+	// Retrying, if set, is called before each retry of a transient failure
+	// with the attempt about to start and the delay before it.
+	Retrying func(err error, attempt int, delay time.Duration)
+}
+
+// Download writes the video to w and returns its metadata. Transient
+// failures restart the stream after the bytes already written. When the
+// server reports a size or SHA-256, the written bytes are checked against
+// them and ErrSizeMismatch or ErrChecksumMismatch is returned, with the
+// metadata, on a difference.
+func (c *Client) Download(ctx context.Context, videoID string, w io.Writer, opts *DownloadOptions) (*media.VideoMetadata, error) {
+	if opts == nil {
+		opts = &DownloadOptions{}
+	}
+	d := &download{client: c, videoID: videoID, w: w, opts: opts, hasher: opts.Hash, written: opts.Offset}
+	if d.hasher == nil && opts.Offset == 0 {
+		d.hasher = sha256.New()
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := d.receive(ctx)
+		if err == nil {
+			break
+		}
+		if attempt >= c.retry.MaxAttempts || !IsTransient(err) || ctx.Err() != nil {
+			return nil, err
+		}
+		delay := c.retry.Backoff(attempt)
+		if opts.Retrying != nil {
+			opts.Retrying(err, attempt+1, delay)
+		}
+		if err := SleepContext(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+
+	if md := d.metadata; md != nil {
+		if md.FileSize > 0 && d.written != md.FileSize {
+			return md, fmt.Errorf("%w: got %d bytes, expected %d", ErrSizeMismatch, d.written, md.FileSize)
+		}
+		if md.Sha256 != "" && d.hasher != nil {
+			if sum := fmt.Sprintf("%x", d.hasher.Sum(nil)); sum != md.Sha256 {
+				return md, fmt.Errorf("%w: got sha256 %s, expected %s", ErrChecksumMismatch, sum, md.Sha256)
+			}
+		}
+	}
+	return d.metadata, nil
+}
+
+// download tracks a Download across retries.
+type download struct {
+	client   *Client
+	videoID  string
+	w        io.Writer
+	opts     *DownloadOptions
+	hasher   hash.Hash // nil when the skipped bytes were not hashed
+	written  int64
+	metadata *media.VideoMetadata
+}
+
+// receive opens one download stream and writes everything past the bytes
+// already written.
+func (d *download) receive(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if d.opts.AttemptTimeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, d.opts.AttemptTimeout)
+		defer cancel()
+	}
+
+	stream, err := d.client.media.DownloadVideo(ctx, &media.DownloadVideoRequest{
+		VideoId:  d.videoID,
+		Offset:   d.written,
+		Priority: d.opts.Priority,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create download stream: %w", err)
+	}
+
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to receive chunk: %w", err)
+		}
+
+		if chunk.Metadata != nil && d.metadata == nil {
+			d.metadata = chunk.Metadata
+			if d.opts.Metadata != nil {
+				d.opts.Metadata(d.metadata)
+			}
+		}
+		if chunk.Stats != nil && d.opts.Stats != nil {
+			d.opts.Stats(chunk.Stats)
+		}
+
+		if len(chunk.Data) == 0 {
+			continue
+		}
+		if d.opts.Wait != nil {
+			if err := d.opts.Wait(ctx, len(chunk.Data)); err != nil {
+				return err
+			}
+		}
+
+		n, err := d.w.Write(chunk.Data)
+		if err != nil {
+			return fmt.Errorf("failed to write: %w", err)
+		}
+		if d.hasher != nil {
+			d.hasher.Write(chunk.Data[:n])
+		}
+		d.written += int64(n)
+		if d.opts.Progress != nil {
+			d.opts.Progress(d.written)
+		}
+	}
+}
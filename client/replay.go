@@ -0,0 +1,56 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// DefaultReplayWindow is how much already-sent data Upload keeps for
+// readers that cannot seek, so that a retried stream can resend what the
+// server did not commit.
+const DefaultReplayWindow = 16 * 1024 * 1024
+
+// replayReader makes a non-seekable stream seekable within the most recent
+// window of bytes read from it.
+type replayReader struct {
+	r      io.Reader
+	window int
+	buf    []byte
+	base   int64 // stream offset of buf[0]
+	pos    int64
+}
+
+// NewReplayReader wraps r so that it can seek back up to window bytes, which
+// is what resuming an interrupted upload from a pipe needs.
+func NewReplayReader(r io.Reader, window int) io.ReadSeeker {
+	return &replayReader{r: r, window: window}
+}
+
+func (r *replayReader) Read(p []byte) (int, error) {
+	if end := r.base + int64(len(r.buf)); r.pos < end {
+		n := copy(p, r.buf[r.pos-r.base:])
+		r.pos += int64(n)
+		return n, nil
+	}
+
+	n, err := r.r.Read(p)
+	r.buf = append(r.buf, p[:n]...)
+	r.pos += int64(n)
+	if drop := len(r.buf) - r.window; drop > 0 {
+		r.buf = r.buf[drop:]
+		r.base += int64(drop)
+	}
+	return n, err
+}
+
+func (r *replayReader) Seek(offset int64, whence int) (int64, error) {
+	if whence != io.SeekStart {
+		return 0, errors.New("replayReader only supports io.SeekStart")
+	}
+	if offset < r.base || offset > r.base+int64(len(r.buf)) {
+		return 0, fmt.Errorf("cannot resume at byte %d, only bytes %d to %d are buffered", offset, r.base, r.base+int64(len(r.buf)))
+	}
+	r.pos = offset
+	return offset, nil
+}
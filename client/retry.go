@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxBackoff caps the delay between two attempts.
+const maxBackoff = 30 * time.Second
+
+// RetryPolicy controls how transfers and unary calls are retried after
+// transient failures.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+}
+
+// DefaultRetryPolicy makes five attempts starting one second apart.
+var DefaultRetryPolicy = RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Second}
+
+// Backoff returns the jittered delay before the given retry (1 for the first
+// retry), doubling each time up to 30 seconds.
+func (p RetryPolicy) Backoff(retry int) time.Duration {
+	d := p.InitialBackoff << (retry - 1)
+	if d <= 0 || d > maxBackoff {
+		d = maxBackoff
+	}
+	// Equal jitter keeps at least half the delay while spreading out clients
+	// that failed at the same moment.
+	return d/2 + rand.N(d/2+1)
+}
+
+// ServiceConfig lets grpc-go retry unary calls that failed with UNAVAILABLE.
+// grpc-go caps maxAttempts at 5 regardless of the configured value. Streams
+// are left out: the client resumes them itself, and a stream replayed by
+// grpc-go behind its back can commit bytes after the client moved on. So is
+// RefreshToken: the server consumes a refresh token even when its response is
+// lost, and a replay with the spent token could only fail.
+func (p RetryPolicy) ServiceConfig() string {
+	return fmt.Sprintf(`{
+  "methodConfig": [{
+    "name": [{"service": "auth.AuthService"}, {"service": "media.MediaService"}],
+    "retryPolicy": {
+      "maxAttempts": %d,
+      "initialBackoff": "%.3fs",
+      "maxBackoff": "%.3fs",
+      "backoffMultiplier": 2,
+      "retryableStatusCodes": ["UNAVAILABLE"]
+    }
+  }, {
+    "name": [
+      {"service": "media.MediaService", "method": "UploadVideo"},
+      {"service": "media.MediaService", "method": "DownloadVideo"},
+      {"service": "media.MediaService", "method": "WatchProgress"},
+      {"service": "auth.AuthService", "method": "RefreshToken"}
+    ]
+  }]
+}`, max(p.MaxAttempts, 2), p.InitialBackoff.Seconds(), maxBackoff.Seconds())
+}
+
+// IsTransient reports whether a failed stream is worth retrying.
+func IsTransient(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.Aborted, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
+
+// SleepContext waits for d unless ctx is done first.
+func SleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,44 @@
+// Command auditverify checks the HMAC chain of audit log files.
+//
+//	COSCUP_AUDIT_HMAC_KEY=... auditverify audit.log.20250809T010000.000000000Z audit.log
+//
+// Files must be given oldest first; rotated files sort that way by name.
+// Every file must end with a seal, except the last one while the server is
+// still writing it: cutting lines off the end of a file removes its seal.
+package main
+
+import (
+	"coscup2025/audit"
+	"errors"
+	"fmt"
+	"os"
+)
+
+func main() {
+	key := os.Getenv("COSCUP_AUDIT_HMAC_KEY")
+	if key == "" || len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, "usage: COSCUP_AUDIT_HMAC_KEY=<key> auditverify <file>...")
+		os.Exit(2)
+	}
+
+	var prev []byte
+	files := os.Args[1:]
+	for i, path := range files {
+		f, err := os.Open(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+			os.Exit(1)
+		}
+		prev, err = audit.Verify(f, []byte(key), prev)
+		f.Close()
+		if errors.Is(err, audit.ErrUnsealed) && i == len(files)-1 {
+			fmt.Printf("%s: ok, but not sealed: the server is still writing it, stopped without closing it, or lines were cut off its end\n", path)
+			continue
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+			os.Exit(1)
+		}
+		fmt.Printf("%s: ok\n", path)
+	}
+}
@@ -0,0 +1,268 @@
+package main
+
+import (
+	"bufio"
+	"coscup2025/proto/auth"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+func newSignUpCmd(opts *options) *cobra.Command {
+	var username, password, terms string
+
+	cmd := &cobra.Command{
+		Use:   "signup",
+		Short: "Create a new user account",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			conn, err := opts.dial()
+			if err != nil {
+				return fmt.Errorf("failed to connect to server: %v", err)
+			}
+			defer conn.Close()
+
+			ctx, cancel := opts.callContext(cmd.Context())
+			defer cancel()
+
+			resp, err := auth.NewAuthServiceClient(conn).SignUp(ctx, &auth.SignUpRequest{
+				Username:             username,
+				Password:             password,
+				AcceptedTermsVersion: terms,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to sign up: %v", err)
+			}
+
+			if opts.json {
+				return writeJSON(map[string]string{"user_id": resp.UserId, "username": username})
+			}
+			fmt.Printf("Created user %s (%s)\n", username, resp.UserId)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&username, "username", "", "account username")
+	cmd.Flags().StringVar(&password, "password", "", "account password")
+	cmd.Flags().StringVar(&terms, "accept-terms", "", "version of the terms of service you accept, see the terms command")
+	cmd.MarkFlagRequired("username")
+	cmd.MarkFlagRequired("password")
+
+	return cmd
+}
+
+func newLoginCmd(opts *options) *cobra.Command {
+	var username, password string
+	var printToken bool
+
+	cmd := &cobra.Command{
+		Use:   "login",
+		Short: "Sign in and cache the tokens for later commands, prompting for missing credentials",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := promptCredentials(&username, &password); err != nil {
+				return err
+			}
+
+			conn, err := opts.dial()
+			if err != nil {
+				return fmt.Errorf("failed to connect to server: %v", err)
+			}
+			defer conn.Close()
+
+			ctx, cancel := opts.callContext(cmd.Context())
+			defer cancel()
+
+			resp, err := auth.NewAuthServiceClient(conn).SignIn(ctx, &auth.SignInRequest{
+				Username: username,
+				Password: password,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to sign in: %v", err)
+			}
+
+			if printToken {
+				if opts.json {
+					return writeJSON(map[string]any{
+						"token":         resp.Token,
+						"refresh_token": resp.RefreshToken,
+						"expires_at":    time.Unix(resp.ExpiresAt, 0).UTC(),
+					})
+				}
+				fmt.Println(resp.Token)
+				return nil
+			}
+
+			creds := &credentials{
+				Username:     username,
+				Token:        resp.Token,
+				RefreshToken: resp.RefreshToken,
+				ExpiresAt:    time.Unix(resp.ExpiresAt, 0),
+			}
+			if err := saveCredentials(opts.server, creds); err != nil {
+				return fmt.Errorf("failed to cache credentials: %v", err)
+			}
+
+			if opts.json {
+				return writeJSON(map[string]any{"server": opts.server, "username": username, "expires_at": creds.ExpiresAt.UTC()})
+			}
+			fmt.Printf("Logged in to %s as %s\n", opts.server, username)
+			fmt.Printf("Token expires at %s and is refreshed automatically\n", creds.ExpiresAt.Local().Format("2006-01-02 15:04:05"))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&username, "username", "", "account username (prompted for when omitted)")
+	cmd.Flags().StringVar(&password, "password", "", "account password (prompted for without echo when omitted)")
+	cmd.Flags().BoolVar(&printToken, "print-token", false, "print the JWT instead of caching it")
+
+	return cmd
+}
+
+// promptCredentials asks on the terminal for whichever of username and
+// password is empty. The password is read without echo.
+func promptCredentials(username, password *string) error {
+	if *username != "" && *password != "" {
+		return nil
+	}
+	if !isTerminal(os.Stdin) {
+		return errors.New("--username and --password are required when not running in a terminal")
+	}
+
+	if *username == "" {
+		fmt.Fprint(os.Stderr, "Username: ")
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("failed to read username: %v", err)
+		}
+		*username = strings.TrimSpace(line)
+	}
+	if *password == "" {
+		fmt.Fprint(os.Stderr, "Password: ")
+		secret, err := term.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return fmt.Errorf("failed to read password: %v", err)
+		}
+		*password = string(secret)
+	}
+
+	if *username == "" || *password == "" {
+		return errors.New("username and password must not be empty")
+	}
+	return nil
+}
+
+func newLogoutCmd(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "logout",
+		Short: "Forget the cached tokens for the server",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := deleteCredentials(opts.server); err != nil {
+				return fmt.Errorf("failed to remove credentials: %v", err)
+			}
+			if opts.json {
+				return writeJSON(map[string]string{"server": opts.server})
+			}
+			fmt.Printf("Logged out of %s\n", opts.server)
+			return nil
+		},
+	}
+}
+
+func newProfileCmd(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "profile",
+		Short: "Show the profile of the authenticated user",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, err := opts.authContext(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			conn, err := opts.dial()
+			if err != nil {
+				return fmt.Errorf("failed to connect to server: %v", err)
+			}
+			defer conn.Close()
+
+			ctx, cancel := opts.callContext(ctx)
+			defer cancel()
+
+			resp, err := auth.NewAuthServiceClient(conn).GetUserProfile(ctx, &auth.GetUserProfileRequest{})
+			if err != nil {
+				return fmt.Errorf("failed to get profile: %v", err)
+			}
+
+			if opts.json {
+				return writeJSON(map[string]string{"user_id": resp.UserId, "username": resp.Username})
+			}
+			fmt.Printf("User ID: %s\n", resp.UserId)
+			fmt.Printf("Username: %s\n", resp.Username)
+			return nil
+		},
+	}
+}
+
+func newTermsCmd(opts *options) *cobra.Command {
+	var accept bool
+
+	cmd := &cobra.Command{
+		Use:   "terms",
+		Short: "Show the current terms of service, or accept them with --accept",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			conn, err := opts.dial()
+			if err != nil {
+				return fmt.Errorf("failed to connect to server: %v", err)
+			}
+			defer conn.Close()
+			client := auth.NewAuthServiceClient(conn)
+
+			ctx, cancel := opts.callContext(cmd.Context())
+			defer cancel()
+
+			terms, err := client.GetTerms(ctx, &auth.GetTermsRequest{})
+			if err != nil {
+				return fmt.Errorf("failed to get terms: %v", err)
+			}
+			if !accept {
+				if opts.json {
+					return writeJSON(map[string]string{"version": terms.Version, "url": terms.Url})
+				}
+				if terms.Version == "" {
+					fmt.Println("The server has no terms of service")
+					return nil
+				}
+				fmt.Printf("Version: %s\n", terms.Version)
+				fmt.Printf("URL: %s\n", terms.Url)
+				return nil
+			}
+
+			authCtx, err := opts.authContext(ctx)
+			if err != nil {
+				return err
+			}
+			resp, err := client.AcceptTerms(authCtx, &auth.AcceptTermsRequest{Version: terms.Version})
+			if err != nil {
+				return fmt.Errorf("failed to accept terms: %v", err)
+			}
+			if opts.json {
+				return writeJSON(map[string]any{"version": resp.Version, "accepted_at": time.Unix(resp.AcceptedAt, 0).UTC()})
+			}
+			fmt.Printf("Accepted the terms of service version %s\n", resp.Version)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&accept, "accept", false, "accept the current terms")
+
+	return cmd
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// batchResult is the outcome of one file in a batch upload.
+type batchResult struct {
+	path    string
+	videoID string
+	size    int64
+	sha256  string
+	elapsed time.Duration
+	err     error
+}
+
+// expandBatch lists the regular files in a directory, or the files matching
+// a glob pattern.
+func expandBatch(pattern string) ([]string, error) {
+	var candidates []string
+	if info, err := os.Stat(pattern); err == nil && info.IsDir() {
+		entries, err := os.ReadDir(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read directory: %v", err)
+		}
+		for _, e := range entries {
+			candidates = append(candidates, filepath.Join(pattern, e.Name()))
+		}
+	} else {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern: %v", err)
+		}
+		candidates = matches
+	}
+
+	var files []string
+	for _, path := range candidates {
+		if info, err := os.Stat(path); err == nil && info.Mode().IsRegular() {
+			files = append(files, path)
+		}
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files match %s", pattern)
+	}
+	return files, nil
+}
+
+// videoIDFromPath derives a video ID from the file name without extension.
+func videoIDFromPath(path string) string {
+	base := filepath.Base(path)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+// uploadBatch uploads files with the given number of concurrent workers.
+func (u *uploader) uploadBatch(ctx context.Context, files []string, workers int) []batchResult {
+	u.plainProgress = true
+
+	jobs := make(chan int)
+	results := make([]batchResult, len(files))
+
+	var wg sync.WaitGroup
+	for range max(workers, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				r := batchResult{path: files[i], videoID: videoIDFromPath(files[i])}
+				start := time.Now()
+				resp, err := u.upload(ctx, r.videoID, r.path)
+				r.elapsed = time.Since(start)
+				if err != nil {
+					r.err = err
+				} else {
+					r.size = resp.TotalBytes
+					r.sha256 = resp.Metadata.GetSha256()
+				}
+				results[i] = r
+			}
+		}()
+	}
+
+	for i := range files {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// reportBatch prints a per-file summary and fails if any transfer failed.
+// kind names the transfers, e.g. "upload".
+func reportBatch(opts *options, kind string, results []batchResult) error {
+	failed := 0
+	for _, r := range results {
+		if r.err != nil {
+			failed++
+		}
+	}
+
+	if opts.json {
+		out := make([]transferJSON, 0, len(results))
+		for _, r := range results {
+			t := transferJSON{VideoID: r.videoID, Path: r.path, Bytes: r.size, SHA256: r.sha256, ElapsedMS: r.elapsed.Milliseconds()}
+			if r.err != nil {
+				t.Error = r.err.Error()
+			}
+			out = append(out, t)
+		}
+		if err := writeJSON(out); err != nil {
+			return err
+		}
+		if failed > 0 {
+			return errReported
+		}
+		return nil
+	}
+
+	fmt.Printf("\n=== %s%s Summary ====\n", strings.ToUpper(kind[:1]), kind[1:])
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "FILE\tVIDEO ID\tSIZE\tDURATION\tRESULT")
+	for _, r := range results {
+		result := "ok"
+		if r.err != nil {
+			result = r.err.Error()
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", r.path, r.videoID, formatBytes(r.size), r.elapsed.Round(time.Millisecond), result)
+	}
+	w.Flush()
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d %ss failed", failed, len(results), kind)
+	}
+	return nil
+}
@@ -0,0 +1,365 @@
+package main
+
+import (
+	"context"
+	"coscup2025/client"
+	"coscup2025/proto/auth"
+	"coscup2025/proto/media"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/metadata"
+)
+
+// benchOps are the operations a bench run measures, in report order.
+var benchOps = []string{"signup", "login", "upload", "download"}
+
+func newBenchCmd(opts *options) *cobra.Command {
+	var b bench
+	var size string
+
+	cmd := &cobra.Command{
+		Use:   "bench",
+		Short: "Generate load with synthetic users and videos and report latency and throughput",
+		Long: `Bench signs up --users new accounts, signs each of them in, uploads
+--uploads synthetic videos per user and downloads each of them --downloads
+times, with at most --concurrency users active at once. It reports latency
+percentiles and throughput per operation. The accounts and videos are left on
+the server.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			n, err := parseRate(size)
+			if err != nil {
+				return err
+			}
+			b.size = n
+			if b.users < 1 || b.concurrency < 1 || b.size < 1 {
+				return errors.New("--users, --concurrency and --size must be at least 1")
+			}
+			if b.prefix == "" {
+				b.prefix = fmt.Sprintf("bench-%d", time.Now().Unix())
+			}
+
+			conn, err := opts.dial()
+			if err != nil {
+				return fmt.Errorf("failed to connect to server: %v", err)
+			}
+			defer conn.Close()
+
+			b.auth = auth.NewAuthServiceClient(conn)
+			b.media = media.NewMediaServiceClient(conn)
+			b.opts = opts
+			b.out = opts.messages()
+			b.stats = newBenchStats()
+
+			elapsed, err := b.run(cmd.Context())
+			if err != nil {
+				return err
+			}
+			return b.stats.report(opts, elapsed)
+		},
+	}
+
+	cmd.Flags().IntVar(&b.users, "users", 10, "number of synthetic accounts to create")
+	cmd.Flags().IntVar(&b.concurrency, "concurrency", 4, "users active at the same time")
+	cmd.Flags().IntVar(&b.uploads, "uploads", 1, "videos uploaded by each user")
+	cmd.Flags().IntVar(&b.downloads, "downloads", 1, "downloads of each uploaded video")
+	cmd.Flags().StringVar(&size, "size", "1M", "size of each synthetic video, e.g. 500K or 2M")
+	cmd.Flags().StringVar(&b.prefix, "prefix", "", "username and video ID prefix (default bench-<unix time>)")
+
+	return cmd
+}
+
+// bench drives one load-generation run.
+type bench struct {
+	users       int
+	concurrency int
+	uploads     int
+	downloads   int
+	size        int64
+	prefix      string
+
+	opts  *options
+	auth  auth.AuthServiceClient
+	media media.MediaServiceClient
+	out   *os.File
+	stats *benchStats
+	terms string
+}
+
+// run plays every synthetic user and returns the wall-clock time it took.
+// Failed operations are counted, not fatal, so that a struggling server
+// still produces a report.
+func (b *bench) run(ctx context.Context) (time.Duration, error) {
+	callCtx, cancel := b.opts.callContext(ctx)
+	terms, err := b.auth.GetTerms(callCtx, &auth.GetTermsRequest{})
+	cancel()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get terms: %v", err)
+	}
+	b.terms = terms.Version
+
+	// Every upload sends the same random payload; the server still keeps
+	// each video in memory, so this only saves generating it per video.
+	payload := make([]byte, b.size)
+	rand.Read(payload)
+
+	fmt.Fprintf(b.out, "Running %d users (%d concurrent), %d uploads of %s each and %d downloads per upload\n",
+		b.users, b.concurrency, b.uploads, formatBytes(b.size), b.downloads)
+
+	users := make(chan int)
+	var wg sync.WaitGroup
+	start := time.Now()
+	for range b.concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range users {
+				b.runUser(ctx, i, payload)
+			}
+		}()
+	}
+
+	for i := range b.users {
+		select {
+		case users <- i:
+		case <-ctx.Done():
+		}
+	}
+	close(users)
+	wg.Wait()
+
+	return time.Since(start), ctx.Err()
+}
+
+// runUser signs up, signs in, uploads and downloads as synthetic user i,
+// stopping at the first step the rest depends on.
+func (b *bench) runUser(ctx context.Context, i int, payload []byte) {
+	username := fmt.Sprintf("%s-%d", b.prefix, i)
+	password := rand.Text()
+
+	err := b.measure(ctx, "signup", 0, func(ctx context.Context) error {
+		_, err := b.auth.SignUp(ctx, &auth.SignUpRequest{Username: username, Password: password, AcceptedTermsVersion: b.terms})
+		return err
+	})
+	if err != nil {
+		return
+	}
+
+	var token string
+	err = b.measure(ctx, "login", 0, func(ctx context.Context) error {
+		resp, err := b.auth.SignIn(ctx, &auth.SignInRequest{Username: username, Password: password})
+		if err == nil {
+			token = resp.Token
+		}
+		return err
+	})
+	if err != nil {
+		return
+	}
+	ctx = metadata.NewOutgoingContext(ctx, metadata.Pairs("authorization", "Bearer "+token))
+
+	for j := range b.uploads {
+		videoID := fmt.Sprintf("%s-%d", username, j)
+		if err := b.measure(ctx, "upload", b.size, func(ctx context.Context) error {
+			return b.upload(ctx, videoID, payload)
+		}); err != nil {
+			continue
+		}
+		for range b.downloads {
+			b.measure(ctx, "download", b.size, func(ctx context.Context) error {
+				return b.download(ctx, videoID)
+			})
+		}
+	}
+}
+
+// measure runs op under the call or transfer deadline and records its
+// latency. n is the number of bytes op moves.
+func (b *bench) measure(ctx context.Context, op string, n int64, fn func(context.Context) error) error {
+	timeout := b.opts.callTimeout
+	if n > 0 {
+		timeout = b.opts.transferTimeout
+	}
+	ctx, cancel := withTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := fn(ctx)
+	b.stats.record(op, time.Since(start), n, err)
+	if err != nil && ctx.Err() == nil {
+		fmt.Fprintf(b.out, "%s failed: %v\n", op, err)
+	}
+	return err
+}
+
+// upload sends payload as a new video in a single stream, without the
+// retries of the upload command so that failures show in the report.
+func (b *bench) upload(ctx context.Context, videoID string, payload []byte) error {
+	resp, err := b.media.CreateUploadSession(ctx, &media.CreateUploadSessionRequest{
+		VideoId:   videoID,
+		TotalSize: int64(len(payload)),
+		Tags:      []string{"bench"},
+	})
+	if err != nil {
+		return err
+	}
+	session := resp.Session
+
+	stream, err := b.media.UploadVideo(ctx)
+	if err != nil {
+		return err
+	}
+	var offset int64
+	for sequence := int64(1); offset < int64(len(payload)); sequence++ {
+		end := min(offset+client.ChunkSize, int64(len(payload)))
+		err := stream.Send(&media.UploadVideoRequest{
+			VideoId:  videoID,
+			Data:     payload[offset:end],
+			Sequence: sequence,
+			UploadId: session.UploadId,
+			Offset:   offset,
+		})
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		offset = end
+	}
+	_, err = stream.CloseAndRecv()
+	return err
+}
+
+// download receives the whole video and discards it.
+func (b *bench) download(ctx context.Context, videoID string) error {
+	stream, err := b.media.DownloadVideo(ctx, &media.DownloadVideoRequest{VideoId: videoID})
+	if err != nil {
+		return err
+	}
+	for {
+		if _, err := stream.Recv(); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+// benchStats collects the outcome of every operation of a run.
+type benchStats struct {
+	mu  sync.Mutex
+	ops map[string]*opStats
+}
+
+type opStats struct {
+	latencies []time.Duration // successful operations only
+	errors    int
+	bytes     int64
+}
+
+func newBenchStats() *benchStats {
+	s := &benchStats{ops: make(map[string]*opStats)}
+	for _, op := range benchOps {
+		s.ops[op] = &opStats{}
+	}
+	return s
+}
+
+func (s *benchStats) record(op string, latency time.Duration, n int64, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o := s.ops[op]
+	if err != nil {
+		o.errors++
+		return
+	}
+	o.latencies = append(o.latencies, latency)
+	o.bytes += n
+}
+
+// benchJSON is the --json form of one operation's results.
+type benchJSON struct {
+	Op             string  `json:"op"`
+	Count          int     `json:"count"`
+	Errors         int     `json:"errors"`
+	P50MS          float64 `json:"p50_ms"`
+	P90MS          float64 `json:"p90_ms"`
+	P99MS          float64 `json:"p99_ms"`
+	MaxMS          float64 `json:"max_ms"`
+	OpsPerSecond   float64 `json:"ops_per_second"`
+	BytesPerSecond float64 `json:"bytes_per_second,omitempty"`
+}
+
+// report prints the results per operation. Throughput is measured over the
+// whole run, so it is what the server sustained with the operations mixed.
+func (s *benchStats) report(opts *options, elapsed time.Duration) error {
+	var rows []benchJSON
+	failed := 0
+	for _, op := range benchOps {
+		o := s.ops[op]
+		slices.Sort(o.latencies)
+		failed += o.errors
+		rows = append(rows, benchJSON{
+			Op:             op,
+			Count:          len(o.latencies),
+			Errors:         o.errors,
+			P50MS:          milliseconds(percentile(o.latencies, 50)),
+			P90MS:          milliseconds(percentile(o.latencies, 90)),
+			P99MS:          milliseconds(percentile(o.latencies, 99)),
+			MaxMS:          milliseconds(percentile(o.latencies, 100)),
+			OpsPerSecond:   float64(len(o.latencies)) / elapsed.Seconds(),
+			BytesPerSecond: float64(o.bytes) / elapsed.Seconds(),
+		})
+	}
+
+	if opts.json {
+		if err := writeJSON(rows); err != nil {
+			return err
+		}
+	} else {
+		fmt.Printf("\n=== Bench Summary (%s) ====\n", elapsed.Round(time.Millisecond))
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "OP\tCOUNT\tERRORS\tP50\tP90\tP99\tMAX\tOPS/S\tTHROUGHPUT")
+		for _, r := range rows {
+			throughput := "-"
+			if r.BytesPerSecond > 0 {
+				throughput = formatBytes(int64(r.BytesPerSecond)) + "/s"
+			}
+			fmt.Fprintf(w, "%s\t%d\t%d\t%.1fms\t%.1fms\t%.1fms\t%.1fms\t%.1f\t%s\n",
+				r.Op, r.Count, r.Errors, r.P50MS, r.P90MS, r.P99MS, r.MaxMS, r.OpsPerSecond, throughput)
+		}
+		w.Flush()
+	}
+
+	if failed > 0 {
+		if opts.json {
+			return errReported
+		}
+		return fmt.Errorf("%d operations failed", failed)
+	}
+	return nil
+}
+
+// percentile returns the nearest-rank p-th percentile of sorted latencies,
+// or zero when there are none.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[max(rank, 1)-1]
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,285 @@
+package main
+
+import (
+	"context"
+	"coscup2025/client"
+	"coscup2025/proto/media"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// chunkOverhead is a generous bound on the bytes an UploadVideoRequest adds
+// to its chunk: the video and upload IDs, offsets and framing.
+const chunkOverhead = 4 << 10
+
+// Statuses of a pre-flight check. A warning does not stop the upload, a
+// failure would.
+const (
+	checkOK   = "ok"
+	checkWarn = "warn"
+	checkFail = "fail"
+)
+
+// preflightFile is an upload to check: a file, or a stream of known size
+// when path is empty.
+type preflightFile struct {
+	videoID string
+	path    string
+	size    int64
+}
+
+// preflightFiles lists the uploads the arguments of `upload` describe.
+func preflightFiles(args []string, batch string, stdin bool, size int64, synthetic string) ([]preflightFile, error) {
+	switch {
+	case batch != "":
+		paths, err := expandBatch(batch)
+		if err != nil {
+			return nil, err
+		}
+		var files []preflightFile
+		for _, path := range paths {
+			info, err := os.Stat(path)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, preflightFile{videoID: videoIDFromPath(path), path: path, size: info.Size()})
+		}
+		return files, nil
+	case stdin:
+		return []preflightFile{{videoID: args[0], size: size}}, nil
+	case synthetic != "":
+		n, err := parseRate(synthetic)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("invalid --synthetic size %q, expected e.g. 500M or 4G", synthetic)
+		}
+		return []preflightFile{{videoID: args[0], size: n}}, nil
+	}
+	info, err := os.Stat(args[1])
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file info: %v", err)
+	}
+	return []preflightFile{{videoID: args[0], path: args[1], size: info.Size()}}, nil
+}
+
+// checkJSON is the --json form of one pre-flight check.
+type checkJSON struct {
+	Check   string `json:"check"`
+	VideoID string `json:"video_id,omitempty"`
+	Status  string `json:"status"`
+	Detail  string `json:"detail"`
+}
+
+// preflightJSON is the --json result of `upload --check`.
+type preflightJSON struct {
+	Ready  bool        `json:"ready"`
+	Checks []checkJSON `json:"checks"`
+}
+
+// preflight checks that uploads of files would be accepted, without sending
+// any of their bytes: that the token is valid for long enough, that the
+// server has room for them and accepts their size, chunks and video IDs,
+// and that the files look like videos. bytesPerSecond, if set, estimates how
+// long the transfer takes.
+type preflight struct {
+	client         media.MediaServiceClient
+	token          string
+	overwrite      bool
+	bytesPerSecond int64
+	checks         []checkJSON
+}
+
+func (p *preflight) add(check, videoID, status, format string, args ...any) {
+	p.checks = append(p.checks, checkJSON{Check: check, VideoID: videoID, Status: status, Detail: fmt.Sprintf(format, args...)})
+}
+
+func (p *preflight) run(ctx context.Context, files []preflightFile) error {
+	limits, err := p.client.GetUploadLimits(ctx, &media.GetUploadLimitsRequest{})
+	if err != nil {
+		if status.Code(err) == codes.Unauthenticated {
+			p.add("token", "", checkFail, "rejected by the server: %s, run `coscupctl login`", status.Convert(err).Message())
+			return nil
+		}
+		return fmt.Errorf("failed to get upload limits: %v", err)
+	}
+
+	var total int64
+	for _, f := range files {
+		total += f.size
+	}
+	p.checkToken(limits, total)
+	p.checkServer(limits, total)
+	for _, f := range files {
+		p.checkSize(limits, f)
+		if f.path != "" {
+			p.checkContentType(f)
+		}
+		if err := p.checkVideoID(ctx, limits, f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkToken reports when the token expires. It is sent again whenever an
+// interrupted stream resumes, so it must outlast the transfer.
+func (p *preflight) checkToken(limits *media.GetUploadLimitsResponse, total int64) {
+	claims := jwt.MapClaims{}
+	exp, ok := time.Time{}, false
+	if _, _, err := new(jwt.Parser).ParseUnverified(p.token, claims); err == nil {
+		if v, isNum := claims["exp"].(float64); isNum {
+			exp, ok = time.Unix(int64(v), 0), true
+		}
+	}
+	switch {
+	case !ok:
+		p.add("token", "", checkOK, "accepted for user %s, without expiry", limits.UserId)
+	case p.bytesPerSecond > 0 && time.Until(exp) < time.Duration(total/p.bytesPerSecond)*time.Second:
+		p.add("token", "", checkWarn, "expires in %s, before the transfer ends at --limit-rate (%s); retries after that fail, log in again first",
+			formatDuration(time.Until(exp)), formatDuration(time.Duration(total/p.bytesPerSecond)*time.Second))
+	default:
+		p.add("token", "", checkOK, "accepted for user %s, expires in %s", limits.UserId, formatDuration(time.Until(exp)))
+	}
+}
+
+// checkServer checks what applies to all files: storage health, the memory
+// the server has left for them and the chunk size.
+func (p *preflight) checkServer(limits *media.GetUploadLimitsResponse, total int64) {
+	if limits.StorageDegraded {
+		p.add("storage", "", checkFail, "the server is failing to store uploads, retry later")
+	} else {
+		p.add("storage", "", checkOK, "accepting writes")
+	}
+
+	switch {
+	case limits.MemoryBudget == 0:
+		p.add("capacity", "", checkOK, "no server memory budget")
+	case total > limits.MemoryAvailable:
+		p.add("capacity", "", checkFail, "%s left of the server's budget, %s needed; retry later",
+			formatBytes(limits.MemoryAvailable), formatBytes(total))
+	default:
+		p.add("capacity", "", checkOK, "%s left of the server's budget, %s needed", formatBytes(limits.MemoryAvailable), formatBytes(total))
+	}
+
+	if client.ChunkSize+chunkOverhead > limits.MaxMessageSize {
+		p.add("chunk size", "", checkFail, "the server receives messages up to %s, chunks are %s",
+			formatBytes(limits.MaxMessageSize), formatBytes(client.ChunkSize))
+	} else {
+		p.add("chunk size", "", checkOK, "chunks of %s fit the server's %s messages; idle uploads are kept for %s",
+			formatBytes(client.ChunkSize), formatBytes(limits.MaxMessageSize), time.Duration(limits.SessionIdleSeconds)*time.Second)
+	}
+}
+
+func (p *preflight) checkSize(limits *media.GetUploadLimitsResponse, f preflightFile) {
+	switch {
+	case limits.MaxVideoSize == 0:
+		p.add("size", f.videoID, checkOK, "%s, no size limit", formatBytes(f.size))
+	case f.size > limits.MaxVideoSize:
+		p.add("size", f.videoID, checkFail, "%s exceeds the server's limit of %s", formatBytes(f.size), formatBytes(limits.MaxVideoSize))
+	default:
+		p.add("size", f.videoID, checkOK, "%s of at most %s", formatBytes(f.size), formatBytes(limits.MaxVideoSize))
+	}
+}
+
+// checkContentType warns about files that look like something other than a
+// video by their content and name. The server stores any content, so this
+// catches picking the wrong file rather than a refused upload.
+func (p *preflight) checkContentType(f preflightFile) {
+	file, err := os.Open(f.path)
+	if err != nil {
+		p.add("content type", f.videoID, checkFail, "failed to open file: %v", err)
+		return
+	}
+	defer file.Close()
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		p.add("content type", f.videoID, checkFail, "failed to read file: %v", err)
+		return
+	}
+
+	sniffed := http.DetectContentType(head[:n])
+	byName := mime.TypeByExtension(filepath.Ext(f.path))
+	for _, t := range []string{sniffed, byName} {
+		if strings.HasPrefix(t, "video/") || strings.HasPrefix(t, "application/ogg") {
+			p.add("content type", f.videoID, checkOK, "%s", t)
+			return
+		}
+	}
+	p.add("content type", f.videoID, checkWarn, "%s does not look like a video (%s)", filepath.Base(f.path), sniffed)
+}
+
+// checkVideoID checks that the upload may take its video ID.
+func (p *preflight) checkVideoID(ctx context.Context, limits *media.GetUploadLimitsResponse, f preflightFile) error {
+	resp, err := p.client.GetVideoMetadata(ctx, &media.GetVideoMetadataRequest{VideoId: f.videoID})
+	switch {
+	case status.Code(err) == codes.NotFound:
+		p.add("video id", f.videoID, checkOK, "available")
+		return nil
+	case status.Code(err) == codes.PermissionDenied:
+		p.add("video id", f.videoID, checkFail, "taken by another user")
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to look up video %s: %v", f.videoID, err)
+	}
+
+	switch {
+	case resp.Metadata.GetUploaderId() != limits.UserId:
+		p.add("video id", f.videoID, checkFail, "taken by %s", resp.Metadata.GetUploaderName())
+	case p.overwrite:
+		p.add("video id", f.videoID, checkOK, "your video, which the upload replaces")
+	case limits.VideoVersioning:
+		p.add("video id", f.videoID, checkOK, "your video, the upload stores its next version")
+	default:
+		p.add("video id", f.videoID, checkFail, "you uploaded it before, pass --overwrite to replace it")
+	}
+	return nil
+}
+
+// ready reports whether no check failed.
+func (p *preflight) ready() bool {
+	for _, c := range p.checks {
+		if c.Status == checkFail {
+			return false
+		}
+	}
+	return true
+}
+
+// report prints the checks, returning errReported when one failed.
+func (p *preflight) report(opts *options) error {
+	if opts.json {
+		if err := writeJSON(preflightJSON{Ready: p.ready(), Checks: p.checks}); err != nil {
+			return err
+		}
+	} else {
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		for _, c := range p.checks {
+			check := c.Check
+			if c.VideoID != "" {
+				check += " (" + c.VideoID + ")"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n", strings.ToUpper(c.Status), check, c.Detail)
+		}
+		w.Flush()
+		if p.ready() {
+			fmt.Println("Ready to upload")
+		} else {
+			fmt.Println("Not ready to upload")
+		}
+	}
+	if !p.ready() {
+		return errReported
+	}
+	return nil
+}
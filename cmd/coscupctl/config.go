@@ -0,0 +1,101 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// fileConfig is the content of the coscupctl config file.
+type fileConfig struct {
+	Server     string `yaml:"server"`
+	Token      string `yaml:"token"`
+	TLS        bool   `yaml:"tls"`
+	CACert     string `yaml:"ca_cert"`
+	ClientCert string `yaml:"client_cert"`
+	ClientKey  string `yaml:"client_key"`
+}
+
+// defaultConfigPath is ~/.config/coscup/config.yaml on Linux and the
+// platform equivalent elsewhere.
+func defaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "coscup", "config.yaml")
+}
+
+func loadFileConfig(path string) (*fileConfig, error) {
+	cfg := &fileConfig{}
+	if path == "" {
+		return cfg, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %v", path, err)
+	}
+	return cfg, nil
+}
+
+// resolve fills in settings that were not given as flags, preferring
+// COSCUP_* environment variables over the config file, and falling back to
+// the credentials cached by `coscupctl login`.
+func (o *options) resolve(cmd *cobra.Command) error {
+	cfg, err := loadFileConfig(o.configPath)
+	if err != nil {
+		return err
+	}
+
+	flags := cmd.Flags()
+	if !flags.Changed("server") {
+		if v := firstNonEmpty(os.Getenv("COSCUP_SERVER"), cfg.Server); v != "" {
+			o.server = v
+		}
+	}
+	if !flags.Changed("tls") {
+		o.tls.enabled = cfg.TLS
+	}
+	if !flags.Changed("ca-cert") {
+		o.tls.caCert = cfg.CACert
+	}
+	if !flags.Changed("client-cert") && !flags.Changed("client-key") {
+		o.tls.clientCert, o.tls.clientKey = cfg.ClientCert, cfg.ClientKey
+	}
+	if !flags.Changed("token") {
+		o.token = firstNonEmpty(os.Getenv("COSCUP_TOKEN"), cfg.Token)
+	}
+	if o.token == "" {
+		creds, err := loadCredentials(o.server)
+		if err != nil {
+			return err
+		}
+		if creds != nil {
+			o.cached = creds
+			o.token = creds.Token
+		}
+	}
+	return nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
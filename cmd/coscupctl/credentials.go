@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"coscup2025/proto/auth"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// refreshMargin is how long before expiry a cached token is refreshed.
+const refreshMargin = 5 * time.Minute
+
+// credentials are the tokens cached by `coscupctl login` for one server.
+type credentials struct {
+	Username     string    `json:"username"`
+	Token        string    `json:"token"`
+	RefreshToken string    `json:"refresh_token"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// loadCredentials returns nil when nothing is cached for server.
+func loadCredentials(server string) (*credentials, error) {
+	store, err := openCredentialStore()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := store.get(server)
+	if err != nil || data == nil {
+		return nil, err
+	}
+
+	var creds credentials
+	if err := json.Unmarshal(data, &creds); err != nil {
+		return nil, fmt.Errorf("invalid cached credentials: %v", err)
+	}
+	return &creds, nil
+}
+
+func saveCredentials(server string, creds *credentials) error {
+	store, err := openCredentialStore()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(creds)
+	if err != nil {
+		return err
+	}
+	return store.set(server, data)
+}
+
+func deleteCredentials(server string) error {
+	store, err := openCredentialStore()
+	if err != nil {
+		return err
+	}
+	return store.delete(server)
+}
+
+// refreshCredentials exchanges the cached refresh token for a new token pair
+// and caches it.
+func (o *options) refreshCredentials(ctx context.Context) error {
+	conn, err := o.dial()
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %v", err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := o.callContext(ctx)
+	defer cancel()
+
+	resp, err := auth.NewAuthServiceClient(conn).RefreshToken(ctx, &auth.RefreshTokenRequest{
+		RefreshToken: o.cached.RefreshToken,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to refresh token, run `coscupctl login` again: %v", err)
+	}
+
+	o.cached.Token = resp.Token
+	o.cached.RefreshToken = resp.RefreshToken
+	o.cached.ExpiresAt = time.Unix(resp.ExpiresAt, 0)
+	o.token = resp.Token
+
+	return saveCredentials(o.server, o.cached)
+}
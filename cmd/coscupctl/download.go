@@ -0,0 +1,275 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"coscup2025/client"
+	"coscup2025/proto/media"
+	"coscup2025/synthmedia"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func newDownloadCmd(opts *options) *cobra.Command {
+	var output, manifest, outputDir string
+	var workers int
+	var resume, deleteOnMismatch, verifySynthetic, batch bool
+	var limitRate string
+
+	cmd := &cobra.Command{
+		Use:   "download <video_id> [<output_file_path>] | --manifest <file>",
+		Short: "Download a video to a file, or to stdout with --output -",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if manifest != "" {
+				return cobra.NoArgs(cmd, args)
+			}
+			return cobra.RangeArgs(1, 2)(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if manifest == "" {
+				if len(args) == 2 {
+					if output != "" {
+						return errors.New("give the output path either as an argument or with --output")
+					}
+					output = args[1]
+				}
+				if output == "" {
+					return errors.New("missing output path, use --output - to write to stdout")
+				}
+				if output == "-" && (resume || deleteOnMismatch || verifySynthetic) {
+					return errors.New("--resume, --delete-on-mismatch and --verify-synthetic need an output file")
+				}
+				if output == "-" && opts.json {
+					return errors.New("--json cannot be combined with --output -")
+				}
+			} else if output != "" {
+				return errors.New("--output cannot be combined with --manifest, use --output-dir")
+			} else if verifySynthetic {
+				return errors.New("--verify-synthetic cannot be combined with --manifest")
+			}
+
+			bytesPerSecond, err := parseRate(limitRate)
+			if err != nil {
+				return err
+			}
+
+			ctx, err := opts.authContext(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			conn, err := opts.dial()
+			if err != nil {
+				return fmt.Errorf("failed to connect to server: %v", err)
+			}
+			defer conn.Close()
+
+			dl := &downloader{
+				client:           client.NewFromConn(conn, opts.retry),
+				retry:            opts.retry,
+				out:              opts.messages(),
+				deleteOnMismatch: deleteOnMismatch,
+				limit:            newBandwidth(bytesPerSecond),
+				timeout:          opts.transferTimeout,
+				priority:         media.DownloadPriority_DOWNLOAD_PRIORITY_INTERACTIVE,
+			}
+			// Mirroring a manifest should not slow down people watching.
+			if batch || manifest != "" {
+				dl.priority = media.DownloadPriority_DOWNLOAD_PRIORITY_BATCH
+			}
+
+			if manifest != "" {
+				entries, err := readManifest(manifest, outputDir)
+				if err != nil {
+					return err
+				}
+				results := dl.downloadBatch(ctx, entries, workers, resume)
+				return reportBatch(opts, "download", results)
+			}
+
+			videoID := args[0]
+			start := time.Now()
+			d, err := dl.fetch(ctx, videoID, output, resume)
+			if err != nil {
+				return fmt.Errorf("failed to download video: %v", err)
+			}
+			if verifySynthetic {
+				if err := verifySyntheticFile(opts.messages(), output); err != nil {
+					return err
+				}
+			}
+
+			if opts.json {
+				return writeJSON(transferJSON{
+					VideoID:   videoID,
+					Path:      output,
+					Bytes:     d.written,
+					SHA256:    fmt.Sprintf("%x", d.hasher.Sum(nil)),
+					ElapsedMS: time.Since(start).Milliseconds(),
+					Server:    newStatsJSON(d.stats),
+				})
+			}
+			if output != "-" {
+				fmt.Printf("Successfully downloaded video: %s to %s\n", videoID, output)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "", "output file, or - to write the video to stdout and messages to stderr")
+	cmd.Flags().StringVar(&limitRate, "limit-rate", "", "cap the download speed in bytes per second, e.g. 500K or 2M")
+	cmd.Flags().StringVar(&manifest, "manifest", "", "download every video listed in this file, one <video_id> [<output_path>] per line")
+	cmd.Flags().StringVar(&outputDir, "output-dir", ".", "directory for --manifest entries without an output path")
+	cmd.Flags().IntVar(&workers, "workers", 4, "concurrent downloads in --manifest mode")
+	cmd.Flags().BoolVar(&batch, "batch", false, "download at batch priority, leaving the server's bandwidth to players first; implied by --manifest")
+	cmd.Flags().BoolVar(&resume, "resume", false, "continue a partial download instead of overwriting the output file")
+	cmd.Flags().BoolVar(&verifySynthetic, "verify-synthetic", false, "check the video byte for byte against the content upload --synthetic generated")
+	cmd.Flags().BoolVar(&deleteOnMismatch, "delete-on-mismatch", false, "remove the output file when its size or checksum does not match the server's")
+
+	return cmd
+}
+
+// verifySyntheticFile checks that path holds content generated by
+// upload --synthetic.
+func verifySyntheticFile(out io.Writer, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	m, err := synthmedia.Verify(bufio.NewReaderSize(file, 1<<20))
+	if err != nil {
+		return fmt.Errorf("%s is not the synthetic video it should be: %v", path, err)
+	}
+	fmt.Fprintf(out, "Verified synthetic content: seed %d, %d bytes\n", m.Seed, m.Size)
+	return nil
+}
+
+// downloader downloads videos to files or stdout.
+type downloader struct {
+	client           *client.Client
+	retry            client.RetryPolicy
+	out              *os.File // human-readable messages
+	deleteOnMismatch bool
+	limit            *bandwidth
+	timeout          time.Duration // per stream attempt, zero for none
+	priority         media.DownloadPriority
+	// plainProgress forces log-line progress, used when several downloads
+	// share the terminal.
+	plainProgress bool
+}
+
+// download is the result of a single video download.
+type download struct {
+	out      *os.File // human-readable messages
+	hasher   hash.Hash
+	written  int64
+	metadata *media.VideoMetadata
+	stats    *media.TransferStats // of the last stream
+}
+
+// fetch writes the video to outputPath, or to stdout when it is "-".
+func (dl *downloader) fetch(ctx context.Context, videoID, outputPath string, resume bool) (_ *download, err error) {
+	d := &download{out: dl.out, hasher: sha256.New()}
+	file := os.Stdout
+
+	if outputPath == "-" {
+		d.out = os.Stderr
+	} else {
+		flags := os.O_RDWR | os.O_CREATE
+		if !resume {
+			flags |= os.O_TRUNC
+		}
+		f, openErr := os.OpenFile(outputPath, flags, 0o644)
+		if openErr != nil {
+			return nil, fmt.Errorf("failed to create output file: %v", openErr)
+		}
+		defer f.Close()
+		file = f
+
+		// Do not leave an empty file behind when nothing could be downloaded.
+		defer func() {
+			if err != nil && d.written == 0 && !resume {
+				os.Remove(outputPath)
+			}
+		}()
+	}
+
+	// Hash what is already on disk so the final checksum covers the whole file.
+	if resume {
+		written, err := io.Copy(d.hasher, file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read partial file: %v", err)
+		}
+		d.written = written
+		if d.written > 0 {
+			fmt.Fprintf(d.out, "Resuming download of %s at %d bytes\n", videoID, d.written)
+		}
+	}
+
+	fmt.Fprintf(d.out, "Downloading video: %s\n", videoID)
+	bar := newProgress(d.out, "download "+videoID, 0, d.written)
+	if dl.plainProgress {
+		bar.tty = false
+	}
+
+	d.metadata, err = dl.client.Download(ctx, videoID, file, &client.DownloadOptions{
+		Offset:         d.written,
+		Hash:           d.hasher,
+		Priority:       dl.priority,
+		AttemptTimeout: dl.timeout,
+		Wait:           dl.limit.wait,
+		Progress: func(written int64) {
+			d.written = written
+			bar.set(written)
+		},
+		Metadata: func(md *media.VideoMetadata) {
+			fmt.Fprintln(d.out, "\n=== Metadata ====")
+			printMetadata(d.out, md)
+			bar.setTotal(md.FileSize)
+		},
+		Stats: func(stats *media.TransferStats) { d.stats = stats },
+		Retrying: func(err error, attempt int, delay time.Duration) {
+			log.Printf("Download interrupted (%v), retrying in %s (attempt %d/%d)", err, delay.Round(time.Millisecond), attempt, dl.retry.MaxAttempts)
+		},
+	})
+	if errors.Is(err, client.ErrSizeMismatch) || errors.Is(err, client.ErrChecksumMismatch) {
+		bar.finish()
+		if !dl.deleteOnMismatch {
+			return nil, fmt.Errorf("%v; %s is corrupt, download it again without --resume", err, outputPath)
+		}
+		file.Close()
+		if rmErr := os.Remove(outputPath); rmErr != nil {
+			return nil, fmt.Errorf("%v; failed to remove %s: %v", err, outputPath, rmErr)
+		}
+		return nil, fmt.Errorf("%v; removed %s", err, outputPath)
+	}
+	if err != nil {
+		return nil, err
+	}
+	bar.finish()
+
+	fmt.Fprintf(d.out, "Download completed: %d bytes\n", d.written)
+
+	if videoMetadata := d.metadata; videoMetadata != nil {
+		fmt.Fprintln(d.out, "\n=== Download Summary ====")
+		fmt.Fprintf(d.out, "Uploader Name: %s (%s)\n", videoMetadata.UploaderName, videoMetadata.UploaderId)
+		if videoMetadata.UploadTimestamp > 0 {
+			uploadTime := time.Unix(videoMetadata.UploadTimestamp, 0)
+			fmt.Fprintf(d.out, "Upload Time: %s\n", uploadTime.Format("2006-01-02 15:04:05"))
+		}
+		fmt.Fprintf(d.out, "Output Path: %s\n", outputPath)
+		fmt.Fprintln(d.out, "===================")
+	}
+
+	return d, nil
+}
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/zalando/go-keyring"
+	"golang.org/x/crypto/scrypt"
+)
+
+// keyringService names the coscupctl entries in the OS keychain.
+const keyringService = "coscupctl"
+
+// credentialStore keeps secrets per server address.
+type credentialStore interface {
+	// get returns nil when nothing is stored for server.
+	get(server string) ([]byte, error)
+	set(server string, data []byte) error
+	delete(server string) error
+}
+
+// openCredentialStore prefers the OS keychain (macOS Keychain, Windows
+// Credential Manager, Secret Service) and falls back to an encrypted file
+// when none is available, e.g. on headless Linux machines.
+func openCredentialStore() (credentialStore, error) {
+	var store credentialStore
+	if _, err := keyring.Get(keyringService, "probe"); err == nil || errors.Is(err, keyring.ErrNotFound) {
+		store = keyringStore{}
+	} else {
+		file, err := newFileStore()
+		if err != nil {
+			return nil, err
+		}
+		store = file
+	}
+
+	if err := migrateLegacyCredentials(store); err != nil {
+		return nil, fmt.Errorf("failed to migrate plaintext credentials: %v", err)
+	}
+	return store, nil
+}
+
+// migrateLegacyCredentials moves tokens from the plaintext credentials.json
+// written by older versions into store and removes the file.
+func migrateLegacyCredentials(store credentialStore) error {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return nil
+	}
+	path := filepath.Join(dir, "coscup", "credentials.json")
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var legacy map[string]json.RawMessage
+	if err := json.Unmarshal(data, &legacy); err != nil {
+		return err
+	}
+	for server, creds := range legacy {
+		if err := store.set(server, creds); err != nil {
+			return err
+		}
+	}
+	return os.Remove(path)
+}
+
+type keyringStore struct{}
+
+func (keyringStore) get(server string) ([]byte, error) {
+	secret, err := keyring.Get(keyringService, server)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return []byte(secret), nil
+}
+
+func (keyringStore) set(server string, data []byte) error {
+	return keyring.Set(keyringService, server, string(data))
+}
+
+func (keyringStore) delete(server string) error {
+	err := keyring.Delete(keyringService, server)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+// fileStore keeps all secrets in one AES-GCM encrypted file. The key is
+// derived from COSCUP_CREDENTIALS_PASSPHRASE when set; otherwise a random
+// key is kept next to the file, readable only by the current user, which
+// still keeps tokens out of backups and config sharing that skip it.
+type fileStore struct {
+	path string
+	key  []byte
+}
+
+func newFileStore() (*fileStore, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	dir = filepath.Join(dir, "coscup")
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+
+	key, err := fileStoreKey(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load credentials key: %v", err)
+	}
+	return &fileStore{path: filepath.Join(dir, "credentials.enc"), key: key}, nil
+}
+
+func fileStoreKey(dir string) ([]byte, error) {
+	if passphrase := os.Getenv("COSCUP_CREDENTIALS_PASSPHRASE"); passphrase != "" {
+		return scrypt.Key([]byte(passphrase), []byte("coscupctl credentials"), 1<<15, 8, 1, 32)
+	}
+
+	// A key of the wrong size is reported rather than replaced: replacing it
+	// would make the credentials sealed with it unreadable for good.
+	path := filepath.Join(dir, "credentials.key")
+	key, err := os.ReadFile(path)
+	switch {
+	case err == nil && len(key) != 32:
+		return nil, fmt.Errorf("%s holds %d bytes, want 32; restore it or remove it together with %s",
+			path, len(key), filepath.Join(dir, "credentials.enc"))
+	case err == nil:
+		return key, nil
+	case !errors.Is(err, fs.ErrNotExist):
+		return nil, err
+	}
+
+	key = make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := file.Write(key); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return key, file.Close()
+}
+
+func (f *fileStore) read() (map[string][]byte, error) {
+	entries := make(map[string][]byte)
+
+	sealed, err := os.ReadFile(f.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := f.cipher()
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errors.New("credentials file is corrupted")
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, errors.New("cannot decrypt credentials file, check COSCUP_CREDENTIALS_PASSPHRASE")
+	}
+
+	if err := json.Unmarshal(plaintext, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (f *fileStore) write(entries map[string][]byte) error {
+	plaintext, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	gcm, err := f.cipher()
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	return os.WriteFile(f.path, gcm.Seal(nonce, nonce, plaintext, nil), 0o600)
+}
+
+func (f *fileStore) cipher() (cipher.AEAD, error) {
+	block, err := aes.NewCipher(f.key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func (f *fileStore) get(server string) ([]byte, error) {
+	entries, err := f.read()
+	if err != nil {
+		return nil, err
+	}
+	return entries[server], nil
+}
+
+func (f *fileStore) set(server string, data []byte) error {
+	entries, err := f.read()
+	if err != nil {
+		return err
+	}
+	entries[server] = data
+	return f.write(entries)
+}
+
+func (f *fileStore) delete(server string) error {
+	entries, err := f.read()
+	if err != nil {
+		return err
+	}
+	delete(entries, server)
+	return f.write(entries)
+}
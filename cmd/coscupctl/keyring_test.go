@@ -0,0 +1,35 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileStoreKey(t *testing.T) {
+	t.Setenv("COSCUP_CREDENTIALS_PASSPHRASE", "")
+	dir := t.TempDir()
+	path := filepath.Join(dir, "credentials.key")
+
+	key, err := fileStoreKey(dir)
+	require.NoError(t, err)
+	assert.Len(t, key, 32)
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	again, err := fileStoreKey(dir)
+	require.NoError(t, err)
+	assert.Equal(t, key, again, "the key is kept")
+
+	require.NoError(t, os.WriteFile(path, key[:16], 0o600))
+	_, err = fileStoreKey(dir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), path)
+	truncated, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, key[:16], truncated, "a damaged key is not overwritten")
+}
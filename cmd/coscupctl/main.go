@@ -0,0 +1,23 @@
+// Command coscupctl is the command-line client for the COSCUP 2025 auth and
+// media services.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+func main() {
+	opts := &options{}
+	if err := newRootCmd(opts).Execute(); err != nil {
+		switch {
+		case errors.Is(err, errReported):
+		case opts.json:
+			writeJSON(errorJSON{Error: err.Error()})
+		default:
+			fmt.Fprintln(os.Stderr, "Error:", err)
+		}
+		os.Exit(1)
+	}
+}
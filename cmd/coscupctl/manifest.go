@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// manifestEntry is one video to download, with its output path.
+type manifestEntry struct {
+	videoID string
+	path    string
+}
+
+// readManifest parses a manifest with one video ID per line, optionally
+// followed by an output path. Blank lines and lines starting with # are
+// ignored; entries without a path are saved as <outputDir>/<video_id>.
+func readManifest(manifestPath, outputDir string) ([]manifestEntry, error) {
+	f, err := os.Open(manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open manifest: %v", err)
+	}
+	defer f.Close()
+
+	var entries []manifestEntry
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fields := strings.Fields(text)
+		if len(fields) > 2 {
+			return nil, fmt.Errorf("%s:%d: expected <video_id> [<output_path>]", manifestPath, line)
+		}
+		entry := manifestEntry{videoID: fields[0], path: filepath.Join(outputDir, fields[0])}
+		if len(fields) == 2 {
+			entry.path = fields[1]
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %v", err)
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("manifest %s lists no videos", manifestPath)
+	}
+	return entries, nil
+}
+
+// downloadBatch downloads the manifest entries with the given number of
+// concurrent workers. Each item is retried on its own by fetch, so one
+// failing video does not stop the others.
+func (dl *downloader) downloadBatch(ctx context.Context, entries []manifestEntry, workers int, resume bool) []batchResult {
+	dl.plainProgress = true
+
+	jobs := make(chan int)
+	results := make([]batchResult, len(entries))
+
+	var wg sync.WaitGroup
+	for range max(workers, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				e := entries[i]
+				r := batchResult{path: e.path, videoID: e.videoID}
+				start := time.Now()
+				if dir := filepath.Dir(e.path); dir != "." {
+					if err := os.MkdirAll(dir, 0o755); err != nil {
+						r.err = err
+						results[i] = r
+						continue
+					}
+				}
+				d, err := dl.fetch(ctx, e.videoID, e.path, resume)
+				r.elapsed = time.Since(start)
+				if err != nil {
+					r.err = err
+				} else {
+					r.size = d.written
+					r.sha256 = fmt.Sprintf("%x", d.hasher.Sum(nil))
+				}
+				results[i] = r
+			}
+		}()
+	}
+
+	for i := range entries {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
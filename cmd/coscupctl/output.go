@@ -0,0 +1,95 @@
+package main
+
+import (
+	"coscup2025/proto/media"
+	"encoding/json"
+	"errors"
+	"os"
+	"time"
+)
+
+// errReported is returned when the failure was already written as part of
+// the command's output, so only the exit status is left to set.
+var errReported = errors.New("failure already reported")
+
+// videoJSON is the --json form of a video and its metadata.
+type videoJSON struct {
+	VideoID      string    `json:"video_id"`
+	UploaderID   string    `json:"uploader_id"`
+	UploaderName string    `json:"uploader_name"`
+	FileName     string    `json:"file_name"`
+	FileSize     int64     `json:"file_size"`
+	SHA256       string    `json:"sha256,omitempty"`
+	Tags         []string  `json:"tags"`
+	UploadedAt   time.Time `json:"uploaded_at"`
+}
+
+func newVideoJSON(videoID string, md *media.VideoMetadata) videoJSON {
+	return videoJSON{
+		VideoID:      videoID,
+		UploaderID:   md.GetUploaderId(),
+		UploaderName: md.GetUploaderName(),
+		FileName:     md.GetFileName(),
+		FileSize:     md.GetFileSize(),
+		SHA256:       md.GetSha256(),
+		Tags:         append([]string{}, md.GetTags()...),
+		UploadedAt:   time.Unix(md.GetUploadTimestamp(), 0).UTC(),
+	}
+}
+
+// transferJSON is the --json form of one upload or download.
+type transferJSON struct {
+	VideoID   string `json:"video_id"`
+	Path      string `json:"path,omitempty"`
+	Bytes     int64  `json:"bytes"`
+	SHA256    string `json:"sha256,omitempty"`
+	ElapsedMS int64  `json:"elapsed_ms"`
+	Error     string `json:"error,omitempty"`
+	// Server is what the server measured of the last stream.
+	Server *statsJSON `json:"server,omitempty"`
+}
+
+// statsJSON is the --json form of the stats of a transfer stream.
+type statsJSON struct {
+	DurationMS         int64   `json:"duration_ms"`
+	Bytes              int64   `json:"bytes"`
+	BytesPerSecond     float64 `json:"bytes_per_second"`
+	ChunkCount         int64   `json:"chunk_count"`
+	Attempts           int64   `json:"attempts"`
+	RetransmittedBytes int64   `json:"retransmitted_bytes"`
+}
+
+func newStatsJSON(s *media.TransferStats) *statsJSON {
+	if s == nil {
+		return nil
+	}
+	return &statsJSON{
+		DurationMS:         s.DurationMs,
+		Bytes:              s.Bytes,
+		BytesPerSecond:     s.BytesPerSecond,
+		ChunkCount:         s.ChunkCount,
+		Attempts:           s.Attempts,
+		RetransmittedBytes: s.RetransmittedBytes,
+	}
+}
+
+// errorJSON is written instead of the result when a command fails.
+type errorJSON struct {
+	Error string `json:"error"`
+}
+
+// writeJSON prints v to stdout as indented JSON.
+func writeJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// messages is where human-readable output goes. With --json it is stderr so
+// that stdout carries nothing but the result.
+func (o *options) messages() *os.File {
+	if o.json {
+		return os.Stderr
+	}
+	return os.Stdout
+}
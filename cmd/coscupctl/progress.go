@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+)
+
+const (
+	barWidth         = 30
+	ttyRefreshPeriod = 100 * time.Millisecond
+	logRefreshPeriod = 5 * time.Second
+)
+
+// progress reports transfer progress with throughput and ETA. On a terminal it
+// redraws a single bar; otherwise it prints a log line every few seconds.
+type progress struct {
+	label string
+	total int64
+	done  int64
+	base  int64 // bytes already present before this run, excluded from the rate
+	start time.Time
+	drawn time.Time
+	tty   bool
+	out   *os.File
+}
+
+// newProgress draws on out, which is os.Stderr when stdout carries data.
+func newProgress(out *os.File, label string, total, done int64) *progress {
+	return &progress{
+		out:   out,
+		label: label,
+		total: total,
+		done:  done,
+		base:  done,
+		start: time.Now(),
+		tty:   isTerminal(out),
+	}
+}
+
+func isTerminal(f *os.File) bool {
+	return term.IsTerminal(int(f.Fd()))
+}
+
+// setTotal updates the expected size once it becomes known.
+func (p *progress) setTotal(total int64) {
+	p.total = total
+}
+
+// set moves the progress to done bytes, e.g. back to the bytes the server
+// kept after an interrupted upload.
+func (p *progress) set(done int64) {
+	p.done = done
+
+	period := logRefreshPeriod
+	if p.tty {
+		period = ttyRefreshPeriod
+	}
+	if now := time.Now(); now.Sub(p.drawn) >= period {
+		p.drawn = now
+		p.draw()
+	}
+}
+
+// finish draws the final state and ends the progress line.
+func (p *progress) finish() {
+	p.draw()
+	if p.tty {
+		fmt.Fprintln(p.out)
+	}
+}
+
+func (p *progress) draw() {
+	elapsed := time.Since(p.start).Seconds()
+	rate := 0.0
+	if elapsed > 0 {
+		rate = float64(p.done-p.base) / elapsed
+	}
+
+	eta := "--"
+	percent := -1.0
+	if p.total > 0 {
+		percent = min(float64(p.done)/float64(p.total)*100, 100)
+		if rate > 0 {
+			eta = formatDuration(time.Duration(float64(p.total-p.done) / rate * float64(time.Second)))
+		}
+	}
+
+	if !p.tty {
+		if percent >= 0 {
+			fmt.Fprintf(p.out, "%s: %.1f%% (%s of %s), %s/s, ETA %s\n",
+				p.label, percent, formatBytes(p.done), formatBytes(p.total), formatBytes(int64(rate)), eta)
+		} else {
+			fmt.Fprintf(p.out, "%s: %s, %s/s\n", p.label, formatBytes(p.done), formatBytes(int64(rate)))
+		}
+		return
+	}
+
+	bar := strings.Repeat("?", barWidth)
+	if percent >= 0 {
+		filled := int(percent / 100 * barWidth)
+		bar = strings.Repeat("=", filled) + strings.Repeat(" ", barWidth-filled)
+	}
+	fmt.Fprintf(p.out, "\r%s [%s] %5.1f%% %10s %10s/s ETA %-8s", p.label, bar, max(percent, 0),
+		formatBytes(p.done), formatBytes(int64(rate)), eta)
+}
+
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	h, m, s := int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, s)
+	}
+	return fmt.Sprintf("%d:%02d", m, s)
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"golang.org/x/net/http/httpproxy"
+	"golang.org/x/net/proxy"
+	"google.golang.org/grpc"
+)
+
+// proxyDialOption routes connections through --proxy, or through a SOCKS5
+// proxy in ALL_PROXY except to the hosts in NO_PROXY. Without either, grpc's
+// default dialer is kept, which already honors HTTPS_PROXY and NO_PROXY.
+func (o *options) proxyDialOption() (grpc.DialOption, error) {
+	if o.proxy == "" {
+		raw := firstNonEmpty(os.Getenv("ALL_PROXY"), os.Getenv("all_proxy"))
+		u, err := url.Parse(raw)
+		if err != nil || (u.Scheme != "socks5" && u.Scheme != "socks5h") {
+			return nil, nil
+		}
+		dialer, err := proxy.FromURL(u, proxy.Direct)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ALL_PROXY: %v", err)
+		}
+		noProxy := firstNonEmpty(os.Getenv("NO_PROXY"), os.Getenv("no_proxy"))
+		return grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			if bypassesProxy(noProxy, addr) {
+				var d net.Dialer
+				return d.DialContext(ctx, "tcp", addr)
+			}
+			return dialer.(proxy.ContextDialer).DialContext(ctx, "tcp", addr)
+		}), nil
+	}
+
+	u, err := url.Parse(o.proxy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %v", err)
+	}
+
+	switch u.Scheme {
+	case "http":
+		return grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			return dialConnect(ctx, u, addr)
+		}), nil
+	case "socks5", "socks5h":
+		dialer, err := proxy.FromURL(u, proxy.Direct)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %v", err)
+		}
+		return socksDialOption(dialer), nil
+	}
+	return nil, fmt.Errorf("unsupported proxy scheme %q, use http or socks5", u.Scheme)
+}
+
+func socksDialOption(dialer proxy.Dialer) grpc.DialOption {
+	return grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+		return dialer.(proxy.ContextDialer).DialContext(ctx, "tcp", addr)
+	})
+}
+
+// bypassesProxy reports whether NO_PROXY exempts addr (host:port) from the
+// proxy, by the rules grpc applies to HTTPS_PROXY: "*" matches every host,
+// "example.com" matches it and its subdomains, ".example.com" only its
+// subdomains, an entry with a port matches only that port, and IP addresses
+// and CIDR ranges match IP literals. The local host is always exempt.
+func bypassesProxy(noProxy, addr string) bool {
+	// Only whether a proxy is returned matters, not which.
+	cfg := httpproxy.Config{HTTPSProxy: "socks5://proxy", NoProxy: noProxy}
+	u, err := cfg.ProxyFunc()(&url.URL{Scheme: "https", Host: addr})
+	return err == nil && u == nil
+}
+
+// dialConnect opens a tunnel to addr through an HTTP proxy with CONNECT.
+func dialConnect(ctx context.Context, proxyURL *url.URL, addr string) (net.Conn, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", proxyURL.Host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach proxy: %v", err)
+	}
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Host: addr},
+		Host:   addr,
+		Header: make(http.Header),
+	}
+	if user := proxyURL.User; user != nil {
+		password, _ := user.Password()
+		credentials := base64.StdEncoding.EncodeToString([]byte(user.Username() + ":" + password))
+		req.Header.Set("Proxy-Authorization", "Basic "+credentials)
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+		defer conn.SetDeadline(time.Time{})
+	}
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to send CONNECT: %v", err)
+	}
+
+	// The server speaks only after the client, so nothing past the response
+	// is buffered.
+	resp, err := http.ReadResponse(bufio.NewReader(conn), req)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to read CONNECT response: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		conn.Close()
+		return nil, fmt.Errorf("proxy refused CONNECT: %s", resp.Status)
+	}
+	return conn, nil
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"coscup2025/client"
+)
+
+func TestBypassesProxy(t *testing.T) {
+	tests := []struct {
+		name    string
+		noProxy string
+		addr    string
+		want    bool
+	}{
+		{"empty", "", "media.coscup.org:443", false},
+		{"exact host", "media.coscup.org", "media.coscup.org:443", true},
+		{"other host", "media.coscup.org", "api.coscup.org:443", false},
+		{"host matches subdomains", "coscup.org", "media.coscup.org:443", true},
+		{"dot suffix", ".coscup.org", "media.coscup.org:443", true},
+		{"dot suffix leaves out the domain", ".coscup.org", "coscup.org:443", false},
+		{"suffix is not a substring", ".coscup.org", "notcoscup.org:443", false},
+		{"wildcard", "*", "media.coscup.org:443", true},
+		{"host and port", "media.coscup.org:50051", "media.coscup.org:50051", true},
+		{"host and other port", "media.coscup.org:50051", "media.coscup.org:443", false},
+		{"IP literal", "10.0.0.5", "10.0.0.5:50051", true},
+		{"other IP", "10.0.0.5", "10.0.0.6:50051", false},
+		{"CIDR", "10.0.0.0/8", "10.1.2.3:50051", true},
+		{"IPv6 literal", "2001:db8::1", "[2001:db8::1]:50051", true},
+		{"list", "example.com, .coscup.org", "media.coscup.org:443", true},
+		{"local host", "", "localhost:50051", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, bypassesProxy(tt.noProxy, tt.addr))
+		})
+	}
+}
+
+// socksServer accepts SOCKS5 CONNECT requests and sends the address each one
+// asked for on targets, then closes the connection.
+func socksServer(t *testing.T) (addr string, targets <-chan string) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { lis.Close() })
+	ch := make(chan string, 16)
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				if target, err := socksConnect(conn); err == nil {
+					ch <- target
+				}
+			}()
+		}
+	}()
+	return lis.Addr().String(), ch
+}
+
+// socksConnect answers the greeting and the CONNECT request on conn, and
+// returns the requested address.
+func socksConnect(conn net.Conn) (string, error) {
+	var greeting [2]byte
+	if _, err := io.ReadFull(conn, greeting[:]); err != nil {
+		return "", err
+	}
+	if _, err := io.ReadFull(conn, make([]byte, greeting[1])); err != nil {
+		return "", err
+	}
+	conn.Write([]byte{5, 0}) // no authentication
+
+	var req [4]byte
+	if _, err := io.ReadFull(conn, req[:]); err != nil {
+		return "", err
+	}
+	var host string
+	switch req[3] {
+	case 1, 4: // IPv4, IPv6
+		ip := make(net.IP, map[byte]int{1: 4, 4: 16}[req[3]])
+		if _, err := io.ReadFull(conn, ip); err != nil {
+			return "", err
+		}
+		host = ip.String()
+	case 3: // domain name
+		var n [1]byte
+		if _, err := io.ReadFull(conn, n[:]); err != nil {
+			return "", err
+		}
+		name := make([]byte, n[0])
+		if _, err := io.ReadFull(conn, name); err != nil {
+			return "", err
+		}
+		host = string(name)
+	default:
+		return "", fmt.Errorf("unknown address type %d", req[3])
+	}
+	var port [2]byte
+	if _, err := io.ReadFull(conn, port[:]); err != nil {
+		return "", err
+	}
+	conn.Write([]byte{5, 0, 0, 1, 0, 0, 0, 0, 0, 0}) // succeeded
+	return net.JoinHostPort(host, strconv.Itoa(int(binary.BigEndian.Uint16(port[:])))), nil
+}
+
+func TestSocks5hSendsTheHostname(t *testing.T) {
+	proxyAddr, targets := socksServer(t)
+	for name, setup := range map[string]func(o *options){
+		"flag": func(o *options) { o.proxy = "socks5h://" + proxyAddr },
+		"env": func(o *options) {
+			t.Setenv("ALL_PROXY", "socks5h://"+proxyAddr)
+			t.Setenv("NO_PROXY", "example.com")
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			o := &options{server: "media.coscup.invalid:50051", retry: client.DefaultRetryPolicy}
+			setup(o)
+			conn, err := o.dial()
+			require.NoError(t, err)
+			defer conn.Close()
+			conn.Connect()
+
+			select {
+			case target := <-targets:
+				// A name that does not resolve reached the proxy as it is
+				assert.Equal(t, "media.coscup.invalid:50051", target)
+			case <-time.After(5 * time.Second):
+				t.Fatal("the proxy received no connection")
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"coscup2025/client"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/time/rate"
+)
+
+// parseRate parses a --limit-rate value in bytes per second with an optional
+// K, M or G suffix (powers of 1024), e.g. "500K" or "2M". Zero disables the
+// limit.
+func parseRate(s string) (int64, error) {
+	if s == "" || s == "0" {
+		return 0, nil
+	}
+
+	multiplier := int64(1)
+	switch strings.ToUpper(s[len(s)-1:]) {
+	case "K":
+		multiplier = 1 << 10
+	case "M":
+		multiplier = 1 << 20
+	case "G":
+		multiplier = 1 << 30
+	}
+	if multiplier > 1 {
+		s = s[:len(s)-1]
+	}
+
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid rate %q, expected e.g. 500K or 2M", s)
+	}
+	return int64(n * float64(multiplier)), nil
+}
+
+// bandwidth caps the combined throughput of all transfers sharing it. A nil
+// bandwidth is unlimited.
+type bandwidth struct {
+	limiter *rate.Limiter
+}
+
+func newBandwidth(bytesPerSecond int64) *bandwidth {
+	if bytesPerSecond <= 0 {
+		return nil
+	}
+	// The burst must fit a whole chunk, so a limit below the chunk size is
+	// enforced on average rather than per second.
+	return &bandwidth{limiter: rate.NewLimiter(rate.Limit(bytesPerSecond), client.ChunkSize)}
+}
+
+// wait blocks until n bytes may be transferred.
+func (b *bandwidth) wait(ctx context.Context, n int) error {
+	if b == nil {
+		return nil
+	}
+	for n > 0 {
+		step := min(n, b.limiter.Burst())
+		if err := b.limiter.WaitN(ctx, step); err != nil {
+			return err
+		}
+		n -= step
+	}
+	return nil
+}
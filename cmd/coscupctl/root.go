@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"coscup2025/client"
+	"errors"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// options holds the flags shared by every subcommand.
+type options struct {
+	configPath string
+	server     string
+	token      string
+	tls        tlsOptions
+	proxy      string
+	retry      client.RetryPolicy
+	json       bool
+
+	// callTimeout bounds unary calls; transferTimeout bounds each upload or
+	// download stream and is unlimited when zero.
+	callTimeout     time.Duration
+	transferTimeout time.Duration
+
+	// cached is set when the token comes from `coscupctl login`.
+	cached *credentials
+}
+
+func newRootCmd(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "coscupctl",
+		Short:         "Command-line client for the COSCUP 2025 media service",
+		SilenceUsage:  true,
+		SilenceErrors: true, // printed by main, as JSON with --json
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.resolve(cmd)
+		},
+	}
+
+	cmd.PersistentFlags().StringVar(&opts.configPath, "config", defaultConfigPath(), "config file providing server and token defaults")
+	cmd.PersistentFlags().StringVar(&opts.server, "server", "localhost:50051", "gRPC server address (env COSCUP_SERVER)")
+	cmd.PersistentFlags().StringVar(&opts.token, "token", "", "JWT used to authenticate requests (env COSCUP_TOKEN)")
+	cmd.PersistentFlags().BoolVar(&opts.tls.enabled, "tls", false, "connect over TLS, verified against the system roots unless --ca-cert is given")
+	cmd.PersistentFlags().StringVar(&opts.tls.caCert, "ca-cert", "", "PEM file with the CA certificates used to verify the server (implies --tls)")
+	cmd.PersistentFlags().StringVar(&opts.tls.clientCert, "client-cert", "", "PEM client certificate for mutual TLS (implies --tls)")
+	cmd.PersistentFlags().StringVar(&opts.tls.clientKey, "client-key", "", "PEM private key for --client-cert")
+	cmd.PersistentFlags().BoolVar(&opts.json, "json", false, "print results as JSON on stdout, and messages on stderr")
+	cmd.PersistentFlags().StringVar(&opts.proxy, "proxy", "", "proxy URL, http://[user:pass@]host:port or socks5://host:port (default from HTTPS_PROXY or a socks5 ALL_PROXY)")
+	cmd.PersistentFlags().DurationVar(&opts.callTimeout, "timeout", 30*time.Second, "deadline for each non-streaming call, 0 for none")
+	cmd.PersistentFlags().DurationVar(&opts.transferTimeout, "transfer-timeout", 0, "deadline for each upload or download stream attempt, 0 for none")
+	cmd.PersistentFlags().IntVar(&opts.retry.MaxAttempts, "max-attempts", 5, "attempts per call or transfer before giving up on transient errors")
+	cmd.PersistentFlags().DurationVar(&opts.retry.InitialBackoff, "retry-backoff", time.Second, "initial delay between retries, doubled after each attempt")
+
+	cmd.AddCommand(
+		newSignUpCmd(opts),
+		newLoginCmd(opts),
+		newLogoutCmd(opts),
+		newProfileCmd(opts),
+		newTermsCmd(opts),
+		newUploadCmd(opts),
+		newDownloadCmd(opts),
+		newListCmd(opts),
+		newDeleteCmd(opts),
+		newMetadataCmd(opts),
+		newBenchCmd(opts),
+	)
+
+	return cmd
+}
+
+// dial opens a client connection to the configured server.
+func (o *options) dial() (*grpc.ClientConn, error) {
+	creds, err := o.tls.transportCredentials()
+	if err != nil {
+		return nil, err
+	}
+	dialOpts := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithDefaultServiceConfig(o.retry.ServiceConfig()),
+	}
+
+	proxyOpt, err := o.proxyDialOption()
+	if err != nil {
+		return nil, err
+	}
+	target := o.server
+	if proxyOpt != nil {
+		dialOpts = append(dialOpts, proxyOpt)
+		// Hand the proxy dialer the server's name rather than the addresses
+		// grpc would resolve it to, for socks5h and NO_PROXY.
+		target = "passthrough:///" + o.server
+	}
+
+	return grpc.NewClient(target, dialOpts...)
+}
+
+// callContext applies the unary call deadline to ctx.
+func (o *options) callContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return withTimeout(ctx, o.callTimeout)
+}
+
+// withTimeout is context.WithTimeout, except that a zero timeout means no
+// deadline.
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// authContext attaches the bearer token to ctx.
+func (o *options) authContext(ctx context.Context) (context.Context, error) {
+	if o.token == "" {
+		return nil, errors.New("not logged in, run `coscupctl login` or pass --token")
+	}
+	if o.cached != nil && time.Until(o.cached.ExpiresAt) < refreshMargin {
+		if err := o.refreshCredentials(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return metadata.NewOutgoingContext(ctx, metadata.Pairs("authorization", "Bearer "+o.token)), nil
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"coscup2025/proto/media"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// fingerprintSize is how much of the file is hashed to detect local changes
+// between an interrupted upload and its resumption.
+const fingerprintSize = 1024 * 1024
+
+// uploadState is persisted while an upload is in flight so that an
+// interrupted upload can continue from the server's committed offset.
+type uploadState struct {
+	UploadID    string    `json:"upload_id"`
+	Server      string    `json:"server"`
+	VideoID     string    `json:"video_id"`
+	Path        string    `json:"path"`
+	Size        int64     `json:"size"`
+	ModTime     time.Time `json:"mod_time"`
+	Fingerprint string    `json:"fingerprint"`
+}
+
+func newUploadState(server, videoID, filePath string, file *os.File, info fs.FileInfo) (*uploadState, error) {
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	h := sha256.New()
+	if _, err := io.Copy(h, io.NewSectionReader(file, 0, fingerprintSize)); err != nil {
+		return nil, err
+	}
+
+	return &uploadState{
+		Server:      server,
+		VideoID:     videoID,
+		Path:        absPath,
+		Size:        info.Size(),
+		ModTime:     info.ModTime(),
+		Fingerprint: fmt.Sprintf("%x", h.Sum(nil)),
+	}, nil
+}
+
+// path is where the state for this server, video and file is stored.
+func (s *uploadState) path() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	key := sha256.Sum256([]byte(s.Server + "\x00" + s.VideoID + "\x00" + s.Path))
+	return filepath.Join(dir, "coscup", "uploads", fmt.Sprintf("%x.json", key[:8]))
+}
+
+// matches reports whether the saved state describes the same, unmodified file.
+func (s *uploadState) matches(current *uploadState) bool {
+	return s.Size == current.Size &&
+		s.ModTime.Equal(current.ModTime) &&
+		s.Fingerprint == current.Fingerprint
+}
+
+// checkSession returns why session, as the server reports it, cannot continue
+// the upload of this file, or nil if it can.
+func (s *uploadState) checkSession(session *media.UploadSession) error {
+	switch {
+	case session.VideoId != s.VideoID:
+		return fmt.Errorf("the session uploads %s", session.VideoId)
+	case session.TotalSize != s.Size:
+		return fmt.Errorf("the session expects %d bytes, the file has %d", session.TotalSize, s.Size)
+	case session.CommittedBytes < 0 || session.CommittedBytes > session.TotalSize:
+		return fmt.Errorf("committed offset %d is outside the file", session.CommittedBytes)
+	}
+	return nil
+}
+
+func (s *uploadState) save() error {
+	path := s.path()
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+func (s *uploadState) remove() error {
+	err := os.Remove(s.path())
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// loadUploadState returns nil when no upload is pending, or when the state
+// file is damaged, since the upload can only start over then.
+func loadUploadState(path string) (*uploadState, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var s uploadState
+	if err := json.Unmarshal(data, &s); err != nil {
+		log.Printf("Ignoring damaged upload state %s: %v", path, err)
+		return nil, nil
+	}
+	return &s, nil
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"coscup2025/proto/media"
+)
+
+// stateOf returns the upload state of the file at path, keeping state files
+// in a temporary cache directory.
+func stateOf(t *testing.T, path string) *uploadState {
+	t.Helper()
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+	info, err := file.Stat()
+	require.NoError(t, err)
+	state, err := newUploadState("localhost:50051", "talk", path, file, info)
+	require.NoError(t, err)
+	return state
+}
+
+func writeVideo(t *testing.T, data string) string {
+	t.Helper()
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	path := filepath.Join(t.TempDir(), "talk.mp4")
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+	return path
+}
+
+func TestUploadStateRoundTrip(t *testing.T) {
+	state := stateOf(t, writeVideo(t, "recording"))
+	state.UploadID = "upload_1"
+	require.NoError(t, state.save())
+
+	saved, err := loadUploadState(state.path())
+	require.NoError(t, err)
+	require.NotNil(t, saved)
+	assert.Equal(t, "upload_1", saved.UploadID)
+	assert.True(t, saved.matches(state))
+
+	require.NoError(t, state.remove())
+	saved, err = loadUploadState(state.path())
+	require.NoError(t, err)
+	assert.Nil(t, saved)
+	assert.NoError(t, state.remove(), "removing twice is fine")
+}
+
+func TestUploadStateDetectsChangedFile(t *testing.T) {
+	path := writeVideo(t, "recording")
+	modTime := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+	saved := stateOf(t, path)
+
+	tests := []struct {
+		name   string
+		change func()
+	}{
+		{"touched", func() {
+			other := modTime.Add(time.Minute)
+			require.NoError(t, os.Chtimes(path, other, other))
+		}},
+		{"same size and time, other bytes", func() {
+			require.NoError(t, os.WriteFile(path, []byte("rEcording"), 0o600))
+			require.NoError(t, os.Chtimes(path, modTime, modTime))
+		}},
+		{"grown", func() {
+			require.NoError(t, os.WriteFile(path, []byte("recording, part 2"), 0o600))
+			require.NoError(t, os.Chtimes(path, modTime, modTime))
+		}},
+	}
+	assert.True(t, saved.matches(stateOf(t, path)), "unchanged")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.change()
+			assert.False(t, saved.matches(stateOf(t, path)))
+		})
+	}
+}
+
+func TestDamagedUploadStateStartsOver(t *testing.T) {
+	state := stateOf(t, writeVideo(t, "recording"))
+	require.NoError(t, state.save())
+	for _, data := range []string{"", "{\"upload_id\": \"upl", "not json"} {
+		require.NoError(t, os.WriteFile(state.path(), []byte(data), 0o600))
+		saved, err := loadUploadState(state.path())
+		assert.NoError(t, err, "%q", data)
+		assert.Nil(t, saved, "%q", data)
+	}
+}
+
+func TestUploadStateChecksSession(t *testing.T) {
+	state := stateOf(t, writeVideo(t, "recording")) // 9 bytes
+	tests := []struct {
+		name    string
+		session *media.UploadSession
+		ok      bool
+	}{
+		{"fresh", &media.UploadSession{VideoId: "talk", TotalSize: 9}, true},
+		{"halfway", &media.UploadSession{VideoId: "talk", TotalSize: 9, CommittedBytes: 4}, true},
+		{"complete", &media.UploadSession{VideoId: "talk", TotalSize: 9, CommittedBytes: 9}, true},
+		{"past the end", &media.UploadSession{VideoId: "talk", TotalSize: 9, CommittedBytes: 10}, false},
+		{"negative", &media.UploadSession{VideoId: "talk", TotalSize: 9, CommittedBytes: -1}, false},
+		{"other size", &media.UploadSession{VideoId: "talk", TotalSize: 20, CommittedBytes: 4}, false},
+		{"other video", &media.UploadSession{VideoId: "keynote", TotalSize: 9}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := state.checkSession(tt.session)
+			if tt.ok {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
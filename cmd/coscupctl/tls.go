@@ -0,0 +1,55 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	grpccreds "google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// tlsOptions selects the transport security used to reach the server.
+type tlsOptions struct {
+	enabled    bool
+	caCert     string
+	clientCert string
+	clientKey  string
+}
+
+// transportCredentials returns insecure credentials unless TLS is enabled or
+// implied by a CA or client certificate. Without --ca-cert the system roots
+// are used.
+func (t tlsOptions) transportCredentials() (grpccreds.TransportCredentials, error) {
+	if !t.enabled && t.caCert == "" && t.clientCert == "" {
+		return insecure.NewCredentials(), nil
+	}
+
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if t.caCert != "" {
+		pem, err := os.ReadFile(t.caCert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificate: %v", err)
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", t.caCert)
+		}
+	}
+
+	if (t.clientCert == "") != (t.clientKey == "") {
+		return nil, errors.New("--client-cert and --client-key must be given together")
+	}
+	if t.clientCert != "" {
+		cert, err := tls.LoadX509KeyPair(t.clientCert, t.clientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %v", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return grpccreds.NewTLS(config), nil
+}
@@ -0,0 +1,319 @@
+package main
+
+import (
+	"context"
+	"coscup2025/client"
+	"coscup2025/proto/media"
+	"coscup2025/synthmedia"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newUploadCmd(opts *options) *cobra.Command {
+	var batch string
+	var workers int
+	var stdin bool
+	var size int64
+	var watchDir, doneDir string
+	var settle time.Duration
+	var limitRate string
+	var tags []string
+	var overwrite bool
+	var synthetic string
+	var seed uint64
+	var check bool
+
+	cmd := &cobra.Command{
+		Use:   "upload <video_id> <video_file_path> | <video_id> --stdin --size <bytes> | <video_id> --synthetic <size> | --batch <dir|glob> | --watch <dir>",
+		Short: "Upload video files, resuming earlier interrupted uploads of them",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if check && watchDir != "" {
+				return errors.New("--check cannot be combined with --watch")
+			}
+			switch {
+			case batch != "" || watchDir != "":
+				return cobra.NoArgs(cmd, args)
+			case stdin:
+				if size <= 0 {
+					return errors.New("--stdin requires --size")
+				}
+				return cobra.ExactArgs(1)(cmd, args)
+			case synthetic != "":
+				return cobra.ExactArgs(1)(cmd, args)
+			}
+			return cobra.ExactArgs(2)(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			bytesPerSecond, err := parseRate(limitRate)
+			if err != nil {
+				return err
+			}
+
+			ctx, err := opts.authContext(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			conn, err := opts.dial()
+			if err != nil {
+				return fmt.Errorf("failed to connect to server: %v", err)
+			}
+			defer conn.Close()
+
+			if check {
+				files, err := preflightFiles(args, batch, stdin, size, synthetic)
+				if err != nil {
+					return err
+				}
+				ctx, cancel := opts.callContext(ctx)
+				defer cancel()
+
+				p := &preflight{
+					client:         media.NewMediaServiceClient(conn),
+					token:          opts.token,
+					overwrite:      overwrite,
+					bytesPerSecond: bytesPerSecond,
+				}
+				if err := p.run(ctx, files); err != nil {
+					return err
+				}
+				return p.report(opts)
+			}
+
+			u := &uploader{
+				client:    client.NewFromConn(conn, opts.retry),
+				media:     media.NewMediaServiceClient(conn),
+				server:    opts.server,
+				retry:     opts.retry,
+				out:       opts.messages(),
+				limit:     newBandwidth(bytesPerSecond),
+				timeout:   opts.transferTimeout,
+				tags:      tags,
+				overwrite: overwrite,
+			}
+
+			if watchDir != "" {
+				ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+				defer stop()
+
+				u.plainProgress = true
+				w := &watcher{opts: opts, u: u, dir: watchDir, doneDir: doneDir, settle: settle, files: make(map[string]*watchedFile)}
+				return w.run(ctx)
+			}
+
+			if batch != "" {
+				files, err := expandBatch(batch)
+				if err != nil {
+					return err
+				}
+				results := u.uploadBatch(ctx, files, workers)
+				return reportBatch(opts, "upload", results)
+			}
+
+			videoID := args[0]
+			start := time.Now()
+			var resp *media.UploadVideoResponse
+			switch {
+			case stdin:
+				resp, err = u.uploadStream(ctx, videoID, os.Stdin, size)
+			case synthetic != "":
+				n, parseErr := parseRate(synthetic)
+				if parseErr != nil || n <= 0 {
+					return fmt.Errorf("invalid --synthetic size %q, expected e.g. 500M or 4G", synthetic)
+				}
+				resp, err = u.uploadStream(ctx, videoID, synthmedia.New(seed, n), n)
+			default:
+				resp, err = u.upload(ctx, videoID, args[1])
+			}
+			if err != nil {
+				return fmt.Errorf("failed to upload video: %v", err)
+			}
+
+			if opts.json {
+				result := transferJSON{
+					VideoID:   resp.VideoId,
+					Bytes:     resp.TotalBytes,
+					SHA256:    resp.Metadata.GetSha256(),
+					ElapsedMS: time.Since(start).Milliseconds(),
+					Server:    newStatsJSON(resp.Stats),
+				}
+				if len(args) == 2 {
+					result.Path = args[1]
+				}
+				return writeJSON(result)
+			}
+			fmt.Printf("Successfully uploaded video: %s\n", videoID)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&batch, "batch", "", "upload every file in a directory or matching a glob, using file names as video IDs")
+	cmd.Flags().IntVar(&workers, "workers", 4, "concurrent uploads in --batch mode")
+	cmd.Flags().StringVar(&watchDir, "watch", "", "keep running and upload every file written to this directory once it stops changing")
+	cmd.Flags().StringVar(&doneDir, "done-dir", "", "in --watch mode, move uploaded files to this directory")
+	cmd.Flags().DurationVar(&settle, "settle", 10*time.Second, "in --watch mode, how long a file must stay unchanged before it is uploaded")
+	cmd.Flags().StringSliceVar(&tags, "tag", nil, "label the video, e.g. with its track or room (repeatable)")
+	cmd.Flags().BoolVar(&overwrite, "overwrite", false, "replace videos you uploaded before under the same IDs")
+	cmd.Flags().StringVar(&limitRate, "limit-rate", "", "cap the total upload speed in bytes per second, e.g. 500K or 2M")
+	cmd.Flags().BoolVar(&stdin, "stdin", false, "read the video from stdin, e.g. piped from ffmpeg")
+	cmd.Flags().Int64Var(&size, "size", 0, "exact number of bytes to read with --stdin")
+	cmd.Flags().StringVar(&synthetic, "synthetic", "", "upload this many generated bytes instead of a file, e.g. 4G, to test large transfers (check them with download --verify-synthetic)")
+	cmd.Flags().Uint64Var(&seed, "seed", 1, "seed of the --synthetic content")
+	cmd.Flags().BoolVar(&check, "check", false, "only check that the server would accept the upload: token, limits, capacity, content type and video ID")
+
+	return cmd
+}
+
+// uploader uploads files through resumable upload sessions.
+type uploader struct {
+	client *client.Client
+	media  media.MediaServiceClient // for the sessions of upload state files
+	server string
+	retry  client.RetryPolicy
+	out    *os.File
+	limit  *bandwidth // shared by concurrent uploads
+	// timeout bounds each stream attempt; zero means none.
+	timeout time.Duration
+	tags    []string
+	// overwrite replaces videos already stored under the same IDs.
+	overwrite bool
+	// plainProgress forces log-line progress, used when several uploads
+	// share the terminal.
+	plainProgress bool
+}
+
+func (u *uploader) upload(ctx context.Context, videoID, filePath string) (*media.UploadVideoResponse, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %v", err)
+	}
+	defer file.Close()
+
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file info: %v", err)
+	}
+
+	state, err := newUploadState(u.server, videoID, filePath, file, fileInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fingerprint file: %v", err)
+	}
+
+	session, err := u.resumeOrCreateSession(ctx, state)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Fprintf(u.out, "Uploading video: %s (size: %d bytes)\n", videoID, fileInfo.Size())
+
+	opts, bar := u.uploadOptions(videoID, session.TotalSize, session.CommittedBytes)
+	response, err := u.client.Resume(ctx, session, file, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	bar.finish()
+
+	if err := state.remove(); err != nil {
+		log.Printf("Failed to remove upload state: %v", err)
+	}
+
+	fmt.Fprintf(u.out, "Upload completed: %s, %d bytes\n", response.VideoId, response.TotalBytes)
+	return response, nil
+}
+
+// uploadStream uploads exactly size bytes read from r. Nothing is persisted,
+// so an interrupted stream upload cannot be resumed by a later run. Readers
+// that cannot seek are replayed from the last DefaultReplayWindow bytes.
+func (u *uploader) uploadStream(ctx context.Context, videoID string, r io.Reader, size int64) (*media.UploadVideoResponse, error) {
+	fmt.Fprintf(u.out, "Uploading video: %s from a stream (size: %d bytes)\n", videoID, size)
+
+	opts, bar := u.uploadOptions(videoID, size, 0)
+	response, err := u.client.Upload(ctx, videoID, r, size, opts)
+	if err != nil {
+		return nil, err
+	}
+	bar.finish()
+
+	fmt.Fprintf(u.out, "Upload completed: %s, %d bytes\n", response.VideoId, response.TotalBytes)
+	return response, nil
+}
+
+// uploadOptions returns the options of an upload of videoID, whose progress
+// is drawn on the returned bar, starting at done of total bytes.
+func (u *uploader) uploadOptions(videoID string, total, done int64) (*client.UploadOptions, *progress) {
+	bar := newProgress(u.out, "upload "+videoID, total, done)
+	if u.plainProgress {
+		bar.tty = false
+	}
+	return &client.UploadOptions{
+		Tags:           u.tags,
+		Overwrite:      u.overwrite,
+		AttemptTimeout: u.timeout,
+		Wait:           u.limit.wait,
+		Progress:       bar.set,
+		Retrying: func(err error, attempt int, delay time.Duration) {
+			log.Printf("Upload of %s interrupted (%v), retrying in %s (attempt %d/%d)", videoID, err, delay.Round(time.Millisecond), attempt, u.retry.MaxAttempts)
+		},
+	}, bar
+}
+
+// resumeOrCreateSession continues the session recorded in the state file when
+// the local file is unchanged and the server still has it, and otherwise
+// starts a new one.
+func (u *uploader) resumeOrCreateSession(ctx context.Context, state *uploadState) (*media.UploadSession, error) {
+	saved, err := loadUploadState(state.path())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read upload state: %v", err)
+	}
+
+	if saved != nil {
+		if !saved.matches(state) {
+			fmt.Fprintln(u.out, "Local file changed since the interrupted upload, starting over")
+		} else {
+			resp, err := u.media.GetUploadSession(ctx, &media.GetUploadSessionRequest{UploadId: saved.UploadID})
+			switch {
+			case err == nil:
+				if err := state.checkSession(resp.Session); err != nil {
+					fmt.Fprintf(u.out, "Upload session does not match the file (%v), starting over\n", err)
+					break
+				}
+				fmt.Fprintf(u.out, "Resuming upload at %d of %d bytes\n", resp.Session.CommittedBytes, resp.Session.TotalSize)
+				return resp.Session, nil
+			case status.Code(err) == codes.NotFound:
+				fmt.Fprintln(u.out, "Upload session expired on the server, starting over")
+			default:
+				return nil, fmt.Errorf("failed to query upload session: %v", err)
+			}
+		}
+	}
+
+	resp, err := u.media.CreateUploadSession(ctx, &media.CreateUploadSessionRequest{
+		VideoId:   state.VideoID,
+		TotalSize: state.Size,
+		Tags:      u.tags,
+		Overwrite: u.overwrite,
+		FileName:  filepath.Base(state.Path),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create upload session: %v", err)
+	}
+
+	state.UploadID = resp.Session.UploadId
+	if err := state.save(); err != nil {
+		return nil, fmt.Errorf("failed to save upload state: %v", err)
+	}
+
+	return resp.Session, nil
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"bufio"
+	"coscup2025/proto/media"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func newListCmd(opts *options) *cobra.Command {
+	var uploader, since, tag string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List stored videos",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req := &media.ListVideosRequest{Uploader: uploader, Tag: tag}
+			if since != "" {
+				t, err := parseSince(since)
+				if err != nil {
+					return err
+				}
+				req.Since = t.Unix()
+			}
+
+			ctx, err := opts.authContext(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			conn, err := opts.dial()
+			if err != nil {
+				return fmt.Errorf("failed to connect to server: %v", err)
+			}
+			defer conn.Close()
+
+			ctx, cancel := opts.callContext(ctx)
+			defer cancel()
+
+			var videos []*media.VideoSummary
+			for {
+				resp, err := media.NewMediaServiceClient(conn).ListVideos(ctx, req)
+				if err != nil {
+					return fmt.Errorf("failed to list videos: %v", err)
+				}
+				videos = append(videos, resp.Videos...)
+				if resp.NextPageToken == "" {
+					break
+				}
+				req.PageToken = resp.NextPageToken
+			}
+
+			if opts.json {
+				out := make([]videoJSON, 0, len(videos))
+				for _, v := range videos {
+					out = append(out, newVideoJSON(v.VideoId, v.Metadata))
+				}
+				return writeJSON(out)
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "VIDEO ID\tUPLOADER\tSIZE\tUPLOADED\tTAGS")
+			for _, v := range videos {
+				md := v.Metadata
+				fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\n", v.VideoId, md.GetUploaderName(), md.GetFileSize(),
+					time.Unix(md.GetUploadTimestamp(), 0).Format("2006-01-02 15:04:05"), strings.Join(md.GetTags(), ","))
+			}
+			return w.Flush()
+		},
+	}
+
+	cmd.Flags().StringVar(&uploader, "uploader", "", "only videos uploaded by this user ID or name")
+	cmd.Flags().StringVar(&since, "since", "", "only videos uploaded after a date (2006-01-02 or RFC 3339) or within a duration (e.g. 24h)")
+	cmd.Flags().StringVar(&tag, "tag", "", "only videos carrying this tag")
+
+	return cmd
+}
+
+// parseSince accepts a duration back from now, a date or an RFC 3339 time.
+func parseSince(s string) (time.Time, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return time.Now().Add(-d), nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --since %q, expected e.g. 24h, 2025-08-09 or 2025-08-09T10:00:00+08:00", s)
+}
+
+func newMetadataCmd(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "metadata <video_id>",
+		Short: "Show the metadata of a video",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, err := opts.authContext(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			conn, err := opts.dial()
+			if err != nil {
+				return fmt.Errorf("failed to connect to server: %v", err)
+			}
+			defer conn.Close()
+
+			ctx, cancel := opts.callContext(ctx)
+			defer cancel()
+
+			resp, err := media.NewMediaServiceClient(conn).GetVideoMetadata(ctx, &media.GetVideoMetadataRequest{
+				VideoId: args[0],
+			})
+			if err != nil {
+				return fmt.Errorf("failed to get metadata: %v", err)
+			}
+
+			if opts.json {
+				return writeJSON(newVideoJSON(resp.VideoId, resp.Metadata))
+			}
+			fmt.Printf("Video ID: %s\n", resp.VideoId)
+			printMetadata(os.Stdout, resp.Metadata)
+			return nil
+		},
+	}
+}
+
+func newDeleteCmd(opts *options) *cobra.Command {
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "delete <video_id>",
+		Short: "Delete a video you uploaded",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !yes {
+				if err := confirm(fmt.Sprintf("Delete video %s? This cannot be undone.", args[0])); err != nil {
+					return err
+				}
+			}
+
+			ctx, err := opts.authContext(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			conn, err := opts.dial()
+			if err != nil {
+				return fmt.Errorf("failed to connect to server: %v", err)
+			}
+			defer conn.Close()
+
+			ctx, cancel := opts.callContext(ctx)
+			defer cancel()
+
+			resp, err := media.NewMediaServiceClient(conn).DeleteVideo(ctx, &media.DeleteVideoRequest{
+				VideoId: args[0],
+			})
+			if err != nil {
+				return fmt.Errorf("failed to delete video: %v", err)
+			}
+
+			if opts.json {
+				return writeJSON(map[string]string{"video_id": resp.VideoId})
+			}
+			fmt.Printf("Deleted video: %s\n", resp.VideoId)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "delete without asking for confirmation")
+
+	return cmd
+}
+
+// confirm asks a yes/no question on the terminal. Without a terminal there is
+// nobody to ask, so the action must be confirmed with --yes.
+func confirm(question string) error {
+	if !isTerminal(os.Stdin) {
+		return errors.New("refusing to delete without confirmation, pass --yes")
+	}
+
+	fmt.Fprintf(os.Stderr, "%s [y/N] ", question)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	}
+	return errors.New("aborted")
+}
+
+func printMetadata(w io.Writer, md *media.VideoMetadata) {
+	fmt.Fprintf(w, "Uploader ID: %s\n", md.GetUploaderId())
+	fmt.Fprintf(w, "Uploader Name: %s\n", md.GetUploaderName())
+	fmt.Fprintf(w, "File Name: %s\n", md.GetFileName())
+	fmt.Fprintf(w, "File Size: %d bytes\n", md.GetFileSize())
+	if md.GetUploadTimestamp() > 0 {
+		uploadTime := time.Unix(md.GetUploadTimestamp(), 0)
+		fmt.Fprintf(w, "Upload Time: %s\n", uploadTime.Format("2006-01-02 15:04:05"))
+	}
+	if tags := md.GetTags(); len(tags) > 0 {
+		fmt.Fprintf(w, "Tags: %s\n", strings.Join(tags, ", "))
+	}
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchedFile is a file in the watch directory waiting to become stable.
+type watchedFile struct {
+	size    int64
+	modTime time.Time
+	since   time.Time // when size and modTime were last seen changing
+	failed  bool      // the last upload failed; retried after the next change
+}
+
+// watcher uploads files that appear in a directory once they stop growing,
+// for recording machines that write talks into a drop folder.
+type watcher struct {
+	opts    *options
+	u       *uploader
+	dir     string
+	doneDir string
+	settle  time.Duration
+	files   map[string]*watchedFile
+}
+
+func (w *watcher) run(ctx context.Context) error {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to start watcher: %v", err)
+	}
+	defer fsw.Close()
+
+	if err := fsw.Add(w.dir); err != nil {
+		return fmt.Errorf("failed to watch %s: %v", w.dir, err)
+	}
+	if w.doneDir != "" {
+		if err := os.MkdirAll(w.doneDir, 0o755); err != nil {
+			return fmt.Errorf("failed to create done directory: %v", err)
+		}
+	}
+
+	// Files already present are picked up like new ones.
+	entries, err := os.ReadDir(w.dir)
+	if err != nil {
+		return fmt.Errorf("failed to read directory: %v", err)
+	}
+	for _, e := range entries {
+		w.observe(filepath.Join(w.dir, e.Name()))
+	}
+
+	fmt.Fprintf(w.u.out, "Watching %s for new recordings (stable after %s)\n", w.dir, w.settle)
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-fsw.Events:
+			if !ok {
+				return nil
+			}
+			if event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename) {
+				delete(w.files, event.Name)
+				continue
+			}
+			w.observe(event.Name)
+		case err, ok := <-fsw.Errors:
+			if !ok {
+				return nil
+			}
+			log.Printf("Watch error: %v", err)
+		case now := <-ticker.C:
+			w.uploadStable(ctx, now)
+		}
+	}
+}
+
+// observe records the current size and modification time of path.
+func (w *watcher) observe(path string) {
+	if strings.HasPrefix(filepath.Base(path), ".") {
+		return
+	}
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() {
+		return
+	}
+
+	f, exists := w.files[path]
+	if !exists {
+		w.files[path] = &watchedFile{size: info.Size(), modTime: info.ModTime(), since: time.Now()}
+		return
+	}
+	if f.size != info.Size() || !f.modTime.Equal(info.ModTime()) {
+		f.size, f.modTime, f.since, f.failed = info.Size(), info.ModTime(), time.Now(), false
+	}
+}
+
+// uploadStable uploads the files that have not changed for the settle period.
+func (w *watcher) uploadStable(ctx context.Context, now time.Time) {
+	for path, f := range w.files {
+		// Writers that keep the file open without generating events are
+		// caught by polling the size here.
+		w.observe(path)
+		if f.failed || now.Sub(f.since) < w.settle {
+			continue
+		}
+
+		videoID := videoIDFromPath(path)
+		authCtx, err := w.opts.authContext(ctx)
+		if err == nil {
+			_, err = w.u.upload(authCtx, videoID, path)
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Printf("Failed to upload %s: %v", path, err)
+			f.failed = true
+			continue
+		}
+		delete(w.files, path)
+
+		if w.doneDir != "" {
+			target := filepath.Join(w.doneDir, filepath.Base(path))
+			if err := os.Rename(path, target); err != nil {
+				log.Printf("Failed to move %s to %s: %v", path, w.doneDir, err)
+			}
+		}
+	}
+}
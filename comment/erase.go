@@ -0,0 +1,26 @@
+package comment
+
+import "slices"
+
+// EraseUser deletes the comments userID posted, since their bodies are as
+// much about the user as the author fields are.
+func (s *commentServer) EraseUser(userID, pseudonym string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for videoID, list := range s.byVideo {
+		list = slices.DeleteFunc(list, func(c *storedComment) bool {
+			if c.comment.AuthorId != userID {
+				return false
+			}
+			delete(s.byID, c.comment.CommentId)
+			return true
+		})
+		if len(list) == 0 {
+			delete(s.byVideo, videoID)
+		} else {
+			s.byVideo[videoID] = list
+		}
+	}
+	return "comments"
+}
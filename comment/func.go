@@ -0,0 +1,115 @@
+package comment
+
+import (
+	"cmp"
+	"context"
+	"coscup2025/auth"
+	"coscup2025/paging"
+	"coscup2025/proto/comment"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *commentServer) PostComment(ctx context.Context, req *comment.PostCommentRequest) (*comment.PostCommentResponse, error) {
+	id, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unknown caller")
+	}
+
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		return nil, status.Error(codes.InvalidArgument, "comment body is required")
+	}
+	if _, ok := s.videos.VideoUploader(ctx, req.VideoId); !ok {
+		return nil, status.Error(codes.NotFound, "video not found")
+	}
+	if !s.allow(id.UserID) {
+		return nil, status.Error(codes.ResourceExhausted, "too many comments, try again later")
+	}
+
+	c := &comment.Comment{
+		VideoId:    req.VideoId,
+		AuthorId:   id.UserID,
+		AuthorName: id.Username,
+		Body:       body,
+		CreatedAt:  time.Now().Unix(),
+	}
+	for _, hook := range s.hooks {
+		if err := hook(ctx, c); err != nil {
+			return nil, err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextSeq++
+	c.CommentId = fmt.Sprintf("c_%d", s.nextSeq)
+	stored := &storedComment{seq: s.nextSeq, comment: c}
+	s.byVideo[c.VideoId] = append(s.byVideo[c.VideoId], stored)
+	s.byID[c.CommentId] = stored
+
+	return &comment.PostCommentResponse{Comment: c}, nil
+}
+
+func (s *commentServer) ListComments(ctx context.Context, req *comment.ListCommentsRequest) (*comment.ListCommentsResponse, error) {
+	if _, ok := s.videos.VideoUploader(ctx, req.VideoId); !ok {
+		return nil, status.Error(codes.NotFound, "video not found")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	page, next, err := paging.Page(s.pager, req, s.byVideo[req.VideoId],
+		func(c *storedComment) int64 { return c.seq }, cmp.Compare[int64], req.VideoId)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &comment.ListCommentsResponse{NextPageToken: next}
+	for _, stored := range page {
+		resp.Comments = append(resp.Comments, stored.comment)
+	}
+	return resp, nil
+}
+
+func (s *commentServer) DeleteComment(ctx context.Context, req *comment.DeleteCommentRequest) (*comment.DeleteCommentResponse, error) {
+	id, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unknown caller")
+	}
+
+	s.mu.Lock()
+	stored, exists := s.byID[req.CommentId]
+	s.mu.Unlock()
+	if !exists {
+		return nil, status.Error(codes.NotFound, "comment not found")
+	}
+
+	// Uploaders moderate the discussion of their own videos.
+	c := stored.comment
+	if c.AuthorId != id.UserID {
+		uploader, ok := s.videos.VideoUploader(ctx, c.VideoId)
+		if !ok || uploader != id.UserID {
+			return nil, status.Error(codes.PermissionDenied, "only the author or the video's uploader may delete this comment")
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.byID, c.CommentId)
+	list := s.byVideo[c.VideoId]
+	for i, sc := range list {
+		if sc == stored {
+			s.byVideo[c.VideoId] = append(list[:i], list[i+1:]...)
+			break
+		}
+	}
+
+	return &comment.DeleteCommentResponse{CommentId: c.CommentId}, nil
+}
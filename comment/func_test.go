@@ -0,0 +1,111 @@
+package comment_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"coscup2025/env"
+	pbComment "coscup2025/proto/comment"
+	pbMedia "coscup2025/proto/media"
+	"coscup2025/servertest"
+)
+
+type testEnv struct {
+	srv *servertest.Server
+}
+
+func setup(t *testing.T, cfg *env.Config) *testEnv {
+	return &testEnv{srv: servertest.New(t, cfg)}
+}
+
+func (e *testEnv) signIn(t *testing.T, username string) context.Context {
+	return servertest.Context(e.srv.CreateUser(t, username, "testpass"))
+}
+
+func (e *testEnv) upload(t *testing.T, ctx context.Context, videoID string) {
+	upload, err := e.srv.Media().UploadVideo(ctx)
+	require.NoError(t, err)
+	require.NoError(t, upload.Send(&pbMedia.UploadVideoRequest{VideoId: videoID, Data: []byte("video"), Sequence: 1}))
+	_, err = upload.CloseAndRecv()
+	require.NoError(t, err)
+}
+
+func TestCommentsArePaginated(t *testing.T) {
+	cfg := env.DefaultConfig()
+	cfg.CommentsPerMinute = 0
+	e := setup(t, cfg)
+	ctx := e.signIn(t, "speaker")
+	e.upload(t, ctx, "talk")
+
+	client := pbComment.NewCommentServiceClient(e.srv.Conn)
+	for _, body := range []string{"one", "two", "three"} {
+		_, err := client.PostComment(ctx, &pbComment.PostCommentRequest{VideoId: "talk", Body: body})
+		require.NoError(t, err)
+	}
+
+	page, err := client.ListComments(ctx, &pbComment.ListCommentsRequest{VideoId: "talk", PageSize: 2})
+	require.NoError(t, err)
+	require.Len(t, page.Comments, 2)
+	assert.Equal(t, "one", page.Comments[0].Body)
+	require.NotEmpty(t, page.NextPageToken)
+
+	page, err = client.ListComments(ctx, &pbComment.ListCommentsRequest{VideoId: "talk", PageSize: 2, PageToken: page.NextPageToken})
+	require.NoError(t, err)
+	require.Len(t, page.Comments, 1)
+	assert.Equal(t, "three", page.Comments[0].Body)
+	assert.Empty(t, page.NextPageToken)
+}
+
+func TestDeleteComment(t *testing.T) {
+	e := setup(t, env.DefaultConfig())
+	speaker := e.signIn(t, "speaker")
+	attendee := e.signIn(t, "attendee")
+	other := e.signIn(t, "other")
+	e.upload(t, speaker, "talk")
+
+	client := pbComment.NewCommentServiceClient(e.srv.Conn)
+	first, err := client.PostComment(attendee, &pbComment.PostCommentRequest{VideoId: "talk", Body: "great talk"})
+	require.NoError(t, err)
+	second, err := client.PostComment(attendee, &pbComment.PostCommentRequest{VideoId: "talk", Body: "slides?"})
+	require.NoError(t, err)
+
+	_, err = client.DeleteComment(other, &pbComment.DeleteCommentRequest{CommentId: first.Comment.CommentId})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// The author and the video's uploader may both delete it.
+	_, err = client.DeleteComment(attendee, &pbComment.DeleteCommentRequest{CommentId: first.Comment.CommentId})
+	require.NoError(t, err)
+	_, err = client.DeleteComment(speaker, &pbComment.DeleteCommentRequest{CommentId: second.Comment.CommentId})
+	require.NoError(t, err)
+
+	list, err := client.ListComments(speaker, &pbComment.ListCommentsRequest{VideoId: "talk"})
+	require.NoError(t, err)
+	assert.Empty(t, list.Comments)
+}
+
+func TestPostCommentLimits(t *testing.T) {
+	cfg := env.DefaultConfig()
+	cfg.CommentsPerMinute = 2
+	cfg.CommentBlocklist = []string{"spam"}
+	e := setup(t, cfg)
+	ctx := e.signIn(t, "speaker")
+	e.upload(t, ctx, "talk")
+
+	client := pbComment.NewCommentServiceClient(e.srv.Conn)
+	_, err := client.PostComment(ctx, &pbComment.PostCommentRequest{VideoId: "missing", Body: "hi"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// Rejected comments count against the limit too.
+	_, err = client.PostComment(ctx, &pbComment.PostCommentRequest{VideoId: "talk", Body: "buy SPAM now"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.PostComment(ctx, &pbComment.PostCommentRequest{VideoId: "talk", Body: "hi"})
+	require.NoError(t, err)
+	_, err = client.PostComment(ctx, &pbComment.PostCommentRequest{VideoId: "talk", Body: "hi again"})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
@@ -0,0 +1,74 @@
+package comment
+
+import (
+	"context"
+	"coscup2025/env"
+	"coscup2025/keyset"
+	"coscup2025/paging"
+	"coscup2025/proto/comment"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// Videos gives the comment service access to the videos being discussed.
+type Videos interface {
+	// VideoUploader returns the uploader of a video the caller may view.
+	VideoUploader(ctx context.Context, videoID string) (string, bool)
+}
+
+type storedComment struct {
+	seq     int64 // position in posting order, used for page tokens
+	comment *comment.Comment
+}
+
+type commentServer struct {
+	comment.UnimplementedCommentServiceServer
+	videos  Videos
+	mu      sync.Mutex
+	byVideo map[string][]*storedComment // oldest first
+	byID    map[string]*storedComment
+	nextSeq int64
+	pager   *paging.Pager
+
+	limitMu  sync.Mutex
+	limiters map[string]*rate.Limiter
+	perMin   int
+
+	hooks []ModerationHook
+}
+
+func NewCommentServer(cfg *env.Config, keys *keyset.Set, videos Videos) *commentServer {
+	s := &commentServer{
+		videos:   videos,
+		byVideo:  make(map[string][]*storedComment),
+		byID:     make(map[string]*storedComment),
+		limiters: make(map[string]*rate.Limiter),
+		perMin:   cfg.CommentsPerMinute,
+		pager:    paging.New(keys),
+	}
+	if len(cfg.CommentBlocklist) > 0 {
+		s.AddModerationHook(BlockWords(cfg.CommentBlocklist))
+	}
+	return s
+}
+
+// AddModerationHook runs h on every new comment before it is stored.
+func (s *commentServer) AddModerationHook(h ModerationHook) {
+	s.hooks = append(s.hooks, h)
+}
+
+// allow reports whether userID may post another comment now.
+func (s *commentServer) allow(userID string) bool {
+	if s.perMin <= 0 {
+		return true
+	}
+	s.limitMu.Lock()
+	defer s.limitMu.Unlock()
+	l, ok := s.limiters[userID]
+	if !ok {
+		l = rate.NewLimiter(rate.Limit(float64(s.perMin)/60), s.perMin)
+		s.limiters[userID] = l
+	}
+	return l.Allow()
+}
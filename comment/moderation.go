@@ -0,0 +1,34 @@
+package comment
+
+import (
+	"context"
+	"coscup2025/proto/comment"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ModerationHook inspects a comment before it is stored. Returning an error
+// rejects the comment with that error, e.g. to forward it to a review queue
+// or an external spam filter instead.
+type ModerationHook func(ctx context.Context, c *comment.Comment) error
+
+// BlockWords rejects comments containing any of words, ignoring case.
+func BlockWords(words []string) ModerationHook {
+	lower := make([]string, 0, len(words))
+	for _, w := range words {
+		if w = strings.ToLower(strings.TrimSpace(w)); w != "" {
+			lower = append(lower, w)
+		}
+	}
+	return func(_ context.Context, c *comment.Comment) error {
+		body := strings.ToLower(c.Body)
+		for _, w := range lower {
+			if strings.Contains(body, w) {
+				return status.Error(codes.InvalidArgument, "comment contains blocked words")
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,88 @@
+// Package deprecation tells clients that they call an RPC declared with
+// `option deprecated = true`. Responses of such RPCs carry the header
+// metadata
+//
+//	deprecation: true
+//	link: </media.v2.MediaService/UploadVideo>; rel="successor-version"
+//
+// where link names the RPC of the same name in the next version of the
+// service, the package with a ".v2" suffix, if there is one. The gateway
+// turns them into the Deprecation and Link HTTP headers.
+package deprecation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// headers caches the header metadata of every method called so far, nil for
+// methods that are not deprecated.
+var headers sync.Map
+
+// UnaryServerInterceptor marks the responses of deprecated unary calls.
+func UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if md := header(info.FullMethod); md != nil {
+		grpc.SetHeader(ctx, md)
+	}
+	return handler(ctx, req)
+}
+
+// StreamServerInterceptor marks the responses of deprecated streaming calls.
+func StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if md := header(info.FullMethod); md != nil {
+		ss.SetHeader(md)
+	}
+	return handler(srv, ss)
+}
+
+// header returns the header metadata of fullMethod, e.g.
+// "/media.MediaService/UploadVideo", or nil if it is not deprecated.
+func header(fullMethod string) metadata.MD {
+	if md, ok := headers.Load(fullMethod); ok {
+		return md.(metadata.MD)
+	}
+	var md metadata.MD
+	if service, method, ok := lookup(fullMethod); ok && method.Options().(*descriptorpb.MethodOptions).GetDeprecated() {
+		md = metadata.Pairs("deprecation", "true")
+		if next, ok := successor(service, method.Name()); ok {
+			md.Append("link", fmt.Sprintf("<%s>; rel=\"successor-version\"", next))
+		}
+	}
+	headers.Store(fullMethod, md)
+	return md
+}
+
+// lookup returns the descriptors of fullMethod.
+func lookup(fullMethod string) (protoreflect.ServiceDescriptor, protoreflect.MethodDescriptor, bool) {
+	service, method, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if !ok {
+		return nil, nil, false
+	}
+	d, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return nil, nil, false
+	}
+	sd, ok := d.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, nil, false
+	}
+	md := sd.Methods().ByName(protoreflect.Name(method))
+	return sd, md, md != nil
+}
+
+// successor returns the full method of method in the next version of
+// service.
+func successor(service protoreflect.ServiceDescriptor, method protoreflect.Name) (string, bool) {
+	next := service.ParentFile().Package().Append("v2").Append(service.Name())
+	fullMethod := "/" + string(next) + "/" + string(method)
+	_, _, ok := lookup(fullMethod)
+	return fullMethod, ok
+}
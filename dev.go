@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/credentials/insecure"
+
+	"coscup2025/client"
+	"coscup2025/env"
+)
+
+// sampleVideo is the video every seeded upload in dev mode stores.
+//
+//go:embed media/client/video_1280x720_1mb.mp4
+var sampleVideo []byte
+
+// devUsers are created in dev mode, with their username as password.
+// "admin" may also call the admin service.
+var devUsers = []string{"alice", "bob", "admin"}
+
+// devVideos are uploaded in dev mode, by the given user and with the given
+// tags.
+var devVideos = []struct {
+	id, user string
+	tags     []string
+}{
+	{"opening", "alice", []string{"room-a", "keynote"}},
+	{"lightning-talks", "alice", []string{"room-a"}},
+	{"closing", "bob", []string{"room-b", "keynote"}},
+}
+
+// devConfig makes cfg run everything in memory, without the files and
+// external services it may point to.
+func devConfig(cfg *env.Config) {
+	cfg.TraceExporter = "none"
+	cfg.UploadSessionDir = ""
+	cfg.DeletionDir = ""
+	cfg.StorageDir = ""
+	cfg.CDNProvider = ""
+	cfg.AuditLogFile = ""
+	cfg.SMTPAddr = ""
+	cfg.TermsVersion = ""
+	if !slices.Contains(cfg.AdminUsers, "admin") {
+		cfg.AdminUsers = append(cfg.AdminUsers, "admin")
+	}
+}
+
+// allowAllOrigins answers CORS requests from any origin, so that a frontend
+// served from another port can call the gateway in dev mode.
+func allowAllOrigins(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Origin") == "" {
+			h.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("Access-Control-Expose-Headers", "*")
+		if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE")
+			w.Header().Set("Access-Control-Allow-Headers", r.Header.Get("Access-Control-Request-Headers"))
+			w.Header().Set("Access-Control-Max-Age", "600")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// seedDev creates the dev users and videos through the gRPC server at addr
+// and prints a token for each user.
+func seedDev(addr string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	tokens := make(map[string]string)
+	for _, user := range devUsers {
+		c, err := client.New(addr, client.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+		if _, err := c.SignUp(ctx, user, user); err != nil {
+			return fmt.Errorf("sign up %s: %w", user, err)
+		}
+		token, err := c.Login(ctx, user, user)
+		if err != nil {
+			return fmt.Errorf("sign in %s: %w", user, err)
+		}
+		tokens[user] = token.AccessToken
+
+		for _, v := range devVideos {
+			if v.user != user {
+				continue
+			}
+			_, err := c.Upload(ctx, v.id, bytes.NewReader(sampleVideo), int64(len(sampleVideo)), &client.UploadOptions{Tags: v.tags})
+			if err != nil {
+				return fmt.Errorf("upload %s: %w", v.id, err)
+			}
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "\nDev mode: %d users and %d videos are ready. Sign in with the username as password, or use a token:\n\n", len(devUsers), len(devVideos))
+	for _, user := range devUsers {
+		fmt.Fprintf(&b, "  %-6s %s\n", user, tokens[user])
+	}
+	fmt.Fprintf(&b, "\n  curl -H \"Authorization: Bearer %s\" http://localhost:8080/v1/profile\n\n", tokens[devUsers[0]])
+	fmt.Print(b.String())
+	return nil
+}
@@ -1,11 +1,580 @@
 package env
 
+import (
+	"cmp"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
 type Config struct {
 	JWTSecret string
+	// JWTSecretFile holds the JWT secret in place of JWTSecret, e.g. as
+	// written by a secret manager or a Vault agent. The server watches it and
+	// rotates to the new secret when it changes, accepting tokens signed with
+	// the previous one for JWTSecretGrace.
+	JWTSecretFile  string
+	JWTSecretGrace time.Duration
+
+	// TLSCertFile and TLSKeyFile switch the gateway to HTTPS when both are set.
+	TLSCertFile string
+	TLSKeyFile  string
+
+	// HTTP3Addr enables an additional HTTP/3 (QUIC) gateway listener on the
+	// given UDP address. It requires TLSCertFile and TLSKeyFile.
+	HTTP3Addr string
+
+	// ChunkEventInterval records a span event for every Nth chunk of an
+	// upload or download. With 0 only summary attributes are recorded, which
+	// keeps spans of multi-GB transfers small.
+	ChunkEventInterval int
+
+	// Tenant is stamped into issued tokens and propagated with the caller's
+	// identity, so traces can be grouped per tenant. Empty leaves it out.
+	Tenant string
+
+	// TraceExporter selects where spans go: "otlp" (default), "jaeger",
+	// "zipkin", "stdout" or "none". TraceEndpoint overrides the exporter's
+	// default address.
+	TraceExporter string
+	TraceEndpoint string
+
+	// SlowRequestLatency and SlowStreamThroughput (bytes per second) flag
+	// unary and streaming calls as slow. Zero disables the check.
+	SlowRequestLatency   time.Duration
+	SlowStreamThroughput int64
+
+	// AuditLogFile enables the audit log. It is rotated when it grows past
+	// AuditLogMaxSize bytes or gets older than AuditLogMaxAge, and every line
+	// is chained with an HMAC keyed by AuditHMACKey.
+	AuditLogFile    string
+	AuditLogMaxSize int64
+	AuditLogMaxAge  time.Duration
+	AuditHMACKey    string
+
+	// SMTPAddr (host:port) is the outbound mail server. Without it the mailer
+	// logs messages instead of sending them.
+	SMTPAddr     string
+	SMTPUsername string
+	SMTPPassword string
+	MailFrom     string
+	// AlertEmails receive the alerts that admin users are notified of, such
+	// as storage failures.
+	AlertEmails []string
+
+	// CommentsPerMinute limits how often a user may comment; 0 disables the
+	// limit. Comments containing a word of CommentBlocklist are rejected.
+	CommentsPerMinute int
+	CommentBlocklist  []string
+
+	// ReportsPerHour limits how many videos a user may report; 0 disables
+	// the limit.
+	ReportsPerHour int
+
+	// AvatarSize is the width and height in pixels that avatars are scaled
+	// down to.
+	AvatarSize int
+
+	// PlayerURL is where share links to the player redirect; {video_id} is
+	// replaced by the video's ID.
+	PlayerURL string
+	// HLSPlayerFile is an HLS.js build (dist/hls.min.js) that the gateway
+	// serves to embed pages, for browsers that cannot play HLS natively.
+	HLSPlayerFile string
+
+	// DefaultLocale is the language of video titles and descriptions shown
+	// when none of the caller's preferred languages is available.
+	DefaultLocale string
+
+	// AdminAddr is the listen address of the admin port serving metrics and
+	// pprof, e.g. ":9090". Admin endpoints only accept clients from the
+	// AdminAllow networks that are not in AdminDeny.
+	AdminAddr  string
+	AdminAllow []string
+	AdminDeny  []string
+	// PolicyFile is a YAML file of CEL authorization policies, which deny
+	// calls beyond the services' own checks, see package policy. Empty
+	// means none.
+	PolicyFile string
+
+	// MeshTrustXFCC takes the identity of the calling workload from the
+	// x-forwarded-client-cert header of a service mesh sidecar, see package
+	// mesh. Only set it when every call passes a sidecar that replaces the
+	// header. Calls to the MeshMethods prefixes are then refused unless their
+	// peer is one of MeshAllowedPeers.
+	MeshTrustXFCC    bool
+	MeshMethods      []string
+	MeshAllowedPeers []string
+
+	// AdminUsers are the usernames allowed to call the admin service, e.g. to
+	// take down reported videos. The rights go to the first account created
+	// with each of them.
+	AdminUsers []string
+
+	// JobWorkers is how many background jobs, such as data exports, run at
+	// once. Their results are deleted JobResultTTL after they finish.
+	JobWorkers   int
+	JobResultTTL time.Duration
+
+	// TermsVersion is the current version of the terms of service and
+	// privacy policy, published at TermsURL. Users must accept it at signup
+	// and again whenever it changes. Empty disables the check.
+	TermsVersion string
+	TermsURL     string
+
+	// InvitationURL is the link of the invitations of imported users, e.g. a
+	// page of the frontend that calls AcceptInvitation; {token} is replaced
+	// by the invitation. Invitations expire after InvitationTTL.
+	InvitationURL string
+	InvitationTTL time.Duration
+
+	// DeletionGracePeriod is how long after requesting it an account is
+	// erased, so that the request can still be cancelled.
+	DeletionGracePeriod time.Duration
+	// DeletionDir keeps scheduled deletions and their receipts on disk, so
+	// that a restart neither cancels an erasure nor loses its receipt. Empty
+	// keeps them in memory only.
+	DeletionDir string
+	// DeletionReceiptKeyFile is the PEM file of the PKCS #8 Ed25519 private
+	// key that signs deletion receipts. Publish its public half for users to
+	// verify receipts with. Empty leaves receipts unsigned.
+	DeletionReceiptKeyFile string
+
+	// GRPCWindowSize and GRPCConnWindowSize fix the HTTP/2 flow-control
+	// windows, in bytes, of each stream and of each connection. They bound
+	// how much a client can send before the server reads it. Zero keeps
+	// gRPC's default, which grows the windows with the measured bandwidth.
+	// GRPCMaxRecvMsgSize caps a single message, and so an upload chunk; zero
+	// keeps gRPC's 4 MiB.
+	GRPCWindowSize     int32
+	GRPCConnWindowSize int32
+	GRPCMaxRecvMsgSize int
+
+	// UploadMaxInFlight caps the bytes an upload stream may hold before they
+	// are stored. Resumable sessions store every chunk as it arrives, but a
+	// stream without a session keeps the whole video until it ends, so this
+	// is its size limit. Zero disables the cap.
+	UploadMaxInFlight int64
+
+	// MemoryBudget caps the bytes of videos, thumbnails and uploads the
+	// server holds in memory. Uploads that would exceed it are rejected with
+	// ResourceExhausted. Zero disables the budget.
+	MemoryBudget int64
+
+	// VideoVersioning makes an upload to the ID of one of the caller's
+	// videos store the next version of it. Without it such an upload fails
+	// with AlreadyExists unless it asks to overwrite the video.
+	VideoVersioning bool
+
+	// MaxVideoSize is the largest video accepted, in bytes. Uploads are
+	// refused as soon as they announce or send more. Zero means no limit.
+	MaxVideoSize int64
+
+	// MaxUserDownloads caps the download streams a user may have open at
+	// once; more fail with ResourceExhausted. Zero means no limit.
+	MaxUserDownloads int
+	// DownloadBandwidth caps the bytes per second sent by all downloads
+	// together. Within it, downloads of interactive priority go ahead of
+	// batch ones. Zero means no limit, and priorities have no effect.
+	DownloadBandwidth int64
+
+	// StreamStallTimeout aborts a stream with Aborted when a message it
+	// receives or sends does not move for this long, so that a client that
+	// went silent does not hold its upload session or download slot. Time a
+	// handler spends between messages does not count. Zero disables it.
+	StreamStallTimeout time.Duration
+	// StreamHeartbeatInterval is how often WatchProgress sends a heartbeat
+	// event while nothing happens. Zero disables heartbeats.
+	StreamHeartbeatInterval time.Duration
+
+	// UploadSessionDir keeps resumable upload sessions on disk, so that
+	// clients can resume them after the server restarts. Empty keeps them in
+	// memory only.
+	UploadSessionDir string
+	// MaxUploadStreamDeadline bounds the deadline clients may request for
+	// every UploadVideo stream of an upload session, so that a large file
+	// gets a deadline fit for its size rather than whatever the client
+	// happened to set. Zero grants none.
+	MaxUploadStreamDeadline time.Duration
+
+	// StorageDir keeps uploaded videos on disk in a content-addressable
+	// store, so that they survive a restart. Empty keeps them in memory only.
+	StorageDir string
+	// S3Bucket keeps uploaded videos and avatars in a bucket of Amazon S3
+	// or an S3-compatible service such as MinIO instead of StorageDir, and
+	// serves downloads from it rather than from memory.
+	S3Bucket string
+	// S3Endpoint is the URL of the service, e.g. http://minio:9000. Empty
+	// is Amazon S3 in S3Region.
+	S3Endpoint string
+	S3Region   string
+	// S3Prefix is prepended to the keys of all objects, e.g. "coscup/".
+	S3Prefix string
+	// S3PathStyle addresses the bucket in the path rather than the host
+	// name, as MinIO expects.
+	S3PathStyle       bool
+	S3AccessKeyID     string
+	S3SecretAccessKey string
+	// S3PartSize is the size of the parts of multipart uploads, at least
+	// 5 MiB. Videos of up to one part are uploaded in a single request.
+	S3PartSize int
+
+	// GatewayBackendAddr is the gRPC address the gateway forwards to. All of
+	// its services share one connection.
+	GatewayBackendAddr string
+	// GatewayBackendCAFile makes the gateway dial the backend over TLS,
+	// verifying it with the CA certificates in the file. Empty dials in
+	// plaintext, which suits a backend on loopback.
+	GatewayBackendCAFile string
+	// GatewayKeepaliveTime is how long the backend connection may stay idle
+	// before the gateway pings it, and GatewayKeepaliveTimeout how long it
+	// waits for the reply before reconnecting. Zero disables keepalives.
+	GatewayKeepaliveTime    time.Duration
+	GatewayKeepaliveTimeout time.Duration
+
+	// CDNProvider makes download links point at a CDN serving StorageDir:
+	// "cloudfront" or "fastly". Empty serves downloads from the gateway.
+	CDNProvider string
+	// CDNBaseURL is the CDN origin that maps to StorageDir.
+	CDNBaseURL string
+	// CDNKeyPairID and CDNPrivateKeyFile are the CloudFront public key ID
+	// and the PEM file of its RSA private key.
+	CDNKeyPairID      string
+	CDNPrivateKeyFile string
+	// CDNSigningKey is the HMAC key shared with the Fastly VCL.
+	CDNSigningKey string
+	// DownloadLinkTTL is how long a link from CreateDownloadLink works.
+	DownloadLinkTTL time.Duration
+	// TranscoderWebhookSecret is the HMAC key that signs the callbacks of
+	// an external transcoder. Empty refuses all callbacks.
+	TranscoderWebhookSecret string
+
+	// ScrubInterval is how often the videos in StorageDir are verified
+	// against their checksums. Zero only scrubs on request through the admin
+	// API.
+	ScrubInterval time.Duration
+	// RetentionInterval is how often the retention rules that admins set
+	// are enforced, deleting the videos they expire. Zero never deletes, and
+	// the rules can only be previewed.
+	RetentionInterval time.Duration
+
+	// ChaosLatency, ChaosUnavailableRate and ChaosAbortRate inject faults
+	// into the calls of the ChaosMethods prefixes, or all calls when empty,
+	// to test client retries: a random delay of up to ChaosLatency, the
+	// probability of failing a call with Unavailable, and the probability of
+	// aborting a stream at each message. All zero, the default, injects
+	// nothing. Never set them in production.
+	ChaosLatency         time.Duration
+	ChaosUnavailableRate float64
+	ChaosAbortRate       float64
+	ChaosMethods         []string
 }
 
 func DefaultConfig() *Config {
 	return &Config{
-		JWTSecret: "my-secret-key",
+		JWTSecret:     "my-secret-key",
+		TraceExporter: "otlp",
+
+		JWTSecretGrace: 24 * time.Hour,
+
+		SlowRequestLatency: 2 * time.Second,
+
+		AuditLogMaxSize: 100 << 20,
+		AuditLogMaxAge:  24 * time.Hour,
+
+		MailFrom: "COSCUP <noreply@coscup.org>",
+
+		CommentsPerMinute: 5,
+		ReportsPerHour:    10,
+
+		AvatarSize: 256,
+
+		PlayerURL:     "/embed/{video_id}",
+		DefaultLocale: "en",
+
+		AdminAllow: []string{"127.0.0.0/8", "::1/128"},
+
+		JobWorkers:   2,
+		JobResultTTL: 24 * time.Hour,
+
+		DeletionGracePeriod: 7 * 24 * time.Hour,
+
+		InvitationURL: "/invite/{token}",
+		InvitationTTL: 7 * 24 * time.Hour,
+
+		UploadMaxInFlight: 256 << 20,
+
+		StreamStallTimeout:      2 * time.Minute,
+		StreamHeartbeatInterval: 15 * time.Second,
+
+		MaxUploadStreamDeadline: 6 * time.Hour,
+
+		S3Region:   "us-east-1",
+		S3PartSize: 16 << 20,
+
+		GatewayBackendAddr:      "localhost:50051",
+		GatewayKeepaliveTime:    30 * time.Second,
+		GatewayKeepaliveTimeout: 10 * time.Second,
+
+		DownloadLinkTTL:   time.Hour,
+		ScrubInterval:     24 * time.Hour,
+		RetentionInterval: time.Hour,
+	}
+}
+
+// FromEnv returns DefaultConfig overridden by COSCUP_* environment variables.
+func FromEnv() *Config {
+	cfg := DefaultConfig()
+	if v := os.Getenv("COSCUP_JWT_SECRET_FILE"); v != "" {
+		cfg.JWTSecretFile = v
+	}
+	if v, err := time.ParseDuration(os.Getenv("COSCUP_JWT_SECRET_GRACE")); err == nil && v >= 0 {
+		cfg.JWTSecretGrace = v
+	}
+	if v := os.Getenv("COSCUP_TLS_CERT_FILE"); v != "" {
+		cfg.TLSCertFile = v
+	}
+	if v := os.Getenv("COSCUP_TLS_KEY_FILE"); v != "" {
+		cfg.TLSKeyFile = v
+	}
+	if v := os.Getenv("COSCUP_HTTP3_ADDR"); v != "" {
+		cfg.HTTP3Addr = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("COSCUP_TRACE_CHUNK_EVENTS")); err == nil && v >= 0 {
+		cfg.ChunkEventInterval = v
+	}
+	if v := os.Getenv("COSCUP_TENANT"); v != "" {
+		cfg.Tenant = v
+	}
+	if v := os.Getenv("COSCUP_TRACE_EXPORTER"); v != "" {
+		cfg.TraceExporter = v
+	}
+	if v := os.Getenv("COSCUP_TRACE_ENDPOINT"); v != "" {
+		cfg.TraceEndpoint = v
+	}
+	if v, err := time.ParseDuration(os.Getenv("COSCUP_SLOW_REQUEST_LATENCY")); err == nil && v >= 0 {
+		cfg.SlowRequestLatency = v
+	}
+	if v, err := strconv.ParseInt(os.Getenv("COSCUP_SLOW_STREAM_THROUGHPUT"), 10, 64); err == nil && v >= 0 {
+		cfg.SlowStreamThroughput = v
+	}
+	if v := os.Getenv("COSCUP_AUDIT_LOG"); v != "" {
+		cfg.AuditLogFile = v
+	}
+	if v, err := strconv.ParseInt(os.Getenv("COSCUP_AUDIT_LOG_MAX_SIZE"), 10, 64); err == nil && v >= 0 {
+		cfg.AuditLogMaxSize = v
+	}
+	if v, err := time.ParseDuration(os.Getenv("COSCUP_AUDIT_LOG_MAX_AGE")); err == nil && v >= 0 {
+		cfg.AuditLogMaxAge = v
+	}
+	if v := os.Getenv("COSCUP_AUDIT_HMAC_KEY"); v != "" {
+		cfg.AuditHMACKey = v
+	}
+	if v := os.Getenv("COSCUP_SMTP_ADDR"); v != "" {
+		cfg.SMTPAddr = v
+	}
+	if v := os.Getenv("COSCUP_SMTP_USERNAME"); v != "" {
+		cfg.SMTPUsername = v
+	}
+	if v := os.Getenv("COSCUP_SMTP_PASSWORD"); v != "" {
+		cfg.SMTPPassword = v
+	}
+	if v := os.Getenv("COSCUP_MAIL_FROM"); v != "" {
+		cfg.MailFrom = v
+	}
+	if v := os.Getenv("COSCUP_ALERT_EMAILS"); v != "" {
+		cfg.AlertEmails = strings.Split(v, ",")
+	}
+	if v, err := strconv.Atoi(os.Getenv("COSCUP_COMMENTS_PER_MINUTE")); err == nil && v >= 0 {
+		cfg.CommentsPerMinute = v
+	}
+	if v := os.Getenv("COSCUP_COMMENT_BLOCKLIST"); v != "" {
+		cfg.CommentBlocklist = strings.Split(v, ",")
+	}
+	if v, err := strconv.Atoi(os.Getenv("COSCUP_REPORTS_PER_HOUR")); err == nil && v >= 0 {
+		cfg.ReportsPerHour = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("COSCUP_AVATAR_SIZE")); err == nil && v > 0 {
+		cfg.AvatarSize = v
+	}
+	if v := os.Getenv("COSCUP_PLAYER_URL"); v != "" {
+		cfg.PlayerURL = v
+	}
+	if v := os.Getenv("COSCUP_HLS_PLAYER_FILE"); v != "" {
+		cfg.HLSPlayerFile = v
+	}
+	if v := os.Getenv("COSCUP_DEFAULT_LOCALE"); v != "" {
+		cfg.DefaultLocale = v
+	}
+	if v := os.Getenv("COSCUP_ADMIN_ADDR"); v != "" {
+		cfg.AdminAddr = v
+	}
+	if v := os.Getenv("COSCUP_ADMIN_ALLOW"); v != "" {
+		cfg.AdminAllow = strings.Split(v, ",")
+	}
+	if v := os.Getenv("COSCUP_ADMIN_DENY"); v != "" {
+		cfg.AdminDeny = strings.Split(v, ",")
+	}
+	if v, err := strconv.ParseBool(os.Getenv("COSCUP_MESH_TRUST_XFCC")); err == nil {
+		cfg.MeshTrustXFCC = v
+	}
+	if v := os.Getenv("COSCUP_MESH_METHODS"); v != "" {
+		cfg.MeshMethods = strings.Split(v, ",")
+	}
+	if v := os.Getenv("COSCUP_MESH_ALLOWED_PEERS"); v != "" {
+		cfg.MeshAllowedPeers = strings.Split(v, ",")
+	}
+	if v := os.Getenv("COSCUP_POLICY_FILE"); v != "" {
+		cfg.PolicyFile = v
+	}
+	if v := os.Getenv("COSCUP_ADMIN_USERS"); v != "" {
+		cfg.AdminUsers = strings.Split(v, ",")
+	}
+	if v, err := strconv.Atoi(os.Getenv("COSCUP_JOB_WORKERS")); err == nil && v > 0 {
+		cfg.JobWorkers = v
+	}
+	if v, err := time.ParseDuration(os.Getenv("COSCUP_JOB_RESULT_TTL")); err == nil && v > 0 {
+		cfg.JobResultTTL = v
+	}
+	if v := os.Getenv("COSCUP_TERMS_VERSION"); v != "" {
+		cfg.TermsVersion = v
+	}
+	if v := os.Getenv("COSCUP_TERMS_URL"); v != "" {
+		cfg.TermsURL = v
+	}
+	if v := os.Getenv("COSCUP_INVITATION_URL"); v != "" {
+		cfg.InvitationURL = v
+	}
+	if v, err := time.ParseDuration(os.Getenv("COSCUP_INVITATION_TTL")); err == nil && v > 0 {
+		cfg.InvitationTTL = v
+	}
+	if v, err := time.ParseDuration(os.Getenv("COSCUP_DELETION_GRACE_PERIOD")); err == nil && v >= 0 {
+		cfg.DeletionGracePeriod = v
+	}
+	if v := os.Getenv("COSCUP_DELETION_DIR"); v != "" {
+		cfg.DeletionDir = v
+	}
+	if v := os.Getenv("COSCUP_DELETION_RECEIPT_KEY_FILE"); v != "" {
+		cfg.DeletionReceiptKeyFile = v
+	}
+	if v, err := strconv.ParseInt(os.Getenv("COSCUP_GRPC_WINDOW_SIZE"), 10, 32); err == nil && v >= 0 {
+		cfg.GRPCWindowSize = int32(v)
+	}
+	if v, err := strconv.ParseInt(os.Getenv("COSCUP_GRPC_CONN_WINDOW_SIZE"), 10, 32); err == nil && v >= 0 {
+		cfg.GRPCConnWindowSize = int32(v)
+	}
+	if v, err := strconv.Atoi(os.Getenv("COSCUP_GRPC_MAX_RECV_MSG_SIZE")); err == nil && v >= 0 {
+		cfg.GRPCMaxRecvMsgSize = v
+	}
+	if v, err := strconv.ParseInt(os.Getenv("COSCUP_UPLOAD_MAX_IN_FLIGHT"), 10, 64); err == nil && v >= 0 {
+		cfg.UploadMaxInFlight = v
+	}
+	if v, err := strconv.ParseInt(os.Getenv("COSCUP_MEMORY_BUDGET"), 10, 64); err == nil && v >= 0 {
+		cfg.MemoryBudget = v
+	}
+	if v, err := strconv.ParseBool(os.Getenv("COSCUP_VIDEO_VERSIONING")); err == nil {
+		cfg.VideoVersioning = v
+	}
+	if v, err := strconv.ParseInt(os.Getenv("COSCUP_MAX_VIDEO_SIZE"), 10, 64); err == nil && v >= 0 {
+		cfg.MaxVideoSize = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("COSCUP_MAX_USER_DOWNLOADS")); err == nil && v >= 0 {
+		cfg.MaxUserDownloads = v
+	}
+	if v, err := strconv.ParseInt(os.Getenv("COSCUP_DOWNLOAD_BANDWIDTH"), 10, 64); err == nil && v >= 0 {
+		cfg.DownloadBandwidth = v
+	}
+	if v, err := time.ParseDuration(os.Getenv("COSCUP_STREAM_STALL_TIMEOUT")); err == nil && v >= 0 {
+		cfg.StreamStallTimeout = v
+	}
+	if v, err := time.ParseDuration(os.Getenv("COSCUP_STREAM_HEARTBEAT_INTERVAL")); err == nil && v >= 0 {
+		cfg.StreamHeartbeatInterval = v
+	}
+	if v := os.Getenv("COSCUP_UPLOAD_SESSION_DIR"); v != "" {
+		cfg.UploadSessionDir = v
+	}
+	if v, err := time.ParseDuration(os.Getenv("COSCUP_MAX_UPLOAD_STREAM_DEADLINE")); err == nil && v >= 0 {
+		cfg.MaxUploadStreamDeadline = v
+	}
+	if v := os.Getenv("COSCUP_STORAGE_DIR"); v != "" {
+		cfg.StorageDir = v
+	}
+	if v := os.Getenv("COSCUP_S3_BUCKET"); v != "" {
+		cfg.S3Bucket = v
+	}
+	if v := os.Getenv("COSCUP_S3_ENDPOINT"); v != "" {
+		cfg.S3Endpoint = v
+	}
+	if v := os.Getenv("COSCUP_S3_REGION"); v != "" {
+		cfg.S3Region = v
+	}
+	if v := os.Getenv("COSCUP_S3_PREFIX"); v != "" {
+		cfg.S3Prefix = v
+	}
+	if v, err := strconv.ParseBool(os.Getenv("COSCUP_S3_PATH_STYLE")); err == nil {
+		cfg.S3PathStyle = v
+	}
+	// The variables of the AWS SDKs work too
+	if v := cmp.Or(os.Getenv("COSCUP_S3_ACCESS_KEY_ID"), os.Getenv("AWS_ACCESS_KEY_ID")); v != "" {
+		cfg.S3AccessKeyID = v
+	}
+	if v := cmp.Or(os.Getenv("COSCUP_S3_SECRET_ACCESS_KEY"), os.Getenv("AWS_SECRET_ACCESS_KEY")); v != "" {
+		cfg.S3SecretAccessKey = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("COSCUP_S3_PART_SIZE")); err == nil && v > 0 {
+		cfg.S3PartSize = v
+	}
+	if v := os.Getenv("COSCUP_GATEWAY_BACKEND_ADDR"); v != "" {
+		cfg.GatewayBackendAddr = v
+	}
+	if v := os.Getenv("COSCUP_GATEWAY_BACKEND_CA_FILE"); v != "" {
+		cfg.GatewayBackendCAFile = v
+	}
+	if v, err := time.ParseDuration(os.Getenv("COSCUP_GATEWAY_KEEPALIVE_TIME")); err == nil && v >= 0 {
+		cfg.GatewayKeepaliveTime = v
+	}
+	if v, err := time.ParseDuration(os.Getenv("COSCUP_GATEWAY_KEEPALIVE_TIMEOUT")); err == nil && v > 0 {
+		cfg.GatewayKeepaliveTimeout = v
+	}
+	if v := os.Getenv("COSCUP_CDN_PROVIDER"); v != "" {
+		cfg.CDNProvider = v
+	}
+	if v := os.Getenv("COSCUP_CDN_BASE_URL"); v != "" {
+		cfg.CDNBaseURL = v
+	}
+	if v := os.Getenv("COSCUP_CDN_KEY_PAIR_ID"); v != "" {
+		cfg.CDNKeyPairID = v
+	}
+	if v := os.Getenv("COSCUP_CDN_PRIVATE_KEY_FILE"); v != "" {
+		cfg.CDNPrivateKeyFile = v
+	}
+	if v := os.Getenv("COSCUP_CDN_SIGNING_KEY"); v != "" {
+		cfg.CDNSigningKey = v
+	}
+	if v, err := time.ParseDuration(os.Getenv("COSCUP_DOWNLOAD_LINK_TTL")); err == nil && v > 0 {
+		cfg.DownloadLinkTTL = v
+	}
+	if v := os.Getenv("COSCUP_TRANSCODER_WEBHOOK_SECRET"); v != "" {
+		cfg.TranscoderWebhookSecret = v
+	}
+	if v, err := time.ParseDuration(os.Getenv("COSCUP_SCRUB_INTERVAL")); err == nil && v >= 0 {
+		cfg.ScrubInterval = v
+	}
+	if v, err := time.ParseDuration(os.Getenv("COSCUP_RETENTION_INTERVAL")); err == nil && v >= 0 {
+		cfg.RetentionInterval = v
+	}
+	if v, err := time.ParseDuration(os.Getenv("COSCUP_CHAOS_LATENCY")); err == nil && v >= 0 {
+		cfg.ChaosLatency = v
+	}
+	if v, err := strconv.ParseFloat(os.Getenv("COSCUP_CHAOS_UNAVAILABLE_RATE"), 64); err == nil && v >= 0 && v <= 1 {
+		cfg.ChaosUnavailableRate = v
+	}
+	if v, err := strconv.ParseFloat(os.Getenv("COSCUP_CHAOS_ABORT_RATE"), 64); err == nil && v >= 0 && v <= 1 {
+		cfg.ChaosAbortRate = v
+	}
+	if v := os.Getenv("COSCUP_CHAOS_METHODS"); v != "" {
+		cfg.ChaosMethods = strings.Split(v, ",")
 	}
+	return cfg
 }
@@ -0,0 +1,111 @@
+// Package faultstore provides a media.Backend that injects storage faults:
+// failed writes at a chosen byte, a full disk, slow reads and corrupted data. Each fault
+// is deterministic, so tests can exercise resumable uploads, client retries
+// and checksum verification without relying on timing or a broken disk.
+package faultstore
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// ErrInjected is the error of an injected write failure.
+var ErrInjected = errors.New("injected storage fault")
+
+// Backend injects the faults it is configured with. The zero value injects
+// none; faults can be changed while the server runs.
+type Backend struct {
+	mu           sync.Mutex
+	failWriteAt  int64 // byte offset, or -1
+	failedWrites int
+	diskFullAt   int64 // byte offset, or -1
+	readDelay    time.Duration
+	corruptAt    int64 // byte offset, or -1
+}
+
+// New returns a backend that injects no faults yet.
+func New() *Backend {
+	return &Backend{failWriteAt: -1, diskFullAt: -1, corruptAt: -1}
+}
+
+// FailWriteAt makes the next upload chunk that would store byte n fail, as
+// if the disk failed partway through it. Only one chunk fails, so a retry
+// succeeds.
+func (b *Backend) FailWriteAt(n int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failWriteAt = n
+}
+
+// FillDiskAt makes every upload chunk that would store byte n or later fail
+// with syscall.ENOSPC, as if the disk ran full there, until the disk is
+// freed with a negative n.
+func (b *Backend) FillDiskAt(n int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.diskFullAt = n
+}
+
+// DelayReads makes every read of video content wait d first.
+func (b *Backend) DelayReads(d time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.readDelay = d
+}
+
+// CorruptAt makes reads return byte n of every video with its bits flipped,
+// as if it rotted on disk. A negative n stops corrupting.
+func (b *Backend) CorruptAt(n int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.corruptAt = n
+}
+
+// FailedWrites returns how many writes failed so far.
+func (b *Backend) FailedWrites() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.failedWrites
+}
+
+// WriteChunk implements media.Backend.
+func (b *Backend) WriteChunk(uploadID string, offset int64, data []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.failWriteAt >= offset && b.failWriteAt < offset+int64(len(data)) {
+		b.failWriteAt = -1
+		b.failedWrites++
+		return ErrInjected
+	}
+	if b.diskFullAt >= 0 && b.diskFullAt < offset+int64(len(data)) {
+		b.failedWrites++
+		return syscall.ENOSPC
+	}
+	return nil
+}
+
+// Open implements media.Backend.
+func (b *Backend) Open(videoID string, content io.ReaderAt) io.ReaderAt {
+	return reader{b: b, content: content}
+}
+
+type reader struct {
+	b       *Backend
+	content io.ReaderAt
+}
+
+func (r reader) ReadAt(p []byte, off int64) (int, error) {
+	r.b.mu.Lock()
+	delay, corruptAt := r.b.readDelay, r.b.corruptAt
+	r.b.mu.Unlock()
+
+	time.Sleep(delay)
+	n, err := r.content.ReadAt(p, off)
+	if corruptAt >= off && corruptAt < off+int64(n) {
+		p[corruptAt-off] ^= 0xff
+	}
+	return n, err
+}
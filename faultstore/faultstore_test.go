@@ -0,0 +1,143 @@
+package faultstore_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"coscup2025/client"
+	"coscup2025/env"
+	"coscup2025/faultstore"
+	"coscup2025/media"
+	pbNotification "coscup2025/proto/notification"
+	"coscup2025/servertest"
+	"coscup2025/smtptest"
+)
+
+func setup(t *testing.T) (*faultstore.Backend, *client.Client) {
+	backend := faultstore.New()
+	srv := servertest.New(t, nil, servertest.WithBackend(backend))
+	token := srv.CreateUser(t, "speaker", "secret")
+	c, err := client.New(servertest.Target,
+		client.WithDialOptions(srv.DialOption()),
+		client.WithToken(client.Token{AccessToken: token}),
+		client.WithRetryPolicy(client.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { c.Close() })
+	return backend, c
+}
+
+func TestUploadResumesAfterWriteFailure(t *testing.T) {
+	backend, c := setup(t)
+	video := bytes.Repeat([]byte("coscup"), 700_000) // four chunks
+	backend.FailWriteAt(2_500_000)
+
+	resp, err := c.Upload(context.Background(), "talk", bytes.NewReader(video), int64(len(video)), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, backend.FailedWrites())
+	// The failed chunk was sent again by a second stream.
+	assert.Equal(t, int64(2), resp.Stats.Attempts)
+	assert.Equal(t, int64(1<<20), resp.Stats.RetransmittedBytes)
+	assert.Equal(t, int64(len(video)-2<<20), resp.Stats.Bytes)
+
+	var got bytes.Buffer
+	_, err = c.Download(context.Background(), "talk", &got, nil)
+	require.NoError(t, err)
+	assert.Equal(t, video, got.Bytes())
+}
+
+func TestDiskFullDegradesStorage(t *testing.T) {
+	backend := faultstore.New()
+	cfg := env.DefaultConfig()
+	cfg.AdminUsers = []string{"admin"}
+	smtp := smtptest.New(t)
+	cfg.SMTPAddr = smtp.Addr()
+	cfg.AlertEmails = []string{"ops@example.com"}
+	srv := servertest.New(t, cfg, servertest.WithBackend(backend))
+	adminToken := srv.CreateUser(t, "admin", "secret")
+	c, err := client.New(servertest.Target,
+		client.WithDialOptions(srv.DialOption()),
+		client.WithToken(client.Token{AccessToken: srv.CreateUser(t, "speaker", "secret")}),
+		client.WithRetryPolicy(client.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { c.Close() })
+	storageHealth := func() healthpb.HealthCheckResponse_ServingStatus {
+		resp, err := srv.Health().Check(context.Background(), &healthpb.HealthCheckRequest{Service: media.StorageHealthService})
+		require.NoError(t, err)
+		return resp.Status
+	}
+	alerts := func() int {
+		resp, err := pbNotification.NewNotificationServiceClient(srv.Conn).ListNotifications(servertest.Context(adminToken), &pbNotification.ListNotificationsRequest{})
+		require.NoError(t, err)
+		n := 0
+		for _, note := range resp.Notifications {
+			if note.Kind == pbNotification.NotificationKind_NOTIFICATION_KIND_STORAGE_ALERT {
+				n++
+			}
+		}
+		return n
+	}
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, storageHealth())
+
+	video := bytes.Repeat([]byte("coscup"), 700_000)
+	backend.FillDiskAt(1 << 20)
+	_, err = c.Upload(context.Background(), "talk", bytes.NewReader(video), int64(len(video)), nil)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	var retry *errdetails.RetryInfo
+	for _, d := range status.Convert(err).Details() {
+		if r, ok := d.(*errdetails.RetryInfo); ok {
+			retry = r
+		}
+	}
+	require.NotNil(t, retry)
+	assert.Positive(t, retry.RetryDelay.AsDuration())
+	// A full disk is not transient, so the client did not retry.
+	assert.Equal(t, 1, backend.FailedWrites())
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, storageHealth())
+	assert.Equal(t, 1, alerts())
+	mail := smtp.RequireMail(t)
+	assert.Equal(t, []string{"ops@example.com"}, mail.To)
+	assert.Equal(t, "[COSCUP] Storage alert", mail.Header.Get("Subject"))
+	assert.Contains(t, mail.Body, "uploads are paused")
+
+	backend.FillDiskAt(-1)
+	_, err = c.Upload(context.Background(), "other", bytes.NewReader(video), int64(len(video)), nil)
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, storageHealth())
+	assert.Equal(t, 2, alerts())
+	assert.Contains(t, smtp.RequireMail(t).Body, "uploads resumed")
+}
+
+func TestDownloadDetectsCorruption(t *testing.T) {
+	backend, c := setup(t)
+	video := []byte("recording")
+	_, err := c.Upload(context.Background(), "talk", bytes.NewReader(video), int64(len(video)), nil)
+	require.NoError(t, err)
+
+	backend.CorruptAt(3)
+	_, err = c.Download(context.Background(), "talk", &bytes.Buffer{}, nil)
+	assert.ErrorIs(t, err, client.ErrChecksumMismatch)
+}
+
+func TestSlowReadsHitDeadline(t *testing.T) {
+	backend, c := setup(t)
+	video := []byte("recording")
+	_, err := c.Upload(context.Background(), "talk", bytes.NewReader(video), int64(len(video)), nil)
+	require.NoError(t, err)
+
+	backend.DelayReads(200 * time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = c.Download(ctx, "talk", &bytes.Buffer{}, nil)
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+}
@@ -0,0 +1,102 @@
+package gateway
+
+import (
+	"bytes"
+	"coscup2025/proto/media"
+	"crypto/sha512"
+	"embed"
+	"encoding/base64"
+	"encoding/hex"
+	"html/template"
+	"net/http"
+	"os"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+//go:embed templates/embed.html
+var templateFS embed.FS
+
+var embedTemplate = template.Must(template.ParseFS(templateFS, "templates/embed.html"))
+
+// playerPath is where the gateway serves the HLS.js build of a PlayerScript.
+const playerPath = "/embed/player/hls.min.js"
+
+// PlayerScript is an HLS.js build that embed pages load from the gateway
+// itself, pinned by its Subresource Integrity hash, for browsers without
+// native HLS playback.
+type PlayerScript struct {
+	// URL changes with the content, so browsers may cache it for good.
+	URL       string
+	Integrity string
+	script    []byte
+}
+
+// ReadPlayerScript reads the HLS.js build in the file at path.
+func ReadPlayerScript(path string) (*PlayerScript, error) {
+	script, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha512.Sum384(script)
+	return &PlayerScript{
+		URL:       playerPath + "?v=" + hex.EncodeToString(sum[:6]),
+		Integrity: "sha384-" + base64.StdEncoding.EncodeToString(sum[:]),
+		script:    script,
+	}, nil
+}
+
+// Serve serves the script at its URL.
+func (p *PlayerScript) Serve(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+	w.Header().Set("Content-Type", "text/javascript; charset=utf-8")
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	w.Write(p.script)
+}
+
+// embedPage is the data rendered into templates/embed.html.
+type embedPage struct {
+	Title  string
+	Source string
+	HLS    bool
+	Poster string
+	Player *PlayerScript
+}
+
+// Embed serves a minimal player page for a video, meant to be iframed into
+// other sites. Public videos play without a token; others need the viewer's
+// token or a video token as the access_token query parameter. HLS videos play
+// natively, or through player where the browser cannot and player is not nil.
+func Embed(client media.MediaServiceClient, player *PlayerScript) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		resp, err := client.GetEmbed(outgoingContext(r), &media.GetEmbedRequest{
+			VideoId: pathParams["video_id"],
+		})
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		page := embedPage{
+			Title:  resp.VideoId,
+			Source: resp.SourceUrl,
+			HLS:    resp.Hls,
+			Poster: resp.ThumbnailUrl,
+			Player: player,
+		}
+		if name := resp.Metadata.GetFileName(); name != "" {
+			page.Title = name
+		}
+
+		var buf bytes.Buffer
+		if err := embedTemplate.Execute(&buf, page); err != nil {
+			http.Error(w, "failed to render player", http.StatusInternalServerError)
+			return
+		}
+
+		// The page embeds a signed source URL, which must not be shared
+		// through caches.
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Cache-Control", "private, no-store")
+		w.Write(buf.Bytes())
+	}
+}
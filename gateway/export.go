@@ -0,0 +1,88 @@
+package gateway
+
+import (
+	"cmp"
+	"context"
+	"coscup2025/proto/account"
+	"coscup2025/proto/media"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+// DownloadExport serves the archive of a finished data export as a file.
+func DownloadExport(client account.AccountServiceClient) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		ctx, cancel := context.WithCancel(outgoingContext(r))
+		defer cancel()
+
+		stream, err := client.DownloadExport(ctx, &account.DownloadExportRequest{ExportId: pathParams["export_id"]})
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		serveExport(w, stream.Recv, "application/zip")
+	}
+}
+
+// DownloadVideoExport serves the archive or manifest of a finished export of
+// videos as a file.
+func DownloadVideoExport(client media.MediaServiceClient) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		ctx, cancel := context.WithCancel(outgoingContext(r))
+		defer cancel()
+
+		stream, err := client.DownloadExport(ctx, &media.DownloadExportRequest{ExportId: pathParams["export_id"]})
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		serveExport(w, stream.Recv, "")
+	}
+}
+
+// exportChunk is a message of an export download, whose first message names
+// the file and its size.
+type exportChunk interface {
+	GetFileName() string
+	GetSize() int64
+	GetData() []byte
+}
+
+// serveExport writes the file of an export download, received with recv. Its
+// content type is that of the first message if it has one, else
+// contentType.
+func serveExport[C exportChunk](w http.ResponseWriter, recv func() (C, error), contentType string) {
+	// As with videos, wait for the first chunk so errors keep their status
+	// codes.
+	chunk, err := recv()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if typed, ok := any(chunk).(interface{ GetContentType() string }); ok {
+		contentType = cmp.Or(typed.GetContentType(), contentType)
+	}
+
+	w.Header().Set("Content-Type", cmp.Or(contentType, "application/octet-stream"))
+	w.Header().Set("Content-Length", strconv.FormatInt(chunk.GetSize(), 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": chunk.GetFileName()}))
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.WriteHeader(http.StatusOK)
+
+	for {
+		if _, err := w.Write(chunk.GetData()); err != nil {
+			return
+		}
+		chunk, err = recv()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			panic(http.ErrAbortHandler)
+		}
+	}
+}
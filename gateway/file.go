@@ -0,0 +1,153 @@
+package gateway
+
+import (
+	"context"
+	"coscup2025/proto/media"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DownloadFile serves a video as a plain file download, for browsers and
+// signed URLs that pass the token as an access_token query parameter. A
+// single byte range, e.g. from a video player seeking, is answered with 206
+// Partial Content. HEAD requests get the same headers without the body.
+// Downloads are saved under the name of the uploaded file. Mirrors may pass
+// priority=batch to yield the server's bandwidth to players.
+func DownloadFile(client media.MediaServiceClient) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		videoID := pathParams["video_id"]
+		offset, length, ranged := parseRange(r.Header.Get("Range"))
+
+		ctx, cancel := context.WithCancel(outgoingContext(r))
+		defer cancel()
+
+		req := &media.DownloadVideoRequest{VideoId: videoID, Offset: offset, Length: length}
+		if p := r.URL.Query().Get("priority"); p != "" {
+			priority, ok := media.DownloadPriority_value["DOWNLOAD_PRIORITY_"+strings.ToUpper(p)]
+			if !ok {
+				writeError(w, status.Errorf(codes.InvalidArgument, "unknown priority %q", p))
+				return
+			}
+			req.Priority = media.DownloadPriority(priority)
+		}
+		if r.Method == http.MethodHead {
+			// The first chunk carries the metadata; a byte of content is
+			// the least that can be asked for. Video tokens of signed URLs
+			// only allow DownloadVideo, so GetVideoMetadata is no option.
+			req.Length = 1
+		}
+		stream, err := client.DownloadVideo(ctx, req)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		// Receive the first chunk before committing the response so that
+		// auth and lookup errors map to regular HTTP status codes.
+		chunk, err := stream.Recv()
+		if status.Code(err) == codes.OutOfRange {
+			http.Error(w, status.Convert(err).Message(), http.StatusRequestedRangeNotSatisfiable)
+			return
+		}
+		if err != nil && err != io.EOF {
+			writeError(w, err)
+			return
+		}
+
+		code := http.StatusOK
+		name := videoID
+		if chunk != nil && chunk.Metadata != nil {
+			if chunk.Metadata.FileName != "" {
+				name = chunk.Metadata.FileName
+			}
+			if chunk.Metadata.Sha256 != "" {
+				w.Header().Set("ETag", `"`+chunk.Metadata.Sha256+`"`)
+			}
+			size := chunk.Metadata.FileSize
+			if ranged {
+				end := size
+				if length > 0 {
+					end = min(offset+length, size)
+				}
+				if offset >= end {
+					w.Header().Set("Content-Range", fmt.Sprintf("bytes */%d", size))
+					http.Error(w, "range not satisfiable", http.StatusRequestedRangeNotSatisfiable)
+					return
+				}
+				w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", offset, end-1, size))
+				size = end - offset
+				code = http.StatusPartialContent
+			}
+			w.Header().Set("Content-Length", strconv.FormatInt(size, 10))
+		}
+		w.Header().Set("Accept-Ranges", "bytes")
+		w.Header().Set("Content-Type", contentType(name))
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": path.Base(name)}))
+		w.WriteHeader(code)
+		if r.Method == http.MethodHead {
+			return
+		}
+
+		for chunk != nil {
+			if _, err := w.Write(chunk.Data); err != nil {
+				return
+			}
+
+			chunk, err = stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				// The status line is gone; cut the body short so the client
+				// sees a truncated download instead of a complete one.
+				panic(http.ErrAbortHandler)
+			}
+		}
+	}
+}
+
+// contentType guesses the type of a video from its file name. Extensions
+// the system maps to anything else, such as .ts to a translation file, are
+// not trusted.
+func contentType(name string) string {
+	if t := mime.TypeByExtension(path.Ext(name)); strings.HasPrefix(t, "video/") {
+		return t
+	}
+	return "application/octet-stream"
+}
+
+// parseRange parses a Range header asking for a single range of bytes from a
+// known offset, "bytes=<first>-[<last>]". Other forms, such as suffix or
+// multiple ranges, are ignored and the whole video is sent, as RFC 9110
+// allows.
+func parseRange(header string) (offset, length int64, ok bool) {
+	spec, found := strings.CutPrefix(header, "bytes=")
+	if !found || strings.Contains(spec, ",") {
+		return 0, 0, false
+	}
+	first, last, found := strings.Cut(strings.TrimSpace(spec), "-")
+	if !found {
+		return 0, 0, false
+	}
+	offset, err := strconv.ParseInt(first, 10, 64)
+	if err != nil || offset < 0 {
+		return 0, 0, false
+	}
+	if last == "" {
+		return offset, 0, true
+	}
+	end, err := strconv.ParseInt(last, 10, 64)
+	if err != nil || end < offset {
+		return 0, 0, false
+	}
+	return offset, end - offset + 1, true
+}
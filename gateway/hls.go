@@ -0,0 +1,50 @@
+package gateway
+
+import (
+	"coscup2025/proto/media"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+// HLS serves the playlist (index.m3u8) and the segments (<n>.ts) of a
+// video. The playlist is authorized like any other call, or not at all for
+// public videos; segments by the signed token in their URI.
+func HLS(client media.MediaServiceClient) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		videoID, file := pathParams["video_id"], pathParams["file"]
+
+		if file == "index.m3u8" {
+			resp, err := client.GetHLSPlaylist(outgoingContext(r), &media.GetHLSPlaylistRequest{VideoId: videoID})
+			if err != nil {
+				writeError(w, err)
+				return
+			}
+			// The playlist holds fresh segment tokens; never reuse it.
+			w.Header().Set("Content-Type", "application/vnd.apple.mpegurl")
+			w.Header().Set("Cache-Control", "private, no-store")
+			w.Write([]byte(resp.Playlist))
+			return
+		}
+
+		index, err := strconv.ParseInt(strings.TrimSuffix(file, ".ts"), 10, 32)
+		if err != nil || !strings.HasSuffix(file, ".ts") {
+			http.NotFound(w, r)
+			return
+		}
+		resp, err := client.GetHLSSegment(r.Context(), &media.GetHLSSegmentRequest{
+			VideoId: videoID,
+			Index:   int32(index),
+			Token:   r.URL.Query().Get("token"),
+		})
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "video/mp2t")
+		w.Header().Set("Cache-Control", "private, max-age=3600")
+		w.Write(resp.Data)
+	}
+}
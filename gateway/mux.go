@@ -0,0 +1,116 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"coscup2025/mesh"
+	pbAccount "coscup2025/proto/account"
+	pbAuth "coscup2025/proto/auth"
+	pbComment "coscup2025/proto/comment"
+	pbMedia "coscup2025/proto/media"
+	pbMediaV2 "coscup2025/proto/media/v2"
+	pbModeration "coscup2025/proto/moderation"
+	pbNotification "coscup2025/proto/notification"
+)
+
+// NewMux returns the gateway in front of the gRPC services behind conn: their
+// REST routes and the handlers of this package. Every route shares conn.
+// Embed pages load player, which may be nil, for HLS videos.
+func NewMux(ctx context.Context, conn *grpc.ClientConn, player *PlayerScript) (*runtime.ServeMux, error) {
+	mux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(func(key string) (string, bool) {
+			switch strings.ToLower(key) {
+			case "authorization":
+				return "authorization", true
+			case "accept-language":
+				return "accept-language", true
+			case mesh.Header, "grpc-metadata-" + mesh.Header:
+				// Gateway calls come from the gateway itself; a peer
+				// certificate sent by the HTTP client must not reach the
+				// services, where it would name any peer the client likes.
+				return "", false
+			default:
+				return runtime.DefaultHeaderMatcher(key)
+			}
+		}),
+		runtime.WithForwardResponseOption(func(ctx context.Context, w http.ResponseWriter, _ proto.Message) error {
+			md, ok := runtime.ServerMetadataFromContext(ctx)
+			if ok {
+				if tokens := md.HeaderMD.Get("x-auth-token"); len(tokens) > 0 {
+					w.Header().Set("X-Auth-Token", tokens[0])
+				}
+				// Localized video metadata depends on Accept-Language.
+				if langs := md.HeaderMD.Get("content-language"); len(langs) > 0 {
+					w.Header().Set("Content-Language", langs[0])
+					w.Header().Add("Vary", "Accept-Language")
+				}
+				// Deprecated RPCs name their successor.
+				if deprecated := md.HeaderMD.Get("deprecation"); len(deprecated) > 0 {
+					w.Header().Set("Deprecation", deprecated[0])
+					for _, link := range md.HeaderMD.Get("link") {
+						w.Header().Add("Link", link)
+					}
+				}
+			}
+			return nil
+		}),
+	)
+
+	services := []func(context.Context, *runtime.ServeMux, *grpc.ClientConn) error{
+		pbAuth.RegisterAuthServiceHandler,
+		pbMedia.RegisterMediaServiceHandler,
+		pbMediaV2.RegisterMediaServiceHandler,
+		pbNotification.RegisterNotificationServiceHandler,
+		pbComment.RegisterCommentServiceHandler,
+		pbModeration.RegisterModerationServiceHandler,
+		pbAccount.RegisterAccountServiceHandler,
+	}
+	for _, register := range services {
+		if err := register(ctx, mux, conn); err != nil {
+			return nil, fmt.Errorf("failed to register gateway: %v", err)
+		}
+	}
+
+	mediaClient := pbMedia.NewMediaServiceClient(conn)
+	handlers := []struct {
+		pattern string
+		handler runtime.HandlerFunc
+	}{
+		{"/v1/video/ws/{video_id}", DownloadWebSocket(mediaClient)},
+		{"/v1/videos/{video_id}/events", ProgressEvents(mediaClient)},
+		{"/v1/video/file/{video_id}", DownloadFile(mediaClient)},
+		{"/s/{code}", ShareLink(mediaClient)},
+		{"/v1/public/videos", PublicVideos(mediaClient)},
+		{"/v1/public/videos/{video_id}/thumbnail", Thumbnail(mediaClient)},
+		{"/v1/public/users/{user_id}/avatar", Avatar(mediaClient)},
+		{"/embed/{video_id}", Embed(mediaClient, player)},
+		{"/v1/hls/{video_id}/{file}", HLS(mediaClient)},
+		{"/v1/me/exports/{export_id}/archive", DownloadExport(pbAccount.NewAccountServiceClient(conn))},
+		{"/v1/exports/{export_id}/download", DownloadVideoExport(mediaClient)},
+	}
+	for _, h := range handlers {
+		if err := mux.HandlePath("GET", h.pattern, h.handler); err != nil {
+			return nil, fmt.Errorf("failed to register handler for %s: %v", h.pattern, err)
+		}
+	}
+	if player != nil {
+		if err := mux.HandlePath("GET", playerPath, player.Serve); err != nil {
+			return nil, fmt.Errorf("failed to register handler for %s: %v", playerPath, err)
+		}
+	}
+	// Clients and proxies check the size and ETag of a file before fetching it.
+	if err := mux.HandlePath("HEAD", "/v1/video/file/{video_id}", DownloadFile(mediaClient)); err != nil {
+		return nil, fmt.Errorf("failed to register handler for HEAD /v1/video/file: %v", err)
+	}
+	if err := mux.HandlePath("POST", "/v1/webhooks/transcoder", TranscoderWebhook(mediaClient)); err != nil {
+		return nil, fmt.Errorf("failed to register handler for /v1/webhooks/transcoder: %v", err)
+	}
+	return mux, nil
+}
@@ -0,0 +1,98 @@
+package gateway
+
+import (
+	"coscup2025/proto/media"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// publicMaxAge is how long caches may reuse public responses without
+// revalidating them.
+const publicMaxAge = "60"
+
+// PublicVideos serves the gallery of published videos to the conference
+// website, a page at a time with the page_size and page_token query
+// parameters. Responses carry an ETag, so caches and browsers can revalidate
+// them cheaply.
+func PublicVideos(client media.MediaServiceClient) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		req := &media.ListPublicVideosRequest{PageToken: r.URL.Query().Get("page_token")}
+		if size, err := strconv.ParseInt(r.URL.Query().Get("page_size"), 10, 32); err == nil {
+			req.PageSize = int32(size)
+		}
+		resp, err := client.ListPublicVideos(r.Context(), req)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		body, err := protojson.Marshal(resp)
+		if err != nil {
+			http.Error(w, "failed to encode response", http.StatusInternalServerError)
+			return
+		}
+		serveCacheable(w, r, "application/json", body)
+	}
+}
+
+// Thumbnail serves the thumbnail image of a video.
+func Thumbnail(client media.MediaServiceClient) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		resp, err := client.GetThumbnail(outgoingContext(r), &media.GetThumbnailRequest{
+			VideoId: pathParams["video_id"],
+		})
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		serveCacheable(w, r, resp.ContentType, resp.Data)
+	}
+}
+
+// Avatar serves the avatar image of a user.
+func Avatar(client media.MediaServiceClient) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		resp, err := client.GetAvatar(outgoingContext(r), &media.GetAvatarRequest{
+			UserId: pathParams["user_id"],
+		})
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		serveCacheable(w, r, resp.ContentType, resp.Data)
+	}
+}
+
+// serveCacheable writes body with a strong ETag derived from its content,
+// or 304 Not Modified if the client already has it.
+func serveCacheable(w http.ResponseWriter, r *http.Request, contentType string, body []byte) {
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "public, max-age="+publicMaxAge)
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Write(body)
+}
+
+// etagMatches reports whether an If-None-Match header lists etag.
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,26 @@
+package gateway
+
+import (
+	"coscup2025/proto/media"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+// ShareLink redirects a short share link to the player or to a signed
+// download URL. Every visit counts as a click.
+func ShareLink(client media.MediaServiceClient) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		resp, err := client.ResolveShareLink(r.Context(), &media.ResolveShareLinkRequest{
+			Code: pathParams["code"],
+		})
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		// Download URLs carry a short-lived token, so neither may be cached.
+		w.Header().Set("Cache-Control", "no-store")
+		http.Redirect(w, r, resp.RedirectUrl, http.StatusFound)
+	}
+}
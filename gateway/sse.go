@@ -0,0 +1,74 @@
+package gateway
+
+import (
+	"coscup2025/proto/media"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// ProgressEvents bridges the WatchProgress stream onto server-sent events so
+// web UIs can render live upload progress with a plain EventSource.
+func ProgressEvents(client media.MediaServiceClient) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+
+		stream, err := client.WatchProgress(outgoingContext(r), &media.WatchProgressRequest{
+			VideoId: pathParams["video_id"],
+		})
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		// Receive the first event before committing the response so that
+		// auth and validation errors map to regular HTTP status codes.
+		ev, err := stream.Recv()
+		if err != nil && err != io.EOF {
+			writeError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.WriteHeader(http.StatusOK)
+
+		for ev != nil {
+			if ev.Heartbeat {
+				// A comment, which EventSource ignores, keeps proxies from
+				// closing the idle connection.
+				if _, err := io.WriteString(w, ": heartbeat\n\n"); err != nil {
+					return
+				}
+			} else {
+				data, err := protojson.Marshal(ev)
+				if err != nil {
+					return
+				}
+				if _, err := fmt.Fprintf(w, "event: progress\ndata: %s\n\n", data); err != nil {
+					return
+				}
+			}
+			flusher.Flush()
+
+			ev, err = stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				fmt.Fprintf(w, "event: error\ndata: %q\n\n", status.Convert(err).Message())
+				flusher.Flush()
+				return
+			}
+		}
+	}
+}
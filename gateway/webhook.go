@@ -0,0 +1,36 @@
+package gateway
+
+import (
+	"coscup2025/proto/media"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+// maxWebhookBody is the largest callback body read, as ReportTranscode
+// accepts.
+const maxWebhookBody = 64 << 10
+
+// TranscoderWebhook receives the callbacks of the transcoder. The body is
+// passed on as it was signed, with the X-Coscup-Signature header.
+func TranscoderWebhook(client media.MediaServiceClient) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBody))
+		if err != nil {
+			http.Error(w, "callback body is too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		resp, err := client.ReportTranscode(outgoingContext(r), &media.ReportTranscodeRequest{
+			Payload:   payload,
+			Signature: r.Header.Get("X-Coscup-Signature"),
+		})
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]bool{"duplicate": resp.Duplicate, "stale": resp.Stale})
+	}
+}
@@ -0,0 +1,121 @@
+package gateway
+
+import (
+	"context"
+	"coscup2025/proto/media"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/websocket"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 64 * 1024,
+}
+
+// downloadHeader is the first (text) frame sent on a download WebSocket,
+// followed by one binary frame per video chunk.
+type downloadHeader struct {
+	VideoID  string          `json:"video_id"`
+	Metadata json.RawMessage `json:"metadata,omitempty"`
+}
+
+// DownloadWebSocket bridges the DownloadVideo stream onto a WebSocket so
+// browsers can receive raw binary chunks instead of chunked JSON.
+func DownloadWebSocket(client media.MediaServiceClient) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		videoID := pathParams["video_id"]
+
+		ctx, cancel := context.WithCancel(outgoingContext(r))
+		defer cancel()
+
+		stream, err := client.DownloadVideo(ctx, &media.DownloadVideoRequest{VideoId: videoID})
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		// Receive the first frame before upgrading so that auth and lookup
+		// errors surface as regular HTTP status codes.
+		first, err := stream.Recv()
+		if err != nil && err != io.EOF {
+			writeError(w, err)
+			return
+		}
+
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			log.Printf("websocket upgrade failed: %v", err)
+			return
+		}
+		defer conn.Close()
+
+		header := downloadHeader{VideoID: videoID}
+		if first != nil && first.Metadata != nil {
+			header.Metadata, _ = protojson.Marshal(first.Metadata)
+		}
+		if err := conn.WriteJSON(header); err != nil {
+			return
+		}
+
+		chunk := first
+		for chunk != nil {
+			// The last message only carries the stats of the stream.
+			if chunk.Stats != nil && len(chunk.Data) == 0 {
+				break
+			}
+			if err := conn.WriteMessage(websocket.BinaryMessage, chunk.Data); err != nil {
+				return
+			}
+
+			chunk, err = stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				closeWithError(conn, err)
+				return
+			}
+		}
+
+		conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	}
+}
+
+// outgoingContext forwards the caller's credentials to the gRPC backend.
+// Browsers cannot set headers on WebSocket or EventSource requests, so the
+// token may also be passed as an access_token query parameter.
+func outgoingContext(r *http.Request) context.Context {
+	token := r.Header.Get("Authorization")
+	if token == "" {
+		if t := r.URL.Query().Get("access_token"); t != "" {
+			token = "Bearer " + t
+		}
+	}
+	if token == "" {
+		return r.Context()
+	}
+	return metadata.AppendToOutgoingContext(r.Context(), "authorization", token)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	http.Error(w, st.Message(), runtime.HTTPStatusFromCode(st.Code()))
+}
+
+func closeWithError(conn *websocket.Conn, err error) {
+	// Close reasons are limited to 123 bytes by the protocol.
+	reason := status.Convert(err).Message()
+	if len(reason) > 123 {
+		reason = strings.ToValidUTF8(reason[:123], "")
+	}
+	conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseInternalServerErr, reason))
+}
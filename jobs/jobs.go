@@ -0,0 +1,186 @@
+// Package jobs runs long tasks in the background and keeps their results for
+// a while, so that clients can poll for them instead of holding a request
+// open.
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// State is the progress of a job.
+type State int
+
+const (
+	Pending State = iota
+	Running
+	Done
+	Failed
+)
+
+// Result is what a finished job produced, such as an archive to download.
+type Result struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+// Func does the work of a job. It should give up when ctx is done.
+type Func func(ctx context.Context) (*Result, error)
+
+// Job is a snapshot of a submitted job.
+type Job struct {
+	ID       string
+	Owner    string // the user who submitted the job
+	Kind     string // e.g. "export"
+	State    State
+	Err      string // why the job failed
+	Result   *Result
+	Created  time.Time
+	Finished time.Time
+	Expires  time.Time // when a finished job is forgotten
+}
+
+// ErrQueueFull is returned by Submit when the workers are not keeping up.
+var ErrQueueFull = errors.New("job queue is full")
+
+const queueSize = 64
+
+type job struct {
+	Job
+	fn Func
+}
+
+// Runner runs submitted jobs on a fixed number of workers.
+type Runner struct {
+	mu     sync.Mutex
+	jobs   map[string]*job
+	nextID int64
+	ttl    time.Duration
+	queue  chan *job
+	wg     sync.WaitGroup
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewRunner starts workers goroutines running jobs. Finished jobs and their
+// results are kept for ttl.
+func NewRunner(workers int, ttl time.Duration) *Runner {
+	if workers < 1 {
+		workers = 1
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &Runner{
+		jobs:   make(map[string]*job),
+		ttl:    ttl,
+		queue:  make(chan *job, queueSize),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+	r.wg.Add(workers)
+	for range workers {
+		go r.run()
+	}
+	return r
+}
+
+// Submit queues fn as a job of owner and returns it while still pending.
+func (r *Runner) Submit(owner, kind string, fn Func) (Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.expire(time.Now())
+	r.nextID++
+	j := &job{
+		Job: Job{
+			ID:      fmt.Sprintf("job_%d", r.nextID),
+			Owner:   owner,
+			Kind:    kind,
+			State:   Pending,
+			Created: time.Now(),
+		},
+		fn: fn,
+	}
+	select {
+	case r.queue <- j:
+	default:
+		return Job{}, ErrQueueFull
+	}
+	r.jobs[j.ID] = j
+	return j.Job, nil
+}
+
+// Get returns the job with the given ID, unless it expired.
+func (r *Runner) Get(id string) (Job, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.expire(time.Now())
+	j, ok := r.jobs[id]
+	if !ok {
+		return Job{}, false
+	}
+	return j.Job, true
+}
+
+// DeleteOwner forgets all jobs of owner and their results. Jobs still
+// running finish, but their results are dropped.
+func (r *Runner) DeleteOwner(owner string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, j := range r.jobs {
+		if j.Owner == owner {
+			delete(r.jobs, id)
+		}
+	}
+}
+
+// Close cancels running jobs and waits for the workers to stop. Jobs still
+// queued are dropped.
+func (r *Runner) Close() {
+	r.cancel()
+	close(r.queue)
+	r.wg.Wait()
+}
+
+// expire forgets jobs that finished more than ttl ago. The caller must hold
+// r.mu.
+func (r *Runner) expire(now time.Time) {
+	for id, j := range r.jobs {
+		if !j.Expires.IsZero() && now.After(j.Expires) {
+			delete(r.jobs, id)
+		}
+	}
+}
+
+func (r *Runner) run() {
+	defer r.wg.Done()
+	for j := range r.queue {
+		if r.ctx.Err() != nil {
+			continue
+		}
+		r.mu.Lock()
+		j.State = Running
+		r.mu.Unlock()
+
+		result, err := j.fn(r.ctx)
+
+		r.mu.Lock()
+		j.Finished = time.Now()
+		j.Expires = j.Finished.Add(r.ttl)
+		if err != nil {
+			log.Printf("Job %s (%s) failed: %v", j.ID, j.Kind, err)
+			j.State = Failed
+			j.Err = err.Error()
+		} else {
+			j.State = Done
+			j.Result = result
+		}
+		r.mu.Unlock()
+	}
+}
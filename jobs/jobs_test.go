@@ -0,0 +1,60 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// wait polls until the job leaves the queue.
+func wait(t *testing.T, r *Runner, id string) Job {
+	var j Job
+	require.Eventually(t, func() bool {
+		var ok bool
+		j, ok = r.Get(id)
+		return ok && (j.State == Done || j.State == Failed)
+	}, time.Second, time.Millisecond)
+	return j
+}
+
+func TestRunnerRunsJobs(t *testing.T) {
+	r := NewRunner(2, time.Hour)
+	defer r.Close()
+
+	ok, err := r.Submit("user_1", "export", func(context.Context) (*Result, error) {
+		return &Result{Name: "export.zip", Data: []byte("zip")}, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, Pending, ok.State)
+	failing, err := r.Submit("user_1", "export", func(context.Context) (*Result, error) {
+		return nil, errors.New("disk full")
+	})
+	require.NoError(t, err)
+
+	done := wait(t, r, ok.ID)
+	assert.Equal(t, Done, done.State)
+	assert.Equal(t, "user_1", done.Owner)
+	assert.Equal(t, []byte("zip"), done.Result.Data)
+	assert.False(t, done.Expires.Before(done.Finished.Add(time.Hour)))
+
+	failed := wait(t, r, failing.ID)
+	assert.Equal(t, Failed, failed.State)
+	assert.Equal(t, "disk full", failed.Err)
+}
+
+func TestRunnerExpiresFinishedJobs(t *testing.T) {
+	r := NewRunner(1, 100*time.Millisecond)
+	defer r.Close()
+
+	j, err := r.Submit("user_1", "export", func(context.Context) (*Result, error) { return &Result{}, nil })
+	require.NoError(t, err)
+	wait(t, r, j.ID)
+
+	time.Sleep(200 * time.Millisecond)
+	_, ok := r.Get(j.ID)
+	assert.False(t, ok)
+}
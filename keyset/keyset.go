@@ -0,0 +1,100 @@
+// Package keyset holds the server secret across rotations. The secret signs
+// tokens, and the keys of other purposes, such as page tokens and HLS segment
+// tokens, are derived from it, so that they rotate with it:
+//
+//	keys := keyset.New([]byte(cfg.JWTSecret))
+//	segments := keys.Derive("hls-segments")
+//	mac := hmac.New(sha256.New, segments.Current())
+package keyset
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"sync"
+	"time"
+)
+
+// Set is the current secret and the one it replaced, which stays valid for
+// a grace period after a rotation.
+type Set struct {
+	mu            sync.RWMutex
+	current       []byte
+	previous      []byte
+	previousUntil time.Time
+}
+
+func New(secret []byte) *Set {
+	return &Set{current: secret}
+}
+
+// Current returns the secret that signs.
+func (s *Set) Current() []byte {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.current
+}
+
+// Valid returns the secrets that verify: the current one, then the previous
+// one during its grace period.
+func (s *Set) Valid() [][]byte {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	keys := [][]byte{s.current}
+	if s.previous != nil && time.Now().Before(s.previousUntil) {
+		keys = append(keys, s.previous)
+	}
+	return keys
+}
+
+// Rotate makes secret the current one. The secret it replaces stays valid for
+// grace. It reports whether the secret changed.
+func (s *Set) Rotate(secret []byte, grace time.Duration) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if bytes.Equal(secret, s.current) {
+		return false
+	}
+	s.previous, s.previousUntil = s.current, time.Now().Add(grace)
+	s.current = secret
+	return true
+}
+
+// EndGrace stops accepting the previous secret, e.g. because it leaked.
+func (s *Set) EndGrace() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.previous, s.previousUntil = nil, time.Time{}
+}
+
+// Derived is the key of one purpose, derived from each secret of a Set so
+// that it cannot be confused with anything else signed by them.
+type Derived struct {
+	set     *Set
+	purpose string
+}
+
+func (s *Set) Derive(purpose string) Derived {
+	return Derived{set: s, purpose: purpose}
+}
+
+// Current returns the key that signs.
+func (d Derived) Current() []byte {
+	return derive(d.set.Current(), d.purpose)
+}
+
+// Valid returns the keys that verify, like Set.Valid.
+func (d Derived) Valid() [][]byte {
+	secrets := d.set.Valid()
+	keys := make([][]byte, len(secrets))
+	for i, secret := range secrets {
+		keys[i] = derive(secret, d.purpose)
+	}
+	return keys
+}
+
+func derive(secret []byte, purpose string) []byte {
+	h := hmac.New(sha256.New, secret)
+	h.Write([]byte(purpose))
+	return h.Sum(nil)
+}
@@ -0,0 +1,30 @@
+package keyset
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRotate(t *testing.T) {
+	keys := New([]byte("first"))
+	segments := keys.Derive("hls-segments")
+	first := segments.Current()
+	assert.NotEqual(t, first, keys.Derive("page-tokens").Current())
+
+	// The replaced secret verifies during its grace period.
+	assert.True(t, keys.Rotate([]byte("second"), time.Hour))
+	assert.False(t, keys.Rotate([]byte("second"), time.Hour))
+	assert.Equal(t, []byte("second"), keys.Current())
+	assert.Equal(t, [][]byte{[]byte("second"), []byte("first")}, keys.Valid())
+	assert.NotEqual(t, first, segments.Current())
+	assert.Equal(t, [][]byte{segments.Current(), first}, segments.Valid())
+
+	keys.EndGrace()
+	assert.Equal(t, [][]byte{segments.Current()}, segments.Valid())
+
+	// Without a grace period it is refused right away.
+	keys.Rotate([]byte("third"), 0)
+	assert.Equal(t, [][]byte{[]byte("third")}, keys.Valid())
+}
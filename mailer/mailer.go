@@ -0,0 +1,177 @@
+// Package mailer sends templated email through a background queue that
+// retries failed deliveries.
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"coscup2025/env"
+	"embed"
+	"errors"
+	"fmt"
+	"log"
+	"path"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+// Message is a rendered plain-text email.
+type Message struct {
+	To      []string
+	Subject string
+	Body    string
+}
+
+// Sender delivers a single message.
+type Sender interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// ErrQueueFull is returned by Send when deliveries are not keeping up.
+var ErrQueueFull = errors.New("mail queue is full")
+
+const (
+	queueSize   = 256
+	maxAttempts = 5
+	sendTimeout = 30 * time.Second
+)
+
+// Mailer renders messages from templates and delivers them in the background.
+type Mailer struct {
+	sender    Sender
+	templates map[string]*template.Template
+	queue     chan *Message
+	wg        sync.WaitGroup
+	// backoff is the delay before the second attempt, doubled for each one
+	// after it.
+	backoff time.Duration
+}
+
+// New returns a mailer delivering through cfg.SMTPAddr, or logging messages
+// instead when no SMTP server is configured, which suits development.
+func New(cfg *env.Config) (*Mailer, error) {
+	var sender Sender = LogSender{}
+	if cfg.SMTPAddr != "" {
+		sender = NewSMTPSender(cfg.SMTPAddr, cfg.SMTPUsername, cfg.SMTPPassword, cfg.MailFrom)
+	}
+	return newMailer(sender)
+}
+
+func newMailer(sender Sender) (*Mailer, error) {
+	templates, err := parseTemplates()
+	if err != nil {
+		return nil, err
+	}
+	m := &Mailer{
+		sender:    sender,
+		templates: templates,
+		queue:     make(chan *Message, queueSize),
+		backoff:   time.Second,
+	}
+	m.wg.Add(1)
+	go m.run()
+	return m, nil
+}
+
+// parseTemplates loads templates/<name>.tmpl, each defining a "subject" and a
+// "body" template.
+func parseTemplates() (map[string]*template.Template, error) {
+	files, err := templateFS.ReadDir("templates")
+	if err != nil {
+		return nil, err
+	}
+	templates := make(map[string]*template.Template)
+	for _, f := range files {
+		name := strings.TrimSuffix(f.Name(), ".tmpl")
+		t, err := template.ParseFS(templateFS, path.Join("templates", f.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse mail template %s: %v", name, err)
+		}
+		if t.Lookup("subject") == nil || t.Lookup("body") == nil {
+			return nil, fmt.Errorf("mail template %s must define subject and body", name)
+		}
+		templates[name] = t
+	}
+	return templates, nil
+}
+
+// Render fills the named template with data.
+func (m *Mailer) Render(name string, to []string, data any) (*Message, error) {
+	t, ok := m.templates[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown mail template %q", name)
+	}
+	var subject, body bytes.Buffer
+	if err := t.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, fmt.Errorf("failed to render %s subject: %v", name, err)
+	}
+	if err := t.ExecuteTemplate(&body, "body", data); err != nil {
+		return nil, fmt.Errorf("failed to render %s body: %v", name, err)
+	}
+	return &Message{
+		To:      to,
+		Subject: strings.TrimSpace(subject.String()),
+		Body:    strings.TrimSpace(body.String()) + "\n",
+	}, nil
+}
+
+// Send renders the named template and queues the message for delivery. It
+// does not wait for the message to be delivered.
+func (m *Mailer) Send(name string, to []string, data any) error {
+	msg, err := m.Render(name, to, data)
+	if err != nil {
+		return err
+	}
+	select {
+	case m.queue <- msg:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Close stops accepting messages and waits for the queued ones to be sent.
+func (m *Mailer) Close() {
+	close(m.queue)
+	m.wg.Wait()
+}
+
+func (m *Mailer) run() {
+	defer m.wg.Done()
+	for msg := range m.queue {
+		m.deliver(msg)
+	}
+}
+
+// deliver tries to send msg up to maxAttempts times with exponential backoff.
+func (m *Mailer) deliver(msg *Message) {
+	delay := m.backoff
+	for attempt := 1; ; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+		err := m.sender.Send(ctx, msg)
+		cancel()
+		if err == nil {
+			return
+		}
+		if attempt >= maxAttempts {
+			log.Printf("Giving up on mail %q to %s after %d attempts: %v", msg.Subject, strings.Join(msg.To, ", "), attempt, err)
+			return
+		}
+		log.Printf("Failed to send mail %q (attempt %d/%d), retrying in %s: %v", msg.Subject, attempt, maxAttempts, delay, err)
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// LogSender writes messages to the log instead of sending them.
+type LogSender struct{}
+
+func (LogSender) Send(_ context.Context, msg *Message) error {
+	log.Printf("Mail to %s\nSubject: %s\n\n%s", strings.Join(msg.To, ", "), msg.Subject, msg.Body)
+	return nil
+}
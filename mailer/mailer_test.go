@@ -0,0 +1,59 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flakySender fails its first `failures` sends.
+type flakySender struct {
+	mu       sync.Mutex
+	failures int
+	attempts int
+	sent     []*Message
+}
+
+func (s *flakySender) Send(_ context.Context, msg *Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attempts++
+	if s.attempts <= s.failures {
+		return errors.New("connection refused")
+	}
+	s.sent = append(s.sent, msg)
+	return nil
+}
+
+func TestSendRendersAndRetries(t *testing.T) {
+	sender := &flakySender{failures: 2}
+	m, err := newMailer(sender)
+	require.NoError(t, err)
+	m.backoff = time.Millisecond
+
+	err = m.Send("admin_alert", []string{"ops@example.com"}, map[string]any{
+		"Title":   "Disk almost full",
+		"Message": "Only 3% left.",
+		"Time":    time.Date(2025, 8, 9, 10, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	m.Close()
+
+	assert.Equal(t, 3, sender.attempts)
+	require.Len(t, sender.sent, 1)
+	assert.Equal(t, "[COSCUP] Disk almost full", sender.sent[0].Subject)
+	assert.Equal(t, "Only 3% left.\n\nTime: 2025-08-09 10:00:00 UTC\n", sender.sent[0].Body)
+}
+
+func TestSendUnknownTemplate(t *testing.T) {
+	m, err := newMailer(LogSender{})
+	require.NoError(t, err)
+	defer m.Close()
+
+	assert.Error(t, m.Send("missing", []string{"ops@example.com"}, nil))
+}
@@ -0,0 +1,30 @@
+package mailer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"coscup2025/smtptest"
+)
+
+func TestSMTPSenderDelivers(t *testing.T) {
+	server := smtptest.New(t)
+	sender := NewSMTPSender(server.Addr(), "", "", "COSCUP <noreply@coscup.org>")
+
+	err := sender.Send(context.Background(), &Message{
+		To:      []string{"ops@example.com", "oncall@example.com"},
+		Subject: "Disk almost full",
+		Body:    "Only 3% left.\nTime: now\n",
+	})
+	require.NoError(t, err)
+
+	mail := server.RequireMail(t)
+	assert.Equal(t, "noreply@coscup.org", mail.From)
+	assert.Equal(t, []string{"ops@example.com", "oncall@example.com"}, mail.To)
+	assert.Equal(t, `"COSCUP" <noreply@coscup.org>`, mail.Header.Get("From"))
+	assert.Equal(t, "Disk almost full", mail.Header.Get("Subject"))
+	assert.Equal(t, "Only 3% left.\nTime: now\n", mail.Body)
+}
@@ -2,42 +2,109 @@ package main
 
 import (
 	"context"
+	"flag"
+	"fmt"
 	"log"
 	"net"
 	"net/http"
-	"strings"
+	"net/http/pprof"
+	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/quic-go/quic-go/http3"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/exporters/zipkin"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	"go.opentelemetry.io/otel/sdk/trace"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
-	"google.golang.org/protobuf/proto"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/reflection"
 
+	"coscup2025/account"
+	"coscup2025/acl"
+	"coscup2025/audit"
 	"coscup2025/auth"
+	"coscup2025/cdn"
+	"coscup2025/chaos"
+	"coscup2025/comment"
+	"coscup2025/deprecation"
+	"coscup2025/env"
+	"coscup2025/gateway"
+	"coscup2025/jobs"
+	"coscup2025/keyset"
+	"coscup2025/mailer"
 	"coscup2025/media"
+	"coscup2025/mesh"
+	"coscup2025/metrics"
+	"coscup2025/moderation"
+	"coscup2025/notification"
+	"coscup2025/policy"
+	"coscup2025/s3store"
+	"coscup2025/stall"
+	"coscup2025/storage"
+	"coscup2025/validation"
 
+	pbAccount "coscup2025/proto/account"
+	pbAdmin "coscup2025/proto/admin"
 	pbAuth "coscup2025/proto/auth"
+	pbComment "coscup2025/proto/comment"
 	pbMedia "coscup2025/proto/media"
+	pbMediaV2 "coscup2025/proto/media/v2"
+	pbModeration "coscup2025/proto/moderation"
+	pbNotification "coscup2025/proto/notification"
 )
 
-func initTracer() func() {
+// newTraceExporter creates the exporter selected by cfg.TraceExporter. It
+// returns nil for "none".
+func newTraceExporter(cfg *env.Config) (trace.SpanExporter, error) {
+	switch cfg.TraceExporter {
+	case "", "otlp", "jaeger":
+		// Jaeger ingests OTLP natively; its dedicated exporter is deprecated.
+		endpoint := cfg.TraceEndpoint
+		if endpoint == "" {
+			endpoint = "localhost:4317"
+		}
+		return otlptracegrpc.New(context.Background(),
+			otlptracegrpc.WithEndpoint(endpoint),
+			otlptracegrpc.WithInsecure(),
+		)
+	case "zipkin":
+		endpoint := cfg.TraceEndpoint
+		if endpoint == "" {
+			endpoint = "http://localhost:9411/api/v2/spans"
+		}
+		return zipkin.New(endpoint)
+	case "stdout":
+		return stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case "none":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q, expected otlp, jaeger, zipkin, stdout or none", cfg.TraceExporter)
+	}
+}
+
+func initTracer(cfg *env.Config) func() {
 	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
 		propagation.TraceContext{},
 		propagation.Baggage{},
 	))
 
-	exporter, err := otlptracegrpc.New(context.Background(),
-		otlptracegrpc.WithEndpoint("localhost:4317"),
-		otlptracegrpc.WithInsecure(),
-	)
+	exporter, err := newTraceExporter(cfg)
 	if err != nil {
-		log.Printf("Failed to create OTLP exporter: %v", err)
+		log.Printf("Failed to create %s exporter: %v", cfg.TraceExporter, err)
 		return func() {}
 	}
 
@@ -52,10 +119,16 @@ func initTracer() func() {
 		return func() {}
 	}
 
-	tp := trace.NewTracerProvider(
-		trace.WithBatcher(exporter),
+	// Without an exporter spans are still created, so trace IDs reach logs,
+	// exemplars and downstream services.
+	opts := []trace.TracerProviderOption{
+		trace.WithSpanProcessor(identitySpanProcessor{}),
 		trace.WithResource(res),
-	)
+	}
+	if exporter != nil {
+		opts = append(opts, trace.WithBatcher(exporter))
+	}
+	tp := trace.NewTracerProvider(opts...)
 	otel.SetTracerProvider(tp)
 
 	return func() {
@@ -65,8 +138,107 @@ func initTracer() func() {
 	}
 }
 
+// identitySpanProcessor labels every span started within an authenticated
+// request with the caller identity the auth interceptor put in the baggage.
+type identitySpanProcessor struct{}
+
+func (identitySpanProcessor) OnStart(ctx context.Context, s trace.ReadWriteSpan) {
+	s.SetAttributes(auth.IdentityAttributes(ctx)...)
+}
+
+func (identitySpanProcessor) OnEnd(trace.ReadOnlySpan)         {}
+func (identitySpanProcessor) Shutdown(context.Context) error   { return nil }
+func (identitySpanProcessor) ForceFlush(context.Context) error { return nil }
+
+// adminServices are the gRPC services only reachable from the admin
+// networks. They must not be exposed through the gateway, whose calls
+// arrive from localhost.
+var adminServices = []string{"/admin."}
+
+// serveAdmin serves metrics and pprof on their own port, restricted to the
+// admin networks.
+func serveAdmin(addr string, list *acl.List) {
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", metrics.Handler())
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	log.Printf("Admin endpoints listening at %s", addr)
+	if err := http.ListenAndServe(addr, list.Handler(mux)); err != nil {
+		log.Fatalf("failed to serve admin endpoints: %v", err)
+	}
+}
+
+// handleGatewayMetrics serves /metrics on the gateway when there is no admin
+// port, restricted to the admin networks all the same.
+func handleGatewayMetrics(mux *runtime.ServeMux, list *acl.List) error {
+	metricsHandler := list.Handler(metrics.Handler())
+	return mux.HandlePath("GET", "/metrics", func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		metricsHandler.ServeHTTP(w, r)
+	})
+}
+
+// newCDNSigner returns the URL signer of the configured CDN.
+func newCDNSigner(cfg *env.Config) (media.URLSigner, error) {
+	if cfg.CDNBaseURL == "" {
+		return nil, fmt.Errorf("COSCUP_CDN_BASE_URL is required")
+	}
+	switch cfg.CDNProvider {
+	case "cloudfront":
+		key, err := os.ReadFile(cfg.CDNPrivateKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		return cdn.NewCloudFront(cfg.CDNBaseURL, cfg.CDNKeyPairID, key)
+	case "fastly":
+		return cdn.NewFastly(cfg.CDNBaseURL, []byte(cfg.CDNSigningKey))
+	default:
+		return nil, fmt.Errorf("unknown CDN provider %q", cfg.CDNProvider)
+	}
+}
+
+// backendDialOptions returns how the gateway dials the gRPC backend.
+func backendDialOptions(cfg *env.Config) ([]grpc.DialOption, error) {
+	creds := insecure.NewCredentials()
+	if cfg.GatewayBackendCAFile != "" {
+		var err error
+		creds, err = credentials.NewClientTLSFromFile(cfg.GatewayBackendCAFile, "")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		// Carry the HTTP request's trace into the gRPC metadata
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		// Wait out a backend that is starting or reconnecting, within the
+		// request's deadline, instead of failing at once.
+		grpc.WithDefaultCallOptions(grpc.WaitForReady(true)),
+	}
+	if cfg.GatewayKeepaliveTime > 0 {
+		opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                cfg.GatewayKeepaliveTime,
+			Timeout:             cfg.GatewayKeepaliveTimeout,
+			PermitWithoutStream: true,
+		}))
+	}
+	return opts, nil
+}
+
 func main() {
-	cleanup := initTracer()
+	dev := flag.Bool("dev", false, "run in memory with demo users and videos, reflection and permissive CORS")
+	flag.Parse()
+
+	cfg := env.FromEnv()
+	if *dev {
+		devConfig(cfg)
+	}
+
+	cleanup := initTracer(cfg)
 	defer cleanup()
 
 	lis, err := net.Listen("tcp", ":50051")
@@ -74,14 +246,244 @@ func main() {
 		log.Fatalf("failed to listen: %v", err)
 	}
 
-	authSrv := auth.NewAuthServer()
-	mediaSrv := media.NewMediaServer()
-	server := grpc.NewServer(
-		grpc.UnaryInterceptor(authSrv.UnaryInterceptor),
-		grpc.StreamInterceptor(authSrv.StreamInterceptor),
-	)
+	if cfg.JWTSecretFile != "" {
+		secret, err := auth.ReadSecretFile(cfg.JWTSecretFile)
+		if err != nil {
+			log.Fatalf("failed to read JWT secret: %v", err)
+		}
+		cfg.JWTSecret = secret
+	}
+	// Rotating the secret file rotates every key derived from it.
+	keys := keyset.New([]byte(cfg.JWTSecret))
+	authSrv := auth.NewAuthServer(cfg, keys)
+	mediaSrv := media.NewMediaServer(cfg, keys)
+	metrics.Registry.MustRegister(mediaSrv.StorageCollector())
+	notificationSrv := notification.NewNotificationServer(cfg, keys)
+	mediaSrv.SetNotifier(notificationSrv)
+	alerter := notification.NewAlerter(notificationSrv, authSrv)
+	if len(cfg.AlertEmails) > 0 {
+		m, err := mailer.New(cfg)
+		if err != nil {
+			log.Fatalf("failed to start mailer: %v", err)
+		}
+		defer m.Close()
+		alerter.MailTo(m, cfg.AlertEmails)
+	}
+	mediaSrv.SetAlerter(alerter)
+	healthSrv := health.NewServer()
+	mediaSrv.SetHealth(healthSrv)
+	mediaSrv.SetTokenSigner(authSrv)
+	if cfg.UploadSessionDir != "" {
+		restored, err := mediaSrv.PersistUploadSessions(cfg.UploadSessionDir)
+		if err != nil {
+			log.Fatalf("failed to open upload session directory: %v", err)
+		}
+		log.Printf("Restored %d upload sessions from %s", restored, cfg.UploadSessionDir)
+	}
+	if cfg.S3Bucket != "" {
+		st, err := s3store.Open(cfg, "videos/")
+		if err != nil {
+			log.Fatalf("failed to open S3 storage: %v", err)
+		}
+		loaded, err := mediaSrv.OffloadVideos(st)
+		if err != nil {
+			log.Fatalf("failed to load stored videos: %v", err)
+		}
+		log.Printf("Loaded %d videos from s3://%s/%s", loaded, cfg.S3Bucket, cfg.S3Prefix)
+		avatarStore, err := s3store.Open(cfg, "avatars/")
+		if err != nil {
+			log.Fatalf("failed to open S3 avatar storage: %v", err)
+		}
+		if _, err := mediaSrv.PersistAvatars(avatarStore); err != nil {
+			log.Fatalf("failed to load stored avatars: %v", err)
+		}
+	} else if cfg.StorageDir != "" {
+		st, err := storage.Open(cfg.StorageDir)
+		if err != nil {
+			log.Fatalf("failed to open storage: %v", err)
+		}
+		loaded, err := mediaSrv.PersistVideos(st)
+		if err != nil {
+			log.Fatalf("failed to load stored videos: %v", err)
+		}
+		log.Printf("Loaded %d videos from %s", loaded, cfg.StorageDir)
+		// A store of its own, so that avatars keyed by user ID never meet
+		// videos of the same ID
+		avatarStore, err := storage.Open(filepath.Join(cfg.StorageDir, "avatars"))
+		if err != nil {
+			log.Fatalf("failed to open avatar storage: %v", err)
+		}
+		if _, err := mediaSrv.PersistAvatars(avatarStore); err != nil {
+			log.Fatalf("failed to load stored avatars: %v", err)
+		}
+		if cfg.ScrubInterval > 0 {
+			go mediaSrv.ScrubPeriodically(context.Background(), cfg.ScrubInterval)
+		}
+	}
+	if cfg.CDNProvider != "" {
+		if cfg.StorageDir == "" && cfg.S3Bucket == "" {
+			log.Fatalf("COSCUP_CDN_PROVIDER requires COSCUP_STORAGE_DIR or COSCUP_S3_BUCKET")
+		}
+		signer, err := newCDNSigner(cfg)
+		if err != nil {
+			log.Fatalf("invalid CDN configuration: %v", err)
+		}
+		mediaSrv.SetCDN(signer)
+	}
+	commentSrv := comment.NewCommentServer(cfg, keys, mediaSrv)
+	moderationSrv := moderation.NewModerationServer(cfg, mediaSrv)
+	moderationSrv.SetNotifier(notificationSrv)
+	jobRunner := jobs.NewRunner(cfg.JobWorkers, cfg.JobResultTTL)
+	defer jobRunner.Close()
+	mediaSrv.SetJobRunner(jobRunner)
+	accountSrv := account.NewAccountServer(cfg, authSrv, mediaSrv, jobRunner)
+	accountSrv.AddEraser(commentSrv)
+	accountSrv.AddEraser(moderationSrv)
+	accountSrv.AddEraser(notificationSrv)
+	if cfg.DeletionReceiptKeyFile != "" {
+		key, err := account.ReadReceiptKey(cfg.DeletionReceiptKeyFile)
+		if err != nil {
+			log.Fatalf("failed to read deletion receipt key: %v", err)
+		}
+		accountSrv.SetReceiptKey(key)
+	} else {
+		log.Printf("COSCUP_DELETION_RECEIPT_KEY_FILE is not set, deletion receipts are not signed")
+	}
+	mediaSrv.SetConsents(accountSrv)
+	slow := metrics.SlowRequests{Latency: cfg.SlowRequestLatency, Throughput: cfg.SlowStreamThroughput}
+	adminACL, err := acl.Parse(cfg.AdminAllow, cfg.AdminDeny)
+	if err != nil {
+		log.Fatalf("invalid admin network list: %v", err)
+	}
+	admin := acl.Methods{List: adminACL, Prefixes: adminServices}
+	// Metrics come first so rejected calls are measured too
+	unary := []grpc.UnaryServerInterceptor{metrics.UnaryServerInterceptor, slow.UnaryServerInterceptor, admin.UnaryServerInterceptor}
+	stream := []grpc.StreamServerInterceptor{metrics.StreamServerInterceptor, slow.StreamServerInterceptor, admin.StreamServerInterceptor}
+	// Like the admin networks, before auth: refused services never get to
+	// present a token.
+	if identities := mesh.New(cfg); identities.Enabled() {
+		log.Printf("Trusting mesh peer identities, restricting %v to %v", identities.Methods, identities.Allowed)
+		unary = append(unary, identities.UnaryServerInterceptor)
+		stream = append(stream, identities.StreamServerInterceptor)
+	}
+	unary = append(unary, authSrv.UnaryInterceptor)
+	stream = append(stream, authSrv.StreamInterceptor)
+
+	var secretRotated func()
+	if cfg.AuditLogFile != "" {
+		key := cfg.AuditHMACKey
+		if key == "" {
+			log.Printf("COSCUP_AUDIT_HMAC_KEY is not set, keying the audit log with the JWT secret")
+			key = cfg.JWTSecret
+		}
+		sink, err := audit.NewFileSink(cfg.AuditLogFile, []byte(key), cfg.AuditLogMaxSize, cfg.AuditLogMaxAge)
+		if err != nil {
+			log.Fatalf("failed to open audit log: %v", err)
+		}
+		defer sink.Close()
+		accountSrv.SetAuditLog(sink)
+
+		// After auth, so events carry the caller
+		auditor := audit.NewAuditor(sink)
+		unary = append(unary, auditor.UnaryServerInterceptor)
+		stream = append(stream, auditor.StreamServerInterceptor)
+		secretRotated = auditor.SecretRotated
+	}
+
+	// Once erasures reach the audit log, as those that fell due while the
+	// server was down run right away.
+	if cfg.DeletionDir != "" {
+		restored, err := accountSrv.PersistDeletions(cfg.DeletionDir)
+		if err != nil {
+			log.Fatalf("failed to open deletion directory: %v", err)
+		}
+		log.Printf("Restored %d scheduled deletions from %s", restored, cfg.DeletionDir)
+	}
+
+	if cfg.JWTSecretFile != "" {
+		if err := authSrv.WatchSecretFile(context.Background(), cfg.JWTSecretFile, cfg.JWTSecretGrace, secretRotated); err != nil {
+			log.Fatalf("failed to watch JWT secret file: %v", err)
+		}
+	}
+
+	// In place of the handlers' own checks, so rejected requests are
+	// audited like other failed calls.
+	unary = append(unary, validation.UnaryServerInterceptor, deprecation.UnaryServerInterceptor)
+	stream = append(stream, validation.StreamServerInterceptor, deprecation.StreamServerInterceptor)
+
+	// After validation, so policies only see well-formed requests.
+	policies, err := policy.Load(cfg)
+	if err != nil {
+		log.Fatalf("invalid authorization policies: %v", err)
+	}
+	if policies.Enabled() {
+		log.Printf("Loaded %d authorization policies from %s", policies.Len(), cfg.PolicyFile)
+		policies.SetVideos(mediaSrv)
+		unary = append(unary, policies.UnaryServerInterceptor)
+		stream = append(stream, policies.StreamServerInterceptor)
+	}
+
+	// Stalled streams fail inside the audit and metrics, which record them
+	// like other failed streams.
+	if watchdog := stall.New(cfg); watchdog.Enabled() {
+		stream = append(stream, watchdog.StreamServerInterceptor)
+	}
+
+	// Innermost, so injected failures are measured, audited and seen by
+	// clients like those of the handlers.
+	if faults := chaos.New(cfg); faults.Enabled() {
+		log.Printf("Chaos faults enabled: latency up to %s, unavailable rate %g, abort rate %g", faults.Latency, faults.UnavailableRate, faults.AbortRate)
+		unary = append(unary, faults.UnaryServerInterceptor)
+		stream = append(stream, faults.StreamServerInterceptor)
+	}
+
+	serverOpts := []grpc.ServerOption{
+		// One server span per RPC, parent of the interceptor and handler spans
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	}
+	// Flow control is what holds back a client that uploads faster than the
+	// server stores; zero leaves gRPC's defaults.
+	if cfg.GRPCWindowSize > 0 {
+		serverOpts = append(serverOpts, grpc.InitialWindowSize(cfg.GRPCWindowSize))
+	}
+	if cfg.GRPCConnWindowSize > 0 {
+		serverOpts = append(serverOpts, grpc.InitialConnWindowSize(cfg.GRPCConnWindowSize))
+	}
+	if cfg.GRPCMaxRecvMsgSize > 0 {
+		serverOpts = append(serverOpts, grpc.MaxRecvMsgSize(cfg.GRPCMaxRecvMsgSize))
+	}
+	// Without this, gRPC closes connections that ping more often than every
+	// five minutes, the gateway's included.
+	if cfg.GatewayKeepaliveTime > 0 {
+		serverOpts = append(serverOpts, grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             min(cfg.GatewayKeepaliveTime, 5*time.Minute),
+			PermitWithoutStream: true,
+		}))
+	}
+	server := grpc.NewServer(serverOpts...)
 	pbAuth.RegisterAuthServiceServer(server, authSrv)
 	pbMedia.RegisterMediaServiceServer(server, mediaSrv)
+	pbMediaV2.RegisterMediaServiceServer(server, mediaSrv.V2())
+	pbNotification.RegisterNotificationServiceServer(server, notificationSrv)
+	pbComment.RegisterCommentServiceServer(server, commentSrv)
+	pbModeration.RegisterModerationServiceServer(server, moderationSrv)
+	adminSrv := moderation.NewAdminServer(cfg, keys, moderationSrv)
+	if cfg.StorageDir != "" && cfg.S3Bucket == "" {
+		adminSrv.SetScrubber(mediaSrv)
+	}
+	adminSrv.SetRetainer(mediaSrv)
+	adminSrv.SetAccounts(authSrv)
+	if cfg.RetentionInterval > 0 {
+		go mediaSrv.EnforceRetentionPeriodically(context.Background(), cfg.RetentionInterval)
+	}
+	pbAdmin.RegisterAdminServiceServer(server, adminSrv)
+	pbAccount.RegisterAccountServiceServer(server, accountSrv)
+	healthpb.RegisterHealthServer(server, healthSrv)
+	if *dev {
+		reflection.Register(server)
+	}
 
 	go func() {
 		log.Printf("gRPC server listening at %v", lis.Addr())
@@ -89,42 +491,83 @@ func main() {
 			log.Fatalf("failed to serve: %v", err)
 		}
 	}()
-
-	ctx := context.Background()
-	mux := runtime.NewServeMux(
-		runtime.WithIncomingHeaderMatcher(func(key string) (string, bool) {
-			switch strings.ToLower(key) {
-			case "authorization":
-				return "authorization", true
-			default:
-				return runtime.DefaultHeaderMatcher(key)
-			}
-		}),
-		runtime.WithForwardResponseOption(func(ctx context.Context, w http.ResponseWriter, _ proto.Message) error {
-			md, ok := runtime.ServerMetadataFromContext(ctx)
-			if ok {
-				if tokens := md.HeaderMD.Get("x-auth-token"); len(tokens) > 0 {
-					w.Header().Set("X-Auth-Token", tokens[0])
-				}
+	if *dev {
+		go func() {
+			if err := seedDev(cfg.GatewayBackendAddr); err != nil {
+				log.Printf("Failed to seed dev data: %v", err)
 			}
-			return nil
-		}),
-	)
+		}()
+	}
 
-	dialOpts := []grpc.DialOption{
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	dialOpts, err := backendDialOptions(cfg)
+	if err != nil {
+		log.Fatalf("invalid gateway backend options: %v", err)
 	}
-	err = pbAuth.RegisterAuthServiceHandlerFromEndpoint(ctx, mux, "localhost:50051", dialOpts)
+	conn, err := grpc.NewClient(cfg.GatewayBackendAddr, dialOpts...)
 	if err != nil {
-		log.Fatalf("failed to register gateway: %v", err)
+		log.Fatalf("failed to dial gRPC server: %v", err)
+	}
+	defer conn.Close()
+
+	var player *gateway.PlayerScript
+	if cfg.HLSPlayerFile != "" {
+		player, err = gateway.ReadPlayerScript(cfg.HLSPlayerFile)
+		if err != nil {
+			log.Fatalf("failed to read HLS player: %v", err)
+		}
 	}
-	err = pbMedia.RegisterMediaServiceHandlerFromEndpoint(ctx, mux, "localhost:50051", dialOpts)
+	mux, err := gateway.NewMux(context.Background(), conn, player)
 	if err != nil {
-		log.Fatalf("failed to register gateway: %v", err)
+		log.Fatalf("failed to set up gateway: %v", err)
+	}
+	if cfg.AdminAddr != "" {
+		go serveAdmin(cfg.AdminAddr, adminACL)
+	} else if err := handleGatewayMetrics(mux, adminACL); err != nil {
+		log.Fatalf("failed to register metrics handler: %v", err)
+	}
+
+	// Continue the browser's traceparent, or start the trace here, so one trace
+	// covers HTTP, the gateway and the gRPC handler.
+	traced := otelhttp.NewHandler(mux, "gateway",
+		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			// Paths embed video IDs; keep span names low-cardinality
+			return "gateway " + r.Method
+		}),
+	)
+
+	var handler http.Handler = traced
+	if *dev {
+		handler = allowAllOrigins(handler)
 	}
+	if cfg.HTTP3Addr != "" {
+		if cfg.TLSCertFile == "" || cfg.TLSKeyFile == "" {
+			log.Fatalf("HTTP/3 gateway requires COSCUP_TLS_CERT_FILE and COSCUP_TLS_KEY_FILE")
+		}
+
+		base := handler
+		h3 := &http3.Server{Addr: cfg.HTTP3Addr, Handler: base}
+		go func() {
+			log.Printf("gRPC-Gateway (HTTP/3) listening at %s", cfg.HTTP3Addr)
+			if err := h3.ListenAndServeTLS(cfg.TLSCertFile, cfg.TLSKeyFile); err != nil {
+				log.Fatalf("failed to serve HTTP/3 gateway: %v", err)
+			}
+		}()
 
-	log.Printf("gRPC-Gateway listening at :8080")
-	if err := http.ListenAndServe(":8080", mux); err != nil {
+		// Advertise the QUIC endpoint so clients can switch over via Alt-Svc.
+		handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			h3.SetQUICHeaders(w.Header())
+			base.ServeHTTP(w, r)
+		})
+	}
+
+	if cfg.TLSCertFile != "" && cfg.TLSKeyFile != "" {
+		log.Printf("gRPC-Gateway listening at :8080 (TLS)")
+		err = http.ListenAndServeTLS(":8080", cfg.TLSCertFile, cfg.TLSKeyFile, handler)
+	} else {
+		log.Printf("gRPC-Gateway listening at :8080")
+		err = http.ListenAndServe(":8080", handler)
+	}
+	if err != nil {
 		log.Fatalf("failed to serve gateway: %v", err)
 	}
 }
@@ -1,94 +1,41 @@
 package main
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
+	"hash/crc32"
 	"io"
-	"net"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/golang-jwt/jwt"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
-	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/protojson"
-	"google.golang.org/protobuf/proto"
 
 	pbAuth "coscup2025/proto/auth"
+	pbMedia "coscup2025/proto/media"
+	pbMediaV2 "coscup2025/proto/media/v2"
 
-	"coscup2025/auth"
+	"coscup2025/acl"
+	"coscup2025/env"
+	"coscup2025/otlptest"
+	"coscup2025/servertest"
 )
 
-func setupTestServer(t *testing.T) (*grpc.Server, *runtime.ServeMux, *bufconn.Listener) {
-	lis := bufconn.Listen(1024 * 1024)
-
-	authSrv := auth.NewAuthServer()
-	server := grpc.NewServer(
-		grpc.UnaryInterceptor(authSrv.UnaryInterceptor),
-	)
-	pbAuth.RegisterAuthServiceServer(server, authSrv)
-
-	go func() {
-		if err := server.Serve(lis); err != nil {
-			t.Errorf("failed to serve: %v", err)
-		}
-	}()
-
-	mux := runtime.NewServeMux(
-		runtime.WithIncomingHeaderMatcher(func(key string) (string, bool) {
-			switch key {
-			case "Authorization":
-				return "authorization", true
-			default:
-				return runtime.DefaultHeaderMatcher(key)
-			}
-		}),
-		runtime.WithForwardResponseOption(func(ctx context.Context, w http.ResponseWriter, _ proto.Message) error {
-			md, ok := runtime.ServerMetadataFromContext(ctx)
-			if ok {
-				if tokens := md.HeaderMD.Get("x-auth-token"); len(tokens) > 0 {
-					w.Header().Set("X-Auth-Token", tokens[0])
-				}
-			}
-			return nil
-		}),
-	)
-
-	ctx := context.Background()
-	dialOpts := []grpc.DialOption{
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
-			return lis.Dial()
-		}),
-	}
-	err := pbAuth.RegisterAuthServiceHandlerFromEndpoint(ctx, mux, "localhost:50051", dialOpts)
-	if err != nil {
-		t.Fatalf("failed to register gateway: %v", err)
-	}
-
-	return server, mux, lis
-}
-
 func TestGetUserProfileHeaderAuthentication(t *testing.T) {
-	server, mux, lis := setupTestServer(t)
-	defer server.Stop()
-	defer lis.Close()
+	srv := servertest.New(t, nil)
 
 	makeRequest := func(t *testing.T, token string) (*http.Response, *pbAuth.GetUserProfileResponse) {
-		req, err := http.NewRequest("GET", "/v1/profile", nil)
-		require.NoError(t, err, "Failed to create request")
-		if token != "" {
-			req.Header.Set("Authorization", "Bearer "+token)
-		}
-		rr := httptest.NewRecorder()
-		mux.ServeHTTP(rr, req)
-		resp := rr.Result()
+		resp := srv.Do(t, "GET", "/v1/profile", token, nil)
 
 		var profile pbAuth.GetUserProfileResponse
 		if resp.StatusCode == http.StatusOK {
@@ -105,26 +52,18 @@ func TestGetUserProfileHeaderAuthentication(t *testing.T) {
 	signUpReq := &pbAuth.SignUpRequest{Username: "testuser", Password: "testpass"}
 	signUpJSON, err := json.Marshal(signUpReq)
 	require.NoError(t, err, "Failed to marshal SignUp request")
-	req, err := http.NewRequest("POST", "/v1/signup", bytes.NewBuffer(signUpJSON))
-	require.NoError(t, err, "Failed to create SignUp request")
-	req.Header.Set("Content-Type", "application/json")
-	rr := httptest.NewRecorder()
-	mux.ServeHTTP(rr, req)
-	require.Equal(t, http.StatusOK, rr.Code, "SignUp failed")
+	resp := srv.Do(t, "POST", "/v1/signup", "", signUpJSON)
+	require.Equal(t, http.StatusOK, resp.StatusCode, "SignUp failed")
 
 	// Step 2: Sign in to get a JWT token
 	signInReq := &pbAuth.SignInRequest{Username: "testuser", Password: "testpass"}
 	signInJSON, err := json.Marshal(signInReq)
 	require.NoError(t, err, "Failed to marshal SignIn request")
-	req, err = http.NewRequest("POST", "/v1/signin", bytes.NewBuffer(signInJSON))
-	require.NoError(t, err, "Failed to create SignIn request")
-	req.Header.Set("Content-Type", "application/json")
-	rr = httptest.NewRecorder()
-	mux.ServeHTTP(rr, req)
-	require.Equal(t, http.StatusOK, rr.Code, "SignIn failed")
+	resp = srv.Do(t, "POST", "/v1/signin", "", signInJSON)
+	require.Equal(t, http.StatusOK, resp.StatusCode, "SignIn failed")
 
 	var signInResp pbAuth.SignInResponse
-	err = json.NewDecoder(rr.Body).Decode(&signInResp)
+	err = json.NewDecoder(resp.Body).Decode(&signInResp)
 	require.NoError(t, err, "Failed to decode SignIn response")
 	token := signInResp.Token
 	require.NotEmpty(t, token, "No token returned from SignIn")
@@ -146,3 +85,368 @@ func TestGetUserProfileHeaderAuthentication(t *testing.T) {
 	resp, _ = makeRequest(t, "")
 	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, "Expected status 401 for missing token")
 }
+
+func TestGatewayServesUploadedVideo(t *testing.T) {
+	srv := servertest.New(t, nil)
+	token := srv.CreateUser(t, "speaker", "secret")
+
+	stream, err := srv.Media().UploadVideo(servertest.Context(token))
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&pbMedia.UploadVideoRequest{VideoId: "keynote", Data: []byte("keynote"), Sequence: 1}))
+	_, err = stream.CloseAndRecv()
+	require.NoError(t, err)
+
+	resp := srv.Do(t, "GET", "/v1/video/file/keynote", token, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "keynote", string(body))
+
+	resp = srv.Do(t, "GET", "/v1/video/file/keynote?priority=batch", token, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp = srv.Do(t, "GET", "/v1/video/file/keynote?priority=urgent", token, nil)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = srv.Do(t, "GET", "/v1/video/file/keynote", "", nil)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestGatewayFileHead(t *testing.T) {
+	srv := servertest.New(t, nil)
+	token := srv.CreateUser(t, "speaker", "secret")
+
+	stream, err := srv.Media().UploadVideo(servertest.Context(token))
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&pbMedia.UploadVideoRequest{VideoId: "keynote", Data: []byte("keynote"), FileName: "Keynote 2025.mp4"}))
+	uploaded, err := stream.CloseAndRecv()
+	require.NoError(t, err)
+
+	for _, method := range []string{"HEAD", "GET"} {
+		resp := srv.Do(t, method, "/v1/video/file/keynote", token, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode, method)
+		assert.Equal(t, "7", resp.Header.Get("Content-Length"), method)
+		assert.Equal(t, "video/mp4", resp.Header.Get("Content-Type"), method)
+		assert.Equal(t, `"`+uploaded.Metadata.Sha256+`"`, resp.Header.Get("ETag"), method)
+		assert.Equal(t, `attachment; filename="Keynote 2025.mp4"`, resp.Header.Get("Content-Disposition"), method)
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		if method == "HEAD" {
+			assert.Empty(t, body)
+		} else {
+			assert.Equal(t, "keynote", string(body))
+		}
+	}
+}
+
+func TestGatewayDownloadsVideoExport(t *testing.T) {
+	srv := servertest.New(t, nil)
+	token := srv.CreateUser(t, "speaker", "secret")
+	ctx := servertest.Context(token)
+
+	stream, err := srv.Media().UploadVideo(ctx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&pbMedia.UploadVideoRequest{VideoId: "keynote", Data: []byte("keynote")}))
+	_, err = stream.CloseAndRecv()
+	require.NoError(t, err)
+
+	resp := srv.Do(t, "POST", "/v1/exports", token, []byte(`{"video_ids": ["keynote"], "format": "EXPORT_FORMAT_MANIFEST"}`))
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var created pbMedia.CreateExportResponse
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, protojson.Unmarshal(body, &created))
+
+	require.Eventually(t, func() bool {
+		got, err := srv.Media().GetExport(ctx, &pbMedia.GetExportRequest{ExportId: created.Export.ExportId})
+		require.NoError(t, err)
+		return got.Export.State == pbMedia.ExportState_EXPORT_STATE_READY
+	}, 5*time.Second, 10*time.Millisecond)
+
+	resp = srv.Do(t, "GET", "/v1/exports/"+created.Export.ExportId+"/download", token, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	var manifest pbMedia.ExportManifest
+	body, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, protojson.Unmarshal(body, &manifest))
+	require.Len(t, manifest.Videos, 1)
+	assert.NotEmpty(t, manifest.Videos[0].Url)
+
+	// Exports are their creator's only
+	other := srv.CreateUser(t, "attendee", "secret")
+	resp = srv.Do(t, "GET", "/v1/exports/"+created.Export.ExportId+"/download", other, nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestGatewayLocalizesMetadata(t *testing.T) {
+	srv := servertest.New(t, nil)
+	token := srv.CreateUser(t, "speaker", "secret")
+
+	stream, err := srv.Media().UploadVideo(servertest.Context(token))
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&pbMedia.UploadVideoRequest{
+		VideoId:  "keynote",
+		Data:     []byte("keynote"),
+		Sequence: 1,
+		Titles:   map[string]string{"zh-TW": "主題演講", "en": "Keynote"},
+	}))
+	_, err = stream.CloseAndRecv()
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("GET", "/v1/videos/keynote/metadata", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept-Language", "zh-TW,zh;q=0.9,en;q=0.8")
+	rr := httptest.NewRecorder()
+	srv.Gateway.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "zh-TW", rr.Header().Get("Content-Language"))
+	assert.Equal(t, "Accept-Language", rr.Header().Get("Vary"))
+
+	var resp pbMedia.GetVideoMetadataResponse
+	require.NoError(t, protojson.Unmarshal(rr.Body.Bytes(), &resp))
+	assert.Equal(t, "主題演講", resp.Metadata.Title)
+
+	// The locale parameter wins over the header.
+	req = httptest.NewRequest("GET", "/v1/videos/keynote/metadata?locale=en", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept-Language", "zh-TW")
+	rr = httptest.NewRecorder()
+	srv.Gateway.ServeHTTP(rr, req)
+	require.NoError(t, protojson.Unmarshal(rr.Body.Bytes(), &resp))
+	assert.Equal(t, "Keynote", resp.Metadata.Title)
+}
+
+func TestMediaV2(t *testing.T) {
+	srv := servertest.New(t, nil)
+	ctx := servertest.Context(srv.CreateUser(t, "speaker", "secret"))
+	client := srv.MediaV2()
+
+	// Step 1: a two-phase upload, whose chunks only name the session
+	created, err := client.CreateUploadSession(ctx, &pbMedia.CreateUploadSessionRequest{VideoId: "keynote", TotalSize: 7})
+	require.NoError(t, err)
+	upload, err := client.UploadVideo(ctx)
+	require.NoError(t, err)
+	require.NoError(t, upload.Send(&pbMediaV2.UploadVideoRequest{UploadId: created.Session.UploadId, Data: []byte("key")}))
+	require.NoError(t, upload.Send(&pbMediaV2.UploadVideoRequest{UploadId: created.Session.UploadId, Offset: 3, Data: []byte("note")}))
+	uploaded, err := upload.CloseAndRecv()
+	require.NoError(t, err)
+	require.Equal(t, "keynote", uploaded.VideoId)
+	require.Equal(t, int64(7), uploaded.TotalBytes)
+
+	// Step 2: a download is framed by a header and a trailer
+	stream, err := client.DownloadVideo(ctx, &pbMedia.DownloadVideoRequest{VideoId: "keynote", Offset: 3})
+	require.NoError(t, err)
+	var frames []*pbMediaV2.DownloadVideoResponse
+	for {
+		frame, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		frames = append(frames, frame)
+	}
+	require.Len(t, frames, 3)
+	assert.Equal(t, int64(7), frames[0].GetHeader().GetMetadata().GetFileSize())
+	assert.Equal(t, int64(3), frames[0].GetHeader().GetOffset())
+	assert.Equal(t, []byte("note"), frames[1].GetChunk().GetData())
+	assert.Equal(t, int64(4), frames[2].GetTrailer().GetBytesSent())
+	assert.Equal(t, int64(4), frames[2].GetTrailer().GetStats().GetBytes())
+	header, err := stream.Header()
+	require.NoError(t, err)
+	assert.Empty(t, header.Get("deprecation"))
+
+	// Step 3: the first version points to its successor
+	v1, err := srv.Media().DownloadVideo(ctx, &pbMedia.DownloadVideoRequest{VideoId: "keynote"})
+	require.NoError(t, err)
+	header, err = v1.Header()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"true"}, header.Get("deprecation"))
+	assert.Equal(t, []string{`</media.v2.MediaService/DownloadVideo>; rel="successor-version"`}, header.Get("link"))
+
+	var unary metadata.MD
+	_, err = srv.Media().GetVideoMetadata(ctx, &pbMedia.GetVideoMetadataRequest{VideoId: "keynote"}, grpc.Header(&unary))
+	require.NoError(t, err)
+	assert.Empty(t, unary.Get("deprecation"))
+}
+
+func TestUploadChunks(t *testing.T) {
+	srv := servertest.New(t, nil)
+	ctx := servertest.Context(srv.CreateUser(t, "speaker", "secret"))
+	client := srv.MediaV2()
+	created, err := client.CreateUploadSession(ctx, &pbMedia.CreateUploadSessionRequest{VideoId: "keynote", TotalSize: 12})
+	require.NoError(t, err)
+	uploadID := created.Session.UploadId
+	chunk := func(offset int64, data string) *pbMediaV2.UploadChunksRequest {
+		return &pbMediaV2.UploadChunksRequest{UploadId: uploadID, Offset: offset, Data: []byte(data),
+			Crc32C: crc32.Checksum([]byte(data), crc32.MakeTable(crc32.Castagnoli))}
+	}
+
+	// Step 1: a chunk that skips ahead and a corrupted one are asked for
+	// again, while the chunks in flight after them are dropped
+	stream, err := client.UploadChunks(ctx)
+	require.NoError(t, err)
+	corrupted := chunk(4, "hall")
+	corrupted.Data = []byte("hell")
+	for _, c := range []*pbMediaV2.UploadChunksRequest{chunk(0, "key "), chunk(8, "main"), corrupted, chunk(8, "main")} {
+		require.NoError(t, stream.Send(c))
+	}
+
+	// Step 2: the chunks sent again from the requested offset complete the
+	// upload
+	require.NoError(t, stream.Send(chunk(4, "hall")))
+	require.NoError(t, stream.Send(chunk(8, "main")))
+	var frames []*pbMediaV2.UploadChunksResponse
+	for len(frames) < 5 {
+		frame, err := stream.Recv()
+		require.NoError(t, err)
+		frames = append(frames, frame)
+	}
+	assert.Equal(t, int64(4), frames[0].GetAck().GetCommittedBytes())
+	assert.Equal(t, int64(4), frames[1].GetNack().GetOffset())
+	assert.Equal(t, pbMediaV2.ChunkNackReason_CHUNK_NACK_REASON_MISSING, frames[1].GetNack().GetReason())
+	assert.Equal(t, int64(4), frames[2].GetNack().GetOffset())
+	assert.Equal(t, pbMediaV2.ChunkNackReason_CHUNK_NACK_REASON_CHECKSUM_MISMATCH, frames[2].GetNack().GetReason())
+	assert.Equal(t, int64(8), frames[3].GetAck().GetCommittedBytes())
+	assert.Equal(t, int64(12), frames[4].GetAck().GetCommittedBytes())
+
+	require.NoError(t, stream.CloseSend())
+	frame, err := stream.Recv()
+	require.NoError(t, err)
+	result := frame.GetResult()
+	require.NotNil(t, result)
+	assert.Equal(t, int64(12), result.TotalBytes)
+	assert.Equal(t, int64(12), result.Stats.GetRetransmittedBytes(), "three dropped chunks")
+	_, err = stream.Recv()
+	assert.Equal(t, io.EOF, err)
+
+	// Step 3: a chunk that never arrives intact fails the stream
+	created, err = client.CreateUploadSession(ctx, &pbMedia.CreateUploadSessionRequest{VideoId: "closing", TotalSize: 4})
+	require.NoError(t, err)
+	uploadID = created.Session.UploadId
+	stream, err = client.UploadChunks(ctx)
+	require.NoError(t, err)
+	corrupted = chunk(0, "talk")
+	corrupted.Crc32C++
+	nacks := 0
+	for err == nil {
+		require.NoError(t, stream.Send(corrupted))
+		_, err = stream.Recv()
+		nacks++
+	}
+	assert.Equal(t, codes.DataLoss, status.Code(err), err)
+	assert.Greater(t, nacks, 1)
+}
+
+func TestVideoIDBelongsToUploader(t *testing.T) {
+	cfg := env.DefaultConfig()
+	cfg.VideoVersioning = true
+	srv := servertest.New(t, cfg)
+	speaker := servertest.Context(srv.CreateUser(t, "speaker", "secret"))
+	other := servertest.Context(srv.CreateUser(t, "other", "secret"))
+
+	upload := func(ctx context.Context, overwrite bool) error {
+		stream, err := srv.Media().UploadVideo(ctx)
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(&pbMedia.UploadVideoRequest{VideoId: "keynote", Data: []byte("keynote"), Overwrite: overwrite}))
+		_, err = stream.CloseAndRecv()
+		return err
+	}
+	require.NoError(t, upload(speaker, false))
+
+	// Versions are only for the uploader.
+	assert.Equal(t, codes.AlreadyExists, status.Code(upload(other, false)))
+	assert.Equal(t, codes.PermissionDenied, status.Code(upload(other, true)))
+	require.NoError(t, upload(speaker, false))
+
+	md, err := srv.Media().GetVideoMetadata(speaker, &pbMedia.GetVideoMetadataRequest{VideoId: "keynote"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), md.Metadata.Version)
+	assert.Equal(t, "speaker", md.Metadata.UploaderName)
+}
+
+func TestGatewayDropsPeerCertificate(t *testing.T) {
+	cfg := env.DefaultConfig()
+	cfg.MeshTrustXFCC = true
+	cfg.MeshMethods = []string{"/media.MediaService/ListVideos"}
+	cfg.MeshAllowedPeers = []string{"spiffe://cluster.local/ns/coscup/sa/transcoder"}
+	srv := servertest.New(t, cfg)
+	token := srv.CreateUser(t, "speaker", "secret")
+	xfcc := "URI=spiffe://cluster.local/ns/coscup/sa/transcoder"
+
+	// Step 1: over gRPC, behind the sidecar, the peer is allowed
+	ctx := metadata.AppendToOutgoingContext(servertest.Context(token), "x-forwarded-client-cert", xfcc)
+	_, err := srv.Media().ListVideos(ctx, &pbMedia.ListVideosRequest{})
+	require.NoError(t, err)
+
+	// Step 2: a peer certificate sent to the gateway is not passed on
+	for _, header := range []string{"", "X-Forwarded-Client-Cert", "Grpc-Metadata-X-Forwarded-Client-Cert"} {
+		req := httptest.NewRequest("GET", "/v1/videos", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		if header != "" {
+			req.Header.Set(header, xfcc)
+		}
+		rr := httptest.NewRecorder()
+		srv.Gateway.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusForbidden, rr.Code, header)
+	}
+}
+
+func TestDevCORSPreflight(t *testing.T) {
+	srv := servertest.New(t, nil)
+	handler := allowAllOrigins(srv.Gateway)
+
+	req := httptest.NewRequest("OPTIONS", "/v1/profile", nil)
+	req.Header.Set("Origin", "http://localhost:3000")
+	req.Header.Set("Access-Control-Request-Method", "GET")
+	req.Header.Set("Access-Control-Request-Headers", "authorization")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+	assert.Equal(t, "*", rr.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "authorization", rr.Header().Get("Access-Control-Allow-Headers"))
+
+	req = httptest.NewRequest("GET", "/v1/profile", nil)
+	req.Header.Set("Origin", "http://localhost:3000")
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	assert.Equal(t, "*", rr.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestGatewayMetricsAreForAdminNetworks(t *testing.T) {
+	list, err := acl.Parse(env.DefaultConfig().AdminAllow, nil)
+	require.NoError(t, err)
+	mux := runtime.NewServeMux()
+	require.NoError(t, handleGatewayMetrics(mux, list))
+
+	for remote, want := range map[string]int{
+		"127.0.0.1:40000":   http.StatusOK,
+		"203.0.113.7:40000": http.StatusForbidden,
+	} {
+		req := httptest.NewRequest("GET", "/metrics", nil)
+		req.RemoteAddr = remote
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		assert.Equal(t, want, rr.Code, remote)
+	}
+}
+
+func TestTracesReachCollector(t *testing.T) {
+	collector := otlptest.New(t)
+	cfg := env.DefaultConfig()
+	cfg.TraceEndpoint = collector.Endpoint()
+	shutdown := initTracer(cfg)
+
+	srv := servertest.New(t, cfg)
+	token := srv.CreateUser(t, "speaker", "secret")
+	ctx := servertest.Context(token)
+	profile, err := srv.Auth().GetUserProfile(ctx, &pbAuth.GetUserProfileRequest{})
+	require.NoError(t, err)
+	_, err = srv.Media().ListVideos(ctx, &pbMedia.ListVideosRequest{})
+	require.NoError(t, err)
+	shutdown() // flushes the batch
+
+	// The identity processor labels handler spans with the caller.
+	span := collector.RequireSpan(t, "ListVideos", attribute.String("enduser.id", profile.UserId))
+	assert.True(t, span.Resource.Has(attribute.String("service.name", "coscup2025-service")))
+}
@@ -0,0 +1,252 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"coscup2025/auth"
+	"coscup2025/proto/media"
+	"coscup2025/storage"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"log"
+	"net/url"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"golang.org/x/image/draw"
+	"golang.org/x/image/webp"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultAvatarSize is the width and height of avatars unless configured.
+const defaultAvatarSize = 256
+
+// maxAvatarPixels bounds the width and height of avatar uploads, checked
+// before they are decoded, so that a small file cannot expand into a huge
+// image.
+const maxAvatarPixels = 4096
+
+// avatarDecoders decode the image types accepted as avatars, of GIFs the
+// first frame.
+var avatarDecoders = map[string]struct {
+	config func(io.Reader) (image.Config, error)
+	decode func(io.Reader) (image.Image, error)
+}{
+	"image/jpeg": {jpeg.DecodeConfig, jpeg.Decode},
+	"image/png":  {png.DecodeConfig, png.Decode},
+	"image/gif":  {gif.DecodeConfig, gif.Decode},
+	"image/webp": {webp.DecodeConfig, webp.Decode},
+}
+
+// avatar is a user's profile picture, as served.
+type avatar struct {
+	Data        []byte
+	ContentType string
+}
+
+// avatarRecord is what the avatar store's index keeps about an avatar
+// besides its image.
+type avatarRecord struct {
+	ContentType string `json:"content_type"`
+}
+
+// avatarURL is the gateway path serving the avatar of userID.
+func avatarURL(userID string) string {
+	return "/v1/public/users/" + url.PathEscape(userID) + "/avatar"
+}
+
+// PersistAvatars keeps avatars in st from now on, and loads the avatars a
+// previous run stored there. It returns how many were loaded.
+func (s *mediaServer) PersistAvatars(st BlobStore) (int, error) {
+	avatars := make(map[string]*avatar)
+	for userID, rec := range st.Records() {
+		var ar avatarRecord
+		if err := json.Unmarshal(rec.Meta, &ar); err != nil {
+			return 0, fmt.Errorf("avatar of %s: invalid record: %v", userID, err)
+		}
+		f, err := st.OpenBlob(rec.Blob)
+		if err != nil {
+			return 0, fmt.Errorf("avatar of %s: %v", userID, err)
+		}
+		data, err := io.ReadAll(f)
+		f.Close()
+		if err != nil {
+			return 0, fmt.Errorf("avatar of %s: %v", userID, err)
+		}
+		avatars[userID] = &avatar{Data: data, ContentType: ar.ContentType}
+	}
+
+	s.avatarMu.Lock()
+	defer s.avatarMu.Unlock()
+
+	for userID, a := range avatars {
+		s.avatars[userID] = a
+	}
+	s.avatarStore = st
+	return len(avatars), nil
+}
+
+// resizeAvatar decodes an uploaded image, crops it to a centered square and
+// scales that down to size pixels, returning the avatar and its final size.
+// Re-encoding also drops the metadata of the upload, such as where a photo
+// was taken.
+func resizeAvatar(data []byte, size int) (*avatar, int, error) {
+	contentType := detectThumbnailType(data)
+	codec, ok := avatarDecoders[contentType]
+	if !ok {
+		return nil, 0, status.Error(grpccodes.InvalidArgument, "avatar must be a JPEG, PNG, GIF or WebP image")
+	}
+
+	cfg, err := codec.config(bytes.NewReader(data))
+	if err != nil {
+		return nil, 0, status.Errorf(grpccodes.InvalidArgument, "invalid image: %v", err)
+	}
+	if cfg.Width > maxAvatarPixels || cfg.Height > maxAvatarPixels {
+		return nil, 0, status.Errorf(grpccodes.InvalidArgument, "avatar of %dx%d pixels exceeds %dx%d", cfg.Width, cfg.Height, maxAvatarPixels, maxAvatarPixels)
+	}
+	img, err := codec.decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, 0, status.Errorf(grpccodes.InvalidArgument, "invalid image: %v", err)
+	}
+
+	b := img.Bounds()
+	side := min(b.Dx(), b.Dy())
+	crop := image.Rect(0, 0, side, side).Add(b.Min).Add(image.Pt((b.Dx()-side)/2, (b.Dy()-side)/2))
+	// Small images are kept at their size rather than blown up
+	size = min(size, side)
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, crop, draw.Src, nil)
+
+	var out bytes.Buffer
+	a := &avatar{ContentType: "image/png"}
+	if contentType == "image/jpeg" {
+		a.ContentType = "image/jpeg"
+		err = jpeg.Encode(&out, dst, &jpeg.Options{Quality: 85})
+	} else {
+		// PNG keeps the transparency of the other types
+		err = png.Encode(&out, dst)
+	}
+	if err != nil {
+		return nil, 0, status.Errorf(grpccodes.Internal, "failed to encode avatar: %v", err)
+	}
+	a.Data = out.Bytes()
+	return a, size, nil
+}
+
+func (s *mediaServer) SetAvatar(ctx context.Context, req *media.SetAvatarRequest) (*media.SetAvatarResponse, error) {
+	_, span := s.tracer.Start(ctx, "SetAvatar")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("service.name", "media-service"),
+		attribute.String("rpc.method", "SetAvatar"),
+		attribute.String("rpc.service", "MediaService"),
+		attribute.Int("avatar.upload_bytes", len(req.Data)),
+	)
+
+	id, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		err := status.Error(grpccodes.Unauthenticated, "unknown caller")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "unknown caller")
+		return nil, err
+	}
+
+	a, size, err := resizeAvatar(req.Data, s.avatarSize)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid avatar")
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int("avatar.size_bytes", len(a.Data)))
+
+	s.avatarMu.Lock()
+	defer s.avatarMu.Unlock()
+
+	if err := s.storeAvatarLocked(id.UserID, a); err != nil {
+		err = status.Errorf(grpccodes.Unavailable, "failed to store avatar: %v", err)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to store avatar")
+		return nil, err
+	}
+	s.avatars[id.UserID] = a
+
+	span.SetStatus(codes.Ok, "avatar set")
+
+	return &media.SetAvatarResponse{
+		AvatarUrl:   avatarURL(id.UserID),
+		Size:        int32(size),
+		ContentType: a.ContentType,
+	}, nil
+}
+
+func (s *mediaServer) GetAvatar(ctx context.Context, req *media.GetAvatarRequest) (*media.GetAvatarResponse, error) {
+	_, span := s.tracer.Start(ctx, "GetAvatar")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("service.name", "media-service"),
+		attribute.String("rpc.method", "GetAvatar"),
+		attribute.String("rpc.service", "MediaService"),
+		attribute.String("avatar.user_id", req.UserId),
+	)
+
+	s.avatarMu.RLock()
+	defer s.avatarMu.RUnlock()
+
+	a, exists := s.avatars[req.UserId]
+	if !exists {
+		err := status.Error(grpccodes.NotFound, "avatar not found")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "avatar not found")
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "avatar returned")
+
+	return &media.GetAvatarResponse{Data: a.Data, ContentType: a.ContentType}, nil
+}
+
+// storeAvatarLocked writes the avatar of userID to the avatar store, if
+// there is one. The caller must hold s.avatarMu.
+func (s *mediaServer) storeAvatarLocked(userID string, a *avatar) error {
+	if s.avatarStore == nil {
+		return nil
+	}
+	meta, err := json.Marshal(avatarRecord{ContentType: a.ContentType})
+	if err != nil {
+		return err
+	}
+	hash, size, err := s.avatarStore.Put(bytes.NewReader(a.Data))
+	if err != nil {
+		return err
+	}
+	if err := s.avatarStore.Set(userID, storage.Record{Blob: hash, Size: size, Meta: meta}); err != nil {
+		s.avatarStore.Discard(hash)
+		return err
+	}
+	return nil
+}
+
+// deleteAvatar removes the avatar of userID, if they have one.
+func (s *mediaServer) deleteAvatar(userID string) {
+	s.avatarMu.Lock()
+	defer s.avatarMu.Unlock()
+
+	delete(s.avatars, userID)
+	if s.avatarStore == nil {
+		return
+	}
+	if _, ok := s.avatarStore.Record(userID); !ok {
+		return
+	}
+	if err := s.avatarStore.Delete(userID); err != nil {
+		log.Printf("Failed to remove avatar of %s from storage: %v", userID, err)
+	}
+}
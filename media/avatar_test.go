@@ -0,0 +1,83 @@
+package media_test
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"coscup2025/env"
+	pbMedia "coscup2025/proto/media"
+)
+
+// encodedImage returns a w by h image, as a JPEG or else a PNG.
+func encodedImage(t *testing.T, w, h int, asJPEG bool) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for x := range w {
+		for y := range h {
+			img.Set(x, y, color.RGBA{uint8(x), uint8(y), 200, 255})
+		}
+	}
+	var b bytes.Buffer
+	if asJPEG {
+		require.NoError(t, jpeg.Encode(&b, img, nil))
+	} else {
+		require.NoError(t, png.Encode(&b, img))
+	}
+	return b.Bytes()
+}
+
+func TestAvatar(t *testing.T) {
+	cfg := env.DefaultConfig()
+	cfg.StorageDir = t.TempDir()
+	cfg.AvatarSize = 64
+	client, ctx := setupMediaClientWithConfig(t, cfg)
+
+	_, err := client.SetAvatar(ctx, &pbMedia.SetAvatarRequest{Data: []byte("not an image")})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = client.SetAvatar(ctx, &pbMedia.SetAvatarRequest{Data: encodedImage(t, 5000, 10, false)})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Step 1: a landscape photo is cropped to a square and scaled down
+	set, err := client.SetAvatar(ctx, &pbMedia.SetAvatarRequest{Data: encodedImage(t, 300, 200, true)})
+	require.NoError(t, err)
+	assert.Equal(t, int32(64), set.Size)
+	assert.Equal(t, "image/jpeg", set.ContentType)
+
+	// Step 2: anyone can fetch it
+	got, err := client.GetAvatar(context.Background(), &pbMedia.GetAvatarRequest{UserId: userIDFromURL(set.AvatarUrl)})
+	require.NoError(t, err)
+	assert.Equal(t, "image/jpeg", got.ContentType)
+	img, err := jpeg.Decode(bytes.NewReader(got.Data))
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 64, 64), img.Bounds())
+
+	// Step 3: small images keep their size, and other types become PNGs
+	set, err = client.SetAvatar(ctx, &pbMedia.SetAvatarRequest{Data: encodedImage(t, 20, 30, false)})
+	require.NoError(t, err)
+	assert.Equal(t, int32(20), set.Size)
+	assert.Equal(t, "image/png", set.ContentType)
+
+	// Step 4: the avatar survives a restart
+	client, _ = setupMediaClientWithConfig(t, cfg)
+	got, err = client.GetAvatar(context.Background(), &pbMedia.GetAvatarRequest{UserId: userIDFromURL(set.AvatarUrl)})
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", got.ContentType)
+
+	_, err = client.GetAvatar(context.Background(), &pbMedia.GetAvatarRequest{UserId: "user_404"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+// userIDFromURL returns the user ID of an avatar URL.
+func userIDFromURL(u string) string {
+	u = u[len("/v1/public/users/"):]
+	return u[:len(u)-len("/avatar")]
+}
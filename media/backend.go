@@ -0,0 +1,34 @@
+package media
+
+import (
+	"io"
+)
+
+// Backend sees the bytes of videos on their way in and out of the server's
+// memory: every chunk an upload session commits and every read of a
+// download. The server keeps working without one; tests set one to inject
+// storage faults, see package faultstore.
+type Backend interface {
+	// WriteChunk is called before data is committed to an upload session
+	// at offset. An error fails the chunk and leaves the session at offset,
+	// so that the client can resume from there.
+	WriteChunk(uploadID string, offset int64, data []byte) error
+	// Open returns the reader a download of videoID uses in place of
+	// content.
+	Open(videoID string, content io.ReaderAt) io.ReaderAt
+}
+
+// SetBackend routes uploads and downloads through b.
+func (s *mediaServer) SetBackend(b Backend) {
+	s.backend = b
+}
+
+// backendContent is video content read through a Backend.
+type backendContent struct {
+	io.ReaderAt
+	size int64
+}
+
+func (c backendContent) Size() int64 {
+	return c.size
+}
@@ -0,0 +1,74 @@
+package media
+
+import (
+	"context"
+	"coscup2025/proto/media"
+	"sync"
+	"time"
+)
+
+// downloadScheduler shares the download bandwidth of the server between
+// download streams. Each chunk is given a turn on a virtual clock that
+// advances by the time the chunk takes at the configured rate. Chunks of
+// interactive downloads queue behind the turns already given out; chunks of
+// batch downloads only get a turn while the link is idle, so that players
+// take the bandwidth of a running mirror after at most one of its chunks.
+type downloadScheduler struct {
+	rate int64 // bytes per second, zero for unlimited
+	// now and sleepUntil are the clock turns are given out by, a fake one
+	// in tests.
+	now        func() time.Time
+	sleepUntil func(ctx context.Context, t time.Time) error
+
+	mu   sync.Mutex
+	free time.Time // when the turns given out so far are over
+}
+
+func newDownloadScheduler(rate int64) *downloadScheduler {
+	return &downloadScheduler{rate: rate, now: time.Now, sleepUntil: sleepUntil}
+}
+
+// wait blocks until a chunk of n bytes of a download of the given priority
+// may be sent, or ctx is done.
+func (d *downloadScheduler) wait(ctx context.Context, priority media.DownloadPriority, n int) error {
+	if d == nil || d.rate <= 0 {
+		return nil
+	}
+	for {
+		d.mu.Lock()
+		now := d.now()
+		start := d.free
+		if start.Before(now) {
+			start = now
+		}
+		if priority == media.DownloadPriority_DOWNLOAD_PRIORITY_BATCH && start.After(now) {
+			// Look again once the link is idle, as an interactive chunk
+			// may have taken the next turn by then.
+			d.mu.Unlock()
+			if err := d.sleepUntil(ctx, start); err != nil {
+				return err
+			}
+			continue
+		}
+		d.free = start.Add(time.Duration(n) * time.Second / time.Duration(d.rate))
+		d.mu.Unlock()
+		return d.sleepUntil(ctx, start)
+	}
+}
+
+// sleepUntil blocks until t or until ctx is done, returning ctx's error in
+// the latter case.
+func sleepUntil(ctx context.Context, t time.Time) error {
+	delay := time.Until(t)
+	if delay <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,128 @@
+package media
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"coscup2025/proto/media"
+)
+
+// fakeClock only moves when advanced.
+type fakeClock struct {
+	mu       sync.Mutex
+	now      time.Time
+	sleepers map[chan struct{}]time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Unix(0, 0), sleepers: make(map[chan struct{}]time.Time)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) SleepUntil(ctx context.Context, t time.Time) error {
+	c.mu.Lock()
+	if !t.After(c.now) {
+		c.mu.Unlock()
+		return ctx.Err()
+	}
+	wake := make(chan struct{})
+	c.sleepers[wake] = t
+	c.mu.Unlock()
+	select {
+	case <-wake:
+		return nil
+	case <-ctx.Done():
+		c.mu.Lock()
+		delete(c.sleepers, wake)
+		c.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	for wake, t := range c.sleepers {
+		if !t.After(c.now) {
+			close(wake)
+			delete(c.sleepers, wake)
+		}
+	}
+}
+
+// waitSleeping waits until n goroutines sleep on the clock.
+func (c *fakeClock) waitSleeping(t *testing.T, n int) {
+	require.Eventually(t, func() bool {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return len(c.sleepers) == n
+	}, 5*time.Second, time.Millisecond)
+}
+
+// sentAt returns the time a chunk waiting on sent was let through.
+func sentAt(t *testing.T, sent <-chan time.Time) time.Time {
+	select {
+	case at := <-sent:
+		return at
+	case <-time.After(5 * time.Second):
+		t.Fatal("the chunk was not let through")
+		return time.Time{}
+	}
+}
+
+func TestDownloadPriority(t *testing.T) {
+	clock := newFakeClock()
+	d := newDownloadScheduler(1000) // a chunk of 1000 bytes every second
+	d.now, d.sleepUntil = clock.Now, clock.SleepUntil
+	ctx := context.Background()
+	batch, interactive := media.DownloadPriority_DOWNLOAD_PRIORITY_BATCH, media.DownloadPriority_DOWNLOAD_PRIORITY_INTERACTIVE
+
+	wait := func(priority media.DownloadPriority) <-chan time.Time {
+		sent := make(chan time.Time, 1)
+		go func() {
+			assert.NoError(t, d.wait(ctx, priority, 1000))
+			sent <- clock.Now()
+		}()
+		return sent
+	}
+
+	// Step 1: a mirror takes the idle link, and its next chunk waits for
+	// the link to be idle again
+	require.Equal(t, time.Unix(0, 0), sentAt(t, wait(batch)))
+	mirror := wait(batch)
+	clock.waitSleeping(t, 1)
+
+	// Step 2: a player arriving meanwhile takes the next turn
+	clock.Advance(500 * time.Millisecond)
+	player := wait(interactive)
+	clock.waitSleeping(t, 2)
+	clock.Advance(500 * time.Millisecond)
+	require.Equal(t, time.Unix(1, 0), sentAt(t, player))
+
+	// Step 3: the mirror goes on after the player's chunk
+	clock.waitSleeping(t, 1)
+	require.Empty(t, mirror)
+	clock.Advance(time.Second)
+	require.Equal(t, time.Unix(2, 0), sentAt(t, mirror))
+
+	// Step 4: interactive chunks queue behind the turns given out
+	first := wait(interactive)
+	clock.waitSleeping(t, 1)
+	second := wait(interactive)
+	clock.waitSleeping(t, 2)
+	clock.Advance(time.Second)
+	require.Equal(t, time.Unix(3, 0), sentAt(t, first))
+	clock.Advance(time.Second)
+	require.Equal(t, time.Unix(4, 0), sentAt(t, second))
+}
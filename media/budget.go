@@ -0,0 +1,62 @@
+package media
+
+import (
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// memoryHeldLocked returns the bytes the server holds in memory: stored
+// videos and thumbnails, upload sessions and uploads without a session. A
+// session counts at least its announced size, which was admitted when it was
+// created. It is computed from the current state, like the storage metrics,
+// so it cannot drift. The caller must hold s.mu.
+func (s *mediaServer) memoryHeldLocked() int64 {
+	held := s.streamBytes
+	for _, v := range s.videos {
+		held += int64(len(v.Data) + len(v.Thumbnail))
+	}
+	for _, session := range s.sessions {
+		held += max(session.totalSize, int64(len(session.data)))
+	}
+	return held
+}
+
+// admitLocked fails with ResourceExhausted when holding n more bytes would
+// exceed the memory budget, so that uploads are turned away before the
+// process runs out of memory. The caller must hold s.mu.
+func (s *mediaServer) admitLocked(n int64) error {
+	if s.memoryBudget <= 0 || n <= 0 {
+		return nil
+	}
+	if s.memoryHeldLocked()+n > s.memoryBudget {
+		return status.Error(grpccodes.ResourceExhausted, "the server is out of upload capacity, retry later")
+	}
+	return nil
+}
+
+// admitChunk admits n more bytes received for an upload. Chunks within a
+// session's announced size are already admitted. Bytes of an upload without a
+// session are counted in s.streamBytes and added to held, which the stream
+// gives back through releaseStreamBytes when it ends.
+func (s *mediaServer) admitChunk(session *uploadSession, n int64, held *int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if session != nil {
+		committed := int64(len(session.data))
+		return s.admitLocked(max(session.totalSize, committed+n) - max(session.totalSize, committed))
+	}
+	if err := s.admitLocked(n); err != nil {
+		return err
+	}
+	s.streamBytes += n
+	*held += n
+	return nil
+}
+
+func (s *mediaServer) releaseStreamBytes(n int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.streamBytes -= n
+}
@@ -0,0 +1,48 @@
+package media
+
+import (
+	"coscup2025/auth"
+	"coscup2025/storage"
+	"errors"
+	"time"
+)
+
+// URLSigner signs URLs under which a CDN serves the blobs of the video store.
+type URLSigner interface {
+	Sign(path string, expires time.Time) (string, error)
+}
+
+// SetCDN makes download links point at the CDN behind signer for videos in
+// the video store, so that their bytes do not pass through the server.
+func (s *mediaServer) SetCDN(signer URLSigner) {
+	s.cdn = signer
+}
+
+var errNoDownloadLinks = errors.New("download links are not available on this server")
+
+// canLinkDownloads reports whether downloadURL can produce links.
+func (s *mediaServer) canLinkDownloads() bool {
+	return s.signer != nil || s.cdn != nil
+}
+
+// downloadURL returns a URL from which videoID downloads without further
+// credentials until the returned time, on behalf of owner. It points at the
+// CDN when the video is in the video store, and at the gateway otherwise.
+// The caller must have checked that owner may view the video.
+func (s *mediaServer) downloadURL(owner *auth.Identity, videoID string, ttl time.Duration) (string, time.Time, error) {
+	if s.cdn != nil && s.videoStore != nil {
+		if rec, ok := s.videoStore.Record(videoID); ok {
+			expires := time.Now().Add(ttl)
+			u, err := s.cdn.Sign(storage.BlobKey(rec.Blob), expires)
+			return u, expires, err
+		}
+	}
+	if s.signer == nil {
+		return "", time.Time{}, errNoDownloadLinks
+	}
+	token, expires, err := s.signer.SignVideoToken(owner, videoID, ttl)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return signedDownloadURL(videoID, token), expires, nil
+}
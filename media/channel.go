@@ -0,0 +1,76 @@
+package media
+
+import (
+	"context"
+	"coscup2025/auth"
+	"coscup2025/proto/media"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+)
+
+// channel groups videos of a user or a tenant. All fields are guarded by
+// mediaServer.mu.
+type channel struct {
+	id                string
+	name              string
+	description       string
+	ownerID           string
+	tenant            string
+	defaultVisibility media.Visibility
+	createdAt         time.Time
+}
+
+func newChannelID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return "ch_" + hex.EncodeToString(b)
+}
+
+func (c *channel) proto() *media.Channel {
+	return &media.Channel{
+		ChannelId:         c.id,
+		Name:              c.name,
+		Description:       c.description,
+		OwnerId:           c.ownerID,
+		Tenant:            c.tenant,
+		DefaultVisibility: c.defaultVisibility,
+		CreatedAt:         c.createdAt.Unix(),
+	}
+}
+
+// managedBy reports whether id may assign videos to the channel.
+func (c *channel) managedBy(id *auth.Identity) bool {
+	if c.tenant != "" {
+		return id.Tenant == c.tenant
+	}
+	return id.UserID == c.ownerID
+}
+
+// isListed reports whether a video shows up in listings for userID.
+func isListed(md *media.VideoMetadata, userID string) bool {
+	if md.TakenDown {
+		return md.UploaderId == userID
+	}
+	switch md.Visibility {
+	case media.Visibility_VISIBILITY_UNSPECIFIED, media.Visibility_VISIBILITY_PUBLIC:
+		return true
+	}
+	return md.UploaderId == userID
+}
+
+// isViewable reports whether userID may fetch a video by its ID.
+func isViewable(md *media.VideoMetadata, userID string) bool {
+	if md.TakenDown || md.Visibility == media.Visibility_VISIBILITY_PRIVATE {
+		return md.UploaderId == userID
+	}
+	return true
+}
+
+// callerID returns the authenticated caller's user ID, or "" for anonymous calls.
+func callerID(ctx context.Context) string {
+	if id, ok := auth.IdentityFromContext(ctx); ok {
+		return id.UserID
+	}
+	return ""
+}
@@ -0,0 +1,43 @@
+package media_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pbMedia "coscup2025/proto/media"
+)
+
+func TestChannelVisibility(t *testing.T) {
+	client, ctx := setupMediaClient(t)
+	uploadVideo(t, client, ctx, "keynote")
+	uploadVideo(t, client, ctx, "rehearsal")
+
+	public, err := client.CreateChannel(ctx, &pbMedia.CreateChannelRequest{Name: "Main Track"})
+	require.NoError(t, err)
+	assert.Equal(t, pbMedia.Visibility_VISIBILITY_PUBLIC, public.Channel.DefaultVisibility)
+	private, err := client.CreateChannel(ctx, &pbMedia.CreateChannelRequest{
+		Name:              "Backstage",
+		DefaultVisibility: pbMedia.Visibility_VISIBILITY_PRIVATE,
+	})
+	require.NoError(t, err)
+
+	_, err = client.AssignVideoToChannel(ctx, &pbMedia.AssignVideoToChannelRequest{ChannelId: public.Channel.ChannelId, VideoId: "keynote"})
+	require.NoError(t, err)
+	assigned, err := client.AssignVideoToChannel(ctx, &pbMedia.AssignVideoToChannelRequest{ChannelId: private.Channel.ChannelId, VideoId: "rehearsal"})
+	require.NoError(t, err)
+	assert.Equal(t, pbMedia.Visibility_VISIBILITY_PRIVATE, assigned.Metadata.Visibility)
+
+	listed, err := client.ListPublicChannels(ctx, &pbMedia.ListPublicChannelsRequest{})
+	require.NoError(t, err)
+	require.Len(t, listed.Channels, 1)
+	assert.Equal(t, "Main Track", listed.Channels[0].Channel.Name)
+	require.Len(t, listed.Channels[0].Videos, 1)
+	assert.Equal(t, "keynote", listed.Channels[0].Videos[0].VideoId)
+
+	// The uploader still sees their private video.
+	videos, err := client.ListVideos(ctx, &pbMedia.ListVideosRequest{})
+	require.NoError(t, err)
+	assert.Len(t, videos.Videos, 2)
+}
@@ -0,0 +1,57 @@
+package media
+
+import (
+	"coscup2025/proto/media"
+	"crypto/sha256"
+	"encoding"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+)
+
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+// checksums computes the SHA-256 and CRC-32C of an upload as its chunks
+// arrive, so finishing a multi-GB upload does not read it a second time. A
+// resumable session keeps its checksums between streams.
+type checksums struct {
+	sha256 hash.Hash
+	crc32c hash.Hash32
+	io.Writer
+}
+
+func newChecksums() *checksums {
+	c := &checksums{sha256: sha256.New(), crc32c: crc32.New(castagnoli)}
+	c.Writer = io.MultiWriter(c.sha256, c.crc32c)
+	return c
+}
+
+// apply records the checksums of everything written so far in metadata.
+func (c *checksums) apply(metadata *media.VideoMetadata) {
+	metadata.Sha256 = fmt.Sprintf("%x", c.sha256.Sum(nil))
+	metadata.Crc32C = c.crc32c.Sum32()
+}
+
+// marshal saves the state of both hashes, to be continued by
+// unmarshalChecksums after a restart.
+func (c *checksums) marshal() (sha, crc []byte, err error) {
+	if sha, err = c.sha256.(encoding.BinaryMarshaler).MarshalBinary(); err != nil {
+		return nil, nil, err
+	}
+	if crc, err = c.crc32c.(encoding.BinaryMarshaler).MarshalBinary(); err != nil {
+		return nil, nil, err
+	}
+	return sha, crc, nil
+}
+
+func unmarshalChecksums(sha, crc []byte) (*checksums, error) {
+	c := newChecksums()
+	if err := c.sha256.(encoding.BinaryUnmarshaler).UnmarshalBinary(sha); err != nil {
+		return nil, err
+	}
+	if err := c.crc32c.(encoding.BinaryUnmarshaler).UnmarshalBinary(crc); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
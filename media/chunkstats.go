@@ -0,0 +1,61 @@
+package media
+
+import (
+	"coscup2025/proto/media"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// chunkStats summarizes the chunks of one stream, so that a span carries the
+// chunk count and latencies instead of one event per chunk.
+type chunkStats struct {
+	count      int64
+	minLatency time.Duration
+	maxLatency time.Duration
+	sumLatency time.Duration
+}
+
+func (c *chunkStats) observe(latency time.Duration) {
+	if c.count == 0 || latency < c.minLatency {
+		c.minLatency = latency
+	}
+	c.maxLatency = max(c.maxLatency, latency)
+	c.sumLatency += latency
+	c.count++
+}
+
+// sampled reports whether the current chunk gets its own span event: every
+// interval-th chunk, or none when interval is 0.
+func (c *chunkStats) sampled(interval int) bool {
+	return interval > 0 && c.count%int64(interval) == 0
+}
+
+func (c *chunkStats) attributes() []attribute.KeyValue {
+	var avg time.Duration
+	if c.count > 0 {
+		avg = c.sumLatency / time.Duration(c.count)
+	}
+	return []attribute.KeyValue{
+		attribute.Int64("chunk.count", c.count),
+		attribute.Float64("chunk.latency_min_ms", float64(c.minLatency)/float64(time.Millisecond)),
+		attribute.Float64("chunk.latency_max_ms", float64(c.maxLatency)/float64(time.Millisecond)),
+		attribute.Float64("chunk.latency_avg_ms", float64(avg)/float64(time.Millisecond)),
+	}
+}
+
+// proto returns the summary of a stream that started at start and moved
+// bytes in the observed chunks.
+func (c *chunkStats) proto(start time.Time, bytes int64) *media.TransferStats {
+	elapsed := time.Since(start)
+	stats := &media.TransferStats{
+		DurationMs: elapsed.Milliseconds(),
+		Bytes:      bytes,
+		ChunkCount: c.count,
+		Attempts:   1,
+	}
+	if elapsed > 0 {
+		stats.BytesPerSecond = float64(bytes) / elapsed.Seconds()
+	}
+	return stats
+}
@@ -0,0 +1,63 @@
+package media
+
+import (
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// replacedVideoLocked applies the policy for uploads to a video ID that is
+// taken. It returns the video an upload of videoID by userID replaces, nil
+// when the ID is free, and whether the upload becomes its next version rather
+// than overwriting it. Only the uploader of a video may replace it, by asking
+// to overwrite it or, when versioning is enabled, by uploading it again. The
+// caller must hold s.mu.
+func (s *mediaServer) replacedVideoLocked(videoID, userID string, overwrite bool) (*VideoInfo, bool, error) {
+	v, exists := s.videos[videoID]
+	switch {
+	case !exists:
+		return nil, false, nil
+	case v.Metadata.UploaderId != userID && overwrite:
+		return nil, false, status.Error(grpccodes.PermissionDenied, "only the uploader may overwrite this video")
+	case v.Metadata.UploaderId != userID:
+		return nil, false, status.Error(grpccodes.AlreadyExists, "video ID is taken")
+	case overwrite:
+		return v, false, nil
+	case s.videoVersioning:
+		return v, true, nil
+	}
+	return nil, false, status.Error(grpccodes.AlreadyExists, "video already exists, set overwrite to replace it")
+}
+
+// checkDuplicate fails an upload of videoID that the policy of
+// replacedVideoLocked would reject, before its bytes are sent. The upload is
+// checked again when it is stored.
+func (s *mediaServer) checkDuplicate(videoID, userID string, overwrite bool) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, _, err := s.replacedVideoLocked(videoID, userID, overwrite)
+	return err
+}
+
+// succeed makes v replace prev, as returned by replacedVideoLocked. A takedown
+// stays in force whatever replaces the video. A new version also keeps what
+// viewers and the uploader attached to the video: its likes, thumbnail,
+// channel and visibility.
+func (v *VideoInfo) succeed(prev *VideoInfo, versioned bool) {
+	v.Metadata.Version = 1
+	if prev == nil {
+		return
+	}
+	v.Metadata.TakenDown = prev.Metadata.TakenDown
+	if !versioned {
+		return
+	}
+	// Videos stored before versions were counted have none.
+	v.Metadata.Version = max(prev.Metadata.Version, 1) + 1
+	v.Metadata.ChannelId = prev.Metadata.ChannelId
+	v.Metadata.Visibility = prev.Metadata.Visibility
+	v.Metadata.LikeCount = prev.Metadata.LikeCount
+	v.Likes = prev.Likes
+	v.Thumbnail = prev.Thumbnail
+	v.ThumbnailType = prev.ThumbnailType
+}
@@ -0,0 +1,75 @@
+package media_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"coscup2025/env"
+	pbMedia "coscup2025/proto/media"
+)
+
+func replaceVideo(client pbMedia.MediaServiceClient, ctx context.Context, videoID, data string, overwrite bool) (*pbMedia.UploadVideoResponse, error) {
+	stream, err := client.UploadVideo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.Send(&pbMedia.UploadVideoRequest{VideoId: videoID, Data: []byte(data), Overwrite: overwrite}); err != nil {
+		return nil, err
+	}
+	return stream.CloseAndRecv()
+}
+
+func TestDuplicateVideoID(t *testing.T) {
+	client, ctx := setupMediaClient(t)
+	uploaded, err := replaceVideo(client, ctx, "keynote", "first", false)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), uploaded.Metadata.Version)
+
+	_, err = replaceVideo(client, ctx, "keynote", "second", false)
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	_, err = client.CreateUploadSession(ctx, &pbMedia.CreateUploadSessionRequest{VideoId: "keynote", TotalSize: 6})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	_, err = client.LikeVideo(ctx, &pbMedia.LikeVideoRequest{VideoId: "keynote"})
+	require.NoError(t, err)
+
+	// Overwriting replaces the video as if it were new.
+	replaced, err := replaceVideo(client, ctx, "keynote", "second", true)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), replaced.Metadata.Version)
+	md, err := client.GetVideoMetadata(ctx, &pbMedia.GetVideoMetadataRequest{VideoId: "keynote"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), md.Metadata.FileSize)
+	assert.Zero(t, md.Metadata.LikeCount)
+}
+
+func TestVideoVersions(t *testing.T) {
+	cfg := env.DefaultConfig()
+	cfg.VideoVersioning = true
+	client, ctx := setupMediaClientWithConfig(t, cfg)
+	_, err := replaceVideo(client, ctx, "keynote", "first", false)
+	require.NoError(t, err)
+	_, err = client.LikeVideo(ctx, &pbMedia.LikeVideoRequest{VideoId: "keynote"})
+	require.NoError(t, err)
+
+	// A new version keeps the likes of the previous one.
+	created, err := client.CreateUploadSession(ctx, &pbMedia.CreateUploadSessionRequest{VideoId: "keynote", TotalSize: 6})
+	require.NoError(t, err)
+	stream, err := client.UploadVideo(ctx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&pbMedia.UploadVideoRequest{VideoId: "keynote", UploadId: created.Session.UploadId, Data: []byte("second")}))
+	uploaded, err := stream.CloseAndRecv()
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), uploaded.Metadata.Version)
+
+	md, err := client.GetVideoMetadata(ctx, &pbMedia.GetVideoMetadataRequest{VideoId: "keynote"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), md.Metadata.Version)
+	assert.Equal(t, int64(6), md.Metadata.FileSize)
+	assert.Equal(t, int64(1), md.Metadata.LikeCount)
+}
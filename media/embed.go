@@ -0,0 +1,19 @@
+package media
+
+import (
+	"coscup2025/auth"
+	"time"
+)
+
+// embedTokenTTL is how long the player's signed source URL stays valid, long
+// enough for the page to sit open before playback starts.
+const embedTokenTTL = time.Hour
+
+// viewer returns the identity a player's source URL is signed for: the
+// caller, or an anonymous viewer that can only watch what anyone may watch.
+func viewer(id *auth.Identity, ok bool) *auth.Identity {
+	if !ok {
+		return &auth.Identity{}
+	}
+	return &auth.Identity{UserID: id.UserID, Username: id.Username, Tenant: id.Tenant}
+}
@@ -0,0 +1,49 @@
+package media_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pbMedia "coscup2025/proto/media"
+)
+
+func TestEmbedHonorsVisibility(t *testing.T) {
+	client, ctx := setupMediaClient(t)
+	uploadVideo(t, client, ctx, "keynote")
+	uploadVideo(t, client, ctx, "rehearsal")
+
+	private, err := client.CreateChannel(ctx, &pbMedia.CreateChannelRequest{
+		Name:              "Backstage",
+		DefaultVisibility: pbMedia.Visibility_VISIBILITY_PRIVATE,
+	})
+	require.NoError(t, err)
+	_, err = client.AssignVideoToChannel(ctx, &pbMedia.AssignVideoToChannelRequest{ChannelId: private.Channel.ChannelId, VideoId: "rehearsal"})
+	require.NoError(t, err)
+
+	anonymous := context.Background()
+	embed, err := client.GetEmbed(anonymous, &pbMedia.GetEmbedRequest{VideoId: "keynote"})
+	require.NoError(t, err)
+	assert.False(t, embed.Hls)
+
+	// The signed source URL lets the anonymous viewer stream the video.
+	source, err := url.Parse(embed.SourceUrl)
+	require.NoError(t, err)
+	signed := metadata.NewOutgoingContext(anonymous, metadata.Pairs("authorization", "Bearer "+source.Query().Get("access_token")))
+	stream, err := client.DownloadVideo(signed, &pbMedia.DownloadVideoRequest{VideoId: "keynote"})
+	require.NoError(t, err)
+	chunk, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("keynote"), chunk.Data)
+
+	_, err = client.GetEmbed(anonymous, &pbMedia.GetEmbedRequest{VideoId: "rehearsal"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = client.GetEmbed(ctx, &pbMedia.GetEmbedRequest{VideoId: "rehearsal"})
+	require.NoError(t, err)
+}
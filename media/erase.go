@@ -0,0 +1,50 @@
+package media
+
+// DeleteUserData erases the videos uploaded by userID along with their
+// playlists, channels, share links, upload sessions, likes and avatar. It returns how
+// many videos and bytes of video data were removed.
+func (s *mediaServer) DeleteUserData(userID string) (int, int64) {
+	s.deleteAvatar(userID)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var videos int
+	var size int64
+	for videoID, v := range s.videos {
+		if v.Metadata.UploaderId != userID {
+			continue
+		}
+		videos++
+		size += v.Metadata.FileSize + int64(len(v.Thumbnail))
+		delete(s.videos, videoID)
+		s.forgetVideoLocked(videoID)
+		s.removeFromPlaylists(videoID)
+	}
+	for _, v := range s.videos {
+		v.setLiked(userID, false)
+	}
+	for id, session := range s.sessions {
+		if session.ownerID == userID {
+			s.dropUploadSessionLocked(id)
+		}
+	}
+	for id, p := range s.playlists {
+		if p.ownerID == userID {
+			delete(s.playlists, id)
+		}
+	}
+	// Only owners assign videos to channels, so no other user's video is
+	// left in a deleted channel.
+	for id, c := range s.channels {
+		if c.ownerID == userID {
+			delete(s.channels, id)
+		}
+	}
+	for code, link := range s.shareLinks {
+		if link.owner.UserID == userID {
+			delete(s.shareLinks, code)
+		}
+	}
+	return videos, size
+}
@@ -0,0 +1,389 @@
+package media
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"cmp"
+	"context"
+	"coscup2025/auth"
+	"coscup2025/jobs"
+	"coscup2025/proto/media"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	exportJobKind   = "video-export"
+	exportChunkSize = 1024 * 1024
+	// maxExportVideos bounds the videos of one export, which is built in
+	// memory.
+	maxExportVideos = 500
+)
+
+// exportedVideo is a video as it was when the export was requested.
+type exportedVideo struct {
+	id       string
+	metadata *media.VideoMetadata
+	content  videoContent
+}
+
+// exportedVideoLocked takes the video for an export. The caller must hold
+// s.mu.
+func (s *mediaServer) exportedVideoLocked(videoID string, info *VideoInfo) exportedVideo {
+	v := exportedVideo{id: videoID, metadata: proto.Clone(info.Metadata).(*media.VideoMetadata), content: bytes.NewReader(info.Data)}
+	if info.blob != "" {
+		v.content = &storedContent{store: s.videoStore, hash: info.blob, size: info.Metadata.FileSize}
+	}
+	return v
+}
+
+// read returns the content of the video, reading offloaded videos from the
+// video store.
+func (v exportedVideo) read() ([]byte, error) {
+	if stored, ok := v.content.(*storedContent); ok {
+		defer stored.close()
+	}
+	return readRange(v.content, 0, v.content.Size())
+}
+
+// SetJobRunner lets callers export videos in the background on r.
+func (s *mediaServer) SetJobRunner(r *jobs.Runner) {
+	s.jobs = r
+}
+
+func (s *mediaServer) CreateExport(ctx context.Context, req *media.CreateExportRequest) (*media.CreateExportResponse, error) {
+	_, span := s.tracer.Start(ctx, "CreateExport")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("service.name", "media-service"),
+		attribute.String("rpc.method", "CreateExport"),
+		attribute.String("rpc.service", "MediaService"),
+		attribute.String("export.format", req.Format.String()),
+	)
+
+	id, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		err := status.Error(grpccodes.Unauthenticated, "unknown caller")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "unknown caller")
+		return nil, err
+	}
+	if s.jobs == nil {
+		err := status.Error(grpccodes.FailedPrecondition, "exports are not available on this server")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "no job runner")
+		return nil, err
+	}
+	if req.Format == media.ExportFormat_EXPORT_FORMAT_MANIFEST && !s.canLinkDownloads() {
+		err := status.Error(grpccodes.FailedPrecondition, errNoDownloadLinks.Error())
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "no URL signer")
+		return nil, err
+	}
+
+	videos, err := s.selectExport(id.UserID, req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid selection")
+		return nil, err
+	}
+
+	j, err := s.jobs.Submit(id.UserID, exportJobKind, s.export(id, req.Format, videos))
+	if errors.Is(err, jobs.ErrQueueFull) {
+		err = status.Error(grpccodes.ResourceExhausted, "too many exports in progress, try again later")
+	} else if err != nil {
+		err = status.Errorf(grpccodes.Internal, "failed to start export: %v", err)
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to start export")
+		return nil, err
+	}
+
+	span.SetAttributes(
+		attribute.String("export.id", j.ID),
+		attribute.Int("video.count", len(videos)),
+	)
+	span.SetStatus(codes.Ok, "export started")
+
+	return &media.CreateExportResponse{Export: exportToProto(j)}, nil
+}
+
+// selectExport returns the videos an export of userID contains: the given
+// IDs, which must all be viewable, or the listed videos matching the
+// filter.
+func (s *mediaServer) selectExport(userID string, req *media.CreateExportRequest) ([]exportedVideo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var videos []exportedVideo
+	if len(req.VideoIds) > 0 {
+		for _, videoID := range req.VideoIds {
+			info, ok := s.videos[videoID]
+			if !ok || !isViewable(info.Metadata, userID) {
+				return nil, status.Errorf(grpccodes.NotFound, "video %s not found", videoID)
+			}
+			videos = append(videos, s.exportedVideoLocked(videoID, info))
+		}
+		return videos, nil
+	}
+
+	if req.Filter == nil {
+		return nil, status.Error(grpccodes.InvalidArgument, "video IDs or a filter are required")
+	}
+	f := req.Filter
+	list := &media.ListVideosRequest{Uploader: f.Uploader, Since: f.Since, Tag: f.Tag}
+	for videoID, info := range s.videos {
+		if !isListed(info.Metadata, userID) || !matchesListFilter(info.Metadata, list) {
+			continue
+		}
+		if f.ChannelId != "" && info.Metadata.ChannelId != f.ChannelId {
+			continue
+		}
+		videos = append(videos, s.exportedVideoLocked(videoID, info))
+	}
+	if len(videos) == 0 {
+		return nil, status.Error(grpccodes.NotFound, "no videos match the filter")
+	}
+	if len(videos) > maxExportVideos {
+		return nil, status.Errorf(grpccodes.InvalidArgument, "%d videos match the filter, exports are limited to %d", len(videos), maxExportVideos)
+	}
+	slices.SortFunc(videos, func(a, b exportedVideo) int { return cmp.Compare(a.id, b.id) })
+	return videos, nil
+}
+
+// export returns the job building the artifact of an export of videos on
+// behalf of owner.
+func (s *mediaServer) export(owner *auth.Identity, format media.ExportFormat, videos []exportedVideo) jobs.Func {
+	return func(ctx context.Context) (*jobs.Result, error) {
+		name := fmt.Sprintf("coscup-videos-%s", time.Now().UTC().Format("20060102-150405"))
+		manifest := &media.ExportManifest{}
+		for _, v := range videos {
+			f := &media.ExportedFile{VideoId: v.id, Metadata: v.metadata}
+			if format == media.ExportFormat_EXPORT_FORMAT_MANIFEST {
+				u, expires, err := s.downloadURL(owner, v.id, s.downloadLinkTTL)
+				if err != nil {
+					return nil, fmt.Errorf("failed to sign the URL of %s: %w", v.id, err)
+				}
+				f.Url, f.UrlExpiresAt = u, expires.Unix()
+			} else {
+				f.Path = exportPath(v.id, v.metadata.FileName)
+			}
+			manifest.Videos = append(manifest.Videos, f)
+		}
+
+		index, err := protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(manifest)
+		if err != nil {
+			return nil, err
+		}
+		switch format {
+		case media.ExportFormat_EXPORT_FORMAT_MANIFEST:
+			return &jobs.Result{Name: name + ".json", ContentType: "application/json", Data: index}, nil
+		case media.ExportFormat_EXPORT_FORMAT_TAR:
+			data, err := buildTar(ctx, index, manifest, videos)
+			if err != nil {
+				return nil, err
+			}
+			return &jobs.Result{Name: name + ".tar", ContentType: "application/x-tar", Data: data}, nil
+		default:
+			data, err := buildZip(ctx, index, manifest, videos)
+			if err != nil {
+				return nil, err
+			}
+			return &jobs.Result{Name: name + ".zip", ContentType: "application/zip", Data: data}, nil
+		}
+	}
+}
+
+// buildZip stores videos.json and the video files at their paths in the
+// manifest, uncompressed since videos are compressed already.
+func buildZip(ctx context.Context, index []byte, manifest *media.ExportManifest, videos []exportedVideo) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	add := func(name string, modified time.Time, data []byte) error {
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Store, Modified: modified})
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	}
+	if err := writeArchive(ctx, add, index, manifest, videos); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func buildTar(ctx context.Context, index []byte, manifest *media.ExportManifest, videos []exportedVideo) ([]byte, error) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	add := func(name string, modified time.Time, data []byte) error {
+		err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(data)), ModTime: modified, Typeflag: tar.TypeReg})
+		if err != nil {
+			return err
+		}
+		_, err = tw.Write(data)
+		return err
+	}
+	if err := writeArchive(ctx, add, index, manifest, videos); err != nil {
+		return nil, err
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeArchive adds videos.json and then every video with add.
+func writeArchive(ctx context.Context, add func(name string, modified time.Time, data []byte) error, index []byte, manifest *media.ExportManifest, videos []exportedVideo) error {
+	if err := add("videos.json", time.Now(), index); err != nil {
+		return err
+	}
+	for i, f := range manifest.Videos {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		data, err := videos[i].read()
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", videos[i].id, err)
+		}
+		if err := add(f.Path, time.Unix(f.Metadata.UploadTimestamp, 0), data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exportPath is where a video file goes in an archive. Uploaded file names
+// are reduced to their base name so they cannot escape <video_id>/.
+func exportPath(videoID, fileName string) string {
+	name := path.Base(path.Clean("/" + fileName))
+	if name == "/" || name == "." {
+		name = "video"
+	}
+	return path.Join(path.Base(path.Clean("/"+videoID)), name)
+}
+
+// callerExport returns the export with the given ID if it belongs to the
+// caller of ctx.
+func (s *mediaServer) callerExport(ctx context.Context, exportID string) (jobs.Job, error) {
+	id, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		return jobs.Job{}, status.Error(grpccodes.Unauthenticated, "unknown caller")
+	}
+	if s.jobs == nil {
+		return jobs.Job{}, status.Error(grpccodes.NotFound, "export not found")
+	}
+	j, ok := s.jobs.Get(exportID)
+	if !ok || j.Owner != id.UserID || j.Kind != exportJobKind {
+		return jobs.Job{}, status.Error(grpccodes.NotFound, "export not found")
+	}
+	return j, nil
+}
+
+func (s *mediaServer) GetExport(ctx context.Context, req *media.GetExportRequest) (*media.GetExportResponse, error) {
+	_, span := s.tracer.Start(ctx, "GetExport")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("service.name", "media-service"),
+		attribute.String("rpc.method", "GetExport"),
+		attribute.String("rpc.service", "MediaService"),
+		attribute.String("export.id", req.ExportId),
+	)
+
+	j, err := s.callerExport(ctx, req.ExportId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "export not found")
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "export found")
+	return &media.GetExportResponse{Export: exportToProto(j)}, nil
+}
+
+func (s *mediaServer) DownloadExport(req *media.DownloadExportRequest, stream media.MediaService_DownloadExportServer) error {
+	_, span := s.tracer.Start(stream.Context(), "DownloadExport")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("service.name", "media-service"),
+		attribute.String("rpc.method", "DownloadExport"),
+		attribute.String("rpc.service", "MediaService"),
+		attribute.String("export.id", req.ExportId),
+	)
+
+	j, err := s.callerExport(stream.Context(), req.ExportId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "export not found")
+		return err
+	}
+	if j.State != jobs.Done {
+		err := status.Error(grpccodes.FailedPrecondition, "export is not ready")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "export not ready")
+		return err
+	}
+
+	data := j.Result.Data
+	first := &media.DownloadExportResponse{FileName: j.Result.Name, Size: int64(len(data)), ContentType: j.Result.ContentType}
+	if len(data) == 0 {
+		return stream.Send(first)
+	}
+	for i := 0; i < len(data); i += exportChunkSize {
+		resp := &media.DownloadExportResponse{}
+		if i == 0 {
+			resp = first
+		}
+		resp.Data = data[i:min(i+exportChunkSize, len(data))]
+		if err := stream.Send(resp); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "failed to send export")
+			return err
+		}
+	}
+
+	span.SetAttributes(attribute.Int("export.size_bytes", len(data)))
+	span.SetStatus(codes.Ok, "export sent")
+	return nil
+}
+
+func exportToProto(j jobs.Job) *media.Export {
+	e := &media.Export{
+		ExportId:  j.ID,
+		CreatedAt: j.Created.Unix(),
+		Error:     j.Err,
+	}
+	switch j.State {
+	case jobs.Pending:
+		e.State = media.ExportState_EXPORT_STATE_PENDING
+	case jobs.Running:
+		e.State = media.ExportState_EXPORT_STATE_RUNNING
+	case jobs.Done:
+		e.State = media.ExportState_EXPORT_STATE_READY
+		e.Size = int64(len(j.Result.Data))
+	case jobs.Failed:
+		e.State = media.ExportState_EXPORT_STATE_FAILED
+	}
+	if !j.Expires.IsZero() {
+		e.ExpiresAt = j.Expires.Unix()
+	}
+	return e
+}
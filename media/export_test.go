@@ -0,0 +1,107 @@
+package media_test
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	pbMedia "coscup2025/proto/media"
+)
+
+// exportArtifact creates an export, waits for it and downloads it.
+func exportArtifact(t *testing.T, client pbMedia.MediaServiceClient, ctx context.Context, req *pbMedia.CreateExportRequest) (*pbMedia.DownloadExportResponse, []byte) {
+	created, err := client.CreateExport(ctx, req)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		got, err := client.GetExport(ctx, &pbMedia.GetExportRequest{ExportId: created.Export.ExportId})
+		require.NoError(t, err)
+		require.NotEqual(t, pbMedia.ExportState_EXPORT_STATE_FAILED, got.Export.State, got.Export.Error)
+		return got.Export.State == pbMedia.ExportState_EXPORT_STATE_READY
+	}, 5*time.Second, 10*time.Millisecond)
+
+	stream, err := client.DownloadExport(ctx, &pbMedia.DownloadExportRequest{ExportId: created.Export.ExportId})
+	require.NoError(t, err)
+	var first *pbMedia.DownloadExportResponse
+	var data []byte
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		if first == nil {
+			first = resp
+		}
+		data = append(data, resp.Data...)
+	}
+	require.Equal(t, first.Size, int64(len(data)))
+	return first, data
+}
+
+func TestExport(t *testing.T) {
+	client, ctx := setupMediaClient(t)
+	uploadVideo(t, client, ctx, "keynote")
+	uploadVideo(t, client, ctx, "lightning")
+
+	_, err := client.CreateExport(ctx, &pbMedia.CreateExportRequest{VideoIds: []string{"keynote", "missing"}})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = client.CreateExport(ctx, &pbMedia.CreateExportRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Step 1: a zip of the given videos, in their order
+	first, data := exportArtifact(t, client, ctx, &pbMedia.CreateExportRequest{VideoIds: []string{"lightning", "keynote"}})
+	assert.Equal(t, "application/zip", first.ContentType)
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	assert.Equal(t, []string{"videos.json", "lightning/lightning", "keynote/keynote"}, names)
+	f, err := zr.Open("keynote/keynote")
+	require.NoError(t, err)
+	content, err := io.ReadAll(f)
+	require.NoError(t, err)
+	assert.Equal(t, "keynote", string(content))
+
+	// Step 2: a tar of the videos matching a filter, by ID
+	first, data = exportArtifact(t, client, ctx, &pbMedia.CreateExportRequest{
+		Filter: &pbMedia.ExportFilter{Uploader: "testuser"},
+		Format: pbMedia.ExportFormat_EXPORT_FORMAT_TAR,
+	})
+	assert.True(t, strings.HasSuffix(first.FileName, ".tar"), first.FileName)
+	tr := tar.NewReader(bytes.NewReader(data))
+	names = nil
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		names = append(names, h.Name)
+	}
+	assert.Equal(t, []string{"videos.json", "keynote/keynote", "lightning/lightning"}, names)
+
+	// Step 3: a manifest of signed URLs
+	_, data = exportArtifact(t, client, ctx, &pbMedia.CreateExportRequest{
+		VideoIds: []string{"keynote"},
+		Format:   pbMedia.ExportFormat_EXPORT_FORMAT_MANIFEST,
+	})
+	var manifest pbMedia.ExportManifest
+	require.NoError(t, protojson.Unmarshal(data, &manifest))
+	require.Len(t, manifest.Videos, 1)
+	assert.True(t, strings.HasPrefix(manifest.Videos[0].Url, "/v1/video/file/keynote?access_token="), manifest.Videos[0].Url)
+	assert.Greater(t, manifest.Videos[0].UrlExpiresAt, time.Now().Unix())
+	assert.Empty(t, manifest.Videos[0].Path)
+}
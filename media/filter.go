@@ -0,0 +1,38 @@
+package media
+
+import (
+	"cmp"
+	"coscup2025/proto/media"
+	"slices"
+)
+
+// matchesListFilter reports whether a video passes the optional filters of a
+// ListVideos request.
+func matchesListFilter(md *media.VideoMetadata, req *media.ListVideosRequest) bool {
+	if req.Uploader != "" && req.Uploader != md.UploaderId && req.Uploader != md.UploaderName {
+		return false
+	}
+	if req.Since > 0 && md.UploadTimestamp < req.Since {
+		return false
+	}
+	if req.Tag != "" && !slices.Contains(md.Tags, req.Tag) {
+		return false
+	}
+	return true
+}
+
+// timeKey is the sort key of listings that show the latest videos first. It
+// ends up in page tokens.
+type timeKey struct {
+	Time    int64  `json:"t"`
+	VideoID string `json:"v"`
+}
+
+// newestFirst orders timeKeys by descending time, and by video ID within
+// the same time.
+func newestFirst(a, b timeKey) int {
+	if c := cmp.Compare(b.Time, a.Time); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.VideoID, b.VideoID)
+}
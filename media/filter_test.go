@@ -0,0 +1,64 @@
+package media_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"coscup2025/env"
+	pbMedia "coscup2025/proto/media"
+	"coscup2025/servertest"
+)
+
+func uploadTagged(t *testing.T, client pbMedia.MediaServiceClient, ctx context.Context, videoID string, tags ...string) {
+	stream, err := client.UploadVideo(ctx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&pbMedia.UploadVideoRequest{VideoId: videoID, Data: []byte(videoID), Tags: tags}))
+	_, err = stream.CloseAndRecv()
+	require.NoError(t, err)
+}
+
+func TestListVideosFilters(t *testing.T) {
+	srv := servertest.New(t, env.DefaultConfig())
+	client := srv.Media()
+	alice := servertest.Context(srv.CreateUser(t, "alice", "alicepass"))
+	bob := servertest.Context(srv.CreateUser(t, "bob", "bobpass"))
+	since := time.Now().Unix()
+	uploadTagged(t, client, alice, "keynote", "main", "day1")
+	uploadTagged(t, client, alice, "workshop", "room-b")
+	uploadTagged(t, client, bob, "lightning", "main")
+
+	md, err := client.GetVideoMetadata(alice, &pbMedia.GetVideoMetadataRequest{VideoId: "keynote"})
+	require.NoError(t, err)
+	aliceID := md.Metadata.UploaderId
+
+	tests := []struct {
+		name string
+		req  *pbMedia.ListVideosRequest
+		want []string
+	}{
+		{"no filter", &pbMedia.ListVideosRequest{}, []string{"keynote", "workshop", "lightning"}},
+		{"uploader name", &pbMedia.ListVideosRequest{Uploader: "bob"}, []string{"lightning"}},
+		{"uploader ID", &pbMedia.ListVideosRequest{Uploader: aliceID}, []string{"keynote", "workshop"}},
+		{"unknown uploader", &pbMedia.ListVideosRequest{Uploader: "carol"}, nil},
+		{"since before the uploads", &pbMedia.ListVideosRequest{Since: since}, []string{"keynote", "workshop", "lightning"}},
+		{"since after the uploads", &pbMedia.ListVideosRequest{Since: since + 3600}, nil},
+		{"tag", &pbMedia.ListVideosRequest{Tag: "main"}, []string{"keynote", "lightning"}},
+		{"tag matches whole tags only", &pbMedia.ListVideosRequest{Tag: "room"}, nil},
+		{"uploader and tag", &pbMedia.ListVideosRequest{Uploader: "alice", Tag: "main"}, []string{"keynote"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := client.ListVideos(alice, tt.req)
+			require.NoError(t, err)
+			var got []string
+			for _, v := range resp.Videos {
+				got = append(got, v.VideoId)
+			}
+			assert.ElementsMatch(t, tt.want, got)
+		})
+	}
+}
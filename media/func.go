@@ -1,19 +1,30 @@
 package media
 
 import (
+	"cmp"
+	"context"
+	"coscup2025/auth"
+	"coscup2025/paging"
 	"coscup2025/proto/media"
+	"coscup2025/proto/notification"
+	"errors"
+	"fmt"
 	"io"
+	"net/url"
+	"slices"
+	"sort"
+	"syscall"
 	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 	grpccodes "google.golang.org/grpc/codes"
-	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
-func (s *mediaServer) UploadVideo(stream media.MediaService_UploadVideoServer) error {
+func (s *mediaServer) UploadVideo(stream media.MediaService_UploadVideoServer) (err error) {
 	_, span := s.tracer.Start(stream.Context(), "UploadVideo")
 	defer span.End()
 
@@ -21,6 +32,43 @@ func (s *mediaServer) UploadVideo(stream media.MediaService_UploadVideoServer) e
 	var totalBytes int64
 	var videoData []byte
 	var chunkCount int64
+	var expectedSize int64
+	var tags []string
+	var titles, descriptions map[string]string
+	var overwrite bool
+	var fileName string
+	var session *uploadSession
+	var resumedAt int64                  // bytes the session held before this stream
+	var held int64                       // admitted bytes of an upload without a session
+	var processing bool                  // every byte arrived and the video is being stored
+	caller := callerID(stream.Context()) // whom progress events go to
+	defer func() { s.releaseStreamBytes(held) }()
+	// A stream that ends without storing its video leaves nothing behind:
+	// data received without a session goes with the stream and its bytes
+	// are released above. A session keeps what it committed for a resume.
+	defer func() {
+		if err == nil {
+			return
+		}
+		uploadStreamsAborted.WithLabelValues(status.Code(err).String()).Inc()
+		if session == nil {
+			uploadDiscardedBytes.Add(float64(totalBytes))
+			span.SetAttributes(attribute.Int64("upload.discarded_bytes", totalBytes))
+		}
+		// Storing the video failed: the upload is gone, unless a resumed
+		// stream can still store its session.
+		if processing {
+			state := media.VideoState_VIDEO_STATE_FAILED
+			if session != nil {
+				state = media.VideoState_VIDEO_STATE_UPLOADING
+			}
+			s.progress.publish(caller, newProgressEvent(videoID, state, totalBytes, totalBytes))
+		}
+	}()
+	sums := newChecksums()
+	var stats chunkStats
+	start := time.Now()
+	lastChunk := start
 
 	span.SetAttributes(
 		attribute.String("service.name", "media-service"),
@@ -38,31 +86,105 @@ func (s *mediaServer) UploadVideo(stream media.MediaService_UploadVideoServer) e
 				return err
 			}
 
-			md, _ := metadata.FromIncomingContext(stream.Context())
+			if session != nil && s.uploadAborted(session) {
+				span.RecordError(errUploadAborted)
+				span.SetStatus(codes.Error, "upload aborted")
+				return errUploadAborted
+			}
+
+			if session != nil && session.totalSize > 0 && totalBytes != session.totalSize {
+				err := status.Errorf(grpccodes.FailedPrecondition, "upload incomplete: received %d of %d bytes", totalBytes, session.totalSize)
+				span.RecordError(err)
+				span.SetStatus(codes.Error, "upload incomplete")
+				return err
+			}
+
+			processing = true
+			s.progress.publish(caller, newProgressEvent(videoID, media.VideoState_VIDEO_STATE_PROCESSING, totalBytes, totalBytes))
+
 			uploaderID := "unknown"
 			uploaderName := "Unknown User"
+			var tenant string
 
-			if userIDs := md.Get("user-id"); len(userIDs) > 0 {
-				uploaderID = userIDs[0]
-			}
-			if userNames := md.Get("user-name"); len(userNames) > 0 {
-				uploaderName = userNames[0]
+			if id, ok := auth.IdentityFromContext(stream.Context()); ok {
+				uploaderID = id.UserID
+				uploaderName = id.Username
+				tenant = id.Tenant
 			}
 
 			metadata := &media.VideoMetadata{
 				UploaderId:      uploaderID,
 				UploaderName:    uploaderName,
 				UploadTimestamp: time.Now().Unix(),
-				FileName:        videoID,
+				FileName:        cmp.Or(fileName, videoID),
 				FileSize:        totalBytes,
+				Tags:            tags,
+				Titles:          titles,
+				Descriptions:    descriptions,
 			}
-
-			s.mu.Lock()
-			s.videos[videoID] = &VideoInfo{
+			sums.apply(metadata)
+			info := &VideoInfo{
 				Data:     videoData,
 				Metadata: metadata,
+				Tenant:   tenant,
+				segments: segmentTS(videoData, hlsTargetDuration),
+			}
+
+			// The stored record carries the version, so the video it
+			// replaces is looked up before, and again once it is written.
+			s.mu.RLock()
+			prev, versioned, err := s.replacedVideoLocked(videoID, uploaderID, overwrite)
+			s.mu.RUnlock()
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, "video ID is taken")
+				return err
+			}
+			info.succeed(prev, versioned)
+
+			rec, err := s.putVideo(videoData, metadata, tenant)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, "failed to store video")
+				return s.storageWriteError(err, session != nil)
+			}
+
+			s.mu.Lock()
+			if session != nil && session.aborted {
+				s.mu.Unlock()
+				s.discardVideo(rec)
+				span.RecordError(errUploadAborted)
+				span.SetStatus(codes.Error, "upload aborted")
+				return errUploadAborted
+			}
+			if s.videos[videoID] != prev {
+				s.mu.Unlock()
+				s.discardVideo(rec)
+				err := status.Error(grpccodes.Aborted, "the video was replaced during the upload, retry")
+				span.RecordError(err)
+				span.SetStatus(codes.Error, "video replaced")
+				return err
+			}
+			// Likes and takedowns may have changed meanwhile.
+			info.succeed(prev, versioned)
+			if err := s.commitVideoLocked(videoID, rec); err != nil {
+				s.mu.Unlock()
+				s.discardVideo(rec)
+				span.RecordError(err)
+				span.SetStatus(codes.Error, "failed to store video")
+				return s.storageWriteError(err, session != nil)
+			}
+			s.offloadLocked(info, rec)
+			s.videos[videoID] = info
+			if session != nil {
+				s.dropUploadSessionLocked(session.id)
 			}
 			s.mu.Unlock()
+			s.storageRecovered()
+
+			s.progress.publish(caller, newProgressEvent(videoID, media.VideoState_VIDEO_STATE_READY, totalBytes, totalBytes))
+			s.notifyCaller(stream.Context(), notification.NotificationKind_NOTIFICATION_KIND_UPLOAD_FINISHED, videoID,
+				fmt.Sprintf("Upload of %s finished (%d bytes)", videoID, totalBytes))
 
 			span.SetAttributes(
 				attribute.String("video.id", videoID),
@@ -70,6 +192,7 @@ func (s *mediaServer) UploadVideo(stream media.MediaService_UploadVideoServer) e
 				attribute.Int64("video.chunk_count", chunkCount),
 				attribute.String("operation.status", "success"),
 			)
+			span.SetAttributes(stats.attributes()...)
 
 			span.AddEvent("video_upload_completed", trace.WithAttributes(
 				attribute.String("video.id", videoID),
@@ -78,27 +201,100 @@ func (s *mediaServer) UploadVideo(stream media.MediaService_UploadVideoServer) e
 
 			span.SetStatus(codes.Ok, "upload completed successfully")
 
+			summary := stats.proto(start, totalBytes-resumedAt)
+			if session != nil {
+				summary.Attempts = session.attempts
+				summary.RetransmittedBytes = max(session.received-totalBytes, 0)
+			}
 			return stream.SendAndClose(&media.UploadVideoResponse{
 				VideoId:    videoID,
 				TotalBytes: totalBytes,
 				Metadata:   metadata,
+				Stats:      summary,
 			})
 		}
 		if err != nil {
+			// Resumable sessions keep their data, so the upload is only paused.
+			if videoID != "" && session == nil {
+				s.progress.publish(caller, newProgressEvent(videoID, media.VideoState_VIDEO_STATE_FAILED, totalBytes, expectedSize))
+				s.notifyCaller(stream.Context(), notification.NotificationKind_NOTIFICATION_KIND_UPLOAD_FAILED, videoID,
+					fmt.Sprintf("Upload of %s failed after %d bytes", videoID, totalBytes))
+			}
+			// The client cancelled or its deadline passed: report that
+			// rather than a server error.
+			if ctxErr := stream.Context().Err(); ctxErr != nil {
+				err := status.FromContextError(ctxErr).Err()
+				span.RecordError(err)
+				span.SetStatus(codes.Error, "upload cancelled")
+				span.SetAttributes(attribute.String("error.type", "upload_cancelled"))
+				return err
+			}
+			// The chunk broke the rules of UploadVideoRequest, or kept
+			// arriving corrupted, see UploadChunks.
+			if code := status.Code(err); code == grpccodes.InvalidArgument || code == grpccodes.DataLoss {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, "invalid chunk")
+				span.SetAttributes(attribute.String("error.type", "invalid_chunk"))
+				return err
+			}
 			span.RecordError(err)
 			span.SetStatus(codes.Error, "failed to receive chunk")
 			span.SetAttributes(attribute.String("error.type", "stream_receive_error"))
 			return status.Errorf(grpccodes.Internal, "failed to receive chunk: %v", err)
 		}
 
-		if videoID == "" {
-			if req.VideoId == "" {
-				err := status.Error(grpccodes.InvalidArgument, "video ID is required")
+		// A heartbeat only keeps the stream from being aborted as stalled
+		// while the client waits for input.
+		if req.Heartbeat {
+			if len(req.Data) > 0 {
+				err := status.Error(grpccodes.InvalidArgument, "a heartbeat carries no data")
 				span.RecordError(err)
-				span.SetStatus(codes.Error, "video ID is required")
+				span.SetStatus(codes.Error, "invalid chunk")
 				return err
 			}
+			continue
+		}
+
+		if videoID == "" {
 			videoID = req.VideoId
+			expectedSize = req.TotalSize
+			tags = req.Tags
+			overwrite = req.Overwrite
+			fileName = req.FileName
+			titles, descriptions, err = normalizeTexts(req.Titles, req.Descriptions)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, "invalid language tag")
+				return err
+			}
+
+			if req.UploadId != "" {
+				session, err = s.claimUploadSession(stream.Context(), req.UploadId, req.VideoId)
+				if err != nil {
+					span.RecordError(err)
+					span.SetStatus(codes.Error, "invalid upload session")
+					return err
+				}
+				defer s.releaseUploadSession(session)
+
+				videoData = session.data
+				sums = session.sums
+				totalBytes = int64(len(session.data))
+				resumedAt = totalBytes
+				expectedSize = session.totalSize
+				tags = session.tags
+				titles, descriptions = session.titles, session.descriptions
+				overwrite = session.overwrite
+				fileName = session.fileName
+				span.SetAttributes(
+					attribute.String("upload.id", session.id),
+					attribute.Int64("upload.resumed_at", totalBytes),
+				)
+			} else if err := s.checkDuplicate(videoID, callerID(stream.Context()), overwrite); err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, "video ID is taken")
+				return err
+			}
 			span.SetAttributes(
 				attribute.String("video.id", videoID),
 				attribute.String("operation.phase", "receiving_chunks"),
@@ -109,6 +305,9 @@ func (s *mediaServer) UploadVideo(stream media.MediaService_UploadVideoServer) e
 		}
 
 		if req.VideoId != videoID {
+			s.progress.publish(caller, newProgressEvent(videoID, media.VideoState_VIDEO_STATE_FAILED, totalBytes, expectedSize))
+			s.notifyCaller(stream.Context(), notification.NotificationKind_NOTIFICATION_KIND_UPLOAD_FAILED, videoID,
+				fmt.Sprintf("Upload of %s failed: inconsistent video ID", videoID))
 			err := status.Error(grpccodes.InvalidArgument, "inconsistent video ID")
 			span.RecordError(err)
 			span.SetStatus(codes.Error, "inconsistent video ID")
@@ -120,16 +319,93 @@ func (s *mediaServer) UploadVideo(stream media.MediaService_UploadVideoServer) e
 			return err
 		}
 
+		if session != nil && req.Offset != totalBytes {
+			err := status.Errorf(grpccodes.FailedPrecondition, "unexpected offset %d, expected %d", req.Offset, totalBytes)
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "unexpected offset")
+			return err
+		}
+
+		// Checked per chunk, so an oversized upload is cut off before its
+		// excess is received.
+		if s.exceedsMaxSize(max(totalBytes+int64(len(req.Data)), expectedSize)) {
+			if session == nil {
+				s.progress.publish(caller, newProgressEvent(videoID, media.VideoState_VIDEO_STATE_FAILED, totalBytes, expectedSize))
+				s.notifyCaller(stream.Context(), notification.NotificationKind_NOTIFICATION_KIND_UPLOAD_FAILED, videoID,
+					fmt.Sprintf("Upload of %s failed: larger than the maximum of %d bytes", videoID, s.maxVideoSize))
+			}
+			err := videoTooLargeError(s.maxVideoSize)
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "video too large")
+			span.SetAttributes(attribute.String("error.type", "video_too_large"))
+			return err
+		}
+
+		// Without a session nothing is stored before the stream ends, so
+		// everything received so far is in flight.
+		if session == nil && s.uploadMaxInFlight > 0 && totalBytes+int64(len(req.Data)) > s.uploadMaxInFlight {
+			s.progress.publish(caller, newProgressEvent(videoID, media.VideoState_VIDEO_STATE_FAILED, totalBytes, expectedSize))
+			s.notifyCaller(stream.Context(), notification.NotificationKind_NOTIFICATION_KIND_UPLOAD_FAILED, videoID,
+				fmt.Sprintf("Upload of %s failed: larger than %d bytes without an upload session", videoID, s.uploadMaxInFlight))
+			err := status.Errorf(grpccodes.ResourceExhausted, "uploads without a session are limited to %d bytes, create an upload session for larger videos", s.uploadMaxInFlight)
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "too many bytes in flight")
+			span.SetAttributes(attribute.String("error.type", "upload_in_flight_exceeded"))
+			return err
+		}
+
+		if err := s.admitChunk(session, int64(len(req.Data)), &held); err != nil {
+			if session == nil {
+				s.progress.publish(caller, newProgressEvent(videoID, media.VideoState_VIDEO_STATE_FAILED, totalBytes, expectedSize))
+				s.notifyCaller(stream.Context(), notification.NotificationKind_NOTIFICATION_KIND_UPLOAD_FAILED, videoID,
+					fmt.Sprintf("Upload of %s failed: the server is out of upload capacity", videoID))
+			}
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "memory budget exceeded")
+			span.SetAttributes(attribute.String("error.type", "memory_budget_exceeded"))
+			return err
+		}
+
+		sums.Write(req.Data)
 		videoData = append(videoData, req.Data...)
 		totalBytes += int64(len(req.Data))
 		chunkCount++
 
-		span.AddEvent("chunk_received", trace.WithAttributes(
-			attribute.Int64("chunk.size_bytes", int64(len(req.Data))),
-			attribute.Int64("chunk.sequence", req.Sequence),
-			attribute.Int64("chunk.number", chunkCount),
-			attribute.Int64("total_bytes_received", totalBytes),
-		))
+		if session != nil {
+			session.received += int64(len(req.Data))
+			if err := s.commitUploadSession(session, videoData); err == errUploadAborted {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, "upload aborted")
+				return err
+			} else if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, "failed to persist upload session")
+				// The session keeps its committed bytes, so the client can
+				// retry from there.
+				return s.storageWriteError(err, true)
+			}
+			s.storageRecovered()
+			if session.streamDeadline > 0 && time.Since(start) > session.streamDeadline {
+				err := streamDeadlineError(session)
+				span.RecordError(err)
+				span.SetStatus(codes.Error, "stream deadline exceeded")
+				return err
+			}
+		}
+
+		s.progress.publish(caller, newProgressEvent(videoID, media.VideoState_VIDEO_STATE_UPLOADING, totalBytes, expectedSize))
+
+		if stats.sampled(s.chunkEventInterval) {
+			span.AddEvent("chunk_received", trace.WithAttributes(
+				attribute.Int64("chunk.size_bytes", int64(len(req.Data))),
+				attribute.Int64("chunk.sequence", req.Sequence),
+				attribute.Int64("chunk.number", chunkCount),
+				attribute.Int64("total_bytes_received", totalBytes),
+			))
+		}
+		now := time.Now()
+		stats.observe(now.Sub(lastChunk))
+		lastChunk = now
 	}
 }
 
@@ -144,14 +420,25 @@ func (s *mediaServer) DownloadVideo(req *media.DownloadVideoRequest, stream medi
 		attribute.String("video.id", req.VideoId),
 	)
 
+	start := time.Now()
 	span.AddEvent("video_download_started", trace.WithAttributes(
 		attribute.String("video.id", req.VideoId),
 	))
 
-	s.mu.RLock()
-	videoInfo, exists := s.videos[req.VideoId]
-	s.mu.RUnlock()
+	if id, ok := auth.IdentityFromContext(stream.Context()); ok && id.VideoID != "" && id.VideoID != req.VideoId {
+		err := status.Error(grpccodes.PermissionDenied, "token is limited to another video")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "permission denied")
+		return err
+	}
 
+	// The stream reads a snapshot, so a concurrent DeleteVideo does not cut it
+	// short.
+	videoInfo, exists := s.snapshotVideo(req.VideoId)
+	defer videoInfo.close()
+	if exists && !isViewable(videoInfo.metadata, callerID(stream.Context())) {
+		exists = false
+	}
 	if !exists {
 		err := status.Error(grpccodes.NotFound, "video not found")
 		span.RecordError(err)
@@ -163,7 +450,8 @@ func (s *mediaServer) DownloadVideo(req *media.DownloadVideoRequest, stream medi
 		return err
 	}
 
-	if len(videoInfo.Data) == 0 {
+	videoSize := videoInfo.content.Size()
+	if videoSize == 0 {
 		err := status.Error(grpccodes.FailedPrecondition, "no download source available for this video")
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "no download source available")
@@ -174,35 +462,70 @@ func (s *mediaServer) DownloadVideo(req *media.DownloadVideoRequest, stream medi
 		return err
 	}
 
-	videoData := videoInfo.Data
-	videoSize := int64(len(videoData))
-	chunkSize := 1024 * 1024
-	totalChunks := int64((len(videoData) + chunkSize - 1) / chunkSize)
+	// An offset at the end of the video is allowed and sends only the
+	// metadata, so a client resuming a complete download still gets it.
+	if req.Offset < 0 || req.Offset > videoSize || req.Length < 0 {
+		err := status.Errorf(grpccodes.OutOfRange, "range %d+%d is outside the video of %d bytes", req.Offset, req.Length, videoSize)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid range")
+		return err
+	}
+	end := videoSize
+	if req.Length > 0 {
+		end = min(req.Offset+req.Length, videoSize)
+	}
+
+	done, err := s.startDownload(callerID(stream.Context()))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "too many concurrent downloads")
+		return err
+	}
+	defer done()
+
+	chunkSize := int64(1024 * 1024)
+	totalChunks := max((end-req.Offset+chunkSize-1)/chunkSize, 1)
 
 	span.SetAttributes(
 		attribute.Int64("video.size_bytes", videoSize),
-		attribute.Int64("video.chunk_size", int64(chunkSize)),
+		attribute.Int64("video.chunk_size", chunkSize),
 		attribute.Int64("video.total_chunks", totalChunks),
+		attribute.Int64("download.offset", req.Offset),
+		attribute.Int64("download.end", end),
+		attribute.String("download.priority", req.Priority.String()),
 		attribute.String("operation.phase", "sending_chunks"),
 	)
 
 	var chunksSent int64
-	for i := 0; i < len(videoData); i += chunkSize {
-		end := min(i+chunkSize, len(videoData))
-
-		chunkSequence := int64(i/chunkSize + 1)
+	var stats chunkStats
+	for chunkSequence := int64(1); chunkSequence <= totalChunks; chunkSequence++ {
+		i := req.Offset + (chunkSequence-1)*chunkSize
+		data, err := readRange(videoInfo.content, i, min(chunkSize, end-i))
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "failed to read video")
+			return status.Errorf(grpccodes.Internal, "failed to read video: %v", err)
+		}
 
 		response := &media.DownloadVideoResponse{
 			VideoId:  req.VideoId,
-			Data:     videoData[i:end],
+			Data:     data,
 			Sequence: chunkSequence,
+			Offset:   i,
 		}
 
 		if chunkSequence == 1 {
-			response.Metadata = videoInfo.Metadata
+			response.Metadata = videoInfo.metadata
+		}
+
+		if err := s.bandwidth.wait(stream.Context(), req.Priority, len(data)); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "download canceled while waiting for bandwidth")
+			return status.FromContextError(err).Err()
 		}
 
-		err := stream.Send(response)
+		sendStart := time.Now()
+		err = stream.Send(response)
 		if err != nil {
 			span.RecordError(err)
 			span.SetStatus(codes.Error, "failed to send chunk")
@@ -214,17 +537,34 @@ func (s *mediaServer) DownloadVideo(req *media.DownloadVideoRequest, stream medi
 		}
 
 		chunksSent++
-		span.AddEvent("chunk_sent", trace.WithAttributes(
-			attribute.Int64("chunk.size_bytes", int64(end-i)),
-			attribute.Int64("chunk.sequence", chunkSequence),
-			attribute.Int64("chunks_sent", chunksSent),
-			attribute.Int64("bytes_sent", int64(end)),
-		))
+		if stats.sampled(s.chunkEventInterval) {
+			span.AddEvent("chunk_sent", trace.WithAttributes(
+				attribute.Int64("chunk.size_bytes", int64(len(data))),
+				attribute.Int64("chunk.sequence", chunkSequence),
+				attribute.Int64("chunks_sent", chunksSent),
+				attribute.Int64("bytes_sent", i+int64(len(data))-req.Offset),
+			))
+		}
+		stats.observe(time.Since(sendStart))
+	}
+
+	// Clients that only look at data skip this message.
+	err = stream.Send(&media.DownloadVideoResponse{
+		VideoId:  req.VideoId,
+		Sequence: totalChunks + 1,
+		Offset:   end,
+		Stats:    stats.proto(start, end-req.Offset),
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to send summary")
+		span.SetAttributes(attribute.String("error.type", "stream_send_error"))
+		return status.Errorf(grpccodes.Internal, "failed to send summary: %v", err)
 	}
 
 	span.AddEvent("video_download_completed", trace.WithAttributes(
 		attribute.String("video.id", req.VideoId),
-		attribute.Int64("total_bytes_sent", videoSize),
+		attribute.Int64("total_bytes_sent", end-req.Offset),
 		attribute.Int64("total_chunks_sent", chunksSent),
 	))
 
@@ -232,8 +572,1327 @@ func (s *mediaServer) DownloadVideo(req *media.DownloadVideoRequest, stream medi
 		attribute.String("operation.status", "success"),
 		attribute.Int64("final_chunks_sent", chunksSent),
 	)
+	span.SetAttributes(stats.attributes()...)
 
 	span.SetStatus(codes.Ok, "download completed successfully")
 
 	return nil
 }
+
+func (s *mediaServer) WatchProgress(req *media.WatchProgressRequest, stream media.MediaService_WatchProgressServer) error {
+	ctx, span := s.tracer.Start(stream.Context(), "WatchProgress")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("service.name", "media-service"),
+		attribute.String("rpc.method", "WatchProgress"),
+		attribute.String("rpc.service", "MediaService"),
+		attribute.String("video.id", req.VideoId),
+	)
+
+	// Only the uploader sees the progress of an upload, and a stored video
+	// is watched like it is fetched.
+	userID := callerID(ctx)
+	events, current, unsubscribe := s.progress.subscribe(req.VideoId, userID)
+	defer unsubscribe()
+
+	// A video that finished before the subscription gets a single final
+	// event, unless the caller is uploading it again.
+	videoInfo, exists := s.snapshotVideo(req.VideoId)
+	if exists && current == nil && !isViewable(videoInfo.metadata, userID) {
+		err := status.Error(grpccodes.NotFound, "video not found")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "video not found")
+		return err
+	}
+	if exists && current == nil {
+		size := videoInfo.metadata.FileSize
+		span.SetStatus(codes.Ok, "video already uploaded")
+		return stream.Send(newProgressEvent(req.VideoId, media.VideoState_VIDEO_STATE_READY, size, size))
+	}
+
+	// While nothing happens, heartbeats repeat the last event.
+	last := newProgressEvent(req.VideoId, media.VideoState_VIDEO_STATE_UNSPECIFIED, 0, 0)
+	if current != nil {
+		last = current
+		if err := stream.Send(current); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "failed to send progress event")
+			return status.Errorf(grpccodes.Internal, "failed to send progress event: %v", err)
+		}
+	}
+	var idle *time.Timer
+	var heartbeat <-chan time.Time
+	if s.heartbeatInterval > 0 {
+		idle = time.NewTimer(s.heartbeatInterval)
+		defer idle.Stop()
+		heartbeat = idle.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-heartbeat:
+			ev := proto.Clone(last).(*media.ProgressEvent)
+			ev.Heartbeat = true
+			ev.Timestamp = time.Now().Unix()
+			if err := stream.Send(ev); err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, "failed to send heartbeat")
+				return status.Errorf(grpccodes.Internal, "failed to send heartbeat: %v", err)
+			}
+			idle.Reset(s.heartbeatInterval)
+		case ev := <-events:
+			last = ev
+			if idle != nil {
+				idle.Reset(s.heartbeatInterval)
+			}
+			if err := stream.Send(ev); err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, "failed to send progress event")
+				return status.Errorf(grpccodes.Internal, "failed to send progress event: %v", err)
+			}
+			if isTerminalState(ev.State) {
+				span.SetStatus(codes.Ok, "video reached a terminal state")
+				return nil
+			}
+		}
+	}
+}
+
+func (s *mediaServer) ListVideos(ctx context.Context, req *media.ListVideosRequest) (*media.ListVideosResponse, error) {
+	_, span := s.tracer.Start(ctx, "ListVideos")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("service.name", "media-service"),
+		attribute.String("rpc.method", "ListVideos"),
+		attribute.String("rpc.service", "MediaService"),
+	)
+
+	userID := callerID(ctx)
+	l := s.localizer(ctx, req.Locale)
+
+	s.mu.RLock()
+	videos := make([]*media.VideoSummary, 0, len(s.videos))
+	for videoID, videoInfo := range s.videos {
+		if !isListed(videoInfo.Metadata, userID) || !matchesListFilter(videoInfo.Metadata, req) {
+			continue
+		}
+		videos = append(videos, &media.VideoSummary{
+			VideoId:  videoID,
+			Metadata: videoInfo.Metadata,
+		})
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(videos, func(i, j int) bool {
+		return videos[i].VideoId < videos[j].VideoId
+	})
+
+	videos, next, err := paging.Page(s.pager, req, videos,
+		func(v *media.VideoSummary) string { return v.VideoId }, cmp.Compare[string],
+		req.Uploader, req.Since, req.Tag)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid page token")
+		return nil, err
+	}
+
+	locales := make([]*media.VideoMetadata, len(videos))
+	for i, v := range videos {
+		v.Metadata = l.localize(v.Metadata)
+		locales[i] = v.Metadata
+	}
+	setContentLanguage(ctx, locales...)
+
+	span.SetAttributes(attribute.Int("video.count", len(videos)))
+	span.SetStatus(codes.Ok, "videos listed")
+
+	return &media.ListVideosResponse{Videos: videos, NextPageToken: next}, nil
+}
+
+func (s *mediaServer) GetVideoMetadata(ctx context.Context, req *media.GetVideoMetadataRequest) (*media.GetVideoMetadataResponse, error) {
+	_, span := s.tracer.Start(ctx, "GetVideoMetadata")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("service.name", "media-service"),
+		attribute.String("rpc.method", "GetVideoMetadata"),
+		attribute.String("rpc.service", "MediaService"),
+		attribute.String("video.id", req.VideoId),
+	)
+
+	videoInfo, exists := s.snapshotVideo(req.VideoId)
+	if !exists || !isViewable(videoInfo.metadata, callerID(ctx)) {
+		err := status.Error(grpccodes.NotFound, "video not found")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "video not found")
+		return nil, err
+	}
+
+	metadata := s.localizer(ctx, req.Locale).localize(videoInfo.metadata)
+	setContentLanguage(ctx, metadata)
+
+	span.SetStatus(codes.Ok, "metadata returned")
+
+	return &media.GetVideoMetadataResponse{
+		VideoId:  req.VideoId,
+		Metadata: metadata,
+	}, nil
+}
+
+func (s *mediaServer) DeleteVideo(ctx context.Context, req *media.DeleteVideoRequest) (*media.DeleteVideoResponse, error) {
+	_, span := s.tracer.Start(ctx, "DeleteVideo")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("service.name", "media-service"),
+		attribute.String("rpc.method", "DeleteVideo"),
+		attribute.String("rpc.service", "MediaService"),
+		attribute.String("video.id", req.VideoId),
+	)
+
+	id, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		err := status.Error(grpccodes.Unauthenticated, "unknown caller")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "unknown caller")
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	videoInfo, exists := s.videos[req.VideoId]
+	if !exists {
+		err := status.Error(grpccodes.NotFound, "video not found")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "video not found")
+		return nil, err
+	}
+
+	if videoInfo.Metadata.UploaderId != id.UserID {
+		err := status.Error(grpccodes.PermissionDenied, "only the uploader may delete this video")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "permission denied")
+		return nil, err
+	}
+
+	s.removeVideoLocked(req.VideoId)
+
+	span.SetStatus(codes.Ok, "video deleted")
+
+	return &media.DeleteVideoResponse{VideoId: req.VideoId}, nil
+}
+
+func (s *mediaServer) CreateUploadSession(ctx context.Context, req *media.CreateUploadSessionRequest) (*media.CreateUploadSessionResponse, error) {
+	_, span := s.tracer.Start(ctx, "CreateUploadSession")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("service.name", "media-service"),
+		attribute.String("rpc.method", "CreateUploadSession"),
+		attribute.String("rpc.service", "MediaService"),
+		attribute.String("video.id", req.VideoId),
+	)
+
+	if s.exceedsMaxSize(req.TotalSize) {
+		err := videoTooLargeError(s.maxVideoSize)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "video too large")
+		return nil, err
+	}
+
+	titles, descriptions, err := normalizeTexts(req.Titles, req.Descriptions)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid language tag")
+		return nil, err
+	}
+
+	id, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		err := status.Error(grpccodes.Unauthenticated, "unknown caller")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "unknown caller")
+		return nil, err
+	}
+
+	if err := s.checkDuplicate(req.VideoId, id.UserID, req.Overwrite); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "video ID is taken")
+		return nil, err
+	}
+
+	now := time.Now()
+	session := &uploadSession{
+		id:             newUploadID(),
+		videoID:        req.VideoId,
+		ownerID:        id.UserID,
+		totalSize:      req.TotalSize,
+		tags:           req.Tags,
+		titles:         titles,
+		descriptions:   descriptions,
+		overwrite:      req.Overwrite,
+		fileName:       req.FileName,
+		sums:           newChecksums(),
+		expiresAt:      now.Add(uploadSessionTTL),
+		streamDeadline: s.grantStreamDeadline(req.StreamDeadlineSeconds),
+	}
+
+	if err := s.sessionStore.create(session); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to persist upload session")
+		if errors.Is(err, syscall.ENOSPC) {
+			return nil, status.Error(grpccodes.ResourceExhausted, "not enough disk space for the upload")
+		}
+		return nil, status.Errorf(grpccodes.Internal, "failed to create upload session: %v", err)
+	}
+
+	s.mu.Lock()
+	s.pruneUploadSessions(now)
+	// The announced size is admitted up front, so an accepted session is not
+	// turned away halfway through.
+	if err := s.admitLocked(req.TotalSize); err != nil {
+		s.sessionStore.remove(session.id)
+		s.mu.Unlock()
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "memory budget exceeded")
+		return nil, err
+	}
+	s.sessions[session.id] = session
+	resp := &media.CreateUploadSessionResponse{Session: session.proto(s.maxVideoSize)}
+	s.mu.Unlock()
+
+	span.SetAttributes(attribute.String("upload.id", session.id))
+	span.SetStatus(codes.Ok, "upload session created")
+
+	return resp, nil
+}
+
+func (s *mediaServer) AbortUpload(ctx context.Context, req *media.AbortUploadRequest) (*media.AbortUploadResponse, error) {
+	_, span := s.tracer.Start(ctx, "AbortUpload")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("service.name", "media-service"),
+		attribute.String("rpc.method", "AbortUpload"),
+		attribute.String("rpc.service", "MediaService"),
+		attribute.String("upload.id", req.UploadId),
+	)
+
+	session, discarded, err := s.abortUploadSession(ctx, req.UploadId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "upload session not available")
+		return nil, err
+	}
+	uploadDiscardedBytes.Add(float64(discarded))
+
+	s.progress.publish(session.ownerID, newProgressEvent(session.videoID, media.VideoState_VIDEO_STATE_FAILED, discarded, session.totalSize))
+
+	span.SetAttributes(
+		attribute.String("video.id", session.videoID),
+		attribute.Int64("upload.discarded_bytes", discarded),
+	)
+	span.SetStatus(codes.Ok, "upload aborted")
+
+	return &media.AbortUploadResponse{UploadId: session.id, DiscardedBytes: discarded}, nil
+}
+
+func (s *mediaServer) GetUploadSession(ctx context.Context, req *media.GetUploadSessionRequest) (*media.GetUploadSessionResponse, error) {
+	_, span := s.tracer.Start(ctx, "GetUploadSession")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("service.name", "media-service"),
+		attribute.String("rpc.method", "GetUploadSession"),
+		attribute.String("rpc.service", "MediaService"),
+		attribute.String("upload.id", req.UploadId),
+	)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	session, err := s.lookupUploadSession(ctx, req.UploadId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "upload session not available")
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "upload session returned")
+
+	return &media.GetUploadSessionResponse{Session: session.proto(s.maxVideoSize)}, nil
+}
+
+func (s *mediaServer) CreatePlaylist(ctx context.Context, req *media.CreatePlaylistRequest) (*media.CreatePlaylistResponse, error) {
+	_, span := s.tracer.Start(ctx, "CreatePlaylist")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("service.name", "media-service"),
+		attribute.String("rpc.method", "CreatePlaylist"),
+		attribute.String("rpc.service", "MediaService"),
+	)
+
+	id, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		err := status.Error(grpccodes.Unauthenticated, "unknown caller")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "unknown caller")
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkPlaylistVideos(req.VideoIds); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid videos")
+		return nil, err
+	}
+
+	now := time.Now()
+	p := &playlist{
+		id:          newPlaylistID(),
+		title:       req.Title,
+		description: req.Description,
+		ownerID:     id.UserID,
+		videoIDs:    append([]string(nil), req.VideoIds...),
+		createdAt:   now,
+		updatedAt:   now,
+	}
+	s.playlists[p.id] = p
+
+	span.SetAttributes(attribute.String("playlist.id", p.id))
+	span.SetStatus(codes.Ok, "playlist created")
+
+	return &media.CreatePlaylistResponse{Playlist: s.playlistProto(p)}, nil
+}
+
+func (s *mediaServer) AddToPlaylist(ctx context.Context, req *media.AddToPlaylistRequest) (*media.AddToPlaylistResponse, error) {
+	_, span := s.tracer.Start(ctx, "AddToPlaylist")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("service.name", "media-service"),
+		attribute.String("rpc.method", "AddToPlaylist"),
+		attribute.String("rpc.service", "MediaService"),
+		attribute.String("playlist.id", req.PlaylistId),
+		attribute.String("video.id", req.VideoId),
+	)
+
+	id, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		err := status.Error(grpccodes.Unauthenticated, "unknown caller")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "unknown caller")
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, err := s.lookupOwnPlaylist(req.PlaylistId, id.UserID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "playlist not available")
+		return nil, err
+	}
+
+	if err := s.checkPlaylistVideos([]string{req.VideoId}); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid video")
+		return nil, err
+	}
+	if slices.Contains(p.videoIDs, req.VideoId) {
+		err := status.Error(grpccodes.AlreadyExists, "video is already in the playlist")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "video already in playlist")
+		return nil, err
+	}
+
+	videoIDs, err := insertAt(p.videoIDs, req.VideoId, req.Position)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid position")
+		return nil, err
+	}
+	p.videoIDs = videoIDs
+	p.updatedAt = time.Now()
+
+	span.SetStatus(codes.Ok, "video added to playlist")
+
+	return &media.AddToPlaylistResponse{Playlist: s.playlistProto(p)}, nil
+}
+
+func (s *mediaServer) ReorderPlaylist(ctx context.Context, req *media.ReorderPlaylistRequest) (*media.ReorderPlaylistResponse, error) {
+	_, span := s.tracer.Start(ctx, "ReorderPlaylist")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("service.name", "media-service"),
+		attribute.String("rpc.method", "ReorderPlaylist"),
+		attribute.String("rpc.service", "MediaService"),
+		attribute.String("playlist.id", req.PlaylistId),
+	)
+
+	id, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		err := status.Error(grpccodes.Unauthenticated, "unknown caller")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "unknown caller")
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, err := s.lookupOwnPlaylist(req.PlaylistId, id.UserID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "playlist not available")
+		return nil, err
+	}
+
+	// The new order must be a permutation of the current videos.
+	current := make(map[string]bool, len(p.videoIDs))
+	for _, videoID := range p.videoIDs {
+		current[videoID] = true
+	}
+	for _, videoID := range req.VideoIds {
+		if !current[videoID] {
+			break
+		}
+		delete(current, videoID)
+	}
+	if len(req.VideoIds) != len(p.videoIDs) || len(current) != 0 {
+		err := status.Error(grpccodes.InvalidArgument, "video IDs must list every video of the playlist exactly once")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid order")
+		return nil, err
+	}
+
+	p.videoIDs = append([]string(nil), req.VideoIds...)
+	p.updatedAt = time.Now()
+
+	span.SetStatus(codes.Ok, "playlist reordered")
+
+	return &media.ReorderPlaylistResponse{Playlist: s.playlistProto(p)}, nil
+}
+
+func (s *mediaServer) GetPlaylist(ctx context.Context, req *media.GetPlaylistRequest) (*media.GetPlaylistResponse, error) {
+	_, span := s.tracer.Start(ctx, "GetPlaylist")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("service.name", "media-service"),
+		attribute.String("rpc.method", "GetPlaylist"),
+		attribute.String("rpc.service", "MediaService"),
+		attribute.String("playlist.id", req.PlaylistId),
+	)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	p, ok := s.playlists[req.PlaylistId]
+	if !ok {
+		err := status.Error(grpccodes.NotFound, "playlist not found")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "playlist not found")
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "playlist returned")
+
+	return &media.GetPlaylistResponse{Playlist: s.playlistProto(p)}, nil
+}
+
+func (s *mediaServer) CreateChannel(ctx context.Context, req *media.CreateChannelRequest) (*media.CreateChannelResponse, error) {
+	_, span := s.tracer.Start(ctx, "CreateChannel")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("service.name", "media-service"),
+		attribute.String("rpc.method", "CreateChannel"),
+		attribute.String("rpc.service", "MediaService"),
+	)
+
+	id, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		err := status.Error(grpccodes.Unauthenticated, "unknown caller")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "unknown caller")
+		return nil, err
+	}
+
+	c := &channel{
+		id:                newChannelID(),
+		name:              req.Name,
+		description:       req.Description,
+		ownerID:           id.UserID,
+		defaultVisibility: req.DefaultVisibility,
+		createdAt:         time.Now(),
+	}
+	if c.defaultVisibility == media.Visibility_VISIBILITY_UNSPECIFIED {
+		c.defaultVisibility = media.Visibility_VISIBILITY_PUBLIC
+	}
+	if req.TenantOwned {
+		if id.Tenant == "" {
+			err := status.Error(grpccodes.FailedPrecondition, "caller belongs to no tenant")
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "no tenant")
+			return nil, err
+		}
+		c.ownerID = ""
+		c.tenant = id.Tenant
+	}
+
+	s.mu.Lock()
+	s.channels[c.id] = c
+	s.mu.Unlock()
+
+	span.SetAttributes(attribute.String("channel.id", c.id))
+	span.SetStatus(codes.Ok, "channel created")
+
+	return &media.CreateChannelResponse{Channel: c.proto()}, nil
+}
+
+func (s *mediaServer) AssignVideoToChannel(ctx context.Context, req *media.AssignVideoToChannelRequest) (*media.AssignVideoToChannelResponse, error) {
+	_, span := s.tracer.Start(ctx, "AssignVideoToChannel")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("service.name", "media-service"),
+		attribute.String("rpc.method", "AssignVideoToChannel"),
+		attribute.String("rpc.service", "MediaService"),
+		attribute.String("channel.id", req.ChannelId),
+		attribute.String("video.id", req.VideoId),
+	)
+
+	id, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		err := status.Error(grpccodes.Unauthenticated, "unknown caller")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "unknown caller")
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, exists := s.channels[req.ChannelId]
+	if !exists {
+		err := status.Error(grpccodes.NotFound, "channel not found")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "channel not found")
+		return nil, err
+	}
+	if !c.managedBy(id) {
+		err := status.Error(grpccodes.PermissionDenied, "only the channel's owner may assign videos to it")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "permission denied")
+		return nil, err
+	}
+
+	videoInfo, exists := s.videos[req.VideoId]
+	if !exists {
+		err := status.Error(grpccodes.NotFound, "video not found")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "video not found")
+		return nil, err
+	}
+	if videoInfo.Metadata.UploaderId != id.UserID {
+		err := status.Error(grpccodes.PermissionDenied, "only the uploader may assign this video")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "permission denied")
+		return nil, err
+	}
+
+	// Metadata is shared with earlier responses, so replace it instead of
+	// modifying it in place.
+	prev := videoInfo.Metadata
+	metadata := proto.Clone(prev).(*media.VideoMetadata)
+	metadata.ChannelId = c.id
+	metadata.Visibility = c.defaultVisibility
+	videoInfo.Metadata = metadata
+	if err := s.storeMetadataLocked(req.VideoId, videoInfo); err != nil {
+		videoInfo.Metadata = prev
+		err = status.Errorf(grpccodes.Unavailable, "failed to store video: %v", err)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to store video")
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "video assigned")
+
+	return &media.AssignVideoToChannelResponse{VideoId: req.VideoId, Metadata: metadata}, nil
+}
+
+func (s *mediaServer) ListPublicChannels(ctx context.Context, req *media.ListPublicChannelsRequest) (*media.ListPublicChannelsResponse, error) {
+	_, span := s.tracer.Start(ctx, "ListPublicChannels")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("service.name", "media-service"),
+		attribute.String("rpc.method", "ListPublicChannels"),
+		attribute.String("rpc.service", "MediaService"),
+	)
+
+	s.mu.RLock()
+	byChannel := make(map[string]*media.PublicChannel)
+	for _, c := range s.channels {
+		if c.defaultVisibility == media.Visibility_VISIBILITY_PUBLIC {
+			byChannel[c.id] = &media.PublicChannel{Channel: c.proto()}
+		}
+	}
+	for videoID, videoInfo := range s.videos {
+		pc, ok := byChannel[videoInfo.Metadata.ChannelId]
+		if ok && videoInfo.Metadata.Visibility == media.Visibility_VISIBILITY_PUBLIC {
+			pc.Videos = append(pc.Videos, &media.VideoSummary{VideoId: videoID, Metadata: videoInfo.Metadata})
+		}
+	}
+	s.mu.RUnlock()
+
+	channels := make([]*media.PublicChannel, 0, len(byChannel))
+	for _, pc := range byChannel {
+		sort.Slice(pc.Videos, func(i, j int) bool {
+			return pc.Videos[i].VideoId < pc.Videos[j].VideoId
+		})
+		channels = append(channels, pc)
+	}
+	sort.Slice(channels, func(i, j int) bool {
+		return channels[i].Channel.Name < channels[j].Channel.Name
+	})
+
+	span.SetAttributes(attribute.Int("channel.count", len(channels)))
+	span.SetStatus(codes.Ok, "channels listed")
+
+	return &media.ListPublicChannelsResponse{Channels: channels}, nil
+}
+
+func (s *mediaServer) LikeVideo(ctx context.Context, req *media.LikeVideoRequest) (*media.LikeVideoResponse, error) {
+	count, err := s.setLiked(ctx, "LikeVideo", req.VideoId, true)
+	if err != nil {
+		return nil, err
+	}
+	return &media.LikeVideoResponse{VideoId: req.VideoId, LikeCount: count}, nil
+}
+
+func (s *mediaServer) UnlikeVideo(ctx context.Context, req *media.UnlikeVideoRequest) (*media.UnlikeVideoResponse, error) {
+	count, err := s.setLiked(ctx, "UnlikeVideo", req.VideoId, false)
+	if err != nil {
+		return nil, err
+	}
+	return &media.UnlikeVideoResponse{VideoId: req.VideoId, LikeCount: count}, nil
+}
+
+// setLiked implements LikeVideo and UnlikeVideo and returns the new like count.
+func (s *mediaServer) setLiked(ctx context.Context, method, videoID string, liked bool) (int64, error) {
+	_, span := s.tracer.Start(ctx, method)
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("service.name", "media-service"),
+		attribute.String("rpc.method", method),
+		attribute.String("rpc.service", "MediaService"),
+		attribute.String("video.id", videoID),
+	)
+
+	id, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		err := status.Error(grpccodes.Unauthenticated, "unknown caller")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "unknown caller")
+		return 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	videoInfo, exists := s.videos[videoID]
+	if !exists || !isViewable(videoInfo.Metadata, id.UserID) {
+		err := status.Error(grpccodes.NotFound, "video not found")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "video not found")
+		return 0, err
+	}
+
+	changed := videoInfo.setLiked(id.UserID, liked)
+	span.SetAttributes(
+		attribute.Bool("like.changed", changed),
+		attribute.Int64("like.count", videoInfo.Metadata.LikeCount),
+	)
+	span.SetStatus(codes.Ok, "like updated")
+
+	return videoInfo.Metadata.LikeCount, nil
+}
+
+func (s *mediaServer) ListFavorites(ctx context.Context, req *media.ListFavoritesRequest) (*media.ListFavoritesResponse, error) {
+	_, span := s.tracer.Start(ctx, "ListFavorites")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("service.name", "media-service"),
+		attribute.String("rpc.method", "ListFavorites"),
+		attribute.String("rpc.service", "MediaService"),
+	)
+
+	id, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		err := status.Error(grpccodes.Unauthenticated, "unknown caller")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "unknown caller")
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	type favorite struct {
+		summary *media.VideoSummary
+		likedAt time.Time
+	}
+	var favorites []favorite
+	for videoID, videoInfo := range s.videos {
+		likedAt, liked := videoInfo.Likes[id.UserID]
+		if !liked || !isViewable(videoInfo.Metadata, id.UserID) {
+			continue
+		}
+		favorites = append(favorites, favorite{
+			summary: &media.VideoSummary{VideoId: videoID, Metadata: videoInfo.Metadata},
+			likedAt: likedAt,
+		})
+	}
+	key := func(f favorite) timeKey { return timeKey{f.likedAt.UnixNano(), f.summary.VideoId} }
+	slices.SortFunc(favorites, func(a, b favorite) int {
+		return newestFirst(key(a), key(b))
+	})
+
+	favorites, next, err := paging.Page(s.pager, req, favorites, key, newestFirst)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid page token")
+		return nil, err
+	}
+
+	resp := &media.ListFavoritesResponse{NextPageToken: next}
+	for _, f := range favorites {
+		resp.Videos = append(resp.Videos, f.summary)
+	}
+
+	span.SetAttributes(attribute.Int("favorites.count", len(resp.Videos)))
+	span.SetStatus(codes.Ok, "favorites listed")
+
+	return resp, nil
+}
+
+func (s *mediaServer) CreateShareLink(ctx context.Context, req *media.CreateShareLinkRequest) (*media.CreateShareLinkResponse, error) {
+	_, span := s.tracer.Start(ctx, "CreateShareLink")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("service.name", "media-service"),
+		attribute.String("rpc.method", "CreateShareLink"),
+		attribute.String("rpc.service", "MediaService"),
+		attribute.String("video.id", req.VideoId),
+		attribute.String("share.target", req.Target.String()),
+	)
+
+	id, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		err := status.Error(grpccodes.Unauthenticated, "unknown caller")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "unknown caller")
+		return nil, err
+	}
+
+	target := req.Target
+	switch target {
+	case media.ShareTarget_SHARE_TARGET_UNSPECIFIED:
+		target = media.ShareTarget_SHARE_TARGET_PLAYER
+	case media.ShareTarget_SHARE_TARGET_PLAYER:
+	case media.ShareTarget_SHARE_TARGET_DOWNLOAD:
+		if !s.canLinkDownloads() {
+			err := status.Error(grpccodes.FailedPrecondition, errNoDownloadLinks.Error())
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "no token signer")
+			return nil, err
+		}
+	default:
+		err := status.Error(grpccodes.InvalidArgument, "unknown share target")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid target")
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	videoInfo, exists := s.videos[req.VideoId]
+	if !exists || !isViewable(videoInfo.Metadata, id.UserID) {
+		err := status.Error(grpccodes.NotFound, "video not found")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "video not found")
+		return nil, err
+	}
+
+	now := time.Now()
+	link := &shareLink{
+		videoID:   req.VideoId,
+		owner:     &auth.Identity{UserID: id.UserID, Username: id.Username, Tenant: id.Tenant},
+		target:    target,
+		createdAt: now,
+	}
+	if req.ExpiresInSeconds > 0 {
+		link.expiresAt = now.Add(time.Duration(req.ExpiresInSeconds) * time.Second)
+	}
+	for {
+		link.code = newShareCode()
+		if _, taken := s.shareLinks[link.code]; !taken {
+			break
+		}
+	}
+	s.shareLinks[link.code] = link
+
+	span.SetAttributes(attribute.String("share.code", link.code))
+	span.SetStatus(codes.Ok, "share link created")
+
+	return &media.CreateShareLinkResponse{Link: link.proto()}, nil
+}
+
+func (s *mediaServer) CreateDownloadLink(ctx context.Context, req *media.CreateDownloadLinkRequest) (*media.CreateDownloadLinkResponse, error) {
+	_, span := s.tracer.Start(ctx, "CreateDownloadLink")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("service.name", "media-service"),
+		attribute.String("rpc.method", "CreateDownloadLink"),
+		attribute.String("rpc.service", "MediaService"),
+		attribute.String("video.id", req.VideoId),
+	)
+
+	if !s.canLinkDownloads() {
+		err := status.Error(grpccodes.FailedPrecondition, errNoDownloadLinks.Error())
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "no URL signer")
+		return nil, err
+	}
+
+	id, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		err := status.Error(grpccodes.Unauthenticated, "unknown caller")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "unknown caller")
+		return nil, err
+	}
+	if id.VideoID != "" && id.VideoID != req.VideoId {
+		err := status.Error(grpccodes.PermissionDenied, "token is limited to another video")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "permission denied")
+		return nil, err
+	}
+
+	s.mu.RLock()
+	videoInfo, exists := s.videos[req.VideoId]
+	exists = exists && isViewable(videoInfo.Metadata, id.UserID)
+	s.mu.RUnlock()
+	if !exists {
+		err := status.Error(grpccodes.NotFound, "video not found")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "video not found")
+		return nil, err
+	}
+
+	u, expires, err := s.downloadURL(id, req.VideoId, s.downloadLinkTTL)
+	if err != nil {
+		err = status.Error(grpccodes.Internal, "failed to sign download URL")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to sign download URL")
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "download link created")
+
+	return &media.CreateDownloadLinkResponse{Url: u, ExpiresAt: expires.Unix()}, nil
+}
+
+func (s *mediaServer) GetShareLink(ctx context.Context, req *media.GetShareLinkRequest) (*media.GetShareLinkResponse, error) {
+	_, span := s.tracer.Start(ctx, "GetShareLink")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("service.name", "media-service"),
+		attribute.String("rpc.method", "GetShareLink"),
+		attribute.String("rpc.service", "MediaService"),
+		attribute.String("share.code", req.Code),
+	)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	link, exists := s.shareLinks[req.Code]
+	if !exists {
+		err := status.Error(grpccodes.NotFound, "share link not found")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "share link not found")
+		return nil, err
+	}
+	if link.owner.UserID != callerID(ctx) {
+		err := status.Error(grpccodes.PermissionDenied, "only the link's creator may view it")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "permission denied")
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "share link returned")
+
+	return &media.GetShareLinkResponse{Link: link.proto()}, nil
+}
+
+func (s *mediaServer) ResolveShareLink(ctx context.Context, req *media.ResolveShareLinkRequest) (*media.ResolveShareLinkResponse, error) {
+	_, span := s.tracer.Start(ctx, "ResolveShareLink")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("service.name", "media-service"),
+		attribute.String("rpc.method", "ResolveShareLink"),
+		attribute.String("rpc.service", "MediaService"),
+		attribute.String("share.code", req.Code),
+	)
+
+	s.mu.Lock()
+	link, exists := s.shareLinks[req.Code]
+	if exists && link.expired(time.Now()) {
+		delete(s.shareLinks, req.Code)
+		exists = false
+	}
+	if exists {
+		videoInfo, ok := s.videos[link.videoID]
+		exists = ok && isViewable(videoInfo.Metadata, link.owner.UserID)
+	}
+	if !exists {
+		s.mu.Unlock()
+		err := status.Error(grpccodes.NotFound, "share link not found")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "share link not found")
+		return nil, err
+	}
+	link.clicks++
+	videoID, owner, target := link.videoID, link.owner, link.target
+	s.mu.Unlock()
+
+	span.SetAttributes(
+		attribute.String("video.id", videoID),
+		attribute.String("share.target", target.String()),
+	)
+
+	resp := &media.ResolveShareLinkResponse{VideoId: videoID}
+	if target == media.ShareTarget_SHARE_TARGET_DOWNLOAD {
+		u, _, err := s.downloadURL(owner, videoID, downloadTokenTTL)
+		if err != nil {
+			err = status.Error(grpccodes.Internal, "failed to sign download URL")
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "failed to sign download URL")
+			return nil, err
+		}
+		resp.RedirectUrl = u
+	} else {
+		resp.RedirectUrl = s.playerRedirect(videoID)
+	}
+
+	span.SetStatus(codes.Ok, "share link resolved")
+
+	return resp, nil
+}
+
+func (s *mediaServer) SetThumbnail(ctx context.Context, req *media.SetThumbnailRequest) (*media.SetThumbnailResponse, error) {
+	_, span := s.tracer.Start(ctx, "SetThumbnail")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("service.name", "media-service"),
+		attribute.String("rpc.method", "SetThumbnail"),
+		attribute.String("rpc.service", "MediaService"),
+		attribute.String("video.id", req.VideoId),
+		attribute.Int("thumbnail.size_bytes", len(req.Data)),
+	)
+
+	id, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		err := status.Error(grpccodes.Unauthenticated, "unknown caller")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "unknown caller")
+		return nil, err
+	}
+
+	contentType := detectThumbnailType(req.Data)
+	if contentType == "" {
+		err := status.Error(grpccodes.InvalidArgument, "thumbnail must be a JPEG, PNG, GIF or WebP image")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "unsupported thumbnail type")
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	videoInfo, exists := s.videos[req.VideoId]
+	if !exists || !isViewable(videoInfo.Metadata, id.UserID) {
+		err := status.Error(grpccodes.NotFound, "video not found")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "video not found")
+		return nil, err
+	}
+	if videoInfo.Metadata.UploaderId != id.UserID {
+		err := status.Error(grpccodes.PermissionDenied, "only the uploader may set the thumbnail")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "permission denied")
+		return nil, err
+	}
+
+	videoInfo.Thumbnail = req.Data
+	videoInfo.ThumbnailType = contentType
+
+	span.SetStatus(codes.Ok, "thumbnail set")
+
+	return &media.SetThumbnailResponse{VideoId: req.VideoId, ThumbnailUrl: thumbnailURL(req.VideoId)}, nil
+}
+
+func (s *mediaServer) GetThumbnail(ctx context.Context, req *media.GetThumbnailRequest) (*media.GetThumbnailResponse, error) {
+	_, span := s.tracer.Start(ctx, "GetThumbnail")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("service.name", "media-service"),
+		attribute.String("rpc.method", "GetThumbnail"),
+		attribute.String("rpc.service", "MediaService"),
+		attribute.String("video.id", req.VideoId),
+	)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	videoInfo, exists := s.videos[req.VideoId]
+	if !exists || !isViewable(videoInfo.Metadata, callerID(ctx)) || len(videoInfo.Thumbnail) == 0 {
+		err := status.Error(grpccodes.NotFound, "thumbnail not found")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "thumbnail not found")
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "thumbnail returned")
+
+	return &media.GetThumbnailResponse{Data: videoInfo.Thumbnail, ContentType: videoInfo.ThumbnailType}, nil
+}
+
+func (s *mediaServer) ListPublicVideos(ctx context.Context, req *media.ListPublicVideosRequest) (*media.ListPublicVideosResponse, error) {
+	_, span := s.tracer.Start(ctx, "ListPublicVideos")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("service.name", "media-service"),
+		attribute.String("rpc.method", "ListPublicVideos"),
+		attribute.String("rpc.service", "MediaService"),
+	)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var videos []*media.PublicVideo
+	for videoID, videoInfo := range s.videos {
+		if isPublished(videoInfo.Metadata) && s.publishes(videoInfo.Metadata.UploaderId) {
+			videos = append(videos, publicVideo(videoID, videoInfo))
+		}
+	}
+	// A stable order keeps the gateway's ETag stable between requests.
+	key := func(v *media.PublicVideo) timeKey { return timeKey{v.Metadata.UploadTimestamp, v.VideoId} }
+	slices.SortFunc(videos, func(a, b *media.PublicVideo) int {
+		return newestFirst(key(a), key(b))
+	})
+
+	videos, next, err := paging.Page(s.pager, req, videos, key, newestFirst)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid page token")
+		return nil, err
+	}
+	resp := &media.ListPublicVideosResponse{Videos: videos, NextPageToken: next}
+
+	span.SetAttributes(attribute.Int("videos.count", len(resp.Videos)))
+	span.SetStatus(codes.Ok, "public videos listed")
+
+	return resp, nil
+}
+
+func (s *mediaServer) GetEmbed(ctx context.Context, req *media.GetEmbedRequest) (*media.GetEmbedResponse, error) {
+	_, span := s.tracer.Start(ctx, "GetEmbed")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("service.name", "media-service"),
+		attribute.String("rpc.method", "GetEmbed"),
+		attribute.String("rpc.service", "MediaService"),
+		attribute.String("video.id", req.VideoId),
+	)
+
+	if s.signer == nil {
+		err := status.Error(grpccodes.FailedPrecondition, "embedding is not available on this server")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "no token signer")
+		return nil, err
+	}
+
+	id, ok := auth.IdentityFromContext(ctx)
+	if ok && id.VideoID != "" && id.VideoID != req.VideoId {
+		err := status.Error(grpccodes.PermissionDenied, "token is limited to another video")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "permission denied")
+		return nil, err
+	}
+	v := viewer(id, ok)
+
+	s.mu.RLock()
+	videoInfo, exists := s.videos[req.VideoId]
+	if exists && !isViewable(videoInfo.Metadata, v.UserID) {
+		exists = false
+	}
+	var resp *media.GetEmbedResponse
+	if exists {
+		resp = &media.GetEmbedResponse{
+			VideoId:  req.VideoId,
+			Metadata: videoInfo.Metadata,
+			Hls:      len(videoInfo.segments) > 0,
+		}
+		if len(videoInfo.Thumbnail) > 0 {
+			resp.ThumbnailUrl = thumbnailURL(req.VideoId)
+		}
+	}
+	s.mu.RUnlock()
+
+	if !exists {
+		err := status.Error(grpccodes.NotFound, "video not found")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "video not found")
+		return nil, err
+	}
+
+	var err error
+	if resp.Hls {
+		var token string
+		token, _, err = s.signer.SignVideoToken(v, req.VideoId, embedTokenTTL)
+		resp.SourceUrl = hlsPlaylistURL(req.VideoId) + "?access_token=" + url.QueryEscape(token)
+	} else {
+		resp.SourceUrl, _, err = s.downloadURL(v, req.VideoId, embedTokenTTL)
+	}
+	if err != nil {
+		err = status.Error(grpccodes.Internal, "failed to sign source URL")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to sign source URL")
+		return nil, err
+	}
+
+	span.SetAttributes(
+		attribute.Bool("embed.anonymous", !ok),
+		attribute.Bool("embed.hls", resp.Hls),
+	)
+	span.SetStatus(codes.Ok, "embed returned")
+
+	return resp, nil
+}
+
+func (s *mediaServer) GetHLSPlaylist(ctx context.Context, req *media.GetHLSPlaylistRequest) (*media.GetHLSPlaylistResponse, error) {
+	_, span := s.tracer.Start(ctx, "GetHLSPlaylist")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("service.name", "media-service"),
+		attribute.String("rpc.method", "GetHLSPlaylist"),
+		attribute.String("rpc.service", "MediaService"),
+		attribute.String("video.id", req.VideoId),
+	)
+
+	id, ok := auth.IdentityFromContext(ctx)
+	if ok && id.VideoID != "" && id.VideoID != req.VideoId {
+		err := status.Error(grpccodes.PermissionDenied, "token is limited to another video")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "permission denied")
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	videoInfo, exists := s.videos[req.VideoId]
+	if !exists || !isViewable(videoInfo.Metadata, viewer(id, ok).UserID) {
+		err := status.Error(grpccodes.NotFound, "video not found")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "video not found")
+		return nil, err
+	}
+	if len(videoInfo.segments) == 0 {
+		err := status.Error(grpccodes.FailedPrecondition, "video is not available as HLS")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "no HLS segments")
+		return nil, err
+	}
+
+	// Every playlist request mints a fresh token for its segments.
+	token := signSegments(s.segmentKeys.Current(), req.VideoId, time.Now().Add(segmentTokenTTL))
+
+	span.SetAttributes(attribute.Int("hls.segments", len(videoInfo.segments)))
+	span.SetStatus(codes.Ok, "playlist returned")
+
+	return &media.GetHLSPlaylistResponse{Playlist: hlsPlaylist(videoInfo.segments, token)}, nil
+}
+
+func (s *mediaServer) GetHLSSegment(ctx context.Context, req *media.GetHLSSegmentRequest) (*media.GetHLSSegmentResponse, error) {
+	_, span := s.tracer.Start(ctx, "GetHLSSegment")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("service.name", "media-service"),
+		attribute.String("rpc.method", "GetHLSSegment"),
+		attribute.String("rpc.service", "MediaService"),
+		attribute.String("video.id", req.VideoId),
+		attribute.Int("hls.segment", int(req.Index)),
+	)
+
+	if !verifySegments(s.segmentKeys.Valid(), req.VideoId, req.Token, time.Now()) {
+		err := status.Error(grpccodes.PermissionDenied, "invalid or expired segment token")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid segment token")
+		return nil, err
+	}
+
+	// Segment tokens are not tied to a viewer, so a taken down video stops
+	// streaming for everyone, its uploader included.
+	videoInfo, exists := s.snapshotVideo(req.VideoId)
+	defer videoInfo.close()
+	if !exists || videoInfo.metadata.TakenDown || req.Index < 0 || int(req.Index) >= len(videoInfo.segments) {
+		err := status.Error(grpccodes.NotFound, "segment not found")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "segment not found")
+		return nil, err
+	}
+
+	seg := videoInfo.segments[req.Index]
+	data, err := readRange(videoInfo.content, int64(seg.offset), int64(seg.size))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to read segment")
+		return nil, status.Errorf(grpccodes.Internal, "failed to read segment: %v", err)
+	}
+	// Segments are only fetched by players.
+	if err := s.bandwidth.wait(ctx, media.DownloadPriority_DOWNLOAD_PRIORITY_INTERACTIVE, len(data)); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "canceled while waiting for bandwidth")
+		return nil, status.FromContextError(err).Err()
+	}
+	span.SetStatus(codes.Ok, "segment returned")
+
+	return &media.GetHLSSegmentResponse{Data: data}, nil
+}
@@ -0,0 +1,51 @@
+package media
+
+import (
+	"coscup2025/proto/account"
+	"coscup2025/proto/media"
+	"net/http"
+	"net/url"
+)
+
+// thumbnailTypes are the image types accepted as thumbnails.
+var thumbnailTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+// thumbnailURL is the gateway path serving the thumbnail of videoID.
+func thumbnailURL(videoID string) string {
+	return "/v1/public/videos/" + url.PathEscape(videoID) + "/thumbnail"
+}
+
+// publishes reports whether userID agreed to their videos being listed
+// publicly.
+func (s *mediaServer) publishes(userID string) bool {
+	return s.consents == nil || s.consents.Consented(userID, account.ConsentPurpose_CONSENT_PURPOSE_PUBLIC_RECORDINGS)
+}
+
+// isPublished reports whether a video belongs in the public gallery.
+func isPublished(md *media.VideoMetadata) bool {
+	return isListed(md, "")
+}
+
+// publicVideo describes a published video for the gallery.
+func publicVideo(videoID string, v *VideoInfo) *media.PublicVideo {
+	p := &media.PublicVideo{VideoId: videoID, Metadata: v.Metadata}
+	if len(v.Thumbnail) > 0 {
+		p.ThumbnailUrl = thumbnailURL(videoID)
+	}
+	return p
+}
+
+// detectThumbnailType returns the image type of data, or "" if it is not an
+// accepted image.
+func detectThumbnailType(data []byte) string {
+	contentType := http.DetectContentType(data)
+	if !thumbnailTypes[contentType] {
+		return ""
+	}
+	return contentType
+}
@@ -0,0 +1,48 @@
+package media_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pbMedia "coscup2025/proto/media"
+)
+
+// pngHeader is enough of a PNG file for content sniffing.
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func TestPublicGallery(t *testing.T) {
+	client, ctx := setupMediaClient(t)
+	uploadVideo(t, client, ctx, "keynote")
+	uploadVideo(t, client, ctx, "rehearsal")
+
+	private, err := client.CreateChannel(ctx, &pbMedia.CreateChannelRequest{
+		Name:              "Backstage",
+		DefaultVisibility: pbMedia.Visibility_VISIBILITY_PRIVATE,
+	})
+	require.NoError(t, err)
+	_, err = client.AssignVideoToChannel(ctx, &pbMedia.AssignVideoToChannelRequest{ChannelId: private.Channel.ChannelId, VideoId: "rehearsal"})
+	require.NoError(t, err)
+
+	_, err = client.SetThumbnail(ctx, &pbMedia.SetThumbnailRequest{VideoId: "keynote", Data: []byte("not an image")})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	set, err := client.SetThumbnail(ctx, &pbMedia.SetThumbnailRequest{VideoId: "keynote", Data: pngHeader})
+	require.NoError(t, err)
+
+	// The website fetches the gallery without signing in.
+	anonymous := context.Background()
+	gallery, err := client.ListPublicVideos(anonymous, &pbMedia.ListPublicVideosRequest{})
+	require.NoError(t, err)
+	require.Len(t, gallery.Videos, 1)
+	assert.Equal(t, "keynote", gallery.Videos[0].VideoId)
+	assert.Equal(t, set.ThumbnailUrl, gallery.Videos[0].ThumbnailUrl)
+
+	thumbnail, err := client.GetThumbnail(anonymous, &pbMedia.GetThumbnailRequest{VideoId: "keynote"})
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", thumbnail.ContentType)
+	assert.Equal(t, pngHeader, thumbnail.Data)
+}
@@ -1,28 +1,272 @@
 package media
 
 import (
+	"cmp"
+	"context"
+	"coscup2025/auth"
+	"coscup2025/env"
+	"coscup2025/jobs"
+	"coscup2025/keyset"
+	"coscup2025/paging"
+	"coscup2025/proto/account"
 	"coscup2025/proto/media"
+	"coscup2025/proto/notification"
+	"fmt"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/text/language"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 type VideoInfo struct {
 	Data     []byte
 	Metadata *media.VideoMetadata
+	Tenant   string
+	// Likes maps the users who liked the video to when they did. It is kept
+	// with the video so that it is stored and removed together with it, and
+	// Metadata.LikeCount always equals its length.
+	Likes map[string]time.Time
+	// Thumbnail is the video's preview image, of type ThumbnailType
+	Thumbnail     []byte
+	ThumbnailType string
+
+	// segments split Data for HLS; nil unless Data is MPEG-TS
+	segments []hlsSegment
+	// blob is the hash of the content in the video store of a server that
+	// offloads videos, in which case Data is nil
+	blob string
 }
 
 type mediaServer struct {
 	media.UnimplementedMediaServiceServer
-	videos map[string]*VideoInfo
-	mu     sync.RWMutex
-	tracer trace.Tracer
+	videos     map[string]*VideoInfo
+	sessions   map[string]*uploadSession
+	playlists  map[string]*playlist
+	channels   map[string]*channel
+	shareLinks map[string]*shareLink
+	mu         sync.RWMutex
+	tracer     trace.Tracer
+	progress   *progressHub
+	notifier   Notifier
+	signer     TokenSigner
+	cdn        URLSigner
+	consents   Consents
+	backend    Backend
+	jobs       *jobs.Runner
+	health     HealthReporter
+	alerter    Alerter
+
+	chunkEventInterval int
+	uploadMaxInFlight  int64
+	memoryBudget       int64
+	maxVideoSize       int64
+	maxMessageSize     int
+	videoVersioning    bool
+	maxUserDownloads   int
+	bandwidth          *downloadScheduler
+	playerURL          string
+	defaultLocale      language.Tag
+	pager              *paging.Pager
+	segmentKeys        keyset.Derived
+	downloadLinkTTL    time.Duration
+	transcoderSecret   []byte
+	heartbeatInterval  time.Duration
+	maxStreamDeadline  time.Duration
+
+	// transcodeEvents are the IDs of processed transcoder callbacks, kept
+	// until their signatures expire, see ReportTranscode.
+	transcodeMu     sync.Mutex
+	transcodeEvents map[string]time.Time
+
+	// streamBytes are held by uploads without a session, see admitChunk.
+	streamBytes int64
+	// downloads counts the open download streams by user, see startDownload.
+	downloads map[string]int
+	// sessionStore persists upload sessions and videoStore uploaded videos;
+	// nil keeps them in memory only. With offload set, videos are served
+	// from videoStore.
+	sessionStore *sessionStore
+	videoStore   BlobStore
+	offload      bool
+	scrub        scrubState
+	retention    retentionState
+	// storageDegraded is set while writes of video bytes fail, see
+	// storageWriteError.
+	storageDegraded atomic.Bool
+
+	// avatars are the profile pictures by user ID, persisted in avatarStore
+	// unless it is nil.
+	avatarMu    sync.RWMutex
+	avatars     map[string]*avatar
+	avatarStore BlobStore
+	avatarSize  int
 }
 
-func NewMediaServer() *mediaServer {
+// Notifier receives user-facing events about videos, such as a finished upload.
+type Notifier interface {
+	Notify(userID string, kind notification.NotificationKind, videoID, message string)
+}
+
+// NewMediaServer returns a media server whose page tokens and HLS segment
+// tokens are signed with keys derived from keys.
+func NewMediaServer(cfg *env.Config, keys *keyset.Set) *mediaServer {
 	return &mediaServer{
-		videos: make(map[string]*VideoInfo),
-		tracer: otel.Tracer("media-service"),
+		videos:             make(map[string]*VideoInfo),
+		sessions:           make(map[string]*uploadSession),
+		playlists:          make(map[string]*playlist),
+		channels:           make(map[string]*channel),
+		shareLinks:         make(map[string]*shareLink),
+		downloads:          make(map[string]int),
+		tracer:             otel.Tracer("media-service"),
+		progress:           newProgressHub(),
+		chunkEventInterval: cfg.ChunkEventInterval,
+		uploadMaxInFlight:  cfg.UploadMaxInFlight,
+		memoryBudget:       cfg.MemoryBudget,
+		maxVideoSize:       cfg.MaxVideoSize,
+		maxMessageSize:     cmp.Or(cfg.GRPCMaxRecvMsgSize, defaultMaxMessageSize),
+		videoVersioning:    cfg.VideoVersioning,
+		maxUserDownloads:   cfg.MaxUserDownloads,
+		bandwidth:          newDownloadScheduler(cfg.DownloadBandwidth),
+		playerURL:          cfg.PlayerURL,
+		defaultLocale:      language.Make(cfg.DefaultLocale),
+		pager:              paging.New(keys),
+		segmentKeys:        keys.Derive("hls-segments"),
+		downloadLinkTTL:    cfg.DownloadLinkTTL,
+		transcoderSecret:   []byte(cfg.TranscoderWebhookSecret),
+		heartbeatInterval:  cfg.StreamHeartbeatInterval,
+		maxStreamDeadline:  cfg.MaxUploadStreamDeadline,
+		transcodeEvents:    make(map[string]time.Time),
+		avatars:            make(map[string]*avatar),
+		avatarSize:         cmp.Or(cfg.AvatarSize, defaultAvatarSize),
+	}
+}
+
+// TokenSigner issues tokens that grant access to a single video.
+type TokenSigner interface {
+	SignVideoToken(id *auth.Identity, videoID string, ttl time.Duration) (string, time.Time, error)
+}
+
+// SetNotifier makes the server report upload outcomes to n.
+func (s *mediaServer) SetNotifier(n Notifier) {
+	s.notifier = n
+}
+
+// Consents tells whether users agreed to uses of their data.
+type Consents interface {
+	Consented(userID string, purpose account.ConsentPurpose) bool
+}
+
+// SetConsents makes the gallery leave out the videos of users who withdrew
+// their consent to publishing recordings.
+func (s *mediaServer) SetConsents(c Consents) {
+	s.consents = c
+}
+
+// SetTokenSigner lets share links lead to signed download URLs minted by signer.
+func (s *mediaServer) SetTokenSigner(signer TokenSigner) {
+	s.signer = signer
+}
+
+// notifyCaller sends a notification to the authenticated caller of ctx, if any.
+func (s *mediaServer) notifyCaller(ctx context.Context, kind notification.NotificationKind, videoID, message string) {
+	if s.notifier == nil {
+		return
+	}
+	if id, ok := auth.IdentityFromContext(ctx); ok {
+		s.notifier.Notify(id.UserID, kind, videoID, message)
+	}
+}
+
+// VideoUploader returns the uploader of a video the caller of ctx may view.
+func (s *mediaServer) VideoUploader(ctx context.Context, videoID string) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	videoInfo, exists := s.videos[videoID]
+	if !exists || !isViewable(videoInfo.Metadata, callerID(ctx)) {
+		return "", false
+	}
+	return videoInfo.Metadata.UploaderId, true
+}
+
+// VideoResource returns the metadata and tenant of a video for
+// authorization policies, whoever the caller is.
+func (s *mediaServer) VideoResource(videoID string) (*media.VideoMetadata, string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	videoInfo, exists := s.videos[videoID]
+	if !exists {
+		return nil, "", false
+	}
+	// Metadata is replaced rather than modified, so it can be shared.
+	return videoInfo.Metadata, videoInfo.Tenant, true
+}
+
+// UploadedVideos returns the videos uploaded by userID, keyed by video ID,
+// for exporting them. The content of offloaded videos is read from the video
+// store.
+func (s *mediaServer) UploadedVideos(userID string) (map[string]*VideoInfo, error) {
+	videos := make(map[string]*VideoInfo)
+	blobs := make(map[string]string)
+	s.mu.RLock()
+	for videoID, v := range s.videos {
+		if v.Metadata.UploaderId != userID {
+			continue
+		}
+		videos[videoID] = &VideoInfo{
+			Data:          v.Data,
+			Metadata:      v.Metadata,
+			Tenant:        v.Tenant,
+			Thumbnail:     v.Thumbnail,
+			ThumbnailType: v.ThumbnailType,
+		}
+		if v.blob != "" {
+			blobs[videoID] = v.blob
+		}
+	}
+	store := s.videoStore
+	s.mu.RUnlock()
+
+	for videoID, hash := range blobs {
+		v := videos[videoID]
+		content := &storedContent{store: store, hash: hash, size: v.Metadata.FileSize}
+		data, err := readRange(content, 0, content.Size())
+		content.close()
+		if err != nil {
+			return nil, fmt.Errorf("video %s: %w", videoID, err)
+		}
+		v.Data = data
+	}
+	return videos, nil
+}
+
+// SetTakenDown hides a video from everyone but its uploader, or shows it
+// again once reinstated.
+func (s *mediaServer) SetTakenDown(videoID string, takenDown bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	videoInfo, exists := s.videos[videoID]
+	if !exists {
+		return status.Error(grpccodes.NotFound, "video not found")
+	}
+	if videoInfo.Metadata.TakenDown == takenDown {
+		return nil
+	}
+	prev := videoInfo.Metadata
+	md := proto.Clone(prev).(*media.VideoMetadata)
+	md.TakenDown = takenDown
+	videoInfo.Metadata = md
+	if err := s.storeMetadataLocked(videoID, videoInfo); err != nil {
+		videoInfo.Metadata = prev
+		return status.Errorf(grpccodes.Unavailable, "failed to store video: %v", err)
 	}
+	return nil
 }
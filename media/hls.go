@@ -0,0 +1,187 @@
+package media
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	tsPacketSize = 188
+	tsSyncByte   = 0x47
+	ptsPerSecond = 90000
+
+	// hlsTargetDuration is the length segments are cut at, on the next
+	// keyframe.
+	hlsTargetDuration = 6 * time.Second
+	// segmentTokenTTL is how long the segment URLs of a playlist stay valid.
+	// VOD players fetch the playlist only once, so it covers a whole talk.
+	segmentTokenTTL = 4 * time.Hour
+)
+
+// hlsSegment is a byte range of an MPEG-TS video served as one HLS segment.
+type hlsSegment struct {
+	offset   int
+	size     int
+	duration float64 // seconds
+}
+
+// maybeTS reports whether content of the given size may be MPEG-TS, going by
+// its size and first byte, since segmentTS needs all of it to tell.
+func maybeTS(r io.ReaderAt, size int64) bool {
+	if size < tsPacketSize || size%tsPacketSize != 0 {
+		return false
+	}
+	b := make([]byte, 1)
+	_, err := r.ReadAt(b, 0)
+	return err == nil && b[0] == tsSyncByte
+}
+
+// segmentTS splits an MPEG-TS stream into HLS segments at keyframes
+// (packets with the random access indicator set), at least target apart.
+// It returns nil if data is not MPEG-TS with timestamps.
+func segmentTS(data []byte, target time.Duration) []hlsSegment {
+	if len(data) < tsPacketSize || len(data)%tsPacketSize != 0 {
+		return nil
+	}
+
+	targetPTS := int64(target.Seconds() * ptsPerSecond)
+	var segments []hlsSegment
+	start, startPTS, lastPTS := 0, int64(-1), int64(-1)
+	for offset := 0; offset < len(data); offset += tsPacketSize {
+		packet := data[offset : offset+tsPacketSize]
+		if packet[0] != tsSyncByte {
+			return nil
+		}
+		pts, ok := packetPTS(packet)
+		if !ok {
+			continue
+		}
+		if startPTS < 0 {
+			startPTS = pts
+		}
+		if isRandomAccess(packet) && pts-startPTS >= targetPTS {
+			segments = append(segments, hlsSegment{
+				offset:   start,
+				size:     offset - start,
+				duration: float64(pts-startPTS) / ptsPerSecond,
+			})
+			start, startPTS = offset, pts
+		}
+		lastPTS = max(lastPTS, pts)
+	}
+	if startPTS < 0 {
+		return nil
+	}
+
+	// The last segment ends at its last timestamp. With a single timestamp
+	// fall back to the target, as a zero duration confuses players.
+	last := float64(lastPTS-startPTS) / ptsPerSecond
+	if last <= 0 {
+		last = target.Seconds()
+	}
+	return append(segments, hlsSegment{offset: start, size: len(data) - start, duration: last})
+}
+
+// payload returns the payload of a TS packet, after any adaptation field.
+func payload(packet []byte) []byte {
+	control := packet[3] >> 4 & 0x3
+	if control&0x1 == 0 {
+		return nil
+	}
+	start := 4
+	if control&0x2 != 0 {
+		start += 1 + int(packet[4])
+	}
+	if start >= len(packet) {
+		return nil
+	}
+	return packet[start:]
+}
+
+// isRandomAccess reports whether a TS packet starts a keyframe.
+func isRandomAccess(packet []byte) bool {
+	control := packet[3] >> 4 & 0x3
+	return control&0x2 != 0 && packet[4] > 0 && packet[5]&0x40 != 0
+}
+
+// packetPTS returns the presentation timestamp of a PES packet starting in
+// this TS packet.
+func packetPTS(packet []byte) (int64, bool) {
+	if packet[1]&0x40 == 0 { // payload_unit_start_indicator
+		return 0, false
+	}
+	p := payload(packet)
+	if len(p) < 14 || p[0] != 0 || p[1] != 0 || p[2] != 1 || p[7]&0x80 == 0 {
+		return 0, false
+	}
+	pts := int64(p[9]>>1&0x07)<<30 |
+		int64(p[10])<<22 | int64(p[11]>>1)<<15 |
+		int64(p[12])<<7 | int64(p[13]>>1)
+	return pts, true
+}
+
+// hlsPlaylist renders the VOD media playlist of segments. Segment URIs are
+// relative to the playlist and carry token.
+func hlsPlaylist(segments []hlsSegment, token string) string {
+	var target float64
+	for _, seg := range segments {
+		target = max(target, seg.duration)
+	}
+
+	var b strings.Builder
+	b.WriteString("#EXTM3U\n#EXT-X-VERSION:3\n#EXT-X-PLAYLIST-TYPE:VOD\n")
+	fmt.Fprintf(&b, "#EXT-X-TARGETDURATION:%d\n#EXT-X-MEDIA-SEQUENCE:0\n", int(math.Ceil(target)))
+	for i, seg := range segments {
+		fmt.Fprintf(&b, "#EXTINF:%.3f,\n%d.ts?token=%s\n", seg.duration, i, url.QueryEscape(token))
+	}
+	b.WriteString("#EXT-X-ENDLIST\n")
+	return b.String()
+}
+
+// hlsPlaylistURL is the gateway path of the HLS playlist of videoID.
+func hlsPlaylistURL(videoID string) string {
+	return "/v1/hls/" + url.PathEscape(videoID) + "/index.m3u8"
+}
+
+// signSegments returns a token granting access to the segments of videoID
+// until expiresAt. Unlike a JWT it is cheap to check on every segment.
+func signSegments(key []byte, videoID string, expiresAt time.Time) string {
+	exp := strconv.FormatInt(expiresAt.Unix(), 10)
+	return exp + "." + base64.RawURLEncoding.EncodeToString(segmentMAC(key, videoID, exp))
+}
+
+// verifySegments checks a token made by signSegments with any of keys.
+func verifySegments(keys [][]byte, videoID, token string, now time.Time) bool {
+	exp, mac, ok := strings.Cut(token, ".")
+	if !ok {
+		return false
+	}
+	expiresAt, err := strconv.ParseInt(exp, 10, 64)
+	if err != nil || now.Unix() > expiresAt {
+		return false
+	}
+	got, err := base64.RawURLEncoding.DecodeString(mac)
+	if err != nil {
+		return false
+	}
+	for _, key := range keys {
+		if hmac.Equal(got, segmentMAC(key, videoID, exp)) {
+			return true
+		}
+	}
+	return false
+}
+
+func segmentMAC(key []byte, videoID, exp string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(videoID + "\n" + exp))
+	return h.Sum(nil)
+}
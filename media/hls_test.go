@@ -0,0 +1,191 @@
+package media_test
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha512"
+	"encoding/base64"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"coscup2025/env"
+	pbMedia "coscup2025/proto/media"
+	"coscup2025/servertest"
+)
+
+// tsFrame returns an MPEG-TS packet starting a PES packet with the given
+// presentation timestamp in seconds.
+func tsFrame(second int64, keyframe bool) []byte {
+	packet := make([]byte, 188)
+	packet[0], packet[1], packet[2] = 0x47, 0x41, 0x00 // payload unit start, PID 0x100
+	p := packet[4:]
+	if keyframe {
+		packet[3], packet[4], packet[5] = 0x30, 1, 0x40 // adaptation field with random access indicator
+		p = packet[6:]
+	} else {
+		packet[3] = 0x10
+	}
+	pts := second * 90000
+	copy(p, []byte{0, 0, 1, 0xe0, 0, 0, 0x80, 0x80, 5,
+		byte(0x21 | pts>>29&0x0e), byte(pts >> 22), byte(pts>>14&0xfe | 1), byte(pts >> 7), byte(pts<<1&0xfe | 1)})
+	return packet
+}
+
+func uploadTS(t *testing.T, client pbMedia.MediaServiceClient, ctx context.Context, videoID string) []byte {
+	var data []byte
+	for s := range int64(10) {
+		data = append(data, tsFrame(s, s%2 == 0)...)
+	}
+	stream, err := client.UploadVideo(ctx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&pbMedia.UploadVideoRequest{VideoId: videoID, Data: data, Sequence: 1}))
+	_, err = stream.CloseAndRecv()
+	require.NoError(t, err)
+	return data
+}
+
+func TestHLSSegmentTokens(t *testing.T) {
+	client, ctx := setupMediaClient(t)
+	data := uploadTS(t, client, ctx, "keynote")
+	uploadVideo(t, client, ctx, "closing")
+
+	anonymous := context.Background()
+	embed, err := client.GetEmbed(anonymous, &pbMedia.GetEmbedRequest{VideoId: "keynote"})
+	require.NoError(t, err)
+	assert.True(t, embed.Hls)
+	assert.True(t, strings.HasPrefix(embed.SourceUrl, "/v1/hls/keynote/index.m3u8?"))
+
+	resp, err := client.GetHLSPlaylist(anonymous, &pbMedia.GetHLSPlaylistRequest{VideoId: "keynote"})
+	require.NoError(t, err)
+
+	var durations []string
+	var uris []*url.URL
+	scanner := bufio.NewScanner(strings.NewReader(resp.Playlist))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if d, ok := strings.CutPrefix(line, "#EXTINF:"); ok {
+			durations = append(durations, d)
+		} else if !strings.HasPrefix(line, "#") {
+			uri, err := url.Parse(line)
+			require.NoError(t, err)
+			uris = append(uris, uri)
+		}
+	}
+	// Cut at the first keyframe 6 seconds in.
+	assert.Equal(t, []string{"6.000,", "3.000,"}, durations)
+	require.Len(t, uris, 2)
+
+	var joined []byte
+	for i, uri := range uris {
+		assert.Equal(t, strconv.Itoa(i)+".ts", uri.Path)
+		seg, err := client.GetHLSSegment(anonymous, &pbMedia.GetHLSSegmentRequest{
+			VideoId: "keynote",
+			Index:   int32(i),
+			Token:   uri.Query().Get("token"),
+		})
+		require.NoError(t, err)
+		joined = append(joined, seg.Data...)
+	}
+	assert.Equal(t, data, joined)
+
+	// Tokens are bound to their video.
+	token := uris[0].Query().Get("token")
+	_, err = client.GetHLSSegment(anonymous, &pbMedia.GetHLSSegmentRequest{VideoId: "closing", Token: token})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = client.GetHLSSegment(anonymous, &pbMedia.GetHLSSegmentRequest{VideoId: "keynote", Token: token + "x"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// Videos that are not MPEG-TS only play as plain files.
+	_, err = client.GetHLSPlaylist(anonymous, &pbMedia.GetHLSPlaylistRequest{VideoId: "closing"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestHLSSegmentTokensRotateWithTheSecret(t *testing.T) {
+	srv := servertest.New(t, nil)
+	client := srv.Media()
+	uploadTS(t, client, servertest.Context(srv.CreateUser(t, "testuser", "testpass")), "keynote")
+
+	anonymous := context.Background()
+	resp, err := client.GetHLSPlaylist(anonymous, &pbMedia.GetHLSPlaylistRequest{VideoId: "keynote"})
+	require.NoError(t, err)
+	old := &pbMedia.GetHLSSegmentRequest{VideoId: "keynote", Token: segmentToken(t, resp.Playlist)}
+
+	// The retired secret still verifies during the grace period...
+	srv.Keys.Rotate([]byte("new-secret"), time.Hour)
+	_, err = client.GetHLSSegment(anonymous, old)
+	require.NoError(t, err)
+
+	// ...and no longer after it, while new playlists sign with the new one.
+	srv.Keys.EndGrace()
+	_, err = client.GetHLSSegment(anonymous, old)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	resp, err = client.GetHLSPlaylist(anonymous, &pbMedia.GetHLSPlaylistRequest{VideoId: "keynote"})
+	require.NoError(t, err)
+	_, err = client.GetHLSSegment(anonymous, &pbMedia.GetHLSSegmentRequest{VideoId: "keynote", Token: segmentToken(t, resp.Playlist)})
+	assert.NoError(t, err)
+}
+
+func TestEmbedLoadsHLSPlayerFromTheGateway(t *testing.T) {
+	script := []byte("window.Hls = undefined;")
+	sum := sha512.Sum384(script)
+	cfg := env.DefaultConfig()
+	cfg.HLSPlayerFile = filepath.Join(t.TempDir(), "hls.min.js")
+	require.NoError(t, os.WriteFile(cfg.HLSPlayerFile, script, 0o600))
+
+	srv := servertest.New(t, cfg)
+	uploadTS(t, srv.Media(), servertest.Context(srv.CreateUser(t, "testuser", "testpass")), "keynote")
+
+	resp := srv.Do(t, "GET", "/embed/keynote", "", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	page := html.UnescapeString(string(body))
+	assert.Contains(t, page, `integrity="sha384-`+base64.StdEncoding.EncodeToString(sum[:])+`"`)
+	assert.Contains(t, page, `crossorigin="anonymous"`)
+
+	start := strings.Index(page, `src="/embed/player/`)
+	require.GreaterOrEqual(t, start, 0, "the page loads the player from the gateway")
+	src := page[start+len(`src="`):]
+	resp = srv.Do(t, "GET", src[:strings.IndexByte(src, '"')], "", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, script, body)
+	assert.Contains(t, resp.Header.Get("Cache-Control"), "immutable")
+
+	// Without a player, browsers only play HLS natively.
+	srv = servertest.New(t, nil)
+	uploadTS(t, srv.Media(), servertest.Context(srv.CreateUser(t, "testuser", "testpass")), "keynote")
+	resp = srv.Do(t, "GET", "/embed/keynote", "", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.NotContains(t, string(body), "<script src=")
+}
+
+// segmentToken returns the token of the first segment in playlist.
+func segmentToken(t *testing.T, playlist string) string {
+	for line := range strings.Lines(playlist) {
+		if !strings.HasPrefix(line, "#") {
+			uri, err := url.Parse(strings.TrimSpace(line))
+			require.NoError(t, err)
+			return uri.Query().Get("token")
+		}
+	}
+	t.Fatal("playlist has no segments")
+	return ""
+}
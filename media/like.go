@@ -0,0 +1,31 @@
+package media
+
+import (
+	"coscup2025/proto/media"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// setLiked records whether userID likes the video and reports whether that
+// changed anything. The caller must hold mediaServer.mu for writing.
+func (v *VideoInfo) setLiked(userID string, liked bool) bool {
+	if _, ok := v.Likes[userID]; ok == liked {
+		return false
+	}
+	if liked {
+		if v.Likes == nil {
+			v.Likes = make(map[string]time.Time)
+		}
+		v.Likes[userID] = time.Now()
+	} else {
+		delete(v.Likes, userID)
+	}
+
+	// Metadata is shared with earlier responses, so replace it instead of
+	// modifying it in place.
+	metadata := proto.Clone(v.Metadata).(*media.VideoMetadata)
+	metadata.LikeCount = int64(len(v.Likes))
+	v.Metadata = metadata
+	return true
+}
@@ -0,0 +1,50 @@
+package media_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pbMedia "coscup2025/proto/media"
+)
+
+func TestLikes(t *testing.T) {
+	client, ctx := setupMediaClient(t)
+	uploadVideo(t, client, ctx, "keynote")
+	uploadVideo(t, client, ctx, "closing")
+
+	// Liking twice counts once.
+	for range 2 {
+		liked, err := client.LikeVideo(ctx, &pbMedia.LikeVideoRequest{VideoId: "keynote"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), liked.LikeCount)
+	}
+	_, err := client.LikeVideo(ctx, &pbMedia.LikeVideoRequest{VideoId: "closing"})
+	require.NoError(t, err)
+	_, err = client.LikeVideo(ctx, &pbMedia.LikeVideoRequest{VideoId: "missing"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	md, err := client.GetVideoMetadata(ctx, &pbMedia.GetVideoMetadataRequest{VideoId: "keynote"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), md.Metadata.LikeCount)
+
+	favorites, err := client.ListFavorites(ctx, &pbMedia.ListFavoritesRequest{})
+	require.NoError(t, err)
+	require.Len(t, favorites.Videos, 2)
+	assert.Equal(t, "closing", favorites.Videos[0].VideoId, "most recently liked first")
+	assert.Equal(t, "keynote", favorites.Videos[1].VideoId)
+
+	for range 2 {
+		unliked, err := client.UnlikeVideo(ctx, &pbMedia.UnlikeVideoRequest{VideoId: "keynote"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), unliked.LikeCount)
+	}
+
+	favorites, err = client.ListFavorites(ctx, &pbMedia.ListFavoritesRequest{})
+	require.NoError(t, err)
+	require.Len(t, favorites.Videos, 1)
+	assert.Equal(t, "closing", favorites.Videos[0].VideoId)
+}
@@ -0,0 +1,106 @@
+package media
+
+import (
+	"context"
+	"coscup2025/auth"
+	"coscup2025/proto/media"
+	"strconv"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultMaxMessageSize is gRPC's limit on a received message, which the
+// server keeps unless COSCUP_GRPC_MAX_RECV_MSG_SIZE is set.
+const defaultMaxMessageSize = 4 << 20
+
+// videoTooLargeError reports that a video exceeds the maximum size. The limit
+// is attached as ErrorInfo metadata so that clients can show it.
+func videoTooLargeError(limit int64) error {
+	msg := "video exceeds the maximum size of " + strconv.FormatInt(limit, 10) + " bytes"
+	st, err := status.New(grpccodes.InvalidArgument, msg).WithDetails(&errdetails.ErrorInfo{
+		Reason:   "VIDEO_TOO_LARGE",
+		Domain:   "media.coscup2025",
+		Metadata: map[string]string{"max_video_size": strconv.FormatInt(limit, 10)},
+	})
+	if err != nil {
+		return status.Error(grpccodes.InvalidArgument, msg)
+	}
+	return st.Err()
+}
+
+// exceedsMaxSize reports whether a video of size bytes is over the limit.
+func (s *mediaServer) exceedsMaxSize(size int64) bool {
+	return s.maxVideoSize > 0 && size > s.maxVideoSize
+}
+
+// startDownload counts a download stream of userID against the per-user cap,
+// failing with ResourceExhausted when the user already has as many open. The
+// stream calls the returned func when it ends. Anonymous downloads are not
+// counted, as there is no user to count them against.
+func (s *mediaServer) startDownload(userID string) (func(), error) {
+	if s.maxUserDownloads <= 0 || userID == "" {
+		return func() {}, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.downloads[userID] >= s.maxUserDownloads {
+		return nil, status.Errorf(grpccodes.ResourceExhausted,
+			"at most %d downloads may run at once, wait for one to finish", s.maxUserDownloads)
+	}
+	s.downloads[userID]++
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.downloads[userID]--; s.downloads[userID] == 0 {
+			delete(s.downloads, userID)
+		}
+	}, nil
+}
+
+// GetUploadLimits reports what an upload of the caller has to fit in. The
+// memory left changes with every upload, so it only tells whether one would
+// be admitted now.
+func (s *mediaServer) GetUploadLimits(ctx context.Context, req *media.GetUploadLimitsRequest) (*media.GetUploadLimitsResponse, error) {
+	_, span := s.tracer.Start(ctx, "GetUploadLimits")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("service.name", "media-service"),
+		attribute.String("rpc.method", "GetUploadLimits"),
+		attribute.String("rpc.service", "MediaService"),
+	)
+
+	id, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		err := status.Error(grpccodes.Unauthenticated, "missing identity")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "missing identity")
+		return nil, err
+	}
+
+	resp := &media.GetUploadLimitsResponse{
+		UserId:                   id.UserID,
+		MaxVideoSize:             s.maxVideoSize,
+		MaxMessageSize:           int64(s.maxMessageSize),
+		StreamMaxInFlight:        s.uploadMaxInFlight,
+		MemoryBudget:             s.memoryBudget,
+		StorageDegraded:          s.storageDegraded.Load(),
+		SessionIdleSeconds:       int64(uploadSessionTTL.Seconds()),
+		VideoVersioning:          s.videoVersioning,
+		MaxStreamDeadlineSeconds: int64(s.maxStreamDeadline.Seconds()),
+	}
+	if s.memoryBudget > 0 {
+		s.mu.RLock()
+		resp.MemoryAvailable = max(s.memoryBudget-s.memoryHeldLocked(), 0)
+		s.mu.RUnlock()
+	}
+
+	span.SetStatus(codes.Ok, "upload limits reported")
+	return resp, nil
+}
@@ -0,0 +1,124 @@
+package media
+
+import (
+	"context"
+	"coscup2025/proto/media"
+	"maps"
+	"slices"
+	"strings"
+
+	"golang.org/x/text/language"
+	"google.golang.org/grpc"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// acceptLanguageKey is the metadata with the caller's preferred languages.
+// The gateway forwards the Accept-Language header as it.
+const acceptLanguageKey = "accept-language"
+
+// normalizeLocalized checks that the keys of texts, the titles or
+// descriptions of a video, are language tags, and returns texts keyed by
+// their canonical form, e.g. "zh-tw" becomes "zh-TW".
+func normalizeLocalized(field string, texts map[string]string) (map[string]string, error) {
+	if len(texts) == 0 {
+		return nil, nil
+	}
+	out := make(map[string]string, len(texts))
+	for k, v := range texts {
+		tag, err := language.Parse(k)
+		if err != nil {
+			return nil, status.Errorf(grpccodes.InvalidArgument, "%s: %q is not a language tag", field, k)
+		}
+		out[tag.String()] = v
+	}
+	return out, nil
+}
+
+// normalizeTexts normalizes the titles and descriptions of an upload.
+func normalizeTexts(titles, descriptions map[string]string) (map[string]string, map[string]string, error) {
+	titles, err := normalizeLocalized("titles", titles)
+	if err != nil {
+		return nil, nil, err
+	}
+	descriptions, err = normalizeLocalized("descriptions", descriptions)
+	if err != nil {
+		return nil, nil, err
+	}
+	return titles, descriptions, nil
+}
+
+// localizer picks the titles and descriptions shown to a caller.
+type localizer struct {
+	prefs    []language.Tag
+	fallback language.Tag
+}
+
+// localizer returns the localizer for locale, a language tag or an
+// Accept-Language list, or for the caller's accept-language metadata when
+// locale is empty.
+func (s *mediaServer) localizer(ctx context.Context, locale string) localizer {
+	if locale == "" {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			locale = strings.Join(md.Get(acceptLanguageKey), ",")
+		}
+	}
+	// Malformed entries are skipped, as browsers send all sorts.
+	prefs, _, _ := language.ParseAcceptLanguage(locale)
+	return localizer{prefs: prefs, fallback: s.defaultLocale}
+}
+
+// localize returns md with title, description and locale set, or md itself
+// when it has no localized texts. md is shared with the stored video, so it
+// is copied before being changed.
+func (l localizer) localize(md *media.VideoMetadata) *media.VideoMetadata {
+	if len(md.Titles) == 0 && len(md.Descriptions) == 0 {
+		return md
+	}
+	md = proto.Clone(md).(*media.VideoMetadata)
+	var descriptionLocale string
+	md.Title, md.Locale = l.pick(md.Titles)
+	md.Description, descriptionLocale = l.pick(md.Descriptions)
+	if md.Locale == "" {
+		md.Locale = descriptionLocale
+	}
+	return md
+}
+
+// pick returns the text of texts in the language that best matches the
+// caller's, and that language. Without a match it falls back to the default
+// locale, or else to the first language in sort order.
+func (l localizer) pick(texts map[string]string) (string, string) {
+	if len(texts) == 0 {
+		return "", ""
+	}
+	keys := slices.Sorted(maps.Keys(texts))
+	tags := make([]language.Tag, len(keys))
+	for i, k := range keys {
+		tags[i] = language.Make(k)
+	}
+
+	// A matcher answers with its first tag when nothing matches.
+	if _, i, c := language.NewMatcher(tags).Match(l.fallback); c != language.No {
+		keys[0], keys[i] = keys[i], keys[0]
+		tags[0], tags[i] = tags[i], tags[0]
+	}
+	_, i, _ := language.NewMatcher(tags).Match(l.prefs...)
+	return texts[keys[i]], keys[i]
+}
+
+// setContentLanguage sends the distinct locales of videos as the
+// content-language header, which the gateway turns into Content-Language.
+func setContentLanguage(ctx context.Context, videos ...*media.VideoMetadata) {
+	var locales []string
+	for _, md := range videos {
+		if md.Locale != "" && !slices.Contains(locales, md.Locale) {
+			locales = append(locales, md.Locale)
+		}
+	}
+	if len(locales) > 0 {
+		grpc.SetHeader(ctx, metadata.Pairs("content-language", strings.Join(locales, ", ")))
+	}
+}
@@ -0,0 +1,85 @@
+package media_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pbMedia "coscup2025/proto/media"
+)
+
+func TestLocalizedMetadata(t *testing.T) {
+	client, ctx := setupMediaClient(t)
+
+	stream, err := client.UploadVideo(ctx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&pbMedia.UploadVideoRequest{
+		VideoId:      "opening",
+		Data:         []byte("opening"),
+		Sequence:     1,
+		Titles:       map[string]string{"zh-tw": "開幕", "en": "Opening"},
+		Descriptions: map[string]string{"en": "Welcome to COSCUP"},
+	}))
+	_, err = stream.CloseAndRecv()
+	require.NoError(t, err)
+
+	get := func(ctx context.Context, locale string) *pbMedia.VideoMetadata {
+		t.Helper()
+		resp, err := client.GetVideoMetadata(ctx, &pbMedia.GetVideoMetadataRequest{VideoId: "opening", Locale: locale})
+		require.NoError(t, err)
+		return resp.Metadata
+	}
+
+	md := get(ctx, "zh-Hant-TW")
+	assert.Equal(t, map[string]string{"zh-TW": "開幕", "en": "Opening"}, md.Titles)
+	assert.Equal(t, "開幕", md.Title)
+	assert.Equal(t, "zh-TW", md.Locale)
+	// Without a description in Chinese, the default locale's is shown.
+	assert.Equal(t, "Welcome to COSCUP", md.Description)
+
+	md = get(metadata.AppendToOutgoingContext(ctx, "accept-language", "zh-TW,zh;q=0.9,en;q=0.8"), "")
+	assert.Equal(t, "開幕", md.Title)
+	md = get(ctx, "")
+	assert.Equal(t, "Opening", md.Title)
+	assert.Equal(t, "en", md.Locale)
+	md = get(ctx, "fr, ja;q=0.5")
+	assert.Equal(t, "Opening", md.Title)
+
+	list, err := client.ListVideos(ctx, &pbMedia.ListVideosRequest{Locale: "zh-TW"})
+	require.NoError(t, err)
+	require.Len(t, list.Videos, 1)
+	assert.Equal(t, "開幕", list.Videos[0].Metadata.Title)
+
+	uploadVideo(t, client, ctx, "untitled")
+	resp, err := client.GetVideoMetadata(ctx, &pbMedia.GetVideoMetadataRequest{VideoId: "untitled", Locale: "en"})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Metadata.Title)
+	assert.Empty(t, resp.Metadata.Locale)
+
+	_, err = client.CreateUploadSession(ctx, &pbMedia.CreateUploadSessionRequest{
+		VideoId: "closing",
+		Titles:  map[string]string{"not a tag!": "Closing"},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Sessions keep the texts until the upload completes.
+	session, err := client.CreateUploadSession(ctx, &pbMedia.CreateUploadSessionRequest{
+		VideoId:   "closing",
+		TotalSize: 7,
+		Titles:    map[string]string{"zh-TW": "閉幕", "en": "Closing"},
+	})
+	require.NoError(t, err)
+	stream, err = client.UploadVideo(ctx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&pbMedia.UploadVideoRequest{VideoId: "closing", UploadId: session.Session.UploadId, Data: []byte("closing")}))
+	_, err = stream.CloseAndRecv()
+	require.NoError(t, err)
+	resp, err = client.GetVideoMetadata(ctx, &pbMedia.GetVideoMetadataRequest{VideoId: "closing", Locale: "zh-TW"})
+	require.NoError(t, err)
+	assert.Equal(t, "閉幕", resp.Metadata.Title)
+}
@@ -0,0 +1,123 @@
+package media
+
+import (
+	"time"
+
+	"coscup2025/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// uploadStreamsAborted counts upload streams that ended without storing
+	// their video, by the status code returned, e.g. Canceled when the client
+	// went away.
+	uploadStreamsAborted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "coscup",
+		Name:      "upload_streams_aborted_total",
+		Help:      "Upload streams that ended without storing their video, by gRPC status code.",
+	}, []string{"code"})
+	uploadDiscardedBytes = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "coscup",
+		Name:      "upload_discarded_bytes_total",
+		Help:      "Bytes of aborted uploads dropped: those received without a session, and those of sessions cancelled with AbortUpload.",
+	})
+	// chunksNacked counts the chunks UploadChunks asked for again, by the
+	// reason of the NACK.
+	chunksNacked = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "coscup",
+		Name:      "upload_chunks_nacked_total",
+		Help:      "Chunks of UploadChunks streams asked for again, by reason.",
+	}, []string{"reason"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(uploadStreamsAborted, uploadDiscardedBytes, chunksNacked)
+}
+
+var (
+	storedBytesDesc = prometheus.NewDesc("coscup_stored_bytes",
+		"Bytes of stored videos by tenant.", []string{"tenant"}, nil)
+	storedVideosDesc = prometheus.NewDesc("coscup_stored_videos",
+		"Number of stored videos by tenant.", []string{"tenant"}, nil)
+	uploadSessionsDesc = prometheus.NewDesc("coscup_upload_sessions",
+		"Unexpired resumable upload sessions, by whether a stream is writing to them.", []string{"state"}, nil)
+	uploadSessionBytesDesc = prometheus.NewDesc("coscup_upload_session_bytes",
+		"Bytes buffered in unfinished upload sessions.", nil, nil)
+	memoryHeldDesc = prometheus.NewDesc("coscup_memory_held_bytes",
+		"Bytes counted against the memory budget: stored videos, thumbnails and admitted uploads.", nil, nil)
+	scrubFinishedDesc = prometheus.NewDesc("coscup_scrub_last_finished_timestamp_seconds",
+		"When the last scrub of the video storage finished.", nil, nil)
+	scrubBlobsDesc = prometheus.NewDesc("coscup_scrub_last_blobs",
+		"Blobs the last scrub checked, found corrupt, repaired, or removed as orphaned.", []string{"result"}, nil)
+)
+
+// storageCollector reports storage usage computed from the stored videos at
+// scrape time, so the numbers cannot drift from the actual state.
+type storageCollector struct {
+	s *mediaServer
+}
+
+// StorageCollector returns a collector for the server's storage and upload
+// session gauges.
+func (s *mediaServer) StorageCollector() prometheus.Collector {
+	return storageCollector{s: s}
+}
+
+func (c storageCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- storedBytesDesc
+	ch <- storedVideosDesc
+	ch <- uploadSessionsDesc
+	ch <- uploadSessionBytesDesc
+	ch <- memoryHeldDesc
+	ch <- scrubFinishedDesc
+	ch <- scrubBlobsDesc
+}
+
+func (c storageCollector) Collect(ch chan<- prometheus.Metric) {
+	c.s.mu.RLock()
+	bytes := make(map[string]int64)
+	videos := make(map[string]int)
+	for _, v := range c.s.videos {
+		tenant := v.Tenant
+		if tenant == "" {
+			tenant = "default"
+		}
+		bytes[tenant] += v.Metadata.FileSize
+		videos[tenant]++
+	}
+
+	now := time.Now()
+	var streaming, pending int
+	var buffered int64
+	for _, session := range c.s.sessions {
+		if !session.active && now.After(session.expiresAt) {
+			continue
+		}
+		if session.active {
+			streaming++
+		} else {
+			pending++
+		}
+		buffered += int64(len(session.data))
+	}
+	held := c.s.memoryHeldLocked()
+	c.s.mu.RUnlock()
+
+	for tenant, n := range bytes {
+		ch <- prometheus.MustNewConstMetric(storedBytesDesc, prometheus.GaugeValue, float64(n), tenant)
+		ch <- prometheus.MustNewConstMetric(storedVideosDesc, prometheus.GaugeValue, float64(videos[tenant]), tenant)
+	}
+	ch <- prometheus.MustNewConstMetric(uploadSessionsDesc, prometheus.GaugeValue, float64(streaming), "streaming")
+	ch <- prometheus.MustNewConstMetric(uploadSessionsDesc, prometheus.GaugeValue, float64(pending), "pending")
+	ch <- prometheus.MustNewConstMetric(uploadSessionBytesDesc, prometheus.GaugeValue, float64(buffered))
+	ch <- prometheus.MustNewConstMetric(memoryHeldDesc, prometheus.GaugeValue, float64(held))
+
+	if _, last := c.s.ScrubStatus(); last != nil {
+		ch <- prometheus.MustNewConstMetric(scrubFinishedDesc, prometheus.GaugeValue, float64(last.FinishedAt))
+		ch <- prometheus.MustNewConstMetric(scrubBlobsDesc, prometheus.GaugeValue, float64(last.CheckedBlobs), "checked")
+		ch <- prometheus.MustNewConstMetric(scrubBlobsDesc, prometheus.GaugeValue, float64(len(last.CorruptBlobs)), "corrupt")
+		ch <- prometheus.MustNewConstMetric(scrubBlobsDesc, prometheus.GaugeValue, float64(last.RepairedBlobs), "repaired")
+		ch <- prometheus.MustNewConstMetric(scrubBlobsDesc, prometheus.GaugeValue, float64(last.OrphanedBlobs), "orphaned")
+	}
+}
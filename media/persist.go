@@ -0,0 +1,188 @@
+package media
+
+import (
+	"bytes"
+	"coscup2025/proto/media"
+	"coscup2025/storage"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// videoRecord is what the storage index keeps about a video besides its
+// content: the metadata as of the upload, and the uploader's tenant.
+type videoRecord struct {
+	Tenant   string          `json:"tenant,omitempty"`
+	Metadata json.RawMessage `json:"metadata"`
+}
+
+// BlobStore keeps blobs with an index, such as a storage.Store on disk or an
+// s3store.Store in a bucket.
+type BlobStore interface {
+	Put(r io.Reader) (string, int64, error)
+	OpenBlob(hash string) (storage.Blob, error)
+	Records() map[string]storage.Record
+	Record(key string) (storage.Record, bool)
+	Set(key string, rec storage.Record) error
+	Delete(key string) error
+	Discard(hash string)
+}
+
+// PersistVideos keeps uploaded videos in st from now on, and loads the videos
+// a previous run stored there. It returns how many were loaded. Videos are
+// still served from memory. Takedowns and the channel and visibility of a
+// video are persisted with its metadata; likes and channels are not.
+func (s *mediaServer) PersistVideos(st *storage.Store) (int, error) {
+	return s.persistVideos(st, false)
+}
+
+// OffloadVideos keeps uploaded videos in st like PersistVideos, but serves
+// them from st rather than memory: the bytes of a video are only held while
+// it is uploaded, and downloads read the ranges they send from st.
+func (s *mediaServer) OffloadVideos(st BlobStore) (int, error) {
+	return s.persistVideos(st, true)
+}
+
+func (s *mediaServer) persistVideos(st BlobStore, offload bool) (int, error) {
+	videos := make(map[string]*VideoInfo)
+	for videoID, rec := range st.Records() {
+		v, err := loadVideo(st, rec, offload)
+		if err != nil {
+			return 0, fmt.Errorf("video %s: %v", videoID, err)
+		}
+		videos[videoID] = v
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for videoID, v := range videos {
+		s.videos[videoID] = v
+	}
+	s.videoStore = st
+	s.offload = offload
+	return len(videos), nil
+}
+
+func loadVideo(st BlobStore, rec storage.Record, offload bool) (*VideoInfo, error) {
+	var vr videoRecord
+	if err := json.Unmarshal(rec.Meta, &vr); err != nil {
+		return nil, fmt.Errorf("invalid record: %v", err)
+	}
+	metadata := &media.VideoMetadata{}
+	if err := protojson.Unmarshal(vr.Metadata, metadata); err != nil {
+		return nil, fmt.Errorf("invalid metadata: %v", err)
+	}
+
+	f, err := st.OpenBlob(rec.Blob)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	v := &VideoInfo{Metadata: metadata, Tenant: vr.Tenant}
+	if offload {
+		v.blob = rec.Blob
+		// Only MPEG-TS is split for HLS, which needs all of it once
+		if !maybeTS(f, rec.Size) {
+			return v, nil
+		}
+	}
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, err
+	}
+	v.segments = segmentTS(data, hlsTargetDuration)
+	if !offload {
+		v.Data = data
+	}
+	return v, nil
+}
+
+// putVideo writes a finished upload's content to the video store, returning
+// the index record to commit with commitVideoLocked. Without a store it
+// returns nil.
+func (s *mediaServer) putVideo(data []byte, metadata *media.VideoMetadata, tenant string) (*storage.Record, error) {
+	if s.videoStore == nil {
+		return nil, nil
+	}
+	meta, err := marshalVideoRecord(metadata, tenant)
+	if err != nil {
+		return nil, err
+	}
+	hash, size, err := s.videoStore.Put(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	return &storage.Record{Blob: hash, Size: size, Meta: meta}, nil
+}
+
+// marshalVideoRecord encodes the index record of a video.
+func marshalVideoRecord(metadata *media.VideoMetadata, tenant string) ([]byte, error) {
+	md, err := protojson.Marshal(metadata)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(videoRecord{Tenant: tenant, Metadata: md})
+}
+
+// storeMetadataLocked rewrites the index record of a stored video with its
+// current metadata, so that a change such as a takedown survives a restart.
+// The caller must hold s.mu.
+func (s *mediaServer) storeMetadataLocked(videoID string, v *VideoInfo) error {
+	if s.videoStore == nil {
+		return nil
+	}
+	rec, ok := s.videoStore.Record(videoID)
+	if !ok {
+		return nil
+	}
+	meta, err := marshalVideoRecord(v.Metadata, v.Tenant)
+	if err != nil {
+		return err
+	}
+	rec.Meta = meta
+	return s.videoStore.Set(videoID, rec)
+}
+
+// commitVideoLocked points videoID at a record from putVideo. It runs under
+// s.mu together with the update of s.videos, so that the index and the
+// served videos agree when the same ID is uploaded twice at once.
+func (s *mediaServer) commitVideoLocked(videoID string, rec *storage.Record) error {
+	if rec == nil {
+		return nil
+	}
+	return s.videoStore.Set(videoID, *rec)
+}
+
+// offloadLocked drops the content of a video that was committed to the video
+// store, which serves it from now on. The caller must hold s.mu.
+func (s *mediaServer) offloadLocked(v *VideoInfo, rec *storage.Record) {
+	if !s.offload || rec == nil {
+		return
+	}
+	v.Data = nil
+	v.blob = rec.Blob
+}
+
+// removeVideoLocked deletes a video from memory, the video store and the
+// playlists. Downloads in flight hold a snapshot and finish undisturbed. The
+// caller must hold s.mu.
+func (s *mediaServer) removeVideoLocked(videoID string) {
+	delete(s.videos, videoID)
+	s.forgetVideoLocked(videoID)
+	s.removeFromPlaylists(videoID)
+}
+
+// forgetVideoLocked removes a deleted video from the video store. The caller
+// must hold s.mu.
+func (s *mediaServer) forgetVideoLocked(videoID string) {
+	if s.videoStore == nil {
+		return
+	}
+	if err := s.videoStore.Delete(videoID); err != nil {
+		log.Printf("Failed to remove video %s from storage: %v", videoID, err)
+	}
+}
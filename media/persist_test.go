@@ -0,0 +1,201 @@
+package media_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"coscup2025/env"
+	"coscup2025/keyset"
+	"coscup2025/media"
+	pbAdmin "coscup2025/proto/admin"
+	pbMedia "coscup2025/proto/media"
+	pbModeration "coscup2025/proto/moderation"
+	"coscup2025/s3test"
+	"coscup2025/servertest"
+	"coscup2025/storage"
+)
+
+func TestVideosSurviveRestart(t *testing.T) {
+	cfg := env.DefaultConfig()
+	cfg.StorageDir = t.TempDir()
+	cfg.AdminUsers = []string{"mod"}
+
+	// Step 1: upload videos, delete one of them, take one down and make
+	// one private
+	srv := servertest.New(t, cfg)
+	client, ctx := srv.Media(), servertest.Context(srv.CreateUser(t, "testuser", "testpass"))
+	mod := servertest.Context(srv.CreateUser(t, "mod", "modpass"))
+	uploadVideo(t, client, ctx, "kept")
+	uploadVideo(t, client, ctx, "deleted")
+	_, err := client.DeleteVideo(ctx, &pbMedia.DeleteVideoRequest{VideoId: "deleted"})
+	require.NoError(t, err)
+	uploadVideo(t, client, ctx, "taken-down")
+	report, err := pbModeration.NewModerationServiceClient(srv.Conn).ReportVideo(mod, &pbModeration.ReportVideoRequest{VideoId: "taken-down", Reason: pbModeration.ReportReason_REPORT_REASON_SPAM})
+	require.NoError(t, err)
+	for _, state := range []pbModeration.CaseState{pbModeration.CaseState_CASE_STATE_UNDER_REVIEW, pbModeration.CaseState_CASE_STATE_TAKEN_DOWN} {
+		_, err = pbAdmin.NewAdminServiceClient(srv.Conn).TransitionCase(mod, &pbAdmin.TransitionCaseRequest{CaseId: report.CaseId, State: state})
+		require.NoError(t, err)
+	}
+	uploadVideo(t, client, ctx, "private")
+	channel, err := client.CreateChannel(ctx, &pbMedia.CreateChannelRequest{
+		Name:              "Rehearsals",
+		DefaultVisibility: pbMedia.Visibility_VISIBILITY_PRIVATE,
+	})
+	require.NoError(t, err)
+	_, err = client.AssignVideoToChannel(ctx, &pbMedia.AssignVideoToChannelRequest{ChannelId: channel.Channel.ChannelId, VideoId: "private"})
+	require.NoError(t, err)
+
+	// Step 2: a new server loads the remaining video with its metadata
+	client, ctx = setupMediaClientWithConfig(t, cfg)
+	stream, err := client.DownloadVideo(ctx, &pbMedia.DownloadVideoRequest{VideoId: "kept"})
+	require.NoError(t, err)
+	chunk, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, []byte("kept"), chunk.Data)
+	require.Equal(t, "testuser", chunk.Metadata.UploaderName)
+	summary, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, int64(4), summary.Stats.GetBytes())
+	_, err = stream.Recv()
+	require.Equal(t, io.EOF, err)
+
+	_, err = client.GetVideoMetadata(ctx, &pbMedia.GetVideoMetadataRequest{VideoId: "deleted"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	// Step 3: the takedown and the private visibility still hold, for
+	// strangers but not for the uploader
+	anonymous := context.Background()
+	_, err = client.GetEmbed(anonymous, &pbMedia.GetEmbedRequest{VideoId: "kept"})
+	require.NoError(t, err)
+	for _, videoID := range []string{"taken-down", "private"} {
+		_, err = client.GetEmbed(anonymous, &pbMedia.GetEmbedRequest{VideoId: videoID})
+		require.Equal(t, codes.NotFound, status.Code(err), videoID)
+	}
+	meta, err := client.GetVideoMetadata(ctx, &pbMedia.GetVideoMetadataRequest{VideoId: "taken-down"})
+	require.NoError(t, err)
+	require.True(t, meta.Metadata.TakenDown)
+	meta, err = client.GetVideoMetadata(ctx, &pbMedia.GetVideoMetadataRequest{VideoId: "private"})
+	require.NoError(t, err)
+	require.Equal(t, pbMedia.Visibility_VISIBILITY_PRIVATE, meta.Metadata.Visibility)
+}
+
+func TestVideosOffloadedToS3(t *testing.T) {
+	bucket := s3test.New(t)
+	cfg := env.DefaultConfig()
+	bucket.Configure(cfg)
+
+	// Step 1: uploads are stored in the bucket
+	client, ctx := setupMediaClientWithConfig(t, cfg)
+	uploadVideo(t, client, ctx, "kept")
+	uploadVideo(t, client, ctx, "deleted")
+	_, err := client.DeleteVideo(ctx, &pbMedia.DeleteVideoRequest{VideoId: "deleted"})
+	require.NoError(t, err)
+	meta, err := client.GetVideoMetadata(ctx, &pbMedia.GetVideoMetadataRequest{VideoId: "kept"})
+	require.NoError(t, err)
+	key := "videos/" + storage.BlobKey(meta.Metadata.Sha256)
+	require.Equal(t, []string{"avatars/format", key, "videos/format", "videos/index.json"}, bucket.Keys())
+
+	// Step 2: a new server reads the remaining video from the bucket in
+	// ranges
+	client, ctx = setupMediaClientWithConfig(t, cfg)
+	before := len(bucket.Requests())
+	stream, err := client.DownloadVideo(ctx, &pbMedia.DownloadVideoRequest{VideoId: "kept"})
+	require.NoError(t, err)
+	chunk, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, []byte("kept"), chunk.Data)
+	require.Equal(t, "testuser", chunk.Metadata.UploaderName)
+	_, err = stream.Recv() // summary
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.Equal(t, io.EOF, err)
+	var ranged []string
+	for _, r := range bucket.Requests()[before:] {
+		if r.Method == "GET" && r.Key == key {
+			ranged = append(ranged, r.Range)
+		}
+	}
+	require.Len(t, ranged, 1)
+	require.True(t, strings.HasPrefix(ranged[0], "bytes=0-"), ranged[0])
+
+	// Step 3: a ranged download reads from its offset
+	before = len(bucket.Requests())
+	stream, err = client.DownloadVideo(ctx, &pbMedia.DownloadVideoRequest{VideoId: "kept", Offset: 1, Length: 2})
+	require.NoError(t, err)
+	chunk, err = stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, []byte("ep"), chunk.Data)
+	requests := bucket.Requests()[before:]
+	require.Len(t, requests, 1)
+	require.True(t, strings.HasPrefix(requests[0].Range, "bytes=1-"), requests[0].Range)
+
+	_, err = client.GetVideoMetadata(ctx, &pbMedia.GetVideoMetadataRequest{VideoId: "deleted"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestDownloadLink(t *testing.T) {
+	cfg := env.DefaultConfig()
+	cfg.StorageDir = t.TempDir()
+	client, ctx := setupMediaClientWithConfig(t, cfg)
+	uploadVideo(t, client, ctx, "talk")
+
+	// Step 1: without a CDN the link leads to the gateway
+	link, err := client.CreateDownloadLink(ctx, &pbMedia.CreateDownloadLinkRequest{VideoId: "talk"})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(link.Url, "/v1/video/file/talk?access_token="), link.Url)
+
+	// Step 2: with a CDN it leads to the stored blob
+	cfg.CDNProvider = "fastly"
+	cfg.CDNBaseURL = "https://cdn.example.com"
+	cfg.CDNSigningKey = "secret"
+	client, ctx = setupMediaClientWithConfig(t, cfg)
+	link, err = client.CreateDownloadLink(ctx, &pbMedia.CreateDownloadLinkRequest{VideoId: "talk"})
+	require.NoError(t, err)
+	meta, err := client.GetVideoMetadata(ctx, &pbMedia.GetVideoMetadataRequest{VideoId: "talk"})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(link.Url, "https://cdn.example.com/"+storage.BlobKey(meta.Metadata.Sha256)+"?token="), link.Url)
+	require.Greater(t, link.ExpiresAt, time.Now().Unix())
+
+	_, err = client.CreateDownloadLink(ctx, &pbMedia.CreateDownloadLinkRequest{VideoId: "missing"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestScrubRepairsCorruptBlob(t *testing.T) {
+	cfg := env.DefaultConfig()
+	cfg.StorageDir = t.TempDir()
+	client, ctx := setupMediaClientWithConfig(t, cfg)
+	uploadVideo(t, client, ctx, "talk")
+	meta, err := client.GetVideoMetadata(ctx, &pbMedia.GetVideoMetadataRequest{VideoId: "talk"})
+	require.NoError(t, err)
+
+	// Load the video into a new server, then damage its blob on disk.
+	st, err := storage.Open(cfg.StorageDir)
+	require.NoError(t, err)
+	srv := media.NewMediaServer(cfg, keyset.New([]byte(cfg.JWTSecret)))
+	_, err = srv.PersistVideos(st)
+	require.NoError(t, err)
+	blob := filepath.Join(cfg.StorageDir, filepath.FromSlash(storage.BlobKey(meta.Metadata.Sha256)))
+	require.NoError(t, os.WriteFile(blob, []byte("tAlk"), 0o600))
+
+	started, err := srv.StartScrub()
+	require.NoError(t, err)
+	require.True(t, started)
+	require.Eventually(t, func() bool {
+		running, _ := srv.ScrubStatus()
+		return !running
+	}, 5*time.Second, 10*time.Millisecond)
+
+	_, report := srv.ScrubStatus()
+	require.Equal(t, []string{meta.Metadata.Sha256}, report.CorruptBlobs)
+	require.EqualValues(t, 1, report.RepairedBlobs)
+	require.NoError(t, st.Verify(meta.Metadata.Sha256))
+}
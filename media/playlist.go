@@ -0,0 +1,108 @@
+package media
+
+import (
+	"coscup2025/proto/media"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// playlist is an ordered collection of videos. All fields are guarded by
+// mediaServer.mu.
+type playlist struct {
+	id          string
+	title       string
+	description string
+	ownerID     string
+	videoIDs    []string
+	createdAt   time.Time
+	updatedAt   time.Time
+}
+
+func newPlaylistID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return "pl_" + hex.EncodeToString(b)
+}
+
+// playlistProto returns p with the metadata of its videos. The caller must
+// hold s.mu.
+func (s *mediaServer) playlistProto(p *playlist) *media.Playlist {
+	pb := &media.Playlist{
+		PlaylistId:  p.id,
+		Title:       p.title,
+		Description: p.description,
+		OwnerId:     p.ownerID,
+		CreatedAt:   p.createdAt.Unix(),
+		UpdatedAt:   p.updatedAt.Unix(),
+	}
+	for _, videoID := range p.videoIDs {
+		summary := &media.VideoSummary{VideoId: videoID}
+		if info, ok := s.videos[videoID]; ok {
+			summary.Metadata = info.Metadata
+		}
+		pb.Videos = append(pb.Videos, summary)
+	}
+	return pb
+}
+
+// lookupOwnPlaylist returns a playlist owned by userID. The caller must hold s.mu.
+func (s *mediaServer) lookupOwnPlaylist(playlistID, userID string) (*playlist, error) {
+	p, ok := s.playlists[playlistID]
+	if !ok {
+		return nil, status.Error(grpccodes.NotFound, "playlist not found")
+	}
+	if p.ownerID != userID {
+		return nil, status.Error(grpccodes.PermissionDenied, "only the owner may change this playlist")
+	}
+	return p, nil
+}
+
+// checkPlaylistVideos verifies that videoIDs exist and are listed once. The
+// caller must hold s.mu.
+func (s *mediaServer) checkPlaylistVideos(videoIDs []string) error {
+	seen := make(map[string]bool, len(videoIDs))
+	for _, videoID := range videoIDs {
+		if _, ok := s.videos[videoID]; !ok {
+			return status.Errorf(grpccodes.NotFound, "video %s not found", videoID)
+		}
+		if seen[videoID] {
+			return status.Errorf(grpccodes.InvalidArgument, "video %s is listed twice", videoID)
+		}
+		seen[videoID] = true
+	}
+	return nil
+}
+
+// removeFromPlaylists drops a deleted video from every playlist. The caller
+// must hold s.mu.
+func (s *mediaServer) removeFromPlaylists(videoID string) {
+	for _, p := range s.playlists {
+		for i, id := range p.videoIDs {
+			if id == videoID {
+				p.videoIDs = append(p.videoIDs[:i], p.videoIDs[i+1:]...)
+				p.updatedAt = time.Now()
+				break
+			}
+		}
+	}
+}
+
+// insertAt places videoID at the 1-based position, or at the end for 0.
+func insertAt(videoIDs []string, videoID string, position int32) ([]string, error) {
+	if position < 0 || int(position) > len(videoIDs)+1 {
+		return nil, status.Error(grpccodes.InvalidArgument, fmt.Sprintf("position must be between 1 and %d, or 0 to append", len(videoIDs)+1))
+	}
+	if position == 0 {
+		return append(videoIDs, videoID), nil
+	}
+	i := int(position) - 1
+	videoIDs = append(videoIDs, "")
+	copy(videoIDs[i+1:], videoIDs[i:])
+	videoIDs[i] = videoID
+	return videoIDs, nil
+}
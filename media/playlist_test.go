@@ -0,0 +1,69 @@
+package media_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pbMedia "coscup2025/proto/media"
+)
+
+func uploadVideo(t *testing.T, client pbMedia.MediaServiceClient, ctx context.Context, videoID string) {
+	stream, err := client.UploadVideo(ctx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&pbMedia.UploadVideoRequest{VideoId: videoID, Data: []byte(videoID), Sequence: 1}))
+	_, err = stream.CloseAndRecv()
+	require.NoError(t, err)
+}
+
+func playlistOrder(p *pbMedia.Playlist) []string {
+	var ids []string
+	for _, v := range p.Videos {
+		ids = append(ids, v.VideoId)
+	}
+	return ids
+}
+
+func TestPlaylist(t *testing.T) {
+	client, ctx := setupMediaClient(t)
+	for _, id := range []string{"opening", "keynote", "closing"} {
+		uploadVideo(t, client, ctx, id)
+	}
+
+	created, err := client.CreatePlaylist(ctx, &pbMedia.CreatePlaylistRequest{
+		Title:    "Day 1 Main Track",
+		VideoIds: []string{"opening", "closing"},
+	})
+	require.NoError(t, err)
+	playlistID := created.Playlist.PlaylistId
+
+	added, err := client.AddToPlaylist(ctx, &pbMedia.AddToPlaylistRequest{PlaylistId: playlistID, VideoId: "keynote", Position: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"opening", "keynote", "closing"}, playlistOrder(added.Playlist))
+
+	_, err = client.AddToPlaylist(ctx, &pbMedia.AddToPlaylistRequest{PlaylistId: playlistID, VideoId: "keynote"})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	_, err = client.ReorderPlaylist(ctx, &pbMedia.ReorderPlaylistRequest{PlaylistId: playlistID, VideoIds: []string{"closing", "opening"}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	reordered, err := client.ReorderPlaylist(ctx, &pbMedia.ReorderPlaylistRequest{
+		PlaylistId: playlistID,
+		VideoIds:   []string{"keynote", "opening", "closing"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"keynote", "opening", "closing"}, playlistOrder(reordered.Playlist))
+
+	// Deleted videos disappear from playlists.
+	_, err = client.DeleteVideo(ctx, &pbMedia.DeleteVideoRequest{VideoId: "opening"})
+	require.NoError(t, err)
+	got, err := client.GetPlaylist(ctx, &pbMedia.GetPlaylistRequest{PlaylistId: playlistID})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"keynote", "closing"}, playlistOrder(got.Playlist))
+	assert.Equal(t, "Day 1 Main Track", got.Playlist.Title)
+	assert.NotNil(t, got.Playlist.Videos[0].Metadata)
+}
@@ -0,0 +1,26 @@
+package media
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// fallocKeepSize is FALLOC_FL_KEEP_SIZE: reserve blocks without changing
+// the file size, so the size still tells how many bytes were written.
+const fallocKeepSize = 0x1
+
+// preallocate reserves disk space for the first size bytes of f, so that a
+// full disk is reported before an upload starts rather than near its end, and
+// large recordings are laid out contiguously. Filesystems that cannot
+// preallocate are left to allocate as data arrives.
+func preallocate(f *os.File, size int64) error {
+	if size <= 0 {
+		return nil
+	}
+	err := syscall.Fallocate(int(f.Fd()), fallocKeepSize, 0, size)
+	if errors.Is(err, syscall.EOPNOTSUPP) || errors.Is(err, syscall.ENOSYS) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,33 @@
+package media_test
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"coscup2025/env"
+	pbMedia "coscup2025/proto/media"
+)
+
+func TestUploadSessionPreallocates(t *testing.T) {
+	cfg := env.DefaultConfig()
+	cfg.UploadSessionDir = t.TempDir()
+	client, ctx := setupMediaClientWithConfig(t, cfg)
+
+	const size = 4 << 20
+	resp, err := client.CreateUploadSession(ctx, &pbMedia.CreateUploadSessionRequest{VideoId: "big", TotalSize: size})
+	require.NoError(t, err)
+
+	info, err := os.Stat(filepath.Join(cfg.UploadSessionDir, resp.Session.UploadId+".part"))
+	require.NoError(t, err)
+	// The size still counts written bytes only.
+	require.Zero(t, info.Size())
+	blocks := info.Sys().(*syscall.Stat_t).Blocks
+	if blocks == 0 {
+		t.Skip("filesystem does not support preallocation")
+	}
+	require.GreaterOrEqual(t, blocks*512, int64(size))
+}
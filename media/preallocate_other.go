@@ -0,0 +1,11 @@
+//go:build !linux
+
+package media
+
+import "os"
+
+// preallocate is a no-op where the server cannot reserve space up front, and
+// disk space is allocated as data arrives.
+func preallocate(f *os.File, size int64) error {
+	return nil
+}
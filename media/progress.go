@@ -0,0 +1,105 @@
+package media
+
+import (
+	"coscup2025/proto/media"
+	"sync"
+	"time"
+)
+
+// progressHub fans out progress events to WatchProgress subscribers. An
+// upload's events only go to its uploader.
+type progressHub struct {
+	mu   sync.Mutex
+	subs map[string]map[chan *media.ProgressEvent]string // by video ID, to the subscriber's user ID
+	// current is the last event of each upload that has not reached a
+	// terminal state, including paused resumable ones.
+	current map[progressKey]*media.ProgressEvent
+}
+
+type progressKey struct {
+	videoID  string
+	uploader string
+}
+
+func newProgressHub() *progressHub {
+	return &progressHub{
+		subs:    make(map[string]map[chan *media.ProgressEvent]string),
+		current: make(map[progressKey]*media.ProgressEvent),
+	}
+}
+
+// subscribe also returns the last event of userID's upload of videoID in
+// progress, if any, so that a subscriber starts from where the upload is.
+func (h *progressHub) subscribe(videoID, userID string) (<-chan *media.ProgressEvent, *media.ProgressEvent, func()) {
+	ch := make(chan *media.ProgressEvent, 16)
+
+	h.mu.Lock()
+	if h.subs[videoID] == nil {
+		h.subs[videoID] = make(map[chan *media.ProgressEvent]string)
+	}
+	h.subs[videoID][ch] = userID
+	current := h.current[progressKey{videoID, userID}]
+	h.mu.Unlock()
+
+	return ch, current, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		delete(h.subs[videoID], ch)
+		if len(h.subs[videoID]) == 0 {
+			delete(h.subs, videoID)
+		}
+	}
+}
+
+// publish sends an event of an upload by uploaderID. It never blocks the
+// publisher: when a subscriber falls behind, its oldest pending event is
+// dropped in favour of the newest one.
+func (h *progressHub) publish(uploaderID string, ev *media.ProgressEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	key := progressKey{ev.VideoId, uploaderID}
+	if isTerminalState(ev.State) {
+		delete(h.current, key)
+	} else {
+		h.current[key] = ev
+	}
+	for ch, userID := range h.subs[ev.VideoId] {
+		if userID != uploaderID {
+			continue
+		}
+		select {
+		case ch <- ev:
+		default:
+			select {
+			case <-ch:
+			default:
+			}
+			select {
+			case ch <- ev:
+			default:
+			}
+		}
+	}
+}
+
+func newProgressEvent(videoID string, state media.VideoState, received, total int64) *media.ProgressEvent {
+	ev := &media.ProgressEvent{
+		VideoId:       videoID,
+		State:         state,
+		BytesReceived: received,
+		TotalBytes:    total,
+		Timestamp:     time.Now().Unix(),
+	}
+	if total > 0 {
+		ev.Percent = min(float64(received)/float64(total)*100, 100)
+	}
+	if state == media.VideoState_VIDEO_STATE_READY {
+		ev.Percent = 100
+	}
+	return ev
+}
+
+func isTerminalState(state media.VideoState) bool {
+	return state == media.VideoState_VIDEO_STATE_READY || state == media.VideoState_VIDEO_STATE_FAILED
+}
@@ -0,0 +1,155 @@
+package media_test
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"coscup2025/env"
+	pbMedia "coscup2025/proto/media"
+	"coscup2025/servertest"
+)
+
+func TestWatchProgressHeartbeats(t *testing.T) {
+	cfg := env.DefaultConfig()
+	cfg.StreamHeartbeatInterval = 20 * time.Millisecond
+	client, ctx := setupMediaClientWithConfig(t, cfg)
+
+	watch, err := client.WatchProgress(ctx, &pbMedia.WatchProgressRequest{VideoId: "talk"})
+	require.NoError(t, err)
+
+	// Step 1: before the upload starts, heartbeats report nothing yet
+	ev, err := watch.Recv()
+	require.NoError(t, err)
+	require.True(t, ev.Heartbeat)
+	require.Equal(t, pbMedia.VideoState_VIDEO_STATE_UNSPECIFIED, ev.State)
+
+	// Step 2: once chunks arrive, heartbeats repeat the last progress
+	created, err := client.CreateUploadSession(ctx, &pbMedia.CreateUploadSessionRequest{VideoId: "talk", TotalSize: 10})
+	require.NoError(t, err)
+	stream, err := client.UploadVideo(ctx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&pbMedia.UploadVideoRequest{VideoId: "talk", UploadId: created.Session.UploadId, Data: []byte("coscup")}))
+	for {
+		ev, err = watch.Recv()
+		require.NoError(t, err)
+		if !ev.Heartbeat && ev.State == pbMedia.VideoState_VIDEO_STATE_UPLOADING {
+			break
+		}
+	}
+	ev, err = watch.Recv()
+	require.NoError(t, err)
+	require.True(t, ev.Heartbeat)
+	require.Equal(t, pbMedia.VideoState_VIDEO_STATE_UPLOADING, ev.State)
+	require.Equal(t, int64(6), ev.BytesReceived)
+
+	// Step 3: a heartbeat from the uploader is not a chunk
+	require.NoError(t, stream.Send(&pbMedia.UploadVideoRequest{VideoId: "talk", UploadId: created.Session.UploadId, Offset: 6, Heartbeat: true}))
+	require.NoError(t, stream.Send(&pbMedia.UploadVideoRequest{VideoId: "talk", UploadId: created.Session.UploadId, Offset: 6, Data: []byte("2025")}))
+	resp, err := stream.CloseAndRecv()
+	require.NoError(t, err)
+	require.Equal(t, int64(10), resp.TotalBytes)
+	require.Equal(t, int64(2), resp.Stats.ChunkCount)
+}
+
+func TestWatchProgressDuringReupload(t *testing.T) {
+	client, ctx := setupMediaClientWithConfig(t, env.DefaultConfig())
+	_, err := replaceVideo(client, ctx, "talk", "first", false)
+	require.NoError(t, err)
+
+	// Step 1: a watcher of a stored video that is being uploaded again
+	// starts from the new upload rather than READY
+	created, err := client.CreateUploadSession(ctx, &pbMedia.CreateUploadSessionRequest{VideoId: "talk", TotalSize: 10, Overwrite: true})
+	require.NoError(t, err)
+	stream, err := client.UploadVideo(ctx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&pbMedia.UploadVideoRequest{VideoId: "talk", UploadId: created.Session.UploadId, Data: []byte("coscup")}))
+	require.Eventually(t, func() bool {
+		watch, err := client.WatchProgress(ctx, &pbMedia.WatchProgressRequest{VideoId: "talk"})
+		require.NoError(t, err)
+		ev, err := watch.Recv()
+		require.NoError(t, err)
+		return ev.State == pbMedia.VideoState_VIDEO_STATE_UPLOADING && ev.BytesReceived == 6
+	}, time.Second, 10*time.Millisecond)
+
+	// Step 2: the rest of the upload is processed, then ready
+	watch, err := client.WatchProgress(ctx, &pbMedia.WatchProgressRequest{VideoId: "talk"})
+	require.NoError(t, err)
+	ev, err := watch.Recv()
+	require.NoError(t, err)
+	require.Equal(t, pbMedia.VideoState_VIDEO_STATE_UPLOADING, ev.State)
+	require.NoError(t, stream.Send(&pbMedia.UploadVideoRequest{VideoId: "talk", UploadId: created.Session.UploadId, Offset: 6, Data: []byte("2025")}))
+	_, err = stream.CloseAndRecv()
+	require.NoError(t, err)
+	var states []pbMedia.VideoState
+	for {
+		ev, err = watch.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		states = append(states, ev.State)
+	}
+	require.Equal(t, []pbMedia.VideoState{
+		pbMedia.VideoState_VIDEO_STATE_UPLOADING,
+		pbMedia.VideoState_VIDEO_STATE_PROCESSING,
+		pbMedia.VideoState_VIDEO_STATE_READY,
+	}, states)
+}
+
+func TestWatchProgressHonorsVisibility(t *testing.T) {
+	cfg := env.DefaultConfig()
+	cfg.StreamHeartbeatInterval = 20 * time.Millisecond
+	srv := servertest.New(t, cfg)
+	client := srv.Media()
+	speaker := servertest.Context(srv.CreateUser(t, "speaker", "secret"))
+	other := servertest.Context(srv.CreateUser(t, "other", "secret"))
+
+	// Step 1: a private video is not found for anyone but its uploader
+	uploadVideo(t, client, speaker, "rehearsal")
+	private, err := client.CreateChannel(speaker, &pbMedia.CreateChannelRequest{
+		Name:              "Backstage",
+		DefaultVisibility: pbMedia.Visibility_VISIBILITY_PRIVATE,
+	})
+	require.NoError(t, err)
+	_, err = client.AssignVideoToChannel(speaker, &pbMedia.AssignVideoToChannelRequest{ChannelId: private.Channel.ChannelId, VideoId: "rehearsal"})
+	require.NoError(t, err)
+	watch, err := client.WatchProgress(other, &pbMedia.WatchProgressRequest{VideoId: "rehearsal"})
+	require.NoError(t, err)
+	_, err = watch.Recv()
+	require.Equal(t, codes.NotFound, status.Code(err))
+	watch, err = client.WatchProgress(speaker, &pbMedia.WatchProgressRequest{VideoId: "rehearsal"})
+	require.NoError(t, err)
+	ev, err := watch.Recv()
+	require.NoError(t, err)
+	require.Equal(t, pbMedia.VideoState_VIDEO_STATE_READY, ev.State)
+
+	// Step 2: the progress of an upload only goes to its uploader
+	mine, err := client.WatchProgress(speaker, &pbMedia.WatchProgressRequest{VideoId: "talk"})
+	require.NoError(t, err)
+	theirs, err := client.WatchProgress(other, &pbMedia.WatchProgressRequest{VideoId: "talk"})
+	require.NoError(t, err)
+	created, err := client.CreateUploadSession(speaker, &pbMedia.CreateUploadSessionRequest{VideoId: "talk", TotalSize: 10})
+	require.NoError(t, err)
+	stream, err := client.UploadVideo(speaker)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&pbMedia.UploadVideoRequest{VideoId: "talk", UploadId: created.Session.UploadId, Data: []byte("coscup")}))
+	for {
+		ev, err = mine.Recv()
+		require.NoError(t, err)
+		if ev.State == pbMedia.VideoState_VIDEO_STATE_UPLOADING {
+			break
+		}
+	}
+	for range 3 {
+		ev, err = theirs.Recv()
+		require.NoError(t, err)
+		require.True(t, ev.Heartbeat)
+		require.Equal(t, pbMedia.VideoState_VIDEO_STATE_UNSPECIFIED, ev.State)
+		require.Zero(t, ev.BytesReceived)
+	}
+}
@@ -0,0 +1,194 @@
+package media
+
+import (
+	"cmp"
+	"context"
+	"coscup2025/proto/admin"
+	"fmt"
+	"log"
+	"slices"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// retentionState holds the retention rules, see admin.RetentionRule.
+type retentionState struct {
+	mu      sync.Mutex
+	rules   []*admin.RetentionRule // in the order they were created
+	nextID  int64
+	nextRun time.Time // zero unless enforcement is scheduled
+}
+
+// SetRetentionRule stores rule, replacing the rule with its ID or creating
+// one when the ID is empty. It reports false for an unknown ID.
+func (s *mediaServer) SetRetentionRule(rule *admin.RetentionRule) (*admin.RetentionRule, bool) {
+	s.retention.mu.Lock()
+	defer s.retention.mu.Unlock()
+
+	rule = proto.Clone(rule).(*admin.RetentionRule)
+	if rule.RuleId == "" {
+		s.retention.nextID++
+		rule.RuleId = fmt.Sprintf("r_%d", s.retention.nextID)
+		rule.CreatedAt = time.Now().Unix()
+		s.retention.rules = append(s.retention.rules, rule)
+		return proto.Clone(rule).(*admin.RetentionRule), true
+	}
+	i := slices.IndexFunc(s.retention.rules, func(r *admin.RetentionRule) bool { return r.RuleId == rule.RuleId })
+	if i < 0 {
+		return nil, false
+	}
+	rule.CreatedAt = s.retention.rules[i].CreatedAt
+	s.retention.rules[i] = rule
+	return proto.Clone(rule).(*admin.RetentionRule), true
+}
+
+// RetentionRules returns the retention rules in the order they were created.
+func (s *mediaServer) RetentionRules() []*admin.RetentionRule {
+	s.retention.mu.Lock()
+	defer s.retention.mu.Unlock()
+
+	rules := make([]*admin.RetentionRule, len(s.retention.rules))
+	for i, r := range s.retention.rules {
+		rules[i] = proto.Clone(r).(*admin.RetentionRule)
+	}
+	return rules
+}
+
+// DeleteRetentionRule removes a rule and reports whether it existed.
+func (s *mediaServer) DeleteRetentionRule(ruleID string) bool {
+	s.retention.mu.Lock()
+	defer s.retention.mu.Unlock()
+
+	n := len(s.retention.rules)
+	s.retention.rules = slices.DeleteFunc(s.retention.rules, func(r *admin.RetentionRule) bool { return r.RuleId == ruleID })
+	return len(s.retention.rules) < n
+}
+
+// PreviewRetention returns when the next enforcement runs, or now when none
+// is scheduled, and the videos it would delete then.
+func (s *mediaServer) PreviewRetention() (time.Time, []*admin.ExpiredVideo) {
+	s.retention.mu.Lock()
+	at := s.retention.nextRun
+	s.retention.mu.Unlock()
+	if at.IsZero() {
+		at = time.Now()
+	}
+	return at, s.expiredVideos(at)
+}
+
+// EnforceRetentionPeriodically deletes the videos the retention rules expire
+// every interval until ctx is done.
+func (s *mediaServer) EnforceRetentionPeriodically(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	s.scheduleRetention(time.Now().Add(interval))
+	for {
+		select {
+		case <-ctx.Done():
+			s.scheduleRetention(time.Time{})
+			return
+		case now := <-ticker.C:
+			s.scheduleRetention(now.Add(interval))
+			if n := s.enforceRetention(now); n > 0 {
+				log.Printf("Retention rules deleted %d videos", n)
+			}
+		}
+	}
+}
+
+func (s *mediaServer) scheduleRetention(at time.Time) {
+	s.retention.mu.Lock()
+	defer s.retention.mu.Unlock()
+	s.retention.nextRun = at
+}
+
+// enforceRetention deletes the videos that are expired at now and returns
+// how many it deleted.
+func (s *mediaServer) enforceRetention(now time.Time) int {
+	rules := s.RetentionRules()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expired := s.expiredVideosLocked(rules, now)
+	for _, e := range expired {
+		s.removeVideoLocked(e.VideoId)
+	}
+	return len(expired)
+}
+
+// expiredVideos returns the videos the retention rules delete at now, oldest
+// first.
+func (s *mediaServer) expiredVideos(now time.Time) []*admin.ExpiredVideo {
+	rules := s.RetentionRules()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.expiredVideosLocked(rules, now)
+}
+
+// expiredVideosLocked is expiredVideos for the given rules. The caller must
+// hold s.mu.
+func (s *mediaServer) expiredVideosLocked(rules []*admin.RetentionRule, now time.Time) []*admin.ExpiredVideo {
+	if len(rules) == 0 {
+		return nil
+	}
+	var expired []*admin.ExpiredVideo
+	for videoID, v := range s.videos {
+		rule := governingRule(rules, v.Tenant, v.Metadata.UploaderId)
+		if rule == nil || rule.MaxAgeSeconds == 0 {
+			continue
+		}
+		uploaded := time.Unix(v.Metadata.UploadTimestamp, 0)
+		if now.Sub(uploaded) < time.Duration(rule.MaxAgeSeconds)*time.Second {
+			continue
+		}
+		expired = append(expired, &admin.ExpiredVideo{
+			VideoId:    videoID,
+			Tenant:     v.Tenant,
+			UploaderId: v.Metadata.UploaderId,
+			UploadedAt: v.Metadata.UploadTimestamp,
+			SizeBytes:  v.Metadata.FileSize,
+			RuleId:     rule.RuleId,
+		})
+	}
+	slices.SortFunc(expired, func(a, b *admin.ExpiredVideo) int {
+		return cmp.Or(cmp.Compare(a.UploadedAt, b.UploadedAt), cmp.Compare(a.VideoId, b.VideoId))
+	})
+	return expired
+}
+
+// governingRule returns the rule that decides how long a video of tenant and
+// uploaderID is kept, or nil when none applies: the most specific match, and
+// of equally specific ones the one keeping videos longest.
+func governingRule(rules []*admin.RetentionRule, tenant, uploaderID string) *admin.RetentionRule {
+	var best *admin.RetentionRule
+	bestSpecificity := -1
+	for _, r := range rules {
+		if (r.Tenant != "" && r.Tenant != tenant) || (r.UploaderId != "" && r.UploaderId != uploaderID) {
+			continue
+		}
+		specificity := 0
+		if r.UploaderId != "" {
+			specificity += 2
+		}
+		if r.Tenant != "" {
+			specificity++
+		}
+		if specificity < bestSpecificity || (specificity == bestSpecificity && !keepsLonger(r, best)) {
+			continue
+		}
+		best, bestSpecificity = r, specificity
+	}
+	return best
+}
+
+// keepsLonger reports whether a keeps videos longer than b.
+func keepsLonger(a, b *admin.RetentionRule) bool {
+	if b.MaxAgeSeconds == 0 {
+		return false
+	}
+	return a.MaxAgeSeconds == 0 || a.MaxAgeSeconds > b.MaxAgeSeconds
+}
@@ -0,0 +1,153 @@
+package media
+
+import (
+	"coscup2025/proto/media"
+	mediav2 "coscup2025/proto/media/v2"
+	"hash/crc32"
+	"io"
+
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxChunkRetransmits bounds how often the chunk at one offset is asked for
+// again before the stream fails, so that a client sending wrong checksums
+// does not loop forever.
+const maxChunkRetransmits = 8
+
+func (v *v2Server) UploadChunks(stream mediav2.MediaService_UploadChunksServer) error {
+	first, err := stream.Recv()
+	if err == io.EOF {
+		return status.Error(grpccodes.InvalidArgument, "no chunk sent")
+	}
+	if err != nil {
+		return err
+	}
+	resp, err := v.s.GetUploadSession(stream.Context(), &media.GetUploadSessionRequest{UploadId: first.UploadId})
+	if err != nil {
+		return err
+	}
+	return v.s.UploadVideo(&chunkStream{
+		MediaService_UploadChunksServer: stream,
+		next:                            first,
+		uploadID:                        first.UploadId,
+		videoID:                         resp.Session.VideoId,
+		expected:                        resp.Session.CommittedBytes,
+		acked:                           resp.Session.CommittedBytes,
+		nacks:                           make(map[int64]int),
+	})
+}
+
+// chunkStream turns the chunks of UploadChunks into those of the first
+// version. Only the chunk at the next expected offset with a matching
+// checksum is passed on. Others are dropped, with a NACK for the first of
+// them, until the client sends from the expected offset again.
+type chunkStream struct {
+	mediav2.MediaService_UploadChunksServer
+	next     *mediav2.UploadChunksRequest // received but not yet returned
+	uploadID string
+	videoID  string
+	sequence int64
+
+	expected int64 // offset of the next chunk to pass on
+	acked    int64 // committed bytes last acknowledged
+	// resending is set from a NACK until the chunk at expected arrives.
+	resending bool
+	nacks     map[int64]int // by offset
+	dropped   int64         // bytes received but not passed on
+}
+
+func (c *chunkStream) Recv() (*media.UploadVideoRequest, error) {
+	// The handler commits a chunk before it receives the next one.
+	if c.expected > c.acked {
+		c.acked = c.expected
+		if err := c.Send(&mediav2.UploadChunksResponse{Frame: &mediav2.UploadChunksResponse_Ack{
+			Ack: &mediav2.ChunkAck{CommittedBytes: c.acked},
+		}}); err != nil {
+			return nil, err
+		}
+	}
+
+	for {
+		chunk := c.next
+		c.next = nil
+		if chunk == nil {
+			var err error
+			if chunk, err = c.MediaService_UploadChunksServer.Recv(); err != nil {
+				return nil, err
+			}
+		}
+		if chunk.UploadId != c.uploadID {
+			return nil, status.Error(grpccodes.InvalidArgument, "a stream writes to a single upload session")
+		}
+		if chunk.Heartbeat {
+			return &media.UploadVideoRequest{VideoId: c.videoID, Heartbeat: true}, nil
+		}
+
+		accepted, reason := c.check(chunk)
+		if accepted {
+			c.sequence++
+			req := &media.UploadVideoRequest{
+				VideoId:  c.videoID,
+				UploadId: c.uploadID,
+				Offset:   c.expected,
+				Data:     chunk.Data[c.expected-chunk.Offset:],
+				Sequence: c.sequence,
+			}
+			c.expected += int64(len(req.Data))
+			c.resending = false
+			return req, nil
+		}
+
+		c.dropped += int64(len(chunk.Data))
+		if reason == mediav2.ChunkNackReason_CHUNK_NACK_REASON_UNSPECIFIED {
+			continue
+		}
+		if err := c.nack(reason); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// check reports whether chunk carries the bytes from the expected offset on,
+// possibly after bytes already passed on. Otherwise it returns the reason to
+// ask for them again, if any: chunks that end before the expected offset are
+// duplicates, and chunks past it that were in flight when a NACK was sent
+// are dropped quietly.
+func (c *chunkStream) check(chunk *mediav2.UploadChunksRequest) (bool, mediav2.ChunkNackReason) {
+	end := chunk.Offset + int64(len(chunk.Data))
+	switch {
+	case chunk.Offset > c.expected:
+		if c.resending {
+			return false, mediav2.ChunkNackReason_CHUNK_NACK_REASON_UNSPECIFIED
+		}
+		return false, mediav2.ChunkNackReason_CHUNK_NACK_REASON_MISSING
+	case chunk.Offset < c.expected && end <= c.expected:
+		return false, mediav2.ChunkNackReason_CHUNK_NACK_REASON_UNSPECIFIED
+	case crc32.Checksum(chunk.Data, castagnoli) != chunk.Crc32C:
+		return false, mediav2.ChunkNackReason_CHUNK_NACK_REASON_CHECKSUM_MISMATCH
+	}
+	return true, mediav2.ChunkNackReason_CHUNK_NACK_REASON_UNSPECIFIED
+}
+
+// nack asks for the chunks from the expected offset on again.
+func (c *chunkStream) nack(reason mediav2.ChunkNackReason) error {
+	c.nacks[c.expected]++
+	if c.nacks[c.expected] > maxChunkRetransmits {
+		return status.Errorf(grpccodes.DataLoss, "the chunk at offset %d was sent again %d times without arriving intact", c.expected, maxChunkRetransmits)
+	}
+	chunksNacked.WithLabelValues(reason.String()).Inc()
+	c.resending = true
+	return c.Send(&mediav2.UploadChunksResponse{Frame: &mediav2.UploadChunksResponse_Nack{
+		Nack: &mediav2.ChunkNack{Offset: c.expected, Reason: reason},
+	}})
+}
+
+// SendAndClose sends the result of the upload, counting the dropped bytes as
+// retransmitted.
+func (c *chunkStream) SendAndClose(resp *media.UploadVideoResponse) error {
+	if resp.Stats != nil {
+		resp.Stats.RetransmittedBytes += c.dropped
+	}
+	return c.Send(&mediav2.UploadChunksResponse{Frame: &mediav2.UploadChunksResponse_Result{Result: resp}})
+}
@@ -0,0 +1,129 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"coscup2025/proto/admin"
+	"coscup2025/storage"
+	"errors"
+	"log"
+	"sync"
+	"time"
+)
+
+// scrubTempAge is how long a temporary file of the video store must be left
+// untouched before a scrub removes it.
+const scrubTempAge = time.Hour
+
+var errNoVideoStore = errors.New("videos are not stored on disk")
+
+// diskStore returns the video store if it is on disk, which scrubs verify.
+func (s *mediaServer) diskStore() *storage.Store {
+	st, _ := s.videoStore.(*storage.Store)
+	return st
+}
+
+// scrubState tracks the scrubs of the video store.
+type scrubState struct {
+	mu      sync.Mutex
+	running bool
+	last    *admin.ScrubReport
+}
+
+// StartScrub verifies the video store in the background, unless a scrub is
+// already running, and reports whether it started one.
+func (s *mediaServer) StartScrub() (bool, error) {
+	if s.diskStore() == nil {
+		return false, errNoVideoStore
+	}
+	s.scrub.mu.Lock()
+	defer s.scrub.mu.Unlock()
+	if s.scrub.running {
+		return false, nil
+	}
+	s.scrub.running = true
+	go s.runScrub(context.Background())
+	return true, nil
+}
+
+// ScrubStatus reports whether a scrub is running and the report of the last
+// one, nil until one finished.
+func (s *mediaServer) ScrubStatus() (bool, *admin.ScrubReport) {
+	s.scrub.mu.Lock()
+	defer s.scrub.mu.Unlock()
+	return s.scrub.running, s.scrub.last
+}
+
+// ScrubPeriodically starts a scrub every interval until ctx is done.
+func (s *mediaServer) ScrubPeriodically(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.StartScrub(); err != nil {
+				log.Printf("Failed to start scrub: %v", err)
+			}
+		}
+	}
+}
+
+func (s *mediaServer) runScrub(ctx context.Context) {
+	started := time.Now()
+	st := s.diskStore()
+	r, err := st.Scrub(ctx, scrubTempAge)
+	repaired := s.repairBlobs(st, r.Corrupt)
+
+	report := &admin.ScrubReport{
+		StartedAt:     started.Unix(),
+		FinishedAt:    time.Now().Unix(),
+		CheckedBlobs:  int64(r.Checked),
+		CheckedBytes:  r.Bytes,
+		CorruptBlobs:  r.Corrupt,
+		RepairedBlobs: int64(repaired),
+		OrphanedBlobs: int64(r.Orphans),
+		TempFiles:     int64(r.Temp),
+		EmptyDirs:     int64(r.Dirs),
+	}
+	if err != nil {
+		log.Printf("Scrub stopped early: %v", err)
+		report.Error = err.Error()
+	}
+	if len(r.Corrupt) > 0 {
+		log.Printf("Scrub quarantined %d corrupt blobs and repaired %d of them", len(r.Corrupt), repaired)
+	}
+
+	s.scrub.mu.Lock()
+	s.scrub.running = false
+	s.scrub.last = report
+	s.scrub.mu.Unlock()
+}
+
+// repairBlobs stores quarantined blobs again from the videos in memory with
+// the same content, and returns how many it repaired.
+func (s *mediaServer) repairBlobs(st *storage.Store, hashes []string) int {
+	repaired := 0
+	for _, hash := range hashes {
+		var data []byte
+		s.mu.RLock()
+		for _, v := range s.videos {
+			if v.Metadata.Sha256 == hash {
+				data = v.Data
+				break
+			}
+		}
+		s.mu.RUnlock()
+		if data == nil {
+			log.Printf("Cannot repair blob %s: no video in memory has its content", hash)
+			continue
+		}
+		if err := st.Repair(hash, bytes.NewReader(data)); err != nil {
+			log.Printf("Failed to repair blob %s: %v", hash, err)
+			continue
+		}
+		repaired++
+	}
+	return repaired
+}
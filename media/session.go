@@ -0,0 +1,220 @@
+package media
+
+import (
+	"context"
+	"coscup2025/auth"
+	"coscup2025/proto/media"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// uploadSessionTTL is how long an idle resumable upload is kept.
+const uploadSessionTTL = 24 * time.Hour
+
+// uploadSession holds the bytes committed so far for a resumable upload.
+// All fields are guarded by mediaServer.mu.
+type uploadSession struct {
+	id        string
+	videoID   string
+	ownerID   string
+	totalSize int64
+	tags      []string
+	// titles and descriptions are by language tag, see normalizeLocalized.
+	titles       map[string]string
+	descriptions map[string]string
+	overwrite    bool // see replacedVideoLocked
+	fileName     string
+	data         []byte
+	sums         *checksums // of data; written only by the stream holding the session
+	// received counts the bytes of every chunk streams sent, committed or
+	// not, so that the bytes sent twice are received - len(data). It is
+	// written only by the stream holding the session.
+	received int64
+	attempts int64 // streams that claimed the session
+	active   bool
+	// aborted is set by AbortUpload on a session a stream still holds. The
+	// stream fails with errUploadAborted and removes its files on release.
+	aborted   bool
+	expiresAt time.Time
+
+	// streamDeadline is how long each stream writing to the session may
+	// run, as granted by CreateUploadSession. Zero sets no deadline.
+	streamDeadline time.Duration
+}
+
+// errUploadAborted ends a stream whose session was cancelled with
+// AbortUpload. It is not transient, so clients do not resume.
+var errUploadAborted = status.Error(grpccodes.Canceled, "upload session was aborted")
+
+func (u *uploadSession) proto(maxVideoSize int64) *media.UploadSession {
+	return &media.UploadSession{
+		UploadId:              u.id,
+		VideoId:               u.videoID,
+		TotalSize:             u.totalSize,
+		CommittedBytes:        int64(len(u.data)),
+		ExpiresAt:             u.expiresAt.Unix(),
+		MaxVideoSize:          maxVideoSize,
+		StreamDeadlineSeconds: int64(u.streamDeadline.Seconds()),
+	}
+}
+
+// grantStreamDeadline returns the stream deadline granted for a request of
+// seconds, at most s.maxStreamDeadline.
+func (s *mediaServer) grantStreamDeadline(seconds int64) time.Duration {
+	if seconds >= int64(s.maxStreamDeadline.Seconds()) {
+		return s.maxStreamDeadline
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// streamDeadlineError ends a stream that ran past the deadline granted to
+// its session. Its chunks are committed, so the client resumes on a new
+// stream.
+func streamDeadlineError(session *uploadSession) error {
+	return status.Errorf(grpccodes.DeadlineExceeded, "upload stream ran past its deadline of %s, resume from offset %d", session.streamDeadline, len(session.data))
+}
+
+func newUploadID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// pruneUploadSessions drops expired sessions, whose uploads then fail. The
+// caller must hold s.mu.
+func (s *mediaServer) pruneUploadSessions(now time.Time) {
+	for id, session := range s.sessions {
+		if !session.active && now.After(session.expiresAt) {
+			s.dropUploadSessionLocked(id)
+			s.progress.publish(session.ownerID, newProgressEvent(session.videoID, media.VideoState_VIDEO_STATE_FAILED, int64(len(session.data)), session.totalSize))
+		}
+	}
+}
+
+// dropUploadSessionLocked forgets a session, on disk too when sessions are
+// persisted. The caller must hold s.mu.
+func (s *mediaServer) dropUploadSessionLocked(id string) {
+	delete(s.sessions, id)
+	s.sessionStore.remove(id)
+}
+
+// lookupUploadSession returns the caller's session. The caller must hold s.mu.
+func (s *mediaServer) lookupUploadSession(ctx context.Context, uploadID string) (*uploadSession, error) {
+	session, exists := s.sessions[uploadID]
+	if !exists || time.Now().After(session.expiresAt) {
+		return nil, status.Error(grpccodes.NotFound, "upload session not found")
+	}
+
+	id, ok := auth.IdentityFromContext(ctx)
+	if !ok || id.UserID != session.ownerID {
+		return nil, status.Error(grpccodes.PermissionDenied, "upload session belongs to another user")
+	}
+
+	return session, nil
+}
+
+// claimUploadSession marks a session as being written by a stream so that two
+// streams cannot append to it concurrently.
+func (s *mediaServer) claimUploadSession(ctx context.Context, uploadID, videoID string) (*uploadSession, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, err := s.lookupUploadSession(ctx, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	if session.videoID != videoID {
+		return nil, status.Error(grpccodes.InvalidArgument, "upload session belongs to another video")
+	}
+	if session.active {
+		return nil, status.Error(grpccodes.Aborted, "upload session is already in use by another stream")
+	}
+
+	session.active = true
+	session.attempts++
+	return session, nil
+}
+
+// commitUploadSession records data received so far. When sessions are
+// persisted, the new bytes are written to disk before they count as
+// committed.
+func (s *mediaServer) commitUploadSession(session *uploadSession, data []byte) error {
+	if s.uploadAborted(session) {
+		return errUploadAborted
+	}
+	expiresAt := time.Now().Add(uploadSessionTTL)
+	// session.data only changes here, by the stream holding the session, so
+	// it can be read without the lock.
+	err := s.writeChunk(session, data[len(session.data):])
+	if err == nil {
+		err = s.sessionStore.commit(session, data[len(session.data):], int64(len(data)), expiresAt)
+	}
+	if err != nil {
+		// The chunk is already hashed. Start the checksums over from the
+		// committed bytes, which is only needed after a failed write.
+		sums := newChecksums()
+		sums.Write(session.data)
+		session.sums = sums
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if session.aborted {
+		return errUploadAborted
+	}
+	session.data = data
+	session.expiresAt = expiresAt
+	return nil
+}
+
+// uploadAborted reports whether AbortUpload cancelled the session.
+func (s *mediaServer) uploadAborted(session *uploadSession) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return session.aborted
+}
+
+// abortUploadSession drops the caller's session and returns its committed
+// bytes. A session held by a stream keeps its files until the stream lets
+// go, since the stream may be writing them.
+func (s *mediaServer) abortUploadSession(ctx context.Context, uploadID string) (*uploadSession, int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, err := s.lookupUploadSession(ctx, uploadID)
+	if err != nil {
+		return nil, 0, err
+	}
+	discarded := int64(len(session.data))
+	if session.active {
+		session.aborted = true
+		delete(s.sessions, session.id)
+	} else {
+		s.dropUploadSessionLocked(session.id)
+	}
+	return session, discarded, nil
+}
+
+// writeChunk passes a chunk to the backend, if any, before it is committed.
+func (s *mediaServer) writeChunk(session *uploadSession, chunk []byte) error {
+	if s.backend == nil {
+		return nil
+	}
+	return s.backend.WriteChunk(session.id, int64(len(session.data)), chunk)
+}
+
+func (s *mediaServer) releaseUploadSession(session *uploadSession) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session.active = false
+	if session.aborted {
+		s.sessionStore.remove(session.id)
+	}
+}
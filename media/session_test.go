@@ -0,0 +1,443 @@
+package media_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"coscup2025/env"
+	"coscup2025/metrics"
+	pbMedia "coscup2025/proto/media"
+	"coscup2025/servertest"
+)
+
+func setupMediaClient(t *testing.T) (pbMedia.MediaServiceClient, context.Context) {
+	return setupMediaClientWithConfig(t, env.DefaultConfig())
+}
+
+func setupMediaClientWithConfig(t *testing.T, cfg *env.Config) (pbMedia.MediaServiceClient, context.Context) {
+	srv := servertest.New(t, cfg)
+	return srv.Media(), servertest.Context(srv.CreateUser(t, "testuser", "testpass"))
+}
+
+func TestResumableUpload(t *testing.T) {
+	client, ctx := setupMediaClient(t)
+	video := bytes.Repeat([]byte("coscup"), 1000)
+
+	created, err := client.CreateUploadSession(ctx, &pbMedia.CreateUploadSessionRequest{
+		VideoId:   "talk",
+		TotalSize: int64(len(video)),
+	})
+	require.NoError(t, err)
+	uploadID := created.Session.UploadId
+
+	// Step 1: send the first half and drop the stream
+	streamCtx, cancel := context.WithCancel(ctx)
+	stream, err := client.UploadVideo(streamCtx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&pbMedia.UploadVideoRequest{
+		VideoId:  "talk",
+		UploadId: uploadID,
+		Data:     video[:3000],
+	}))
+	require.Eventually(t, func() bool {
+		resp, err := client.GetUploadSession(ctx, &pbMedia.GetUploadSessionRequest{UploadId: uploadID})
+		return err == nil && resp.Session.CommittedBytes == 3000
+	}, time.Second, 10*time.Millisecond)
+	cancel()
+
+	// Step 2: a wrong offset is rejected once the dropped stream is released
+	require.Eventually(t, func() bool {
+		stream, err = client.UploadVideo(ctx)
+		require.NoError(t, err)
+		stream.Send(&pbMedia.UploadVideoRequest{VideoId: "talk", UploadId: uploadID, Offset: 0, Data: video[:10]})
+		_, err = stream.CloseAndRecv()
+		return status.Code(err) == codes.FailedPrecondition
+	}, time.Second, 10*time.Millisecond)
+
+	// Step 3: resume from the committed offset
+	stream, err = client.UploadVideo(ctx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&pbMedia.UploadVideoRequest{
+		VideoId:  "talk",
+		UploadId: uploadID,
+		Offset:   3000,
+		Data:     video[3000:],
+	}))
+	resp, err := stream.CloseAndRecv()
+	require.NoError(t, err)
+	require.Equal(t, int64(len(video)), resp.TotalBytes)
+	// The checksums carried over from the dropped stream cover the whole video
+	require.Equal(t, fmt.Sprintf("%x", sha256.Sum256(video)), resp.Metadata.Sha256)
+	require.Equal(t, crc32.Checksum(video, crc32.MakeTable(crc32.Castagnoli)), resp.Metadata.Crc32C)
+
+	// Step 4: the session is gone once the video is stored
+	_, err = client.GetUploadSession(ctx, &pbMedia.GetUploadSessionRequest{UploadId: uploadID})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestAbortUpload(t *testing.T) {
+	cfg := env.DefaultConfig()
+	cfg.MemoryBudget = 10000
+	cfg.UploadSessionDir = t.TempDir()
+	client, ctx := setupMediaClientWithConfig(t, cfg)
+	video := bytes.Repeat([]byte("coscup"), 1000)
+
+	// Step 1: a session holds its announced size until it is aborted
+	created, err := client.CreateUploadSession(ctx, &pbMedia.CreateUploadSessionRequest{VideoId: "talk", TotalSize: int64(len(video))})
+	require.NoError(t, err)
+	uploadID := created.Session.UploadId
+
+	stream, err := client.UploadVideo(ctx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&pbMedia.UploadVideoRequest{VideoId: "talk", UploadId: uploadID, Data: video[:3000]}))
+	require.Eventually(t, func() bool {
+		resp, err := client.GetUploadSession(ctx, &pbMedia.GetUploadSessionRequest{UploadId: uploadID})
+		return err == nil && resp.Session.CommittedBytes == 3000
+	}, time.Second, 10*time.Millisecond)
+
+	// Step 2: aborting while the stream is still open ends the stream
+	aborted, err := client.AbortUpload(ctx, &pbMedia.AbortUploadRequest{UploadId: uploadID})
+	require.NoError(t, err)
+	require.Equal(t, int64(3000), aborted.DiscardedBytes)
+	stream.Send(&pbMedia.UploadVideoRequest{VideoId: "talk", UploadId: uploadID, Offset: 3000, Data: video[3000:]})
+	_, err = stream.CloseAndRecv()
+	require.Equal(t, codes.Canceled, status.Code(err))
+
+	// Step 3: the session, its files and its share of the budget are gone
+	_, err = client.GetUploadSession(ctx, &pbMedia.GetUploadSessionRequest{UploadId: uploadID})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	_, err = client.AbortUpload(ctx, &pbMedia.AbortUploadRequest{UploadId: uploadID})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	files, err := os.ReadDir(cfg.UploadSessionDir)
+	require.NoError(t, err)
+	require.Empty(t, files)
+	_, err = client.CreateUploadSession(ctx, &pbMedia.CreateUploadSessionRequest{VideoId: "other", TotalSize: 9000})
+	require.NoError(t, err)
+}
+
+func TestUploadStreamDeadline(t *testing.T) {
+	cfg := env.DefaultConfig()
+	cfg.MaxUploadStreamDeadline = time.Second
+	client, ctx := setupMediaClientWithConfig(t, cfg)
+	video := bytes.Repeat([]byte("coscup"), 1000)
+
+	// Step 1: requests are granted up to the configured limit
+	limits, err := client.GetUploadLimits(ctx, &pbMedia.GetUploadLimitsRequest{})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), limits.MaxStreamDeadlineSeconds)
+	created, err := client.CreateUploadSession(ctx, &pbMedia.CreateUploadSessionRequest{VideoId: "other", TotalSize: 1})
+	require.NoError(t, err)
+	require.Zero(t, created.Session.StreamDeadlineSeconds)
+	created, err = client.CreateUploadSession(ctx, &pbMedia.CreateUploadSessionRequest{
+		VideoId:               "talk",
+		TotalSize:             int64(len(video)),
+		StreamDeadlineSeconds: 3600,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), created.Session.StreamDeadlineSeconds)
+	uploadID := created.Session.UploadId
+
+	// Step 2: a stream running past the deadline ends after committing its
+	// last chunk
+	stream, err := client.UploadVideo(ctx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&pbMedia.UploadVideoRequest{VideoId: "talk", UploadId: uploadID, Data: video[:3000]}))
+	time.Sleep(1100 * time.Millisecond)
+	require.NoError(t, stream.Send(&pbMedia.UploadVideoRequest{VideoId: "talk", UploadId: uploadID, Offset: 3000, Data: video[3000:]}))
+	_, err = stream.CloseAndRecv()
+	require.Equal(t, codes.DeadlineExceeded, status.Code(err), err)
+	got, err := client.GetUploadSession(ctx, &pbMedia.GetUploadSessionRequest{UploadId: uploadID})
+	require.NoError(t, err)
+	require.Equal(t, int64(len(video)), got.Session.CommittedBytes)
+
+	// Step 3: a new stream stores the video
+	stream, err = client.UploadVideo(ctx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&pbMedia.UploadVideoRequest{VideoId: "talk", UploadId: uploadID, Offset: int64(len(video))}))
+	resp, err := stream.CloseAndRecv()
+	require.NoError(t, err)
+	require.Equal(t, int64(len(video)), resp.TotalBytes)
+}
+
+func TestUploadMaxInFlight(t *testing.T) {
+	cfg := env.DefaultConfig()
+	cfg.UploadMaxInFlight = 4000
+	client, ctx := setupMediaClientWithConfig(t, cfg)
+	video := bytes.Repeat([]byte("coscup"), 1000)
+
+	// Step 1: without a session the whole video is in flight and too large
+	stream, err := client.UploadVideo(ctx)
+	require.NoError(t, err)
+	stream.Send(&pbMedia.UploadVideoRequest{VideoId: "talk", Data: video[:3000]})
+	stream.Send(&pbMedia.UploadVideoRequest{VideoId: "talk", Data: video[3000:]})
+	_, err = stream.CloseAndRecv()
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// Step 2: a session stores each chunk as it arrives, so the cap does not apply
+	created, err := client.CreateUploadSession(ctx, &pbMedia.CreateUploadSessionRequest{
+		VideoId:   "talk",
+		TotalSize: int64(len(video)),
+	})
+	require.NoError(t, err)
+	stream, err = client.UploadVideo(ctx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&pbMedia.UploadVideoRequest{VideoId: "talk", UploadId: created.Session.UploadId, Data: video[:3000]}))
+	require.NoError(t, stream.Send(&pbMedia.UploadVideoRequest{VideoId: "talk", UploadId: created.Session.UploadId, Offset: 3000, Data: video[3000:]}))
+	resp, err := stream.CloseAndRecv()
+	require.NoError(t, err)
+	require.Equal(t, int64(len(video)), resp.TotalBytes)
+}
+
+func TestMemoryBudget(t *testing.T) {
+	cfg := env.DefaultConfig()
+	cfg.MemoryBudget = 10000
+	client, ctx := setupMediaClientWithConfig(t, cfg)
+	video := bytes.Repeat([]byte("coscup"), 1000)
+
+	// Step 1: a session reserves its announced size when it is created
+	created, err := client.CreateUploadSession(ctx, &pbMedia.CreateUploadSessionRequest{
+		VideoId:   "first",
+		TotalSize: int64(len(video)),
+	})
+	require.NoError(t, err)
+	_, err = client.CreateUploadSession(ctx, &pbMedia.CreateUploadSessionRequest{
+		VideoId:   "second",
+		TotalSize: int64(len(video)),
+	})
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// Step 2: an upload without a session is turned away once it would not fit
+	stream, err := client.UploadVideo(ctx)
+	require.NoError(t, err)
+	stream.Send(&pbMedia.UploadVideoRequest{VideoId: "third", Data: video})
+	_, err = stream.CloseAndRecv()
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// Step 3: the admitted session completes within its reservation
+	stream, err = client.UploadVideo(ctx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&pbMedia.UploadVideoRequest{VideoId: "first", UploadId: created.Session.UploadId, Data: video}))
+	_, err = stream.CloseAndRecv()
+	require.NoError(t, err)
+
+	// Step 4: deleting the video frees its share of the budget
+	_, err = client.DeleteVideo(ctx, &pbMedia.DeleteVideoRequest{VideoId: "first"})
+	require.NoError(t, err)
+	_, err = client.CreateUploadSession(ctx, &pbMedia.CreateUploadSessionRequest{
+		VideoId:   "second",
+		TotalSize: int64(len(video)),
+	})
+	require.NoError(t, err)
+}
+
+// counterValue returns the value of a counter in the metrics registry.
+func counterValue(t *testing.T, name string, labels map[string]string) float64 {
+	families, err := metrics.Registry.Gather()
+	require.NoError(t, err)
+	for _, f := range families {
+		if f.GetName() != name {
+			continue
+		}
+	metric:
+		for _, m := range f.Metric {
+			for _, l := range m.Label {
+				if labels[l.GetName()] != l.GetValue() {
+					continue metric
+				}
+			}
+			return m.GetCounter().GetValue()
+		}
+	}
+	return 0
+}
+
+func TestGetUploadLimits(t *testing.T) {
+	cfg := env.DefaultConfig()
+	cfg.MemoryBudget = 10000
+	cfg.MaxVideoSize = 8000
+	client, ctx := setupMediaClientWithConfig(t, cfg)
+
+	// Step 1: the limits hold the configuration and the whole budget is left
+	limits, err := client.GetUploadLimits(ctx, &pbMedia.GetUploadLimitsRequest{})
+	require.NoError(t, err)
+	require.NotEmpty(t, limits.UserId)
+	require.Equal(t, int64(8000), limits.MaxVideoSize)
+	require.Equal(t, int64(4<<20), limits.MaxMessageSize)
+	require.Equal(t, int64(10000), limits.MemoryBudget)
+	require.Equal(t, int64(10000), limits.MemoryAvailable)
+	require.False(t, limits.StorageDegraded)
+
+	// Step 2: a session takes its announced size off the budget
+	_, err = client.CreateUploadSession(ctx, &pbMedia.CreateUploadSessionRequest{VideoId: "talk", TotalSize: 6000})
+	require.NoError(t, err)
+	limits, err = client.GetUploadLimits(ctx, &pbMedia.GetUploadLimitsRequest{})
+	require.NoError(t, err)
+	require.Equal(t, int64(4000), limits.MemoryAvailable)
+}
+
+func TestCancelledUploadIsDiscarded(t *testing.T) {
+	cfg := env.DefaultConfig()
+	cfg.MemoryBudget = 10000
+	client, ctx := setupMediaClientWithConfig(t, cfg)
+	video := bytes.Repeat([]byte("coscup"), 1000)
+	cancelled := counterValue(t, "coscup_upload_streams_aborted_total", map[string]string{"code": "Canceled"})
+	discarded := counterValue(t, "coscup_upload_discarded_bytes_total", nil)
+
+	// Step 1: the client goes away in the middle of an upload
+	streamCtx, cancel := context.WithCancel(ctx)
+	stream, err := client.UploadVideo(streamCtx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&pbMedia.UploadVideoRequest{VideoId: "talk", Data: video[:4000]}))
+	require.NoError(t, stream.Send(&pbMedia.UploadVideoRequest{VideoId: "talk", Data: video[4000:]}))
+	cancel()
+
+	// Step 2: the partial data is dropped and counted
+	require.Eventually(t, func() bool {
+		return counterValue(t, "coscup_upload_streams_aborted_total", map[string]string{"code": "Canceled"}) == cancelled+1
+	}, time.Second, 10*time.Millisecond)
+	// Depending on when the cancellation arrived, the server read some or
+	// all of the chunks.
+	require.LessOrEqual(t, counterValue(t, "coscup_upload_discarded_bytes_total", nil)-discarded, float64(len(video)))
+	_, err = client.GetVideoMetadata(ctx, &pbMedia.GetVideoMetadataRequest{VideoId: "talk"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	// Step 3: its bytes no longer count against the memory budget
+	stream, err = client.UploadVideo(ctx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&pbMedia.UploadVideoRequest{VideoId: "talk", Data: video}))
+	_, err = stream.CloseAndRecv()
+	require.NoError(t, err)
+}
+
+func TestMaxVideoSize(t *testing.T) {
+	cfg := env.DefaultConfig()
+	cfg.MaxVideoSize = 5000
+	client, ctx := setupMediaClientWithConfig(t, cfg)
+	video := bytes.Repeat([]byte("coscup"), 1000)
+
+	// Step 1: a session announcing more is refused with the limit attached
+	_, err := client.CreateUploadSession(ctx, &pbMedia.CreateUploadSessionRequest{
+		VideoId:   "talk",
+		TotalSize: int64(len(video)),
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	info := status.Convert(err).Details()[0].(*errdetails.ErrorInfo)
+	require.Equal(t, "VIDEO_TOO_LARGE", info.Reason)
+	require.Equal(t, "5000", info.Metadata["max_video_size"])
+
+	// Step 2: sessions report the limit so clients can check before sending
+	created, err := client.CreateUploadSession(ctx, &pbMedia.CreateUploadSessionRequest{VideoId: "talk"})
+	require.NoError(t, err)
+	require.Equal(t, int64(5000), created.Session.MaxVideoSize)
+
+	// Step 3: a stream is cut off by the chunk that crosses the limit
+	stream, err := client.UploadVideo(ctx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&pbMedia.UploadVideoRequest{VideoId: "talk", UploadId: created.Session.UploadId, Data: video[:3000]}))
+	stream.Send(&pbMedia.UploadVideoRequest{VideoId: "talk", UploadId: created.Session.UploadId, Offset: 3000, Data: video[3000:]})
+	_, err = stream.CloseAndRecv()
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	resp, err := client.GetUploadSession(ctx, &pbMedia.GetUploadSessionRequest{UploadId: created.Session.UploadId})
+	require.NoError(t, err)
+	require.Equal(t, int64(3000), resp.Session.CommittedBytes)
+}
+
+func TestUploadSessionSurvivesRestart(t *testing.T) {
+	cfg := env.DefaultConfig()
+	cfg.UploadSessionDir = t.TempDir()
+	video := bytes.Repeat([]byte("coscup"), 1000)
+
+	// Step 1: commit the first half on one server
+	client, ctx := setupMediaClientWithConfig(t, cfg)
+	created, err := client.CreateUploadSession(ctx, &pbMedia.CreateUploadSessionRequest{
+		VideoId:   "talk",
+		TotalSize: int64(len(video)),
+	})
+	require.NoError(t, err)
+	uploadID := created.Session.UploadId
+
+	stream, err := client.UploadVideo(ctx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&pbMedia.UploadVideoRequest{VideoId: "talk", UploadId: uploadID, Data: video[:3000]}))
+	require.Eventually(t, func() bool {
+		resp, err := client.GetUploadSession(ctx, &pbMedia.GetUploadSessionRequest{UploadId: uploadID})
+		return err == nil && resp.Session.CommittedBytes == 3000
+	}, time.Second, 10*time.Millisecond)
+
+	// Bytes written by a commit that crashed before saving its state are dropped
+	part, err := os.OpenFile(filepath.Join(cfg.UploadSessionDir, uploadID+".part"), os.O_WRONLY|os.O_APPEND, 0)
+	require.NoError(t, err)
+	part.Write([]byte("garbage"))
+	part.Close()
+
+	// Step 2: a new server picks the session up where it was left
+	client, ctx = setupMediaClientWithConfig(t, cfg)
+	resp, err := client.GetUploadSession(ctx, &pbMedia.GetUploadSessionRequest{UploadId: uploadID})
+	require.NoError(t, err)
+	require.Equal(t, int64(3000), resp.Session.CommittedBytes)
+
+	stream, err = client.UploadVideo(ctx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&pbMedia.UploadVideoRequest{VideoId: "talk", UploadId: uploadID, Offset: 3000, Data: video[3000:]}))
+	done, err := stream.CloseAndRecv()
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf("%x", sha256.Sum256(video)), done.Metadata.Sha256)
+
+	// Step 3: the finished session is removed from disk
+	entries, err := os.ReadDir(cfg.UploadSessionDir)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestMaxUserDownloads(t *testing.T) {
+	cfg := env.DefaultConfig()
+	cfg.MaxUserDownloads = 1
+	client, ctx := setupMediaClientWithConfig(t, cfg)
+
+	// Large enough that the first download stalls on flow control while
+	// nobody reads it.
+	upload, err := client.UploadVideo(ctx)
+	require.NoError(t, err)
+	chunk := bytes.Repeat([]byte("c"), 1<<20)
+	for i := range int64(16) {
+		require.NoError(t, upload.Send(&pbMedia.UploadVideoRequest{VideoId: "talk", Data: chunk, Sequence: i + 1}))
+	}
+	_, err = upload.CloseAndRecv()
+	require.NoError(t, err)
+
+	// Step 1: a second concurrent download is refused
+	firstCtx, cancel := context.WithCancel(ctx)
+	first, err := client.DownloadVideo(firstCtx, &pbMedia.DownloadVideoRequest{VideoId: "talk"})
+	require.NoError(t, err)
+	_, err = first.Recv()
+	require.NoError(t, err)
+
+	second, err := client.DownloadVideo(ctx, &pbMedia.DownloadVideoRequest{VideoId: "talk"})
+	require.NoError(t, err)
+	_, err = second.Recv()
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// Step 2: once the first ends, the user may download again
+	cancel()
+	require.Eventually(t, func() bool {
+		stream, err := client.DownloadVideo(ctx, &pbMedia.DownloadVideoRequest{VideoId: "talk", Length: 1})
+		if err != nil {
+			return false
+		}
+		_, err = stream.Recv()
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
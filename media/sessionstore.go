@@ -0,0 +1,255 @@
+package media
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// sessionStore keeps resumable upload sessions on disk so that they survive a
+// restart. A session is stored as <id>.part with its committed bytes and
+// <id>.json with the rest of its state. Data is appended before the state is
+// rewritten, so after a crash the part file may be longer than the state says
+// and the excess is dropped when loading. A nil store keeps nothing.
+type sessionStore struct {
+	dir string
+}
+
+// sessionState is the JSON form of an upload session.
+type sessionState struct {
+	ID           string            `json:"id"`
+	VideoID      string            `json:"video_id"`
+	OwnerID      string            `json:"owner_id"`
+	TotalSize    int64             `json:"total_size"`
+	Tags         []string          `json:"tags,omitempty"`
+	Titles       map[string]string `json:"titles,omitempty"`
+	Descriptions map[string]string `json:"descriptions,omitempty"`
+	Overwrite    bool              `json:"overwrite,omitempty"`
+	FileName     string            `json:"file_name,omitempty"`
+	Received     int64             `json:"received,omitempty"`
+	Attempts     int64             `json:"attempts,omitempty"`
+	Committed    int64             `json:"committed"`
+	ExpiresAt    time.Time         `json:"expires_at"`
+	// StreamDeadline is the deadline granted to the streams of the session.
+	StreamDeadline time.Duration `json:"stream_deadline,omitempty"`
+	// SHA256 and CRC32C are the marshaled hash states over the committed
+	// bytes, so that the checksums continue without rereading them.
+	SHA256 []byte `json:"sha256"`
+	CRC32C []byte `json:"crc32c"`
+}
+
+func (st *sessionStore) partPath(id string) string {
+	return filepath.Join(st.dir, id+".part")
+}
+
+func (st *sessionStore) statePath(id string) string {
+	return filepath.Join(st.dir, id+".json")
+}
+
+// create stores a new session without data, reserving disk space for its
+// announced size. It fails with syscall.ENOSPC when that space is not there.
+func (st *sessionStore) create(u *uploadSession) error {
+	if st == nil {
+		return nil
+	}
+	f, err := os.OpenFile(st.partPath(u.id), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	err = preallocate(f, u.totalSize)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = st.saveState(u, 0, u.expiresAt)
+	}
+	if err != nil {
+		st.remove(u.id)
+	}
+	return err
+}
+
+// commit writes data to the session so that it ends at committed, and
+// records that committed bytes and the current checksums are stored. Data is
+// written at its offset rather than appended, so that bytes left by a failed
+// commit are overwritten. Only the stream holding the session writes its
+// files.
+func (st *sessionStore) commit(u *uploadSession, data []byte, committed int64, expiresAt time.Time) error {
+	if st == nil {
+		return nil
+	}
+	f, err := os.OpenFile(st.partPath(u.id), os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteAt(data, committed-int64(len(data))); err != nil {
+		f.Close()
+		return err
+	}
+	// The state must not claim bytes that could still be lost.
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return st.saveState(u, committed, expiresAt)
+}
+
+// saveState replaces the state file atomically.
+func (st *sessionStore) saveState(u *uploadSession, committed int64, expiresAt time.Time) error {
+	sha, crc, err := u.sums.marshal()
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(sessionState{
+		ID:             u.id,
+		VideoID:        u.videoID,
+		OwnerID:        u.ownerID,
+		TotalSize:      u.totalSize,
+		Tags:           u.tags,
+		Titles:         u.titles,
+		Descriptions:   u.descriptions,
+		Overwrite:      u.overwrite,
+		FileName:       u.fileName,
+		Received:       u.received,
+		Attempts:       u.attempts,
+		Committed:      committed,
+		ExpiresAt:      expiresAt,
+		SHA256:         sha,
+		CRC32C:         crc,
+		StreamDeadline: u.streamDeadline,
+	})
+	if err != nil {
+		return err
+	}
+	tmp := st.statePath(u.id) + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, st.statePath(u.id))
+}
+
+// remove deletes the files of a session.
+func (st *sessionStore) remove(id string) {
+	if st == nil {
+		return
+	}
+	for _, path := range []string{st.statePath(id), st.partPath(id)} {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			log.Printf("Failed to remove upload session file: %v", err)
+		}
+	}
+}
+
+// load reads every unexpired session in the directory. Expired or damaged
+// sessions are deleted and logged, since their clients can only start over.
+func (st *sessionStore) load(now time.Time) ([]*uploadSession, error) {
+	entries, err := os.ReadDir(st.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var sessions []*uploadSession
+	for _, e := range entries {
+		id, ok := strings.CutSuffix(e.Name(), ".json")
+		if !ok {
+			continue
+		}
+		u, err := st.loadSession(id)
+		switch {
+		case err != nil:
+			log.Printf("Dropping upload session %s: %v", id, err)
+			st.remove(id)
+		case now.After(u.expiresAt):
+			st.remove(id)
+		default:
+			sessions = append(sessions, u)
+		}
+	}
+	return sessions, nil
+}
+
+func (st *sessionStore) loadSession(id string) (*uploadSession, error) {
+	b, err := os.ReadFile(st.statePath(id))
+	if err != nil {
+		return nil, err
+	}
+	var state sessionState
+	if err := json.Unmarshal(b, &state); err != nil {
+		return nil, fmt.Errorf("invalid state: %v", err)
+	}
+	if state.ID != id {
+		return nil, fmt.Errorf("state belongs to session %s", state.ID)
+	}
+
+	sums, err := unmarshalChecksums(state.SHA256, state.CRC32C)
+	if err != nil {
+		return nil, fmt.Errorf("invalid checksum state: %v", err)
+	}
+
+	f, err := os.OpenFile(st.partPath(id), os.O_RDWR, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	data := make([]byte, state.Committed)
+	if _, err := io.ReadFull(f, data); err != nil {
+		return nil, fmt.Errorf("part file is shorter than its %d committed bytes", state.Committed)
+	}
+	// Drop bytes appended after the state was last saved.
+	if err := f.Truncate(state.Committed); err != nil {
+		return nil, err
+	}
+	// Truncating may release the space reserved past the end.
+	if err := preallocate(f, state.TotalSize); err != nil {
+		log.Printf("Failed to reserve space for upload session %s: %v", id, err)
+	}
+
+	return &uploadSession{
+		id:             state.ID,
+		videoID:        state.VideoID,
+		ownerID:        state.OwnerID,
+		totalSize:      state.TotalSize,
+		tags:           state.Tags,
+		titles:         state.Titles,
+		descriptions:   state.Descriptions,
+		overwrite:      state.Overwrite,
+		fileName:       state.FileName,
+		received:       state.Received,
+		attempts:       state.Attempts,
+		data:           data,
+		sums:           sums,
+		expiresAt:      state.ExpiresAt,
+		streamDeadline: state.StreamDeadline,
+	}, nil
+}
+
+// PersistUploadSessions keeps resumable upload sessions in dir from now on,
+// and restores the sessions a previous run left there. It returns how many
+// were restored.
+func (s *mediaServer) PersistUploadSessions(dir string) (int, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return 0, err
+	}
+	st := &sessionStore{dir: dir}
+	sessions, err := st.load(time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, u := range sessions {
+		s.sessions[u.id] = u
+	}
+	s.sessionStore = st
+	return len(sessions), nil
+}
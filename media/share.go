@@ -0,0 +1,64 @@
+package media
+
+import (
+	"coscup2025/auth"
+	"coscup2025/proto/media"
+	"crypto/rand"
+	"encoding/base64"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// downloadTokenTTL is how long the signed download URL behind a share link
+// stays valid. It only has to last until the download starts.
+const downloadTokenTTL = 5 * time.Minute
+
+// shareLink is a short code leading to a video. All fields are guarded by
+// mediaServer.mu.
+type shareLink struct {
+	code      string
+	videoID   string
+	owner     *auth.Identity // download tokens are issued on the owner's behalf
+	target    media.ShareTarget
+	clicks    int64
+	createdAt time.Time
+	expiresAt time.Time // zero if the link does not expire
+}
+
+func newShareCode() string {
+	b := make([]byte, 6)
+	rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func (l *shareLink) expired(now time.Time) bool {
+	return !l.expiresAt.IsZero() && now.After(l.expiresAt)
+}
+
+func (l *shareLink) proto() *media.ShareLink {
+	p := &media.ShareLink{
+		Code:      l.code,
+		VideoId:   l.videoID,
+		Target:    l.target,
+		OwnerId:   l.owner.UserID,
+		Clicks:    l.clicks,
+		CreatedAt: l.createdAt.Unix(),
+		Path:      "/s/" + l.code,
+	}
+	if !l.expiresAt.IsZero() {
+		p.ExpiresAt = l.expiresAt.Unix()
+	}
+	return p
+}
+
+// playerRedirect returns the player URL for videoID.
+func (s *mediaServer) playerRedirect(videoID string) string {
+	return strings.ReplaceAll(s.playerURL, "{video_id}", url.PathEscape(videoID))
+}
+
+// signedDownloadURL returns the gateway's file download URL for videoID,
+// authorized by a signed token.
+func signedDownloadURL(videoID, token string) string {
+	return "/v1/video/file/" + url.PathEscape(videoID) + "?access_token=" + url.QueryEscape(token)
+}
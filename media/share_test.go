@@ -0,0 +1,84 @@
+package media_test
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pbMedia "coscup2025/proto/media"
+)
+
+func TestShareLinks(t *testing.T) {
+	client, ctx := setupMediaClient(t)
+	uploadVideo(t, client, ctx, "keynote")
+	uploadVideo(t, client, ctx, "closing")
+
+	player, err := client.CreateShareLink(ctx, &pbMedia.CreateShareLinkRequest{VideoId: "keynote"})
+	require.NoError(t, err)
+	assert.Equal(t, pbMedia.ShareTarget_SHARE_TARGET_PLAYER, player.Link.Target)
+	assert.Equal(t, "/s/"+player.Link.Code, player.Link.Path)
+
+	// Visitors of a share link are not signed in.
+	anonymous := context.Background()
+	for range 2 {
+		resolved, err := client.ResolveShareLink(anonymous, &pbMedia.ResolveShareLinkRequest{Code: player.Link.Code})
+		require.NoError(t, err)
+		assert.Equal(t, "/embed/keynote", resolved.RedirectUrl)
+	}
+	got, err := client.GetShareLink(ctx, &pbMedia.GetShareLinkRequest{Code: player.Link.Code})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), got.Link.Clicks)
+
+	_, err = client.ResolveShareLink(anonymous, &pbMedia.ResolveShareLinkRequest{Code: "missing"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestShareLinkSignsDownloads(t *testing.T) {
+	client, ctx := setupMediaClient(t)
+	uploadVideo(t, client, ctx, "keynote")
+	uploadVideo(t, client, ctx, "closing")
+
+	created, err := client.CreateShareLink(ctx, &pbMedia.CreateShareLinkRequest{
+		VideoId:          "keynote",
+		Target:           pbMedia.ShareTarget_SHARE_TARGET_DOWNLOAD,
+		ExpiresInSeconds: 3600,
+	})
+	require.NoError(t, err)
+	assert.NotZero(t, created.Link.ExpiresAt)
+
+	resolved, err := client.ResolveShareLink(context.Background(), &pbMedia.ResolveShareLinkRequest{Code: created.Link.Code})
+	require.NoError(t, err)
+	redirect, err := url.Parse(resolved.RedirectUrl)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(redirect.Path, "/v1/video/file/keynote"))
+	token := redirect.Query().Get("access_token")
+	require.NotEmpty(t, token)
+
+	signed := metadata.NewOutgoingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+	stream, err := client.DownloadVideo(signed, &pbMedia.DownloadVideoRequest{VideoId: "keynote"})
+	require.NoError(t, err)
+	chunk, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("keynote"), chunk.Data)
+	summary, err := stream.Recv()
+	require.NoError(t, err)
+	assert.NotNil(t, summary.Stats)
+	_, err = stream.Recv()
+	assert.Equal(t, io.EOF, err)
+
+	// The token grants nothing beyond downloading that one video.
+	stream, err = client.DownloadVideo(signed, &pbMedia.DownloadVideoRequest{VideoId: "closing"})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = client.DeleteVideo(signed, &pbMedia.DeleteVideoRequest{VideoId: "keynote"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
@@ -0,0 +1,111 @@
+package media
+
+import (
+	"bytes"
+	"coscup2025/proto/media"
+	"coscup2025/storage"
+	"errors"
+	"io"
+	"sync"
+)
+
+// videoContent is the read side of stored video content. Downloads, ranged
+// requests and HLS segments all read through ReadAt, so a backend that keeps
+// videos outside memory only has to serve the requested bytes.
+type videoContent interface {
+	io.ReaderAt
+	Size() int64
+}
+
+// videoSnapshot is what a stream needs of a video, taken under mediaServer.mu
+// so that the stream can run without holding the lock.
+//
+// Stored video data is never modified in place: an upload stores a new
+// VideoInfo and metadata changes replace Metadata. A snapshot therefore stays
+// valid when the video is replaced or deleted while it is being read. The
+// reader detaches from the store, finishes with the bytes it started with, and
+// the memory is released once the last reader drops it.
+//
+// The content of offloaded videos is read from the video store instead, where
+// it stays until the video is deleted. Reads that go on after that fail.
+type videoSnapshot struct {
+	content  videoContent
+	metadata *media.VideoMetadata
+	segments []hlsSegment
+	stored   *storedContent // set for offloaded videos
+}
+
+// snapshotVideo returns the current content of a video. Streams reading the
+// content must close the snapshot.
+func (s *mediaServer) snapshotVideo(videoID string) (videoSnapshot, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	v, exists := s.videos[videoID]
+	if !exists {
+		return videoSnapshot{}, false
+	}
+	snapshot := videoSnapshot{metadata: v.Metadata, segments: v.segments}
+	var content videoContent = bytes.NewReader(v.Data)
+	if v.blob != "" {
+		snapshot.stored = &storedContent{store: s.videoStore, hash: v.blob, size: v.Metadata.FileSize}
+		content = snapshot.stored
+	}
+	if s.backend != nil {
+		content = backendContent{s.backend.Open(videoID, content), content.Size()}
+	}
+	snapshot.content = content
+	return snapshot, true
+}
+
+// close releases the blob of an offloaded video, if it was read.
+func (v videoSnapshot) close() {
+	if v.stored != nil {
+		v.stored.close()
+	}
+}
+
+// storedContent is the content of an offloaded video, whose blob it opens on
+// the first read.
+type storedContent struct {
+	store BlobStore
+	hash  string
+	size  int64
+
+	once sync.Once
+	blob storage.Blob
+	err  error
+}
+
+func (c *storedContent) ReadAt(p []byte, off int64) (int, error) {
+	c.once.Do(func() {
+		c.blob, c.err = c.store.OpenBlob(c.hash)
+	})
+	if c.err != nil {
+		return 0, c.err
+	}
+	return c.blob.ReadAt(p, off)
+}
+
+func (c *storedContent) Size() int64 {
+	return c.size
+}
+
+func (c *storedContent) close() {
+	c.once.Do(func() {
+		c.err = errors.New("content was closed")
+	})
+	if c.blob != nil {
+		c.blob.Close()
+	}
+}
+
+// readRange reads length bytes of content starting at offset.
+func readRange(content videoContent, offset, length int64) ([]byte, error) {
+	buf := make([]byte, length)
+	n, err := content.ReadAt(buf, offset)
+	if err == io.EOF && int64(n) == length {
+		err = nil
+	}
+	return buf[:n], err
+}
@@ -0,0 +1,100 @@
+package media_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pbMedia "coscup2025/proto/media"
+)
+
+func TestDeleteDuringDownload(t *testing.T) {
+	client, ctx := setupMediaClient(t)
+	video := bytes.Repeat([]byte("coscup"), 1<<20) // several download chunks
+
+	upload, err := client.UploadVideo(ctx)
+	require.NoError(t, err)
+	for i := 0; i < len(video); i += 1 << 20 {
+		require.NoError(t, upload.Send(&pbMedia.UploadVideoRequest{VideoId: "talk", Data: video[i:min(i+1<<20, len(video))]}))
+	}
+	_, err = upload.CloseAndRecv()
+	require.NoError(t, err)
+
+	// Step 1: start a download and delete the video after its first chunk
+	download, err := client.DownloadVideo(ctx, &pbMedia.DownloadVideoRequest{VideoId: "talk"})
+	require.NoError(t, err)
+	first, err := download.Recv()
+	require.NoError(t, err)
+
+	_, err = client.DeleteVideo(ctx, &pbMedia.DeleteVideoRequest{VideoId: "talk"})
+	require.NoError(t, err)
+
+	// Step 2: the download in flight still receives the whole video
+	received := append([]byte{}, first.Data...)
+	for {
+		chunk, err := download.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		received = append(received, chunk.Data...)
+	}
+	require.Equal(t, video, received)
+
+	// Step 3: new downloads no longer find it
+	download, err = client.DownloadVideo(ctx, &pbMedia.DownloadVideoRequest{VideoId: "talk"})
+	require.NoError(t, err)
+	_, err = download.Recv()
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestRangedDownload(t *testing.T) {
+	client, ctx := setupMediaClient(t)
+	video := bytes.Repeat([]byte("0123456789"), 300_000) // three download chunks
+
+	upload, err := client.UploadVideo(ctx)
+	require.NoError(t, err)
+	for i := 0; i < len(video); i += 1 << 20 {
+		require.NoError(t, upload.Send(&pbMedia.UploadVideoRequest{VideoId: "talk", Data: video[i:min(i+1<<20, len(video))]}))
+	}
+	_, err = upload.CloseAndRecv()
+	require.NoError(t, err)
+
+	download := func(offset, length int64) ([]byte, error) {
+		stream, err := client.DownloadVideo(ctx, &pbMedia.DownloadVideoRequest{VideoId: "talk", Offset: offset, Length: length})
+		require.NoError(t, err)
+		var received []byte
+		for {
+			chunk, err := stream.Recv()
+			if err == io.EOF {
+				return received, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			require.Equal(t, offset+int64(len(received)), chunk.Offset)
+			received = append(received, chunk.Data...)
+		}
+	}
+
+	// A range across a chunk boundary
+	data, err := download(1<<20-5, 10)
+	require.NoError(t, err)
+	require.Equal(t, video[1<<20-5:1<<20+5], data)
+
+	// The rest of the video from an offset, as when resuming
+	data, err = download(2_000_000, 0)
+	require.NoError(t, err)
+	require.Equal(t, video[2_000_000:], data)
+
+	// A length past the end is cut short, an offset past it is rejected
+	data, err = download(int64(len(video))-3, 100)
+	require.NoError(t, err)
+	require.Equal(t, video[len(video)-3:], data)
+	_, err = download(int64(len(video))+1, 0)
+	require.Equal(t, codes.OutOfRange, status.Code(err))
+}
@@ -0,0 +1,114 @@
+package media
+
+import (
+	"coscup2025/storage"
+	"errors"
+	"fmt"
+	"log"
+	"syscall"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	grpccodes "google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// StorageHealthService is the health check service that reports whether
+// video bytes can be stored. It is NOT_SERVING while writes fail, e.g. on a
+// full disk; the server as a whole keeps serving, since stored videos can
+// still be watched.
+const StorageHealthService = "media.storage"
+
+// How long clients are told to wait before resuming after a failed write. A
+// full disk needs an operator, other failures are often over sooner.
+const (
+	diskFullRetryDelay = time.Minute
+	storageRetryDelay  = 5 * time.Second
+)
+
+// HealthReporter receives the serving status of the storage, such as a
+// grpc health.Server.
+type HealthReporter interface {
+	SetServingStatus(service string, status healthpb.HealthCheckResponse_ServingStatus)
+}
+
+// Alerter tells the operators about failures they need to fix.
+type Alerter interface {
+	Alert(message string)
+}
+
+// SetHealth makes the server report the storage's status to h, as
+// StorageHealthService.
+func (s *mediaServer) SetHealth(h HealthReporter) {
+	s.health = h
+	h.SetServingStatus(StorageHealthService, healthpb.HealthCheckResponse_SERVING)
+}
+
+// SetAlerter makes the server alert a when storage writes start or stop
+// failing.
+func (s *mediaServer) SetAlerter(a Alerter) {
+	s.alerter = a
+}
+
+// storageWriteError marks the storage degraded after err failed a write of
+// video bytes, and returns the status for the client: ResourceExhausted on a
+// full disk and Unavailable otherwise, both with the delay to retry after.
+// resumable tells whether the client keeps its committed bytes.
+func (s *mediaServer) storageWriteError(err error, resumable bool) error {
+	s.storageFailed(err)
+
+	code, msg, delay := grpccodes.Unavailable, "failed to store the upload", storageRetryDelay
+	if errors.Is(err, syscall.ENOSPC) {
+		code, msg, delay = grpccodes.ResourceExhausted, "not enough disk space to store the upload", diskFullRetryDelay
+	}
+	hint := "retry the upload"
+	if resumable {
+		hint = "resume from the committed offset"
+	}
+	st, detailErr := status.New(code, fmt.Sprintf("%s, %s later: %v", msg, hint, err)).WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(delay),
+	})
+	if detailErr != nil {
+		return status.Errorf(code, "%s, %s later: %v", msg, hint, err)
+	}
+	return st.Err()
+}
+
+// storageFailed marks the storage degraded, alerting on the first failure.
+func (s *mediaServer) storageFailed(err error) {
+	if !s.storageDegraded.CompareAndSwap(false, true) {
+		return
+	}
+	log.Printf("Storage writes are failing: %v", err)
+	if s.health != nil {
+		s.health.SetServingStatus(StorageHealthService, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+	if s.alerter != nil {
+		s.alerter.Alert(fmt.Sprintf("Storage writes are failing, uploads are paused: %v", err))
+	}
+}
+
+// storageRecovered clears a degraded storage after a successful write.
+func (s *mediaServer) storageRecovered() {
+	if !s.storageDegraded.CompareAndSwap(true, false) {
+		return
+	}
+	log.Printf("Storage writes succeed again")
+	if s.health != nil {
+		s.health.SetServingStatus(StorageHealthService, healthpb.HealthCheckResponse_SERVING)
+	}
+	if s.alerter != nil {
+		s.alerter.Alert("Storage writes succeed again, uploads resumed")
+	}
+}
+
+// discardVideo removes the blob of a record from putVideo that will not be
+// committed, so that a failed upload leaves nothing behind.
+func (s *mediaServer) discardVideo(rec *storage.Record) {
+	if rec == nil {
+		return
+	}
+	s.videoStore.Discard(rec.Blob)
+}
@@ -0,0 +1,199 @@
+package media
+
+import (
+	"context"
+	"coscup2025/proto/media"
+	"coscup2025/proto/notification"
+	"coscup2025/validation"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// transcodeTolerance is how old a callback signature may be. Event IDs are
+// remembered as long, so a replayed callback is either too old or a
+// duplicate.
+const transcodeTolerance = 5 * time.Minute
+
+// SignTranscodeCallback returns the signature of a transcoder callback with
+// payload at t, as the X-Coscup-Signature header.
+func SignTranscodeCallback(secret, payload []byte, t time.Time) string {
+	ts := strconv.FormatInt(t.Unix(), 10)
+	return "t=" + ts + ",v1=" + hex.EncodeToString(transcodeMAC(secret, ts, payload))
+}
+
+func transcodeMAC(secret []byte, ts string, payload []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(ts + "."))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// verifyTranscodeSignature checks a signature from SignTranscodeCallback,
+// which must have been made within transcodeTolerance of now.
+func verifyTranscodeSignature(secret, payload []byte, signature string, now time.Time) error {
+	var ts string
+	var sigs []string
+	for _, part := range strings.Split(signature, ",") {
+		k, v, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch k {
+		case "t":
+			ts = v
+		case "v1":
+			sigs = append(sigs, v)
+		}
+	}
+	t, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil || len(sigs) == 0 {
+		return errors.New("malformed signature")
+	}
+	if d := now.Sub(time.Unix(t, 0)); d > transcodeTolerance || d < -transcodeTolerance {
+		return errors.New("signature timestamp is outside the tolerance")
+	}
+	want := transcodeMAC(secret, ts, payload)
+	for _, sig := range sigs {
+		if got, err := hex.DecodeString(sig); err == nil && hmac.Equal(got, want) {
+			return nil
+		}
+	}
+	return errors.New("signature mismatch")
+}
+
+func (s *mediaServer) ReportTranscode(ctx context.Context, req *media.ReportTranscodeRequest) (*media.ReportTranscodeResponse, error) {
+	_, span := s.tracer.Start(ctx, "ReportTranscode")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("service.name", "media-service"),
+		attribute.String("rpc.method", "ReportTranscode"),
+		attribute.String("rpc.service", "MediaService"),
+	)
+
+	if len(s.transcoderSecret) == 0 {
+		err := status.Error(grpccodes.FailedPrecondition, "transcoder callbacks are not enabled")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "no webhook secret")
+		return nil, err
+	}
+	now := time.Now()
+	if err := verifyTranscodeSignature(s.transcoderSecret, req.Payload, req.Signature, now); err != nil {
+		err = status.Errorf(grpccodes.Unauthenticated, "invalid callback: %v", err)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid signature")
+		return nil, err
+	}
+
+	event := &media.TranscodeEvent{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(req.Payload, event); err != nil {
+		err = status.Errorf(grpccodes.InvalidArgument, "invalid payload: %v", err)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid payload")
+		return nil, err
+	}
+	if err := validation.Validate(event); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid payload")
+		return nil, err
+	}
+	span.SetAttributes(
+		attribute.String("video.id", event.VideoId),
+		attribute.String("transcode.event_id", event.EventId),
+		attribute.String("transcode.rendition", event.Rendition),
+		attribute.String("transcode.state", event.State.String()),
+	)
+
+	// Held while the event is applied, so that concurrent retries of a
+	// callback are processed once.
+	s.transcodeMu.Lock()
+	defer s.transcodeMu.Unlock()
+
+	for id, seen := range s.transcodeEvents {
+		if now.Sub(seen) > 2*transcodeTolerance {
+			delete(s.transcodeEvents, id)
+		}
+	}
+	if _, ok := s.transcodeEvents[event.EventId]; ok {
+		span.SetStatus(codes.Ok, "duplicate event")
+		return &media.ReportTranscodeResponse{Duplicate: true}, nil
+	}
+
+	stale, err := s.applyTranscodeEvent(event)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "video not found")
+		return nil, err
+	}
+	s.transcodeEvents[event.EventId] = now
+
+	span.SetAttributes(attribute.Bool("transcode.stale", stale))
+	span.SetStatus(codes.Ok, "event processed")
+	return &media.ReportTranscodeResponse{Stale: stale}, nil
+}
+
+// applyTranscodeEvent updates the rendition an event is about and notifies
+// the uploader once it is ready or failed. It reports whether the event was
+// ignored as outdated.
+func (s *mediaServer) applyTranscodeEvent(event *media.TranscodeEvent) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v, ok := s.videos[event.VideoId]
+	if !ok {
+		return false, status.Error(grpccodes.NotFound, "video not found")
+	}
+	// The video was replaced since it was sent to the transcoder.
+	if event.VideoSha256 != "" && !strings.EqualFold(event.VideoSha256, v.Metadata.Sha256) {
+		return true, nil
+	}
+	i := slices.IndexFunc(v.Metadata.Renditions, func(r *media.Rendition) bool { return r.Name == event.Rendition })
+	var prev *media.Rendition
+	if i >= 0 {
+		prev = v.Metadata.Renditions[i]
+		if prev.UpdatedAt > event.OccurredAt {
+			return true, nil
+		}
+	}
+
+	r := &media.Rendition{
+		Name:      event.Rendition,
+		State:     event.State,
+		Url:       event.Url,
+		Error:     event.Error,
+		UpdatedAt: event.OccurredAt,
+	}
+	// Metadata is shared with earlier responses, so replace it instead of
+	// modifying it in place.
+	metadata := proto.Clone(v.Metadata).(*media.VideoMetadata)
+	if i >= 0 {
+		metadata.Renditions[i] = r
+	} else {
+		metadata.Renditions = append(metadata.Renditions, r)
+	}
+	v.Metadata = metadata
+
+	if s.notifier == nil || (prev != nil && prev.State == r.State) {
+		return false, nil
+	}
+	switch r.State {
+	case media.RenditionState_RENDITION_STATE_READY:
+		s.notifier.Notify(metadata.UploaderId, notification.NotificationKind_NOTIFICATION_KIND_TRANSCODE_DONE, event.VideoId,
+			fmt.Sprintf("The %s rendition of %s is ready", r.Name, event.VideoId))
+	case media.RenditionState_RENDITION_STATE_FAILED:
+		s.notifier.Notify(metadata.UploaderId, notification.NotificationKind_NOTIFICATION_KIND_TRANSCODE_FAILED, event.VideoId,
+			fmt.Sprintf("Transcoding %s to %s failed: %s", event.VideoId, r.Name, r.Error))
+	}
+	return false, nil
+}
@@ -0,0 +1,88 @@
+package media_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"coscup2025/env"
+	"coscup2025/media"
+	pbMedia "coscup2025/proto/media"
+)
+
+const transcoderSecret = "transcoder-secret"
+
+// transcodeRequest signs a callback payload as the transcoder does at t.
+func transcodeRequest(payload string, t time.Time) *pbMedia.ReportTranscodeRequest {
+	return &pbMedia.ReportTranscodeRequest{
+		Payload:   []byte(payload),
+		Signature: media.SignTranscodeCallback([]byte(transcoderSecret), []byte(payload), t),
+	}
+}
+
+func transcodeEvent(eventID, state string, occurredAt int64) string {
+	return fmt.Sprintf(`{"eventId":%q,"videoId":"keynote","rendition":"720p","state":%q,"url":"https://cdn.example/keynote/720p.mp4","occurredAt":%d}`,
+		eventID, state, occurredAt)
+}
+
+func TestReportTranscode(t *testing.T) {
+	cfg := env.DefaultConfig()
+	cfg.TranscoderWebhookSecret = transcoderSecret
+	client, ctx := setupMediaClientWithConfig(t, cfg)
+	uploadVideo(t, client, ctx, "keynote")
+
+	// The transcoder calls without a token
+	webhook := context.Background()
+	now := time.Now()
+
+	// Step 1: a signed event updates the rendition
+	resp, err := client.ReportTranscode(webhook, transcodeRequest(transcodeEvent("e1", "RENDITION_STATE_READY", 2000), now))
+	require.NoError(t, err)
+	assert.False(t, resp.Duplicate)
+	assert.False(t, resp.Stale)
+	got, err := client.GetVideoMetadata(ctx, &pbMedia.GetVideoMetadataRequest{VideoId: "keynote"})
+	require.NoError(t, err)
+	require.Len(t, got.Metadata.Renditions, 1)
+	assert.Equal(t, "720p", got.Metadata.Renditions[0].Name)
+	assert.Equal(t, pbMedia.RenditionState_RENDITION_STATE_READY, got.Metadata.Renditions[0].State)
+	assert.Equal(t, "https://cdn.example/keynote/720p.mp4", got.Metadata.Renditions[0].Url)
+
+	// Step 2: a retried event is acknowledged once
+	resp, err = client.ReportTranscode(webhook, transcodeRequest(transcodeEvent("e1", "RENDITION_STATE_READY", 2000), now))
+	require.NoError(t, err)
+	assert.True(t, resp.Duplicate)
+
+	// Step 3: an event older than the rendition arrived late and is ignored
+	resp, err = client.ReportTranscode(webhook, transcodeRequest(transcodeEvent("e0", "RENDITION_STATE_PROCESSING", 1000), now))
+	require.NoError(t, err)
+	assert.True(t, resp.Stale)
+	got, err = client.GetVideoMetadata(ctx, &pbMedia.GetVideoMetadataRequest{VideoId: "keynote"})
+	require.NoError(t, err)
+	assert.Equal(t, pbMedia.RenditionState_RENDITION_STATE_READY, got.Metadata.Renditions[0].State)
+
+	// Step 4: a forged or expired signature is refused
+	req := transcodeRequest(transcodeEvent("e2", "RENDITION_STATE_FAILED", 3000), now)
+	req.Payload = []byte(transcodeEvent("e2", "RENDITION_STATE_FAILED", 4000))
+	_, err = client.ReportTranscode(webhook, req)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = client.ReportTranscode(webhook, transcodeRequest(transcodeEvent("e2", "RENDITION_STATE_FAILED", 3000), now.Add(-time.Hour)))
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// Step 5: signed events must still be valid and about a known video
+	_, err = client.ReportTranscode(webhook, transcodeRequest(`{"eventId":"e3","videoId":"keynote"}`, now))
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = client.ReportTranscode(webhook, transcodeRequest(`{"eventId":"e4","videoId":"missing","rendition":"720p","state":"RENDITION_STATE_READY","occurredAt":1}`, now))
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestReportTranscodeWithoutSecret(t *testing.T) {
+	client, _ := setupMediaClient(t)
+	_, err := client.ReportTranscode(context.Background(), transcodeRequest(transcodeEvent("e1", "RENDITION_STATE_READY", 1), time.Now()))
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
@@ -0,0 +1,132 @@
+package media
+
+import (
+	"context"
+	"coscup2025/proto/media"
+	mediav2 "coscup2025/proto/media/v2"
+	"io"
+
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// v2Server serves media.v2.MediaService by adapting its messages to the
+// handlers of the first version, so both versions share one implementation.
+type v2Server struct {
+	mediav2.UnimplementedMediaServiceServer
+	s *mediaServer
+}
+
+// V2 returns version 2 of the media service, served by s.
+func (s *mediaServer) V2() mediav2.MediaServiceServer {
+	return &v2Server{s: s}
+}
+
+func (v *v2Server) CreateUploadSession(ctx context.Context, req *media.CreateUploadSessionRequest) (*media.CreateUploadSessionResponse, error) {
+	return v.s.CreateUploadSession(ctx, req)
+}
+
+func (v *v2Server) GetUploadSession(ctx context.Context, req *media.GetUploadSessionRequest) (*media.GetUploadSessionResponse, error) {
+	return v.s.GetUploadSession(ctx, req)
+}
+
+func (v *v2Server) AbortUpload(ctx context.Context, req *media.AbortUploadRequest) (*media.AbortUploadResponse, error) {
+	return v.s.AbortUpload(ctx, req)
+}
+
+func (v *v2Server) UploadVideo(stream mediav2.MediaService_UploadVideoServer) error {
+	first, err := stream.Recv()
+	if err == io.EOF {
+		return status.Error(grpccodes.InvalidArgument, "no chunk sent")
+	}
+	if err != nil {
+		return err
+	}
+	// First version chunks carry the video ID, which the session knows.
+	resp, err := v.s.GetUploadSession(stream.Context(), &media.GetUploadSessionRequest{UploadId: first.UploadId})
+	if err != nil {
+		return err
+	}
+	return v.s.UploadVideo(&v2UploadStream{
+		MediaService_UploadVideoServer: stream,
+		next:                           first,
+		uploadID:                       first.UploadId,
+		videoID:                        resp.Session.VideoId,
+	})
+}
+
+// v2UploadStream turns the chunks of a v2 upload into those of the first
+// version.
+type v2UploadStream struct {
+	mediav2.MediaService_UploadVideoServer
+	next     *mediav2.UploadVideoRequest // received but not yet returned
+	uploadID string
+	videoID  string
+	sequence int64
+}
+
+func (u *v2UploadStream) Recv() (*media.UploadVideoRequest, error) {
+	chunk := u.next
+	u.next = nil
+	if chunk == nil {
+		var err error
+		if chunk, err = u.MediaService_UploadVideoServer.Recv(); err != nil {
+			return nil, err
+		}
+	}
+	if chunk.UploadId != u.uploadID {
+		return nil, status.Error(grpccodes.InvalidArgument, "a stream writes to a single upload session")
+	}
+	u.sequence++
+	return &media.UploadVideoRequest{
+		VideoId:   u.videoID,
+		UploadId:  chunk.UploadId,
+		Offset:    chunk.Offset,
+		Data:      chunk.Data,
+		Sequence:  u.sequence,
+		Heartbeat: chunk.Heartbeat,
+	}, nil
+}
+
+func (v *v2Server) DownloadVideo(req *media.DownloadVideoRequest, stream mediav2.MediaService_DownloadVideoServer) error {
+	d := &v2DownloadStream{MediaService_DownloadVideoServer: stream}
+	if err := v.s.DownloadVideo(req, d); err != nil {
+		return err
+	}
+	return stream.Send(&mediav2.DownloadVideoResponse{Frame: &mediav2.DownloadVideoResponse_Trailer{
+		Trailer: &mediav2.DownloadTrailer{BytesSent: d.sent, Stats: d.stats},
+	}})
+}
+
+// v2DownloadStream turns the chunks of the first version into frames: the
+// metadata of the first chunk becomes the header, and the stats of the last
+// message go to the trailer.
+type v2DownloadStream struct {
+	mediav2.MediaService_DownloadVideoServer
+	started bool
+	sent    int64
+	stats   *media.TransferStats
+}
+
+func (d *v2DownloadStream) Send(chunk *media.DownloadVideoResponse) error {
+	if !d.started {
+		d.started = true
+		err := d.MediaService_DownloadVideoServer.Send(&mediav2.DownloadVideoResponse{Frame: &mediav2.DownloadVideoResponse_Header{
+			Header: &mediav2.DownloadHeader{VideoId: chunk.VideoId, Metadata: chunk.Metadata, Offset: chunk.Offset},
+		}})
+		if err != nil {
+			return err
+		}
+	}
+	if chunk.Stats != nil {
+		d.stats = chunk.Stats
+	}
+	// The first version sends an empty chunk to resume a complete download.
+	if len(chunk.Data) == 0 {
+		return nil
+	}
+	d.sent += int64(len(chunk.Data))
+	return d.MediaService_DownloadVideoServer.Send(&mediav2.DownloadVideoResponse{Frame: &mediav2.DownloadVideoResponse_Chunk{
+		Chunk: &mediav2.DownloadChunk{Offset: chunk.Offset, Data: chunk.Data},
+	}})
+}
@@ -0,0 +1,225 @@
+// Package mesh extracts the identity of the workload calling the server from
+// the x-forwarded-client-cert header that the sidecar of a service mesh, such
+// as Istio or another Envoy-based mesh, adds after verifying the workload's
+// certificate. This peer identity is separate from the user of a token: a
+// call can come from the transcoder service on behalf of no user, or from
+// the gateway on behalf of any.
+package mesh
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"coscup2025/env"
+)
+
+// Header is the metadata key of the peer certificate.
+const Header = "x-forwarded-client-cert"
+
+// Peer is the workload that called the server, as its certificate names it.
+type Peer struct {
+	// URI is the URI SAN, in Istio the SPIFFE ID, e.g.
+	// spiffe://cluster.local/ns/coscup/sa/transcoder.
+	URI     string
+	Subject string
+	DNS     []string
+	// Hash is the SHA-256 of the certificate, in hex.
+	Hash string
+}
+
+// ID returns the identity of p for logs and allow lists: its URI, or else
+// its subject or first DNS name.
+func (p *Peer) ID() string {
+	var dns string
+	if len(p.DNS) > 0 {
+		dns = p.DNS[0]
+	}
+	return cmp.Or(p.URI, p.Subject, dns)
+}
+
+// ParseXFCC returns the peer of an x-forwarded-client-cert header. A header
+// that passed several proxies lists a certificate for each; the last one is
+// that of the immediate peer, verified by the sidecar of this server.
+func ParseXFCC(v string) (*Peer, error) {
+	elements := split(v, ',')
+	last := strings.TrimSpace(elements[len(elements)-1])
+	if last == "" {
+		return nil, errors.New("empty x-forwarded-client-cert")
+	}
+	p := &Peer{}
+	for _, pair := range split(last, ';') {
+		key, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid x-forwarded-client-cert pair %q", pair)
+		}
+		value = unquote(value)
+		switch strings.ToLower(key) {
+		case "uri":
+			p.URI = value
+		case "subject":
+			p.Subject = value
+		case "dns":
+			p.DNS = append(p.DNS, value)
+		case "hash":
+			p.Hash = value
+		}
+	}
+	if p.ID() == "" {
+		return nil, errors.New("x-forwarded-client-cert names no identity")
+	}
+	return p, nil
+}
+
+// split splits s at sep outside of double quotes.
+func split(s string, sep byte) []string {
+	var parts []string
+	quoted, escaped, start := false, false, 0
+	for i := 0; i < len(s); i++ {
+		switch {
+		case escaped:
+			escaped = false
+		case s[i] == '\\':
+			escaped = true
+		case s[i] == '"':
+			quoted = !quoted
+		case s[i] == sep && !quoted:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// unquote removes the quotes of a quoted value and its escapes.
+func unquote(v string) string {
+	if len(v) < 2 || v[0] != '"' || v[len(v)-1] != '"' {
+		return v
+	}
+	v = v[1 : len(v)-1]
+	var b strings.Builder
+	for i := 0; i < len(v); i++ {
+		if v[i] == '\\' && i+1 < len(v) {
+			i++
+		}
+		b.WriteByte(v[i])
+	}
+	return b.String()
+}
+
+type peerKey struct{}
+
+// PeerFromContext returns the workload that made the call of ctx, if the
+// server trusts the mesh and the call named one.
+func PeerFromContext(ctx context.Context) (*Peer, bool) {
+	p, ok := ctx.Value(peerKey{}).(*Peer)
+	return p, ok
+}
+
+// Identities puts the peer of each call into its context and refuses calls
+// to restricted methods from peers that are not allowed.
+type Identities struct {
+	// Trust enables the extraction. Only set it when every call passes a
+	// sidecar that replaces the header, or clients could forge it.
+	Trust bool
+	// Methods are the prefixes of the methods only allowed peers may call,
+	// e.g. "/admin.AdminService/".
+	Methods []string
+	// Allowed are the IDs of the allowed peers. An ID ending in "*" allows
+	// every ID starting with what precedes it, e.g.
+	// "spiffe://cluster.local/ns/coscup/*".
+	Allowed []string
+}
+
+// New returns the peer identities configured in cfg.
+func New(cfg *env.Config) *Identities {
+	return &Identities{Trust: cfg.MeshTrustXFCC, Methods: cfg.MeshMethods, Allowed: cfg.MeshAllowedPeers}
+}
+
+// Enabled reports whether i extracts peers.
+func (i *Identities) Enabled() bool {
+	return i.Trust
+}
+
+// allows reports whether p may call restricted methods.
+func (i *Identities) allows(p *Peer) bool {
+	for _, id := range i.Allowed {
+		if prefix, ok := strings.CutSuffix(id, "*"); ok && strings.HasPrefix(p.ID(), prefix) {
+			return true
+		}
+		if id == p.ID() {
+			return true
+		}
+	}
+	return false
+}
+
+func (i *Identities) restricted(fullMethod string) bool {
+	for _, prefix := range i.Methods {
+		if strings.HasPrefix(fullMethod, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// extract returns ctx with the peer of its call, if it names one.
+func (i *Identities) extract(ctx context.Context, fullMethod string) (context.Context, error) {
+	var p *Peer
+	if values := metadata.ValueFromIncomingContext(ctx, Header); len(values) > 0 {
+		var err error
+		if p, err = ParseXFCC(values[len(values)-1]); err != nil {
+			slog.WarnContext(ctx, "ignored invalid peer certificate header", "method", fullMethod, "error", err)
+		}
+	}
+
+	if p != nil {
+		ctx = context.WithValue(ctx, peerKey{}, p)
+		trace.SpanFromContext(ctx).SetAttributes(attribute.String("mesh.peer", p.ID()))
+	}
+	if i.restricted(fullMethod) && (p == nil || !i.allows(p)) {
+		id := "none"
+		if p != nil {
+			id = p.ID()
+		}
+		slog.WarnContext(ctx, "service call refused", "peer", id, "method", fullMethod)
+		return nil, status.Error(codes.PermissionDenied, "calls from this service are not allowed")
+	}
+	return ctx, nil
+}
+
+// UnaryServerInterceptor extracts the peer of unary calls.
+func (i *Identities) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := i.extract(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamServerInterceptor extracts the peer of streaming calls.
+func (i *Identities) StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := i.extract(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &peerStream{ServerStream: ss, ctx: ctx})
+}
+
+// peerStream carries the context with the peer.
+type peerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *peerStream) Context() context.Context { return s.ctx }
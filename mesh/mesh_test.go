@@ -0,0 +1,89 @@
+package mesh
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestParseXFCC(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   *Peer
+	}{
+		{
+			name:   "istio",
+			header: `By=spiffe://cluster.local/ns/coscup/sa/media;Hash=4f6e;Subject="";URI=spiffe://cluster.local/ns/coscup/sa/transcoder`,
+			want:   &Peer{URI: "spiffe://cluster.local/ns/coscup/sa/transcoder", Hash: "4f6e"},
+		},
+		{
+			name:   "quoted subject with separators",
+			header: `Hash=ab;Subject="CN=ops, O=COSCUP\"s; team";DNS=ops.internal;DNS=ops`,
+			want:   &Peer{Subject: `CN=ops, O=COSCUP"s; team`, DNS: []string{"ops.internal", "ops"}, Hash: "ab"},
+		},
+		{
+			name:   "last proxy wins",
+			header: `URI=spiffe://cluster.local/ns/edge/sa/ingress,URI=spiffe://cluster.local/ns/coscup/sa/gateway`,
+			want:   &Peer{URI: "spiffe://cluster.local/ns/coscup/sa/gateway"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseXFCC(tt.header)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	for _, header := range []string{"", "Hash=ab", "URI"} {
+		_, err := ParseXFCC(header)
+		assert.Error(t, err, header)
+	}
+}
+
+func TestIdentities(t *testing.T) {
+	i := &Identities{
+		Trust:   true,
+		Methods: []string{"/admin.AdminService/"},
+		Allowed: []string{"spiffe://cluster.local/ns/ops/*", "spiffe://cluster.local/ns/coscup/sa/cron"},
+	}
+	call := func(method, xfcc string) (*Peer, error) {
+		ctx := context.Background()
+		if xfcc != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(Header, xfcc))
+		}
+		var p *Peer
+		_, err := i.UnaryServerInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			p, _ = PeerFromContext(ctx)
+			return nil, nil
+		})
+		return p, err
+	}
+
+	// Unrestricted methods only record the peer
+	p, err := call("/media.MediaService/ListVideos", "URI=spiffe://cluster.local/ns/coscup/sa/gateway")
+	require.NoError(t, err)
+	assert.Equal(t, "spiffe://cluster.local/ns/coscup/sa/gateway", p.ID())
+	p, err = call("/media.MediaService/ListVideos", "")
+	require.NoError(t, err)
+	assert.Nil(t, p)
+
+	// Restricted methods need an allowed peer
+	_, err = call("/admin.AdminService/ListCases", "URI=spiffe://cluster.local/ns/ops/sa/moderation-bot")
+	require.NoError(t, err)
+	_, err = call("/admin.AdminService/ListCases", "URI=spiffe://cluster.local/ns/coscup/sa/cron")
+	require.NoError(t, err)
+	_, err = call("/admin.AdminService/ListCases", "URI=spiffe://cluster.local/ns/coscup/sa/cron-evil")
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = call("/admin.AdminService/ListCases", "")
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = call("/admin.AdminService/ListCases", "URI")
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
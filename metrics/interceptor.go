@@ -0,0 +1,58 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// UnaryServerInterceptor records the duration of unary calls.
+func UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	observe(ctx, requestDuration.WithLabelValues(info.FullMethod, status.Code(err).String()), time.Since(start).Seconds())
+	return resp, err
+}
+
+// StreamServerInterceptor records the duration and the bytes transferred of
+// streaming calls.
+func StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	cs := &countingStream{ServerStream: ss}
+	err := handler(srv, cs)
+
+	ctx := ss.Context()
+	observe(ctx, requestDuration.WithLabelValues(info.FullMethod, status.Code(err).String()), time.Since(start).Seconds())
+	if info.IsClientStream {
+		observe(ctx, transferBytes.WithLabelValues(info.FullMethod, "received"), float64(cs.received))
+	}
+	if info.IsServerStream {
+		observe(ctx, transferBytes.WithLabelValues(info.FullMethod, "sent"), float64(cs.sent))
+	}
+	return err
+}
+
+// countingStream counts the encoded size of the messages passing through.
+type countingStream struct {
+	grpc.ServerStream
+	sent, received int64
+}
+
+func (s *countingStream) SendMsg(m interface{}) error {
+	err := s.ServerStream.SendMsg(m)
+	if msg, ok := m.(proto.Message); ok && err == nil {
+		s.sent += int64(proto.Size(msg))
+	}
+	return err
+}
+
+func (s *countingStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if msg, ok := m.(proto.Message); ok && err == nil {
+		s.received += int64(proto.Size(msg))
+	}
+	return err
+}
@@ -0,0 +1,61 @@
+// Package metrics exposes Prometheus metrics for the gRPC services.
+package metrics
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Registry holds every collector served by Handler.
+var Registry = prometheus.NewRegistry()
+
+var (
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "coscup",
+		Name:      "grpc_request_duration_seconds",
+		Help:      "Duration of gRPC calls by method and status code.",
+		Buckets:   []float64{0.005, 0.01, 0.05, 0.1, 0.5, 1, 5, 10, 30, 60, 300},
+	}, []string{"method", "code"})
+
+	transferBytes = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "coscup",
+		Name:      "grpc_stream_bytes",
+		Help:      "Bytes sent and received per streaming call, e.g. one upload or download.",
+		Buckets:   prometheus.ExponentialBuckets(64*1024, 4, 10), // 64 KiB to 16 GiB
+	}, []string{"method", "direction"})
+)
+
+func init() {
+	Registry.MustRegister(requestDuration, transferBytes)
+
+	// Uploads are buffered in memory, so heap growth, GC pauses and
+	// goroutines per stream show trouble well before the process is killed.
+	Registry.MustRegister(
+		collectors.NewGoCollector(collectors.WithGoCollectorRuntimeMetrics(
+			collectors.MetricsGC, collectors.MetricsMemory, collectors.MetricsScheduler,
+		)),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// Handler serves the registry in the OpenMetrics format, which is needed for
+// exemplars to reach Prometheus.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{EnableOpenMetrics: true})
+}
+
+// observe records v on o. When ctx belongs to a sampled trace its ID is
+// attached as an exemplar, so a slow bucket links to the trace behind it.
+func observe(ctx context.Context, o prometheus.Observer, v float64) {
+	sc := trace.SpanContextFromContext(ctx)
+	if eo, ok := o.(prometheus.ExemplarObserver); ok && sc.IsSampled() {
+		eo.ObserveWithExemplar(v, prometheus.Labels{"trace_id": sc.TraceID().String()})
+		return
+	}
+	o.Observe(v)
+}
@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestObserveAttachesTraceExemplar(t *testing.T) {
+	hist := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "test_seconds"})
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(hist)
+
+	traceID := trace.TraceID{1, 2, 3}
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     trace.SpanID{1},
+		TraceFlags: trace.FlagsSampled,
+	}))
+	observe(ctx, hist, 0.2)
+	observe(context.Background(), hist, 0.3)
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	require.Len(t, families, 1)
+	h := families[0].GetMetric()[0].GetHistogram()
+	assert.Equal(t, uint64(2), h.GetSampleCount())
+
+	var exemplars []string
+	for _, b := range h.GetBucket() {
+		if ex := b.GetExemplar(); ex != nil {
+			for _, l := range ex.GetLabel() {
+				exemplars = append(exemplars, l.GetName()+"="+l.GetValue())
+			}
+		}
+	}
+	assert.Equal(t, []string{"trace_id=" + traceID.String()}, exemplars)
+}
@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+)
+
+var slowRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "coscup",
+	Name:      "grpc_slow_requests_total",
+	Help:      "Calls that exceeded the slow-request latency or throughput threshold.",
+}, []string{"method"})
+
+func init() {
+	Registry.MustRegister(slowRequests)
+}
+
+// SlowRequests flags calls that are too slow: unary calls taking longer than
+// Latency, and streaming calls moving fewer than Throughput bytes per second.
+// A zero threshold disables that check. Slow calls are logged at WARN, get a
+// slow=true attribute on their server span and are counted per method.
+type SlowRequests struct {
+	Latency    time.Duration
+	Throughput int64
+}
+
+// UnaryServerInterceptor checks unary calls against the latency threshold.
+func (s SlowRequests) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	if elapsed := time.Since(start); s.Latency > 0 && elapsed > s.Latency {
+		flagSlow(ctx, info.FullMethod, "elapsed", elapsed.Round(time.Millisecond), "threshold", s.Latency)
+	}
+	return resp, err
+}
+
+// StreamServerInterceptor checks streaming calls against the throughput
+// threshold. Streams shorter than a second are not judged.
+func (s SlowRequests) StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	cs := &countingStream{ServerStream: ss}
+	err := handler(srv, cs)
+
+	elapsed := time.Since(start)
+	if s.Throughput <= 0 || elapsed < time.Second {
+		return err
+	}
+	bytes := cs.sent + cs.received
+	if rate := int64(float64(bytes) / elapsed.Seconds()); rate < s.Throughput {
+		flagSlow(ss.Context(), info.FullMethod, "elapsed", elapsed.Round(time.Millisecond), "bytes", bytes,
+			"bytes_per_second", rate, "threshold", s.Throughput)
+	}
+	return err
+}
+
+func flagSlow(ctx context.Context, method string, args ...any) {
+	slowRequests.WithLabelValues(method).Inc()
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.Bool("slow", true))
+	args = append([]any{"method", method, "trace_id", span.SpanContext().TraceID().String()}, args...)
+	slog.WarnContext(ctx, "slow request", args...)
+}
@@ -0,0 +1,207 @@
+package moderation
+
+import (
+	"cmp"
+	"context"
+	"coscup2025/auth"
+	"coscup2025/env"
+	"coscup2025/keyset"
+	"coscup2025/paging"
+	"coscup2025/proto/admin"
+	"coscup2025/proto/moderation"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// adminServer lets moderators work through the cases of a moderationServer.
+type adminServer struct {
+	admin.UnimplementedAdminServiceServer
+	cases    *moderationServer
+	scrubber Scrubber
+	retainer Retainer
+	accounts Accounts
+	pager    *paging.Pager
+}
+
+// Scrubber verifies and compacts the stored videos.
+type Scrubber interface {
+	// StartScrub starts a scrub in the background and reports whether it
+	// did, which it does not while one is running.
+	StartScrub() (bool, error)
+	// ScrubStatus reports whether a scrub is running and how the last one
+	// went.
+	ScrubStatus() (bool, *admin.ScrubReport)
+}
+
+// Retainer keeps the retention rules and enforces them on the videos.
+type Retainer interface {
+	// SetRetentionRule creates a rule when its ID is empty and otherwise
+	// replaces the rule with its ID, reporting false when there is none.
+	SetRetentionRule(rule *admin.RetentionRule) (*admin.RetentionRule, bool)
+	RetentionRules() []*admin.RetentionRule
+	DeleteRetentionRule(ruleID string) bool
+	// PreviewRetention returns when the rules are enforced next and the
+	// videos that deletes.
+	PreviewRetention() (time.Time, []*admin.ExpiredVideo)
+}
+
+func NewAdminServer(cfg *env.Config, keys *keyset.Set, cases *moderationServer) *adminServer {
+	return &adminServer{cases: cases, pager: paging.New(keys)}
+}
+
+// SetScrubber lets admins scrub the stored videos through sc.
+func (s *adminServer) SetScrubber(sc Scrubber) {
+	s.scrubber = sc
+}
+
+// SetRetainer lets admins manage the retention rules of r.
+func (s *adminServer) SetRetainer(r Retainer) {
+	s.retainer = r
+}
+
+// authorize returns the caller if they are an admin user.
+func (s *adminServer) authorize(ctx context.Context) (*auth.Identity, error) {
+	id, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unknown caller")
+	}
+	if !id.Admin {
+		return nil, status.Error(codes.PermissionDenied, "admin users only")
+	}
+	return id, nil
+}
+
+func (s *adminServer) ListCases(ctx context.Context, req *admin.ListCasesRequest) (*admin.ListCasesResponse, error) {
+	if _, err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	s.cases.mu.Lock()
+	defer s.cases.mu.Unlock()
+
+	var listed []*videoCase
+	for _, c := range s.cases.queue {
+		if req.State != moderation.CaseState_CASE_STATE_UNSPECIFIED && c.state != req.State {
+			continue
+		}
+		listed = append(listed, c)
+	}
+	page, next, err := paging.Page(s.pager, req, listed, func(c *videoCase) int64 { return c.seq }, cmp.Compare[int64], req.State)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &admin.ListCasesResponse{NextPageToken: next}
+	for _, c := range page {
+		resp.Cases = append(resp.Cases, c.toProto())
+	}
+	return resp, nil
+}
+
+func (s *adminServer) TransitionCase(ctx context.Context, req *admin.TransitionCaseRequest) (*admin.TransitionCaseResponse, error) {
+	id, err := s.authorize(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.cases.mu.Lock()
+	defer s.cases.mu.Unlock()
+
+	c, ok := s.cases.caseByID(req.CaseId)
+	if !ok {
+		return nil, status.Error(codes.NotFound, "case not found")
+	}
+	if !canReview(c.state, req.State) {
+		return nil, status.Errorf(codes.FailedPrecondition, "cannot move a case from %v to %v", c.state, req.State)
+	}
+	if err := s.cases.transition(c, req.State, id.UserID, req.Note, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return &admin.TransitionCaseResponse{Case: c.toProto()}, nil
+}
+
+func (s *adminServer) StartScrub(ctx context.Context, req *admin.StartScrubRequest) (*admin.StartScrubResponse, error) {
+	if _, err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	if s.scrubber == nil {
+		return nil, status.Error(codes.FailedPrecondition, "videos are not stored on disk")
+	}
+
+	started, err := s.scrubber.StartScrub()
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return &admin.StartScrubResponse{Started: started}, nil
+}
+
+func (s *adminServer) GetScrubStatus(ctx context.Context, req *admin.GetScrubStatusRequest) (*admin.GetScrubStatusResponse, error) {
+	if _, err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	if s.scrubber == nil {
+		return nil, status.Error(codes.FailedPrecondition, "videos are not stored on disk")
+	}
+
+	running, last := s.scrubber.ScrubStatus()
+	return &admin.GetScrubStatusResponse{Running: running, Last: last}, nil
+}
+
+func (s *adminServer) SetRetentionRule(ctx context.Context, req *admin.SetRetentionRuleRequest) (*admin.SetRetentionRuleResponse, error) {
+	if _, err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	if s.retainer == nil {
+		return nil, status.Error(codes.FailedPrecondition, "retention rules are not enforced")
+	}
+
+	rule, ok := s.retainer.SetRetentionRule(req.Rule)
+	if !ok {
+		return nil, status.Error(codes.NotFound, "retention rule not found")
+	}
+	return &admin.SetRetentionRuleResponse{Rule: rule}, nil
+}
+
+func (s *adminServer) ListRetentionRules(ctx context.Context, req *admin.ListRetentionRulesRequest) (*admin.ListRetentionRulesResponse, error) {
+	if _, err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	if s.retainer == nil {
+		return nil, status.Error(codes.FailedPrecondition, "retention rules are not enforced")
+	}
+
+	return &admin.ListRetentionRulesResponse{Rules: s.retainer.RetentionRules()}, nil
+}
+
+func (s *adminServer) DeleteRetentionRule(ctx context.Context, req *admin.DeleteRetentionRuleRequest) (*admin.DeleteRetentionRuleResponse, error) {
+	if _, err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	if s.retainer == nil {
+		return nil, status.Error(codes.FailedPrecondition, "retention rules are not enforced")
+	}
+
+	if !s.retainer.DeleteRetentionRule(req.RuleId) {
+		return nil, status.Error(codes.NotFound, "retention rule not found")
+	}
+	return &admin.DeleteRetentionRuleResponse{}, nil
+}
+
+func (s *adminServer) PreviewRetention(ctx context.Context, req *admin.PreviewRetentionRequest) (*admin.PreviewRetentionResponse, error) {
+	if _, err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	if s.retainer == nil {
+		return nil, status.Error(codes.FailedPrecondition, "retention rules are not enforced")
+	}
+
+	at, videos := s.retainer.PreviewRetention()
+	resp := &admin.PreviewRetentionResponse{EvaluatedAt: at.Unix(), Videos: videos}
+	for _, v := range videos {
+		resp.TotalBytes += v.SizeBytes
+	}
+	return resp, nil
+}
@@ -0,0 +1,103 @@
+package moderation
+
+import (
+	"coscup2025/proto/moderation"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// report is one user's report about a video.
+type report struct {
+	reporterID string
+	reason     moderation.ReportReason
+	details    string
+	reportedAt time.Time
+}
+
+// transition is one step of a case through the takedown workflow.
+type transition struct {
+	from, to moderation.CaseState
+	actorID  string
+	note     string
+	at       time.Time
+}
+
+// videoCase collects the reports about one video for the moderators. All
+// fields are guarded by moderationServer.mu.
+type videoCase struct {
+	id         string
+	seq        int64 // position in the queue, used for page tokens
+	videoID    string
+	uploaderID string
+	reports    map[string]*report // by reporter ID, so repeated reports fold
+	state      moderation.CaseState
+	history    []transition
+	openedAt   time.Time
+	updatedAt  time.Time
+}
+
+// openCase returns the case of videoID, creating and queueing it if needed.
+// New cases have no state until they are moved to reported. The caller must
+// hold s.mu.
+func (s *moderationServer) openCase(videoID, uploaderID string, now time.Time) *videoCase {
+	if c, ok := s.cases[videoID]; ok {
+		return c
+	}
+	s.nextID++
+	c := &videoCase{
+		id:         fmt.Sprintf("case_%d", s.nextID),
+		seq:        s.nextID,
+		videoID:    videoID,
+		uploaderID: uploaderID,
+		reports:    make(map[string]*report),
+		openedAt:   now,
+		updatedAt:  now,
+	}
+	s.cases[videoID] = c
+	s.queue = append(s.queue, c)
+	return c
+}
+
+// caseByID returns the case with the given ID. The caller must hold s.mu.
+func (s *moderationServer) caseByID(id string) (*videoCase, bool) {
+	for _, c := range s.queue {
+		if c.id == id {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+// toProto returns a snapshot of c. The caller must hold s.mu.
+func (c *videoCase) toProto() *moderation.Case {
+	pb := &moderation.Case{
+		CaseId:     c.id,
+		VideoId:    c.videoID,
+		UploaderId: c.uploaderID,
+		State:      c.state,
+		OpenedAt:   c.openedAt.Unix(),
+		UpdatedAt:  c.updatedAt.Unix(),
+	}
+	for _, r := range c.reports {
+		pb.Reports = append(pb.Reports, &moderation.Report{
+			ReporterId: r.reporterID,
+			Reason:     r.reason,
+			Details:    r.details,
+			ReportedAt: r.reportedAt.Unix(),
+		})
+	}
+	sort.Slice(pb.Reports, func(i, j int) bool {
+		return pb.Reports[i].ReportedAt < pb.Reports[j].ReportedAt
+	})
+	for _, t := range c.history {
+		pb.History = append(pb.History, &moderation.Transition{
+			From:    t.from,
+			To:      t.to,
+			ActorId: t.actorID,
+			Note:    t.note,
+			At:      t.at.Unix(),
+		})
+	}
+	return pb
+}
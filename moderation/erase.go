@@ -0,0 +1,26 @@
+package moderation
+
+import "slices"
+
+// EraseUser deletes the reports userID filed and the cases about their
+// videos, which are erased along with them. Moderation steps they took stay
+// in the history of their cases under pseudonym.
+func (s *moderationServer) EraseUser(userID, pseudonym string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.queue = slices.DeleteFunc(s.queue, func(c *videoCase) bool {
+		if c.uploaderID == userID {
+			delete(s.cases, c.videoID)
+			return true
+		}
+		delete(c.reports, userID)
+		for i := range c.history {
+			if c.history[i].actorID == userID {
+				c.history[i].actorID = pseudonym
+			}
+		}
+		return false
+	})
+	return "moderation reports"
+}
@@ -0,0 +1,95 @@
+package moderation
+
+import (
+	"context"
+	"coscup2025/auth"
+	"coscup2025/proto/moderation"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *moderationServer) ReportVideo(ctx context.Context, req *moderation.ReportVideoRequest) (*moderation.ReportVideoResponse, error) {
+	id, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unknown caller")
+	}
+
+	details := strings.TrimSpace(req.Details)
+	if req.Reason == moderation.ReportReason_REPORT_REASON_OTHER && details == "" {
+		return nil, status.Error(codes.InvalidArgument, "details are required for other reasons")
+	}
+
+	uploaderID, ok := s.videos.VideoUploader(ctx, req.VideoId)
+	if !ok {
+		return nil, status.Error(codes.NotFound, "video not found")
+	}
+
+	s.mu.Lock()
+	c, exists := s.cases[req.VideoId]
+	duplicate := exists && c.reports[id.UserID] != nil
+	s.mu.Unlock()
+
+	// Updating an earlier report adds nothing to the queue, so only new
+	// reports count against the limit.
+	if !duplicate && !s.allow(id.UserID) {
+		return nil, status.Error(codes.ResourceExhausted, "too many reports, try again later")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	c = s.openCase(req.VideoId, uploaderID, now)
+	r, duplicate := c.reports[id.UserID]
+	if !duplicate {
+		r = &report{reporterID: id.UserID}
+		c.reports[id.UserID] = r
+	}
+	r.reason = req.Reason
+	r.details = details
+	r.reportedAt = now
+	c.updatedAt = now
+
+	// A reinstated video is only reopened by someone new, so a rejected
+	// reporter cannot keep it in the queue by repeating their report.
+	if c.state == moderation.CaseState_CASE_STATE_UNSPECIFIED ||
+		c.state == moderation.CaseState_CASE_STATE_REINSTATED && !duplicate {
+		if err := s.transition(c, moderation.CaseState_CASE_STATE_REPORTED, id.UserID, "", now); err != nil {
+			return nil, err
+		}
+	}
+
+	return &moderation.ReportVideoResponse{
+		CaseId:      c.id,
+		Duplicate:   duplicate,
+		ReportCount: int32(len(c.reports)),
+	}, nil
+}
+
+func (s *moderationServer) AppealTakedown(ctx context.Context, req *moderation.AppealTakedownRequest) (*moderation.AppealTakedownResponse, error) {
+	id, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unknown caller")
+	}
+
+	message := strings.TrimSpace(req.Message)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.cases[req.VideoId]
+	if !ok || c.uploaderID != id.UserID {
+		return nil, status.Error(codes.NotFound, "no takedown of this video")
+	}
+	if c.state != moderation.CaseState_CASE_STATE_TAKEN_DOWN {
+		return nil, status.Error(codes.FailedPrecondition, "only taken down videos can be appealed")
+	}
+	if err := s.transition(c, moderation.CaseState_CASE_STATE_APPEALED, id.UserID, message, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return &moderation.AppealTakedownResponse{CaseId: c.id, State: c.state}, nil
+}
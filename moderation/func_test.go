@@ -0,0 +1,80 @@
+package moderation_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"coscup2025/env"
+	pbMedia "coscup2025/proto/media"
+	pbModeration "coscup2025/proto/moderation"
+	"coscup2025/servertest"
+)
+
+func setup(t *testing.T, cfg *env.Config) (*grpc.ClientConn, func(username string) context.Context) {
+	srv := servertest.New(t, cfg)
+	signIn := func(username string) context.Context {
+		return servertest.Context(srv.CreateUser(t, username, "testpass"))
+	}
+	return srv.Conn, signIn
+}
+
+func upload(t *testing.T, conn *grpc.ClientConn, ctx context.Context, videoID string) {
+	stream, err := pbMedia.NewMediaServiceClient(conn).UploadVideo(ctx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&pbMedia.UploadVideoRequest{VideoId: videoID, Data: []byte("video"), Sequence: 1}))
+	_, err = stream.CloseAndRecv()
+	require.NoError(t, err)
+}
+
+func TestReportsFoldIntoOneCase(t *testing.T) {
+	conn, signIn := setup(t, env.DefaultConfig())
+	speaker, alice, bob := signIn("speaker"), signIn("alice"), signIn("bob")
+	upload(t, conn, speaker, "talk")
+
+	client := pbModeration.NewModerationServiceClient(conn)
+	first, err := client.ReportVideo(alice, &pbModeration.ReportVideoRequest{VideoId: "talk", Reason: pbModeration.ReportReason_REPORT_REASON_SPAM})
+	require.NoError(t, err)
+	assert.False(t, first.Duplicate)
+	assert.Equal(t, int32(1), first.ReportCount)
+
+	again, err := client.ReportVideo(alice, &pbModeration.ReportVideoRequest{VideoId: "talk", Reason: pbModeration.ReportReason_REPORT_REASON_COPYRIGHT})
+	require.NoError(t, err)
+	assert.True(t, again.Duplicate)
+	assert.Equal(t, int32(1), again.ReportCount)
+
+	other, err := client.ReportVideo(bob, &pbModeration.ReportVideoRequest{VideoId: "talk", Reason: pbModeration.ReportReason_REPORT_REASON_SPAM})
+	require.NoError(t, err)
+	assert.Equal(t, first.CaseId, other.CaseId)
+	assert.Equal(t, int32(2), other.ReportCount)
+}
+
+func TestReportVideoValidation(t *testing.T) {
+	cfg := env.DefaultConfig()
+	cfg.ReportsPerHour = 1
+	conn, signIn := setup(t, cfg)
+	speaker, alice := signIn("speaker"), signIn("alice")
+	upload(t, conn, speaker, "talk")
+	upload(t, conn, speaker, "closing")
+
+	client := pbModeration.NewModerationServiceClient(conn)
+	_, err := client.ReportVideo(alice, &pbModeration.ReportVideoRequest{VideoId: "talk"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = client.ReportVideo(alice, &pbModeration.ReportVideoRequest{VideoId: "talk", Reason: pbModeration.ReportReason_REPORT_REASON_OTHER})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = client.ReportVideo(alice, &pbModeration.ReportVideoRequest{VideoId: "missing", Reason: pbModeration.ReportReason_REPORT_REASON_SPAM})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.ReportVideo(alice, &pbModeration.ReportVideoRequest{VideoId: "talk", Reason: pbModeration.ReportReason_REPORT_REASON_SPAM})
+	require.NoError(t, err)
+	// Updating the report is not limited, a new one is.
+	_, err = client.ReportVideo(alice, &pbModeration.ReportVideoRequest{VideoId: "talk", Reason: pbModeration.ReportReason_REPORT_REASON_OTHER, Details: "reupload of a paid course"})
+	require.NoError(t, err)
+	_, err = client.ReportVideo(alice, &pbModeration.ReportVideoRequest{VideoId: "closing", Reason: pbModeration.ReportReason_REPORT_REASON_SPAM})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
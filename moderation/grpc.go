@@ -0,0 +1,70 @@
+package moderation
+
+import (
+	"context"
+	"coscup2025/env"
+	"coscup2025/proto/moderation"
+	"coscup2025/proto/notification"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Videos gives the moderation service access to the reported videos.
+type Videos interface {
+	// VideoUploader returns the uploader of a video the caller may view.
+	VideoUploader(ctx context.Context, videoID string) (string, bool)
+	// SetTakenDown hides a video from everyone but its uploader, or shows it
+	// again.
+	SetTakenDown(videoID string, takenDown bool) error
+}
+
+// Notifier tells uploaders about the moderation of their videos.
+type Notifier interface {
+	Notify(userID string, kind notification.NotificationKind, videoID, message string)
+}
+
+type moderationServer struct {
+	moderation.UnimplementedModerationServiceServer
+	videos   Videos
+	notifier Notifier
+	mu       sync.Mutex
+	cases    map[string]*videoCase // by video ID
+	queue    []*videoCase          // all cases, oldest first
+	nextID   int64
+
+	limitMu  sync.Mutex
+	limiters map[string]*rate.Limiter
+	perHour  int
+}
+
+func NewModerationServer(cfg *env.Config, videos Videos) *moderationServer {
+	return &moderationServer{
+		videos:   videos,
+		cases:    make(map[string]*videoCase),
+		limiters: make(map[string]*rate.Limiter),
+		perHour:  cfg.ReportsPerHour,
+	}
+}
+
+// SetNotifier makes the server notify uploaders whenever a case about one of
+// their videos changes state.
+func (s *moderationServer) SetNotifier(n Notifier) {
+	s.notifier = n
+}
+
+// allow reports whether userID may file another report now.
+func (s *moderationServer) allow(userID string) bool {
+	if s.perHour <= 0 {
+		return true
+	}
+	s.limitMu.Lock()
+	defer s.limitMu.Unlock()
+	l, ok := s.limiters[userID]
+	if !ok {
+		l = rate.NewLimiter(rate.Every(time.Hour/time.Duration(s.perHour)), s.perHour)
+		s.limiters[userID] = l
+	}
+	return l.Allow()
+}
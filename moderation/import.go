@@ -0,0 +1,181 @@
+package moderation
+
+import (
+	"bytes"
+	"context"
+	"coscup2025/auth"
+	"coscup2025/proto/admin"
+	pbAuth "coscup2025/proto/auth"
+	"coscup2025/validation"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxImportRows bounds the users of one import.
+const maxImportRows = 1000
+
+// Accounts manages accounts on behalf of admins.
+type Accounts interface {
+	UserID(username string) (string, bool)
+	// ImportUser creates the account of username with a temporary password
+	// or, if invite, an invitation, failing with auth.ErrUserExists when the
+	// username is taken.
+	ImportUser(username string, invite bool) (*auth.ImportedUser, error)
+	// RevokeSessions refuses the tokens of the user with the given ID issued
+	// until now, reporting false when there is no such user.
+	RevokeSessions(userID string) (int, bool)
+	// RevokeAllSessions refuses every token issued until now and returns
+	// when that is.
+	RevokeAllSessions() (int, time.Time)
+}
+
+// SetAccounts lets admins import users into a and revoke their sessions.
+func (s *adminServer) SetAccounts(a Accounts) {
+	s.accounts = a
+}
+
+// importRow is a user to import, as written in the payload.
+type importRow struct {
+	Username   string `json:"username"`
+	Credential string `json:"credential"`
+}
+
+func (s *adminServer) ImportUsers(ctx context.Context, req *admin.ImportUsersRequest) (*admin.ImportUsersResponse, error) {
+	if _, err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	if s.accounts == nil {
+		return nil, status.Error(codes.FailedPrecondition, "users cannot be imported")
+	}
+
+	var rows []importRow
+	var err error
+	switch req.Format {
+	case admin.ImportFormat_IMPORT_FORMAT_CSV:
+		rows, err = parseImportCSV(req.Payload)
+	case admin.ImportFormat_IMPORT_FORMAT_JSON:
+		err = json.Unmarshal(req.Payload, &rows)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid payload: %v", err)
+	}
+	if len(rows) > maxImportRows {
+		return nil, status.Errorf(codes.InvalidArgument, "%d users exceed the limit of %d per import", len(rows), maxImportRows)
+	}
+
+	resp := &admin.ImportUsersResponse{}
+	seen := make(map[string]bool, len(rows))
+	for i, row := range rows {
+		u := s.importUser(row, req.Credential, req.DryRun, seen)
+		u.Row = int32(i + 1)
+		if u.Status == admin.ImportStatus_IMPORT_STATUS_CREATED {
+			resp.Created++
+		} else {
+			resp.Skipped++
+		}
+		resp.Users = append(resp.Users, u)
+	}
+	return resp, nil
+}
+
+// importUser imports one row, unless dryRun. seen holds the usernames of the
+// rows before it.
+func (s *adminServer) importUser(row importRow, credential admin.ImportCredential, dryRun bool, seen map[string]bool) *admin.ImportedUser {
+	u := &admin.ImportedUser{Username: row.Username}
+	invalid := func(format string, args ...any) *admin.ImportedUser {
+		u.Status = admin.ImportStatus_IMPORT_STATUS_INVALID
+		u.Error = fmt.Sprintf(format, args...)
+		return u
+	}
+
+	switch strings.ToLower(strings.TrimSpace(row.Credential)) {
+	case "":
+	case "password":
+		credential = admin.ImportCredential_IMPORT_CREDENTIAL_PASSWORD
+	case "invitation":
+		credential = admin.ImportCredential_IMPORT_CREDENTIAL_INVITATION
+	default:
+		return invalid("unknown credential %q, expected password or invitation", row.Credential)
+	}
+	// The same rules as for signing up
+	if err := validation.Validate(&pbAuth.SignUpRequest{Username: row.Username, Password: "-"}); err != nil {
+		return invalid("%s", status.Convert(err).Message())
+	}
+
+	if _, exists := s.accounts.UserID(row.Username); exists || seen[row.Username] {
+		u.Status = admin.ImportStatus_IMPORT_STATUS_EXISTS
+		return u
+	}
+	seen[row.Username] = true
+	u.Status = admin.ImportStatus_IMPORT_STATUS_CREATED
+	if dryRun {
+		return u
+	}
+
+	imported, err := s.accounts.ImportUser(row.Username, credential == admin.ImportCredential_IMPORT_CREDENTIAL_INVITATION)
+	if errors.Is(err, auth.ErrUserExists) {
+		u.Status = admin.ImportStatus_IMPORT_STATUS_EXISTS
+		return u
+	}
+	if err != nil {
+		return invalid("%v", err)
+	}
+	u.UserId = imported.UserID
+	u.TemporaryPassword = imported.Password
+	u.InvitationUrl = imported.InvitationURL
+	if !imported.InvitationExpiresAt.IsZero() {
+		u.InvitationExpiresAt = imported.InvitationExpiresAt.Unix()
+	}
+	return u
+}
+
+// parseImportCSV reads the rows of a CSV payload, whose header names the
+// columns.
+func parseImportCSV(payload []byte) ([]importRow, error) {
+	r := csv.NewReader(bytes.NewReader(payload))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %v", err)
+	}
+	usernameCol, credentialCol := -1, -1
+	for i, name := range header {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "username":
+			usernameCol = i
+		case "credential":
+			credentialCol = i
+		}
+	}
+	if usernameCol < 0 {
+		return nil, errors.New("header has no username column")
+	}
+
+	var rows []importRow
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		var row importRow
+		if usernameCol < len(record) {
+			row.Username = strings.TrimSpace(record[usernameCol])
+		}
+		if credentialCol >= 0 && credentialCol < len(record) {
+			row.Credential = record[credentialCol]
+		}
+		rows = append(rows, row)
+	}
+}
@@ -0,0 +1,102 @@
+package moderation_test
+
+import (
+	"context"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"coscup2025/env"
+	pbAdmin "coscup2025/proto/admin"
+	pbAuth "coscup2025/proto/auth"
+)
+
+const speakersCSV = `name,username,email,credential
+Alice,alice,alice@example.com,password
+Bob,bob,bob@example.com,invitation
+Existing,carol,carol@example.com,
+Duplicate,alice,,
+Broken,dave,,carrier pigeon
+`
+
+func TestImportUsers(t *testing.T) {
+	cfg := env.DefaultConfig()
+	cfg.AdminUsers = []string{"mod"}
+	conn, signIn := setup(t, cfg)
+	mod, carol := signIn("mod"), signIn("carol")
+	client := pbAdmin.NewAdminServiceClient(conn)
+	authClient := pbAuth.NewAuthServiceClient(conn)
+	req := &pbAdmin.ImportUsersRequest{Format: pbAdmin.ImportFormat_IMPORT_FORMAT_CSV, Payload: []byte(speakersCSV)}
+
+	_, err := client.ImportUsers(carol, req)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// Step 1: a dry run reports every row without creating anyone
+	req.DryRun = true
+	resp, err := client.ImportUsers(mod, req)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), resp.Created)
+	assert.Equal(t, int32(3), resp.Skipped)
+	_, err = authClient.SignIn(context.Background(), &pbAuth.SignInRequest{Username: "alice", Password: "anything"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// Step 2: the import creates the accounts and reports each row
+	req.DryRun = false
+	resp, err = client.ImportUsers(mod, req)
+	require.NoError(t, err)
+	require.Len(t, resp.Users, 5)
+	alice, bob, existing, duplicate, broken := resp.Users[0], resp.Users[1], resp.Users[2], resp.Users[3], resp.Users[4]
+	assert.Equal(t, pbAdmin.ImportStatus_IMPORT_STATUS_CREATED, alice.Status)
+	assert.NotEmpty(t, alice.TemporaryPassword)
+	assert.Empty(t, alice.InvitationUrl)
+	assert.Equal(t, pbAdmin.ImportStatus_IMPORT_STATUS_CREATED, bob.Status)
+	assert.Empty(t, bob.TemporaryPassword)
+	assert.Regexp(t, "^/invite/.+", bob.InvitationUrl)
+	assert.Equal(t, pbAdmin.ImportStatus_IMPORT_STATUS_EXISTS, existing.Status)
+	assert.Equal(t, pbAdmin.ImportStatus_IMPORT_STATUS_EXISTS, duplicate.Status)
+	assert.Equal(t, int32(4), duplicate.Row)
+	assert.Equal(t, pbAdmin.ImportStatus_IMPORT_STATUS_INVALID, broken.Status)
+	assert.Contains(t, broken.Error, "carrier pigeon")
+
+	// Step 3: alice signs in with the temporary password
+	_, err = authClient.SignIn(context.Background(), &pbAuth.SignInRequest{Username: "alice", Password: alice.TemporaryPassword})
+	require.NoError(t, err)
+
+	// Step 4: bob signs in only after accepting the invitation, which works once
+	_, err = authClient.SignIn(context.Background(), &pbAuth.SignInRequest{Username: "bob", Password: ""})
+	assert.Error(t, err)
+	invitation := path.Base(bob.InvitationUrl)
+	accepted, err := authClient.AcceptInvitation(context.Background(), &pbAuth.AcceptInvitationRequest{Invitation: invitation, Password: "bobpass"})
+	require.NoError(t, err)
+	assert.Equal(t, bob.UserId, accepted.UserId)
+	assert.NotEmpty(t, accepted.Token)
+	_, err = authClient.SignIn(context.Background(), &pbAuth.SignInRequest{Username: "bob", Password: "bobpass"})
+	require.NoError(t, err)
+	_, err = authClient.AcceptInvitation(context.Background(), &pbAuth.AcceptInvitationRequest{Invitation: invitation, Password: "stolen"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestImportUsersJSON(t *testing.T) {
+	cfg := env.DefaultConfig()
+	cfg.AdminUsers = []string{"mod"}
+	conn, signIn := setup(t, cfg)
+	client := pbAdmin.NewAdminServiceClient(conn)
+
+	mod := signIn("mod")
+	resp, err := client.ImportUsers(mod, &pbAdmin.ImportUsersRequest{
+		Format:     pbAdmin.ImportFormat_IMPORT_FORMAT_JSON,
+		Payload:    []byte(`[{"username": "alice"}, {"username": ""}]`),
+		Credential: pbAdmin.ImportCredential_IMPORT_CREDENTIAL_INVITATION,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Users, 2)
+	assert.NotEmpty(t, resp.Users[0].InvitationUrl)
+	assert.Equal(t, pbAdmin.ImportStatus_IMPORT_STATUS_INVALID, resp.Users[1].Status)
+
+	_, err = client.ImportUsers(mod, &pbAdmin.ImportUsersRequest{Format: pbAdmin.ImportFormat_IMPORT_FORMAT_JSON, Payload: []byte("{")})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,86 @@
+package moderation_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"coscup2025/env"
+	pbAdmin "coscup2025/proto/admin"
+	pbMedia "coscup2025/proto/media"
+)
+
+func TestRetentionPreview(t *testing.T) {
+	cfg := env.DefaultConfig()
+	cfg.AdminUsers = []string{"mod"}
+	conn, signIn := setup(t, cfg)
+	speaker, alice, mod := signIn("speaker"), signIn("alice"), signIn("mod")
+	upload(t, conn, speaker, "talk")
+	upload(t, conn, alice, "demo")
+	md, err := pbMedia.NewMediaServiceClient(conn).GetVideoMetadata(speaker, &pbMedia.GetVideoMetadataRequest{VideoId: "talk"})
+	require.NoError(t, err)
+
+	client := pbAdmin.NewAdminServiceClient(conn)
+	_, err = client.SetRetentionRule(alice, &pbAdmin.SetRetentionRuleRequest{Rule: &pbAdmin.RetentionRule{MaxAgeSeconds: 60}})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// Step 1: a rule for all videos expires both by the next run, an hour
+	// from now
+	all, err := client.SetRetentionRule(mod, &pbAdmin.SetRetentionRuleRequest{Rule: &pbAdmin.RetentionRule{MaxAgeSeconds: 60, Note: "raw uploads"}})
+	require.NoError(t, err)
+	preview, err := client.PreviewRetention(mod, &pbAdmin.PreviewRetentionRequest{})
+	require.NoError(t, err)
+	assert.Greater(t, preview.EvaluatedAt, time.Now().Add(59*time.Minute).Unix())
+	require.Len(t, preview.Videos, 2)
+	assert.Equal(t, int64(10), preview.TotalBytes)
+	assert.Equal(t, all.Rule.RuleId, preview.Videos[0].RuleId)
+
+	// Step 2: a rule for the speaker keeps their videos forever
+	keep, err := client.SetRetentionRule(mod, &pbAdmin.SetRetentionRuleRequest{Rule: &pbAdmin.RetentionRule{UploaderId: md.Metadata.UploaderId}})
+	require.NoError(t, err)
+	preview, err = client.PreviewRetention(mod, &pbAdmin.PreviewRetentionRequest{})
+	require.NoError(t, err)
+	require.Len(t, preview.Videos, 1)
+	assert.Equal(t, "demo", preview.Videos[0].VideoId)
+
+	// Step 3: replacing the general rule keeps the other videos a day
+	_, err = client.SetRetentionRule(mod, &pbAdmin.SetRetentionRuleRequest{Rule: &pbAdmin.RetentionRule{RuleId: all.Rule.RuleId, MaxAgeSeconds: 86400}})
+	require.NoError(t, err)
+	preview, err = client.PreviewRetention(mod, &pbAdmin.PreviewRetentionRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, preview.Videos)
+
+	rules, err := client.ListRetentionRules(mod, &pbAdmin.ListRetentionRulesRequest{})
+	require.NoError(t, err)
+	require.Len(t, rules.Rules, 2)
+	assert.Equal(t, int64(86400), rules.Rules[0].MaxAgeSeconds)
+	assert.Equal(t, keep.Rule.RuleId, rules.Rules[1].RuleId)
+
+	_, err = client.DeleteRetentionRule(mod, &pbAdmin.DeleteRetentionRuleRequest{RuleId: keep.Rule.RuleId})
+	require.NoError(t, err)
+	_, err = client.DeleteRetentionRule(mod, &pbAdmin.DeleteRetentionRuleRequest{RuleId: keep.Rule.RuleId})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = client.SetRetentionRule(mod, &pbAdmin.SetRetentionRuleRequest{Rule: &pbAdmin.RetentionRule{RuleId: keep.Rule.RuleId}})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestRetentionDeletesExpiredVideos(t *testing.T) {
+	cfg := env.DefaultConfig()
+	cfg.AdminUsers = []string{"mod"}
+	cfg.RetentionInterval = 50 * time.Millisecond
+	conn, signIn := setup(t, cfg)
+	speaker, mod := signIn("speaker"), signIn("mod")
+	upload(t, conn, speaker, "talk")
+
+	_, err := pbAdmin.NewAdminServiceClient(conn).SetRetentionRule(mod, &pbAdmin.SetRetentionRuleRequest{Rule: &pbAdmin.RetentionRule{MaxAgeSeconds: 1}})
+	require.NoError(t, err)
+	mediaClient := pbMedia.NewMediaServiceClient(conn)
+	require.Eventually(t, func() bool {
+		_, err := mediaClient.GetVideoMetadata(speaker, &pbMedia.GetVideoMetadataRequest{VideoId: "talk"})
+		return status.Code(err) == codes.NotFound
+	}, 5*time.Second, 50*time.Millisecond)
+}
@@ -0,0 +1,36 @@
+package moderation
+
+import (
+	"context"
+	"coscup2025/proto/admin"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *adminServer) RevokeUserSessions(ctx context.Context, req *admin.RevokeUserSessionsRequest) (*admin.RevokeUserSessionsResponse, error) {
+	if _, err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	if s.accounts == nil {
+		return nil, status.Error(codes.FailedPrecondition, "sessions cannot be revoked")
+	}
+
+	n, ok := s.accounts.RevokeSessions(req.UserId)
+	if !ok {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	return &admin.RevokeUserSessionsResponse{RevokedRefreshTokens: int32(n)}, nil
+}
+
+func (s *adminServer) RevokeAllSessions(ctx context.Context, req *admin.RevokeAllSessionsRequest) (*admin.RevokeAllSessionsResponse, error) {
+	if _, err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	if s.accounts == nil {
+		return nil, status.Error(codes.FailedPrecondition, "sessions cannot be revoked")
+	}
+
+	n, epoch := s.accounts.RevokeAllSessions()
+	return &admin.RevokeAllSessionsResponse{RevokedRefreshTokens: int32(n), Epoch: epoch.Unix()}, nil
+}
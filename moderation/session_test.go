@@ -0,0 +1,70 @@
+package moderation_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"coscup2025/env"
+	pbAdmin "coscup2025/proto/admin"
+	pbAuth "coscup2025/proto/auth"
+)
+
+// nextSecond waits until the second after now, since tokens issued within
+// the second of a revocation are refused too.
+func nextSecond() {
+	time.Sleep(time.Until(time.Now().Truncate(time.Second).Add(time.Second)))
+}
+
+func TestRevokeSessions(t *testing.T) {
+	cfg := env.DefaultConfig()
+	cfg.AdminUsers = []string{"mod"}
+	conn, signIn := setup(t, cfg)
+	mod, alice, bob := signIn("mod"), signIn("alice"), signIn("bob")
+	client := pbAdmin.NewAdminServiceClient(conn)
+	authClient := pbAuth.NewAuthServiceClient(conn)
+	profile := func(ctx context.Context) error {
+		_, err := authClient.GetUserProfile(ctx, &pbAuth.GetUserProfileRequest{})
+		return err
+	}
+	aliceProfile, err := authClient.GetUserProfile(alice, &pbAuth.GetUserProfileRequest{})
+	require.NoError(t, err)
+
+	_, err = client.RevokeUserSessions(bob, &pbAdmin.RevokeUserSessionsRequest{UserId: aliceProfile.UserId})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = client.RevokeUserSessions(mod, &pbAdmin.RevokeUserSessionsRequest{UserId: "user_404"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// Step 1: revoking the sessions of one user signs only them out
+	signedIn, err := authClient.SignIn(context.Background(), &pbAuth.SignInRequest{Username: "alice", Password: "testpass"})
+	require.NoError(t, err)
+	resp, err := client.RevokeUserSessions(mod, &pbAdmin.RevokeUserSessionsRequest{UserId: aliceProfile.UserId})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), resp.RevokedRefreshTokens)
+	assert.Equal(t, codes.Unauthenticated, status.Code(profile(alice)))
+	_, err = authClient.RefreshToken(context.Background(), &pbAuth.RefreshTokenRequest{RefreshToken: signedIn.RefreshToken})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.NoError(t, profile(bob))
+
+	// Step 2: signing in again works
+	nextSecond()
+	signedIn, err = authClient.SignIn(context.Background(), &pbAuth.SignInRequest{Username: "alice", Password: "testpass"})
+	require.NoError(t, err)
+	assert.NoError(t, profile(metadata.NewOutgoingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+signedIn.Token))))
+
+	// Step 3: revoking all sessions signs everyone out, the admin included
+	all, err := client.RevokeAllSessions(mod, &pbAdmin.RevokeAllSessionsRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), all.RevokedRefreshTokens)
+	assert.InDelta(t, time.Now().Unix(), all.Epoch, 1)
+	assert.Equal(t, codes.Unauthenticated, status.Code(profile(bob)))
+	assert.Equal(t, codes.Unauthenticated, status.Code(profile(mod)))
+	_, err = authClient.RefreshToken(context.Background(), &pbAuth.RefreshTokenRequest{RefreshToken: signedIn.RefreshToken})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
@@ -0,0 +1,72 @@
+package moderation
+
+import (
+	"coscup2025/proto/moderation"
+	"coscup2025/proto/notification"
+	"slices"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// reviews lists the states moderators may move a case to from each state.
+// Cases become reported through ReportVideo and appealed through
+// AppealTakedown instead.
+var reviews = map[moderation.CaseState][]moderation.CaseState{
+	moderation.CaseState_CASE_STATE_REPORTED:     {moderation.CaseState_CASE_STATE_UNDER_REVIEW},
+	moderation.CaseState_CASE_STATE_UNDER_REVIEW: {moderation.CaseState_CASE_STATE_TAKEN_DOWN, moderation.CaseState_CASE_STATE_REINSTATED},
+	moderation.CaseState_CASE_STATE_TAKEN_DOWN:   {moderation.CaseState_CASE_STATE_REINSTATED},
+	moderation.CaseState_CASE_STATE_APPEALED:     {moderation.CaseState_CASE_STATE_TAKEN_DOWN, moderation.CaseState_CASE_STATE_REINSTATED},
+}
+
+// canReview reports whether a moderator may move a case from one state to
+// another.
+func canReview(from, to moderation.CaseState) bool {
+	return slices.Contains(reviews[from], to)
+}
+
+var stateMessages = map[moderation.CaseState]string{
+	moderation.CaseState_CASE_STATE_REPORTED:     "Your video was reported and will be reviewed by a moderator.",
+	moderation.CaseState_CASE_STATE_UNDER_REVIEW: "A moderator is reviewing the reports about your video.",
+	moderation.CaseState_CASE_STATE_TAKEN_DOWN:   "Your video was taken down and is now only visible to you. You may appeal this decision.",
+	moderation.CaseState_CASE_STATE_APPEALED:     "Your appeal was received and will be reviewed by a moderator.",
+	moderation.CaseState_CASE_STATE_REINSTATED:   "Your video was reinstated.",
+}
+
+// transition moves c to state to, hides or restores the video accordingly
+// and notifies the uploader. The caller must hold s.mu.
+func (s *moderationServer) transition(c *videoCase, to moderation.CaseState, actorID, note string, now time.Time) error {
+	var err error
+	switch to {
+	case moderation.CaseState_CASE_STATE_TAKEN_DOWN:
+		err = s.videos.SetTakenDown(c.videoID, true)
+	case moderation.CaseState_CASE_STATE_REINSTATED:
+		err = s.videos.SetTakenDown(c.videoID, false)
+	}
+	if err != nil {
+		if _, ok := status.FromError(err); !ok {
+			err = status.Errorf(codes.Internal, "failed to update video: %v", err)
+		}
+		return err
+	}
+
+	c.history = append(c.history, transition{from: c.state, to: to, actorID: actorID, note: note, at: now})
+	c.state = to
+	c.updatedAt = now
+
+	if s.notifier == nil {
+		return nil
+	}
+	kind := notification.NotificationKind_NOTIFICATION_KIND_TAKEDOWN_UPDATE
+	if to == moderation.CaseState_CASE_STATE_REPORTED {
+		kind = notification.NotificationKind_NOTIFICATION_KIND_VIDEO_FLAGGED
+	}
+	message := stateMessages[to]
+	// Uploaders already know what they wrote in their appeal
+	if note != "" && actorID != c.uploaderID {
+		message += " Moderator note: " + note
+	}
+	s.notifier.Notify(c.uploaderID, kind, c.videoID, message)
+	return nil
+}
@@ -0,0 +1,97 @@
+package moderation_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"coscup2025/env"
+	pbAdmin "coscup2025/proto/admin"
+	pbMedia "coscup2025/proto/media"
+	pbModeration "coscup2025/proto/moderation"
+	pbNotification "coscup2025/proto/notification"
+)
+
+func TestTakedownWorkflow(t *testing.T) {
+	cfg := env.DefaultConfig()
+	cfg.AdminUsers = []string{"mod"}
+	conn, signIn := setup(t, cfg)
+	speaker, alice, carol, mod := signIn("speaker"), signIn("alice"), signIn("carol"), signIn("mod")
+	upload(t, conn, speaker, "talk")
+
+	client := pbModeration.NewModerationServiceClient(conn)
+	adminClient := pbAdmin.NewAdminServiceClient(conn)
+	mediaClient := pbMedia.NewMediaServiceClient(conn)
+
+	report, err := client.ReportVideo(alice, &pbModeration.ReportVideoRequest{VideoId: "talk", Reason: pbModeration.ReportReason_REPORT_REASON_SPAM})
+	require.NoError(t, err)
+
+	_, err = adminClient.ListCases(alice, &pbAdmin.ListCasesRequest{})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	cases, err := adminClient.ListCases(mod, &pbAdmin.ListCasesRequest{State: pbModeration.CaseState_CASE_STATE_REPORTED})
+	require.NoError(t, err)
+	require.Len(t, cases.Cases, 1)
+	assert.Equal(t, report.CaseId, cases.Cases[0].CaseId)
+	assert.Len(t, cases.Cases[0].Reports, 1)
+
+	move := func(state pbModeration.CaseState) error {
+		_, err := adminClient.TransitionCase(mod, &pbAdmin.TransitionCaseRequest{CaseId: report.CaseId, State: state, Note: "see guidelines"})
+		return err
+	}
+	// Cases are reviewed before a decision
+	assert.Equal(t, codes.FailedPrecondition, status.Code(move(pbModeration.CaseState_CASE_STATE_TAKEN_DOWN)))
+	require.NoError(t, move(pbModeration.CaseState_CASE_STATE_UNDER_REVIEW))
+
+	_, err = client.AppealTakedown(speaker, &pbModeration.AppealTakedownRequest{VideoId: "talk"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	require.NoError(t, move(pbModeration.CaseState_CASE_STATE_TAKEN_DOWN))
+	_, err = mediaClient.GetVideoMetadata(alice, &pbMedia.GetVideoMetadataRequest{VideoId: "talk"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	md, err := mediaClient.GetVideoMetadata(speaker, &pbMedia.GetVideoMetadataRequest{VideoId: "talk"})
+	require.NoError(t, err)
+	assert.True(t, md.Metadata.TakenDown)
+
+	_, err = client.AppealTakedown(alice, &pbModeration.AppealTakedownRequest{VideoId: "talk"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	appeal, err := client.AppealTakedown(speaker, &pbModeration.AppealTakedownRequest{VideoId: "talk", Message: "this is my own talk"})
+	require.NoError(t, err)
+	assert.Equal(t, pbModeration.CaseState_CASE_STATE_APPEALED, appeal.State)
+
+	require.NoError(t, move(pbModeration.CaseState_CASE_STATE_REINSTATED))
+	_, err = mediaClient.GetVideoMetadata(alice, &pbMedia.GetVideoMetadataRequest{VideoId: "talk"})
+	require.NoError(t, err)
+
+	// Only a new reporter reopens a reinstated case
+	_, err = client.ReportVideo(alice, &pbModeration.ReportVideoRequest{VideoId: "talk", Reason: pbModeration.ReportReason_REPORT_REASON_SPAM})
+	require.NoError(t, err)
+	cases, err = adminClient.ListCases(mod, &pbAdmin.ListCasesRequest{State: pbModeration.CaseState_CASE_STATE_REPORTED})
+	require.NoError(t, err)
+	assert.Empty(t, cases.Cases)
+	_, err = client.ReportVideo(carol, &pbModeration.ReportVideoRequest{VideoId: "talk", Reason: pbModeration.ReportReason_REPORT_REASON_SPAM})
+	require.NoError(t, err)
+	cases, err = adminClient.ListCases(mod, &pbAdmin.ListCasesRequest{State: pbModeration.CaseState_CASE_STATE_REPORTED})
+	require.NoError(t, err)
+	require.Len(t, cases.Cases, 1)
+	assert.Len(t, cases.Cases[0].History, 6)
+
+	// The uploader hears about the upload and every step, newest first
+	notes, err := pbNotification.NewNotificationServiceClient(conn).ListNotifications(speaker, &pbNotification.ListNotificationsRequest{})
+	require.NoError(t, err)
+	var kinds []pbNotification.NotificationKind
+	for _, n := range notes.Notifications {
+		kinds = append(kinds, n.Kind)
+	}
+	assert.Equal(t, []pbNotification.NotificationKind{
+		pbNotification.NotificationKind_NOTIFICATION_KIND_VIDEO_FLAGGED,
+		pbNotification.NotificationKind_NOTIFICATION_KIND_TAKEDOWN_UPDATE,
+		pbNotification.NotificationKind_NOTIFICATION_KIND_TAKEDOWN_UPDATE,
+		pbNotification.NotificationKind_NOTIFICATION_KIND_TAKEDOWN_UPDATE,
+		pbNotification.NotificationKind_NOTIFICATION_KIND_TAKEDOWN_UPDATE,
+		pbNotification.NotificationKind_NOTIFICATION_KIND_VIDEO_FLAGGED,
+		pbNotification.NotificationKind_NOTIFICATION_KIND_UPLOAD_FINISHED,
+	}, kinds)
+}
@@ -0,0 +1,57 @@
+package notification
+
+import (
+	"coscup2025/proto/notification"
+	"log"
+	"time"
+)
+
+// AdminDirectory finds the admin users, such as the auth server.
+type AdminDirectory interface {
+	// AdminIDs returns the IDs of the admin users who have an account.
+	AdminIDs() []string
+}
+
+// Mailer sends templated email, such as a mailer.Mailer.
+type Mailer interface {
+	Send(name string, to []string, data any) error
+}
+
+// Alerter notifies the admin users of failures the operators need to fix,
+// such as a full disk.
+type Alerter struct {
+	notifications *notificationServer
+	admins        AdminDirectory
+	mailer        Mailer
+	mailTo        []string
+}
+
+func NewAlerter(notifications *notificationServer, admins AdminDirectory) *Alerter {
+	return &Alerter{notifications: notifications, admins: admins}
+}
+
+// MailTo makes alerts also go to addresses by email through m, so that they
+// reach operators who are not signed in.
+func (a *Alerter) MailTo(m Mailer, addresses []string) {
+	a.mailer = m
+	a.mailTo = addresses
+}
+
+// Alert sends message to every admin user who signed up, and mails it to
+// the addresses of MailTo.
+func (a *Alerter) Alert(message string) {
+	for _, id := range a.admins.AdminIDs() {
+		a.notifications.Notify(id, notification.NotificationKind_NOTIFICATION_KIND_STORAGE_ALERT, "", message)
+	}
+	if a.mailer == nil || len(a.mailTo) == 0 {
+		return
+	}
+	err := a.mailer.Send("admin_alert", a.mailTo, map[string]any{
+		"Title":   "Storage alert",
+		"Message": message,
+		"Time":    time.Now(),
+	})
+	if err != nil {
+		log.Printf("Failed to mail alert: %v", err)
+	}
+}
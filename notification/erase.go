@@ -0,0 +1,10 @@
+package notification
+
+// EraseUser deletes the notifications of userID, who is being erased.
+func (s *notificationServer) EraseUser(userID, pseudonym string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.byUser, userID)
+	return "notifications"
+}
@@ -0,0 +1,168 @@
+package notification
+
+import (
+	"cmp"
+	"context"
+	"coscup2025/auth"
+	"coscup2025/paging"
+	"coscup2025/proto/notification"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func (s *notificationServer) ListNotifications(ctx context.Context, req *notification.ListNotificationsRequest) (*notification.ListNotificationsResponse, error) {
+	id, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	// limit is the page size of older clients.
+	if req.PageSize == 0 {
+		req.PageSize = req.Limit
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resp := &notification.ListNotificationsResponse{}
+	var listed []*notification.Notification
+	list := s.byUser[id.UserID]
+	for i := len(list) - 1; i >= 0; i-- {
+		n := list[i]
+		if !n.Read {
+			resp.UnreadCount++
+		}
+		if req.UnreadOnly && n.Read {
+			continue
+		}
+		listed = append(listed, n)
+	}
+
+	newestFirst := func(a, b int64) int { return cmp.Compare(b, a) }
+	page, next, err := paging.Page(s.pager, req, listed, sequence, newestFirst, req.UnreadOnly)
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range page {
+		resp.Notifications = append(resp.Notifications, proto.Clone(n).(*notification.Notification))
+	}
+	resp.NextPageToken = next
+	return resp, nil
+}
+
+// sequence returns the number in the ID of n, which grows with every
+// notification.
+func sequence(n *notification.Notification) int64 {
+	seq, _ := strconv.ParseInt(strings.TrimPrefix(n.NotificationId, "n_"), 10, 64)
+	return seq
+}
+
+func (s *notificationServer) MarkRead(ctx context.Context, req *notification.MarkReadRequest) (*notification.MarkReadResponse, error) {
+	id, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+	if !req.All && len(req.NotificationIds) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "notification IDs or all are required")
+	}
+
+	ids := make(map[string]bool, len(req.NotificationIds))
+	for _, nid := range req.NotificationIds {
+		ids[nid] = true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var marked int32
+	for _, n := range s.byUser[id.UserID] {
+		if !n.Read && (req.All || ids[n.NotificationId]) {
+			n.Read = true
+			marked++
+		}
+	}
+	return &notification.MarkReadResponse{Marked: marked}, nil
+}
+
+func (s *notificationServer) Subscribe(req *notification.SubscribeRequest, stream notification.NotificationService_SubscribeServer) error {
+	id, ok := auth.IdentityFromContext(stream.Context())
+	if !ok {
+		return status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	ch, unsubscribe := s.subscribe(id.UserID)
+	defer unsubscribe()
+
+	// Send headers right away so clients know they will not miss anything
+	// from here on.
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case n := <-ch:
+			if err := stream.Send(n); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Notify stores a notification for userID and pushes it to the user's
+// subscribers. It never blocks: a subscriber that falls behind misses pushes
+// but still finds them with ListNotifications.
+func (s *notificationServer) Notify(userID string, kind notification.NotificationKind, videoID, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextID++
+	n := &notification.Notification{
+		NotificationId: fmt.Sprintf("n_%d", s.nextID),
+		Kind:           kind,
+		VideoId:        videoID,
+		Message:        message,
+		CreatedAt:      time.Now().Unix(),
+	}
+	list := append(s.byUser[userID], n)
+	if len(list) > maxPerUser {
+		list = list[len(list)-maxPerUser:]
+	}
+	s.byUser[userID] = list
+
+	for ch := range s.subs[userID] {
+		select {
+		case ch <- proto.Clone(n).(*notification.Notification):
+		default:
+		}
+	}
+}
+
+func (s *notificationServer) subscribe(userID string) (<-chan *notification.Notification, func()) {
+	ch := make(chan *notification.Notification, 16)
+
+	s.mu.Lock()
+	if s.subs[userID] == nil {
+		s.subs[userID] = make(map[chan *notification.Notification]struct{})
+	}
+	s.subs[userID][ch] = struct{}{}
+	s.mu.Unlock()
+
+	return ch, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.subs[userID], ch)
+		if len(s.subs[userID]) == 0 {
+			delete(s.subs, userID)
+		}
+	}
+}
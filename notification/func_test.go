@@ -0,0 +1,48 @@
+package notification_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pbMedia "coscup2025/proto/media"
+	pbNotification "coscup2025/proto/notification"
+	"coscup2025/servertest"
+)
+
+func TestUploadNotifiesUploader(t *testing.T) {
+	srv := servertest.New(t, nil)
+	ctx := servertest.Context(srv.CreateUser(t, "testuser", "testpass"))
+
+	client := pbNotification.NewNotificationServiceClient(srv.Conn)
+	sub, err := client.Subscribe(ctx, &pbNotification.SubscribeRequest{})
+	require.NoError(t, err)
+	// Subscriptions are registered asynchronously; wait until the stream is up.
+	_, err = sub.Header()
+	require.NoError(t, err)
+
+	upload, err := srv.Media().UploadVideo(ctx)
+	require.NoError(t, err)
+	require.NoError(t, upload.Send(&pbMedia.UploadVideoRequest{VideoId: "talk", Data: []byte("video"), Sequence: 1}))
+	_, err = upload.CloseAndRecv()
+	require.NoError(t, err)
+
+	pushed, err := sub.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, pbNotification.NotificationKind_NOTIFICATION_KIND_UPLOAD_FINISHED, pushed.Kind)
+	assert.Equal(t, "talk", pushed.VideoId)
+
+	list, err := client.ListNotifications(ctx, &pbNotification.ListNotificationsRequest{UnreadOnly: true})
+	require.NoError(t, err)
+	require.Len(t, list.Notifications, 1)
+	assert.Equal(t, int32(1), list.UnreadCount)
+
+	marked, err := client.MarkRead(ctx, &pbNotification.MarkReadRequest{NotificationIds: []string{pushed.NotificationId}})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), marked.Marked)
+
+	list, err = client.ListNotifications(ctx, &pbNotification.ListNotificationsRequest{UnreadOnly: true})
+	require.NoError(t, err)
+	assert.Empty(t, list.Notifications)
+}
@@ -0,0 +1,30 @@
+package notification
+
+import (
+	"coscup2025/env"
+	"coscup2025/keyset"
+	"coscup2025/paging"
+	"coscup2025/proto/notification"
+	"sync"
+)
+
+// maxPerUser bounds the notifications kept for each user; older ones are
+// dropped first.
+const maxPerUser = 100
+
+type notificationServer struct {
+	notification.UnimplementedNotificationServiceServer
+	mu     sync.Mutex
+	byUser map[string][]*notification.Notification // oldest first
+	subs   map[string]map[chan *notification.Notification]struct{}
+	nextID int64
+	pager  *paging.Pager
+}
+
+func NewNotificationServer(cfg *env.Config, keys *keyset.Set) *notificationServer {
+	return &notificationServer{
+		byUser: make(map[string][]*notification.Notification),
+		subs:   make(map[string]map[chan *notification.Notification]struct{}),
+		pager:  paging.New(keys),
+	}
+}
@@ -0,0 +1,333 @@
+// Package otlptest stands in for an OTLP collector in tests. It accepts
+// spans and metrics over OTLP/gRPC and records them, so that tests can check
+// what the server exports:
+//
+//	collector := otlptest.New(t)
+//	// export to collector.Endpoint() with an insecure OTLP/gRPC exporter
+//	collector.RequireSpan(t, "ListVideos", attribute.String("enduser.id", "user_1"))
+package otlptest
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/grpc"
+)
+
+// WaitTimeout is how long RequireSpan and RequireMetric wait for exporters to
+// deliver. Batching exporters wait as long before sending, so flush the
+// provider first.
+var WaitTimeout = 5 * time.Second
+
+// Attributes are the attributes of a span, data point or resource.
+type Attributes map[attribute.Key]attribute.Value
+
+// Has reports whether every one of attrs is among a.
+func (a Attributes) Has(attrs ...attribute.KeyValue) bool {
+	for _, kv := range attrs {
+		if v, ok := a[kv.Key]; !ok || v.Emit() != kv.Value.Emit() {
+			return false
+		}
+	}
+	return true
+}
+
+// Span is an exported span.
+type Span struct {
+	Name         string
+	TraceID      string // hex
+	SpanID       string // hex
+	ParentSpanID string // hex, empty for a root span
+	Attributes   Attributes
+	Resource     Attributes
+	Events       []string // names
+	// StatusCode is "Unset", "Ok" or "Error".
+	StatusCode    string
+	StatusMessage string
+}
+
+// Metric is an exported metric, with one point per attribute set.
+type Metric struct {
+	Name     string
+	Unit     string
+	Resource Attributes
+	Points   []Point
+}
+
+// Point is a data point of a metric. Value is the value of a sum or gauge
+// and the sum of a histogram, whose Count is its number of observations.
+type Point struct {
+	Attributes Attributes
+	Value      float64
+	Count      uint64
+}
+
+// Collector records what exporters send it.
+type Collector struct {
+	addr string
+
+	mu      sync.Mutex
+	spans   []Span
+	metrics []Metric
+	// changed is closed and replaced on every export.
+	changed chan struct{}
+}
+
+// New starts a collector on a loopback port. It is stopped when the test
+// ends.
+func New(t testing.TB) *Collector {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	c := &Collector{addr: lis.Addr().String(), changed: make(chan struct{})}
+
+	server := grpc.NewServer()
+	coltracepb.RegisterTraceServiceServer(server, traceService{c: c})
+	colmetricspb.RegisterMetricsServiceServer(server, metricsService{c: c})
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+	return c
+}
+
+// Endpoint is the host:port to give OTLP/gRPC exporters, without TLS.
+func (c *Collector) Endpoint() string {
+	return c.addr
+}
+
+// Spans returns the spans received so far.
+func (c *Collector) Spans() []Span {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Span(nil), c.spans...)
+}
+
+// Metrics returns the metrics received so far, in the order of their
+// exports. A metric exported periodically appears once per export.
+func (c *Collector) Metrics() []Metric {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Metric(nil), c.metrics...)
+}
+
+// Reset forgets everything received.
+func (c *Collector) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.spans, c.metrics = nil, nil
+}
+
+// FindSpan returns the first span named name that has attrs.
+func (c *Collector) FindSpan(name string, attrs ...attribute.KeyValue) (Span, bool) {
+	for _, s := range c.Spans() {
+		if s.Name == name && s.Attributes.Has(attrs...) {
+			return s, true
+		}
+	}
+	return Span{}, false
+}
+
+// RequireSpan waits for a span named name that has attrs and fails the test
+// if none arrives within WaitTimeout.
+func (c *Collector) RequireSpan(t testing.TB, name string, attrs ...attribute.KeyValue) Span {
+	t.Helper()
+	var span Span
+	if !c.wait(func() (ok bool) { span, ok = c.FindSpan(name, attrs...); return ok }) {
+		var seen []string
+		for _, s := range c.Spans() {
+			seen = append(seen, s.Name)
+		}
+		t.Fatalf("no span %q with %v; received %d spans: %s", name, attrs, len(seen), strings.Join(seen, ", "))
+	}
+	return span
+}
+
+// FindMetric returns the latest export of the metric named name, keeping
+// only its points that have attrs. It reports false when no point does.
+func (c *Collector) FindMetric(name string, attrs ...attribute.KeyValue) (Metric, bool) {
+	metrics := c.Metrics()
+	for i := len(metrics) - 1; i >= 0; i-- {
+		m := metrics[i]
+		if m.Name != name {
+			continue
+		}
+		var points []Point
+		for _, p := range m.Points {
+			if p.Attributes.Has(attrs...) {
+				points = append(points, p)
+			}
+		}
+		if len(points) > 0 {
+			m.Points = points
+			return m, true
+		}
+	}
+	return Metric{}, false
+}
+
+// RequireMetric waits for a metric named name with a point that has attrs
+// and fails the test if none arrives within WaitTimeout.
+func (c *Collector) RequireMetric(t testing.TB, name string, attrs ...attribute.KeyValue) Metric {
+	t.Helper()
+	var metric Metric
+	if !c.wait(func() (ok bool) { metric, ok = c.FindMetric(name, attrs...); return ok }) {
+		t.Fatalf("no metric %q with a point with %v", name, attrs)
+	}
+	return metric
+}
+
+// wait calls found after every export until it returns true or WaitTimeout
+// passes.
+func (c *Collector) wait(found func() bool) bool {
+	timeout := time.After(WaitTimeout)
+	for {
+		c.mu.Lock()
+		changed := c.changed
+		c.mu.Unlock()
+		if found() {
+			return true
+		}
+		select {
+		case <-changed:
+		case <-timeout:
+			return false
+		}
+	}
+}
+
+// notifyLocked wakes up waiters after an export.
+func (c *Collector) notifyLocked() {
+	close(c.changed)
+	c.changed = make(chan struct{})
+}
+
+type traceService struct {
+	coltracepb.UnimplementedTraceServiceServer
+	c *Collector
+}
+
+func (s traceService) Export(ctx context.Context, req *coltracepb.ExportTraceServiceRequest) (*coltracepb.ExportTraceServiceResponse, error) {
+	var spans []Span
+	for _, rs := range req.ResourceSpans {
+		resource := attributes(rs.GetResource().GetAttributes())
+		for _, ss := range rs.ScopeSpans {
+			for _, sp := range ss.Spans {
+				span := Span{
+					Name:          sp.Name,
+					TraceID:       hex.EncodeToString(sp.TraceId),
+					SpanID:        hex.EncodeToString(sp.SpanId),
+					ParentSpanID:  hex.EncodeToString(sp.ParentSpanId),
+					Attributes:    attributes(sp.Attributes),
+					Resource:      resource,
+					StatusCode:    "Unset",
+					StatusMessage: sp.GetStatus().GetMessage(),
+				}
+				switch sp.GetStatus().GetCode() {
+				case tracepb.Status_STATUS_CODE_OK:
+					span.StatusCode = "Ok"
+				case tracepb.Status_STATUS_CODE_ERROR:
+					span.StatusCode = "Error"
+				}
+				for _, e := range sp.Events {
+					span.Events = append(span.Events, e.Name)
+				}
+				spans = append(spans, span)
+			}
+		}
+	}
+
+	s.c.mu.Lock()
+	defer s.c.mu.Unlock()
+	s.c.spans = append(s.c.spans, spans...)
+	s.c.notifyLocked()
+	return &coltracepb.ExportTraceServiceResponse{}, nil
+}
+
+type metricsService struct {
+	colmetricspb.UnimplementedMetricsServiceServer
+	c *Collector
+}
+
+func (s metricsService) Export(ctx context.Context, req *colmetricspb.ExportMetricsServiceRequest) (*colmetricspb.ExportMetricsServiceResponse, error) {
+	var metrics []Metric
+	for _, rm := range req.ResourceMetrics {
+		resource := attributes(rm.GetResource().GetAttributes())
+		for _, sm := range rm.ScopeMetrics {
+			for _, m := range sm.Metrics {
+				metrics = append(metrics, Metric{Name: m.Name, Unit: m.Unit, Resource: resource, Points: points(m)})
+			}
+		}
+	}
+
+	s.c.mu.Lock()
+	defer s.c.mu.Unlock()
+	s.c.metrics = append(s.c.metrics, metrics...)
+	s.c.notifyLocked()
+	return &colmetricspb.ExportMetricsServiceResponse{}, nil
+}
+
+func points(m *metricspb.Metric) []Point {
+	var points []Point
+	number := func(dps []*metricspb.NumberDataPoint) {
+		for _, dp := range dps {
+			v := dp.GetAsDouble()
+			if _, ok := dp.Value.(*metricspb.NumberDataPoint_AsInt); ok {
+				v = float64(dp.GetAsInt())
+			}
+			points = append(points, Point{Attributes: attributes(dp.Attributes), Value: v})
+		}
+	}
+	switch data := m.Data.(type) {
+	case *metricspb.Metric_Sum:
+		number(data.Sum.DataPoints)
+	case *metricspb.Metric_Gauge:
+		number(data.Gauge.DataPoints)
+	case *metricspb.Metric_Histogram:
+		for _, dp := range data.Histogram.DataPoints {
+			points = append(points, Point{Attributes: attributes(dp.Attributes), Value: dp.GetSum(), Count: dp.Count})
+		}
+	}
+	return points
+}
+
+func attributes(kvs []*commonpb.KeyValue) Attributes {
+	attrs := make(Attributes, len(kvs))
+	for _, kv := range kvs {
+		attrs[attribute.Key(kv.Key)] = value(kv.Value)
+	}
+	return attrs
+}
+
+func value(v *commonpb.AnyValue) attribute.Value {
+	switch v := v.GetValue().(type) {
+	case *commonpb.AnyValue_StringValue:
+		return attribute.StringValue(v.StringValue)
+	case *commonpb.AnyValue_BoolValue:
+		return attribute.BoolValue(v.BoolValue)
+	case *commonpb.AnyValue_IntValue:
+		return attribute.Int64Value(v.IntValue)
+	case *commonpb.AnyValue_DoubleValue:
+		return attribute.Float64Value(v.DoubleValue)
+	case *commonpb.AnyValue_ArrayValue:
+		var values []string
+		for _, e := range v.ArrayValue.Values {
+			values = append(values, value(e).Emit())
+		}
+		return attribute.StringSliceValue(values)
+	default:
+		return attribute.StringValue(fmt.Sprint(v))
+	}
+}
@@ -0,0 +1,73 @@
+package otlptest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func TestCollectorRecordsSpans(t *testing.T) {
+	c := New(t)
+	exporter, err := otlptracegrpc.New(context.Background(),
+		otlptracegrpc.WithEndpoint(c.Endpoint()),
+		otlptracegrpc.WithInsecure(),
+	)
+	require.NoError(t, err)
+	tp := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter))
+	defer tp.Shutdown(context.Background())
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "Upload")
+	_, child := tp.Tracer("test").Start(ctx, "StoreChunk")
+	child.SetAttributes(attribute.Int("chunk.bytes", 1024), attribute.String("video.id", "talk"))
+	child.AddEvent("committed")
+	child.SetStatus(codes.Error, "disk full")
+	child.End()
+	parent.End()
+	require.NoError(t, tp.ForceFlush(context.Background()))
+
+	span := c.RequireSpan(t, "StoreChunk", attribute.String("video.id", "talk"), attribute.Int("chunk.bytes", 1024))
+	assert.Equal(t, []string{"committed"}, span.Events)
+	assert.Equal(t, "Error", span.StatusCode)
+	assert.Equal(t, "disk full", span.StatusMessage)
+	assert.Equal(t, c.RequireSpan(t, "Upload").SpanID, span.ParentSpanID)
+
+	_, found := c.FindSpan("StoreChunk", attribute.String("video.id", "other"))
+	assert.False(t, found)
+}
+
+func TestCollectorRecordsMetrics(t *testing.T) {
+	c := New(t)
+	conn, err := grpc.NewClient(c.Endpoint(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	method := &commonpb.KeyValue{Key: "method", Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: "Upload"}}}
+	_, err = colmetricspb.NewMetricsServiceClient(conn).Export(context.Background(), &colmetricspb.ExportMetricsServiceRequest{
+		ResourceMetrics: []*metricspb.ResourceMetrics{{
+			ScopeMetrics: []*metricspb.ScopeMetrics{{
+				Metrics: []*metricspb.Metric{{
+					Name: "requests",
+					Data: &metricspb.Metric_Sum{Sum: &metricspb.Sum{DataPoints: []*metricspb.NumberDataPoint{
+						{Attributes: []*commonpb.KeyValue{method}, Value: &metricspb.NumberDataPoint_AsInt{AsInt: 3}},
+					}}},
+				}},
+			}},
+		}},
+	})
+	require.NoError(t, err)
+
+	m := c.RequireMetric(t, "requests", attribute.String("method", "Upload"))
+	require.Len(t, m.Points, 1)
+	assert.Equal(t, 3.0, m.Points[0].Value)
+}
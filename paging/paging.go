@@ -0,0 +1,174 @@
+// Package paging cuts list responses into pages with opaque page tokens.
+// Every list RPC takes a page_size and a page_token and answers with a
+// next_page_token, which is empty on the last page:
+//
+//	videos, next, err := paging.Page(s.pager, req, videos,
+//		func(v *media.VideoSummary) string { return v.VideoId }, cmp.Compare[string],
+//		req.Uploader, req.Tag)
+//
+// A token records the sort key of the last item of its page, so listings
+// continue where they stopped even when items were added or removed in
+// between. Tokens are signed, and bound to the filters of the request that
+// issued them, so clients can neither forge a position nor reuse a token
+// with other filters.
+package paging
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"coscup2025/keyset"
+)
+
+const (
+	// DefaultPageSize is the page size of requests without one.
+	DefaultPageSize = 50
+	// MaxPageSize caps the page size clients may ask for.
+	MaxPageSize = 500
+)
+
+// errInvalidToken is returned for tokens that were not issued for the
+// request.
+var errInvalidToken = status.Error(codes.InvalidArgument, "invalid page token")
+
+// Request is a list request with page_size and page_token fields.
+type Request interface {
+	GetPageSize() int32
+	GetPageToken() string
+}
+
+// Pager issues and checks page tokens.
+type Pager struct {
+	keys keyset.Derived
+}
+
+// New returns a pager signing tokens with a key derived from the server
+// secret. Tokens stay valid across restarts with the same secret, and during
+// the grace period of a rotation.
+func New(keys *keyset.Set) *Pager {
+	return &Pager{keys: keys.Derive("page-tokens")}
+}
+
+// cursor is the content of a page token.
+type cursor struct {
+	Key     json.RawMessage `json:"k"`
+	Filters []byte          `json:"f"` // digest of the request's filters
+}
+
+// Page returns the page of items that req asks for and the token of the
+// next page. items must be in list order, which compare defines on the
+// unique keys that key returns. filters are the request parameters that
+// select items, such as a tag to match; a token is only accepted with the
+// filters it was issued with.
+func Page[T, K any](p *Pager, req Request, items []T, key func(T) K, compare func(a, b K) int, filters ...any) ([]T, string, error) {
+	size := int(req.GetPageSize())
+	if size <= 0 {
+		size = DefaultPageSize
+	}
+	size = min(size, MaxPageSize)
+
+	digest, err := digestFilters(filters)
+	if err != nil {
+		return nil, "", status.Errorf(codes.Internal, "failed to encode filters: %v", err)
+	}
+
+	start := 0
+	if token := req.GetPageToken(); token != "" {
+		after, err := decode[K](p, token, digest)
+		if err != nil {
+			return nil, "", err
+		}
+		// The item of the token may be gone, so look for the first one
+		// after it rather than for the item itself.
+		for start < len(items) && compare(key(items[start]), after) <= 0 {
+			start++
+		}
+	}
+
+	end := min(start+size, len(items))
+	page := items[start:end]
+	if end == len(items) {
+		return page, "", nil
+	}
+	next, err := p.encode(key(page[len(page)-1]), digest)
+	if err != nil {
+		return nil, "", status.Errorf(codes.Internal, "failed to encode page token: %v", err)
+	}
+	return page, next, nil
+}
+
+func digestFilters(filters []any) ([]byte, error) {
+	if len(filters) == 0 {
+		return nil, nil
+	}
+	b, err := json.Marshal(filters)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(b)
+	return sum[:8], nil
+}
+
+// encode returns the token resuming a listing after key.
+func (p *Pager) encode(key any, digest []byte) (string, error) {
+	k, err := json.Marshal(key)
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(cursor{Key: k, Filters: digest})
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(sign(p.keys.Current(), payload)), nil
+}
+
+// decode checks token and returns its key.
+func decode[K any](p *Pager, token string, digest []byte) (K, error) {
+	var key K
+	encoded, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return key, errInvalidToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return key, errInvalidToken
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !p.verify(payload, mac) {
+		return key, errInvalidToken
+	}
+
+	var c cursor
+	if err := json.Unmarshal(payload, &c); err != nil {
+		return key, errInvalidToken
+	}
+	if !bytes.Equal(c.Filters, digest) {
+		return key, status.Error(codes.InvalidArgument, "page token was issued for other filters")
+	}
+	if err := json.Unmarshal(c.Key, &key); err != nil {
+		return key, errInvalidToken
+	}
+	return key, nil
+}
+
+func (p *Pager) verify(payload, mac []byte) bool {
+	for _, key := range p.keys.Valid() {
+		if hmac.Equal(mac, sign(key, payload)) {
+			return true
+		}
+	}
+	return false
+}
+
+func sign(key, payload []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(payload)
+	return mac.Sum(nil)[:16]
+}
@@ -0,0 +1,109 @@
+package paging_test
+
+import (
+	"cmp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"coscup2025/keyset"
+	"coscup2025/paging"
+)
+
+type request struct {
+	size  int32
+	token string
+}
+
+func (r request) GetPageSize() int32   { return r.size }
+func (r request) GetPageToken() string { return r.token }
+
+func identity(s string) string { return s }
+
+func TestPage(t *testing.T) {
+	p := paging.New(keyset.New([]byte("secret")))
+	items := []string{"a", "b", "c", "d", "e", "f", "g"}
+
+	var got []string
+	req := request{size: 3}
+	for pages := 1; ; pages++ {
+		page, next, err := paging.Page(p, req, items, identity, cmp.Compare[string], "tag")
+		require.NoError(t, err)
+		got = append(got, page...)
+		if next == "" {
+			assert.Equal(t, 3, pages)
+			break
+		}
+		req.token = next
+	}
+	assert.Equal(t, items, got)
+
+	// The listing continues after the last item of the page, even when that
+	// item is gone.
+	page, next, err := paging.Page(p, request{size: 2}, items, identity, cmp.Compare[string], "tag")
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b"}, page)
+	page, _, err = paging.Page(p, request{size: 2, token: next}, []string{"a", "c", "d"}, identity, cmp.Compare[string], "tag")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c", "d"}, page)
+}
+
+func TestPageSizes(t *testing.T) {
+	p := paging.New(keyset.New([]byte("secret")))
+	items := make([]int, paging.MaxPageSize+1)
+	for i := range items {
+		items[i] = i
+	}
+	key := func(i int) int { return i }
+
+	page, next, err := paging.Page(p, request{}, items, key, cmp.Compare[int])
+	require.NoError(t, err)
+	assert.Len(t, page, paging.DefaultPageSize)
+	assert.NotEmpty(t, next)
+
+	page, _, err = paging.Page(p, request{size: paging.MaxPageSize + 100}, items, key, cmp.Compare[int])
+	require.NoError(t, err)
+	assert.Len(t, page, paging.MaxPageSize)
+}
+
+func TestInvalidTokens(t *testing.T) {
+	p := paging.New(keyset.New([]byte("secret")))
+	items := []string{"a", "b", "c"}
+	_, next, err := paging.Page(p, request{size: 1}, items, identity, cmp.Compare[string], "tag")
+	require.NoError(t, err)
+
+	for name, token := range map[string]string{
+		"garbage":  "not a token",
+		"tampered": "x" + next[1:],
+		"unsigned": next[:len(next)-4],
+	} {
+		_, _, err := paging.Page(p, request{token: token}, items, identity, cmp.Compare[string], "tag")
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), name)
+	}
+
+	_, _, err = paging.Page(p, request{token: next}, items, identity, cmp.Compare[string], "other tag")
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, _, err = paging.Page(paging.New(keyset.New([]byte("other secret"))), request{token: next}, items, identity, cmp.Compare[string], "tag")
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestTokensRotateWithTheSecret(t *testing.T) {
+	keys := keyset.New([]byte("secret"))
+	p := paging.New(keys)
+	items := []string{"a", "b", "c"}
+	_, next, err := paging.Page(p, request{size: 1}, items, identity, cmp.Compare[string])
+	require.NoError(t, err)
+
+	keys.Rotate([]byte("new secret"), time.Hour)
+	page, _, err := paging.Page(p, request{size: 1, token: next}, items, identity, cmp.Compare[string])
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b"}, page)
+
+	keys.EndGrace()
+	_, _, err = paging.Page(p, request{size: 1, token: next}, items, identity, cmp.Compare[string])
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,314 @@
+// Package policy evaluates authorization policies that operators write in
+// CEL, on top of the checks the services make themselves. A policy can only
+// deny a call the services would allow, never allow one they deny, so that a
+// mistake in a policy cannot open up the server.
+package policy
+
+import (
+	"context"
+	"coscup2025/auth"
+	"coscup2025/env"
+	"coscup2025/mesh"
+	"coscup2025/proto/media"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"gopkg.in/yaml.v3"
+)
+
+// costLimit bounds the work of one evaluation, so that a policy looping
+// over large lists cannot stall calls.
+const costLimit = 100000
+
+// File is the policy file, in YAML.
+type File struct {
+	// Data is made available to the conditions as data, e.g. the owners of
+	// each track.
+	Data map[string]any `yaml:"data"`
+	// Policies are all checked; a call must pass every one that applies.
+	Policies []Policy `yaml:"policies"`
+}
+
+// Policy denies the calls to Methods for which Condition is false.
+type Policy struct {
+	Name string `yaml:"name"`
+	// Methods limits the policy to the methods starting with one of these
+	// prefixes, e.g. "/media.MediaService/DeleteVideo". Empty means every
+	// method.
+	Methods []string `yaml:"methods"`
+	// Condition is a CEL expression of type bool over method, user, peer,
+	// request, resource and data, see Engine.
+	Condition string `yaml:"condition"`
+	// Message is the error the denied caller sees.
+	Message string `yaml:"message"`
+}
+
+// Videos finds the videos that requests name, such as the media server.
+type Videos interface {
+	// VideoResource returns the metadata and tenant of a video, whoever
+	// asks.
+	VideoResource(videoID string) (*media.VideoMetadata, string, bool)
+}
+
+// Engine checks calls against the policies. The conditions see:
+//
+//   - method: the full gRPC method, e.g. "/media.MediaService/DeleteVideo"
+//   - user: the caller's id, username, tenant, video_id (set for video
+//     tokens), admin (whether they are an admin user) and claims (all claims
+//     of their token); empty for calls without a token
+//   - peer: the id and uri of the calling workload, see package mesh; empty
+//     unless the server trusts the mesh
+//   - request: the request message, e.g. request.video_id; the first message
+//     of a stream
+//   - resource: the video the request names by its video_id: id, exists,
+//     tenant and metadata, which is empty when there is no such video
+//   - data: the data of the policy file
+//
+// The zero value has no policies.
+type Engine struct {
+	policies []*compiled
+	data     map[string]any
+
+	mu     sync.RWMutex
+	videos Videos
+}
+
+type compiled struct {
+	Policy
+	program cel.Program
+}
+
+// Load returns the engine for the policy file in cfg, with no policies when
+// there is none.
+func Load(cfg *env.Config) (*Engine, error) {
+	if cfg.PolicyFile == "" {
+		return &Engine{}, nil
+	}
+	b, err := os.ReadFile(cfg.PolicyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file: %v", err)
+	}
+	return Parse(b)
+}
+
+// Parse compiles a policy file.
+func Parse(b []byte) (*Engine, error) {
+	var f File
+	if err := yaml.Unmarshal(b, &f); err != nil {
+		return nil, fmt.Errorf("invalid policy file: %v", err)
+	}
+
+	celEnv, err := cel.NewEnv(
+		cel.TypeDescs(protoregistry.GlobalFiles),
+		cel.Variable("method", cel.StringType),
+		cel.Variable("user", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("peer", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("request", cel.DynType),
+		cel.Variable("resource", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("data", cel.MapType(cel.StringType, cel.DynType)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &Engine{data: f.Data}
+	if e.data == nil {
+		e.data = map[string]any{}
+	}
+	for i, p := range f.Policies {
+		if p.Name == "" {
+			p.Name = fmt.Sprintf("policy %d", i+1)
+		}
+		ast, iss := celEnv.Compile(p.Condition)
+		if iss.Err() != nil {
+			return nil, fmt.Errorf("%s: %v", p.Name, iss.Err())
+		}
+		if ast.OutputType() != cel.BoolType {
+			return nil, fmt.Errorf("%s: condition is %s, not bool", p.Name, ast.OutputType())
+		}
+		program, err := celEnv.Program(ast, cel.CostLimit(costLimit))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", p.Name, err)
+		}
+		e.policies = append(e.policies, &compiled{Policy: p, program: program})
+	}
+	return e, nil
+}
+
+// Enabled reports whether e has any policies.
+func (e *Engine) Enabled() bool {
+	return len(e.policies) > 0
+}
+
+// Len returns the number of policies.
+func (e *Engine) Len() int {
+	return len(e.policies)
+}
+
+// SetVideos makes e look up the videos requests name in v.
+func (e *Engine) SetVideos(v Videos) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.videos = v
+}
+
+// Check returns PermissionDenied when a policy denies the call of method
+// with req. A condition that fails to evaluate denies the call too.
+func (e *Engine) Check(ctx context.Context, method string, req any) error {
+	var vars map[string]any
+	for _, p := range e.policies {
+		if !applies(p.Methods, method) {
+			continue
+		}
+		if vars == nil {
+			vars = e.input(ctx, method, req)
+		}
+		out, _, err := p.program.Eval(vars)
+		if err != nil {
+			log.Printf("Policy %q failed on %s: %v", p.Name, method, err)
+			return e.deny(ctx, p, "the call could not be authorized")
+		}
+		if allowed, _ := out.Value().(bool); !allowed {
+			return e.deny(ctx, p, p.Message)
+		}
+	}
+	return nil
+}
+
+func (e *Engine) deny(ctx context.Context, p *compiled, message string) error {
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("policy.denied", p.Name))
+	if message == "" {
+		message = "denied by policy " + p.Name
+	}
+	return status.Error(codes.PermissionDenied, message)
+}
+
+func applies(prefixes []string, method string) bool {
+	if len(prefixes) == 0 {
+		return true
+	}
+	for _, p := range prefixes {
+		if strings.HasPrefix(method, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// input returns the variables of the conditions.
+func (e *Engine) input(ctx context.Context, method string, req any) map[string]any {
+	user := map[string]any{"id": "", "username": "", "tenant": "", "video_id": "", "admin": false, "claims": map[string]any{}}
+	if id, ok := auth.IdentityFromContext(ctx); ok {
+		user["id"] = id.UserID
+		user["username"] = id.Username
+		user["tenant"] = id.Tenant
+		user["video_id"] = id.VideoID
+		user["admin"] = id.Admin
+		if id.Claims != nil {
+			user["claims"] = id.Claims
+		}
+	}
+
+	peer := map[string]any{"id": "", "uri": ""}
+	if p, ok := mesh.PeerFromContext(ctx); ok {
+		peer["id"] = p.ID()
+		peer["uri"] = p.URI
+	}
+
+	resource := map[string]any{"id": "", "exists": false, "tenant": "", "metadata": &media.VideoMetadata{}}
+	var request any = map[string]any{}
+	if m, ok := req.(proto.Message); ok {
+		request = m
+		if videoID := videoIDOf(m); videoID != "" {
+			resource["id"] = videoID
+			e.mu.RLock()
+			videos := e.videos
+			e.mu.RUnlock()
+			if videos != nil {
+				if metadata, tenant, ok := videos.VideoResource(videoID); ok {
+					resource["exists"] = true
+					resource["tenant"] = tenant
+					resource["metadata"] = metadata
+				}
+			}
+		}
+	}
+
+	return map[string]any{
+		"method":   method,
+		"user":     user,
+		"peer":     peer,
+		"request":  request,
+		"resource": resource,
+		"data":     e.data,
+	}
+}
+
+// videoIDOf returns the video_id field of a request, if it has one.
+func videoIDOf(m proto.Message) string {
+	r := m.ProtoReflect()
+	fd := r.Descriptor().Fields().ByName("video_id")
+	if fd == nil || fd.Kind() != protoreflect.StringKind || fd.IsList() {
+		return ""
+	}
+	return r.Get(fd).String()
+}
+
+// UnaryServerInterceptor denies the unary calls a policy denies.
+func (e *Engine) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := e.Check(ctx, info.FullMethod, req); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamServerInterceptor denies the streams a policy denies, checking their
+// first message.
+func (e *Engine) StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if !slices.ContainsFunc(e.policies, func(p *compiled) bool { return applies(p.Methods, info.FullMethod) }) {
+		return handler(srv, ss)
+	}
+	cs := &checkedStream{ServerStream: ss, engine: e, method: info.FullMethod}
+	err := handler(srv, cs)
+	// The handler may have turned the denial into an error of its own.
+	if cs.denied != nil {
+		return cs.denied
+	}
+	return err
+}
+
+// checkedStream checks the first message received.
+type checkedStream struct {
+	grpc.ServerStream
+	engine  *Engine
+	method  string
+	checked bool
+	denied  error
+}
+
+func (s *checkedStream) RecvMsg(m interface{}) error {
+	if s.denied != nil {
+		return s.denied
+	}
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if !s.checked {
+		s.checked = true
+		s.denied = s.engine.Check(s.Context(), s.method, m)
+	}
+	return s.denied
+}
@@ -0,0 +1,101 @@
+package policy_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"coscup2025/env"
+	"coscup2025/policy"
+	pbMedia "coscup2025/proto/media"
+	"coscup2025/servertest"
+)
+
+const trackOwners = `
+data:
+  track_owners:
+    security: [alice]
+policies:
+  - name: track-owners-delete
+    methods: [/media.MediaService/DeleteVideo]
+    condition: >
+      user.admin || resource.metadata.tags.all(t,
+        !(t in data.track_owners) || user.username in data.track_owners[t])
+    message: only the track's owners may delete its videos
+  - name: few-tags
+    methods: [/media.MediaService/UploadVideo]
+    condition: request.tags.size() <= 3
+`
+
+func setup(t *testing.T) *servertest.Server {
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(trackOwners), 0o600))
+	cfg := env.DefaultConfig()
+	cfg.PolicyFile = path
+	return servertest.New(t, cfg)
+}
+
+func upload(ctx context.Context, client pbMedia.MediaServiceClient, videoID string, tags ...string) error {
+	stream, err := client.UploadVideo(ctx)
+	if err != nil {
+		return err
+	}
+	if err := stream.Send(&pbMedia.UploadVideoRequest{VideoId: videoID, Data: []byte(videoID), Sequence: 1, Tags: tags}); err != nil {
+		return err
+	}
+	_, err = stream.CloseAndRecv()
+	return err
+}
+
+func TestTrackOwnersDelete(t *testing.T) {
+	srv := setup(t)
+	client := srv.Media()
+	alice := servertest.Context(srv.CreateUser(t, "alice", "secret"))
+	bob := servertest.Context(srv.CreateUser(t, "bob", "secret"))
+
+	// Step 1: alice owns the security track and may delete its videos
+	require.NoError(t, upload(alice, client, "alice-talk", "security"))
+	_, err := client.DeleteVideo(alice, &pbMedia.DeleteVideoRequest{VideoId: "alice-talk"})
+	require.NoError(t, err)
+
+	// Step 2: bob may not, though bob uploaded the video
+	require.NoError(t, upload(bob, client, "bob-talk", "security", "go"))
+	_, err = client.DeleteVideo(bob, &pbMedia.DeleteVideoRequest{VideoId: "bob-talk"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.Equal(t, "only the track's owners may delete its videos", status.Convert(err).Message())
+
+	// Step 3: videos outside the owned tracks are not affected
+	require.NoError(t, upload(bob, client, "bob-other", "go"))
+	_, err = client.DeleteVideo(bob, &pbMedia.DeleteVideoRequest{VideoId: "bob-other"})
+	require.NoError(t, err)
+}
+
+func TestStreamPolicy(t *testing.T) {
+	srv := setup(t)
+	client := srv.Media()
+	ctx := servertest.Context(srv.CreateUser(t, "speaker", "secret"))
+
+	err := upload(ctx, client, "talk", "a", "b", "c", "d")
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.Equal(t, "denied by policy few-tags", status.Convert(err).Message())
+
+	require.NoError(t, upload(ctx, client, "talk", "a", "b"))
+}
+
+func TestParse(t *testing.T) {
+	engine, err := policy.Parse(nil)
+	require.NoError(t, err)
+	assert.False(t, engine.Enabled())
+
+	_, err = policy.Parse([]byte("policies:\n  - name: broken\n    condition: user.username ==\n"))
+	assert.ErrorContains(t, err, "broken")
+
+	_, err = policy.Parse([]byte("policies:\n  - name: not-bool\n    condition: user.username\n"))
+	assert.ErrorContains(t, err, "not bool")
+}
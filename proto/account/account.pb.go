@@ -0,0 +1,1756 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: account/account.proto
+
+package account
+
+import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	media "coscup2025/proto/media"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ExportState int32
+
+const (
+	ExportState_EXPORT_STATE_UNSPECIFIED ExportState = 0
+	ExportState_EXPORT_STATE_PENDING     ExportState = 1
+	ExportState_EXPORT_STATE_RUNNING     ExportState = 2
+	ExportState_EXPORT_STATE_READY       ExportState = 3
+	ExportState_EXPORT_STATE_FAILED      ExportState = 4
+)
+
+// Enum value maps for ExportState.
+var (
+	ExportState_name = map[int32]string{
+		0: "EXPORT_STATE_UNSPECIFIED",
+		1: "EXPORT_STATE_PENDING",
+		2: "EXPORT_STATE_RUNNING",
+		3: "EXPORT_STATE_READY",
+		4: "EXPORT_STATE_FAILED",
+	}
+	ExportState_value = map[string]int32{
+		"EXPORT_STATE_UNSPECIFIED": 0,
+		"EXPORT_STATE_PENDING":     1,
+		"EXPORT_STATE_RUNNING":     2,
+		"EXPORT_STATE_READY":       3,
+		"EXPORT_STATE_FAILED":      4,
+	}
+)
+
+func (x ExportState) Enum() *ExportState {
+	p := new(ExportState)
+	*p = x
+	return p
+}
+
+func (x ExportState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExportState) Descriptor() protoreflect.EnumDescriptor {
+	return file_account_account_proto_enumTypes[0].Descriptor()
+}
+
+func (ExportState) Type() protoreflect.EnumType {
+	return &file_account_account_proto_enumTypes[0]
+}
+
+func (x ExportState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExportState.Descriptor instead.
+func (ExportState) EnumDescriptor() ([]byte, []int) {
+	return file_account_account_proto_rawDescGZIP(), []int{0}
+}
+
+// ConsentPurpose is a use of a user's data that needs their consent
+type ConsentPurpose int32
+
+const (
+	ConsentPurpose_CONSENT_PURPOSE_UNSPECIFIED ConsentPurpose = 0
+	// The server does no analytics and sends users no email, so nobody can
+	// consent to them.
+	//
+	// Deprecated: Marked as deprecated in account/account.proto.
+	ConsentPurpose_CONSENT_PURPOSE_ANALYTICS ConsentPurpose = 1
+	// Deprecated: Marked as deprecated in account/account.proto.
+	ConsentPurpose_CONSENT_PURPOSE_EMAIL_NOTIFICATIONS ConsentPurpose = 2
+	ConsentPurpose_CONSENT_PURPOSE_PUBLIC_RECORDINGS   ConsentPurpose = 3 // listing the user's videos in the public gallery
+)
+
+// Enum value maps for ConsentPurpose.
+var (
+	ConsentPurpose_name = map[int32]string{
+		0: "CONSENT_PURPOSE_UNSPECIFIED",
+		1: "CONSENT_PURPOSE_ANALYTICS",
+		2: "CONSENT_PURPOSE_EMAIL_NOTIFICATIONS",
+		3: "CONSENT_PURPOSE_PUBLIC_RECORDINGS",
+	}
+	ConsentPurpose_value = map[string]int32{
+		"CONSENT_PURPOSE_UNSPECIFIED":         0,
+		"CONSENT_PURPOSE_ANALYTICS":           1,
+		"CONSENT_PURPOSE_EMAIL_NOTIFICATIONS": 2,
+		"CONSENT_PURPOSE_PUBLIC_RECORDINGS":   3,
+	}
+)
+
+func (x ConsentPurpose) Enum() *ConsentPurpose {
+	p := new(ConsentPurpose)
+	*p = x
+	return p
+}
+
+func (x ConsentPurpose) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ConsentPurpose) Descriptor() protoreflect.EnumDescriptor {
+	return file_account_account_proto_enumTypes[1].Descriptor()
+}
+
+func (ConsentPurpose) Type() protoreflect.EnumType {
+	return &file_account_account_proto_enumTypes[1]
+}
+
+func (x ConsentPurpose) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ConsentPurpose.Descriptor instead.
+func (ConsentPurpose) EnumDescriptor() ([]byte, []int) {
+	return file_account_account_proto_rawDescGZIP(), []int{1}
+}
+
+type Export struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExportId      string                 `protobuf:"bytes,1,opt,name=export_id,json=exportId,proto3" json:"export_id,omitempty"`
+	State         ExportState            `protobuf:"varint,2,opt,name=state,proto3,enum=account.ExportState" json:"state,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt     int64                  `protobuf:"varint,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // when the archive is deleted, once finished
+	Size          int64                  `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`                            // of the archive, once ready
+	Error         string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`                           // why the export failed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Export) Reset() {
+	*x = Export{}
+	mi := &file_account_account_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Export) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Export) ProtoMessage() {}
+
+func (x *Export) ProtoReflect() protoreflect.Message {
+	mi := &file_account_account_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Export.ProtoReflect.Descriptor instead.
+func (*Export) Descriptor() ([]byte, []int) {
+	return file_account_account_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Export) GetExportId() string {
+	if x != nil {
+		return x.ExportId
+	}
+	return ""
+}
+
+func (x *Export) GetState() ExportState {
+	if x != nil {
+		return x.State
+	}
+	return ExportState_EXPORT_STATE_UNSPECIFIED
+}
+
+func (x *Export) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Export) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *Export) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Export) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ExportMyDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IncludeMedia  bool                   `protobuf:"varint,1,opt,name=include_media,json=includeMedia,proto3" json:"include_media,omitempty"` // also add the uploaded video files
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportMyDataRequest) Reset() {
+	*x = ExportMyDataRequest{}
+	mi := &file_account_account_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportMyDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportMyDataRequest) ProtoMessage() {}
+
+func (x *ExportMyDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_account_account_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportMyDataRequest.ProtoReflect.Descriptor instead.
+func (*ExportMyDataRequest) Descriptor() ([]byte, []int) {
+	return file_account_account_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ExportMyDataRequest) GetIncludeMedia() bool {
+	if x != nil {
+		return x.IncludeMedia
+	}
+	return false
+}
+
+type ExportMyDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Export        *Export                `protobuf:"bytes,1,opt,name=export,proto3" json:"export,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportMyDataResponse) Reset() {
+	*x = ExportMyDataResponse{}
+	mi := &file_account_account_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportMyDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportMyDataResponse) ProtoMessage() {}
+
+func (x *ExportMyDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_account_account_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportMyDataResponse.ProtoReflect.Descriptor instead.
+func (*ExportMyDataResponse) Descriptor() ([]byte, []int) {
+	return file_account_account_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ExportMyDataResponse) GetExport() *Export {
+	if x != nil {
+		return x.Export
+	}
+	return nil
+}
+
+type GetExportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExportId      string                 `protobuf:"bytes,1,opt,name=export_id,json=exportId,proto3" json:"export_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetExportRequest) Reset() {
+	*x = GetExportRequest{}
+	mi := &file_account_account_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetExportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetExportRequest) ProtoMessage() {}
+
+func (x *GetExportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_account_account_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetExportRequest.ProtoReflect.Descriptor instead.
+func (*GetExportRequest) Descriptor() ([]byte, []int) {
+	return file_account_account_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetExportRequest) GetExportId() string {
+	if x != nil {
+		return x.ExportId
+	}
+	return ""
+}
+
+type GetExportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Export        *Export                `protobuf:"bytes,1,opt,name=export,proto3" json:"export,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetExportResponse) Reset() {
+	*x = GetExportResponse{}
+	mi := &file_account_account_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetExportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetExportResponse) ProtoMessage() {}
+
+func (x *GetExportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_account_account_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetExportResponse.ProtoReflect.Descriptor instead.
+func (*GetExportResponse) Descriptor() ([]byte, []int) {
+	return file_account_account_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetExportResponse) GetExport() *Export {
+	if x != nil {
+		return x.Export
+	}
+	return nil
+}
+
+type DownloadExportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExportId      string                 `protobuf:"bytes,1,opt,name=export_id,json=exportId,proto3" json:"export_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadExportRequest) Reset() {
+	*x = DownloadExportRequest{}
+	mi := &file_account_account_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadExportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadExportRequest) ProtoMessage() {}
+
+func (x *DownloadExportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_account_account_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadExportRequest.ProtoReflect.Descriptor instead.
+func (*DownloadExportRequest) Descriptor() ([]byte, []int) {
+	return file_account_account_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DownloadExportRequest) GetExportId() string {
+	if x != nil {
+		return x.ExportId
+	}
+	return ""
+}
+
+type DownloadExportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileName      string                 `protobuf:"bytes,1,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"` // only in the first message
+	Size          int64                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`                        // only in the first message
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadExportResponse) Reset() {
+	*x = DownloadExportResponse{}
+	mi := &file_account_account_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadExportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadExportResponse) ProtoMessage() {}
+
+func (x *DownloadExportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_account_account_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadExportResponse.ProtoReflect.Descriptor instead.
+func (*DownloadExportResponse) Descriptor() ([]byte, []int) {
+	return file_account_account_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DownloadExportResponse) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *DownloadExportResponse) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *DownloadExportResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// Login is a successful sign-in, as exported in profile.json
+type Login struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	At            int64                  `protobuf:"varint,1,opt,name=at,proto3" json:"at,omitempty"`
+	Address       string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	UserAgent     string                 `protobuf:"bytes,3,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Login) Reset() {
+	*x = Login{}
+	mi := &file_account_account_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Login) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Login) ProtoMessage() {}
+
+func (x *Login) ProtoReflect() protoreflect.Message {
+	mi := &file_account_account_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Login.ProtoReflect.Descriptor instead.
+func (*Login) Descriptor() ([]byte, []int) {
+	return file_account_account_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Login) GetAt() int64 {
+	if x != nil {
+		return x.At
+	}
+	return 0
+}
+
+func (x *Login) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Login) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+// Profile is the profile.json file of an export
+type Profile struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UserId          string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username        string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	CreatedAt       int64                  `protobuf:"varint,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Logins          []*Login               `protobuf:"bytes,4,rep,name=logins,proto3" json:"logins,omitempty"`                                 // oldest first
+	TermsVersion    string                 `protobuf:"bytes,5,opt,name=terms_version,json=termsVersion,proto3" json:"terms_version,omitempty"` // of the terms of service last accepted
+	TermsAcceptedAt int64                  `protobuf:"varint,6,opt,name=terms_accepted_at,json=termsAcceptedAt,proto3" json:"terms_accepted_at,omitempty"`
+	Consent         *Consent               `protobuf:"bytes,7,opt,name=consent,proto3" json:"consent,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Profile) Reset() {
+	*x = Profile{}
+	mi := &file_account_account_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Profile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Profile) ProtoMessage() {}
+
+func (x *Profile) ProtoReflect() protoreflect.Message {
+	mi := &file_account_account_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Profile.ProtoReflect.Descriptor instead.
+func (*Profile) Descriptor() ([]byte, []int) {
+	return file_account_account_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Profile) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Profile) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *Profile) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Profile) GetLogins() []*Login {
+	if x != nil {
+		return x.Logins
+	}
+	return nil
+}
+
+func (x *Profile) GetTermsVersion() string {
+	if x != nil {
+		return x.TermsVersion
+	}
+	return ""
+}
+
+func (x *Profile) GetTermsAcceptedAt() int64 {
+	if x != nil {
+		return x.TermsAcceptedAt
+	}
+	return 0
+}
+
+func (x *Profile) GetConsent() *Consent {
+	if x != nil {
+		return x.Consent
+	}
+	return nil
+}
+
+type ExportedVideo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	Metadata      *media.VideoMetadata   `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	MediaPath     string                 `protobuf:"bytes,3,opt,name=media_path,json=mediaPath,proto3" json:"media_path,omitempty"` // path of the video file in the archive, if included
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportedVideo) Reset() {
+	*x = ExportedVideo{}
+	mi := &file_account_account_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportedVideo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportedVideo) ProtoMessage() {}
+
+func (x *ExportedVideo) ProtoReflect() protoreflect.Message {
+	mi := &file_account_account_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportedVideo.ProtoReflect.Descriptor instead.
+func (*ExportedVideo) Descriptor() ([]byte, []int) {
+	return file_account_account_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ExportedVideo) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *ExportedVideo) GetMetadata() *media.VideoMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *ExportedVideo) GetMediaPath() string {
+	if x != nil {
+		return x.MediaPath
+	}
+	return ""
+}
+
+// Videos is the videos.json file of an export
+type Videos struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Videos        []*ExportedVideo       `protobuf:"bytes,1,rep,name=videos,proto3" json:"videos,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Videos) Reset() {
+	*x = Videos{}
+	mi := &file_account_account_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Videos) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Videos) ProtoMessage() {}
+
+func (x *Videos) ProtoReflect() protoreflect.Message {
+	mi := &file_account_account_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Videos.ProtoReflect.Descriptor instead.
+func (*Videos) Descriptor() ([]byte, []int) {
+	return file_account_account_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Videos) GetVideos() []*ExportedVideo {
+	if x != nil {
+		return x.Videos
+	}
+	return nil
+}
+
+type Deletion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReceiptId     string                 `protobuf:"bytes,1,opt,name=receipt_id,json=receiptId,proto3" json:"receipt_id,omitempty"` // retrieve the receipt with this once erased
+	RequestedAt   int64                  `protobuf:"varint,2,opt,name=requested_at,json=requestedAt,proto3" json:"requested_at,omitempty"`
+	ScheduledAt   int64                  `protobuf:"varint,3,opt,name=scheduled_at,json=scheduledAt,proto3" json:"scheduled_at,omitempty"` // when the account is erased
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Deletion) Reset() {
+	*x = Deletion{}
+	mi := &file_account_account_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Deletion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Deletion) ProtoMessage() {}
+
+func (x *Deletion) ProtoReflect() protoreflect.Message {
+	mi := &file_account_account_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Deletion.ProtoReflect.Descriptor instead.
+func (*Deletion) Descriptor() ([]byte, []int) {
+	return file_account_account_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Deletion) GetReceiptId() string {
+	if x != nil {
+		return x.ReceiptId
+	}
+	return ""
+}
+
+func (x *Deletion) GetRequestedAt() int64 {
+	if x != nil {
+		return x.RequestedAt
+	}
+	return 0
+}
+
+func (x *Deletion) GetScheduledAt() int64 {
+	if x != nil {
+		return x.ScheduledAt
+	}
+	return 0
+}
+
+// DeletionReceipt proves that an account was erased. The signature is an
+// Ed25519 signature, by the key whose public half the operator publishes,
+// over the lines
+// "coscup-deletion-receipt/v1", receipt_id, subject, requested_at, erased_at,
+// videos_deleted, bytes_deleted and the erased items joined by commas, each
+// terminated by "\n", with numbers in decimal.
+type DeletionReceipt struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReceiptId     string                 `protobuf:"bytes,1,opt,name=receipt_id,json=receiptId,proto3" json:"receipt_id,omitempty"`
+	Subject       string                 `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"` // the pseudonym replacing the user in the audit log
+	RequestedAt   int64                  `protobuf:"varint,3,opt,name=requested_at,json=requestedAt,proto3" json:"requested_at,omitempty"`
+	ErasedAt      int64                  `protobuf:"varint,4,opt,name=erased_at,json=erasedAt,proto3" json:"erased_at,omitempty"`
+	VideosDeleted int32                  `protobuf:"varint,5,opt,name=videos_deleted,json=videosDeleted,proto3" json:"videos_deleted,omitempty"`
+	BytesDeleted  int64                  `protobuf:"varint,6,opt,name=bytes_deleted,json=bytesDeleted,proto3" json:"bytes_deleted,omitempty"`
+	Erased        []string               `protobuf:"bytes,7,rep,name=erased,proto3" json:"erased,omitempty"`       // e.g. "account", "tokens", "media"
+	Signature     []byte                 `protobuf:"bytes,8,opt,name=signature,proto3" json:"signature,omitempty"` // empty when the server has no receipt key
+	// No longer set: a key carried by the receipt proves nothing, so verify
+	// with the published one.
+	//
+	// Deprecated: Marked as deprecated in account/account.proto.
+	PublicKey     []byte `protobuf:"bytes,9,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletionReceipt) Reset() {
+	*x = DeletionReceipt{}
+	mi := &file_account_account_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletionReceipt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletionReceipt) ProtoMessage() {}
+
+func (x *DeletionReceipt) ProtoReflect() protoreflect.Message {
+	mi := &file_account_account_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletionReceipt.ProtoReflect.Descriptor instead.
+func (*DeletionReceipt) Descriptor() ([]byte, []int) {
+	return file_account_account_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DeletionReceipt) GetReceiptId() string {
+	if x != nil {
+		return x.ReceiptId
+	}
+	return ""
+}
+
+func (x *DeletionReceipt) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *DeletionReceipt) GetRequestedAt() int64 {
+	if x != nil {
+		return x.RequestedAt
+	}
+	return 0
+}
+
+func (x *DeletionReceipt) GetErasedAt() int64 {
+	if x != nil {
+		return x.ErasedAt
+	}
+	return 0
+}
+
+func (x *DeletionReceipt) GetVideosDeleted() int32 {
+	if x != nil {
+		return x.VideosDeleted
+	}
+	return 0
+}
+
+func (x *DeletionReceipt) GetBytesDeleted() int64 {
+	if x != nil {
+		return x.BytesDeleted
+	}
+	return 0
+}
+
+func (x *DeletionReceipt) GetErased() []string {
+	if x != nil {
+		return x.Erased
+	}
+	return nil
+}
+
+func (x *DeletionReceipt) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+// Deprecated: Marked as deprecated in account/account.proto.
+func (x *DeletionReceipt) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+type RequestDeletionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestDeletionRequest) Reset() {
+	*x = RequestDeletionRequest{}
+	mi := &file_account_account_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestDeletionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestDeletionRequest) ProtoMessage() {}
+
+func (x *RequestDeletionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_account_account_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestDeletionRequest.ProtoReflect.Descriptor instead.
+func (*RequestDeletionRequest) Descriptor() ([]byte, []int) {
+	return file_account_account_proto_rawDescGZIP(), []int{13}
+}
+
+type RequestDeletionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deletion      *Deletion              `protobuf:"bytes,1,opt,name=deletion,proto3" json:"deletion,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestDeletionResponse) Reset() {
+	*x = RequestDeletionResponse{}
+	mi := &file_account_account_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestDeletionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestDeletionResponse) ProtoMessage() {}
+
+func (x *RequestDeletionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_account_account_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestDeletionResponse.ProtoReflect.Descriptor instead.
+func (*RequestDeletionResponse) Descriptor() ([]byte, []int) {
+	return file_account_account_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RequestDeletionResponse) GetDeletion() *Deletion {
+	if x != nil {
+		return x.Deletion
+	}
+	return nil
+}
+
+type GetDeletionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDeletionRequest) Reset() {
+	*x = GetDeletionRequest{}
+	mi := &file_account_account_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeletionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeletionRequest) ProtoMessage() {}
+
+func (x *GetDeletionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_account_account_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeletionRequest.ProtoReflect.Descriptor instead.
+func (*GetDeletionRequest) Descriptor() ([]byte, []int) {
+	return file_account_account_proto_rawDescGZIP(), []int{15}
+}
+
+type GetDeletionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deletion      *Deletion              `protobuf:"bytes,1,opt,name=deletion,proto3" json:"deletion,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDeletionResponse) Reset() {
+	*x = GetDeletionResponse{}
+	mi := &file_account_account_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeletionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeletionResponse) ProtoMessage() {}
+
+func (x *GetDeletionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_account_account_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeletionResponse.ProtoReflect.Descriptor instead.
+func (*GetDeletionResponse) Descriptor() ([]byte, []int) {
+	return file_account_account_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetDeletionResponse) GetDeletion() *Deletion {
+	if x != nil {
+		return x.Deletion
+	}
+	return nil
+}
+
+type CancelDeletionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelDeletionRequest) Reset() {
+	*x = CancelDeletionRequest{}
+	mi := &file_account_account_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelDeletionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelDeletionRequest) ProtoMessage() {}
+
+func (x *CancelDeletionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_account_account_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelDeletionRequest.ProtoReflect.Descriptor instead.
+func (*CancelDeletionRequest) Descriptor() ([]byte, []int) {
+	return file_account_account_proto_rawDescGZIP(), []int{17}
+}
+
+type CancelDeletionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelDeletionResponse) Reset() {
+	*x = CancelDeletionResponse{}
+	mi := &file_account_account_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelDeletionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelDeletionResponse) ProtoMessage() {}
+
+func (x *CancelDeletionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_account_account_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelDeletionResponse.ProtoReflect.Descriptor instead.
+func (*CancelDeletionResponse) Descriptor() ([]byte, []int) {
+	return file_account_account_proto_rawDescGZIP(), []int{18}
+}
+
+type GetDeletionReceiptRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReceiptId     string                 `protobuf:"bytes,1,opt,name=receipt_id,json=receiptId,proto3" json:"receipt_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDeletionReceiptRequest) Reset() {
+	*x = GetDeletionReceiptRequest{}
+	mi := &file_account_account_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeletionReceiptRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeletionReceiptRequest) ProtoMessage() {}
+
+func (x *GetDeletionReceiptRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_account_account_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeletionReceiptRequest.ProtoReflect.Descriptor instead.
+func (*GetDeletionReceiptRequest) Descriptor() ([]byte, []int) {
+	return file_account_account_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetDeletionReceiptRequest) GetReceiptId() string {
+	if x != nil {
+		return x.ReceiptId
+	}
+	return ""
+}
+
+type GetDeletionReceiptResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Receipt       *DeletionReceipt       `protobuf:"bytes,1,opt,name=receipt,proto3" json:"receipt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDeletionReceiptResponse) Reset() {
+	*x = GetDeletionReceiptResponse{}
+	mi := &file_account_account_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeletionReceiptResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeletionReceiptResponse) ProtoMessage() {}
+
+func (x *GetDeletionReceiptResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_account_account_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeletionReceiptResponse.ProtoReflect.Descriptor instead.
+func (*GetDeletionReceiptResponse) Descriptor() ([]byte, []int) {
+	return file_account_account_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetDeletionReceiptResponse) GetReceipt() *DeletionReceipt {
+	if x != nil {
+		return x.Receipt
+	}
+	return nil
+}
+
+// Consent records what a user agreed to. Recordings are published unless the
+// user withdraws.
+type Consent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Deprecated: Marked as deprecated in account/account.proto.
+	Analytics bool `protobuf:"varint,1,opt,name=analytics,proto3" json:"analytics,omitempty"` // always false
+	// Deprecated: Marked as deprecated in account/account.proto.
+	EmailNotifications bool  `protobuf:"varint,2,opt,name=email_notifications,json=emailNotifications,proto3" json:"email_notifications,omitempty"` // always false
+	PublicRecordings   bool  `protobuf:"varint,3,opt,name=public_recordings,json=publicRecordings,proto3" json:"public_recordings,omitempty"`
+	UpdatedAt          int64 `protobuf:"varint,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"` // 0 while the defaults apply
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Consent) Reset() {
+	*x = Consent{}
+	mi := &file_account_account_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Consent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Consent) ProtoMessage() {}
+
+func (x *Consent) ProtoReflect() protoreflect.Message {
+	mi := &file_account_account_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Consent.ProtoReflect.Descriptor instead.
+func (*Consent) Descriptor() ([]byte, []int) {
+	return file_account_account_proto_rawDescGZIP(), []int{21}
+}
+
+// Deprecated: Marked as deprecated in account/account.proto.
+func (x *Consent) GetAnalytics() bool {
+	if x != nil {
+		return x.Analytics
+	}
+	return false
+}
+
+// Deprecated: Marked as deprecated in account/account.proto.
+func (x *Consent) GetEmailNotifications() bool {
+	if x != nil {
+		return x.EmailNotifications
+	}
+	return false
+}
+
+func (x *Consent) GetPublicRecordings() bool {
+	if x != nil {
+		return x.PublicRecordings
+	}
+	return false
+}
+
+func (x *Consent) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+type GetConsentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConsentRequest) Reset() {
+	*x = GetConsentRequest{}
+	mi := &file_account_account_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConsentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConsentRequest) ProtoMessage() {}
+
+func (x *GetConsentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_account_account_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConsentRequest.ProtoReflect.Descriptor instead.
+func (*GetConsentRequest) Descriptor() ([]byte, []int) {
+	return file_account_account_proto_rawDescGZIP(), []int{22}
+}
+
+type GetConsentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Consent       *Consent               `protobuf:"bytes,1,opt,name=consent,proto3" json:"consent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConsentResponse) Reset() {
+	*x = GetConsentResponse{}
+	mi := &file_account_account_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConsentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConsentResponse) ProtoMessage() {}
+
+func (x *GetConsentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_account_account_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConsentResponse.ProtoReflect.Descriptor instead.
+func (*GetConsentResponse) Descriptor() ([]byte, []int) {
+	return file_account_account_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetConsentResponse) GetConsent() *Consent {
+	if x != nil {
+		return x.Consent
+	}
+	return nil
+}
+
+type SetConsentRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Deprecated: Marked as deprecated in account/account.proto.
+	Analytics *bool `protobuf:"varint,1,opt,name=analytics,proto3,oneof" json:"analytics,omitempty"` // only false is accepted
+	// Deprecated: Marked as deprecated in account/account.proto.
+	EmailNotifications *bool `protobuf:"varint,2,opt,name=email_notifications,json=emailNotifications,proto3,oneof" json:"email_notifications,omitempty"` // only false is accepted
+	PublicRecordings   *bool `protobuf:"varint,3,opt,name=public_recordings,json=publicRecordings,proto3,oneof" json:"public_recordings,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *SetConsentRequest) Reset() {
+	*x = SetConsentRequest{}
+	mi := &file_account_account_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetConsentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetConsentRequest) ProtoMessage() {}
+
+func (x *SetConsentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_account_account_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetConsentRequest.ProtoReflect.Descriptor instead.
+func (*SetConsentRequest) Descriptor() ([]byte, []int) {
+	return file_account_account_proto_rawDescGZIP(), []int{24}
+}
+
+// Deprecated: Marked as deprecated in account/account.proto.
+func (x *SetConsentRequest) GetAnalytics() bool {
+	if x != nil && x.Analytics != nil {
+		return *x.Analytics
+	}
+	return false
+}
+
+// Deprecated: Marked as deprecated in account/account.proto.
+func (x *SetConsentRequest) GetEmailNotifications() bool {
+	if x != nil && x.EmailNotifications != nil {
+		return *x.EmailNotifications
+	}
+	return false
+}
+
+func (x *SetConsentRequest) GetPublicRecordings() bool {
+	if x != nil && x.PublicRecordings != nil {
+		return *x.PublicRecordings
+	}
+	return false
+}
+
+type SetConsentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Consent       *Consent               `protobuf:"bytes,1,opt,name=consent,proto3" json:"consent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetConsentResponse) Reset() {
+	*x = SetConsentResponse{}
+	mi := &file_account_account_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetConsentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetConsentResponse) ProtoMessage() {}
+
+func (x *SetConsentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_account_account_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetConsentResponse.ProtoReflect.Descriptor instead.
+func (*SetConsentResponse) Descriptor() ([]byte, []int) {
+	return file_account_account_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SetConsentResponse) GetConsent() *Consent {
+	if x != nil {
+		return x.Consent
+	}
+	return nil
+}
+
+var File_account_account_proto protoreflect.FileDescriptor
+
+const file_account_account_proto_rawDesc = "" +
+	"\n" +
+	"\x15account/account.proto\x12\aaccount\x1a\x1bbuf/validate/validate.proto\x1a\x1cgoogle/api/annotations.proto\x1a\x11media/media.proto\"\xb9\x01\n" +
+	"\x06Export\x12\x1b\n" +
+	"\texport_id\x18\x01 \x01(\tR\bexportId\x12*\n" +
+	"\x05state\x18\x02 \x01(\x0e2\x14.account.ExportStateR\x05state\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\x03R\texpiresAt\x12\x12\n" +
+	"\x04size\x18\x05 \x01(\x03R\x04size\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\":\n" +
+	"\x13ExportMyDataRequest\x12#\n" +
+	"\rinclude_media\x18\x01 \x01(\bR\fincludeMedia\"?\n" +
+	"\x14ExportMyDataResponse\x12'\n" +
+	"\x06export\x18\x01 \x01(\v2\x0f.account.ExportR\x06export\";\n" +
+	"\x10GetExportRequest\x12'\n" +
+	"\texport_id\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x01R\bexportId\"<\n" +
+	"\x11GetExportResponse\x12'\n" +
+	"\x06export\x18\x01 \x01(\v2\x0f.account.ExportR\x06export\"@\n" +
+	"\x15DownloadExportRequest\x12'\n" +
+	"\texport_id\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x01R\bexportId\"]\n" +
+	"\x16DownloadExportResponse\x12\x1b\n" +
+	"\tfile_name\x18\x01 \x01(\tR\bfileName\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\"P\n" +
+	"\x05Login\x12\x0e\n" +
+	"\x02at\x18\x01 \x01(\x03R\x02at\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\x03 \x01(\tR\tuserAgent\"\x82\x02\n" +
+	"\aProfile\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\x03R\tcreatedAt\x12&\n" +
+	"\x06logins\x18\x04 \x03(\v2\x0e.account.LoginR\x06logins\x12#\n" +
+	"\rterms_version\x18\x05 \x01(\tR\ftermsVersion\x12*\n" +
+	"\x11terms_accepted_at\x18\x06 \x01(\x03R\x0ftermsAcceptedAt\x12*\n" +
+	"\aconsent\x18\a \x01(\v2\x10.account.ConsentR\aconsent\"{\n" +
+	"\rExportedVideo\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x120\n" +
+	"\bmetadata\x18\x02 \x01(\v2\x14.media.VideoMetadataR\bmetadata\x12\x1d\n" +
+	"\n" +
+	"media_path\x18\x03 \x01(\tR\tmediaPath\"8\n" +
+	"\x06Videos\x12.\n" +
+	"\x06videos\x18\x01 \x03(\v2\x16.account.ExportedVideoR\x06videos\"o\n" +
+	"\bDeletion\x12\x1d\n" +
+	"\n" +
+	"receipt_id\x18\x01 \x01(\tR\treceiptId\x12!\n" +
+	"\frequested_at\x18\x02 \x01(\x03R\vrequestedAt\x12!\n" +
+	"\fscheduled_at\x18\x03 \x01(\x03R\vscheduledAt\"\xaf\x02\n" +
+	"\x0fDeletionReceipt\x12\x1d\n" +
+	"\n" +
+	"receipt_id\x18\x01 \x01(\tR\treceiptId\x12\x18\n" +
+	"\asubject\x18\x02 \x01(\tR\asubject\x12!\n" +
+	"\frequested_at\x18\x03 \x01(\x03R\vrequestedAt\x12\x1b\n" +
+	"\terased_at\x18\x04 \x01(\x03R\berasedAt\x12%\n" +
+	"\x0evideos_deleted\x18\x05 \x01(\x05R\rvideosDeleted\x12#\n" +
+	"\rbytes_deleted\x18\x06 \x01(\x03R\fbytesDeleted\x12\x16\n" +
+	"\x06erased\x18\a \x03(\tR\x06erased\x12\x1c\n" +
+	"\tsignature\x18\b \x01(\fR\tsignature\x12!\n" +
+	"\n" +
+	"public_key\x18\t \x01(\fB\x02\x18\x01R\tpublicKey\"\x18\n" +
+	"\x16RequestDeletionRequest\"H\n" +
+	"\x17RequestDeletionResponse\x12-\n" +
+	"\bdeletion\x18\x01 \x01(\v2\x11.account.DeletionR\bdeletion\"\x14\n" +
+	"\x12GetDeletionRequest\"D\n" +
+	"\x13GetDeletionResponse\x12-\n" +
+	"\bdeletion\x18\x01 \x01(\v2\x11.account.DeletionR\bdeletion\"\x17\n" +
+	"\x15CancelDeletionRequest\"\x18\n" +
+	"\x16CancelDeletionResponse\"F\n" +
+	"\x19GetDeletionReceiptRequest\x12)\n" +
+	"\n" +
+	"receipt_id\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x01R\treceiptId\"P\n" +
+	"\x1aGetDeletionReceiptResponse\x122\n" +
+	"\areceipt\x18\x01 \x01(\v2\x18.account.DeletionReceiptR\areceipt\"\xac\x01\n" +
+	"\aConsent\x12 \n" +
+	"\tanalytics\x18\x01 \x01(\bB\x02\x18\x01R\tanalytics\x123\n" +
+	"\x13email_notifications\x18\x02 \x01(\bB\x02\x18\x01R\x12emailNotifications\x12+\n" +
+	"\x11public_recordings\x18\x03 \x01(\bR\x10publicRecordings\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\x03R\tupdatedAt\"\x13\n" +
+	"\x11GetConsentRequest\"@\n" +
+	"\x12GetConsentResponse\x12*\n" +
+	"\aconsent\x18\x01 \x01(\v2\x10.account.ConsentR\aconsent\"\xe2\x01\n" +
+	"\x11SetConsentRequest\x12%\n" +
+	"\tanalytics\x18\x01 \x01(\bB\x02\x18\x01H\x00R\tanalytics\x88\x01\x01\x128\n" +
+	"\x13email_notifications\x18\x02 \x01(\bB\x02\x18\x01H\x01R\x12emailNotifications\x88\x01\x01\x120\n" +
+	"\x11public_recordings\x18\x03 \x01(\bH\x02R\x10publicRecordings\x88\x01\x01B\f\n" +
+	"\n" +
+	"_analyticsB\x16\n" +
+	"\x14_email_notificationsB\x14\n" +
+	"\x12_public_recordings\"@\n" +
+	"\x12SetConsentResponse\x12*\n" +
+	"\aconsent\x18\x01 \x01(\v2\x10.account.ConsentR\aconsent*\x90\x01\n" +
+	"\vExportState\x12\x1c\n" +
+	"\x18EXPORT_STATE_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14EXPORT_STATE_PENDING\x10\x01\x12\x18\n" +
+	"\x14EXPORT_STATE_RUNNING\x10\x02\x12\x16\n" +
+	"\x12EXPORT_STATE_READY\x10\x03\x12\x17\n" +
+	"\x13EXPORT_STATE_FAILED\x10\x04*\xa8\x01\n" +
+	"\x0eConsentPurpose\x12\x1f\n" +
+	"\x1bCONSENT_PURPOSE_UNSPECIFIED\x10\x00\x12!\n" +
+	"\x19CONSENT_PURPOSE_ANALYTICS\x10\x01\x1a\x02\b\x01\x12+\n" +
+	"#CONSENT_PURPOSE_EMAIL_NOTIFICATIONS\x10\x02\x1a\x02\b\x01\x12%\n" +
+	"!CONSENT_PURPOSE_PUBLIC_RECORDINGS\x10\x032\xc3\a\n" +
+	"\x0eAccountService\x12f\n" +
+	"\fExportMyData\x12\x1c.account.ExportMyDataRequest\x1a\x1d.account.ExportMyDataResponse\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/v1/me/exports\x12f\n" +
+	"\tGetExport\x12\x19.account.GetExportRequest\x1a\x1a.account.GetExportResponse\"\"\x82\xd3\xe4\x93\x02\x1c\x12\x1a/v1/me/exports/{export_id}\x12S\n" +
+	"\x0eDownloadExport\x12\x1e.account.DownloadExportRequest\x1a\x1f.account.DownloadExportResponse0\x01\x12p\n" +
+	"\x0fRequestDeletion\x12\x1f.account.RequestDeletionRequest\x1a .account.RequestDeletionResponse\"\x1a\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/v1/me/deletion\x12a\n" +
+	"\vGetDeletion\x12\x1b.account.GetDeletionRequest\x1a\x1c.account.GetDeletionResponse\"\x17\x82\xd3\xe4\x93\x02\x11\x12\x0f/v1/me/deletion\x12j\n" +
+	"\x0eCancelDeletion\x12\x1e.account.CancelDeletionRequest\x1a\x1f.account.CancelDeletionResponse\"\x17\x82\xd3\xe4\x93\x02\x11*\x0f/v1/me/deletion\x12]\n" +
+	"\n" +
+	"GetConsent\x12\x1a.account.GetConsentRequest\x1a\x1b.account.GetConsentResponse\"\x16\x82\xd3\xe4\x93\x02\x10\x12\x0e/v1/me/consent\x12`\n" +
+	"\n" +
+	"SetConsent\x12\x1a.account.SetConsentRequest\x1a\x1b.account.SetConsentResponse\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*\x1a\x0e/v1/me/consent\x12\x89\x01\n" +
+	"\x12GetDeletionReceipt\x12\".account.GetDeletionReceiptRequest\x1a#.account.GetDeletionReceiptResponse\"*\x82\xd3\xe4\x93\x02$\x12\"/v1/deletion-receipts/{receipt_id}B\"Z coscup2025/proto/account;accountb\x06proto3"
+
+var (
+	file_account_account_proto_rawDescOnce sync.Once
+	file_account_account_proto_rawDescData []byte
+)
+
+func file_account_account_proto_rawDescGZIP() []byte {
+	file_account_account_proto_rawDescOnce.Do(func() {
+		file_account_account_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_account_account_proto_rawDesc), len(file_account_account_proto_rawDesc)))
+	})
+	return file_account_account_proto_rawDescData
+}
+
+var file_account_account_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_account_account_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
+var file_account_account_proto_goTypes = []any{
+	(ExportState)(0),                   // 0: account.ExportState
+	(ConsentPurpose)(0),                // 1: account.ConsentPurpose
+	(*Export)(nil),                     // 2: account.Export
+	(*ExportMyDataRequest)(nil),        // 3: account.ExportMyDataRequest
+	(*ExportMyDataResponse)(nil),       // 4: account.ExportMyDataResponse
+	(*GetExportRequest)(nil),           // 5: account.GetExportRequest
+	(*GetExportResponse)(nil),          // 6: account.GetExportResponse
+	(*DownloadExportRequest)(nil),      // 7: account.DownloadExportRequest
+	(*DownloadExportResponse)(nil),     // 8: account.DownloadExportResponse
+	(*Login)(nil),                      // 9: account.Login
+	(*Profile)(nil),                    // 10: account.Profile
+	(*ExportedVideo)(nil),              // 11: account.ExportedVideo
+	(*Videos)(nil),                     // 12: account.Videos
+	(*Deletion)(nil),                   // 13: account.Deletion
+	(*DeletionReceipt)(nil),            // 14: account.DeletionReceipt
+	(*RequestDeletionRequest)(nil),     // 15: account.RequestDeletionRequest
+	(*RequestDeletionResponse)(nil),    // 16: account.RequestDeletionResponse
+	(*GetDeletionRequest)(nil),         // 17: account.GetDeletionRequest
+	(*GetDeletionResponse)(nil),        // 18: account.GetDeletionResponse
+	(*CancelDeletionRequest)(nil),      // 19: account.CancelDeletionRequest
+	(*CancelDeletionResponse)(nil),     // 20: account.CancelDeletionResponse
+	(*GetDeletionReceiptRequest)(nil),  // 21: account.GetDeletionReceiptRequest
+	(*GetDeletionReceiptResponse)(nil), // 22: account.GetDeletionReceiptResponse
+	(*Consent)(nil),                    // 23: account.Consent
+	(*GetConsentRequest)(nil),          // 24: account.GetConsentRequest
+	(*GetConsentResponse)(nil),         // 25: account.GetConsentResponse
+	(*SetConsentRequest)(nil),          // 26: account.SetConsentRequest
+	(*SetConsentResponse)(nil),         // 27: account.SetConsentResponse
+	(*media.VideoMetadata)(nil),        // 28: media.VideoMetadata
+}
+var file_account_account_proto_depIdxs = []int32{
+	0,  // 0: account.Export.state:type_name -> account.ExportState
+	2,  // 1: account.ExportMyDataResponse.export:type_name -> account.Export
+	2,  // 2: account.GetExportResponse.export:type_name -> account.Export
+	9,  // 3: account.Profile.logins:type_name -> account.Login
+	23, // 4: account.Profile.consent:type_name -> account.Consent
+	28, // 5: account.ExportedVideo.metadata:type_name -> media.VideoMetadata
+	11, // 6: account.Videos.videos:type_name -> account.ExportedVideo
+	13, // 7: account.RequestDeletionResponse.deletion:type_name -> account.Deletion
+	13, // 8: account.GetDeletionResponse.deletion:type_name -> account.Deletion
+	14, // 9: account.GetDeletionReceiptResponse.receipt:type_name -> account.DeletionReceipt
+	23, // 10: account.GetConsentResponse.consent:type_name -> account.Consent
+	23, // 11: account.SetConsentResponse.consent:type_name -> account.Consent
+	3,  // 12: account.AccountService.ExportMyData:input_type -> account.ExportMyDataRequest
+	5,  // 13: account.AccountService.GetExport:input_type -> account.GetExportRequest
+	7,  // 14: account.AccountService.DownloadExport:input_type -> account.DownloadExportRequest
+	15, // 15: account.AccountService.RequestDeletion:input_type -> account.RequestDeletionRequest
+	17, // 16: account.AccountService.GetDeletion:input_type -> account.GetDeletionRequest
+	19, // 17: account.AccountService.CancelDeletion:input_type -> account.CancelDeletionRequest
+	24, // 18: account.AccountService.GetConsent:input_type -> account.GetConsentRequest
+	26, // 19: account.AccountService.SetConsent:input_type -> account.SetConsentRequest
+	21, // 20: account.AccountService.GetDeletionReceipt:input_type -> account.GetDeletionReceiptRequest
+	4,  // 21: account.AccountService.ExportMyData:output_type -> account.ExportMyDataResponse
+	6,  // 22: account.AccountService.GetExport:output_type -> account.GetExportResponse
+	8,  // 23: account.AccountService.DownloadExport:output_type -> account.DownloadExportResponse
+	16, // 24: account.AccountService.RequestDeletion:output_type -> account.RequestDeletionResponse
+	18, // 25: account.AccountService.GetDeletion:output_type -> account.GetDeletionResponse
+	20, // 26: account.AccountService.CancelDeletion:output_type -> account.CancelDeletionResponse
+	25, // 27: account.AccountService.GetConsent:output_type -> account.GetConsentResponse
+	27, // 28: account.AccountService.SetConsent:output_type -> account.SetConsentResponse
+	22, // 29: account.AccountService.GetDeletionReceipt:output_type -> account.GetDeletionReceiptResponse
+	21, // [21:30] is the sub-list for method output_type
+	12, // [12:21] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
+}
+
+func init() { file_account_account_proto_init() }
+func file_account_account_proto_init() {
+	if File_account_account_proto != nil {
+		return
+	}
+	file_account_account_proto_msgTypes[24].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_account_account_proto_rawDesc), len(file_account_account_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   26,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_account_account_proto_goTypes,
+		DependencyIndexes: file_account_account_proto_depIdxs,
+		EnumInfos:         file_account_account_proto_enumTypes,
+		MessageInfos:      file_account_account_proto_msgTypes,
+	}.Build()
+	File_account_account_proto = out.File
+	file_account_account_proto_goTypes = nil
+	file_account_account_proto_depIdxs = nil
+}
@@ -0,0 +1,1857 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: admin/admin.proto
+
+package admin
+
+import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	moderation "coscup2025/proto/moderation"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ImportFormat is the format of the users to import.
+type ImportFormat int32
+
+const (
+	ImportFormat_IMPORT_FORMAT_UNSPECIFIED ImportFormat = 0
+	// A header row naming the columns, of which username is required and
+	// credential optional; other columns, such as names or emails, are ignored
+	ImportFormat_IMPORT_FORMAT_CSV ImportFormat = 1
+	// An array of objects with a username and an optional credential
+	ImportFormat_IMPORT_FORMAT_JSON ImportFormat = 2
+)
+
+// Enum value maps for ImportFormat.
+var (
+	ImportFormat_name = map[int32]string{
+		0: "IMPORT_FORMAT_UNSPECIFIED",
+		1: "IMPORT_FORMAT_CSV",
+		2: "IMPORT_FORMAT_JSON",
+	}
+	ImportFormat_value = map[string]int32{
+		"IMPORT_FORMAT_UNSPECIFIED": 0,
+		"IMPORT_FORMAT_CSV":         1,
+		"IMPORT_FORMAT_JSON":        2,
+	}
+)
+
+func (x ImportFormat) Enum() *ImportFormat {
+	p := new(ImportFormat)
+	*p = x
+	return p
+}
+
+func (x ImportFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ImportFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_admin_admin_proto_enumTypes[0].Descriptor()
+}
+
+func (ImportFormat) Type() protoreflect.EnumType {
+	return &file_admin_admin_proto_enumTypes[0]
+}
+
+func (x ImportFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ImportFormat.Descriptor instead.
+func (ImportFormat) EnumDescriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{0}
+}
+
+// ImportCredential is how an imported user signs in the first time. In a
+// payload it is written "password" or "invitation".
+type ImportCredential int32
+
+const (
+	ImportCredential_IMPORT_CREDENTIAL_UNSPECIFIED ImportCredential = 0 // a temporary password
+	ImportCredential_IMPORT_CREDENTIAL_PASSWORD    ImportCredential = 1 // a generated temporary password
+	ImportCredential_IMPORT_CREDENTIAL_INVITATION  ImportCredential = 2 // a single-use link to choose a password
+)
+
+// Enum value maps for ImportCredential.
+var (
+	ImportCredential_name = map[int32]string{
+		0: "IMPORT_CREDENTIAL_UNSPECIFIED",
+		1: "IMPORT_CREDENTIAL_PASSWORD",
+		2: "IMPORT_CREDENTIAL_INVITATION",
+	}
+	ImportCredential_value = map[string]int32{
+		"IMPORT_CREDENTIAL_UNSPECIFIED": 0,
+		"IMPORT_CREDENTIAL_PASSWORD":    1,
+		"IMPORT_CREDENTIAL_INVITATION":  2,
+	}
+)
+
+func (x ImportCredential) Enum() *ImportCredential {
+	p := new(ImportCredential)
+	*p = x
+	return p
+}
+
+func (x ImportCredential) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ImportCredential) Descriptor() protoreflect.EnumDescriptor {
+	return file_admin_admin_proto_enumTypes[1].Descriptor()
+}
+
+func (ImportCredential) Type() protoreflect.EnumType {
+	return &file_admin_admin_proto_enumTypes[1]
+}
+
+func (x ImportCredential) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ImportCredential.Descriptor instead.
+func (ImportCredential) EnumDescriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{1}
+}
+
+type ImportStatus int32
+
+const (
+	ImportStatus_IMPORT_STATUS_UNSPECIFIED ImportStatus = 0
+	ImportStatus_IMPORT_STATUS_CREATED     ImportStatus = 1 // or would be, in a dry run
+	ImportStatus_IMPORT_STATUS_EXISTS      ImportStatus = 2 // the username is taken, the row is skipped
+	ImportStatus_IMPORT_STATUS_INVALID     ImportStatus = 3 // see error
+)
+
+// Enum value maps for ImportStatus.
+var (
+	ImportStatus_name = map[int32]string{
+		0: "IMPORT_STATUS_UNSPECIFIED",
+		1: "IMPORT_STATUS_CREATED",
+		2: "IMPORT_STATUS_EXISTS",
+		3: "IMPORT_STATUS_INVALID",
+	}
+	ImportStatus_value = map[string]int32{
+		"IMPORT_STATUS_UNSPECIFIED": 0,
+		"IMPORT_STATUS_CREATED":     1,
+		"IMPORT_STATUS_EXISTS":      2,
+		"IMPORT_STATUS_INVALID":     3,
+	}
+)
+
+func (x ImportStatus) Enum() *ImportStatus {
+	p := new(ImportStatus)
+	*p = x
+	return p
+}
+
+func (x ImportStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ImportStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_admin_admin_proto_enumTypes[2].Descriptor()
+}
+
+func (ImportStatus) Type() protoreflect.EnumType {
+	return &file_admin_admin_proto_enumTypes[2]
+}
+
+func (x ImportStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ImportStatus.Descriptor instead.
+func (ImportStatus) EnumDescriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{2}
+}
+
+type ListCasesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	State         moderation.CaseState   `protobuf:"varint,1,opt,name=state,proto3,enum=moderation.CaseState" json:"state,omitempty"` // optional, only cases in this state
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`     // defaults to 50, at most 500
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`   // next_page_token of the previous page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCasesRequest) Reset() {
+	*x = ListCasesRequest{}
+	mi := &file_admin_admin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCasesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCasesRequest) ProtoMessage() {}
+
+func (x *ListCasesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCasesRequest.ProtoReflect.Descriptor instead.
+func (*ListCasesRequest) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ListCasesRequest) GetState() moderation.CaseState {
+	if x != nil {
+		return x.State
+	}
+	return moderation.CaseState(0)
+}
+
+func (x *ListCasesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListCasesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListCasesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cases         []*moderation.Case     `protobuf:"bytes,1,rep,name=cases,proto3" json:"cases,omitempty"`                                        // oldest first
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // empty on the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCasesResponse) Reset() {
+	*x = ListCasesResponse{}
+	mi := &file_admin_admin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCasesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCasesResponse) ProtoMessage() {}
+
+func (x *ListCasesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCasesResponse.ProtoReflect.Descriptor instead.
+func (*ListCasesResponse) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListCasesResponse) GetCases() []*moderation.Case {
+	if x != nil {
+		return x.Cases
+	}
+	return nil
+}
+
+func (x *ListCasesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type TransitionCaseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CaseId        string                 `protobuf:"bytes,1,opt,name=case_id,json=caseId,proto3" json:"case_id,omitempty"`
+	State         moderation.CaseState   `protobuf:"varint,2,opt,name=state,proto3,enum=moderation.CaseState" json:"state,omitempty"`
+	Note          string                 `protobuf:"bytes,3,opt,name=note,proto3" json:"note,omitempty"` // optional, shown to the uploader
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransitionCaseRequest) Reset() {
+	*x = TransitionCaseRequest{}
+	mi := &file_admin_admin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransitionCaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransitionCaseRequest) ProtoMessage() {}
+
+func (x *TransitionCaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransitionCaseRequest.ProtoReflect.Descriptor instead.
+func (*TransitionCaseRequest) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TransitionCaseRequest) GetCaseId() string {
+	if x != nil {
+		return x.CaseId
+	}
+	return ""
+}
+
+func (x *TransitionCaseRequest) GetState() moderation.CaseState {
+	if x != nil {
+		return x.State
+	}
+	return moderation.CaseState(0)
+}
+
+func (x *TransitionCaseRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type TransitionCaseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Case          *moderation.Case       `protobuf:"bytes,1,opt,name=case,proto3" json:"case,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransitionCaseResponse) Reset() {
+	*x = TransitionCaseResponse{}
+	mi := &file_admin_admin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransitionCaseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransitionCaseResponse) ProtoMessage() {}
+
+func (x *TransitionCaseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransitionCaseResponse.ProtoReflect.Descriptor instead.
+func (*TransitionCaseResponse) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TransitionCaseResponse) GetCase() *moderation.Case {
+	if x != nil {
+		return x.Case
+	}
+	return nil
+}
+
+// ScrubReport is the outcome of a scrub of the video storage.
+type ScrubReport struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartedAt     int64                  `protobuf:"varint,1,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt    int64                  `protobuf:"varint,2,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	CheckedBlobs  int64                  `protobuf:"varint,3,opt,name=checked_blobs,json=checkedBlobs,proto3" json:"checked_blobs,omitempty"`
+	CheckedBytes  int64                  `protobuf:"varint,4,opt,name=checked_bytes,json=checkedBytes,proto3" json:"checked_bytes,omitempty"`
+	CorruptBlobs  []string               `protobuf:"bytes,5,rep,name=corrupt_blobs,json=corruptBlobs,proto3" json:"corrupt_blobs,omitempty"`     // SHA-256 of blobs moved to quarantine
+	RepairedBlobs int64                  `protobuf:"varint,6,opt,name=repaired_blobs,json=repairedBlobs,proto3" json:"repaired_blobs,omitempty"` // corrupt blobs stored again from memory
+	OrphanedBlobs int64                  `protobuf:"varint,7,opt,name=orphaned_blobs,json=orphanedBlobs,proto3" json:"orphaned_blobs,omitempty"` // unreferenced blobs removed
+	TempFiles     int64                  `protobuf:"varint,8,opt,name=temp_files,json=tempFiles,proto3" json:"temp_files,omitempty"`             // abandoned temporary files removed
+	EmptyDirs     int64                  `protobuf:"varint,9,opt,name=empty_dirs,json=emptyDirs,proto3" json:"empty_dirs,omitempty"`             // empty directories removed
+	Error         string                 `protobuf:"bytes,10,opt,name=error,proto3" json:"error,omitempty"`                                      // why the scrub stopped early, if it did
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScrubReport) Reset() {
+	*x = ScrubReport{}
+	mi := &file_admin_admin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScrubReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScrubReport) ProtoMessage() {}
+
+func (x *ScrubReport) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScrubReport.ProtoReflect.Descriptor instead.
+func (*ScrubReport) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ScrubReport) GetStartedAt() int64 {
+	if x != nil {
+		return x.StartedAt
+	}
+	return 0
+}
+
+func (x *ScrubReport) GetFinishedAt() int64 {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return 0
+}
+
+func (x *ScrubReport) GetCheckedBlobs() int64 {
+	if x != nil {
+		return x.CheckedBlobs
+	}
+	return 0
+}
+
+func (x *ScrubReport) GetCheckedBytes() int64 {
+	if x != nil {
+		return x.CheckedBytes
+	}
+	return 0
+}
+
+func (x *ScrubReport) GetCorruptBlobs() []string {
+	if x != nil {
+		return x.CorruptBlobs
+	}
+	return nil
+}
+
+func (x *ScrubReport) GetRepairedBlobs() int64 {
+	if x != nil {
+		return x.RepairedBlobs
+	}
+	return 0
+}
+
+func (x *ScrubReport) GetOrphanedBlobs() int64 {
+	if x != nil {
+		return x.OrphanedBlobs
+	}
+	return 0
+}
+
+func (x *ScrubReport) GetTempFiles() int64 {
+	if x != nil {
+		return x.TempFiles
+	}
+	return 0
+}
+
+func (x *ScrubReport) GetEmptyDirs() int64 {
+	if x != nil {
+		return x.EmptyDirs
+	}
+	return 0
+}
+
+func (x *ScrubReport) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type StartScrubRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartScrubRequest) Reset() {
+	*x = StartScrubRequest{}
+	mi := &file_admin_admin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartScrubRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartScrubRequest) ProtoMessage() {}
+
+func (x *StartScrubRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartScrubRequest.ProtoReflect.Descriptor instead.
+func (*StartScrubRequest) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{5}
+}
+
+type StartScrubResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Started       bool                   `protobuf:"varint,1,opt,name=started,proto3" json:"started,omitempty"` // false if a scrub was already running
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartScrubResponse) Reset() {
+	*x = StartScrubResponse{}
+	mi := &file_admin_admin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartScrubResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartScrubResponse) ProtoMessage() {}
+
+func (x *StartScrubResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartScrubResponse.ProtoReflect.Descriptor instead.
+func (*StartScrubResponse) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *StartScrubResponse) GetStarted() bool {
+	if x != nil {
+		return x.Started
+	}
+	return false
+}
+
+type GetScrubStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetScrubStatusRequest) Reset() {
+	*x = GetScrubStatusRequest{}
+	mi := &file_admin_admin_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetScrubStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetScrubStatusRequest) ProtoMessage() {}
+
+func (x *GetScrubStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetScrubStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetScrubStatusRequest) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{7}
+}
+
+type GetScrubStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Running       bool                   `protobuf:"varint,1,opt,name=running,proto3" json:"running,omitempty"`
+	Last          *ScrubReport           `protobuf:"bytes,2,opt,name=last,proto3" json:"last,omitempty"` // unset until a scrub finished
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetScrubStatusResponse) Reset() {
+	*x = GetScrubStatusResponse{}
+	mi := &file_admin_admin_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetScrubStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetScrubStatusResponse) ProtoMessage() {}
+
+func (x *GetScrubStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetScrubStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetScrubStatusResponse) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetScrubStatusResponse) GetRunning() bool {
+	if x != nil {
+		return x.Running
+	}
+	return false
+}
+
+func (x *GetScrubStatusResponse) GetLast() *ScrubReport {
+	if x != nil {
+		return x.Last
+	}
+	return nil
+}
+
+// RetentionRule deletes the videos it applies to once they are older than
+// max_age_seconds. A rule applies to the videos of a tenant, of an uploader,
+// of both, or to all videos when neither is set. Of the rules that match a
+// video only the most specific one counts, so that a rule for an uploader
+// can keep their videos longer than the rule of their tenant; among equally
+// specific rules the one keeping videos longest counts.
+type RetentionRule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RuleId        string                 `protobuf:"bytes,1,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`                         // assigned by the server, empty to create a rule
+	Tenant        string                 `protobuf:"bytes,2,opt,name=tenant,proto3" json:"tenant,omitempty"`                                       // optional
+	UploaderId    string                 `protobuf:"bytes,3,opt,name=uploader_id,json=uploaderId,proto3" json:"uploader_id,omitempty"`             // optional
+	MaxAgeSeconds int64                  `protobuf:"varint,4,opt,name=max_age_seconds,json=maxAgeSeconds,proto3" json:"max_age_seconds,omitempty"` // since the upload; 0 keeps the videos forever
+	Note          string                 `protobuf:"bytes,5,opt,name=note,proto3" json:"note,omitempty"`                                           // optional, e.g. why the rule exists
+	CreatedAt     int64                  `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`               // Unix seconds, set by the server
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetentionRule) Reset() {
+	*x = RetentionRule{}
+	mi := &file_admin_admin_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetentionRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetentionRule) ProtoMessage() {}
+
+func (x *RetentionRule) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetentionRule.ProtoReflect.Descriptor instead.
+func (*RetentionRule) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RetentionRule) GetRuleId() string {
+	if x != nil {
+		return x.RuleId
+	}
+	return ""
+}
+
+func (x *RetentionRule) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *RetentionRule) GetUploaderId() string {
+	if x != nil {
+		return x.UploaderId
+	}
+	return ""
+}
+
+func (x *RetentionRule) GetMaxAgeSeconds() int64 {
+	if x != nil {
+		return x.MaxAgeSeconds
+	}
+	return 0
+}
+
+func (x *RetentionRule) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *RetentionRule) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type SetRetentionRuleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rule          *RetentionRule         `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetRetentionRuleRequest) Reset() {
+	*x = SetRetentionRuleRequest{}
+	mi := &file_admin_admin_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetRetentionRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRetentionRuleRequest) ProtoMessage() {}
+
+func (x *SetRetentionRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRetentionRuleRequest.ProtoReflect.Descriptor instead.
+func (*SetRetentionRuleRequest) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SetRetentionRuleRequest) GetRule() *RetentionRule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+type SetRetentionRuleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rule          *RetentionRule         `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetRetentionRuleResponse) Reset() {
+	*x = SetRetentionRuleResponse{}
+	mi := &file_admin_admin_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetRetentionRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRetentionRuleResponse) ProtoMessage() {}
+
+func (x *SetRetentionRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRetentionRuleResponse.ProtoReflect.Descriptor instead.
+func (*SetRetentionRuleResponse) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SetRetentionRuleResponse) GetRule() *RetentionRule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+type ListRetentionRulesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRetentionRulesRequest) Reset() {
+	*x = ListRetentionRulesRequest{}
+	mi := &file_admin_admin_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRetentionRulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRetentionRulesRequest) ProtoMessage() {}
+
+func (x *ListRetentionRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRetentionRulesRequest.ProtoReflect.Descriptor instead.
+func (*ListRetentionRulesRequest) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{12}
+}
+
+type ListRetentionRulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rules         []*RetentionRule       `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRetentionRulesResponse) Reset() {
+	*x = ListRetentionRulesResponse{}
+	mi := &file_admin_admin_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRetentionRulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRetentionRulesResponse) ProtoMessage() {}
+
+func (x *ListRetentionRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRetentionRulesResponse.ProtoReflect.Descriptor instead.
+func (*ListRetentionRulesResponse) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListRetentionRulesResponse) GetRules() []*RetentionRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+type DeleteRetentionRuleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RuleId        string                 `protobuf:"bytes,1,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRetentionRuleRequest) Reset() {
+	*x = DeleteRetentionRuleRequest{}
+	mi := &file_admin_admin_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRetentionRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRetentionRuleRequest) ProtoMessage() {}
+
+func (x *DeleteRetentionRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRetentionRuleRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRetentionRuleRequest) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DeleteRetentionRuleRequest) GetRuleId() string {
+	if x != nil {
+		return x.RuleId
+	}
+	return ""
+}
+
+type DeleteRetentionRuleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRetentionRuleResponse) Reset() {
+	*x = DeleteRetentionRuleResponse{}
+	mi := &file_admin_admin_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRetentionRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRetentionRuleResponse) ProtoMessage() {}
+
+func (x *DeleteRetentionRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRetentionRuleResponse.ProtoReflect.Descriptor instead.
+func (*DeleteRetentionRuleResponse) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{15}
+}
+
+type PreviewRetentionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreviewRetentionRequest) Reset() {
+	*x = PreviewRetentionRequest{}
+	mi := &file_admin_admin_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewRetentionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewRetentionRequest) ProtoMessage() {}
+
+func (x *PreviewRetentionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewRetentionRequest.ProtoReflect.Descriptor instead.
+func (*PreviewRetentionRequest) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{16}
+}
+
+// ExpiredVideo is a video that a retention rule deletes.
+type ExpiredVideo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	Tenant        string                 `protobuf:"bytes,2,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	UploaderId    string                 `protobuf:"bytes,3,opt,name=uploader_id,json=uploaderId,proto3" json:"uploader_id,omitempty"`
+	UploadedAt    int64                  `protobuf:"varint,4,opt,name=uploaded_at,json=uploadedAt,proto3" json:"uploaded_at,omitempty"` // Unix seconds
+	SizeBytes     int64                  `protobuf:"varint,5,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	RuleId        string                 `protobuf:"bytes,6,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"` // the rule that deletes it
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExpiredVideo) Reset() {
+	*x = ExpiredVideo{}
+	mi := &file_admin_admin_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpiredVideo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpiredVideo) ProtoMessage() {}
+
+func (x *ExpiredVideo) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpiredVideo.ProtoReflect.Descriptor instead.
+func (*ExpiredVideo) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ExpiredVideo) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *ExpiredVideo) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *ExpiredVideo) GetUploaderId() string {
+	if x != nil {
+		return x.UploaderId
+	}
+	return ""
+}
+
+func (x *ExpiredVideo) GetUploadedAt() int64 {
+	if x != nil {
+		return x.UploadedAt
+	}
+	return 0
+}
+
+func (x *ExpiredVideo) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *ExpiredVideo) GetRuleId() string {
+	if x != nil {
+		return x.RuleId
+	}
+	return ""
+}
+
+type PreviewRetentionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EvaluatedAt   int64                  `protobuf:"varint,1,opt,name=evaluated_at,json=evaluatedAt,proto3" json:"evaluated_at,omitempty"` // Unix seconds, when the next enforcement runs or now when none is scheduled
+	Videos        []*ExpiredVideo        `protobuf:"bytes,2,rep,name=videos,proto3" json:"videos,omitempty"`                               // oldest first
+	TotalBytes    int64                  `protobuf:"varint,3,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreviewRetentionResponse) Reset() {
+	*x = PreviewRetentionResponse{}
+	mi := &file_admin_admin_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewRetentionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewRetentionResponse) ProtoMessage() {}
+
+func (x *PreviewRetentionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewRetentionResponse.ProtoReflect.Descriptor instead.
+func (*PreviewRetentionResponse) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *PreviewRetentionResponse) GetEvaluatedAt() int64 {
+	if x != nil {
+		return x.EvaluatedAt
+	}
+	return 0
+}
+
+func (x *PreviewRetentionResponse) GetVideos() []*ExpiredVideo {
+	if x != nil {
+		return x.Videos
+	}
+	return nil
+}
+
+func (x *PreviewRetentionResponse) GetTotalBytes() int64 {
+	if x != nil {
+		return x.TotalBytes
+	}
+	return 0
+}
+
+type ImportUsersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Format        ImportFormat           `protobuf:"varint,1,opt,name=format,proto3,enum=admin.ImportFormat" json:"format,omitempty"`
+	Payload       []byte                 `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`                                    // at most 1000 users
+	Credential    ImportCredential       `protobuf:"varint,3,opt,name=credential,proto3,enum=admin.ImportCredential" json:"credential,omitempty"` // for rows that do not name one
+	DryRun        bool                   `protobuf:"varint,4,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`                       // check the rows without creating any account
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportUsersRequest) Reset() {
+	*x = ImportUsersRequest{}
+	mi := &file_admin_admin_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportUsersRequest) ProtoMessage() {}
+
+func (x *ImportUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportUsersRequest.ProtoReflect.Descriptor instead.
+func (*ImportUsersRequest) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ImportUsersRequest) GetFormat() ImportFormat {
+	if x != nil {
+		return x.Format
+	}
+	return ImportFormat_IMPORT_FORMAT_UNSPECIFIED
+}
+
+func (x *ImportUsersRequest) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *ImportUsersRequest) GetCredential() ImportCredential {
+	if x != nil {
+		return x.Credential
+	}
+	return ImportCredential_IMPORT_CREDENTIAL_UNSPECIFIED
+}
+
+func (x *ImportUsersRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+// ImportedUser is the outcome of one row of an import.
+type ImportedUser struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Row                 int32                  `protobuf:"varint,1,opt,name=row,proto3" json:"row,omitempty"` // 1 for the first user
+	Username            string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Status              ImportStatus           `protobuf:"varint,3,opt,name=status,proto3,enum=admin.ImportStatus" json:"status,omitempty"`
+	Error               string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`                                                           // why the row is invalid
+	UserId              string                 `protobuf:"bytes,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                                           // of a created account
+	TemporaryPassword   string                 `protobuf:"bytes,6,opt,name=temporary_password,json=temporaryPassword,proto3" json:"temporary_password,omitempty"`          // for CREDENTIAL_PASSWORD, shown only in this response
+	InvitationUrl       string                 `protobuf:"bytes,7,opt,name=invitation_url,json=invitationUrl,proto3" json:"invitation_url,omitempty"`                      // for CREDENTIAL_INVITATION, shown only in this response
+	InvitationExpiresAt int64                  `protobuf:"varint,8,opt,name=invitation_expires_at,json=invitationExpiresAt,proto3" json:"invitation_expires_at,omitempty"` // Unix seconds
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ImportedUser) Reset() {
+	*x = ImportedUser{}
+	mi := &file_admin_admin_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportedUser) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportedUser) ProtoMessage() {}
+
+func (x *ImportedUser) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportedUser.ProtoReflect.Descriptor instead.
+func (*ImportedUser) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ImportedUser) GetRow() int32 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *ImportedUser) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *ImportedUser) GetStatus() ImportStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ImportStatus_IMPORT_STATUS_UNSPECIFIED
+}
+
+func (x *ImportedUser) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ImportedUser) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ImportedUser) GetTemporaryPassword() string {
+	if x != nil {
+		return x.TemporaryPassword
+	}
+	return ""
+}
+
+func (x *ImportedUser) GetInvitationUrl() string {
+	if x != nil {
+		return x.InvitationUrl
+	}
+	return ""
+}
+
+func (x *ImportedUser) GetInvitationExpiresAt() int64 {
+	if x != nil {
+		return x.InvitationExpiresAt
+	}
+	return 0
+}
+
+type ImportUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*ImportedUser        `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"` // in the order of the rows
+	Created       int32                  `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
+	Skipped       int32                  `protobuf:"varint,3,opt,name=skipped,proto3" json:"skipped,omitempty"` // existing and invalid rows
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportUsersResponse) Reset() {
+	*x = ImportUsersResponse{}
+	mi := &file_admin_admin_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportUsersResponse) ProtoMessage() {}
+
+func (x *ImportUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportUsersResponse.ProtoReflect.Descriptor instead.
+func (*ImportUsersResponse) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ImportUsersResponse) GetUsers() []*ImportedUser {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *ImportUsersResponse) GetCreated() int32 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *ImportUsersResponse) GetSkipped() int32 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+type RevokeUserSessionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeUserSessionsRequest) Reset() {
+	*x = RevokeUserSessionsRequest{}
+	mi := &file_admin_admin_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeUserSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeUserSessionsRequest) ProtoMessage() {}
+
+func (x *RevokeUserSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeUserSessionsRequest.ProtoReflect.Descriptor instead.
+func (*RevokeUserSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *RevokeUserSessionsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type RevokeUserSessionsResponse struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	RevokedRefreshTokens int32                  `protobuf:"varint,1,opt,name=revoked_refresh_tokens,json=revokedRefreshTokens,proto3" json:"revoked_refresh_tokens,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *RevokeUserSessionsResponse) Reset() {
+	*x = RevokeUserSessionsResponse{}
+	mi := &file_admin_admin_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeUserSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeUserSessionsResponse) ProtoMessage() {}
+
+func (x *RevokeUserSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeUserSessionsResponse.ProtoReflect.Descriptor instead.
+func (*RevokeUserSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *RevokeUserSessionsResponse) GetRevokedRefreshTokens() int32 {
+	if x != nil {
+		return x.RevokedRefreshTokens
+	}
+	return 0
+}
+
+type RevokeAllSessionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeAllSessionsRequest) Reset() {
+	*x = RevokeAllSessionsRequest{}
+	mi := &file_admin_admin_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAllSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAllSessionsRequest) ProtoMessage() {}
+
+func (x *RevokeAllSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAllSessionsRequest.ProtoReflect.Descriptor instead.
+func (*RevokeAllSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{24}
+}
+
+type RevokeAllSessionsResponse struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	RevokedRefreshTokens int32                  `protobuf:"varint,1,opt,name=revoked_refresh_tokens,json=revokedRefreshTokens,proto3" json:"revoked_refresh_tokens,omitempty"`
+	Epoch                int64                  `protobuf:"varint,2,opt,name=epoch,proto3" json:"epoch,omitempty"` // Unix seconds, tokens issued until then are refused
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *RevokeAllSessionsResponse) Reset() {
+	*x = RevokeAllSessionsResponse{}
+	mi := &file_admin_admin_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAllSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAllSessionsResponse) ProtoMessage() {}
+
+func (x *RevokeAllSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAllSessionsResponse.ProtoReflect.Descriptor instead.
+func (*RevokeAllSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RevokeAllSessionsResponse) GetRevokedRefreshTokens() int32 {
+	if x != nil {
+		return x.RevokedRefreshTokens
+	}
+	return 0
+}
+
+func (x *RevokeAllSessionsResponse) GetEpoch() int64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+var File_admin_admin_proto protoreflect.FileDescriptor
+
+const file_admin_admin_proto_rawDesc = "" +
+	"\n" +
+	"\x11admin/admin.proto\x12\x05admin\x1a\x1bbuf/validate/validate.proto\x1a\x1bmoderation/moderation.proto\"\x85\x01\n" +
+	"\x10ListCasesRequest\x125\n" +
+	"\x05state\x18\x01 \x01(\x0e2\x15.moderation.CaseStateB\b\xbaH\x05\x82\x01\x02\x10\x01R\x05state\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"c\n" +
+	"\x11ListCasesResponse\x12&\n" +
+	"\x05cases\x18\x01 \x03(\v2\x10.moderation.CaseR\x05cases\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\x93\x01\n" +
+	"\x15TransitionCaseRequest\x12#\n" +
+	"\acase_id\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x01R\x06caseId\x127\n" +
+	"\x05state\x18\x02 \x01(\x0e2\x15.moderation.CaseStateB\n" +
+	"\xbaH\a\x82\x01\x04\x10\x01 \x00R\x05state\x12\x1c\n" +
+	"\x04note\x18\x03 \x01(\tB\b\xbaH\x05r\x03\x18\xd0\x0fR\x04note\">\n" +
+	"\x16TransitionCaseResponse\x12$\n" +
+	"\x04case\x18\x01 \x01(\v2\x10.moderation.CaseR\x04case\"\xde\x02\n" +
+	"\vScrubReport\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\x01 \x01(\x03R\tstartedAt\x12\x1f\n" +
+	"\vfinished_at\x18\x02 \x01(\x03R\n" +
+	"finishedAt\x12#\n" +
+	"\rchecked_blobs\x18\x03 \x01(\x03R\fcheckedBlobs\x12#\n" +
+	"\rchecked_bytes\x18\x04 \x01(\x03R\fcheckedBytes\x12#\n" +
+	"\rcorrupt_blobs\x18\x05 \x03(\tR\fcorruptBlobs\x12%\n" +
+	"\x0erepaired_blobs\x18\x06 \x01(\x03R\rrepairedBlobs\x12%\n" +
+	"\x0eorphaned_blobs\x18\a \x01(\x03R\rorphanedBlobs\x12\x1d\n" +
+	"\n" +
+	"temp_files\x18\b \x01(\x03R\ttempFiles\x12\x1d\n" +
+	"\n" +
+	"empty_dirs\x18\t \x01(\x03R\temptyDirs\x12\x14\n" +
+	"\x05error\x18\n" +
+	" \x01(\tR\x05error\"\x13\n" +
+	"\x11StartScrubRequest\".\n" +
+	"\x12StartScrubResponse\x12\x18\n" +
+	"\astarted\x18\x01 \x01(\bR\astarted\"\x17\n" +
+	"\x15GetScrubStatusRequest\"Z\n" +
+	"\x16GetScrubStatusResponse\x12\x18\n" +
+	"\arunning\x18\x01 \x01(\bR\arunning\x12&\n" +
+	"\x04last\x18\x02 \x01(\v2\x12.admin.ScrubReportR\x04last\"\xed\x01\n" +
+	"\rRetentionRule\x12!\n" +
+	"\arule_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\x18\x80\x01R\x06ruleId\x12 \n" +
+	"\x06tenant\x18\x02 \x01(\tB\b\xbaH\x05r\x03\x18\x80\x01R\x06tenant\x12)\n" +
+	"\vuploader_id\x18\x03 \x01(\tB\b\xbaH\x05r\x03\x18\x80\x01R\n" +
+	"uploaderId\x12/\n" +
+	"\x0fmax_age_seconds\x18\x04 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\rmaxAgeSeconds\x12\x1c\n" +
+	"\x04note\x18\x05 \x01(\tB\b\xbaH\x05r\x03\x18\xd0\x0fR\x04note\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\x03R\tcreatedAt\"K\n" +
+	"\x17SetRetentionRuleRequest\x120\n" +
+	"\x04rule\x18\x01 \x01(\v2\x14.admin.RetentionRuleB\x06\xbaH\x03\xc8\x01\x01R\x04rule\"D\n" +
+	"\x18SetRetentionRuleResponse\x12(\n" +
+	"\x04rule\x18\x01 \x01(\v2\x14.admin.RetentionRuleR\x04rule\"\x1b\n" +
+	"\x19ListRetentionRulesRequest\"H\n" +
+	"\x1aListRetentionRulesResponse\x12*\n" +
+	"\x05rules\x18\x01 \x03(\v2\x14.admin.RetentionRuleR\x05rules\"A\n" +
+	"\x1aDeleteRetentionRuleRequest\x12#\n" +
+	"\arule_id\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x01R\x06ruleId\"\x1d\n" +
+	"\x1bDeleteRetentionRuleResponse\"\x19\n" +
+	"\x17PreviewRetentionRequest\"\xbb\x01\n" +
+	"\fExpiredVideo\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x16\n" +
+	"\x06tenant\x18\x02 \x01(\tR\x06tenant\x12\x1f\n" +
+	"\vuploader_id\x18\x03 \x01(\tR\n" +
+	"uploaderId\x12\x1f\n" +
+	"\vuploaded_at\x18\x04 \x01(\x03R\n" +
+	"uploadedAt\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x05 \x01(\x03R\tsizeBytes\x12\x17\n" +
+	"\arule_id\x18\x06 \x01(\tR\x06ruleId\"\x8b\x01\n" +
+	"\x18PreviewRetentionResponse\x12!\n" +
+	"\fevaluated_at\x18\x01 \x01(\x03R\vevaluatedAt\x12+\n" +
+	"\x06videos\x18\x02 \x03(\v2\x13.admin.ExpiredVideoR\x06videos\x12\x1f\n" +
+	"\vtotal_bytes\x18\x03 \x01(\x03R\n" +
+	"totalBytes\"\xd0\x01\n" +
+	"\x12ImportUsersRequest\x127\n" +
+	"\x06format\x18\x01 \x01(\x0e2\x13.admin.ImportFormatB\n" +
+	"\xbaH\a\x82\x01\x04\x10\x01 \x00R\x06format\x12%\n" +
+	"\apayload\x18\x02 \x01(\fB\v\xbaH\bz\x06\x10\x01\x18\x80\x80@R\apayload\x12A\n" +
+	"\n" +
+	"credential\x18\x03 \x01(\x0e2\x17.admin.ImportCredentialB\b\xbaH\x05\x82\x01\x02\x10\x01R\n" +
+	"credential\x12\x17\n" +
+	"\adry_run\x18\x04 \x01(\bR\x06dryRun\"\xa2\x02\n" +
+	"\fImportedUser\x12\x10\n" +
+	"\x03row\x18\x01 \x01(\x05R\x03row\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12+\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x13.admin.ImportStatusR\x06status\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12\x17\n" +
+	"\auser_id\x18\x05 \x01(\tR\x06userId\x12-\n" +
+	"\x12temporary_password\x18\x06 \x01(\tR\x11temporaryPassword\x12%\n" +
+	"\x0einvitation_url\x18\a \x01(\tR\rinvitationUrl\x122\n" +
+	"\x15invitation_expires_at\x18\b \x01(\x03R\x13invitationExpiresAt\"t\n" +
+	"\x13ImportUsersResponse\x12)\n" +
+	"\x05users\x18\x01 \x03(\v2\x13.admin.ImportedUserR\x05users\x12\x18\n" +
+	"\acreated\x18\x02 \x01(\x05R\acreated\x12\x18\n" +
+	"\askipped\x18\x03 \x01(\x05R\askipped\"@\n" +
+	"\x19RevokeUserSessionsRequest\x12#\n" +
+	"\auser_id\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x01R\x06userId\"R\n" +
+	"\x1aRevokeUserSessionsResponse\x124\n" +
+	"\x16revoked_refresh_tokens\x18\x01 \x01(\x05R\x14revokedRefreshTokens\"\x1a\n" +
+	"\x18RevokeAllSessionsRequest\"g\n" +
+	"\x19RevokeAllSessionsResponse\x124\n" +
+	"\x16revoked_refresh_tokens\x18\x01 \x01(\x05R\x14revokedRefreshTokens\x12\x14\n" +
+	"\x05epoch\x18\x02 \x01(\x03R\x05epoch*\\\n" +
+	"\fImportFormat\x12\x1d\n" +
+	"\x19IMPORT_FORMAT_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11IMPORT_FORMAT_CSV\x10\x01\x12\x16\n" +
+	"\x12IMPORT_FORMAT_JSON\x10\x02*w\n" +
+	"\x10ImportCredential\x12!\n" +
+	"\x1dIMPORT_CREDENTIAL_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aIMPORT_CREDENTIAL_PASSWORD\x10\x01\x12 \n" +
+	"\x1cIMPORT_CREDENTIAL_INVITATION\x10\x02*}\n" +
+	"\fImportStatus\x12\x1d\n" +
+	"\x19IMPORT_STATUS_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15IMPORT_STATUS_CREATED\x10\x01\x12\x18\n" +
+	"\x14IMPORT_STATUS_EXISTS\x10\x02\x12\x19\n" +
+	"\x15IMPORT_STATUS_INVALID\x10\x032\x8b\a\n" +
+	"\fAdminService\x12>\n" +
+	"\tListCases\x12\x17.admin.ListCasesRequest\x1a\x18.admin.ListCasesResponse\x12M\n" +
+	"\x0eTransitionCase\x12\x1c.admin.TransitionCaseRequest\x1a\x1d.admin.TransitionCaseResponse\x12A\n" +
+	"\n" +
+	"StartScrub\x12\x18.admin.StartScrubRequest\x1a\x19.admin.StartScrubResponse\x12M\n" +
+	"\x0eGetScrubStatus\x12\x1c.admin.GetScrubStatusRequest\x1a\x1d.admin.GetScrubStatusResponse\x12S\n" +
+	"\x10SetRetentionRule\x12\x1e.admin.SetRetentionRuleRequest\x1a\x1f.admin.SetRetentionRuleResponse\x12Y\n" +
+	"\x12ListRetentionRules\x12 .admin.ListRetentionRulesRequest\x1a!.admin.ListRetentionRulesResponse\x12\\\n" +
+	"\x13DeleteRetentionRule\x12!.admin.DeleteRetentionRuleRequest\x1a\".admin.DeleteRetentionRuleResponse\x12S\n" +
+	"\x10PreviewRetention\x12\x1e.admin.PreviewRetentionRequest\x1a\x1f.admin.PreviewRetentionResponse\x12D\n" +
+	"\vImportUsers\x12\x19.admin.ImportUsersRequest\x1a\x1a.admin.ImportUsersResponse\x12Y\n" +
+	"\x12RevokeUserSessions\x12 .admin.RevokeUserSessionsRequest\x1a!.admin.RevokeUserSessionsResponse\x12V\n" +
+	"\x11RevokeAllSessions\x12\x1f.admin.RevokeAllSessionsRequest\x1a .admin.RevokeAllSessionsResponseB\x1eZ\x1ccoscup2025/proto/admin;adminb\x06proto3"
+
+var (
+	file_admin_admin_proto_rawDescOnce sync.Once
+	file_admin_admin_proto_rawDescData []byte
+)
+
+func file_admin_admin_proto_rawDescGZIP() []byte {
+	file_admin_admin_proto_rawDescOnce.Do(func() {
+		file_admin_admin_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_admin_admin_proto_rawDesc), len(file_admin_admin_proto_rawDesc)))
+	})
+	return file_admin_admin_proto_rawDescData
+}
+
+var file_admin_admin_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_admin_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
+var file_admin_admin_proto_goTypes = []any{
+	(ImportFormat)(0),                   // 0: admin.ImportFormat
+	(ImportCredential)(0),               // 1: admin.ImportCredential
+	(ImportStatus)(0),                   // 2: admin.ImportStatus
+	(*ListCasesRequest)(nil),            // 3: admin.ListCasesRequest
+	(*ListCasesResponse)(nil),           // 4: admin.ListCasesResponse
+	(*TransitionCaseRequest)(nil),       // 5: admin.TransitionCaseRequest
+	(*TransitionCaseResponse)(nil),      // 6: admin.TransitionCaseResponse
+	(*ScrubReport)(nil),                 // 7: admin.ScrubReport
+	(*StartScrubRequest)(nil),           // 8: admin.StartScrubRequest
+	(*StartScrubResponse)(nil),          // 9: admin.StartScrubResponse
+	(*GetScrubStatusRequest)(nil),       // 10: admin.GetScrubStatusRequest
+	(*GetScrubStatusResponse)(nil),      // 11: admin.GetScrubStatusResponse
+	(*RetentionRule)(nil),               // 12: admin.RetentionRule
+	(*SetRetentionRuleRequest)(nil),     // 13: admin.SetRetentionRuleRequest
+	(*SetRetentionRuleResponse)(nil),    // 14: admin.SetRetentionRuleResponse
+	(*ListRetentionRulesRequest)(nil),   // 15: admin.ListRetentionRulesRequest
+	(*ListRetentionRulesResponse)(nil),  // 16: admin.ListRetentionRulesResponse
+	(*DeleteRetentionRuleRequest)(nil),  // 17: admin.DeleteRetentionRuleRequest
+	(*DeleteRetentionRuleResponse)(nil), // 18: admin.DeleteRetentionRuleResponse
+	(*PreviewRetentionRequest)(nil),     // 19: admin.PreviewRetentionRequest
+	(*ExpiredVideo)(nil),                // 20: admin.ExpiredVideo
+	(*PreviewRetentionResponse)(nil),    // 21: admin.PreviewRetentionResponse
+	(*ImportUsersRequest)(nil),          // 22: admin.ImportUsersRequest
+	(*ImportedUser)(nil),                // 23: admin.ImportedUser
+	(*ImportUsersResponse)(nil),         // 24: admin.ImportUsersResponse
+	(*RevokeUserSessionsRequest)(nil),   // 25: admin.RevokeUserSessionsRequest
+	(*RevokeUserSessionsResponse)(nil),  // 26: admin.RevokeUserSessionsResponse
+	(*RevokeAllSessionsRequest)(nil),    // 27: admin.RevokeAllSessionsRequest
+	(*RevokeAllSessionsResponse)(nil),   // 28: admin.RevokeAllSessionsResponse
+	(moderation.CaseState)(0),           // 29: moderation.CaseState
+	(*moderation.Case)(nil),             // 30: moderation.Case
+}
+var file_admin_admin_proto_depIdxs = []int32{
+	29, // 0: admin.ListCasesRequest.state:type_name -> moderation.CaseState
+	30, // 1: admin.ListCasesResponse.cases:type_name -> moderation.Case
+	29, // 2: admin.TransitionCaseRequest.state:type_name -> moderation.CaseState
+	30, // 3: admin.TransitionCaseResponse.case:type_name -> moderation.Case
+	7,  // 4: admin.GetScrubStatusResponse.last:type_name -> admin.ScrubReport
+	12, // 5: admin.SetRetentionRuleRequest.rule:type_name -> admin.RetentionRule
+	12, // 6: admin.SetRetentionRuleResponse.rule:type_name -> admin.RetentionRule
+	12, // 7: admin.ListRetentionRulesResponse.rules:type_name -> admin.RetentionRule
+	20, // 8: admin.PreviewRetentionResponse.videos:type_name -> admin.ExpiredVideo
+	0,  // 9: admin.ImportUsersRequest.format:type_name -> admin.ImportFormat
+	1,  // 10: admin.ImportUsersRequest.credential:type_name -> admin.ImportCredential
+	2,  // 11: admin.ImportedUser.status:type_name -> admin.ImportStatus
+	23, // 12: admin.ImportUsersResponse.users:type_name -> admin.ImportedUser
+	3,  // 13: admin.AdminService.ListCases:input_type -> admin.ListCasesRequest
+	5,  // 14: admin.AdminService.TransitionCase:input_type -> admin.TransitionCaseRequest
+	8,  // 15: admin.AdminService.StartScrub:input_type -> admin.StartScrubRequest
+	10, // 16: admin.AdminService.GetScrubStatus:input_type -> admin.GetScrubStatusRequest
+	13, // 17: admin.AdminService.SetRetentionRule:input_type -> admin.SetRetentionRuleRequest
+	15, // 18: admin.AdminService.ListRetentionRules:input_type -> admin.ListRetentionRulesRequest
+	17, // 19: admin.AdminService.DeleteRetentionRule:input_type -> admin.DeleteRetentionRuleRequest
+	19, // 20: admin.AdminService.PreviewRetention:input_type -> admin.PreviewRetentionRequest
+	22, // 21: admin.AdminService.ImportUsers:input_type -> admin.ImportUsersRequest
+	25, // 22: admin.AdminService.RevokeUserSessions:input_type -> admin.RevokeUserSessionsRequest
+	27, // 23: admin.AdminService.RevokeAllSessions:input_type -> admin.RevokeAllSessionsRequest
+	4,  // 24: admin.AdminService.ListCases:output_type -> admin.ListCasesResponse
+	6,  // 25: admin.AdminService.TransitionCase:output_type -> admin.TransitionCaseResponse
+	9,  // 26: admin.AdminService.StartScrub:output_type -> admin.StartScrubResponse
+	11, // 27: admin.AdminService.GetScrubStatus:output_type -> admin.GetScrubStatusResponse
+	14, // 28: admin.AdminService.SetRetentionRule:output_type -> admin.SetRetentionRuleResponse
+	16, // 29: admin.AdminService.ListRetentionRules:output_type -> admin.ListRetentionRulesResponse
+	18, // 30: admin.AdminService.DeleteRetentionRule:output_type -> admin.DeleteRetentionRuleResponse
+	21, // 31: admin.AdminService.PreviewRetention:output_type -> admin.PreviewRetentionResponse
+	24, // 32: admin.AdminService.ImportUsers:output_type -> admin.ImportUsersResponse
+	26, // 33: admin.AdminService.RevokeUserSessions:output_type -> admin.RevokeUserSessionsResponse
+	28, // 34: admin.AdminService.RevokeAllSessions:output_type -> admin.RevokeAllSessionsResponse
+	24, // [24:35] is the sub-list for method output_type
+	13, // [13:24] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
+}
+
+func init() { file_admin_admin_proto_init() }
+func file_admin_admin_proto_init() {
+	if File_admin_admin_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_admin_admin_proto_rawDesc), len(file_admin_admin_proto_rawDesc)),
+			NumEnums:      3,
+			NumMessages:   26,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_admin_admin_proto_goTypes,
+		DependencyIndexes: file_admin_admin_proto_depIdxs,
+		EnumInfos:         file_admin_admin_proto_enumTypes,
+		MessageInfos:      file_admin_admin_proto_msgTypes,
+	}.Build()
+	File_admin_admin_proto = out.File
+	file_admin_admin_proto_goTypes = nil
+	file_admin_admin_proto_depIdxs = nil
+}
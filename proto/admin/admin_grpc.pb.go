@@ -0,0 +1,551 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: admin/admin.proto
+
+package admin
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AdminService_ListCases_FullMethodName           = "/admin.AdminService/ListCases"
+	AdminService_TransitionCase_FullMethodName      = "/admin.AdminService/TransitionCase"
+	AdminService_StartScrub_FullMethodName          = "/admin.AdminService/StartScrub"
+	AdminService_GetScrubStatus_FullMethodName      = "/admin.AdminService/GetScrubStatus"
+	AdminService_SetRetentionRule_FullMethodName    = "/admin.AdminService/SetRetentionRule"
+	AdminService_ListRetentionRules_FullMethodName  = "/admin.AdminService/ListRetentionRules"
+	AdminService_DeleteRetentionRule_FullMethodName = "/admin.AdminService/DeleteRetentionRule"
+	AdminService_PreviewRetention_FullMethodName    = "/admin.AdminService/PreviewRetention"
+	AdminService_ImportUsers_FullMethodName         = "/admin.AdminService/ImportUsers"
+	AdminService_RevokeUserSessions_FullMethodName  = "/admin.AdminService/RevokeUserSessions"
+	AdminService_RevokeAllSessions_FullMethodName   = "/admin.AdminService/RevokeAllSessions"
+)
+
+// AdminServiceClient is the client API for AdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AdminService serves moderators. It is only reachable from the admin
+// networks and by admin users, and deliberately not exposed on the gateway
+type AdminServiceClient interface {
+	// ListCases returns moderation cases, oldest first
+	ListCases(ctx context.Context, in *ListCasesRequest, opts ...grpc.CallOption) (*ListCasesResponse, error)
+	// TransitionCase moves a case to another state of the takedown workflow
+	// and notifies the uploader
+	TransitionCase(ctx context.Context, in *TransitionCaseRequest, opts ...grpc.CallOption) (*TransitionCaseResponse, error)
+	// StartScrub starts verifying the stored videos in the background, unless
+	// a scrub is already running
+	StartScrub(ctx context.Context, in *StartScrubRequest, opts ...grpc.CallOption) (*StartScrubResponse, error)
+	// GetScrubStatus reports whether a scrub is running and how the last one
+	// went
+	GetScrubStatus(ctx context.Context, in *GetScrubStatusRequest, opts ...grpc.CallOption) (*GetScrubStatusResponse, error)
+	// SetRetentionRule creates a retention rule, or replaces the one with its
+	// rule_id
+	SetRetentionRule(ctx context.Context, in *SetRetentionRuleRequest, opts ...grpc.CallOption) (*SetRetentionRuleResponse, error)
+	// ListRetentionRules returns the retention rules in the order they were
+	// created
+	ListRetentionRules(ctx context.Context, in *ListRetentionRulesRequest, opts ...grpc.CallOption) (*ListRetentionRulesResponse, error)
+	// DeleteRetentionRule removes a retention rule
+	DeleteRetentionRule(ctx context.Context, in *DeleteRetentionRuleRequest, opts ...grpc.CallOption) (*DeleteRetentionRuleResponse, error)
+	// PreviewRetention is a dry run: it returns the videos the next
+	// enforcement of the retention rules would delete, without deleting them
+	PreviewRetention(ctx context.Context, in *PreviewRetentionRequest, opts ...grpc.CallOption) (*PreviewRetentionResponse, error)
+	// ImportUsers creates accounts for a list of speakers, each with a
+	// temporary password or an invitation to choose one, and reports the
+	// outcome of every row. Rows that fail do not stop the others
+	ImportUsers(ctx context.Context, in *ImportUsersRequest, opts ...grpc.CallOption) (*ImportUsersResponse, error)
+	// RevokeUserSessions signs a user out everywhere: their refresh tokens are
+	// dropped and the tokens issued to them so far are refused
+	RevokeUserSessions(ctx context.Context, in *RevokeUserSessionsRequest, opts ...grpc.CallOption) (*RevokeUserSessionsResponse, error)
+	// RevokeAllSessions signs everyone out, the caller included, for incident
+	// response: every refresh token is dropped and every token issued so far
+	// is refused. If the JWT secret leaked, rotate it first
+	RevokeAllSessions(ctx context.Context, in *RevokeAllSessionsRequest, opts ...grpc.CallOption) (*RevokeAllSessionsResponse, error)
+}
+
+type adminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminServiceClient(cc grpc.ClientConnInterface) AdminServiceClient {
+	return &adminServiceClient{cc}
+}
+
+func (c *adminServiceClient) ListCases(ctx context.Context, in *ListCasesRequest, opts ...grpc.CallOption) (*ListCasesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCasesResponse)
+	err := c.cc.Invoke(ctx, AdminService_ListCases_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) TransitionCase(ctx context.Context, in *TransitionCaseRequest, opts ...grpc.CallOption) (*TransitionCaseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransitionCaseResponse)
+	err := c.cc.Invoke(ctx, AdminService_TransitionCase_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) StartScrub(ctx context.Context, in *StartScrubRequest, opts ...grpc.CallOption) (*StartScrubResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartScrubResponse)
+	err := c.cc.Invoke(ctx, AdminService_StartScrub_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetScrubStatus(ctx context.Context, in *GetScrubStatusRequest, opts ...grpc.CallOption) (*GetScrubStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetScrubStatusResponse)
+	err := c.cc.Invoke(ctx, AdminService_GetScrubStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SetRetentionRule(ctx context.Context, in *SetRetentionRuleRequest, opts ...grpc.CallOption) (*SetRetentionRuleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetRetentionRuleResponse)
+	err := c.cc.Invoke(ctx, AdminService_SetRetentionRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListRetentionRules(ctx context.Context, in *ListRetentionRulesRequest, opts ...grpc.CallOption) (*ListRetentionRulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRetentionRulesResponse)
+	err := c.cc.Invoke(ctx, AdminService_ListRetentionRules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) DeleteRetentionRule(ctx context.Context, in *DeleteRetentionRuleRequest, opts ...grpc.CallOption) (*DeleteRetentionRuleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteRetentionRuleResponse)
+	err := c.cc.Invoke(ctx, AdminService_DeleteRetentionRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) PreviewRetention(ctx context.Context, in *PreviewRetentionRequest, opts ...grpc.CallOption) (*PreviewRetentionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PreviewRetentionResponse)
+	err := c.cc.Invoke(ctx, AdminService_PreviewRetention_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ImportUsers(ctx context.Context, in *ImportUsersRequest, opts ...grpc.CallOption) (*ImportUsersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportUsersResponse)
+	err := c.cc.Invoke(ctx, AdminService_ImportUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) RevokeUserSessions(ctx context.Context, in *RevokeUserSessionsRequest, opts ...grpc.CallOption) (*RevokeUserSessionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeUserSessionsResponse)
+	err := c.cc.Invoke(ctx, AdminService_RevokeUserSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) RevokeAllSessions(ctx context.Context, in *RevokeAllSessionsRequest, opts ...grpc.CallOption) (*RevokeAllSessionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeAllSessionsResponse)
+	err := c.cc.Invoke(ctx, AdminService_RevokeAllSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServiceServer is the server API for AdminService service.
+// All implementations must embed UnimplementedAdminServiceServer
+// for forward compatibility.
+//
+// AdminService serves moderators. It is only reachable from the admin
+// networks and by admin users, and deliberately not exposed on the gateway
+type AdminServiceServer interface {
+	// ListCases returns moderation cases, oldest first
+	ListCases(context.Context, *ListCasesRequest) (*ListCasesResponse, error)
+	// TransitionCase moves a case to another state of the takedown workflow
+	// and notifies the uploader
+	TransitionCase(context.Context, *TransitionCaseRequest) (*TransitionCaseResponse, error)
+	// StartScrub starts verifying the stored videos in the background, unless
+	// a scrub is already running
+	StartScrub(context.Context, *StartScrubRequest) (*StartScrubResponse, error)
+	// GetScrubStatus reports whether a scrub is running and how the last one
+	// went
+	GetScrubStatus(context.Context, *GetScrubStatusRequest) (*GetScrubStatusResponse, error)
+	// SetRetentionRule creates a retention rule, or replaces the one with its
+	// rule_id
+	SetRetentionRule(context.Context, *SetRetentionRuleRequest) (*SetRetentionRuleResponse, error)
+	// ListRetentionRules returns the retention rules in the order they were
+	// created
+	ListRetentionRules(context.Context, *ListRetentionRulesRequest) (*ListRetentionRulesResponse, error)
+	// DeleteRetentionRule removes a retention rule
+	DeleteRetentionRule(context.Context, *DeleteRetentionRuleRequest) (*DeleteRetentionRuleResponse, error)
+	// PreviewRetention is a dry run: it returns the videos the next
+	// enforcement of the retention rules would delete, without deleting them
+	PreviewRetention(context.Context, *PreviewRetentionRequest) (*PreviewRetentionResponse, error)
+	// ImportUsers creates accounts for a list of speakers, each with a
+	// temporary password or an invitation to choose one, and reports the
+	// outcome of every row. Rows that fail do not stop the others
+	ImportUsers(context.Context, *ImportUsersRequest) (*ImportUsersResponse, error)
+	// RevokeUserSessions signs a user out everywhere: their refresh tokens are
+	// dropped and the tokens issued to them so far are refused
+	RevokeUserSessions(context.Context, *RevokeUserSessionsRequest) (*RevokeUserSessionsResponse, error)
+	// RevokeAllSessions signs everyone out, the caller included, for incident
+	// response: every refresh token is dropped and every token issued so far
+	// is refused. If the JWT secret leaked, rotate it first
+	RevokeAllSessions(context.Context, *RevokeAllSessionsRequest) (*RevokeAllSessionsResponse, error)
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+// UnimplementedAdminServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAdminServiceServer struct{}
+
+func (UnimplementedAdminServiceServer) ListCases(context.Context, *ListCasesRequest) (*ListCasesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCases not implemented")
+}
+func (UnimplementedAdminServiceServer) TransitionCase(context.Context, *TransitionCaseRequest) (*TransitionCaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransitionCase not implemented")
+}
+func (UnimplementedAdminServiceServer) StartScrub(context.Context, *StartScrubRequest) (*StartScrubResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartScrub not implemented")
+}
+func (UnimplementedAdminServiceServer) GetScrubStatus(context.Context, *GetScrubStatusRequest) (*GetScrubStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetScrubStatus not implemented")
+}
+func (UnimplementedAdminServiceServer) SetRetentionRule(context.Context, *SetRetentionRuleRequest) (*SetRetentionRuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRetentionRule not implemented")
+}
+func (UnimplementedAdminServiceServer) ListRetentionRules(context.Context, *ListRetentionRulesRequest) (*ListRetentionRulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRetentionRules not implemented")
+}
+func (UnimplementedAdminServiceServer) DeleteRetentionRule(context.Context, *DeleteRetentionRuleRequest) (*DeleteRetentionRuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRetentionRule not implemented")
+}
+func (UnimplementedAdminServiceServer) PreviewRetention(context.Context, *PreviewRetentionRequest) (*PreviewRetentionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreviewRetention not implemented")
+}
+func (UnimplementedAdminServiceServer) ImportUsers(context.Context, *ImportUsersRequest) (*ImportUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportUsers not implemented")
+}
+func (UnimplementedAdminServiceServer) RevokeUserSessions(context.Context, *RevokeUserSessionsRequest) (*RevokeUserSessionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeUserSessions not implemented")
+}
+func (UnimplementedAdminServiceServer) RevokeAllSessions(context.Context, *RevokeAllSessionsRequest) (*RevokeAllSessionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeAllSessions not implemented")
+}
+func (UnimplementedAdminServiceServer) mustEmbedUnimplementedAdminServiceServer() {}
+func (UnimplementedAdminServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdminServiceServer will
+// result in compilation errors.
+type UnsafeAdminServiceServer interface {
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+func RegisterAdminServiceServer(s grpc.ServiceRegistrar, srv AdminServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAdminServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AdminService_ServiceDesc, srv)
+}
+
+func _AdminService_ListCases_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCasesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListCases(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ListCases_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListCases(ctx, req.(*ListCasesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_TransitionCase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransitionCaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).TransitionCase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_TransitionCase_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).TransitionCase(ctx, req.(*TransitionCaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_StartScrub_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartScrubRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).StartScrub(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_StartScrub_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).StartScrub(ctx, req.(*StartScrubRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetScrubStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetScrubStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetScrubStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_GetScrubStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetScrubStatus(ctx, req.(*GetScrubStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SetRetentionRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRetentionRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetRetentionRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_SetRetentionRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetRetentionRule(ctx, req.(*SetRetentionRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListRetentionRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRetentionRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListRetentionRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ListRetentionRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListRetentionRules(ctx, req.(*ListRetentionRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_DeleteRetentionRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRetentionRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).DeleteRetentionRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_DeleteRetentionRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).DeleteRetentionRule(ctx, req.(*DeleteRetentionRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_PreviewRetention_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewRetentionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).PreviewRetention(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_PreviewRetention_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).PreviewRetention(ctx, req.(*PreviewRetentionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ImportUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ImportUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ImportUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ImportUsers(ctx, req.(*ImportUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_RevokeUserSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeUserSessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).RevokeUserSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_RevokeUserSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).RevokeUserSessions(ctx, req.(*RevokeUserSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_RevokeAllSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeAllSessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).RevokeAllSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_RevokeAllSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).RevokeAllSessions(ctx, req.(*RevokeAllSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AdminService_ServiceDesc is the grpc.ServiceDesc for AdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "admin.AdminService",
+	HandlerType: (*AdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListCases",
+			Handler:    _AdminService_ListCases_Handler,
+		},
+		{
+			MethodName: "TransitionCase",
+			Handler:    _AdminService_TransitionCase_Handler,
+		},
+		{
+			MethodName: "StartScrub",
+			Handler:    _AdminService_StartScrub_Handler,
+		},
+		{
+			MethodName: "GetScrubStatus",
+			Handler:    _AdminService_GetScrubStatus_Handler,
+		},
+		{
+			MethodName: "SetRetentionRule",
+			Handler:    _AdminService_SetRetentionRule_Handler,
+		},
+		{
+			MethodName: "ListRetentionRules",
+			Handler:    _AdminService_ListRetentionRules_Handler,
+		},
+		{
+			MethodName: "DeleteRetentionRule",
+			Handler:    _AdminService_DeleteRetentionRule_Handler,
+		},
+		{
+			MethodName: "PreviewRetention",
+			Handler:    _AdminService_PreviewRetention_Handler,
+		},
+		{
+			MethodName: "ImportUsers",
+			Handler:    _AdminService_ImportUsers_Handler,
+		},
+		{
+			MethodName: "RevokeUserSessions",
+			Handler:    _AdminService_RevokeUserSessions_Handler,
+		},
+		{
+			MethodName: "RevokeAllSessions",
+			Handler:    _AdminService_RevokeAllSessions_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "admin/admin.proto",
+}
@@ -1,17 +1,19 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.34.2
+// 	protoc-gen-go v1.36.6
 // 	protoc        (unknown)
 // source: media/media.proto
 
 package media
 
 import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
 	sync "sync"
+	unsafe "unsafe"
 )
 
 const (
@@ -21,34 +23,5053 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// DownloadPriority is how a download shares the server's download bandwidth.
+// It only matters when COSCUP_DOWNLOAD_BANDWIDTH is set.
+type DownloadPriority int32
+
+const (
+	DownloadPriority_DOWNLOAD_PRIORITY_UNSPECIFIED DownloadPriority = 0 // treated as interactive
+	DownloadPriority_DOWNLOAD_PRIORITY_INTERACTIVE DownloadPriority = 1 // a player, sent ahead of batch downloads
+	DownloadPriority_DOWNLOAD_PRIORITY_BATCH       DownloadPriority = 2 // a mirror or archive, sent only while no player waits
+)
+
+// Enum value maps for DownloadPriority.
+var (
+	DownloadPriority_name = map[int32]string{
+		0: "DOWNLOAD_PRIORITY_UNSPECIFIED",
+		1: "DOWNLOAD_PRIORITY_INTERACTIVE",
+		2: "DOWNLOAD_PRIORITY_BATCH",
+	}
+	DownloadPriority_value = map[string]int32{
+		"DOWNLOAD_PRIORITY_UNSPECIFIED": 0,
+		"DOWNLOAD_PRIORITY_INTERACTIVE": 1,
+		"DOWNLOAD_PRIORITY_BATCH":       2,
+	}
+)
+
+func (x DownloadPriority) Enum() *DownloadPriority {
+	p := new(DownloadPriority)
+	*p = x
+	return p
+}
+
+func (x DownloadPriority) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DownloadPriority) Descriptor() protoreflect.EnumDescriptor {
+	return file_media_media_proto_enumTypes[0].Descriptor()
+}
+
+func (DownloadPriority) Type() protoreflect.EnumType {
+	return &file_media_media_proto_enumTypes[0]
+}
+
+func (x DownloadPriority) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DownloadPriority.Descriptor instead.
+func (DownloadPriority) EnumDescriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{0}
+}
+
+type RenditionState int32
+
+const (
+	RenditionState_RENDITION_STATE_UNSPECIFIED RenditionState = 0
+	RenditionState_RENDITION_STATE_PROCESSING  RenditionState = 1
+	RenditionState_RENDITION_STATE_READY       RenditionState = 2
+	RenditionState_RENDITION_STATE_FAILED      RenditionState = 3
+)
+
+// Enum value maps for RenditionState.
+var (
+	RenditionState_name = map[int32]string{
+		0: "RENDITION_STATE_UNSPECIFIED",
+		1: "RENDITION_STATE_PROCESSING",
+		2: "RENDITION_STATE_READY",
+		3: "RENDITION_STATE_FAILED",
+	}
+	RenditionState_value = map[string]int32{
+		"RENDITION_STATE_UNSPECIFIED": 0,
+		"RENDITION_STATE_PROCESSING":  1,
+		"RENDITION_STATE_READY":       2,
+		"RENDITION_STATE_FAILED":      3,
+	}
+)
+
+func (x RenditionState) Enum() *RenditionState {
+	p := new(RenditionState)
+	*p = x
+	return p
+}
+
+func (x RenditionState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RenditionState) Descriptor() protoreflect.EnumDescriptor {
+	return file_media_media_proto_enumTypes[1].Descriptor()
+}
+
+func (RenditionState) Type() protoreflect.EnumType {
+	return &file_media_media_proto_enumTypes[1]
+}
+
+func (x RenditionState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RenditionState.Descriptor instead.
+func (RenditionState) EnumDescriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{1}
+}
+
+// Visibility controls who can find and watch a video. Videos outside any
+// channel are unspecified, which is treated as public.
+type Visibility int32
+
+const (
+	Visibility_VISIBILITY_UNSPECIFIED Visibility = 0
+	Visibility_VISIBILITY_PUBLIC      Visibility = 1
+	Visibility_VISIBILITY_UNLISTED    Visibility = 2 // watchable by anyone with the ID, but not listed
+	Visibility_VISIBILITY_PRIVATE     Visibility = 3 // only the uploader
+)
+
+// Enum value maps for Visibility.
+var (
+	Visibility_name = map[int32]string{
+		0: "VISIBILITY_UNSPECIFIED",
+		1: "VISIBILITY_PUBLIC",
+		2: "VISIBILITY_UNLISTED",
+		3: "VISIBILITY_PRIVATE",
+	}
+	Visibility_value = map[string]int32{
+		"VISIBILITY_UNSPECIFIED": 0,
+		"VISIBILITY_PUBLIC":      1,
+		"VISIBILITY_UNLISTED":    2,
+		"VISIBILITY_PRIVATE":     3,
+	}
+)
+
+func (x Visibility) Enum() *Visibility {
+	p := new(Visibility)
+	*p = x
+	return p
+}
+
+func (x Visibility) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Visibility) Descriptor() protoreflect.EnumDescriptor {
+	return file_media_media_proto_enumTypes[2].Descriptor()
+}
+
+func (Visibility) Type() protoreflect.EnumType {
+	return &file_media_media_proto_enumTypes[2]
+}
+
+func (x Visibility) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Visibility.Descriptor instead.
+func (Visibility) EnumDescriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{2}
+}
+
+type VideoState int32
+
+const (
+	VideoState_VIDEO_STATE_UNSPECIFIED VideoState = 0
+	VideoState_VIDEO_STATE_UPLOADING   VideoState = 1
+	VideoState_VIDEO_STATE_PROCESSING  VideoState = 2
+	VideoState_VIDEO_STATE_READY       VideoState = 3
+	VideoState_VIDEO_STATE_FAILED      VideoState = 4
+)
+
+// Enum value maps for VideoState.
+var (
+	VideoState_name = map[int32]string{
+		0: "VIDEO_STATE_UNSPECIFIED",
+		1: "VIDEO_STATE_UPLOADING",
+		2: "VIDEO_STATE_PROCESSING",
+		3: "VIDEO_STATE_READY",
+		4: "VIDEO_STATE_FAILED",
+	}
+	VideoState_value = map[string]int32{
+		"VIDEO_STATE_UNSPECIFIED": 0,
+		"VIDEO_STATE_UPLOADING":   1,
+		"VIDEO_STATE_PROCESSING":  2,
+		"VIDEO_STATE_READY":       3,
+		"VIDEO_STATE_FAILED":      4,
+	}
+)
+
+func (x VideoState) Enum() *VideoState {
+	p := new(VideoState)
+	*p = x
+	return p
+}
+
+func (x VideoState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (VideoState) Descriptor() protoreflect.EnumDescriptor {
+	return file_media_media_proto_enumTypes[3].Descriptor()
+}
+
+func (VideoState) Type() protoreflect.EnumType {
+	return &file_media_media_proto_enumTypes[3]
+}
+
+func (x VideoState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use VideoState.Descriptor instead.
+func (VideoState) EnumDescriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{3}
+}
+
+// ShareTarget is where a share link leads.
+type ShareTarget int32
+
+const (
+	ShareTarget_SHARE_TARGET_UNSPECIFIED ShareTarget = 0 // treated as the player
+	ShareTarget_SHARE_TARGET_PLAYER      ShareTarget = 1
+	ShareTarget_SHARE_TARGET_DOWNLOAD    ShareTarget = 2 // a short-lived signed download URL
+)
+
+// Enum value maps for ShareTarget.
+var (
+	ShareTarget_name = map[int32]string{
+		0: "SHARE_TARGET_UNSPECIFIED",
+		1: "SHARE_TARGET_PLAYER",
+		2: "SHARE_TARGET_DOWNLOAD",
+	}
+	ShareTarget_value = map[string]int32{
+		"SHARE_TARGET_UNSPECIFIED": 0,
+		"SHARE_TARGET_PLAYER":      1,
+		"SHARE_TARGET_DOWNLOAD":    2,
+	}
+)
+
+func (x ShareTarget) Enum() *ShareTarget {
+	p := new(ShareTarget)
+	*p = x
+	return p
+}
+
+func (x ShareTarget) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ShareTarget) Descriptor() protoreflect.EnumDescriptor {
+	return file_media_media_proto_enumTypes[4].Descriptor()
+}
+
+func (ShareTarget) Type() protoreflect.EnumType {
+	return &file_media_media_proto_enumTypes[4]
+}
+
+func (x ShareTarget) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ShareTarget.Descriptor instead.
+func (ShareTarget) EnumDescriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{4}
+}
+
+type ExportFormat int32
+
+const (
+	ExportFormat_EXPORT_FORMAT_UNSPECIFIED ExportFormat = 0 // a zip archive
+	ExportFormat_EXPORT_FORMAT_ZIP         ExportFormat = 1
+	ExportFormat_EXPORT_FORMAT_TAR         ExportFormat = 2
+	ExportFormat_EXPORT_FORMAT_MANIFEST    ExportFormat = 3 // an ExportManifest of signed download URLs, as JSON
+)
+
+// Enum value maps for ExportFormat.
+var (
+	ExportFormat_name = map[int32]string{
+		0: "EXPORT_FORMAT_UNSPECIFIED",
+		1: "EXPORT_FORMAT_ZIP",
+		2: "EXPORT_FORMAT_TAR",
+		3: "EXPORT_FORMAT_MANIFEST",
+	}
+	ExportFormat_value = map[string]int32{
+		"EXPORT_FORMAT_UNSPECIFIED": 0,
+		"EXPORT_FORMAT_ZIP":         1,
+		"EXPORT_FORMAT_TAR":         2,
+		"EXPORT_FORMAT_MANIFEST":    3,
+	}
+)
+
+func (x ExportFormat) Enum() *ExportFormat {
+	p := new(ExportFormat)
+	*p = x
+	return p
+}
+
+func (x ExportFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExportFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_media_media_proto_enumTypes[5].Descriptor()
+}
+
+func (ExportFormat) Type() protoreflect.EnumType {
+	return &file_media_media_proto_enumTypes[5]
+}
+
+func (x ExportFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExportFormat.Descriptor instead.
+func (ExportFormat) EnumDescriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{5}
+}
+
+type ExportState int32
+
+const (
+	ExportState_EXPORT_STATE_UNSPECIFIED ExportState = 0
+	ExportState_EXPORT_STATE_PENDING     ExportState = 1
+	ExportState_EXPORT_STATE_RUNNING     ExportState = 2
+	ExportState_EXPORT_STATE_READY       ExportState = 3
+	ExportState_EXPORT_STATE_FAILED      ExportState = 4
+)
+
+// Enum value maps for ExportState.
+var (
+	ExportState_name = map[int32]string{
+		0: "EXPORT_STATE_UNSPECIFIED",
+		1: "EXPORT_STATE_PENDING",
+		2: "EXPORT_STATE_RUNNING",
+		3: "EXPORT_STATE_READY",
+		4: "EXPORT_STATE_FAILED",
+	}
+	ExportState_value = map[string]int32{
+		"EXPORT_STATE_UNSPECIFIED": 0,
+		"EXPORT_STATE_PENDING":     1,
+		"EXPORT_STATE_RUNNING":     2,
+		"EXPORT_STATE_READY":       3,
+		"EXPORT_STATE_FAILED":      4,
+	}
+)
+
+func (x ExportState) Enum() *ExportState {
+	p := new(ExportState)
+	*p = x
+	return p
+}
+
+func (x ExportState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExportState) Descriptor() protoreflect.EnumDescriptor {
+	return file_media_media_proto_enumTypes[6].Descriptor()
+}
+
+func (ExportState) Type() protoreflect.EnumType {
+	return &file_media_media_proto_enumTypes[6]
+}
+
+func (x ExportState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExportState.Descriptor instead.
+func (ExportState) EnumDescriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{6}
+}
+
+// An upload to a video ID that is taken fails with ALREADY_EXISTS, unless the
+// caller uploaded the stored video and either sets overwrite, which replaces
+// it, or the server keeps versions, which stores the upload as its next
+// version with the likes, thumbnail and channel of the previous one.
 type UploadVideoRequest struct {
-	state         protoimpl.MessageState
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	VideoId      string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	Data         []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Sequence     int64                  `protobuf:"varint,3,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	TotalSize    int64                  `protobuf:"varint,4,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`                                                               // expected size in bytes, optional, set on the first chunk
+	UploadId     string                 `protobuf:"bytes,5,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`                                                                   // resumable upload session, optional
+	Offset       int64                  `protobuf:"varint,6,opt,name=offset,proto3" json:"offset,omitempty"`                                                                                      // byte offset of data within the video, required with upload_id
+	Tags         []string               `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`                                                                                           // labels such as the track or room, set on the first chunk without upload_id
+	Titles       map[string]string      `protobuf:"bytes,8,rep,name=titles,proto3" json:"titles,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`             // by BCP 47 language tag, e.g. "zh-TW" or "en"; set like tags
+	Descriptions map[string]string      `protobuf:"bytes,9,rep,name=descriptions,proto3" json:"descriptions,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // by BCP 47 language tag; set like tags
+	Overwrite    bool                   `protobuf:"varint,10,opt,name=overwrite,proto3" json:"overwrite,omitempty"`                                                                               // replace a video of the caller already stored as video_id; set like tags
+	FileName     string                 `protobuf:"bytes,11,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`                                                                  // name of the uploaded file, under which downloads are saved; set like tags, video_id by default
+	// heartbeat marks a message without data that only shows the client is
+	// alive, e.g. while it waits for input, so that the stream is not aborted
+	// as stalled. Everything but video_id is ignored.
+	Heartbeat     bool `protobuf:"varint,12,opt,name=heartbeat,proto3" json:"heartbeat,omitempty"`
+	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadVideoRequest) Reset() {
+	*x = UploadVideoRequest{}
+	mi := &file_media_media_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadVideoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadVideoRequest) ProtoMessage() {}
+
+func (x *UploadVideoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadVideoRequest.ProtoReflect.Descriptor instead.
+func (*UploadVideoRequest) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *UploadVideoRequest) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *UploadVideoRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *UploadVideoRequest) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *UploadVideoRequest) GetTotalSize() int64 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+func (x *UploadVideoRequest) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+func (x *UploadVideoRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *UploadVideoRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *UploadVideoRequest) GetTitles() map[string]string {
+	if x != nil {
+		return x.Titles
+	}
+	return nil
+}
+
+func (x *UploadVideoRequest) GetDescriptions() map[string]string {
+	if x != nil {
+		return x.Descriptions
+	}
+	return nil
+}
+
+func (x *UploadVideoRequest) GetOverwrite() bool {
+	if x != nil {
+		return x.Overwrite
+	}
+	return false
+}
+
+func (x *UploadVideoRequest) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *UploadVideoRequest) GetHeartbeat() bool {
+	if x != nil {
+		return x.Heartbeat
+	}
+	return false
+}
+
+type UploadVideoResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	TotalBytes    int64                  `protobuf:"varint,2,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"`
+	Metadata      *VideoMetadata         `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Stats         *TransferStats         `protobuf:"bytes,4,opt,name=stats,proto3" json:"stats,omitempty"` // of this stream
 	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadVideoResponse) Reset() {
+	*x = UploadVideoResponse{}
+	mi := &file_media_media_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadVideoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadVideoResponse) ProtoMessage() {}
+
+func (x *UploadVideoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadVideoResponse.ProtoReflect.Descriptor instead.
+func (*UploadVideoResponse) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UploadVideoResponse) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *UploadVideoResponse) GetTotalBytes() int64 {
+	if x != nil {
+		return x.TotalBytes
+	}
+	return 0
+}
+
+func (x *UploadVideoResponse) GetMetadata() *VideoMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *UploadVideoResponse) GetStats() *TransferStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+// TransferStats summarizes an upload or download stream, so that clients can
+// tell how well a transfer went.
+type TransferStats struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	DurationMs     int64                  `protobuf:"varint,1,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`                // from the start of the stream to its last chunk
+	Bytes          int64                  `protobuf:"varint,2,opt,name=bytes,proto3" json:"bytes,omitempty"`                                            // content bytes the stream moved
+	BytesPerSecond float64                `protobuf:"fixed64,3,opt,name=bytes_per_second,json=bytesPerSecond,proto3" json:"bytes_per_second,omitempty"` // average throughput, bytes over duration
+	ChunkCount     int64                  `protobuf:"varint,4,opt,name=chunk_count,json=chunkCount,proto3" json:"chunk_count,omitempty"`
+	// attempts counts the streams that wrote to an upload session, this one
+	// included, so attempts - 1 were retries. It is 1 for uploads without a
+	// session and for downloads.
+	Attempts int64 `protobuf:"varint,5,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	// retransmitted_bytes were received by an upload session more than once,
+	// e.g. again after a chunk failed to be stored.
+	RetransmittedBytes int64 `protobuf:"varint,6,opt,name=retransmitted_bytes,json=retransmittedBytes,proto3" json:"retransmitted_bytes,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *TransferStats) Reset() {
+	*x = TransferStats{}
+	mi := &file_media_media_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferStats) ProtoMessage() {}
+
+func (x *TransferStats) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferStats.ProtoReflect.Descriptor instead.
+func (*TransferStats) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TransferStats) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *TransferStats) GetBytes() int64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+func (x *TransferStats) GetBytesPerSecond() float64 {
+	if x != nil {
+		return x.BytesPerSecond
+	}
+	return 0
+}
+
+func (x *TransferStats) GetChunkCount() int64 {
+	if x != nil {
+		return x.ChunkCount
+	}
+	return 0
+}
+
+func (x *TransferStats) GetAttempts() int64 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *TransferStats) GetRetransmittedBytes() int64 {
+	if x != nil {
+		return x.RetransmittedBytes
+	}
+	return 0
+}
+
+type DownloadVideoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	Offset        int64                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"` // first byte to send, e.g. to resume a download
+	Length        int64                  `protobuf:"varint,3,opt,name=length,proto3" json:"length,omitempty"` // bytes to send from offset; 0 sends the rest of the video
+	Priority      DownloadPriority       `protobuf:"varint,4,opt,name=priority,proto3,enum=media.DownloadPriority" json:"priority,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadVideoRequest) Reset() {
+	*x = DownloadVideoRequest{}
+	mi := &file_media_media_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadVideoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadVideoRequest) ProtoMessage() {}
+
+func (x *DownloadVideoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadVideoRequest.ProtoReflect.Descriptor instead.
+func (*DownloadVideoRequest) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DownloadVideoRequest) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *DownloadVideoRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *DownloadVideoRequest) GetLength() int64 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+func (x *DownloadVideoRequest) GetPriority() DownloadPriority {
+	if x != nil {
+		return x.Priority
+	}
+	return DownloadPriority_DOWNLOAD_PRIORITY_UNSPECIFIED
+}
+
+type VideoMetadata struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UploaderId      string                 `protobuf:"bytes,1,opt,name=uploader_id,json=uploaderId,proto3" json:"uploader_id,omitempty"`
+	UploaderName    string                 `protobuf:"bytes,2,opt,name=uploader_name,json=uploaderName,proto3" json:"uploader_name,omitempty"`
+	UploadTimestamp int64                  `protobuf:"varint,3,opt,name=upload_timestamp,json=uploadTimestamp,proto3" json:"upload_timestamp,omitempty"`
+	FileName        string                 `protobuf:"bytes,4,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	FileSize        int64                  `protobuf:"varint,5,opt,name=file_size,json=fileSize,proto3" json:"file_size,omitempty"`
+	Sha256          string                 `protobuf:"bytes,6,opt,name=sha256,proto3" json:"sha256,omitempty"` // hex-encoded SHA-256 of the video content
+	Tags            []string               `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
+	ChannelId       string                 `protobuf:"bytes,8,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	Visibility      Visibility             `protobuf:"varint,9,opt,name=visibility,proto3,enum=media.Visibility" json:"visibility,omitempty"`
+	LikeCount       int64                  `protobuf:"varint,10,opt,name=like_count,json=likeCount,proto3" json:"like_count,omitempty"`
+	TakenDown       bool                   `protobuf:"varint,11,opt,name=taken_down,json=takenDown,proto3" json:"taken_down,omitempty"`                                                               // hidden by moderators from everyone but the uploader
+	Crc32C          uint32                 `protobuf:"varint,12,opt,name=crc32c,proto3" json:"crc32c,omitempty"`                                                                                      // CRC-32C (Castagnoli) of the video content
+	Titles          map[string]string      `protobuf:"bytes,13,rep,name=titles,proto3" json:"titles,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`             // by BCP 47 language tag
+	Descriptions    map[string]string      `protobuf:"bytes,14,rep,name=descriptions,proto3" json:"descriptions,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // by BCP 47 language tag
+	// title and description are the entries of titles and descriptions that
+	// best match the requested locale, and locale is the language of title.
+	// They are only set by GetVideoMetadata and ListVideos.
+	Title         string       `protobuf:"bytes,15,opt,name=title,proto3" json:"title,omitempty"`
+	Description   string       `protobuf:"bytes,16,opt,name=description,proto3" json:"description,omitempty"`
+	Locale        string       `protobuf:"bytes,17,opt,name=locale,proto3" json:"locale,omitempty"`
+	Version       int64        `protobuf:"varint,18,opt,name=version,proto3" json:"version,omitempty"`      // 1 for a new video, counting up with each upload to its ID when versioning is enabled
+	Renditions    []*Rendition `protobuf:"bytes,19,rep,name=renditions,proto3" json:"renditions,omitempty"` // as reported by the external transcoder
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VideoMetadata) Reset() {
+	*x = VideoMetadata{}
+	mi := &file_media_media_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VideoMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VideoMetadata) ProtoMessage() {}
+
+func (x *VideoMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VideoMetadata.ProtoReflect.Descriptor instead.
+func (*VideoMetadata) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *VideoMetadata) GetUploaderId() string {
+	if x != nil {
+		return x.UploaderId
+	}
+	return ""
+}
+
+func (x *VideoMetadata) GetUploaderName() string {
+	if x != nil {
+		return x.UploaderName
+	}
+	return ""
+}
+
+func (x *VideoMetadata) GetUploadTimestamp() int64 {
+	if x != nil {
+		return x.UploadTimestamp
+	}
+	return 0
+}
+
+func (x *VideoMetadata) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *VideoMetadata) GetFileSize() int64 {
+	if x != nil {
+		return x.FileSize
+	}
+	return 0
+}
+
+func (x *VideoMetadata) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *VideoMetadata) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *VideoMetadata) GetChannelId() string {
+	if x != nil {
+		return x.ChannelId
+	}
+	return ""
+}
+
+func (x *VideoMetadata) GetVisibility() Visibility {
+	if x != nil {
+		return x.Visibility
+	}
+	return Visibility_VISIBILITY_UNSPECIFIED
+}
+
+func (x *VideoMetadata) GetLikeCount() int64 {
+	if x != nil {
+		return x.LikeCount
+	}
+	return 0
+}
+
+func (x *VideoMetadata) GetTakenDown() bool {
+	if x != nil {
+		return x.TakenDown
+	}
+	return false
+}
+
+func (x *VideoMetadata) GetCrc32C() uint32 {
+	if x != nil {
+		return x.Crc32C
+	}
+	return 0
+}
+
+func (x *VideoMetadata) GetTitles() map[string]string {
+	if x != nil {
+		return x.Titles
+	}
+	return nil
+}
+
+func (x *VideoMetadata) GetDescriptions() map[string]string {
+	if x != nil {
+		return x.Descriptions
+	}
+	return nil
+}
+
+func (x *VideoMetadata) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *VideoMetadata) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *VideoMetadata) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *VideoMetadata) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *VideoMetadata) GetRenditions() []*Rendition {
+	if x != nil {
+		return x.Renditions
+	}
+	return nil
+}
+
+// Rendition is a transcoded variant of a video, such as a 720p version.
+type Rendition struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // e.g. "720p"
+	State         RenditionState         `protobuf:"varint,2,opt,name=state,proto3,enum=media.RenditionState" json:"state,omitempty"`
+	Url           string                 `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`                               // where the rendition is served, once ready
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`                           // why transcoding failed
+	UpdatedAt     int64                  `protobuf:"varint,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"` // occurred_at of the event that last changed it, Unix milliseconds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Rendition) Reset() {
+	*x = Rendition{}
+	mi := &file_media_media_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Rendition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rendition) ProtoMessage() {}
+
+func (x *Rendition) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rendition.ProtoReflect.Descriptor instead.
+func (*Rendition) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Rendition) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Rendition) GetState() RenditionState {
+	if x != nil {
+		return x.State
+	}
+	return RenditionState_RENDITION_STATE_UNSPECIFIED
+}
+
+func (x *Rendition) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Rendition) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *Rendition) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+type DownloadVideoResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Sequence      int64                  `protobuf:"varint,3,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Metadata      *VideoMetadata         `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Offset        int64                  `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"` // position of data in the video
+	Stats         *TransferStats         `protobuf:"bytes,6,opt,name=stats,proto3" json:"stats,omitempty"`    // set on a last message without data, once every chunk was sent
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadVideoResponse) Reset() {
+	*x = DownloadVideoResponse{}
+	mi := &file_media_media_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadVideoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadVideoResponse) ProtoMessage() {}
+
+func (x *DownloadVideoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadVideoResponse.ProtoReflect.Descriptor instead.
+func (*DownloadVideoResponse) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DownloadVideoResponse) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *DownloadVideoResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *DownloadVideoResponse) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *DownloadVideoResponse) GetMetadata() *VideoMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *DownloadVideoResponse) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *DownloadVideoResponse) GetStats() *TransferStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+type WatchProgressRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchProgressRequest) Reset() {
+	*x = WatchProgressRequest{}
+	mi := &file_media_media_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchProgressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchProgressRequest) ProtoMessage() {}
+
+func (x *WatchProgressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchProgressRequest.ProtoReflect.Descriptor instead.
+func (*WatchProgressRequest) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *WatchProgressRequest) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+type ProgressEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	State         VideoState             `protobuf:"varint,2,opt,name=state,proto3,enum=media.VideoState" json:"state,omitempty"`
+	BytesReceived int64                  `protobuf:"varint,3,opt,name=bytes_received,json=bytesReceived,proto3" json:"bytes_received,omitempty"`
+	TotalBytes    int64                  `protobuf:"varint,4,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"` // 0 when the client did not announce a size
+	Percent       float64                `protobuf:"fixed64,5,opt,name=percent,proto3" json:"percent,omitempty"`
+	Timestamp     int64                  `protobuf:"varint,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// heartbeat is set on events sent while nothing happens, which repeat the
+	// last progress, so that idle watchers are not taken for dead
+	Heartbeat     bool `protobuf:"varint,7,opt,name=heartbeat,proto3" json:"heartbeat,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProgressEvent) Reset() {
+	*x = ProgressEvent{}
+	mi := &file_media_media_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProgressEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProgressEvent) ProtoMessage() {}
+
+func (x *ProgressEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProgressEvent.ProtoReflect.Descriptor instead.
+func (*ProgressEvent) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ProgressEvent) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *ProgressEvent) GetState() VideoState {
+	if x != nil {
+		return x.State
+	}
+	return VideoState_VIDEO_STATE_UNSPECIFIED
+}
+
+func (x *ProgressEvent) GetBytesReceived() int64 {
+	if x != nil {
+		return x.BytesReceived
+	}
+	return 0
+}
+
+func (x *ProgressEvent) GetTotalBytes() int64 {
+	if x != nil {
+		return x.TotalBytes
+	}
+	return 0
+}
+
+func (x *ProgressEvent) GetPercent() float64 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
+func (x *ProgressEvent) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *ProgressEvent) GetHeartbeat() bool {
+	if x != nil {
+		return x.Heartbeat
+	}
+	return false
+}
+
+type ListVideosRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Uploader string                 `protobuf:"bytes,1,opt,name=uploader,proto3" json:"uploader,omitempty"` // uploader ID or name, optional
+	Since    int64                  `protobuf:"varint,2,opt,name=since,proto3" json:"since,omitempty"`      // only videos uploaded at or after this Unix time, optional
+	Tag      string                 `protobuf:"bytes,3,opt,name=tag,proto3" json:"tag,omitempty"`           // only videos carrying this tag, optional
+	// locale picks the language of titles and descriptions, as a language tag
+	// or an Accept-Language list. Without it the accept-language metadata,
+	// i.e. the Accept-Language header through the gateway, is used.
+	Locale        string `protobuf:"bytes,4,opt,name=locale,proto3" json:"locale,omitempty"`
+	PageSize      int32  `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // defaults to 50, at most 500
+	PageToken     string `protobuf:"bytes,6,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // next_page_token of the previous page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListVideosRequest) Reset() {
+	*x = ListVideosRequest{}
+	mi := &file_media_media_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListVideosRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVideosRequest) ProtoMessage() {}
+
+func (x *ListVideosRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVideosRequest.ProtoReflect.Descriptor instead.
+func (*ListVideosRequest) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListVideosRequest) GetUploader() string {
+	if x != nil {
+		return x.Uploader
+	}
+	return ""
+}
+
+func (x *ListVideosRequest) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+func (x *ListVideosRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *ListVideosRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *ListVideosRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListVideosRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type VideoSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	Metadata      *VideoMetadata         `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VideoSummary) Reset() {
+	*x = VideoSummary{}
+	mi := &file_media_media_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VideoSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VideoSummary) ProtoMessage() {}
+
+func (x *VideoSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VideoSummary.ProtoReflect.Descriptor instead.
+func (*VideoSummary) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *VideoSummary) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *VideoSummary) GetMetadata() *VideoMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type ListVideosResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Videos        []*VideoSummary        `protobuf:"bytes,1,rep,name=videos,proto3" json:"videos,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // empty on the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListVideosResponse) Reset() {
+	*x = ListVideosResponse{}
+	mi := &file_media_media_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListVideosResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVideosResponse) ProtoMessage() {}
+
+func (x *ListVideosResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVideosResponse.ProtoReflect.Descriptor instead.
+func (*ListVideosResponse) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListVideosResponse) GetVideos() []*VideoSummary {
+	if x != nil {
+		return x.Videos
+	}
+	return nil
+}
+
+func (x *ListVideosResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type GetVideoMetadataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	Locale        string                 `protobuf:"bytes,2,opt,name=locale,proto3" json:"locale,omitempty"` // as in ListVideosRequest
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVideoMetadataRequest) Reset() {
+	*x = GetVideoMetadataRequest{}
+	mi := &file_media_media_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVideoMetadataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVideoMetadataRequest) ProtoMessage() {}
+
+func (x *GetVideoMetadataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVideoMetadataRequest.ProtoReflect.Descriptor instead.
+func (*GetVideoMetadataRequest) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetVideoMetadataRequest) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *GetVideoMetadataRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+type GetVideoMetadataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	Metadata      *VideoMetadata         `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVideoMetadataResponse) Reset() {
+	*x = GetVideoMetadataResponse{}
+	mi := &file_media_media_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVideoMetadataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVideoMetadataResponse) ProtoMessage() {}
+
+func (x *GetVideoMetadataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVideoMetadataResponse.ProtoReflect.Descriptor instead.
+func (*GetVideoMetadataResponse) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetVideoMetadataResponse) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *GetVideoMetadataResponse) GetMetadata() *VideoMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type DeleteVideoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteVideoRequest) Reset() {
+	*x = DeleteVideoRequest{}
+	mi := &file_media_media_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteVideoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteVideoRequest) ProtoMessage() {}
+
+func (x *DeleteVideoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteVideoRequest.ProtoReflect.Descriptor instead.
+func (*DeleteVideoRequest) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DeleteVideoRequest) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+type DeleteVideoResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteVideoResponse) Reset() {
+	*x = DeleteVideoResponse{}
+	mi := &file_media_media_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteVideoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteVideoResponse) ProtoMessage() {}
+
+func (x *DeleteVideoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteVideoResponse.ProtoReflect.Descriptor instead.
+func (*DeleteVideoResponse) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DeleteVideoResponse) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+type UploadSession struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	UploadId              string                 `protobuf:"bytes,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	VideoId               string                 `protobuf:"bytes,2,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	TotalSize             int64                  `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	CommittedBytes        int64                  `protobuf:"varint,4,opt,name=committed_bytes,json=committedBytes,proto3" json:"committed_bytes,omitempty"` // bytes stored so far; resume sending from this offset
+	ExpiresAt             int64                  `protobuf:"varint,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	MaxVideoSize          int64                  `protobuf:"varint,6,opt,name=max_video_size,json=maxVideoSize,proto3" json:"max_video_size,omitempty"`                            // largest video the server accepts in bytes; 0 means no limit
+	StreamDeadlineSeconds int64                  `protobuf:"varint,7,opt,name=stream_deadline_seconds,json=streamDeadlineSeconds,proto3" json:"stream_deadline_seconds,omitempty"` // granted deadline of every UploadVideo stream of the session; 0 means none
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *UploadSession) Reset() {
+	*x = UploadSession{}
+	mi := &file_media_media_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadSession) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadSession) ProtoMessage() {}
+
+func (x *UploadSession) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadSession.ProtoReflect.Descriptor instead.
+func (*UploadSession) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *UploadSession) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+func (x *UploadSession) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *UploadSession) GetTotalSize() int64 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+func (x *UploadSession) GetCommittedBytes() int64 {
+	if x != nil {
+		return x.CommittedBytes
+	}
+	return 0
+}
+
+func (x *UploadSession) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *UploadSession) GetMaxVideoSize() int64 {
+	if x != nil {
+		return x.MaxVideoSize
+	}
+	return 0
+}
+
+func (x *UploadSession) GetStreamDeadlineSeconds() int64 {
+	if x != nil {
+		return x.StreamDeadlineSeconds
+	}
+	return 0
+}
+
+type GetUploadLimitsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUploadLimitsRequest) Reset() {
+	*x = GetUploadLimitsRequest{}
+	mi := &file_media_media_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUploadLimitsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUploadLimitsRequest) ProtoMessage() {}
+
+func (x *GetUploadLimitsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUploadLimitsRequest.ProtoReflect.Descriptor instead.
+func (*GetUploadLimitsRequest) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{17}
+}
+
+type GetUploadLimitsResponse struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	UserId                   string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                                                             // the caller, whose token the server accepted
+	MaxVideoSize             int64                  `protobuf:"varint,2,opt,name=max_video_size,json=maxVideoSize,proto3" json:"max_video_size,omitempty"`                                        // largest video in bytes; 0 means no limit
+	MaxMessageSize           int64                  `protobuf:"varint,3,opt,name=max_message_size,json=maxMessageSize,proto3" json:"max_message_size,omitempty"`                                  // largest UploadVideoRequest the server receives in bytes, so a chunk must be smaller
+	StreamMaxInFlight        int64                  `protobuf:"varint,4,opt,name=stream_max_in_flight,json=streamMaxInFlight,proto3" json:"stream_max_in_flight,omitempty"`                       // largest upload without a session in bytes; 0 means no limit
+	MemoryBudget             int64                  `protobuf:"varint,5,opt,name=memory_budget,json=memoryBudget,proto3" json:"memory_budget,omitempty"`                                          // bytes of uploads and videos the server holds at most; 0 means no budget
+	MemoryAvailable          int64                  `protobuf:"varint,6,opt,name=memory_available,json=memoryAvailable,proto3" json:"memory_available,omitempty"`                                 // bytes left of memory_budget, which a new upload session takes in full
+	StorageDegraded          bool                   `protobuf:"varint,7,opt,name=storage_degraded,json=storageDegraded,proto3" json:"storage_degraded,omitempty"`                                 // writes of video bytes are failing, see the media.storage health check
+	SessionIdleSeconds       int64                  `protobuf:"varint,8,opt,name=session_idle_seconds,json=sessionIdleSeconds,proto3" json:"session_idle_seconds,omitempty"`                      // how long an upload session is kept without a chunk
+	VideoVersioning          bool                   `protobuf:"varint,9,opt,name=video_versioning,json=videoVersioning,proto3" json:"video_versioning,omitempty"`                                 // uploading to the ID of one of the caller's videos stores its next version
+	MaxStreamDeadlineSeconds int64                  `protobuf:"varint,10,opt,name=max_stream_deadline_seconds,json=maxStreamDeadlineSeconds,proto3" json:"max_stream_deadline_seconds,omitempty"` // longest stream_deadline_seconds an upload session is granted; 0 means none
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *GetUploadLimitsResponse) Reset() {
+	*x = GetUploadLimitsResponse{}
+	mi := &file_media_media_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUploadLimitsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUploadLimitsResponse) ProtoMessage() {}
+
+func (x *GetUploadLimitsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUploadLimitsResponse.ProtoReflect.Descriptor instead.
+func (*GetUploadLimitsResponse) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetUploadLimitsResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetUploadLimitsResponse) GetMaxVideoSize() int64 {
+	if x != nil {
+		return x.MaxVideoSize
+	}
+	return 0
+}
+
+func (x *GetUploadLimitsResponse) GetMaxMessageSize() int64 {
+	if x != nil {
+		return x.MaxMessageSize
+	}
+	return 0
+}
+
+func (x *GetUploadLimitsResponse) GetStreamMaxInFlight() int64 {
+	if x != nil {
+		return x.StreamMaxInFlight
+	}
+	return 0
+}
+
+func (x *GetUploadLimitsResponse) GetMemoryBudget() int64 {
+	if x != nil {
+		return x.MemoryBudget
+	}
+	return 0
+}
+
+func (x *GetUploadLimitsResponse) GetMemoryAvailable() int64 {
+	if x != nil {
+		return x.MemoryAvailable
+	}
+	return 0
+}
+
+func (x *GetUploadLimitsResponse) GetStorageDegraded() bool {
+	if x != nil {
+		return x.StorageDegraded
+	}
+	return false
+}
+
+func (x *GetUploadLimitsResponse) GetSessionIdleSeconds() int64 {
+	if x != nil {
+		return x.SessionIdleSeconds
+	}
+	return 0
+}
+
+func (x *GetUploadLimitsResponse) GetVideoVersioning() bool {
+	if x != nil {
+		return x.VideoVersioning
+	}
+	return false
+}
+
+func (x *GetUploadLimitsResponse) GetMaxStreamDeadlineSeconds() int64 {
+	if x != nil {
+		return x.MaxStreamDeadlineSeconds
+	}
+	return 0
+}
+
+type CreateUploadSessionRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	VideoId      string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	TotalSize    int64                  `protobuf:"varint,2,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	Tags         []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	Titles       map[string]string      `protobuf:"bytes,4,rep,name=titles,proto3" json:"titles,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`             // by BCP 47 language tag
+	Descriptions map[string]string      `protobuf:"bytes,5,rep,name=descriptions,proto3" json:"descriptions,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // by BCP 47 language tag
+	Overwrite    bool                   `protobuf:"varint,6,opt,name=overwrite,proto3" json:"overwrite,omitempty"`                                                                                // replace a video of the caller already stored as video_id
+	FileName     string                 `protobuf:"bytes,7,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`                                                                   // see UploadVideoRequest.file_name
+	// stream_deadline_seconds asks how long every UploadVideo stream of the
+	// session may run. The server grants at most max_stream_deadline_seconds
+	// of GetUploadLimitsResponse and ends a stream that runs longer with
+	// DEADLINE_EXCEEDED once its last chunk is committed, so that the client
+	// resumes on a new stream.
+	StreamDeadlineSeconds int64 `protobuf:"varint,8,opt,name=stream_deadline_seconds,json=streamDeadlineSeconds,proto3" json:"stream_deadline_seconds,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *CreateUploadSessionRequest) Reset() {
+	*x = CreateUploadSessionRequest{}
+	mi := &file_media_media_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateUploadSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateUploadSessionRequest) ProtoMessage() {}
+
+func (x *CreateUploadSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateUploadSessionRequest.ProtoReflect.Descriptor instead.
+func (*CreateUploadSessionRequest) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CreateUploadSessionRequest) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *CreateUploadSessionRequest) GetTotalSize() int64 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+func (x *CreateUploadSessionRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *CreateUploadSessionRequest) GetTitles() map[string]string {
+	if x != nil {
+		return x.Titles
+	}
+	return nil
+}
+
+func (x *CreateUploadSessionRequest) GetDescriptions() map[string]string {
+	if x != nil {
+		return x.Descriptions
+	}
+	return nil
+}
+
+func (x *CreateUploadSessionRequest) GetOverwrite() bool {
+	if x != nil {
+		return x.Overwrite
+	}
+	return false
+}
+
+func (x *CreateUploadSessionRequest) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *CreateUploadSessionRequest) GetStreamDeadlineSeconds() int64 {
+	if x != nil {
+		return x.StreamDeadlineSeconds
+	}
+	return 0
+}
+
+type CreateUploadSessionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Session       *UploadSession         `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateUploadSessionResponse) Reset() {
+	*x = CreateUploadSessionResponse{}
+	mi := &file_media_media_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateUploadSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateUploadSessionResponse) ProtoMessage() {}
+
+func (x *CreateUploadSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateUploadSessionResponse.ProtoReflect.Descriptor instead.
+func (*CreateUploadSessionResponse) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CreateUploadSessionResponse) GetSession() *UploadSession {
+	if x != nil {
+		return x.Session
+	}
+	return nil
+}
+
+type GetUploadSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UploadId      string                 `protobuf:"bytes,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUploadSessionRequest) Reset() {
+	*x = GetUploadSessionRequest{}
+	mi := &file_media_media_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUploadSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUploadSessionRequest) ProtoMessage() {}
+
+func (x *GetUploadSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUploadSessionRequest.ProtoReflect.Descriptor instead.
+func (*GetUploadSessionRequest) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetUploadSessionRequest) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+type GetUploadSessionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Session       *UploadSession         `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUploadSessionResponse) Reset() {
+	*x = GetUploadSessionResponse{}
+	mi := &file_media_media_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUploadSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUploadSessionResponse) ProtoMessage() {}
+
+func (x *GetUploadSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUploadSessionResponse.ProtoReflect.Descriptor instead.
+func (*GetUploadSessionResponse) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetUploadSessionResponse) GetSession() *UploadSession {
+	if x != nil {
+		return x.Session
+	}
+	return nil
+}
+
+type AbortUploadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UploadId      string                 `protobuf:"bytes,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AbortUploadRequest) Reset() {
+	*x = AbortUploadRequest{}
+	mi := &file_media_media_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AbortUploadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbortUploadRequest) ProtoMessage() {}
+
+func (x *AbortUploadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbortUploadRequest.ProtoReflect.Descriptor instead.
+func (*AbortUploadRequest) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *AbortUploadRequest) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+type AbortUploadResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UploadId       string                 `protobuf:"bytes,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	DiscardedBytes int64                  `protobuf:"varint,2,opt,name=discarded_bytes,json=discardedBytes,proto3" json:"discarded_bytes,omitempty"` // committed bytes that were dropped
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AbortUploadResponse) Reset() {
+	*x = AbortUploadResponse{}
+	mi := &file_media_media_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AbortUploadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbortUploadResponse) ProtoMessage() {}
+
+func (x *AbortUploadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbortUploadResponse.ProtoReflect.Descriptor instead.
+func (*AbortUploadResponse) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *AbortUploadResponse) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+func (x *AbortUploadResponse) GetDiscardedBytes() int64 {
+	if x != nil {
+		return x.DiscardedBytes
+	}
+	return 0
+}
+
+type Playlist struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlaylistId    string                 `protobuf:"bytes,1,opt,name=playlist_id,json=playlistId,proto3" json:"playlist_id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	OwnerId       string                 `protobuf:"bytes,4,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	Videos        []*VideoSummary        `protobuf:"bytes,5,rep,name=videos,proto3" json:"videos,omitempty"` // in playback order
+	CreatedAt     int64                  `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     int64                  `protobuf:"varint,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Playlist) Reset() {
+	*x = Playlist{}
+	mi := &file_media_media_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Playlist) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Playlist) ProtoMessage() {}
+
+func (x *Playlist) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Playlist.ProtoReflect.Descriptor instead.
+func (*Playlist) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *Playlist) GetPlaylistId() string {
+	if x != nil {
+		return x.PlaylistId
+	}
+	return ""
+}
+
+func (x *Playlist) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Playlist) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Playlist) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *Playlist) GetVideos() []*VideoSummary {
+	if x != nil {
+		return x.Videos
+	}
+	return nil
+}
+
+func (x *Playlist) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Playlist) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+type CreatePlaylistRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	VideoIds      []string               `protobuf:"bytes,3,rep,name=video_ids,json=videoIds,proto3" json:"video_ids,omitempty"` // initial videos, optional
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePlaylistRequest) Reset() {
+	*x = CreatePlaylistRequest{}
+	mi := &file_media_media_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePlaylistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePlaylistRequest) ProtoMessage() {}
+
+func (x *CreatePlaylistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePlaylistRequest.ProtoReflect.Descriptor instead.
+func (*CreatePlaylistRequest) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *CreatePlaylistRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreatePlaylistRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreatePlaylistRequest) GetVideoIds() []string {
+	if x != nil {
+		return x.VideoIds
+	}
+	return nil
+}
+
+type CreatePlaylistResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Playlist      *Playlist              `protobuf:"bytes,1,opt,name=playlist,proto3" json:"playlist,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePlaylistResponse) Reset() {
+	*x = CreatePlaylistResponse{}
+	mi := &file_media_media_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePlaylistResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePlaylistResponse) ProtoMessage() {}
+
+func (x *CreatePlaylistResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePlaylistResponse.ProtoReflect.Descriptor instead.
+func (*CreatePlaylistResponse) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CreatePlaylistResponse) GetPlaylist() *Playlist {
+	if x != nil {
+		return x.Playlist
+	}
+	return nil
+}
+
+type AddToPlaylistRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlaylistId    string                 `protobuf:"bytes,1,opt,name=playlist_id,json=playlistId,proto3" json:"playlist_id,omitempty"`
+	VideoId       string                 `protobuf:"bytes,2,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	Position      int32                  `protobuf:"varint,3,opt,name=position,proto3" json:"position,omitempty"` // 1-based position to insert at; 0 appends
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddToPlaylistRequest) Reset() {
+	*x = AddToPlaylistRequest{}
+	mi := &file_media_media_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddToPlaylistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddToPlaylistRequest) ProtoMessage() {}
+
+func (x *AddToPlaylistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddToPlaylistRequest.ProtoReflect.Descriptor instead.
+func (*AddToPlaylistRequest) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *AddToPlaylistRequest) GetPlaylistId() string {
+	if x != nil {
+		return x.PlaylistId
+	}
+	return ""
+}
+
+func (x *AddToPlaylistRequest) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *AddToPlaylistRequest) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+type AddToPlaylistResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Playlist      *Playlist              `protobuf:"bytes,1,opt,name=playlist,proto3" json:"playlist,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddToPlaylistResponse) Reset() {
+	*x = AddToPlaylistResponse{}
+	mi := &file_media_media_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddToPlaylistResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddToPlaylistResponse) ProtoMessage() {}
+
+func (x *AddToPlaylistResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddToPlaylistResponse.ProtoReflect.Descriptor instead.
+func (*AddToPlaylistResponse) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *AddToPlaylistResponse) GetPlaylist() *Playlist {
+	if x != nil {
+		return x.Playlist
+	}
+	return nil
+}
+
+type ReorderPlaylistRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlaylistId    string                 `protobuf:"bytes,1,opt,name=playlist_id,json=playlistId,proto3" json:"playlist_id,omitempty"`
+	VideoIds      []string               `protobuf:"bytes,2,rep,name=video_ids,json=videoIds,proto3" json:"video_ids,omitempty"` // every video of the playlist, in the new order
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReorderPlaylistRequest) Reset() {
+	*x = ReorderPlaylistRequest{}
+	mi := &file_media_media_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReorderPlaylistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReorderPlaylistRequest) ProtoMessage() {}
+
+func (x *ReorderPlaylistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReorderPlaylistRequest.ProtoReflect.Descriptor instead.
+func (*ReorderPlaylistRequest) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ReorderPlaylistRequest) GetPlaylistId() string {
+	if x != nil {
+		return x.PlaylistId
+	}
+	return ""
+}
+
+func (x *ReorderPlaylistRequest) GetVideoIds() []string {
+	if x != nil {
+		return x.VideoIds
+	}
+	return nil
+}
+
+type ReorderPlaylistResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Playlist      *Playlist              `protobuf:"bytes,1,opt,name=playlist,proto3" json:"playlist,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReorderPlaylistResponse) Reset() {
+	*x = ReorderPlaylistResponse{}
+	mi := &file_media_media_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReorderPlaylistResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReorderPlaylistResponse) ProtoMessage() {}
+
+func (x *ReorderPlaylistResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReorderPlaylistResponse.ProtoReflect.Descriptor instead.
+func (*ReorderPlaylistResponse) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ReorderPlaylistResponse) GetPlaylist() *Playlist {
+	if x != nil {
+		return x.Playlist
+	}
+	return nil
+}
+
+type GetPlaylistRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlaylistId    string                 `protobuf:"bytes,1,opt,name=playlist_id,json=playlistId,proto3" json:"playlist_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPlaylistRequest) Reset() {
+	*x = GetPlaylistRequest{}
+	mi := &file_media_media_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPlaylistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPlaylistRequest) ProtoMessage() {}
+
+func (x *GetPlaylistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPlaylistRequest.ProtoReflect.Descriptor instead.
+func (*GetPlaylistRequest) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetPlaylistRequest) GetPlaylistId() string {
+	if x != nil {
+		return x.PlaylistId
+	}
+	return ""
+}
+
+type GetPlaylistResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Playlist      *Playlist              `protobuf:"bytes,1,opt,name=playlist,proto3" json:"playlist,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPlaylistResponse) Reset() {
+	*x = GetPlaylistResponse{}
+	mi := &file_media_media_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPlaylistResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPlaylistResponse) ProtoMessage() {}
+
+func (x *GetPlaylistResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPlaylistResponse.ProtoReflect.Descriptor instead.
+func (*GetPlaylistResponse) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetPlaylistResponse) GetPlaylist() *Playlist {
+	if x != nil {
+		return x.Playlist
+	}
+	return nil
+}
+
+type Channel struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ChannelId         string                 `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	Name              string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description       string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	OwnerId           string                 `protobuf:"bytes,4,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"` // empty for tenant-owned channels
+	Tenant            string                 `protobuf:"bytes,5,opt,name=tenant,proto3" json:"tenant,omitempty"`                  // set for tenant-owned channels
+	DefaultVisibility Visibility             `protobuf:"varint,6,opt,name=default_visibility,json=defaultVisibility,proto3,enum=media.Visibility" json:"default_visibility,omitempty"`
+	CreatedAt         int64                  `protobuf:"varint,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Channel) Reset() {
+	*x = Channel{}
+	mi := &file_media_media_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Channel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Channel) ProtoMessage() {}
+
+func (x *Channel) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Channel.ProtoReflect.Descriptor instead.
+func (*Channel) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *Channel) GetChannelId() string {
+	if x != nil {
+		return x.ChannelId
+	}
+	return ""
+}
+
+func (x *Channel) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Channel) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Channel) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *Channel) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *Channel) GetDefaultVisibility() Visibility {
+	if x != nil {
+		return x.DefaultVisibility
+	}
+	return Visibility_VISIBILITY_UNSPECIFIED
+}
+
+func (x *Channel) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type CreateChannelRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Name              string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description       string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	DefaultVisibility Visibility             `protobuf:"varint,3,opt,name=default_visibility,json=defaultVisibility,proto3,enum=media.Visibility" json:"default_visibility,omitempty"` // unspecified means public
+	TenantOwned       bool                   `protobuf:"varint,4,opt,name=tenant_owned,json=tenantOwned,proto3" json:"tenant_owned,omitempty"`                                         // owned by the caller's tenant instead of the caller
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CreateChannelRequest) Reset() {
+	*x = CreateChannelRequest{}
+	mi := &file_media_media_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateChannelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateChannelRequest) ProtoMessage() {}
+
+func (x *CreateChannelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateChannelRequest.ProtoReflect.Descriptor instead.
+func (*CreateChannelRequest) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *CreateChannelRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateChannelRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateChannelRequest) GetDefaultVisibility() Visibility {
+	if x != nil {
+		return x.DefaultVisibility
+	}
+	return Visibility_VISIBILITY_UNSPECIFIED
+}
+
+func (x *CreateChannelRequest) GetTenantOwned() bool {
+	if x != nil {
+		return x.TenantOwned
+	}
+	return false
+}
+
+type CreateChannelResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Channel       *Channel               `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateChannelResponse) Reset() {
+	*x = CreateChannelResponse{}
+	mi := &file_media_media_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateChannelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateChannelResponse) ProtoMessage() {}
+
+func (x *CreateChannelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateChannelResponse.ProtoReflect.Descriptor instead.
+func (*CreateChannelResponse) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *CreateChannelResponse) GetChannel() *Channel {
+	if x != nil {
+		return x.Channel
+	}
+	return nil
+}
+
+type AssignVideoToChannelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChannelId     string                 `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	VideoId       string                 `protobuf:"bytes,2,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignVideoToChannelRequest) Reset() {
+	*x = AssignVideoToChannelRequest{}
+	mi := &file_media_media_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignVideoToChannelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignVideoToChannelRequest) ProtoMessage() {}
+
+func (x *AssignVideoToChannelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignVideoToChannelRequest.ProtoReflect.Descriptor instead.
+func (*AssignVideoToChannelRequest) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *AssignVideoToChannelRequest) GetChannelId() string {
+	if x != nil {
+		return x.ChannelId
+	}
+	return ""
+}
+
+func (x *AssignVideoToChannelRequest) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+type AssignVideoToChannelResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	Metadata      *VideoMetadata         `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignVideoToChannelResponse) Reset() {
+	*x = AssignVideoToChannelResponse{}
+	mi := &file_media_media_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignVideoToChannelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignVideoToChannelResponse) ProtoMessage() {}
+
+func (x *AssignVideoToChannelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignVideoToChannelResponse.ProtoReflect.Descriptor instead.
+func (*AssignVideoToChannelResponse) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *AssignVideoToChannelResponse) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *AssignVideoToChannelResponse) GetMetadata() *VideoMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type ListPublicChannelsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPublicChannelsRequest) Reset() {
+	*x = ListPublicChannelsRequest{}
+	mi := &file_media_media_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPublicChannelsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPublicChannelsRequest) ProtoMessage() {}
+
+func (x *ListPublicChannelsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPublicChannelsRequest.ProtoReflect.Descriptor instead.
+func (*ListPublicChannelsRequest) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{39}
+}
+
+type PublicChannel struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Channel       *Channel               `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	Videos        []*VideoSummary        `protobuf:"bytes,2,rep,name=videos,proto3" json:"videos,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublicChannel) Reset() {
+	*x = PublicChannel{}
+	mi := &file_media_media_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublicChannel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublicChannel) ProtoMessage() {}
+
+func (x *PublicChannel) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublicChannel.ProtoReflect.Descriptor instead.
+func (*PublicChannel) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *PublicChannel) GetChannel() *Channel {
+	if x != nil {
+		return x.Channel
+	}
+	return nil
+}
+
+func (x *PublicChannel) GetVideos() []*VideoSummary {
+	if x != nil {
+		return x.Videos
+	}
+	return nil
+}
+
+type ListPublicChannelsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Channels      []*PublicChannel       `protobuf:"bytes,1,rep,name=channels,proto3" json:"channels,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPublicChannelsResponse) Reset() {
+	*x = ListPublicChannelsResponse{}
+	mi := &file_media_media_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPublicChannelsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPublicChannelsResponse) ProtoMessage() {}
+
+func (x *ListPublicChannelsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPublicChannelsResponse.ProtoReflect.Descriptor instead.
+func (*ListPublicChannelsResponse) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ListPublicChannelsResponse) GetChannels() []*PublicChannel {
+	if x != nil {
+		return x.Channels
+	}
+	return nil
+}
+
+type LikeVideoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LikeVideoRequest) Reset() {
+	*x = LikeVideoRequest{}
+	mi := &file_media_media_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LikeVideoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LikeVideoRequest) ProtoMessage() {}
+
+func (x *LikeVideoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LikeVideoRequest.ProtoReflect.Descriptor instead.
+func (*LikeVideoRequest) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *LikeVideoRequest) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+type LikeVideoResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	LikeCount     int64                  `protobuf:"varint,2,opt,name=like_count,json=likeCount,proto3" json:"like_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LikeVideoResponse) Reset() {
+	*x = LikeVideoResponse{}
+	mi := &file_media_media_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LikeVideoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LikeVideoResponse) ProtoMessage() {}
+
+func (x *LikeVideoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LikeVideoResponse.ProtoReflect.Descriptor instead.
+func (*LikeVideoResponse) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *LikeVideoResponse) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *LikeVideoResponse) GetLikeCount() int64 {
+	if x != nil {
+		return x.LikeCount
+	}
+	return 0
+}
+
+type UnlikeVideoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlikeVideoRequest) Reset() {
+	*x = UnlikeVideoRequest{}
+	mi := &file_media_media_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlikeVideoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlikeVideoRequest) ProtoMessage() {}
+
+func (x *UnlikeVideoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlikeVideoRequest.ProtoReflect.Descriptor instead.
+func (*UnlikeVideoRequest) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *UnlikeVideoRequest) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+type UnlikeVideoResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	LikeCount     int64                  `protobuf:"varint,2,opt,name=like_count,json=likeCount,proto3" json:"like_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlikeVideoResponse) Reset() {
+	*x = UnlikeVideoResponse{}
+	mi := &file_media_media_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlikeVideoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlikeVideoResponse) ProtoMessage() {}
+
+func (x *UnlikeVideoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlikeVideoResponse.ProtoReflect.Descriptor instead.
+func (*UnlikeVideoResponse) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *UnlikeVideoResponse) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *UnlikeVideoResponse) GetLikeCount() int64 {
+	if x != nil {
+		return x.LikeCount
+	}
+	return 0
+}
+
+type ListFavoritesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // defaults to 50, at most 500
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // next_page_token of the previous page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFavoritesRequest) Reset() {
+	*x = ListFavoritesRequest{}
+	mi := &file_media_media_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFavoritesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFavoritesRequest) ProtoMessage() {}
+
+func (x *ListFavoritesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFavoritesRequest.ProtoReflect.Descriptor instead.
+func (*ListFavoritesRequest) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ListFavoritesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListFavoritesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ShareLink struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	VideoId       string                 `protobuf:"bytes,2,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	Target        ShareTarget            `protobuf:"varint,3,opt,name=target,proto3,enum=media.ShareTarget" json:"target,omitempty"`
+	OwnerId       string                 `protobuf:"bytes,4,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	Clicks        int64                  `protobuf:"varint,5,opt,name=clicks,proto3" json:"clicks,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt     int64                  `protobuf:"varint,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // 0 if the link does not expire
+	Path          string                 `protobuf:"bytes,8,opt,name=path,proto3" json:"path,omitempty"`                             // the link's path on the gateway, /s/{code}
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareLink) Reset() {
+	*x = ShareLink{}
+	mi := &file_media_media_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareLink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareLink) ProtoMessage() {}
+
+func (x *ShareLink) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareLink.ProtoReflect.Descriptor instead.
+func (*ShareLink) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ShareLink) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ShareLink) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *ShareLink) GetTarget() ShareTarget {
+	if x != nil {
+		return x.Target
+	}
+	return ShareTarget_SHARE_TARGET_UNSPECIFIED
+}
+
+func (x *ShareLink) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *ShareLink) GetClicks() int64 {
+	if x != nil {
+		return x.Clicks
+	}
+	return 0
+}
+
+func (x *ShareLink) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *ShareLink) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *ShareLink) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type CreateShareLinkRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	VideoId          string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	Target           ShareTarget            `protobuf:"varint,2,opt,name=target,proto3,enum=media.ShareTarget" json:"target,omitempty"`
+	ExpiresInSeconds int64                  `protobuf:"varint,3,opt,name=expires_in_seconds,json=expiresInSeconds,proto3" json:"expires_in_seconds,omitempty"` // optional, 0 for a link that does not expire
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CreateShareLinkRequest) Reset() {
+	*x = CreateShareLinkRequest{}
+	mi := &file_media_media_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateShareLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateShareLinkRequest) ProtoMessage() {}
+
+func (x *CreateShareLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateShareLinkRequest.ProtoReflect.Descriptor instead.
+func (*CreateShareLinkRequest) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *CreateShareLinkRequest) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *CreateShareLinkRequest) GetTarget() ShareTarget {
+	if x != nil {
+		return x.Target
+	}
+	return ShareTarget_SHARE_TARGET_UNSPECIFIED
+}
+
+func (x *CreateShareLinkRequest) GetExpiresInSeconds() int64 {
+	if x != nil {
+		return x.ExpiresInSeconds
+	}
+	return 0
+}
+
+type CreateShareLinkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Link          *ShareLink             `protobuf:"bytes,1,opt,name=link,proto3" json:"link,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateShareLinkResponse) Reset() {
+	*x = CreateShareLinkResponse{}
+	mi := &file_media_media_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateShareLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateShareLinkResponse) ProtoMessage() {}
+
+func (x *CreateShareLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateShareLinkResponse.ProtoReflect.Descriptor instead.
+func (*CreateShareLinkResponse) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *CreateShareLinkResponse) GetLink() *ShareLink {
+	if x != nil {
+		return x.Link
+	}
+	return nil
+}
+
+type CreateDownloadLinkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateDownloadLinkRequest) Reset() {
+	*x = CreateDownloadLinkRequest{}
+	mi := &file_media_media_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateDownloadLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateDownloadLinkRequest) ProtoMessage() {}
+
+func (x *CreateDownloadLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateDownloadLinkRequest.ProtoReflect.Descriptor instead.
+func (*CreateDownloadLinkRequest) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *CreateDownloadLinkRequest) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+type CreateDownloadLinkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	ExpiresAt     int64                  `protobuf:"varint,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // Unix time after which the URL stops working
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateDownloadLinkResponse) Reset() {
+	*x = CreateDownloadLinkResponse{}
+	mi := &file_media_media_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateDownloadLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateDownloadLinkResponse) ProtoMessage() {}
+
+func (x *CreateDownloadLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateDownloadLinkResponse.ProtoReflect.Descriptor instead.
+func (*CreateDownloadLinkResponse) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *CreateDownloadLinkResponse) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CreateDownloadLinkResponse) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+type GetShareLinkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetShareLinkRequest) Reset() {
+	*x = GetShareLinkRequest{}
+	mi := &file_media_media_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetShareLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShareLinkRequest) ProtoMessage() {}
+
+func (x *GetShareLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetShareLinkRequest.ProtoReflect.Descriptor instead.
+func (*GetShareLinkRequest) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *GetShareLinkRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type GetShareLinkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Link          *ShareLink             `protobuf:"bytes,1,opt,name=link,proto3" json:"link,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetShareLinkResponse) Reset() {
+	*x = GetShareLinkResponse{}
+	mi := &file_media_media_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetShareLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShareLinkResponse) ProtoMessage() {}
+
+func (x *GetShareLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetShareLinkResponse.ProtoReflect.Descriptor instead.
+func (*GetShareLinkResponse) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *GetShareLinkResponse) GetLink() *ShareLink {
+	if x != nil {
+		return x.Link
+	}
+	return nil
+}
+
+type ResolveShareLinkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveShareLinkRequest) Reset() {
+	*x = ResolveShareLinkRequest{}
+	mi := &file_media_media_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveShareLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveShareLinkRequest) ProtoMessage() {}
+
+func (x *ResolveShareLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveShareLinkRequest.ProtoReflect.Descriptor instead.
+func (*ResolveShareLinkRequest) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ResolveShareLinkRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type ResolveShareLinkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	RedirectUrl   string                 `protobuf:"bytes,2,opt,name=redirect_url,json=redirectUrl,proto3" json:"redirect_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveShareLinkResponse) Reset() {
+	*x = ResolveShareLinkResponse{}
+	mi := &file_media_media_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveShareLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveShareLinkResponse) ProtoMessage() {}
+
+func (x *ResolveShareLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveShareLinkResponse.ProtoReflect.Descriptor instead.
+func (*ResolveShareLinkResponse) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ResolveShareLinkResponse) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *ResolveShareLinkResponse) GetRedirectUrl() string {
+	if x != nil {
+		return x.RedirectUrl
+	}
+	return ""
+}
+
+type SetThumbnailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"` // a JPEG, PNG, GIF or WebP image of at most 2 MiB
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetThumbnailRequest) Reset() {
+	*x = SetThumbnailRequest{}
+	mi := &file_media_media_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetThumbnailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetThumbnailRequest) ProtoMessage() {}
+
+func (x *SetThumbnailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetThumbnailRequest.ProtoReflect.Descriptor instead.
+func (*SetThumbnailRequest) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *SetThumbnailRequest) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *SetThumbnailRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type SetThumbnailResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	ThumbnailUrl  string                 `protobuf:"bytes,2,opt,name=thumbnail_url,json=thumbnailUrl,proto3" json:"thumbnail_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetThumbnailResponse) Reset() {
+	*x = SetThumbnailResponse{}
+	mi := &file_media_media_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetThumbnailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetThumbnailResponse) ProtoMessage() {}
+
+func (x *SetThumbnailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetThumbnailResponse.ProtoReflect.Descriptor instead.
+func (*SetThumbnailResponse) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *SetThumbnailResponse) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *SetThumbnailResponse) GetThumbnailUrl() string {
+	if x != nil {
+		return x.ThumbnailUrl
+	}
+	return ""
+}
+
+type GetThumbnailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetThumbnailRequest) Reset() {
+	*x = GetThumbnailRequest{}
+	mi := &file_media_media_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetThumbnailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetThumbnailRequest) ProtoMessage() {}
+
+func (x *GetThumbnailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetThumbnailRequest.ProtoReflect.Descriptor instead.
+func (*GetThumbnailRequest) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *GetThumbnailRequest) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+type GetThumbnailResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetThumbnailResponse) Reset() {
+	*x = GetThumbnailResponse{}
+	mi := &file_media_media_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetThumbnailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetThumbnailResponse) ProtoMessage() {}
+
+func (x *GetThumbnailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetThumbnailResponse.ProtoReflect.Descriptor instead.
+func (*GetThumbnailResponse) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *GetThumbnailResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *GetThumbnailResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+type SetAvatarRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"` // a JPEG, PNG, GIF or WebP image of at most 1 MiB and 4096x4096 pixels
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetAvatarRequest) Reset() {
+	*x = SetAvatarRequest{}
+	mi := &file_media_media_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetAvatarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetAvatarRequest) ProtoMessage() {}
+
+func (x *SetAvatarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetAvatarRequest.ProtoReflect.Descriptor instead.
+func (*SetAvatarRequest) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *SetAvatarRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type SetAvatarResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AvatarUrl     string                 `protobuf:"bytes,1,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
+	Size          int32                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`                                 // width and height in pixels
+	ContentType   string                 `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"` // JPEG for JPEG images, PNG for the others
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetAvatarResponse) Reset() {
+	*x = SetAvatarResponse{}
+	mi := &file_media_media_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetAvatarResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetAvatarResponse) ProtoMessage() {}
+
+func (x *SetAvatarResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetAvatarResponse.ProtoReflect.Descriptor instead.
+func (*SetAvatarResponse) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *SetAvatarResponse) GetAvatarUrl() string {
+	if x != nil {
+		return x.AvatarUrl
+	}
+	return ""
+}
+
+func (x *SetAvatarResponse) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *SetAvatarResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+type GetAvatarRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAvatarRequest) Reset() {
+	*x = GetAvatarRequest{}
+	mi := &file_media_media_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAvatarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAvatarRequest) ProtoMessage() {}
+
+func (x *GetAvatarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAvatarRequest.ProtoReflect.Descriptor instead.
+func (*GetAvatarRequest) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *GetAvatarRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetAvatarResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAvatarResponse) Reset() {
+	*x = GetAvatarResponse{}
+	mi := &file_media_media_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAvatarResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAvatarResponse) ProtoMessage() {}
+
+func (x *GetAvatarResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAvatarResponse.ProtoReflect.Descriptor instead.
+func (*GetAvatarResponse) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *GetAvatarResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *GetAvatarResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+type ListPublicVideosRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // defaults to 50, at most 500
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // next_page_token of the previous page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPublicVideosRequest) Reset() {
+	*x = ListPublicVideosRequest{}
+	mi := &file_media_media_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPublicVideosRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPublicVideosRequest) ProtoMessage() {}
+
+func (x *ListPublicVideosRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPublicVideosRequest.ProtoReflect.Descriptor instead.
+func (*ListPublicVideosRequest) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *ListPublicVideosRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListPublicVideosRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type PublicVideo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	Metadata      *VideoMetadata         `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	ThumbnailUrl  string                 `protobuf:"bytes,3,opt,name=thumbnail_url,json=thumbnailUrl,proto3" json:"thumbnail_url,omitempty"` // empty if the video has no thumbnail
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublicVideo) Reset() {
+	*x = PublicVideo{}
+	mi := &file_media_media_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublicVideo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublicVideo) ProtoMessage() {}
+
+func (x *PublicVideo) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublicVideo.ProtoReflect.Descriptor instead.
+func (*PublicVideo) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *PublicVideo) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *PublicVideo) GetMetadata() *VideoMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *PublicVideo) GetThumbnailUrl() string {
+	if x != nil {
+		return x.ThumbnailUrl
+	}
+	return ""
+}
+
+type ListPublicVideosResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Videos        []*PublicVideo         `protobuf:"bytes,1,rep,name=videos,proto3" json:"videos,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // empty on the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPublicVideosResponse) Reset() {
+	*x = ListPublicVideosResponse{}
+	mi := &file_media_media_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPublicVideosResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPublicVideosResponse) ProtoMessage() {}
+
+func (x *ListPublicVideosResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPublicVideosResponse.ProtoReflect.Descriptor instead.
+func (*ListPublicVideosResponse) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *ListPublicVideosResponse) GetVideos() []*PublicVideo {
+	if x != nil {
+		return x.Videos
+	}
+	return nil
+}
+
+func (x *ListPublicVideosResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type GetEmbedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEmbedRequest) Reset() {
+	*x = GetEmbedRequest{}
+	mi := &file_media_media_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEmbedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEmbedRequest) ProtoMessage() {}
+
+func (x *GetEmbedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEmbedRequest.ProtoReflect.Descriptor instead.
+func (*GetEmbedRequest) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *GetEmbedRequest) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+type GetHLSPlaylistRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHLSPlaylistRequest) Reset() {
+	*x = GetHLSPlaylistRequest{}
+	mi := &file_media_media_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHLSPlaylistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHLSPlaylistRequest) ProtoMessage() {}
+
+func (x *GetHLSPlaylistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHLSPlaylistRequest.ProtoReflect.Descriptor instead.
+func (*GetHLSPlaylistRequest) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *GetHLSPlaylistRequest) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+type GetHLSPlaylistResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Playlist      string                 `protobuf:"bytes,1,opt,name=playlist,proto3" json:"playlist,omitempty"` // an M3U8 VOD media playlist
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHLSPlaylistResponse) Reset() {
+	*x = GetHLSPlaylistResponse{}
+	mi := &file_media_media_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHLSPlaylistResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHLSPlaylistResponse) ProtoMessage() {}
+
+func (x *GetHLSPlaylistResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHLSPlaylistResponse.ProtoReflect.Descriptor instead.
+func (*GetHLSPlaylistResponse) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *GetHLSPlaylistResponse) GetPlaylist() string {
+	if x != nil {
+		return x.Playlist
+	}
+	return ""
+}
+
+type GetHLSSegmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	Index         int32                  `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"` // 0-based position in the playlist
+	Token         string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`  // the token query parameter of the segment URI
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHLSSegmentRequest) Reset() {
+	*x = GetHLSSegmentRequest{}
+	mi := &file_media_media_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHLSSegmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHLSSegmentRequest) ProtoMessage() {}
+
+func (x *GetHLSSegmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHLSSegmentRequest.ProtoReflect.Descriptor instead.
+func (*GetHLSSegmentRequest) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *GetHLSSegmentRequest) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *GetHLSSegmentRequest) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *GetHLSSegmentRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type GetHLSSegmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"` // MPEG-TS
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHLSSegmentResponse) Reset() {
+	*x = GetHLSSegmentResponse{}
+	mi := &file_media_media_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHLSSegmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHLSSegmentResponse) ProtoMessage() {}
+
+func (x *GetHLSSegmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHLSSegmentResponse.ProtoReflect.Descriptor instead.
+func (*GetHLSSegmentResponse) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *GetHLSSegmentResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type GetEmbedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	Metadata      *VideoMetadata         `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	SourceUrl     string                 `protobuf:"bytes,3,opt,name=source_url,json=sourceUrl,proto3" json:"source_url,omitempty"`          // signed URL of the video for the player
+	Hls           bool                   `protobuf:"varint,4,opt,name=hls,proto3" json:"hls,omitempty"`                                      // source_url is an HLS playlist rather than a plain file
+	ThumbnailUrl  string                 `protobuf:"bytes,5,opt,name=thumbnail_url,json=thumbnailUrl,proto3" json:"thumbnail_url,omitempty"` // empty if the video has no thumbnail
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEmbedResponse) Reset() {
+	*x = GetEmbedResponse{}
+	mi := &file_media_media_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEmbedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEmbedResponse) ProtoMessage() {}
+
+func (x *GetEmbedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEmbedResponse.ProtoReflect.Descriptor instead.
+func (*GetEmbedResponse) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *GetEmbedResponse) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *GetEmbedResponse) GetMetadata() *VideoMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *GetEmbedResponse) GetSourceUrl() string {
+	if x != nil {
+		return x.SourceUrl
+	}
+	return ""
+}
+
+func (x *GetEmbedResponse) GetHls() bool {
+	if x != nil {
+		return x.Hls
+	}
+	return false
+}
+
+func (x *GetEmbedResponse) GetThumbnailUrl() string {
+	if x != nil {
+		return x.ThumbnailUrl
+	}
+	return ""
+}
+
+type ListFavoritesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Videos        []*VideoSummary        `protobuf:"bytes,1,rep,name=videos,proto3" json:"videos,omitempty"`                                      // most recently liked first
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // empty on the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFavoritesResponse) Reset() {
+	*x = ListFavoritesResponse{}
+	mi := &file_media_media_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFavoritesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFavoritesResponse) ProtoMessage() {}
+
+func (x *ListFavoritesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFavoritesResponse.ProtoReflect.Descriptor instead.
+func (*ListFavoritesResponse) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *ListFavoritesResponse) GetVideos() []*VideoSummary {
+	if x != nil {
+		return x.Videos
+	}
+	return nil
+}
+
+func (x *ListFavoritesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// ExportFilter selects the videos the caller can list, as ListVideos does.
+type ExportFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uploader      string                 `protobuf:"bytes,1,opt,name=uploader,proto3" json:"uploader,omitempty"`                    // uploader ID or name, optional
+	Since         int64                  `protobuf:"varint,2,opt,name=since,proto3" json:"since,omitempty"`                         // only videos uploaded at or after this Unix time, optional
+	Tag           string                 `protobuf:"bytes,3,opt,name=tag,proto3" json:"tag,omitempty"`                              // optional
+	ChannelId     string                 `protobuf:"bytes,4,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"` // optional
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportFilter) Reset() {
+	*x = ExportFilter{}
+	mi := &file_media_media_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportFilter) ProtoMessage() {}
+
+func (x *ExportFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportFilter.ProtoReflect.Descriptor instead.
+func (*ExportFilter) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *ExportFilter) GetUploader() string {
+	if x != nil {
+		return x.Uploader
+	}
+	return ""
+}
+
+func (x *ExportFilter) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+func (x *ExportFilter) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *ExportFilter) GetChannelId() string {
+	if x != nil {
+		return x.ChannelId
+	}
+	return ""
+}
+
+type CreateExportRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// video_ids are exported in this order. Without them, the videos matching
+	// filter are, ordered by ID.
+	VideoIds      []string      `protobuf:"bytes,1,rep,name=video_ids,json=videoIds,proto3" json:"video_ids,omitempty"`
+	Filter        *ExportFilter `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	Format        ExportFormat  `protobuf:"varint,3,opt,name=format,proto3,enum=media.ExportFormat" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateExportRequest) Reset() {
+	*x = CreateExportRequest{}
+	mi := &file_media_media_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateExportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateExportRequest) ProtoMessage() {}
+
+func (x *CreateExportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateExportRequest.ProtoReflect.Descriptor instead.
+func (*CreateExportRequest) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *CreateExportRequest) GetVideoIds() []string {
+	if x != nil {
+		return x.VideoIds
+	}
+	return nil
+}
+
+func (x *CreateExportRequest) GetFilter() *ExportFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *CreateExportRequest) GetFormat() ExportFormat {
+	if x != nil {
+		return x.Format
+	}
+	return ExportFormat_EXPORT_FORMAT_UNSPECIFIED
+}
+
+type Export struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExportId      string                 `protobuf:"bytes,1,opt,name=export_id,json=exportId,proto3" json:"export_id,omitempty"`
+	State         ExportState            `protobuf:"varint,2,opt,name=state,proto3,enum=media.ExportState" json:"state,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt     int64                  `protobuf:"varint,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // when the artifact is deleted, once finished
+	Size          int64                  `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`                            // of the artifact, once ready
+	Error         string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`                           // why the export failed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Export) Reset() {
+	*x = Export{}
+	mi := &file_media_media_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Export) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Export) ProtoMessage() {}
+
+func (x *Export) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Export.ProtoReflect.Descriptor instead.
+func (*Export) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *Export) GetExportId() string {
+	if x != nil {
+		return x.ExportId
+	}
+	return ""
+}
+
+func (x *Export) GetState() ExportState {
+	if x != nil {
+		return x.State
+	}
+	return ExportState_EXPORT_STATE_UNSPECIFIED
+}
+
+func (x *Export) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Export) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *Export) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Export) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type CreateExportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Export        *Export                `protobuf:"bytes,1,opt,name=export,proto3" json:"export,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateExportResponse) Reset() {
+	*x = CreateExportResponse{}
+	mi := &file_media_media_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateExportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateExportResponse) ProtoMessage() {}
+
+func (x *CreateExportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
 
-	VideoId  string `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
-	Data     []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
-	Sequence int64  `protobuf:"varint,3,opt,name=sequence,proto3" json:"sequence,omitempty"`
+// Deprecated: Use CreateExportResponse.ProtoReflect.Descriptor instead.
+func (*CreateExportResponse) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{77}
 }
 
-func (x *UploadVideoRequest) Reset() {
-	*x = UploadVideoRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_media_media_proto_msgTypes[0]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *CreateExportResponse) GetExport() *Export {
+	if x != nil {
+		return x.Export
 	}
+	return nil
 }
 
-func (x *UploadVideoRequest) String() string {
+type GetExportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExportId      string                 `protobuf:"bytes,1,opt,name=export_id,json=exportId,proto3" json:"export_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetExportRequest) Reset() {
+	*x = GetExportRequest{}
+	mi := &file_media_media_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetExportRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UploadVideoRequest) ProtoMessage() {}
+func (*GetExportRequest) ProtoMessage() {}
 
-func (x *UploadVideoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_media_media_proto_msgTypes[0]
-	if protoimpl.UnsafeEnabled && x != nil {
+func (x *GetExportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[78]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -58,60 +5079,132 @@ func (x *UploadVideoRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UploadVideoRequest.ProtoReflect.Descriptor instead.
-func (*UploadVideoRequest) Descriptor() ([]byte, []int) {
-	return file_media_media_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use GetExportRequest.ProtoReflect.Descriptor instead.
+func (*GetExportRequest) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{78}
 }
 
-func (x *UploadVideoRequest) GetVideoId() string {
+func (x *GetExportRequest) GetExportId() string {
 	if x != nil {
-		return x.VideoId
+		return x.ExportId
 	}
 	return ""
 }
 
-func (x *UploadVideoRequest) GetData() []byte {
+type GetExportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Export        *Export                `protobuf:"bytes,1,opt,name=export,proto3" json:"export,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetExportResponse) Reset() {
+	*x = GetExportResponse{}
+	mi := &file_media_media_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetExportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetExportResponse) ProtoMessage() {}
+
+func (x *GetExportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[79]
 	if x != nil {
-		return x.Data
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *UploadVideoRequest) GetSequence() int64 {
+// Deprecated: Use GetExportResponse.ProtoReflect.Descriptor instead.
+func (*GetExportResponse) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *GetExportResponse) GetExport() *Export {
 	if x != nil {
-		return x.Sequence
+		return x.Export
 	}
-	return 0
+	return nil
 }
 
-type UploadVideoResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+type DownloadExportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExportId      string                 `protobuf:"bytes,1,opt,name=export_id,json=exportId,proto3" json:"export_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-	VideoId    string         `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
-	TotalBytes int64          `protobuf:"varint,2,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"`
-	Metadata   *VideoMetadata `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
+func (x *DownloadExportRequest) Reset() {
+	*x = DownloadExportRequest{}
+	mi := &file_media_media_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *UploadVideoResponse) Reset() {
-	*x = UploadVideoResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_media_media_proto_msgTypes[1]
+func (x *DownloadExportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadExportRequest) ProtoMessage() {}
+
+func (x *DownloadExportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[80]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
+	return mi.MessageOf(x)
 }
 
-func (x *UploadVideoResponse) String() string {
+// Deprecated: Use DownloadExportRequest.ProtoReflect.Descriptor instead.
+func (*DownloadExportRequest) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *DownloadExportRequest) GetExportId() string {
+	if x != nil {
+		return x.ExportId
+	}
+	return ""
+}
+
+type DownloadExportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileName      string                 `protobuf:"bytes,1,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`          // only in the first message
+	Size          int64                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`                                 // only in the first message
+	ContentType   string                 `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"` // only in the first message
+	Data          []byte                 `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadExportResponse) Reset() {
+	*x = DownloadExportResponse{}
+	mi := &file_media_media_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadExportResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UploadVideoResponse) ProtoMessage() {}
+func (*DownloadExportResponse) ProtoMessage() {}
 
-func (x *UploadVideoResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_media_media_proto_msgTypes[1]
-	if protoimpl.UnsafeEnabled && x != nil {
+func (x *DownloadExportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[81]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -121,58 +5214,66 @@ func (x *UploadVideoResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UploadVideoResponse.ProtoReflect.Descriptor instead.
-func (*UploadVideoResponse) Descriptor() ([]byte, []int) {
-	return file_media_media_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use DownloadExportResponse.ProtoReflect.Descriptor instead.
+func (*DownloadExportResponse) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{81}
 }
 
-func (x *UploadVideoResponse) GetVideoId() string {
+func (x *DownloadExportResponse) GetFileName() string {
 	if x != nil {
-		return x.VideoId
+		return x.FileName
 	}
 	return ""
 }
 
-func (x *UploadVideoResponse) GetTotalBytes() int64 {
+func (x *DownloadExportResponse) GetSize() int64 {
 	if x != nil {
-		return x.TotalBytes
+		return x.Size
 	}
 	return 0
 }
 
-func (x *UploadVideoResponse) GetMetadata() *VideoMetadata {
+func (x *DownloadExportResponse) GetContentType() string {
 	if x != nil {
-		return x.Metadata
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *DownloadExportResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
 	}
 	return nil
 }
 
-type DownloadVideoRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+type ExportedFile struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	Metadata      *VideoMetadata         `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Path          string                 `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`                                        // of the video file in an archive
+	Url           string                 `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`                                          // signed download URL, in a manifest
+	UrlExpiresAt  int64                  `protobuf:"varint,5,opt,name=url_expires_at,json=urlExpiresAt,proto3" json:"url_expires_at,omitempty"` // Unix seconds
 	unknownFields protoimpl.UnknownFields
-
-	VideoId string `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DownloadVideoRequest) Reset() {
-	*x = DownloadVideoRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_media_media_proto_msgTypes[2]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+func (x *ExportedFile) Reset() {
+	*x = ExportedFile{}
+	mi := &file_media_media_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *DownloadVideoRequest) String() string {
+func (x *ExportedFile) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DownloadVideoRequest) ProtoMessage() {}
+func (*ExportedFile) ProtoMessage() {}
 
-func (x *DownloadVideoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_media_media_proto_msgTypes[2]
-	if protoimpl.UnsafeEnabled && x != nil {
+func (x *ExportedFile) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[82]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -182,48 +5283,129 @@ func (x *DownloadVideoRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DownloadVideoRequest.ProtoReflect.Descriptor instead.
-func (*DownloadVideoRequest) Descriptor() ([]byte, []int) {
-	return file_media_media_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use ExportedFile.ProtoReflect.Descriptor instead.
+func (*ExportedFile) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{82}
 }
 
-func (x *DownloadVideoRequest) GetVideoId() string {
+func (x *ExportedFile) GetVideoId() string {
 	if x != nil {
 		return x.VideoId
 	}
 	return ""
 }
 
-type VideoMetadata struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+func (x *ExportedFile) GetMetadata() *VideoMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *ExportedFile) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ExportedFile) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *ExportedFile) GetUrlExpiresAt() int64 {
+	if x != nil {
+		return x.UrlExpiresAt
+	}
+	return 0
+}
+
+// ExportManifest lists the videos of an export. It is the artifact of a
+// manifest export, and the videos.json file of an archive.
+type ExportManifest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Videos        []*ExportedFile        `protobuf:"bytes,1,rep,name=videos,proto3" json:"videos,omitempty"`
 	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-	UploaderId      string `protobuf:"bytes,1,opt,name=uploader_id,json=uploaderId,proto3" json:"uploader_id,omitempty"`
-	UploaderName    string `protobuf:"bytes,2,opt,name=uploader_name,json=uploaderName,proto3" json:"uploader_name,omitempty"`
-	UploadTimestamp int64  `protobuf:"varint,3,opt,name=upload_timestamp,json=uploadTimestamp,proto3" json:"upload_timestamp,omitempty"`
-	FileName        string `protobuf:"bytes,4,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
-	FileSize        int64  `protobuf:"varint,5,opt,name=file_size,json=fileSize,proto3" json:"file_size,omitempty"`
+func (x *ExportManifest) Reset() {
+	*x = ExportManifest{}
+	mi := &file_media_media_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *VideoMetadata) Reset() {
-	*x = VideoMetadata{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_media_media_proto_msgTypes[3]
+func (x *ExportManifest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportManifest) ProtoMessage() {}
+
+func (x *ExportManifest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[83]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
+	return mi.MessageOf(x)
 }
 
-func (x *VideoMetadata) String() string {
+// Deprecated: Use ExportManifest.ProtoReflect.Descriptor instead.
+func (*ExportManifest) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *ExportManifest) GetVideos() []*ExportedFile {
+	if x != nil {
+		return x.Videos
+	}
+	return nil
+}
+
+// TranscodeEvent is the JSON payload of a transcoder callback.
+type TranscodeEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// event_id identifies the event across retries of the callback, which
+	// are processed once.
+	EventId string `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	VideoId string `protobuf:"bytes,2,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	// video_sha256 is the SHA-256 of the transcoded content, optional. Events
+	// about content that was replaced since are ignored.
+	VideoSha256 string         `protobuf:"bytes,3,opt,name=video_sha256,json=videoSha256,proto3" json:"video_sha256,omitempty"`
+	Rendition   string         `protobuf:"bytes,4,opt,name=rendition,proto3" json:"rendition,omitempty"`
+	State       RenditionState `protobuf:"varint,5,opt,name=state,proto3,enum=media.RenditionState" json:"state,omitempty"`
+	Url         string         `protobuf:"bytes,6,opt,name=url,proto3" json:"url,omitempty"`
+	Error       string         `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	// occurred_at orders the events of a rendition, Unix milliseconds. An
+	// event older than the rendition's last update is ignored.
+	OccurredAt    int64 `protobuf:"varint,8,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TranscodeEvent) Reset() {
+	*x = TranscodeEvent{}
+	mi := &file_media_media_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TranscodeEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VideoMetadata) ProtoMessage() {}
+func (*TranscodeEvent) ProtoMessage() {}
 
-func (x *VideoMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_media_media_proto_msgTypes[3]
-	if protoimpl.UnsafeEnabled && x != nil {
+func (x *TranscodeEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[84]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -233,75 +5415,94 @@ func (x *VideoMetadata) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VideoMetadata.ProtoReflect.Descriptor instead.
-func (*VideoMetadata) Descriptor() ([]byte, []int) {
-	return file_media_media_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use TranscodeEvent.ProtoReflect.Descriptor instead.
+func (*TranscodeEvent) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{84}
 }
 
-func (x *VideoMetadata) GetUploaderId() string {
+func (x *TranscodeEvent) GetEventId() string {
 	if x != nil {
-		return x.UploaderId
+		return x.EventId
 	}
 	return ""
 }
 
-func (x *VideoMetadata) GetUploaderName() string {
+func (x *TranscodeEvent) GetVideoId() string {
 	if x != nil {
-		return x.UploaderName
+		return x.VideoId
 	}
 	return ""
 }
 
-func (x *VideoMetadata) GetUploadTimestamp() int64 {
+func (x *TranscodeEvent) GetVideoSha256() string {
 	if x != nil {
-		return x.UploadTimestamp
+		return x.VideoSha256
 	}
-	return 0
+	return ""
 }
 
-func (x *VideoMetadata) GetFileName() string {
+func (x *TranscodeEvent) GetRendition() string {
 	if x != nil {
-		return x.FileName
+		return x.Rendition
 	}
 	return ""
 }
 
-func (x *VideoMetadata) GetFileSize() int64 {
+func (x *TranscodeEvent) GetState() RenditionState {
 	if x != nil {
-		return x.FileSize
+		return x.State
 	}
-	return 0
+	return RenditionState_RENDITION_STATE_UNSPECIFIED
 }
 
-type DownloadVideoResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *TranscodeEvent) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
 
-	VideoId  string         `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
-	Data     []byte         `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
-	Sequence int64          `protobuf:"varint,3,opt,name=sequence,proto3" json:"sequence,omitempty"`
-	Metadata *VideoMetadata `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+func (x *TranscodeEvent) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
 }
 
-func (x *DownloadVideoResponse) Reset() {
-	*x = DownloadVideoResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_media_media_proto_msgTypes[4]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *TranscodeEvent) GetOccurredAt() int64 {
+	if x != nil {
+		return x.OccurredAt
 	}
+	return 0
 }
 
-func (x *DownloadVideoResponse) String() string {
+type ReportTranscodeRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Payload []byte                 `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"` // a TranscodeEvent as JSON, exactly as signed
+	// signature is "t=<unix seconds>,v1=<hex HMAC-SHA256 of t.payload>", the
+	// X-Coscup-Signature header of the callback. Several v1 entries are
+	// accepted while the secret is rotated.
+	Signature     string `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportTranscodeRequest) Reset() {
+	*x = ReportTranscodeRequest{}
+	mi := &file_media_media_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportTranscodeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DownloadVideoResponse) ProtoMessage() {}
+func (*ReportTranscodeRequest) ProtoMessage() {}
 
-func (x *DownloadVideoResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_media_media_proto_msgTypes[4]
-	if protoimpl.UnsafeEnabled && x != nil {
+func (x *ReportTranscodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[85]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -311,136 +5512,836 @@ func (x *DownloadVideoResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DownloadVideoResponse.ProtoReflect.Descriptor instead.
-func (*DownloadVideoResponse) Descriptor() ([]byte, []int) {
-	return file_media_media_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use ReportTranscodeRequest.ProtoReflect.Descriptor instead.
+func (*ReportTranscodeRequest) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{85}
 }
 
-func (x *DownloadVideoResponse) GetVideoId() string {
+func (x *ReportTranscodeRequest) GetPayload() []byte {
 	if x != nil {
-		return x.VideoId
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *ReportTranscodeRequest) GetSignature() string {
+	if x != nil {
+		return x.Signature
 	}
 	return ""
 }
 
-func (x *DownloadVideoResponse) GetData() []byte {
+type ReportTranscodeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Duplicate     bool                   `protobuf:"varint,1,opt,name=duplicate,proto3" json:"duplicate,omitempty"` // the event was processed before
+	Stale         bool                   `protobuf:"varint,2,opt,name=stale,proto3" json:"stale,omitempty"`         // the event was ignored as outdated
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportTranscodeResponse) Reset() {
+	*x = ReportTranscodeResponse{}
+	mi := &file_media_media_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportTranscodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportTranscodeResponse) ProtoMessage() {}
+
+func (x *ReportTranscodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_media_proto_msgTypes[86]
 	if x != nil {
-		return x.Data
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *DownloadVideoResponse) GetSequence() int64 {
+// Deprecated: Use ReportTranscodeResponse.ProtoReflect.Descriptor instead.
+func (*ReportTranscodeResponse) Descriptor() ([]byte, []int) {
+	return file_media_media_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *ReportTranscodeResponse) GetDuplicate() bool {
 	if x != nil {
-		return x.Sequence
+		return x.Duplicate
 	}
-	return 0
+	return false
 }
 
-func (x *DownloadVideoResponse) GetMetadata() *VideoMetadata {
+func (x *ReportTranscodeResponse) GetStale() bool {
 	if x != nil {
-		return x.Metadata
+		return x.Stale
 	}
-	return nil
+	return false
 }
 
 var File_media_media_proto protoreflect.FileDescriptor
 
-var file_media_media_proto_rawDesc = []byte{
-	0x0a, 0x11, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2f, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x12, 0x05, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x5f, 0x0a, 0x12, 0x55, 0x70, 0x6c, 0x6f,
-	0x61, 0x64, 0x56, 0x69, 0x64, 0x65, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19,
-	0x0a, 0x08, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x07, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74,
-	0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1a, 0x0a,
-	0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x22, 0x83, 0x01, 0x0a, 0x13, 0x55, 0x70,
-	0x6c, 0x6f, 0x61, 0x64, 0x56, 0x69, 0x64, 0x65, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x19, 0x0a, 0x08, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b,
-	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x30, 0x0a,
-	0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x14, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x56, 0x69, 0x64, 0x65, 0x6f, 0x4d, 0x65, 0x74,
-	0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22,
-	0x31, 0x0a, 0x14, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x56, 0x69, 0x64, 0x65, 0x6f,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x76, 0x69, 0x64, 0x65, 0x6f,
-	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x69, 0x64, 0x65, 0x6f,
-	0x49, 0x64, 0x22, 0xba, 0x01, 0x0a, 0x0d, 0x56, 0x69, 0x64, 0x65, 0x6f, 0x4d, 0x65, 0x74, 0x61,
-	0x64, 0x61, 0x74, 0x61, 0x12, 0x1f, 0x0a, 0x0b, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72,
-	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x75, 0x70, 0x6c, 0x6f, 0x61,
-	0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x65,
-	0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x75, 0x70,
-	0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x75, 0x70,
-	0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x54, 0x69, 0x6d, 0x65,
-	0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6e, 0x61,
-	0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61,
-	0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x22,
-	0x94, 0x01, 0x0a, 0x15, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x56, 0x69, 0x64, 0x65,
-	0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x76, 0x69, 0x64,
-	0x65, 0x6f, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x69, 0x64,
-	0x65, 0x6f, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75,
-	0x65, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75,
-	0x65, 0x6e, 0x63, 0x65, 0x12, 0x30, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x56,
-	0x69, 0x64, 0x65, 0x6f, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65,
-	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x32, 0xe8, 0x01, 0x0a, 0x0c, 0x4d, 0x65, 0x64, 0x69, 0x61,
-	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x63, 0x0a, 0x0b, 0x55, 0x70, 0x6c, 0x6f, 0x61,
-	0x64, 0x56, 0x69, 0x64, 0x65, 0x6f, 0x12, 0x19, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x55,
-	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x56, 0x69, 0x64, 0x65, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x1a, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64,
-	0x56, 0x69, 0x64, 0x65, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1b, 0x82,
-	0xd3, 0xe4, 0x93, 0x02, 0x15, 0x3a, 0x01, 0x2a, 0x22, 0x10, 0x2f, 0x76, 0x31, 0x2f, 0x76, 0x69,
-	0x64, 0x65, 0x6f, 0x2f, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x28, 0x01, 0x12, 0x73, 0x0a, 0x0d,
-	0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x56, 0x69, 0x64, 0x65, 0x6f, 0x12, 0x1b, 0x2e,
-	0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x56, 0x69,
-	0x64, 0x65, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x6d, 0x65, 0x64,
-	0x69, 0x61, 0x2e, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x56, 0x69, 0x64, 0x65, 0x6f,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x25, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1f,
-	0x12, 0x1d, 0x2f, 0x76, 0x31, 0x2f, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x2f, 0x64, 0x6f, 0x77, 0x6e,
-	0x6c, 0x6f, 0x61, 0x64, 0x2f, 0x7b, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x5f, 0x69, 0x64, 0x7d, 0x30,
-	0x01, 0x42, 0x1e, 0x5a, 0x1c, 0x63, 0x6f, 0x73, 0x63, 0x75, 0x70, 0x32, 0x30, 0x32, 0x35, 0x2f,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x3b, 0x6d, 0x65, 0x64, 0x69,
-	0x61, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
-}
+const file_media_media_proto_rawDesc = "" +
+	"\n" +
+	"\x11media/media.proto\x12\x05media\x1a\x1bbuf/validate/validate.proto\x1a\x1cgoogle/api/annotations.proto\"\xb1\x05\n" +
+	"\x12UploadVideoRequest\x12%\n" +
+	"\bvideo_id\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x01R\avideoId\x12\x1e\n" +
+	"\x04data\x18\x02 \x01(\fB\n" +
+	"\xbaH\az\x05\x18\x80\x80\x80\bR\x04data\x12#\n" +
+	"\bsequence\x18\x03 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\bsequence\x12&\n" +
+	"\n" +
+	"total_size\x18\x04 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\ttotalSize\x12%\n" +
+	"\tupload_id\x18\x05 \x01(\tB\b\xbaH\x05r\x03\x18\x80\x01R\buploadId\x12\x1f\n" +
+	"\x06offset\x18\x06 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\x06offset\x12\"\n" +
+	"\x04tags\x18\a \x03(\tB\x0e\xbaH\v\x92\x01\b\x10 \"\x04r\x02\x18@R\x04tags\x12T\n" +
+	"\x06titles\x18\b \x03(\v2%.media.UploadVideoRequest.TitlesEntryB\x15\xbaH\x12\x9a\x01\x0f\x10 \"\x04r\x02\x18#*\x05r\x03\x18\xc8\x01R\x06titles\x12f\n" +
+	"\fdescriptions\x18\t \x03(\v2+.media.UploadVideoRequest.DescriptionsEntryB\x15\xbaH\x12\x9a\x01\x0f\x10 \"\x04r\x02\x18#*\x05r\x03\x18\x88'R\fdescriptions\x12\x1c\n" +
+	"\toverwrite\x18\n" +
+	" \x01(\bR\toverwrite\x12%\n" +
+	"\tfile_name\x18\v \x01(\tB\b\xbaH\x05r\x03\x18\xff\x01R\bfileName\x12\x1c\n" +
+	"\theartbeat\x18\f \x01(\bR\theartbeat\x1a9\n" +
+	"\vTitlesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a?\n" +
+	"\x11DescriptionsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xaf\x01\n" +
+	"\x13UploadVideoResponse\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x1f\n" +
+	"\vtotal_bytes\x18\x02 \x01(\x03R\n" +
+	"totalBytes\x120\n" +
+	"\bmetadata\x18\x03 \x01(\v2\x14.media.VideoMetadataR\bmetadata\x12*\n" +
+	"\x05stats\x18\x04 \x01(\v2\x14.media.TransferStatsR\x05stats\"\xde\x01\n" +
+	"\rTransferStats\x12\x1f\n" +
+	"\vduration_ms\x18\x01 \x01(\x03R\n" +
+	"durationMs\x12\x14\n" +
+	"\x05bytes\x18\x02 \x01(\x03R\x05bytes\x12(\n" +
+	"\x10bytes_per_second\x18\x03 \x01(\x01R\x0ebytesPerSecond\x12\x1f\n" +
+	"\vchunk_count\x18\x04 \x01(\x03R\n" +
+	"chunkCount\x12\x1a\n" +
+	"\battempts\x18\x05 \x01(\x03R\battempts\x12/\n" +
+	"\x13retransmitted_bytes\x18\x06 \x01(\x03R\x12retransmittedBytes\"\xa2\x01\n" +
+	"\x14DownloadVideoRequest\x12%\n" +
+	"\bvideo_id\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x01R\avideoId\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x03R\x06offset\x12\x16\n" +
+	"\x06length\x18\x03 \x01(\x03R\x06length\x123\n" +
+	"\bpriority\x18\x04 \x01(\x0e2\x17.media.DownloadPriorityR\bpriority\"\xac\x06\n" +
+	"\rVideoMetadata\x12\x1f\n" +
+	"\vuploader_id\x18\x01 \x01(\tR\n" +
+	"uploaderId\x12#\n" +
+	"\ruploader_name\x18\x02 \x01(\tR\fuploaderName\x12)\n" +
+	"\x10upload_timestamp\x18\x03 \x01(\x03R\x0fuploadTimestamp\x12\x1b\n" +
+	"\tfile_name\x18\x04 \x01(\tR\bfileName\x12\x1b\n" +
+	"\tfile_size\x18\x05 \x01(\x03R\bfileSize\x12\x16\n" +
+	"\x06sha256\x18\x06 \x01(\tR\x06sha256\x12\x12\n" +
+	"\x04tags\x18\a \x03(\tR\x04tags\x12\x1d\n" +
+	"\n" +
+	"channel_id\x18\b \x01(\tR\tchannelId\x121\n" +
+	"\n" +
+	"visibility\x18\t \x01(\x0e2\x11.media.VisibilityR\n" +
+	"visibility\x12\x1d\n" +
+	"\n" +
+	"like_count\x18\n" +
+	" \x01(\x03R\tlikeCount\x12\x1d\n" +
+	"\n" +
+	"taken_down\x18\v \x01(\bR\ttakenDown\x12\x16\n" +
+	"\x06crc32c\x18\f \x01(\rR\x06crc32c\x128\n" +
+	"\x06titles\x18\r \x03(\v2 .media.VideoMetadata.TitlesEntryR\x06titles\x12J\n" +
+	"\fdescriptions\x18\x0e \x03(\v2&.media.VideoMetadata.DescriptionsEntryR\fdescriptions\x12\x14\n" +
+	"\x05title\x18\x0f \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x10 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06locale\x18\x11 \x01(\tR\x06locale\x12\x18\n" +
+	"\aversion\x18\x12 \x01(\x03R\aversion\x120\n" +
+	"\n" +
+	"renditions\x18\x13 \x03(\v2\x10.media.RenditionR\n" +
+	"renditions\x1a9\n" +
+	"\vTitlesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a?\n" +
+	"\x11DescriptionsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x93\x01\n" +
+	"\tRendition\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12+\n" +
+	"\x05state\x18\x02 \x01(\x0e2\x15.media.RenditionStateR\x05state\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\x03R\tupdatedAt\"\xd8\x01\n" +
+	"\x15DownloadVideoResponse\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\x12\x1a\n" +
+	"\bsequence\x18\x03 \x01(\x03R\bsequence\x120\n" +
+	"\bmetadata\x18\x04 \x01(\v2\x14.media.VideoMetadataR\bmetadata\x12\x16\n" +
+	"\x06offset\x18\x05 \x01(\x03R\x06offset\x12*\n" +
+	"\x05stats\x18\x06 \x01(\v2\x14.media.TransferStatsR\x05stats\"=\n" +
+	"\x14WatchProgressRequest\x12%\n" +
+	"\bvideo_id\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x01R\avideoId\"\xf1\x01\n" +
+	"\rProgressEvent\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12'\n" +
+	"\x05state\x18\x02 \x01(\x0e2\x11.media.VideoStateR\x05state\x12%\n" +
+	"\x0ebytes_received\x18\x03 \x01(\x03R\rbytesReceived\x12\x1f\n" +
+	"\vtotal_bytes\x18\x04 \x01(\x03R\n" +
+	"totalBytes\x12\x18\n" +
+	"\apercent\x18\x05 \x01(\x01R\apercent\x12\x1c\n" +
+	"\ttimestamp\x18\x06 \x01(\x03R\ttimestamp\x12\x1c\n" +
+	"\theartbeat\x18\a \x01(\bR\theartbeat\"\xc8\x01\n" +
+	"\x11ListVideosRequest\x12$\n" +
+	"\buploader\x18\x01 \x01(\tB\b\xbaH\x05r\x03\x18\x80\x01R\buploader\x12\x14\n" +
+	"\x05since\x18\x02 \x01(\x03R\x05since\x12\x19\n" +
+	"\x03tag\x18\x03 \x01(\tB\a\xbaH\x04r\x02\x18@R\x03tag\x12 \n" +
+	"\x06locale\x18\x04 \x01(\tB\b\xbaH\x05r\x03\x18\x80\x02R\x06locale\x12\x1b\n" +
+	"\tpage_size\x18\x05 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x06 \x01(\tR\tpageToken\"[\n" +
+	"\fVideoSummary\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x120\n" +
+	"\bmetadata\x18\x02 \x01(\v2\x14.media.VideoMetadataR\bmetadata\"i\n" +
+	"\x12ListVideosResponse\x12+\n" +
+	"\x06videos\x18\x01 \x03(\v2\x13.media.VideoSummaryR\x06videos\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"b\n" +
+	"\x17GetVideoMetadataRequest\x12%\n" +
+	"\bvideo_id\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x01R\avideoId\x12 \n" +
+	"\x06locale\x18\x02 \x01(\tB\b\xbaH\x05r\x03\x18\x80\x02R\x06locale\"g\n" +
+	"\x18GetVideoMetadataResponse\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x120\n" +
+	"\bmetadata\x18\x02 \x01(\v2\x14.media.VideoMetadataR\bmetadata\";\n" +
+	"\x12DeleteVideoRequest\x12%\n" +
+	"\bvideo_id\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x01R\avideoId\"0\n" +
+	"\x13DeleteVideoResponse\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\"\x8c\x02\n" +
+	"\rUploadSession\x12\x1b\n" +
+	"\tupload_id\x18\x01 \x01(\tR\buploadId\x12\x19\n" +
+	"\bvideo_id\x18\x02 \x01(\tR\avideoId\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x03 \x01(\x03R\ttotalSize\x12'\n" +
+	"\x0fcommitted_bytes\x18\x04 \x01(\x03R\x0ecommittedBytes\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\x03R\texpiresAt\x12$\n" +
+	"\x0emax_video_size\x18\x06 \x01(\x03R\fmaxVideoSize\x126\n" +
+	"\x17stream_deadline_seconds\x18\a \x01(\x03R\x15streamDeadlineSeconds\"\x18\n" +
+	"\x16GetUploadLimitsRequest\"\xca\x03\n" +
+	"\x17GetUploadLimitsResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12$\n" +
+	"\x0emax_video_size\x18\x02 \x01(\x03R\fmaxVideoSize\x12(\n" +
+	"\x10max_message_size\x18\x03 \x01(\x03R\x0emaxMessageSize\x12/\n" +
+	"\x14stream_max_in_flight\x18\x04 \x01(\x03R\x11streamMaxInFlight\x12#\n" +
+	"\rmemory_budget\x18\x05 \x01(\x03R\fmemoryBudget\x12)\n" +
+	"\x10memory_available\x18\x06 \x01(\x03R\x0fmemoryAvailable\x12)\n" +
+	"\x10storage_degraded\x18\a \x01(\bR\x0fstorageDegraded\x120\n" +
+	"\x14session_idle_seconds\x18\b \x01(\x03R\x12sessionIdleSeconds\x12)\n" +
+	"\x10video_versioning\x18\t \x01(\bR\x0fvideoVersioning\x12=\n" +
+	"\x1bmax_stream_deadline_seconds\x18\n" +
+	" \x01(\x03R\x18maxStreamDeadlineSeconds\"\xdf\x04\n" +
+	"\x1aCreateUploadSessionRequest\x12%\n" +
+	"\bvideo_id\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x01R\avideoId\x12&\n" +
+	"\n" +
+	"total_size\x18\x02 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\ttotalSize\x12\"\n" +
+	"\x04tags\x18\x03 \x03(\tB\x0e\xbaH\v\x92\x01\b\x10 \"\x04r\x02\x18@R\x04tags\x12\\\n" +
+	"\x06titles\x18\x04 \x03(\v2-.media.CreateUploadSessionRequest.TitlesEntryB\x15\xbaH\x12\x9a\x01\x0f\x10 \"\x04r\x02\x18#*\x05r\x03\x18\xc8\x01R\x06titles\x12n\n" +
+	"\fdescriptions\x18\x05 \x03(\v23.media.CreateUploadSessionRequest.DescriptionsEntryB\x15\xbaH\x12\x9a\x01\x0f\x10 \"\x04r\x02\x18#*\x05r\x03\x18\x88'R\fdescriptions\x12\x1c\n" +
+	"\toverwrite\x18\x06 \x01(\bR\toverwrite\x12%\n" +
+	"\tfile_name\x18\a \x01(\tB\b\xbaH\x05r\x03\x18\xff\x01R\bfileName\x12?\n" +
+	"\x17stream_deadline_seconds\x18\b \x01(\x03B\a\xbaH\x04\"\x02(\x00R\x15streamDeadlineSeconds\x1a9\n" +
+	"\vTitlesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a?\n" +
+	"\x11DescriptionsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"M\n" +
+	"\x1bCreateUploadSessionResponse\x12.\n" +
+	"\asession\x18\x01 \x01(\v2\x14.media.UploadSessionR\asession\"B\n" +
+	"\x17GetUploadSessionRequest\x12'\n" +
+	"\tupload_id\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x01R\buploadId\"J\n" +
+	"\x18GetUploadSessionResponse\x12.\n" +
+	"\asession\x18\x01 \x01(\v2\x14.media.UploadSessionR\asession\"=\n" +
+	"\x12AbortUploadRequest\x12'\n" +
+	"\tupload_id\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x01R\buploadId\"[\n" +
+	"\x13AbortUploadResponse\x12\x1b\n" +
+	"\tupload_id\x18\x01 \x01(\tR\buploadId\x12'\n" +
+	"\x0fdiscarded_bytes\x18\x02 \x01(\x03R\x0ediscardedBytes\"\xe9\x01\n" +
+	"\bPlaylist\x12\x1f\n" +
+	"\vplaylist_id\x18\x01 \x01(\tR\n" +
+	"playlistId\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x19\n" +
+	"\bowner_id\x18\x04 \x01(\tR\aownerId\x12+\n" +
+	"\x06videos\x18\x05 \x03(\v2\x13.media.VideoSummaryR\x06videos\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\x03R\tupdatedAt\"\x96\x01\n" +
+	"\x15CreatePlaylistRequest\x12 \n" +
+	"\x05title\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\xc8\x01R\x05title\x12*\n" +
+	"\vdescription\x18\x02 \x01(\tB\b\xbaH\x05r\x03\x18\x88'R\vdescription\x12/\n" +
+	"\tvideo_ids\x18\x03 \x03(\tB\x12\xbaH\x0f\x92\x01\f\x10\xf4\x03\"\ar\x05\x10\x01\x18\x80\x01R\bvideoIds\"E\n" +
+	"\x16CreatePlaylistResponse\x12+\n" +
+	"\bplaylist\x18\x01 \x01(\v2\x0f.media.PlaylistR\bplaylist\"\x8f\x01\n" +
+	"\x14AddToPlaylistRequest\x12+\n" +
+	"\vplaylist_id\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x01R\n" +
+	"playlistId\x12%\n" +
+	"\bvideo_id\x18\x02 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x01R\avideoId\x12#\n" +
+	"\bposition\x18\x03 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\bposition\"D\n" +
+	"\x15AddToPlaylistResponse\x12+\n" +
+	"\bplaylist\x18\x01 \x01(\v2\x0f.media.PlaylistR\bplaylist\"v\n" +
+	"\x16ReorderPlaylistRequest\x12+\n" +
+	"\vplaylist_id\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x01R\n" +
+	"playlistId\x12/\n" +
+	"\tvideo_ids\x18\x02 \x03(\tB\x12\xbaH\x0f\x92\x01\f\x10\xf4\x03\"\ar\x05\x10\x01\x18\x80\x01R\bvideoIds\"F\n" +
+	"\x17ReorderPlaylistResponse\x12+\n" +
+	"\bplaylist\x18\x01 \x01(\v2\x0f.media.PlaylistR\bplaylist\"A\n" +
+	"\x12GetPlaylistRequest\x12+\n" +
+	"\vplaylist_id\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x01R\n" +
+	"playlistId\"B\n" +
+	"\x13GetPlaylistResponse\x12+\n" +
+	"\bplaylist\x18\x01 \x01(\v2\x0f.media.PlaylistR\bplaylist\"\xf2\x01\n" +
+	"\aChannel\x12\x1d\n" +
+	"\n" +
+	"channel_id\x18\x01 \x01(\tR\tchannelId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x19\n" +
+	"\bowner_id\x18\x04 \x01(\tR\aownerId\x12\x16\n" +
+	"\x06tenant\x18\x05 \x01(\tR\x06tenant\x12@\n" +
+	"\x12default_visibility\x18\x06 \x01(\x0e2\x11.media.VisibilityR\x11defaultVisibility\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\x03R\tcreatedAt\"\xc6\x01\n" +
+	"\x14CreateChannelRequest\x12\x1d\n" +
+	"\x04name\x18\x01 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18dR\x04name\x12*\n" +
+	"\vdescription\x18\x02 \x01(\tB\b\xbaH\x05r\x03\x18\x88'R\vdescription\x12@\n" +
+	"\x12default_visibility\x18\x03 \x01(\x0e2\x11.media.VisibilityR\x11defaultVisibility\x12!\n" +
+	"\ftenant_owned\x18\x04 \x01(\bR\vtenantOwned\"A\n" +
+	"\x15CreateChannelResponse\x12(\n" +
+	"\achannel\x18\x01 \x01(\v2\x0e.media.ChannelR\achannel\"o\n" +
+	"\x1bAssignVideoToChannelRequest\x12)\n" +
+	"\n" +
+	"channel_id\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x01R\tchannelId\x12%\n" +
+	"\bvideo_id\x18\x02 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x01R\avideoId\"k\n" +
+	"\x1cAssignVideoToChannelResponse\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x120\n" +
+	"\bmetadata\x18\x02 \x01(\v2\x14.media.VideoMetadataR\bmetadata\"\x1b\n" +
+	"\x19ListPublicChannelsRequest\"f\n" +
+	"\rPublicChannel\x12(\n" +
+	"\achannel\x18\x01 \x01(\v2\x0e.media.ChannelR\achannel\x12+\n" +
+	"\x06videos\x18\x02 \x03(\v2\x13.media.VideoSummaryR\x06videos\"N\n" +
+	"\x1aListPublicChannelsResponse\x120\n" +
+	"\bchannels\x18\x01 \x03(\v2\x14.media.PublicChannelR\bchannels\"9\n" +
+	"\x10LikeVideoRequest\x12%\n" +
+	"\bvideo_id\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x01R\avideoId\"M\n" +
+	"\x11LikeVideoResponse\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x1d\n" +
+	"\n" +
+	"like_count\x18\x02 \x01(\x03R\tlikeCount\";\n" +
+	"\x12UnlikeVideoRequest\x12%\n" +
+	"\bvideo_id\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x01R\avideoId\"O\n" +
+	"\x13UnlikeVideoResponse\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x1d\n" +
+	"\n" +
+	"like_count\x18\x02 \x01(\x03R\tlikeCount\"R\n" +
+	"\x14ListFavoritesRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\"\xeb\x01\n" +
+	"\tShareLink\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x19\n" +
+	"\bvideo_id\x18\x02 \x01(\tR\avideoId\x12*\n" +
+	"\x06target\x18\x03 \x01(\x0e2\x12.media.ShareTargetR\x06target\x12\x19\n" +
+	"\bowner_id\x18\x04 \x01(\tR\aownerId\x12\x16\n" +
+	"\x06clicks\x18\x05 \x01(\x03R\x06clicks\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\x03R\texpiresAt\x12\x12\n" +
+	"\x04path\x18\b \x01(\tR\x04path\"\xa2\x01\n" +
+	"\x16CreateShareLinkRequest\x12%\n" +
+	"\bvideo_id\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x01R\avideoId\x12*\n" +
+	"\x06target\x18\x02 \x01(\x0e2\x12.media.ShareTargetR\x06target\x125\n" +
+	"\x12expires_in_seconds\x18\x03 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\x10expiresInSeconds\"?\n" +
+	"\x17CreateShareLinkResponse\x12$\n" +
+	"\x04link\x18\x01 \x01(\v2\x10.media.ShareLinkR\x04link\"B\n" +
+	"\x19CreateDownloadLinkRequest\x12%\n" +
+	"\bvideo_id\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x01R\avideoId\"M\n" +
+	"\x1aCreateDownloadLinkResponse\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\x03R\texpiresAt\"5\n" +
+	"\x13GetShareLinkRequest\x12\x1e\n" +
+	"\x04code\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x01R\x04code\"<\n" +
+	"\x14GetShareLinkResponse\x12$\n" +
+	"\x04link\x18\x01 \x01(\v2\x10.media.ShareLinkR\x04link\"9\n" +
+	"\x17ResolveShareLinkRequest\x12\x1e\n" +
+	"\x04code\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x01R\x04code\"X\n" +
+	"\x18ResolveShareLinkResponse\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12!\n" +
+	"\fredirect_url\x18\x02 \x01(\tR\vredirectUrl\"^\n" +
+	"\x13SetThumbnailRequest\x12%\n" +
+	"\bvideo_id\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x01R\avideoId\x12 \n" +
+	"\x04data\x18\x02 \x01(\fB\f\xbaH\tz\a\x10\x01\x18\x80\x80\x80\x01R\x04data\"V\n" +
+	"\x14SetThumbnailResponse\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12#\n" +
+	"\rthumbnail_url\x18\x02 \x01(\tR\fthumbnailUrl\"<\n" +
+	"\x13GetThumbnailRequest\x12%\n" +
+	"\bvideo_id\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x01R\avideoId\"M\n" +
+	"\x14GetThumbnailResponse\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\"3\n" +
+	"\x10SetAvatarRequest\x12\x1f\n" +
+	"\x04data\x18\x01 \x01(\fB\v\xbaH\bz\x06\x10\x01\x18\x80\x80@R\x04data\"i\n" +
+	"\x11SetAvatarResponse\x12\x1d\n" +
+	"\n" +
+	"avatar_url\x18\x01 \x01(\tR\tavatarUrl\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x05R\x04size\x12!\n" +
+	"\fcontent_type\x18\x03 \x01(\tR\vcontentType\"7\n" +
+	"\x10GetAvatarRequest\x12#\n" +
+	"\auser_id\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x01R\x06userId\"J\n" +
+	"\x11GetAvatarResponse\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\"U\n" +
+	"\x17ListPublicVideosRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\"\x7f\n" +
+	"\vPublicVideo\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x120\n" +
+	"\bmetadata\x18\x02 \x01(\v2\x14.media.VideoMetadataR\bmetadata\x12#\n" +
+	"\rthumbnail_url\x18\x03 \x01(\tR\fthumbnailUrl\"n\n" +
+	"\x18ListPublicVideosResponse\x12*\n" +
+	"\x06videos\x18\x01 \x03(\v2\x12.media.PublicVideoR\x06videos\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"8\n" +
+	"\x0fGetEmbedRequest\x12%\n" +
+	"\bvideo_id\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x01R\avideoId\">\n" +
+	"\x15GetHLSPlaylistRequest\x12%\n" +
+	"\bvideo_id\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x01R\avideoId\"4\n" +
+	"\x16GetHLSPlaylistResponse\x12\x1a\n" +
+	"\bplaylist\x18\x01 \x01(\tR\bplaylist\"s\n" +
+	"\x14GetHLSSegmentRequest\x12%\n" +
+	"\bvideo_id\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x01R\avideoId\x12\x14\n" +
+	"\x05index\x18\x02 \x01(\x05R\x05index\x12\x1e\n" +
+	"\x05token\x18\x03 \x01(\tB\b\xbaH\x05r\x03\x18\x80\bR\x05token\"+\n" +
+	"\x15GetHLSSegmentResponse\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"\xb5\x01\n" +
+	"\x10GetEmbedResponse\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x120\n" +
+	"\bmetadata\x18\x02 \x01(\v2\x14.media.VideoMetadataR\bmetadata\x12\x1d\n" +
+	"\n" +
+	"source_url\x18\x03 \x01(\tR\tsourceUrl\x12\x10\n" +
+	"\x03hls\x18\x04 \x01(\bR\x03hls\x12#\n" +
+	"\rthumbnail_url\x18\x05 \x01(\tR\fthumbnailUrl\"l\n" +
+	"\x15ListFavoritesResponse\x12+\n" +
+	"\x06videos\x18\x01 \x03(\v2\x13.media.VideoSummaryR\x06videos\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\x8e\x01\n" +
+	"\fExportFilter\x12$\n" +
+	"\buploader\x18\x01 \x01(\tB\b\xbaH\x05r\x03\x18\x80\x01R\buploader\x12\x14\n" +
+	"\x05since\x18\x02 \x01(\x03R\x05since\x12\x19\n" +
+	"\x03tag\x18\x03 \x01(\tB\a\xbaH\x04r\x02\x18@R\x03tag\x12'\n" +
+	"\n" +
+	"channel_id\x18\x04 \x01(\tB\b\xbaH\x05r\x03\x18\x80\x01R\tchannelId\"\xac\x01\n" +
+	"\x13CreateExportRequest\x121\n" +
+	"\tvideo_ids\x18\x01 \x03(\tB\x14\xbaH\x11\x92\x01\x0e\x10\xf4\x03\x18\x01\"\ar\x05\x10\x01\x18\x80\x01R\bvideoIds\x12+\n" +
+	"\x06filter\x18\x02 \x01(\v2\x13.media.ExportFilterR\x06filter\x125\n" +
+	"\x06format\x18\x03 \x01(\x0e2\x13.media.ExportFormatB\b\xbaH\x05\x82\x01\x02\x10\x01R\x06format\"\xb7\x01\n" +
+	"\x06Export\x12\x1b\n" +
+	"\texport_id\x18\x01 \x01(\tR\bexportId\x12(\n" +
+	"\x05state\x18\x02 \x01(\x0e2\x12.media.ExportStateR\x05state\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\x03R\texpiresAt\x12\x12\n" +
+	"\x04size\x18\x05 \x01(\x03R\x04size\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\"=\n" +
+	"\x14CreateExportResponse\x12%\n" +
+	"\x06export\x18\x01 \x01(\v2\r.media.ExportR\x06export\";\n" +
+	"\x10GetExportRequest\x12'\n" +
+	"\texport_id\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x01R\bexportId\":\n" +
+	"\x11GetExportResponse\x12%\n" +
+	"\x06export\x18\x01 \x01(\v2\r.media.ExportR\x06export\"@\n" +
+	"\x15DownloadExportRequest\x12'\n" +
+	"\texport_id\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x01R\bexportId\"\x80\x01\n" +
+	"\x16DownloadExportResponse\x12\x1b\n" +
+	"\tfile_name\x18\x01 \x01(\tR\bfileName\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\x12!\n" +
+	"\fcontent_type\x18\x03 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04data\x18\x04 \x01(\fR\x04data\"\xa7\x01\n" +
+	"\fExportedFile\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x120\n" +
+	"\bmetadata\x18\x02 \x01(\v2\x14.media.VideoMetadataR\bmetadata\x12\x12\n" +
+	"\x04path\x18\x03 \x01(\tR\x04path\x12\x10\n" +
+	"\x03url\x18\x04 \x01(\tR\x03url\x12$\n" +
+	"\x0eurl_expires_at\x18\x05 \x01(\x03R\furlExpiresAt\"=\n" +
+	"\x0eExportManifest\x12+\n" +
+	"\x06videos\x18\x01 \x03(\v2\x13.media.ExportedFileR\x06videos\"\xd2\x02\n" +
+	"\x0eTranscodeEvent\x12%\n" +
+	"\bevent_id\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x01R\aeventId\x12%\n" +
+	"\bvideo_id\x18\x02 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x01R\avideoId\x12*\n" +
+	"\fvideo_sha256\x18\x03 \x01(\tB\a\xbaH\x04r\x02\x18@R\vvideoSha256\x12'\n" +
+	"\trendition\x18\x04 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18@R\trendition\x127\n" +
+	"\x05state\x18\x05 \x01(\x0e2\x15.media.RenditionStateB\n" +
+	"\xbaH\a\x82\x01\x04\x10\x01 \x00R\x05state\x12\x1a\n" +
+	"\x03url\x18\x06 \x01(\tB\b\xbaH\x05r\x03\x18\x80\x10R\x03url\x12\x1e\n" +
+	"\x05error\x18\a \x01(\tB\b\xbaH\x05r\x03\x18\xd0\x0fR\x05error\x12(\n" +
+	"\voccurred_at\x18\b \x01(\x03B\a\xbaH\x04\"\x02 \x00R\n" +
+	"occurredAt\"g\n" +
+	"\x16ReportTranscodeRequest\x12#\n" +
+	"\apayload\x18\x01 \x01(\fB\t\xbaH\x06z\x04\x18\x80\x80\x04R\apayload\x12(\n" +
+	"\tsignature\x18\x02 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\bR\tsignature\"M\n" +
+	"\x17ReportTranscodeResponse\x12\x1c\n" +
+	"\tduplicate\x18\x01 \x01(\bR\tduplicate\x12\x14\n" +
+	"\x05stale\x18\x02 \x01(\bR\x05stale*u\n" +
+	"\x10DownloadPriority\x12!\n" +
+	"\x1dDOWNLOAD_PRIORITY_UNSPECIFIED\x10\x00\x12!\n" +
+	"\x1dDOWNLOAD_PRIORITY_INTERACTIVE\x10\x01\x12\x1b\n" +
+	"\x17DOWNLOAD_PRIORITY_BATCH\x10\x02*\x88\x01\n" +
+	"\x0eRenditionState\x12\x1f\n" +
+	"\x1bRENDITION_STATE_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aRENDITION_STATE_PROCESSING\x10\x01\x12\x19\n" +
+	"\x15RENDITION_STATE_READY\x10\x02\x12\x1a\n" +
+	"\x16RENDITION_STATE_FAILED\x10\x03*p\n" +
+	"\n" +
+	"Visibility\x12\x1a\n" +
+	"\x16VISIBILITY_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11VISIBILITY_PUBLIC\x10\x01\x12\x17\n" +
+	"\x13VISIBILITY_UNLISTED\x10\x02\x12\x16\n" +
+	"\x12VISIBILITY_PRIVATE\x10\x03*\x8f\x01\n" +
+	"\n" +
+	"VideoState\x12\x1b\n" +
+	"\x17VIDEO_STATE_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15VIDEO_STATE_UPLOADING\x10\x01\x12\x1a\n" +
+	"\x16VIDEO_STATE_PROCESSING\x10\x02\x12\x15\n" +
+	"\x11VIDEO_STATE_READY\x10\x03\x12\x16\n" +
+	"\x12VIDEO_STATE_FAILED\x10\x04*_\n" +
+	"\vShareTarget\x12\x1c\n" +
+	"\x18SHARE_TARGET_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13SHARE_TARGET_PLAYER\x10\x01\x12\x19\n" +
+	"\x15SHARE_TARGET_DOWNLOAD\x10\x02*w\n" +
+	"\fExportFormat\x12\x1d\n" +
+	"\x19EXPORT_FORMAT_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11EXPORT_FORMAT_ZIP\x10\x01\x12\x15\n" +
+	"\x11EXPORT_FORMAT_TAR\x10\x02\x12\x1a\n" +
+	"\x16EXPORT_FORMAT_MANIFEST\x10\x03*\x90\x01\n" +
+	"\vExportState\x12\x1c\n" +
+	"\x18EXPORT_STATE_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14EXPORT_STATE_PENDING\x10\x01\x12\x18\n" +
+	"\x14EXPORT_STATE_RUNNING\x10\x02\x12\x16\n" +
+	"\x12EXPORT_STATE_READY\x10\x03\x12\x17\n" +
+	"\x13EXPORT_STATE_FAILED\x10\x042\xc7\x1c\n" +
+	"\fMediaService\x12f\n" +
+	"\vUploadVideo\x12\x19.media.UploadVideoRequest\x1a\x1a.media.UploadVideoResponse\"\x1e\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/v1/video/upload\x88\x02\x01(\x01\x12v\n" +
+	"\rDownloadVideo\x12\x1b.media.DownloadVideoRequest\x1a\x1c.media.DownloadVideoResponse\"(\x82\xd3\xe4\x93\x02\x1f\x12\x1d/v1/video/download/{video_id}\x88\x02\x010\x01\x12D\n" +
+	"\rWatchProgress\x12\x1b.media.WatchProgressRequest\x1a\x14.media.ProgressEvent0\x01\x12U\n" +
+	"\n" +
+	"ListVideos\x12\x18.media.ListVideosRequest\x1a\x19.media.ListVideosResponse\"\x12\x82\xd3\xe4\x93\x02\f\x12\n" +
+	"/v1/videos\x12{\n" +
+	"\x10GetVideoMetadata\x12\x1e.media.GetVideoMetadataRequest\x1a\x1f.media.GetVideoMetadataResponse\"&\x82\xd3\xe4\x93\x02 \x12\x1e/v1/videos/{video_id}/metadata\x12t\n" +
+	"\x13CreateUploadSession\x12!.media.CreateUploadSessionRequest\x1a\".media.CreateUploadSessionResponse\"\x16\x82\xd3\xe4\x93\x02\x10:\x01*\"\v/v1/uploads\x12t\n" +
+	"\x10GetUploadSession\x12\x1e.media.GetUploadSessionRequest\x1a\x1f.media.GetUploadSessionResponse\"\x1f\x82\xd3\xe4\x93\x02\x19\x12\x17/v1/uploads/{upload_id}\x12k\n" +
+	"\x0fGetUploadLimits\x12\x1d.media.GetUploadLimitsRequest\x1a\x1e.media.GetUploadLimitsResponse\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/v1/upload-limits\x12e\n" +
+	"\vAbortUpload\x12\x19.media.AbortUploadRequest\x1a\x1a.media.AbortUploadResponse\"\x1f\x82\xd3\xe4\x93\x02\x19*\x17/v1/uploads/{upload_id}\x12c\n" +
+	"\vDeleteVideo\x12\x19.media.DeleteVideoRequest\x1a\x1a.media.DeleteVideoResponse\"\x1d\x82\xd3\xe4\x93\x02\x17*\x15/v1/videos/{video_id}\x12g\n" +
+	"\x0eCreatePlaylist\x12\x1c.media.CreatePlaylistRequest\x1a\x1d.media.CreatePlaylistResponse\"\x18\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/v1/playlists\x12y\n" +
+	"\rAddToPlaylist\x12\x1b.media.AddToPlaylistRequest\x1a\x1c.media.AddToPlaylistResponse\"-\x82\xd3\xe4\x93\x02':\x01*\"\"/v1/playlists/{playlist_id}/videos\x12~\n" +
+	"\x0fReorderPlaylist\x12\x1d.media.ReorderPlaylistRequest\x1a\x1e.media.ReorderPlaylistResponse\",\x82\xd3\xe4\x93\x02&:\x01*\x1a!/v1/playlists/{playlist_id}/order\x12i\n" +
+	"\vGetPlaylist\x12\x19.media.GetPlaylistRequest\x1a\x1a.media.GetPlaylistResponse\"#\x82\xd3\xe4\x93\x02\x1d\x12\x1b/v1/playlists/{playlist_id}\x12c\n" +
+	"\rCreateChannel\x12\x1b.media.CreateChannelRequest\x1a\x1c.media.CreateChannelResponse\"\x17\x82\xd3\xe4\x93\x02\x11:\x01*\"\f/v1/channels\x12\x8c\x01\n" +
+	"\x14AssignVideoToChannel\x12\".media.AssignVideoToChannelRequest\x1a#.media.AssignVideoToChannelResponse\"+\x82\xd3\xe4\x93\x02%:\x01*\" /v1/channels/{channel_id}/videos\x12v\n" +
+	"\x12ListPublicChannels\x12 .media.ListPublicChannelsRequest\x1a!.media.ListPublicChannelsResponse\"\x1b\x82\xd3\xe4\x93\x02\x15\x12\x13/v1/public/channels\x12b\n" +
+	"\tLikeVideo\x12\x17.media.LikeVideoRequest\x1a\x18.media.LikeVideoResponse\"\"\x82\xd3\xe4\x93\x02\x1c\x1a\x1a/v1/videos/{video_id}/like\x12h\n" +
+	"\vUnlikeVideo\x12\x19.media.UnlikeVideoRequest\x1a\x1a.media.UnlikeVideoResponse\"\"\x82\xd3\xe4\x93\x02\x1c*\x1a/v1/videos/{video_id}/like\x12a\n" +
+	"\rListFavorites\x12\x1b.media.ListFavoritesRequest\x1a\x1c.media.ListFavoritesResponse\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/v1/favorites\x12~\n" +
+	"\x0fCreateShareLink\x12\x1d.media.CreateShareLinkRequest\x1a\x1e.media.CreateShareLinkResponse\",\x82\xd3\xe4\x93\x02&:\x01*\"!/v1/videos/{video_id}/share-links\x12\x86\x01\n" +
+	"\x12CreateDownloadLink\x12 .media.CreateDownloadLinkRequest\x1a!.media.CreateDownloadLinkResponse\"+\x82\xd3\xe4\x93\x02%\"#/v1/videos/{video_id}/download-link\x12g\n" +
+	"\fGetShareLink\x12\x1a.media.GetShareLinkRequest\x1a\x1b.media.GetShareLinkResponse\"\x1e\x82\xd3\xe4\x93\x02\x18\x12\x16/v1/share-links/{code}\x12S\n" +
+	"\x10ResolveShareLink\x12\x1e.media.ResolveShareLinkRequest\x1a\x1f.media.ResolveShareLinkResponse\x12s\n" +
+	"\fSetThumbnail\x12\x1a.media.SetThumbnailRequest\x1a\x1b.media.SetThumbnailResponse\"*\x82\xd3\xe4\x93\x02$:\x01*\x1a\x1f/v1/videos/{video_id}/thumbnail\x12G\n" +
+	"\fGetThumbnail\x12\x1a.media.GetThumbnailRequest\x1a\x1b.media.GetThumbnailResponse\x12U\n" +
+	"\tSetAvatar\x12\x17.media.SetAvatarRequest\x1a\x18.media.SetAvatarResponse\"\x15\x82\xd3\xe4\x93\x02\x0f:\x01*\x1a\n" +
+	"/v1/avatar\x12>\n" +
+	"\tGetAvatar\x12\x17.media.GetAvatarRequest\x1a\x18.media.GetAvatarResponse\x12S\n" +
+	"\x10ListPublicVideos\x12\x1e.media.ListPublicVideosRequest\x1a\x1f.media.ListPublicVideosResponse\x12;\n" +
+	"\bGetEmbed\x12\x16.media.GetEmbedRequest\x1a\x17.media.GetEmbedResponse\x12M\n" +
+	"\x0eGetHLSPlaylist\x12\x1c.media.GetHLSPlaylistRequest\x1a\x1d.media.GetHLSPlaylistResponse\x12J\n" +
+	"\rGetHLSSegment\x12\x1b.media.GetHLSSegmentRequest\x1a\x1c.media.GetHLSSegmentResponse\x12_\n" +
+	"\fCreateExport\x12\x1a.media.CreateExportRequest\x1a\x1b.media.CreateExportResponse\"\x16\x82\xd3\xe4\x93\x02\x10:\x01*\"\v/v1/exports\x12_\n" +
+	"\tGetExport\x12\x17.media.GetExportRequest\x1a\x18.media.GetExportResponse\"\x1f\x82\xd3\xe4\x93\x02\x19\x12\x17/v1/exports/{export_id}\x12O\n" +
+	"\x0eDownloadExport\x12\x1c.media.DownloadExportRequest\x1a\x1d.media.DownloadExportResponse0\x01\x12P\n" +
+	"\x0fReportTranscode\x12\x1d.media.ReportTranscodeRequest\x1a\x1e.media.ReportTranscodeResponseB\x1eZ\x1ccoscup2025/proto/media;mediab\x06proto3"
 
 var (
 	file_media_media_proto_rawDescOnce sync.Once
-	file_media_media_proto_rawDescData = file_media_media_proto_rawDesc
+	file_media_media_proto_rawDescData []byte
 )
 
 func file_media_media_proto_rawDescGZIP() []byte {
 	file_media_media_proto_rawDescOnce.Do(func() {
-		file_media_media_proto_rawDescData = protoimpl.X.CompressGZIP(file_media_media_proto_rawDescData)
+		file_media_media_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_media_media_proto_rawDesc), len(file_media_media_proto_rawDesc)))
 	})
 	return file_media_media_proto_rawDescData
 }
 
-var file_media_media_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_media_media_proto_enumTypes = make([]protoimpl.EnumInfo, 7)
+var file_media_media_proto_msgTypes = make([]protoimpl.MessageInfo, 93)
 var file_media_media_proto_goTypes = []any{
-	(*UploadVideoRequest)(nil),    // 0: media.UploadVideoRequest
-	(*UploadVideoResponse)(nil),   // 1: media.UploadVideoResponse
-	(*DownloadVideoRequest)(nil),  // 2: media.DownloadVideoRequest
-	(*VideoMetadata)(nil),         // 3: media.VideoMetadata
-	(*DownloadVideoResponse)(nil), // 4: media.DownloadVideoResponse
+	(DownloadPriority)(0),                // 0: media.DownloadPriority
+	(RenditionState)(0),                  // 1: media.RenditionState
+	(Visibility)(0),                      // 2: media.Visibility
+	(VideoState)(0),                      // 3: media.VideoState
+	(ShareTarget)(0),                     // 4: media.ShareTarget
+	(ExportFormat)(0),                    // 5: media.ExportFormat
+	(ExportState)(0),                     // 6: media.ExportState
+	(*UploadVideoRequest)(nil),           // 7: media.UploadVideoRequest
+	(*UploadVideoResponse)(nil),          // 8: media.UploadVideoResponse
+	(*TransferStats)(nil),                // 9: media.TransferStats
+	(*DownloadVideoRequest)(nil),         // 10: media.DownloadVideoRequest
+	(*VideoMetadata)(nil),                // 11: media.VideoMetadata
+	(*Rendition)(nil),                    // 12: media.Rendition
+	(*DownloadVideoResponse)(nil),        // 13: media.DownloadVideoResponse
+	(*WatchProgressRequest)(nil),         // 14: media.WatchProgressRequest
+	(*ProgressEvent)(nil),                // 15: media.ProgressEvent
+	(*ListVideosRequest)(nil),            // 16: media.ListVideosRequest
+	(*VideoSummary)(nil),                 // 17: media.VideoSummary
+	(*ListVideosResponse)(nil),           // 18: media.ListVideosResponse
+	(*GetVideoMetadataRequest)(nil),      // 19: media.GetVideoMetadataRequest
+	(*GetVideoMetadataResponse)(nil),     // 20: media.GetVideoMetadataResponse
+	(*DeleteVideoRequest)(nil),           // 21: media.DeleteVideoRequest
+	(*DeleteVideoResponse)(nil),          // 22: media.DeleteVideoResponse
+	(*UploadSession)(nil),                // 23: media.UploadSession
+	(*GetUploadLimitsRequest)(nil),       // 24: media.GetUploadLimitsRequest
+	(*GetUploadLimitsResponse)(nil),      // 25: media.GetUploadLimitsResponse
+	(*CreateUploadSessionRequest)(nil),   // 26: media.CreateUploadSessionRequest
+	(*CreateUploadSessionResponse)(nil),  // 27: media.CreateUploadSessionResponse
+	(*GetUploadSessionRequest)(nil),      // 28: media.GetUploadSessionRequest
+	(*GetUploadSessionResponse)(nil),     // 29: media.GetUploadSessionResponse
+	(*AbortUploadRequest)(nil),           // 30: media.AbortUploadRequest
+	(*AbortUploadResponse)(nil),          // 31: media.AbortUploadResponse
+	(*Playlist)(nil),                     // 32: media.Playlist
+	(*CreatePlaylistRequest)(nil),        // 33: media.CreatePlaylistRequest
+	(*CreatePlaylistResponse)(nil),       // 34: media.CreatePlaylistResponse
+	(*AddToPlaylistRequest)(nil),         // 35: media.AddToPlaylistRequest
+	(*AddToPlaylistResponse)(nil),        // 36: media.AddToPlaylistResponse
+	(*ReorderPlaylistRequest)(nil),       // 37: media.ReorderPlaylistRequest
+	(*ReorderPlaylistResponse)(nil),      // 38: media.ReorderPlaylistResponse
+	(*GetPlaylistRequest)(nil),           // 39: media.GetPlaylistRequest
+	(*GetPlaylistResponse)(nil),          // 40: media.GetPlaylistResponse
+	(*Channel)(nil),                      // 41: media.Channel
+	(*CreateChannelRequest)(nil),         // 42: media.CreateChannelRequest
+	(*CreateChannelResponse)(nil),        // 43: media.CreateChannelResponse
+	(*AssignVideoToChannelRequest)(nil),  // 44: media.AssignVideoToChannelRequest
+	(*AssignVideoToChannelResponse)(nil), // 45: media.AssignVideoToChannelResponse
+	(*ListPublicChannelsRequest)(nil),    // 46: media.ListPublicChannelsRequest
+	(*PublicChannel)(nil),                // 47: media.PublicChannel
+	(*ListPublicChannelsResponse)(nil),   // 48: media.ListPublicChannelsResponse
+	(*LikeVideoRequest)(nil),             // 49: media.LikeVideoRequest
+	(*LikeVideoResponse)(nil),            // 50: media.LikeVideoResponse
+	(*UnlikeVideoRequest)(nil),           // 51: media.UnlikeVideoRequest
+	(*UnlikeVideoResponse)(nil),          // 52: media.UnlikeVideoResponse
+	(*ListFavoritesRequest)(nil),         // 53: media.ListFavoritesRequest
+	(*ShareLink)(nil),                    // 54: media.ShareLink
+	(*CreateShareLinkRequest)(nil),       // 55: media.CreateShareLinkRequest
+	(*CreateShareLinkResponse)(nil),      // 56: media.CreateShareLinkResponse
+	(*CreateDownloadLinkRequest)(nil),    // 57: media.CreateDownloadLinkRequest
+	(*CreateDownloadLinkResponse)(nil),   // 58: media.CreateDownloadLinkResponse
+	(*GetShareLinkRequest)(nil),          // 59: media.GetShareLinkRequest
+	(*GetShareLinkResponse)(nil),         // 60: media.GetShareLinkResponse
+	(*ResolveShareLinkRequest)(nil),      // 61: media.ResolveShareLinkRequest
+	(*ResolveShareLinkResponse)(nil),     // 62: media.ResolveShareLinkResponse
+	(*SetThumbnailRequest)(nil),          // 63: media.SetThumbnailRequest
+	(*SetThumbnailResponse)(nil),         // 64: media.SetThumbnailResponse
+	(*GetThumbnailRequest)(nil),          // 65: media.GetThumbnailRequest
+	(*GetThumbnailResponse)(nil),         // 66: media.GetThumbnailResponse
+	(*SetAvatarRequest)(nil),             // 67: media.SetAvatarRequest
+	(*SetAvatarResponse)(nil),            // 68: media.SetAvatarResponse
+	(*GetAvatarRequest)(nil),             // 69: media.GetAvatarRequest
+	(*GetAvatarResponse)(nil),            // 70: media.GetAvatarResponse
+	(*ListPublicVideosRequest)(nil),      // 71: media.ListPublicVideosRequest
+	(*PublicVideo)(nil),                  // 72: media.PublicVideo
+	(*ListPublicVideosResponse)(nil),     // 73: media.ListPublicVideosResponse
+	(*GetEmbedRequest)(nil),              // 74: media.GetEmbedRequest
+	(*GetHLSPlaylistRequest)(nil),        // 75: media.GetHLSPlaylistRequest
+	(*GetHLSPlaylistResponse)(nil),       // 76: media.GetHLSPlaylistResponse
+	(*GetHLSSegmentRequest)(nil),         // 77: media.GetHLSSegmentRequest
+	(*GetHLSSegmentResponse)(nil),        // 78: media.GetHLSSegmentResponse
+	(*GetEmbedResponse)(nil),             // 79: media.GetEmbedResponse
+	(*ListFavoritesResponse)(nil),        // 80: media.ListFavoritesResponse
+	(*ExportFilter)(nil),                 // 81: media.ExportFilter
+	(*CreateExportRequest)(nil),          // 82: media.CreateExportRequest
+	(*Export)(nil),                       // 83: media.Export
+	(*CreateExportResponse)(nil),         // 84: media.CreateExportResponse
+	(*GetExportRequest)(nil),             // 85: media.GetExportRequest
+	(*GetExportResponse)(nil),            // 86: media.GetExportResponse
+	(*DownloadExportRequest)(nil),        // 87: media.DownloadExportRequest
+	(*DownloadExportResponse)(nil),       // 88: media.DownloadExportResponse
+	(*ExportedFile)(nil),                 // 89: media.ExportedFile
+	(*ExportManifest)(nil),               // 90: media.ExportManifest
+	(*TranscodeEvent)(nil),               // 91: media.TranscodeEvent
+	(*ReportTranscodeRequest)(nil),       // 92: media.ReportTranscodeRequest
+	(*ReportTranscodeResponse)(nil),      // 93: media.ReportTranscodeResponse
+	nil,                                  // 94: media.UploadVideoRequest.TitlesEntry
+	nil,                                  // 95: media.UploadVideoRequest.DescriptionsEntry
+	nil,                                  // 96: media.VideoMetadata.TitlesEntry
+	nil,                                  // 97: media.VideoMetadata.DescriptionsEntry
+	nil,                                  // 98: media.CreateUploadSessionRequest.TitlesEntry
+	nil,                                  // 99: media.CreateUploadSessionRequest.DescriptionsEntry
 }
 var file_media_media_proto_depIdxs = []int32{
-	3, // 0: media.UploadVideoResponse.metadata:type_name -> media.VideoMetadata
-	3, // 1: media.DownloadVideoResponse.metadata:type_name -> media.VideoMetadata
-	0, // 2: media.MediaService.UploadVideo:input_type -> media.UploadVideoRequest
-	2, // 3: media.MediaService.DownloadVideo:input_type -> media.DownloadVideoRequest
-	1, // 4: media.MediaService.UploadVideo:output_type -> media.UploadVideoResponse
-	4, // 5: media.MediaService.DownloadVideo:output_type -> media.DownloadVideoResponse
-	4, // [4:6] is the sub-list for method output_type
-	2, // [2:4] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	94, // 0: media.UploadVideoRequest.titles:type_name -> media.UploadVideoRequest.TitlesEntry
+	95, // 1: media.UploadVideoRequest.descriptions:type_name -> media.UploadVideoRequest.DescriptionsEntry
+	11, // 2: media.UploadVideoResponse.metadata:type_name -> media.VideoMetadata
+	9,  // 3: media.UploadVideoResponse.stats:type_name -> media.TransferStats
+	0,  // 4: media.DownloadVideoRequest.priority:type_name -> media.DownloadPriority
+	2,  // 5: media.VideoMetadata.visibility:type_name -> media.Visibility
+	96, // 6: media.VideoMetadata.titles:type_name -> media.VideoMetadata.TitlesEntry
+	97, // 7: media.VideoMetadata.descriptions:type_name -> media.VideoMetadata.DescriptionsEntry
+	12, // 8: media.VideoMetadata.renditions:type_name -> media.Rendition
+	1,  // 9: media.Rendition.state:type_name -> media.RenditionState
+	11, // 10: media.DownloadVideoResponse.metadata:type_name -> media.VideoMetadata
+	9,  // 11: media.DownloadVideoResponse.stats:type_name -> media.TransferStats
+	3,  // 12: media.ProgressEvent.state:type_name -> media.VideoState
+	11, // 13: media.VideoSummary.metadata:type_name -> media.VideoMetadata
+	17, // 14: media.ListVideosResponse.videos:type_name -> media.VideoSummary
+	11, // 15: media.GetVideoMetadataResponse.metadata:type_name -> media.VideoMetadata
+	98, // 16: media.CreateUploadSessionRequest.titles:type_name -> media.CreateUploadSessionRequest.TitlesEntry
+	99, // 17: media.CreateUploadSessionRequest.descriptions:type_name -> media.CreateUploadSessionRequest.DescriptionsEntry
+	23, // 18: media.CreateUploadSessionResponse.session:type_name -> media.UploadSession
+	23, // 19: media.GetUploadSessionResponse.session:type_name -> media.UploadSession
+	17, // 20: media.Playlist.videos:type_name -> media.VideoSummary
+	32, // 21: media.CreatePlaylistResponse.playlist:type_name -> media.Playlist
+	32, // 22: media.AddToPlaylistResponse.playlist:type_name -> media.Playlist
+	32, // 23: media.ReorderPlaylistResponse.playlist:type_name -> media.Playlist
+	32, // 24: media.GetPlaylistResponse.playlist:type_name -> media.Playlist
+	2,  // 25: media.Channel.default_visibility:type_name -> media.Visibility
+	2,  // 26: media.CreateChannelRequest.default_visibility:type_name -> media.Visibility
+	41, // 27: media.CreateChannelResponse.channel:type_name -> media.Channel
+	11, // 28: media.AssignVideoToChannelResponse.metadata:type_name -> media.VideoMetadata
+	41, // 29: media.PublicChannel.channel:type_name -> media.Channel
+	17, // 30: media.PublicChannel.videos:type_name -> media.VideoSummary
+	47, // 31: media.ListPublicChannelsResponse.channels:type_name -> media.PublicChannel
+	4,  // 32: media.ShareLink.target:type_name -> media.ShareTarget
+	4,  // 33: media.CreateShareLinkRequest.target:type_name -> media.ShareTarget
+	54, // 34: media.CreateShareLinkResponse.link:type_name -> media.ShareLink
+	54, // 35: media.GetShareLinkResponse.link:type_name -> media.ShareLink
+	11, // 36: media.PublicVideo.metadata:type_name -> media.VideoMetadata
+	72, // 37: media.ListPublicVideosResponse.videos:type_name -> media.PublicVideo
+	11, // 38: media.GetEmbedResponse.metadata:type_name -> media.VideoMetadata
+	17, // 39: media.ListFavoritesResponse.videos:type_name -> media.VideoSummary
+	81, // 40: media.CreateExportRequest.filter:type_name -> media.ExportFilter
+	5,  // 41: media.CreateExportRequest.format:type_name -> media.ExportFormat
+	6,  // 42: media.Export.state:type_name -> media.ExportState
+	83, // 43: media.CreateExportResponse.export:type_name -> media.Export
+	83, // 44: media.GetExportResponse.export:type_name -> media.Export
+	11, // 45: media.ExportedFile.metadata:type_name -> media.VideoMetadata
+	89, // 46: media.ExportManifest.videos:type_name -> media.ExportedFile
+	1,  // 47: media.TranscodeEvent.state:type_name -> media.RenditionState
+	7,  // 48: media.MediaService.UploadVideo:input_type -> media.UploadVideoRequest
+	10, // 49: media.MediaService.DownloadVideo:input_type -> media.DownloadVideoRequest
+	14, // 50: media.MediaService.WatchProgress:input_type -> media.WatchProgressRequest
+	16, // 51: media.MediaService.ListVideos:input_type -> media.ListVideosRequest
+	19, // 52: media.MediaService.GetVideoMetadata:input_type -> media.GetVideoMetadataRequest
+	26, // 53: media.MediaService.CreateUploadSession:input_type -> media.CreateUploadSessionRequest
+	28, // 54: media.MediaService.GetUploadSession:input_type -> media.GetUploadSessionRequest
+	24, // 55: media.MediaService.GetUploadLimits:input_type -> media.GetUploadLimitsRequest
+	30, // 56: media.MediaService.AbortUpload:input_type -> media.AbortUploadRequest
+	21, // 57: media.MediaService.DeleteVideo:input_type -> media.DeleteVideoRequest
+	33, // 58: media.MediaService.CreatePlaylist:input_type -> media.CreatePlaylistRequest
+	35, // 59: media.MediaService.AddToPlaylist:input_type -> media.AddToPlaylistRequest
+	37, // 60: media.MediaService.ReorderPlaylist:input_type -> media.ReorderPlaylistRequest
+	39, // 61: media.MediaService.GetPlaylist:input_type -> media.GetPlaylistRequest
+	42, // 62: media.MediaService.CreateChannel:input_type -> media.CreateChannelRequest
+	44, // 63: media.MediaService.AssignVideoToChannel:input_type -> media.AssignVideoToChannelRequest
+	46, // 64: media.MediaService.ListPublicChannels:input_type -> media.ListPublicChannelsRequest
+	49, // 65: media.MediaService.LikeVideo:input_type -> media.LikeVideoRequest
+	51, // 66: media.MediaService.UnlikeVideo:input_type -> media.UnlikeVideoRequest
+	53, // 67: media.MediaService.ListFavorites:input_type -> media.ListFavoritesRequest
+	55, // 68: media.MediaService.CreateShareLink:input_type -> media.CreateShareLinkRequest
+	57, // 69: media.MediaService.CreateDownloadLink:input_type -> media.CreateDownloadLinkRequest
+	59, // 70: media.MediaService.GetShareLink:input_type -> media.GetShareLinkRequest
+	61, // 71: media.MediaService.ResolveShareLink:input_type -> media.ResolveShareLinkRequest
+	63, // 72: media.MediaService.SetThumbnail:input_type -> media.SetThumbnailRequest
+	65, // 73: media.MediaService.GetThumbnail:input_type -> media.GetThumbnailRequest
+	67, // 74: media.MediaService.SetAvatar:input_type -> media.SetAvatarRequest
+	69, // 75: media.MediaService.GetAvatar:input_type -> media.GetAvatarRequest
+	71, // 76: media.MediaService.ListPublicVideos:input_type -> media.ListPublicVideosRequest
+	74, // 77: media.MediaService.GetEmbed:input_type -> media.GetEmbedRequest
+	75, // 78: media.MediaService.GetHLSPlaylist:input_type -> media.GetHLSPlaylistRequest
+	77, // 79: media.MediaService.GetHLSSegment:input_type -> media.GetHLSSegmentRequest
+	82, // 80: media.MediaService.CreateExport:input_type -> media.CreateExportRequest
+	85, // 81: media.MediaService.GetExport:input_type -> media.GetExportRequest
+	87, // 82: media.MediaService.DownloadExport:input_type -> media.DownloadExportRequest
+	92, // 83: media.MediaService.ReportTranscode:input_type -> media.ReportTranscodeRequest
+	8,  // 84: media.MediaService.UploadVideo:output_type -> media.UploadVideoResponse
+	13, // 85: media.MediaService.DownloadVideo:output_type -> media.DownloadVideoResponse
+	15, // 86: media.MediaService.WatchProgress:output_type -> media.ProgressEvent
+	18, // 87: media.MediaService.ListVideos:output_type -> media.ListVideosResponse
+	20, // 88: media.MediaService.GetVideoMetadata:output_type -> media.GetVideoMetadataResponse
+	27, // 89: media.MediaService.CreateUploadSession:output_type -> media.CreateUploadSessionResponse
+	29, // 90: media.MediaService.GetUploadSession:output_type -> media.GetUploadSessionResponse
+	25, // 91: media.MediaService.GetUploadLimits:output_type -> media.GetUploadLimitsResponse
+	31, // 92: media.MediaService.AbortUpload:output_type -> media.AbortUploadResponse
+	22, // 93: media.MediaService.DeleteVideo:output_type -> media.DeleteVideoResponse
+	34, // 94: media.MediaService.CreatePlaylist:output_type -> media.CreatePlaylistResponse
+	36, // 95: media.MediaService.AddToPlaylist:output_type -> media.AddToPlaylistResponse
+	38, // 96: media.MediaService.ReorderPlaylist:output_type -> media.ReorderPlaylistResponse
+	40, // 97: media.MediaService.GetPlaylist:output_type -> media.GetPlaylistResponse
+	43, // 98: media.MediaService.CreateChannel:output_type -> media.CreateChannelResponse
+	45, // 99: media.MediaService.AssignVideoToChannel:output_type -> media.AssignVideoToChannelResponse
+	48, // 100: media.MediaService.ListPublicChannels:output_type -> media.ListPublicChannelsResponse
+	50, // 101: media.MediaService.LikeVideo:output_type -> media.LikeVideoResponse
+	52, // 102: media.MediaService.UnlikeVideo:output_type -> media.UnlikeVideoResponse
+	80, // 103: media.MediaService.ListFavorites:output_type -> media.ListFavoritesResponse
+	56, // 104: media.MediaService.CreateShareLink:output_type -> media.CreateShareLinkResponse
+	58, // 105: media.MediaService.CreateDownloadLink:output_type -> media.CreateDownloadLinkResponse
+	60, // 106: media.MediaService.GetShareLink:output_type -> media.GetShareLinkResponse
+	62, // 107: media.MediaService.ResolveShareLink:output_type -> media.ResolveShareLinkResponse
+	64, // 108: media.MediaService.SetThumbnail:output_type -> media.SetThumbnailResponse
+	66, // 109: media.MediaService.GetThumbnail:output_type -> media.GetThumbnailResponse
+	68, // 110: media.MediaService.SetAvatar:output_type -> media.SetAvatarResponse
+	70, // 111: media.MediaService.GetAvatar:output_type -> media.GetAvatarResponse
+	73, // 112: media.MediaService.ListPublicVideos:output_type -> media.ListPublicVideosResponse
+	79, // 113: media.MediaService.GetEmbed:output_type -> media.GetEmbedResponse
+	76, // 114: media.MediaService.GetHLSPlaylist:output_type -> media.GetHLSPlaylistResponse
+	78, // 115: media.MediaService.GetHLSSegment:output_type -> media.GetHLSSegmentResponse
+	84, // 116: media.MediaService.CreateExport:output_type -> media.CreateExportResponse
+	86, // 117: media.MediaService.GetExport:output_type -> media.GetExportResponse
+	88, // 118: media.MediaService.DownloadExport:output_type -> media.DownloadExportResponse
+	93, // 119: media.MediaService.ReportTranscode:output_type -> media.ReportTranscodeResponse
+	84, // [84:120] is the sub-list for method output_type
+	48, // [48:84] is the sub-list for method input_type
+	48, // [48:48] is the sub-list for extension type_name
+	48, // [48:48] is the sub-list for extension extendee
+	0,  // [0:48] is the sub-list for field type_name
 }
 
 func init() { file_media_media_proto_init() }
@@ -448,84 +6349,22 @@ func file_media_media_proto_init() {
 	if File_media_media_proto != nil {
 		return
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_media_media_proto_msgTypes[0].Exporter = func(v any, i int) any {
-			switch v := v.(*UploadVideoRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_media_media_proto_msgTypes[1].Exporter = func(v any, i int) any {
-			switch v := v.(*UploadVideoResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_media_media_proto_msgTypes[2].Exporter = func(v any, i int) any {
-			switch v := v.(*DownloadVideoRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_media_media_proto_msgTypes[3].Exporter = func(v any, i int) any {
-			switch v := v.(*VideoMetadata); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_media_media_proto_msgTypes[4].Exporter = func(v any, i int) any {
-			switch v := v.(*DownloadVideoResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_media_media_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   5,
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_media_media_proto_rawDesc), len(file_media_media_proto_rawDesc)),
+			NumEnums:      7,
+			NumMessages:   93,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_media_media_proto_goTypes,
 		DependencyIndexes: file_media_media_proto_depIdxs,
+		EnumInfos:         file_media_media_proto_enumTypes,
 		MessageInfos:      file_media_media_proto_msgTypes,
 	}.Build()
 	File_media_media_proto = out.File
-	file_media_media_proto_rawDesc = nil
 	file_media_media_proto_goTypes = nil
 	file_media_media_proto_depIdxs = nil
 }
@@ -19,20 +19,154 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	MediaService_UploadVideo_FullMethodName   = "/media.MediaService/UploadVideo"
-	MediaService_DownloadVideo_FullMethodName = "/media.MediaService/DownloadVideo"
+	MediaService_UploadVideo_FullMethodName          = "/media.MediaService/UploadVideo"
+	MediaService_DownloadVideo_FullMethodName        = "/media.MediaService/DownloadVideo"
+	MediaService_WatchProgress_FullMethodName        = "/media.MediaService/WatchProgress"
+	MediaService_ListVideos_FullMethodName           = "/media.MediaService/ListVideos"
+	MediaService_GetVideoMetadata_FullMethodName     = "/media.MediaService/GetVideoMetadata"
+	MediaService_CreateUploadSession_FullMethodName  = "/media.MediaService/CreateUploadSession"
+	MediaService_GetUploadSession_FullMethodName     = "/media.MediaService/GetUploadSession"
+	MediaService_GetUploadLimits_FullMethodName      = "/media.MediaService/GetUploadLimits"
+	MediaService_AbortUpload_FullMethodName          = "/media.MediaService/AbortUpload"
+	MediaService_DeleteVideo_FullMethodName          = "/media.MediaService/DeleteVideo"
+	MediaService_CreatePlaylist_FullMethodName       = "/media.MediaService/CreatePlaylist"
+	MediaService_AddToPlaylist_FullMethodName        = "/media.MediaService/AddToPlaylist"
+	MediaService_ReorderPlaylist_FullMethodName      = "/media.MediaService/ReorderPlaylist"
+	MediaService_GetPlaylist_FullMethodName          = "/media.MediaService/GetPlaylist"
+	MediaService_CreateChannel_FullMethodName        = "/media.MediaService/CreateChannel"
+	MediaService_AssignVideoToChannel_FullMethodName = "/media.MediaService/AssignVideoToChannel"
+	MediaService_ListPublicChannels_FullMethodName   = "/media.MediaService/ListPublicChannels"
+	MediaService_LikeVideo_FullMethodName            = "/media.MediaService/LikeVideo"
+	MediaService_UnlikeVideo_FullMethodName          = "/media.MediaService/UnlikeVideo"
+	MediaService_ListFavorites_FullMethodName        = "/media.MediaService/ListFavorites"
+	MediaService_CreateShareLink_FullMethodName      = "/media.MediaService/CreateShareLink"
+	MediaService_CreateDownloadLink_FullMethodName   = "/media.MediaService/CreateDownloadLink"
+	MediaService_GetShareLink_FullMethodName         = "/media.MediaService/GetShareLink"
+	MediaService_ResolveShareLink_FullMethodName     = "/media.MediaService/ResolveShareLink"
+	MediaService_SetThumbnail_FullMethodName         = "/media.MediaService/SetThumbnail"
+	MediaService_GetThumbnail_FullMethodName         = "/media.MediaService/GetThumbnail"
+	MediaService_SetAvatar_FullMethodName            = "/media.MediaService/SetAvatar"
+	MediaService_GetAvatar_FullMethodName            = "/media.MediaService/GetAvatar"
+	MediaService_ListPublicVideos_FullMethodName     = "/media.MediaService/ListPublicVideos"
+	MediaService_GetEmbed_FullMethodName             = "/media.MediaService/GetEmbed"
+	MediaService_GetHLSPlaylist_FullMethodName       = "/media.MediaService/GetHLSPlaylist"
+	MediaService_GetHLSSegment_FullMethodName        = "/media.MediaService/GetHLSSegment"
+	MediaService_CreateExport_FullMethodName         = "/media.MediaService/CreateExport"
+	MediaService_GetExport_FullMethodName            = "/media.MediaService/GetExport"
+	MediaService_DownloadExport_FullMethodName       = "/media.MediaService/DownloadExport"
+	MediaService_ReportTranscode_FullMethodName      = "/media.MediaService/ReportTranscode"
 )
 
 // MediaServiceClient is the client API for MediaService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 //
-// MediaService defines the media streaming service
+// MediaService defines the media streaming service. It is version 1 of the
+// API; media.v2.MediaService in media/v2/media.proto supersedes the RPCs
+// marked deprecated, whose responses carry a deprecation header.
 type MediaServiceClient interface {
-	// UploadVideo streams video chunks from client to server
+	// Deprecated: Do not use.
+	// UploadVideo streams video chunks from client to server. Deprecated: use
+	// the two-phase media.v2.MediaService.UploadVideo.
 	UploadVideo(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadVideoRequest, UploadVideoResponse], error)
-	// DownloadVideo streams video chunks from server to client
+	// Deprecated: Do not use.
+	// DownloadVideo streams video chunks from server to client. Deprecated:
+	// use media.v2.MediaService.DownloadVideo, which frames the chunks.
 	DownloadVideo(ctx context.Context, in *DownloadVideoRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadVideoResponse], error)
+	// WatchProgress streams upload and processing progress events for a video
+	WatchProgress(ctx context.Context, in *WatchProgressRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ProgressEvent], error)
+	// ListVideos lists the stored videos
+	ListVideos(ctx context.Context, in *ListVideosRequest, opts ...grpc.CallOption) (*ListVideosResponse, error)
+	// GetVideoMetadata returns the metadata of a single video
+	GetVideoMetadata(ctx context.Context, in *GetVideoMetadataRequest, opts ...grpc.CallOption) (*GetVideoMetadataResponse, error)
+	// CreateUploadSession starts a resumable upload; chunks sent to UploadVideo
+	// with the returned upload_id survive interrupted streams
+	CreateUploadSession(ctx context.Context, in *CreateUploadSessionRequest, opts ...grpc.CallOption) (*CreateUploadSessionResponse, error)
+	// GetUploadSession reports how far a resumable upload has progressed
+	GetUploadSession(ctx context.Context, in *GetUploadSessionRequest, opts ...grpc.CallOption) (*GetUploadSessionResponse, error)
+	// GetUploadLimits reports the limits uploads of the caller are held to,
+	// so that clients can check a long transfer before starting it
+	GetUploadLimits(ctx context.Context, in *GetUploadLimitsRequest, opts ...grpc.CallOption) (*GetUploadLimitsResponse, error)
+	// AbortUpload cancels a resumable upload and frees its stored bytes at
+	// once; a stream writing to it fails with CANCELLED
+	AbortUpload(ctx context.Context, in *AbortUploadRequest, opts ...grpc.CallOption) (*AbortUploadResponse, error)
+	// DeleteVideo removes a video; only its uploader may delete it
+	DeleteVideo(ctx context.Context, in *DeleteVideoRequest, opts ...grpc.CallOption) (*DeleteVideoResponse, error)
+	// CreatePlaylist starts an ordered collection of videos owned by the caller
+	CreatePlaylist(ctx context.Context, in *CreatePlaylistRequest, opts ...grpc.CallOption) (*CreatePlaylistResponse, error)
+	// AddToPlaylist inserts a video into one of the caller's playlists
+	AddToPlaylist(ctx context.Context, in *AddToPlaylistRequest, opts ...grpc.CallOption) (*AddToPlaylistResponse, error)
+	// ReorderPlaylist sets the order of all videos in one of the caller's playlists
+	ReorderPlaylist(ctx context.Context, in *ReorderPlaylistRequest, opts ...grpc.CallOption) (*ReorderPlaylistResponse, error)
+	// GetPlaylist returns a playlist with the metadata of its videos, in order
+	GetPlaylist(ctx context.Context, in *GetPlaylistRequest, opts ...grpc.CallOption) (*GetPlaylistResponse, error)
+	// CreateChannel creates a channel owned by the caller, or by the caller's
+	// tenant so that every user of the tenant can manage it
+	CreateChannel(ctx context.Context, in *CreateChannelRequest, opts ...grpc.CallOption) (*CreateChannelResponse, error)
+	// AssignVideoToChannel moves one of the caller's videos into a channel the
+	// caller manages; the video takes the channel's default visibility
+	AssignVideoToChannel(ctx context.Context, in *AssignVideoToChannelRequest, opts ...grpc.CallOption) (*AssignVideoToChannelResponse, error)
+	// ListPublicChannels lists public channels with their public videos; it
+	// needs no authentication
+	ListPublicChannels(ctx context.Context, in *ListPublicChannelsRequest, opts ...grpc.CallOption) (*ListPublicChannelsResponse, error)
+	// LikeVideo adds a video to the caller's favorites; liking it again has no
+	// effect
+	LikeVideo(ctx context.Context, in *LikeVideoRequest, opts ...grpc.CallOption) (*LikeVideoResponse, error)
+	// UnlikeVideo removes a video from the caller's favorites; unliking a video
+	// that is not liked has no effect
+	UnlikeVideo(ctx context.Context, in *UnlikeVideoRequest, opts ...grpc.CallOption) (*UnlikeVideoResponse, error)
+	// ListFavorites lists the videos the caller liked, most recently liked first
+	ListFavorites(ctx context.Context, in *ListFavoritesRequest, opts ...grpc.CallOption) (*ListFavoritesResponse, error)
+	// CreateShareLink creates a short link to a video the caller can view.
+	// The gateway serves it at /s/{code}
+	CreateShareLink(ctx context.Context, in *CreateShareLinkRequest, opts ...grpc.CallOption) (*CreateShareLinkResponse, error)
+	// CreateDownloadLink returns a short-lived URL from which a video the
+	// caller can view downloads without further credentials. It points at the
+	// CDN when one is configured, and at the gateway otherwise
+	CreateDownloadLink(ctx context.Context, in *CreateDownloadLinkRequest, opts ...grpc.CallOption) (*CreateDownloadLinkResponse, error)
+	// GetShareLink returns one of the caller's share links with its click count
+	GetShareLink(ctx context.Context, in *GetShareLinkRequest, opts ...grpc.CallOption) (*GetShareLinkResponse, error)
+	// ResolveShareLink counts a click on a share link and returns where it
+	// leads; it needs no authentication and backs the gateway's /s/{code}
+	ResolveShareLink(ctx context.Context, in *ResolveShareLinkRequest, opts ...grpc.CallOption) (*ResolveShareLinkResponse, error)
+	// SetThumbnail sets the preview image of one of the caller's videos
+	SetThumbnail(ctx context.Context, in *SetThumbnailRequest, opts ...grpc.CallOption) (*SetThumbnailResponse, error)
+	// GetThumbnail returns the preview image of a video; it needs no
+	// authentication and backs the gateway's thumbnail URLs
+	GetThumbnail(ctx context.Context, in *GetThumbnailRequest, opts ...grpc.CallOption) (*GetThumbnailResponse, error)
+	// SetAvatar sets the caller's profile picture, cropped to a square and
+	// scaled down to the server's avatar size
+	SetAvatar(ctx context.Context, in *SetAvatarRequest, opts ...grpc.CallOption) (*SetAvatarResponse, error)
+	// GetAvatar returns the profile picture of a user; it needs no
+	// authentication and backs the gateway's avatar URLs
+	GetAvatar(ctx context.Context, in *GetAvatarRequest, opts ...grpc.CallOption) (*GetAvatarResponse, error)
+	// ListPublicVideos lists the published videos, newest first; it needs no
+	// authentication and backs the gateway's cacheable /v1/public/videos
+	ListPublicVideos(ctx context.Context, in *ListPublicVideosRequest, opts ...grpc.CallOption) (*ListPublicVideosResponse, error)
+	// GetEmbed returns what the embeddable player needs to play a video the
+	// caller may view. A token is optional, so public videos play for anyone
+	GetEmbed(ctx context.Context, in *GetEmbedRequest, opts ...grpc.CallOption) (*GetEmbedResponse, error)
+	// GetHLSPlaylist returns the HLS playlist of an MPEG-TS video. Its segment
+	// URIs carry a signed token, so players need no credentials for them. A
+	// token is optional, as for GetEmbed
+	GetHLSPlaylist(ctx context.Context, in *GetHLSPlaylistRequest, opts ...grpc.CallOption) (*GetHLSPlaylistResponse, error)
+	// GetHLSSegment returns one segment of an HLS playlist; it is authorized
+	// by the segment token from the playlist instead of a JWT
+	GetHLSSegment(ctx context.Context, in *GetHLSSegmentRequest, opts ...grpc.CallOption) (*GetHLSSegmentResponse, error)
+	// CreateExport starts building, in the background, a zip or tar archive
+	// of several videos the caller can view, or a manifest of signed URLs to
+	// them, for bulk retrieval such as all recordings of a track. Poll
+	// GetExport until it is ready, then fetch it with DownloadExport
+	CreateExport(ctx context.Context, in *CreateExportRequest, opts ...grpc.CallOption) (*CreateExportResponse, error)
+	// GetExport reports the progress of one of the caller's exports
+	GetExport(ctx context.Context, in *GetExportRequest, opts ...grpc.CallOption) (*GetExportResponse, error)
+	// DownloadExport streams a finished export until it expires
+	DownloadExport(ctx context.Context, in *DownloadExportRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadExportResponse], error)
+	// ReportTranscode receives the callbacks of an external transcoder about
+	// the renditions of a video. It is authorized by the HMAC signature of the
+	// payload instead of a JWT, and backs the gateway's
+	// /v1/webhooks/transcoder
+	ReportTranscode(ctx context.Context, in *ReportTranscodeRequest, opts ...grpc.CallOption) (*ReportTranscodeResponse, error)
 }
 
 type mediaServiceClient struct {
@@ -43,6 +177,7 @@ func NewMediaServiceClient(cc grpc.ClientConnInterface) MediaServiceClient {
 	return &mediaServiceClient{cc}
 }
 
+// Deprecated: Do not use.
 func (c *mediaServiceClient) UploadVideo(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadVideoRequest, UploadVideoResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	stream, err := c.cc.NewStream(ctx, &MediaService_ServiceDesc.Streams[0], MediaService_UploadVideo_FullMethodName, cOpts...)
@@ -56,6 +191,7 @@ func (c *mediaServiceClient) UploadVideo(ctx context.Context, opts ...grpc.CallO
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type MediaService_UploadVideoClient = grpc.ClientStreamingClient[UploadVideoRequest, UploadVideoResponse]
 
+// Deprecated: Do not use.
 func (c *mediaServiceClient) DownloadVideo(ctx context.Context, in *DownloadVideoRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadVideoResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	stream, err := c.cc.NewStream(ctx, &MediaService_ServiceDesc.Streams[1], MediaService_DownloadVideo_FullMethodName, cOpts...)
@@ -75,16 +211,474 @@ func (c *mediaServiceClient) DownloadVideo(ctx context.Context, in *DownloadVide
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type MediaService_DownloadVideoClient = grpc.ServerStreamingClient[DownloadVideoResponse]
 
+func (c *mediaServiceClient) WatchProgress(ctx context.Context, in *WatchProgressRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ProgressEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MediaService_ServiceDesc.Streams[2], MediaService_WatchProgress_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchProgressRequest, ProgressEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MediaService_WatchProgressClient = grpc.ServerStreamingClient[ProgressEvent]
+
+func (c *mediaServiceClient) ListVideos(ctx context.Context, in *ListVideosRequest, opts ...grpc.CallOption) (*ListVideosResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListVideosResponse)
+	err := c.cc.Invoke(ctx, MediaService_ListVideos_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) GetVideoMetadata(ctx context.Context, in *GetVideoMetadataRequest, opts ...grpc.CallOption) (*GetVideoMetadataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetVideoMetadataResponse)
+	err := c.cc.Invoke(ctx, MediaService_GetVideoMetadata_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) CreateUploadSession(ctx context.Context, in *CreateUploadSessionRequest, opts ...grpc.CallOption) (*CreateUploadSessionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateUploadSessionResponse)
+	err := c.cc.Invoke(ctx, MediaService_CreateUploadSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) GetUploadSession(ctx context.Context, in *GetUploadSessionRequest, opts ...grpc.CallOption) (*GetUploadSessionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUploadSessionResponse)
+	err := c.cc.Invoke(ctx, MediaService_GetUploadSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) GetUploadLimits(ctx context.Context, in *GetUploadLimitsRequest, opts ...grpc.CallOption) (*GetUploadLimitsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUploadLimitsResponse)
+	err := c.cc.Invoke(ctx, MediaService_GetUploadLimits_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) AbortUpload(ctx context.Context, in *AbortUploadRequest, opts ...grpc.CallOption) (*AbortUploadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AbortUploadResponse)
+	err := c.cc.Invoke(ctx, MediaService_AbortUpload_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) DeleteVideo(ctx context.Context, in *DeleteVideoRequest, opts ...grpc.CallOption) (*DeleteVideoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteVideoResponse)
+	err := c.cc.Invoke(ctx, MediaService_DeleteVideo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) CreatePlaylist(ctx context.Context, in *CreatePlaylistRequest, opts ...grpc.CallOption) (*CreatePlaylistResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreatePlaylistResponse)
+	err := c.cc.Invoke(ctx, MediaService_CreatePlaylist_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) AddToPlaylist(ctx context.Context, in *AddToPlaylistRequest, opts ...grpc.CallOption) (*AddToPlaylistResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddToPlaylistResponse)
+	err := c.cc.Invoke(ctx, MediaService_AddToPlaylist_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) ReorderPlaylist(ctx context.Context, in *ReorderPlaylistRequest, opts ...grpc.CallOption) (*ReorderPlaylistResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReorderPlaylistResponse)
+	err := c.cc.Invoke(ctx, MediaService_ReorderPlaylist_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) GetPlaylist(ctx context.Context, in *GetPlaylistRequest, opts ...grpc.CallOption) (*GetPlaylistResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPlaylistResponse)
+	err := c.cc.Invoke(ctx, MediaService_GetPlaylist_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) CreateChannel(ctx context.Context, in *CreateChannelRequest, opts ...grpc.CallOption) (*CreateChannelResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateChannelResponse)
+	err := c.cc.Invoke(ctx, MediaService_CreateChannel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) AssignVideoToChannel(ctx context.Context, in *AssignVideoToChannelRequest, opts ...grpc.CallOption) (*AssignVideoToChannelResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AssignVideoToChannelResponse)
+	err := c.cc.Invoke(ctx, MediaService_AssignVideoToChannel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) ListPublicChannels(ctx context.Context, in *ListPublicChannelsRequest, opts ...grpc.CallOption) (*ListPublicChannelsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPublicChannelsResponse)
+	err := c.cc.Invoke(ctx, MediaService_ListPublicChannels_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) LikeVideo(ctx context.Context, in *LikeVideoRequest, opts ...grpc.CallOption) (*LikeVideoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LikeVideoResponse)
+	err := c.cc.Invoke(ctx, MediaService_LikeVideo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) UnlikeVideo(ctx context.Context, in *UnlikeVideoRequest, opts ...grpc.CallOption) (*UnlikeVideoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnlikeVideoResponse)
+	err := c.cc.Invoke(ctx, MediaService_UnlikeVideo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) ListFavorites(ctx context.Context, in *ListFavoritesRequest, opts ...grpc.CallOption) (*ListFavoritesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFavoritesResponse)
+	err := c.cc.Invoke(ctx, MediaService_ListFavorites_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) CreateShareLink(ctx context.Context, in *CreateShareLinkRequest, opts ...grpc.CallOption) (*CreateShareLinkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateShareLinkResponse)
+	err := c.cc.Invoke(ctx, MediaService_CreateShareLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) CreateDownloadLink(ctx context.Context, in *CreateDownloadLinkRequest, opts ...grpc.CallOption) (*CreateDownloadLinkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateDownloadLinkResponse)
+	err := c.cc.Invoke(ctx, MediaService_CreateDownloadLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) GetShareLink(ctx context.Context, in *GetShareLinkRequest, opts ...grpc.CallOption) (*GetShareLinkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetShareLinkResponse)
+	err := c.cc.Invoke(ctx, MediaService_GetShareLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) ResolveShareLink(ctx context.Context, in *ResolveShareLinkRequest, opts ...grpc.CallOption) (*ResolveShareLinkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResolveShareLinkResponse)
+	err := c.cc.Invoke(ctx, MediaService_ResolveShareLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) SetThumbnail(ctx context.Context, in *SetThumbnailRequest, opts ...grpc.CallOption) (*SetThumbnailResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetThumbnailResponse)
+	err := c.cc.Invoke(ctx, MediaService_SetThumbnail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) GetThumbnail(ctx context.Context, in *GetThumbnailRequest, opts ...grpc.CallOption) (*GetThumbnailResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetThumbnailResponse)
+	err := c.cc.Invoke(ctx, MediaService_GetThumbnail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) SetAvatar(ctx context.Context, in *SetAvatarRequest, opts ...grpc.CallOption) (*SetAvatarResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetAvatarResponse)
+	err := c.cc.Invoke(ctx, MediaService_SetAvatar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) GetAvatar(ctx context.Context, in *GetAvatarRequest, opts ...grpc.CallOption) (*GetAvatarResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAvatarResponse)
+	err := c.cc.Invoke(ctx, MediaService_GetAvatar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) ListPublicVideos(ctx context.Context, in *ListPublicVideosRequest, opts ...grpc.CallOption) (*ListPublicVideosResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPublicVideosResponse)
+	err := c.cc.Invoke(ctx, MediaService_ListPublicVideos_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) GetEmbed(ctx context.Context, in *GetEmbedRequest, opts ...grpc.CallOption) (*GetEmbedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetEmbedResponse)
+	err := c.cc.Invoke(ctx, MediaService_GetEmbed_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) GetHLSPlaylist(ctx context.Context, in *GetHLSPlaylistRequest, opts ...grpc.CallOption) (*GetHLSPlaylistResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetHLSPlaylistResponse)
+	err := c.cc.Invoke(ctx, MediaService_GetHLSPlaylist_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) GetHLSSegment(ctx context.Context, in *GetHLSSegmentRequest, opts ...grpc.CallOption) (*GetHLSSegmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetHLSSegmentResponse)
+	err := c.cc.Invoke(ctx, MediaService_GetHLSSegment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) CreateExport(ctx context.Context, in *CreateExportRequest, opts ...grpc.CallOption) (*CreateExportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateExportResponse)
+	err := c.cc.Invoke(ctx, MediaService_CreateExport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) GetExport(ctx context.Context, in *GetExportRequest, opts ...grpc.CallOption) (*GetExportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetExportResponse)
+	err := c.cc.Invoke(ctx, MediaService_GetExport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) DownloadExport(ctx context.Context, in *DownloadExportRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadExportResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MediaService_ServiceDesc.Streams[3], MediaService_DownloadExport_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DownloadExportRequest, DownloadExportResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MediaService_DownloadExportClient = grpc.ServerStreamingClient[DownloadExportResponse]
+
+func (c *mediaServiceClient) ReportTranscode(ctx context.Context, in *ReportTranscodeRequest, opts ...grpc.CallOption) (*ReportTranscodeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReportTranscodeResponse)
+	err := c.cc.Invoke(ctx, MediaService_ReportTranscode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MediaServiceServer is the server API for MediaService service.
 // All implementations must embed UnimplementedMediaServiceServer
 // for forward compatibility.
 //
-// MediaService defines the media streaming service
+// MediaService defines the media streaming service. It is version 1 of the
+// API; media.v2.MediaService in media/v2/media.proto supersedes the RPCs
+// marked deprecated, whose responses carry a deprecation header.
 type MediaServiceServer interface {
-	// UploadVideo streams video chunks from client to server
+	// Deprecated: Do not use.
+	// UploadVideo streams video chunks from client to server. Deprecated: use
+	// the two-phase media.v2.MediaService.UploadVideo.
 	UploadVideo(grpc.ClientStreamingServer[UploadVideoRequest, UploadVideoResponse]) error
-	// DownloadVideo streams video chunks from server to client
+	// Deprecated: Do not use.
+	// DownloadVideo streams video chunks from server to client. Deprecated:
+	// use media.v2.MediaService.DownloadVideo, which frames the chunks.
 	DownloadVideo(*DownloadVideoRequest, grpc.ServerStreamingServer[DownloadVideoResponse]) error
+	// WatchProgress streams upload and processing progress events for a video
+	WatchProgress(*WatchProgressRequest, grpc.ServerStreamingServer[ProgressEvent]) error
+	// ListVideos lists the stored videos
+	ListVideos(context.Context, *ListVideosRequest) (*ListVideosResponse, error)
+	// GetVideoMetadata returns the metadata of a single video
+	GetVideoMetadata(context.Context, *GetVideoMetadataRequest) (*GetVideoMetadataResponse, error)
+	// CreateUploadSession starts a resumable upload; chunks sent to UploadVideo
+	// with the returned upload_id survive interrupted streams
+	CreateUploadSession(context.Context, *CreateUploadSessionRequest) (*CreateUploadSessionResponse, error)
+	// GetUploadSession reports how far a resumable upload has progressed
+	GetUploadSession(context.Context, *GetUploadSessionRequest) (*GetUploadSessionResponse, error)
+	// GetUploadLimits reports the limits uploads of the caller are held to,
+	// so that clients can check a long transfer before starting it
+	GetUploadLimits(context.Context, *GetUploadLimitsRequest) (*GetUploadLimitsResponse, error)
+	// AbortUpload cancels a resumable upload and frees its stored bytes at
+	// once; a stream writing to it fails with CANCELLED
+	AbortUpload(context.Context, *AbortUploadRequest) (*AbortUploadResponse, error)
+	// DeleteVideo removes a video; only its uploader may delete it
+	DeleteVideo(context.Context, *DeleteVideoRequest) (*DeleteVideoResponse, error)
+	// CreatePlaylist starts an ordered collection of videos owned by the caller
+	CreatePlaylist(context.Context, *CreatePlaylistRequest) (*CreatePlaylistResponse, error)
+	// AddToPlaylist inserts a video into one of the caller's playlists
+	AddToPlaylist(context.Context, *AddToPlaylistRequest) (*AddToPlaylistResponse, error)
+	// ReorderPlaylist sets the order of all videos in one of the caller's playlists
+	ReorderPlaylist(context.Context, *ReorderPlaylistRequest) (*ReorderPlaylistResponse, error)
+	// GetPlaylist returns a playlist with the metadata of its videos, in order
+	GetPlaylist(context.Context, *GetPlaylistRequest) (*GetPlaylistResponse, error)
+	// CreateChannel creates a channel owned by the caller, or by the caller's
+	// tenant so that every user of the tenant can manage it
+	CreateChannel(context.Context, *CreateChannelRequest) (*CreateChannelResponse, error)
+	// AssignVideoToChannel moves one of the caller's videos into a channel the
+	// caller manages; the video takes the channel's default visibility
+	AssignVideoToChannel(context.Context, *AssignVideoToChannelRequest) (*AssignVideoToChannelResponse, error)
+	// ListPublicChannels lists public channels with their public videos; it
+	// needs no authentication
+	ListPublicChannels(context.Context, *ListPublicChannelsRequest) (*ListPublicChannelsResponse, error)
+	// LikeVideo adds a video to the caller's favorites; liking it again has no
+	// effect
+	LikeVideo(context.Context, *LikeVideoRequest) (*LikeVideoResponse, error)
+	// UnlikeVideo removes a video from the caller's favorites; unliking a video
+	// that is not liked has no effect
+	UnlikeVideo(context.Context, *UnlikeVideoRequest) (*UnlikeVideoResponse, error)
+	// ListFavorites lists the videos the caller liked, most recently liked first
+	ListFavorites(context.Context, *ListFavoritesRequest) (*ListFavoritesResponse, error)
+	// CreateShareLink creates a short link to a video the caller can view.
+	// The gateway serves it at /s/{code}
+	CreateShareLink(context.Context, *CreateShareLinkRequest) (*CreateShareLinkResponse, error)
+	// CreateDownloadLink returns a short-lived URL from which a video the
+	// caller can view downloads without further credentials. It points at the
+	// CDN when one is configured, and at the gateway otherwise
+	CreateDownloadLink(context.Context, *CreateDownloadLinkRequest) (*CreateDownloadLinkResponse, error)
+	// GetShareLink returns one of the caller's share links with its click count
+	GetShareLink(context.Context, *GetShareLinkRequest) (*GetShareLinkResponse, error)
+	// ResolveShareLink counts a click on a share link and returns where it
+	// leads; it needs no authentication and backs the gateway's /s/{code}
+	ResolveShareLink(context.Context, *ResolveShareLinkRequest) (*ResolveShareLinkResponse, error)
+	// SetThumbnail sets the preview image of one of the caller's videos
+	SetThumbnail(context.Context, *SetThumbnailRequest) (*SetThumbnailResponse, error)
+	// GetThumbnail returns the preview image of a video; it needs no
+	// authentication and backs the gateway's thumbnail URLs
+	GetThumbnail(context.Context, *GetThumbnailRequest) (*GetThumbnailResponse, error)
+	// SetAvatar sets the caller's profile picture, cropped to a square and
+	// scaled down to the server's avatar size
+	SetAvatar(context.Context, *SetAvatarRequest) (*SetAvatarResponse, error)
+	// GetAvatar returns the profile picture of a user; it needs no
+	// authentication and backs the gateway's avatar URLs
+	GetAvatar(context.Context, *GetAvatarRequest) (*GetAvatarResponse, error)
+	// ListPublicVideos lists the published videos, newest first; it needs no
+	// authentication and backs the gateway's cacheable /v1/public/videos
+	ListPublicVideos(context.Context, *ListPublicVideosRequest) (*ListPublicVideosResponse, error)
+	// GetEmbed returns what the embeddable player needs to play a video the
+	// caller may view. A token is optional, so public videos play for anyone
+	GetEmbed(context.Context, *GetEmbedRequest) (*GetEmbedResponse, error)
+	// GetHLSPlaylist returns the HLS playlist of an MPEG-TS video. Its segment
+	// URIs carry a signed token, so players need no credentials for them. A
+	// token is optional, as for GetEmbed
+	GetHLSPlaylist(context.Context, *GetHLSPlaylistRequest) (*GetHLSPlaylistResponse, error)
+	// GetHLSSegment returns one segment of an HLS playlist; it is authorized
+	// by the segment token from the playlist instead of a JWT
+	GetHLSSegment(context.Context, *GetHLSSegmentRequest) (*GetHLSSegmentResponse, error)
+	// CreateExport starts building, in the background, a zip or tar archive
+	// of several videos the caller can view, or a manifest of signed URLs to
+	// them, for bulk retrieval such as all recordings of a track. Poll
+	// GetExport until it is ready, then fetch it with DownloadExport
+	CreateExport(context.Context, *CreateExportRequest) (*CreateExportResponse, error)
+	// GetExport reports the progress of one of the caller's exports
+	GetExport(context.Context, *GetExportRequest) (*GetExportResponse, error)
+	// DownloadExport streams a finished export until it expires
+	DownloadExport(*DownloadExportRequest, grpc.ServerStreamingServer[DownloadExportResponse]) error
+	// ReportTranscode receives the callbacks of an external transcoder about
+	// the renditions of a video. It is authorized by the HMAC signature of the
+	// payload instead of a JWT, and backs the gateway's
+	// /v1/webhooks/transcoder
+	ReportTranscode(context.Context, *ReportTranscodeRequest) (*ReportTranscodeResponse, error)
 	mustEmbedUnimplementedMediaServiceServer()
 }
 
@@ -101,6 +695,108 @@ func (UnimplementedMediaServiceServer) UploadVideo(grpc.ClientStreamingServer[Up
 func (UnimplementedMediaServiceServer) DownloadVideo(*DownloadVideoRequest, grpc.ServerStreamingServer[DownloadVideoResponse]) error {
 	return status.Errorf(codes.Unimplemented, "method DownloadVideo not implemented")
 }
+func (UnimplementedMediaServiceServer) WatchProgress(*WatchProgressRequest, grpc.ServerStreamingServer[ProgressEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchProgress not implemented")
+}
+func (UnimplementedMediaServiceServer) ListVideos(context.Context, *ListVideosRequest) (*ListVideosResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListVideos not implemented")
+}
+func (UnimplementedMediaServiceServer) GetVideoMetadata(context.Context, *GetVideoMetadataRequest) (*GetVideoMetadataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVideoMetadata not implemented")
+}
+func (UnimplementedMediaServiceServer) CreateUploadSession(context.Context, *CreateUploadSessionRequest) (*CreateUploadSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUploadSession not implemented")
+}
+func (UnimplementedMediaServiceServer) GetUploadSession(context.Context, *GetUploadSessionRequest) (*GetUploadSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUploadSession not implemented")
+}
+func (UnimplementedMediaServiceServer) GetUploadLimits(context.Context, *GetUploadLimitsRequest) (*GetUploadLimitsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUploadLimits not implemented")
+}
+func (UnimplementedMediaServiceServer) AbortUpload(context.Context, *AbortUploadRequest) (*AbortUploadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AbortUpload not implemented")
+}
+func (UnimplementedMediaServiceServer) DeleteVideo(context.Context, *DeleteVideoRequest) (*DeleteVideoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteVideo not implemented")
+}
+func (UnimplementedMediaServiceServer) CreatePlaylist(context.Context, *CreatePlaylistRequest) (*CreatePlaylistResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePlaylist not implemented")
+}
+func (UnimplementedMediaServiceServer) AddToPlaylist(context.Context, *AddToPlaylistRequest) (*AddToPlaylistResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddToPlaylist not implemented")
+}
+func (UnimplementedMediaServiceServer) ReorderPlaylist(context.Context, *ReorderPlaylistRequest) (*ReorderPlaylistResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReorderPlaylist not implemented")
+}
+func (UnimplementedMediaServiceServer) GetPlaylist(context.Context, *GetPlaylistRequest) (*GetPlaylistResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPlaylist not implemented")
+}
+func (UnimplementedMediaServiceServer) CreateChannel(context.Context, *CreateChannelRequest) (*CreateChannelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateChannel not implemented")
+}
+func (UnimplementedMediaServiceServer) AssignVideoToChannel(context.Context, *AssignVideoToChannelRequest) (*AssignVideoToChannelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AssignVideoToChannel not implemented")
+}
+func (UnimplementedMediaServiceServer) ListPublicChannels(context.Context, *ListPublicChannelsRequest) (*ListPublicChannelsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPublicChannels not implemented")
+}
+func (UnimplementedMediaServiceServer) LikeVideo(context.Context, *LikeVideoRequest) (*LikeVideoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LikeVideo not implemented")
+}
+func (UnimplementedMediaServiceServer) UnlikeVideo(context.Context, *UnlikeVideoRequest) (*UnlikeVideoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnlikeVideo not implemented")
+}
+func (UnimplementedMediaServiceServer) ListFavorites(context.Context, *ListFavoritesRequest) (*ListFavoritesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFavorites not implemented")
+}
+func (UnimplementedMediaServiceServer) CreateShareLink(context.Context, *CreateShareLinkRequest) (*CreateShareLinkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateShareLink not implemented")
+}
+func (UnimplementedMediaServiceServer) CreateDownloadLink(context.Context, *CreateDownloadLinkRequest) (*CreateDownloadLinkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateDownloadLink not implemented")
+}
+func (UnimplementedMediaServiceServer) GetShareLink(context.Context, *GetShareLinkRequest) (*GetShareLinkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShareLink not implemented")
+}
+func (UnimplementedMediaServiceServer) ResolveShareLink(context.Context, *ResolveShareLinkRequest) (*ResolveShareLinkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveShareLink not implemented")
+}
+func (UnimplementedMediaServiceServer) SetThumbnail(context.Context, *SetThumbnailRequest) (*SetThumbnailResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetThumbnail not implemented")
+}
+func (UnimplementedMediaServiceServer) GetThumbnail(context.Context, *GetThumbnailRequest) (*GetThumbnailResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetThumbnail not implemented")
+}
+func (UnimplementedMediaServiceServer) SetAvatar(context.Context, *SetAvatarRequest) (*SetAvatarResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetAvatar not implemented")
+}
+func (UnimplementedMediaServiceServer) GetAvatar(context.Context, *GetAvatarRequest) (*GetAvatarResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAvatar not implemented")
+}
+func (UnimplementedMediaServiceServer) ListPublicVideos(context.Context, *ListPublicVideosRequest) (*ListPublicVideosResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPublicVideos not implemented")
+}
+func (UnimplementedMediaServiceServer) GetEmbed(context.Context, *GetEmbedRequest) (*GetEmbedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEmbed not implemented")
+}
+func (UnimplementedMediaServiceServer) GetHLSPlaylist(context.Context, *GetHLSPlaylistRequest) (*GetHLSPlaylistResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHLSPlaylist not implemented")
+}
+func (UnimplementedMediaServiceServer) GetHLSSegment(context.Context, *GetHLSSegmentRequest) (*GetHLSSegmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHLSSegment not implemented")
+}
+func (UnimplementedMediaServiceServer) CreateExport(context.Context, *CreateExportRequest) (*CreateExportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateExport not implemented")
+}
+func (UnimplementedMediaServiceServer) GetExport(context.Context, *GetExportRequest) (*GetExportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExport not implemented")
+}
+func (UnimplementedMediaServiceServer) DownloadExport(*DownloadExportRequest, grpc.ServerStreamingServer[DownloadExportResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadExport not implemented")
+}
+func (UnimplementedMediaServiceServer) ReportTranscode(context.Context, *ReportTranscodeRequest) (*ReportTranscodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportTranscode not implemented")
+}
 func (UnimplementedMediaServiceServer) mustEmbedUnimplementedMediaServiceServer() {}
 func (UnimplementedMediaServiceServer) testEmbeddedByValue()                      {}
 
@@ -140,13 +836,740 @@ func _MediaService_DownloadVideo_Handler(srv interface{}, stream grpc.ServerStre
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type MediaService_DownloadVideoServer = grpc.ServerStreamingServer[DownloadVideoResponse]
 
+func _MediaService_WatchProgress_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchProgressRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MediaServiceServer).WatchProgress(m, &grpc.GenericServerStream[WatchProgressRequest, ProgressEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MediaService_WatchProgressServer = grpc.ServerStreamingServer[ProgressEvent]
+
+func _MediaService_ListVideos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListVideosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).ListVideos(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_ListVideos_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).ListVideos(ctx, req.(*ListVideosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_GetVideoMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVideoMetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).GetVideoMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_GetVideoMetadata_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).GetVideoMetadata(ctx, req.(*GetVideoMetadataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_CreateUploadSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateUploadSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).CreateUploadSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_CreateUploadSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).CreateUploadSession(ctx, req.(*CreateUploadSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_GetUploadSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUploadSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).GetUploadSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_GetUploadSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).GetUploadSession(ctx, req.(*GetUploadSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_GetUploadLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUploadLimitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).GetUploadLimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_GetUploadLimits_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).GetUploadLimits(ctx, req.(*GetUploadLimitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_AbortUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AbortUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).AbortUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_AbortUpload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).AbortUpload(ctx, req.(*AbortUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_DeleteVideo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteVideoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).DeleteVideo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_DeleteVideo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).DeleteVideo(ctx, req.(*DeleteVideoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_CreatePlaylist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePlaylistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).CreatePlaylist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_CreatePlaylist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).CreatePlaylist(ctx, req.(*CreatePlaylistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_AddToPlaylist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddToPlaylistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).AddToPlaylist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_AddToPlaylist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).AddToPlaylist(ctx, req.(*AddToPlaylistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_ReorderPlaylist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReorderPlaylistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).ReorderPlaylist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_ReorderPlaylist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).ReorderPlaylist(ctx, req.(*ReorderPlaylistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_GetPlaylist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPlaylistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).GetPlaylist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_GetPlaylist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).GetPlaylist(ctx, req.(*GetPlaylistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_CreateChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateChannelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).CreateChannel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_CreateChannel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).CreateChannel(ctx, req.(*CreateChannelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_AssignVideoToChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssignVideoToChannelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).AssignVideoToChannel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_AssignVideoToChannel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).AssignVideoToChannel(ctx, req.(*AssignVideoToChannelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_ListPublicChannels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPublicChannelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).ListPublicChannels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_ListPublicChannels_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).ListPublicChannels(ctx, req.(*ListPublicChannelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_LikeVideo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LikeVideoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).LikeVideo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_LikeVideo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).LikeVideo(ctx, req.(*LikeVideoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_UnlikeVideo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlikeVideoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).UnlikeVideo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_UnlikeVideo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).UnlikeVideo(ctx, req.(*UnlikeVideoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_ListFavorites_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFavoritesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).ListFavorites(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_ListFavorites_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).ListFavorites(ctx, req.(*ListFavoritesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_CreateShareLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateShareLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).CreateShareLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_CreateShareLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).CreateShareLink(ctx, req.(*CreateShareLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_CreateDownloadLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateDownloadLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).CreateDownloadLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_CreateDownloadLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).CreateDownloadLink(ctx, req.(*CreateDownloadLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_GetShareLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetShareLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).GetShareLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_GetShareLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).GetShareLink(ctx, req.(*GetShareLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_ResolveShareLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveShareLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).ResolveShareLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_ResolveShareLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).ResolveShareLink(ctx, req.(*ResolveShareLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_SetThumbnail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetThumbnailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).SetThumbnail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_SetThumbnail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).SetThumbnail(ctx, req.(*SetThumbnailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_GetThumbnail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetThumbnailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).GetThumbnail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_GetThumbnail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).GetThumbnail(ctx, req.(*GetThumbnailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_SetAvatar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetAvatarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).SetAvatar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_SetAvatar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).SetAvatar(ctx, req.(*SetAvatarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_GetAvatar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAvatarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).GetAvatar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_GetAvatar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).GetAvatar(ctx, req.(*GetAvatarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_ListPublicVideos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPublicVideosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).ListPublicVideos(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_ListPublicVideos_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).ListPublicVideos(ctx, req.(*ListPublicVideosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_GetEmbed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEmbedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).GetEmbed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_GetEmbed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).GetEmbed(ctx, req.(*GetEmbedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_GetHLSPlaylist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHLSPlaylistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).GetHLSPlaylist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_GetHLSPlaylist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).GetHLSPlaylist(ctx, req.(*GetHLSPlaylistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_GetHLSSegment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHLSSegmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).GetHLSSegment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_GetHLSSegment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).GetHLSSegment(ctx, req.(*GetHLSSegmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_CreateExport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateExportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).CreateExport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_CreateExport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).CreateExport(ctx, req.(*CreateExportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_GetExport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetExportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).GetExport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_GetExport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).GetExport(ctx, req.(*GetExportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_DownloadExport_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadExportRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MediaServiceServer).DownloadExport(m, &grpc.GenericServerStream[DownloadExportRequest, DownloadExportResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MediaService_DownloadExportServer = grpc.ServerStreamingServer[DownloadExportResponse]
+
+func _MediaService_ReportTranscode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportTranscodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).ReportTranscode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_ReportTranscode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).ReportTranscode(ctx, req.(*ReportTranscodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // MediaService_ServiceDesc is the grpc.ServiceDesc for MediaService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var MediaService_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "media.MediaService",
 	HandlerType: (*MediaServiceServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListVideos",
+			Handler:    _MediaService_ListVideos_Handler,
+		},
+		{
+			MethodName: "GetVideoMetadata",
+			Handler:    _MediaService_GetVideoMetadata_Handler,
+		},
+		{
+			MethodName: "CreateUploadSession",
+			Handler:    _MediaService_CreateUploadSession_Handler,
+		},
+		{
+			MethodName: "GetUploadSession",
+			Handler:    _MediaService_GetUploadSession_Handler,
+		},
+		{
+			MethodName: "GetUploadLimits",
+			Handler:    _MediaService_GetUploadLimits_Handler,
+		},
+		{
+			MethodName: "AbortUpload",
+			Handler:    _MediaService_AbortUpload_Handler,
+		},
+		{
+			MethodName: "DeleteVideo",
+			Handler:    _MediaService_DeleteVideo_Handler,
+		},
+		{
+			MethodName: "CreatePlaylist",
+			Handler:    _MediaService_CreatePlaylist_Handler,
+		},
+		{
+			MethodName: "AddToPlaylist",
+			Handler:    _MediaService_AddToPlaylist_Handler,
+		},
+		{
+			MethodName: "ReorderPlaylist",
+			Handler:    _MediaService_ReorderPlaylist_Handler,
+		},
+		{
+			MethodName: "GetPlaylist",
+			Handler:    _MediaService_GetPlaylist_Handler,
+		},
+		{
+			MethodName: "CreateChannel",
+			Handler:    _MediaService_CreateChannel_Handler,
+		},
+		{
+			MethodName: "AssignVideoToChannel",
+			Handler:    _MediaService_AssignVideoToChannel_Handler,
+		},
+		{
+			MethodName: "ListPublicChannels",
+			Handler:    _MediaService_ListPublicChannels_Handler,
+		},
+		{
+			MethodName: "LikeVideo",
+			Handler:    _MediaService_LikeVideo_Handler,
+		},
+		{
+			MethodName: "UnlikeVideo",
+			Handler:    _MediaService_UnlikeVideo_Handler,
+		},
+		{
+			MethodName: "ListFavorites",
+			Handler:    _MediaService_ListFavorites_Handler,
+		},
+		{
+			MethodName: "CreateShareLink",
+			Handler:    _MediaService_CreateShareLink_Handler,
+		},
+		{
+			MethodName: "CreateDownloadLink",
+			Handler:    _MediaService_CreateDownloadLink_Handler,
+		},
+		{
+			MethodName: "GetShareLink",
+			Handler:    _MediaService_GetShareLink_Handler,
+		},
+		{
+			MethodName: "ResolveShareLink",
+			Handler:    _MediaService_ResolveShareLink_Handler,
+		},
+		{
+			MethodName: "SetThumbnail",
+			Handler:    _MediaService_SetThumbnail_Handler,
+		},
+		{
+			MethodName: "GetThumbnail",
+			Handler:    _MediaService_GetThumbnail_Handler,
+		},
+		{
+			MethodName: "SetAvatar",
+			Handler:    _MediaService_SetAvatar_Handler,
+		},
+		{
+			MethodName: "GetAvatar",
+			Handler:    _MediaService_GetAvatar_Handler,
+		},
+		{
+			MethodName: "ListPublicVideos",
+			Handler:    _MediaService_ListPublicVideos_Handler,
+		},
+		{
+			MethodName: "GetEmbed",
+			Handler:    _MediaService_GetEmbed_Handler,
+		},
+		{
+			MethodName: "GetHLSPlaylist",
+			Handler:    _MediaService_GetHLSPlaylist_Handler,
+		},
+		{
+			MethodName: "GetHLSSegment",
+			Handler:    _MediaService_GetHLSSegment_Handler,
+		},
+		{
+			MethodName: "CreateExport",
+			Handler:    _MediaService_CreateExport_Handler,
+		},
+		{
+			MethodName: "GetExport",
+			Handler:    _MediaService_GetExport_Handler,
+		},
+		{
+			MethodName: "ReportTranscode",
+			Handler:    _MediaService_ReportTranscode_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "UploadVideo",
@@ -158,6 +1581,16 @@ var MediaService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _MediaService_DownloadVideo_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "WatchProgress",
+			Handler:       _MediaService_WatchProgress_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "DownloadExport",
+			Handler:       _MediaService_DownloadExport_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "media/media.proto",
 }
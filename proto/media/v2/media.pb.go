@@ -0,0 +1,845 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: media/v2/media.proto
+
+package mediav2
+
+import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	media "coscup2025/proto/media"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ChunkNackReason int32
+
+const (
+	ChunkNackReason_CHUNK_NACK_REASON_UNSPECIFIED       ChunkNackReason = 0
+	ChunkNackReason_CHUNK_NACK_REASON_CHECKSUM_MISMATCH ChunkNackReason = 1 // the chunk at offset does not match its crc32c
+	ChunkNackReason_CHUNK_NACK_REASON_MISSING           ChunkNackReason = 2 // a chunk past offset arrived before the one at offset
+)
+
+// Enum value maps for ChunkNackReason.
+var (
+	ChunkNackReason_name = map[int32]string{
+		0: "CHUNK_NACK_REASON_UNSPECIFIED",
+		1: "CHUNK_NACK_REASON_CHECKSUM_MISMATCH",
+		2: "CHUNK_NACK_REASON_MISSING",
+	}
+	ChunkNackReason_value = map[string]int32{
+		"CHUNK_NACK_REASON_UNSPECIFIED":       0,
+		"CHUNK_NACK_REASON_CHECKSUM_MISMATCH": 1,
+		"CHUNK_NACK_REASON_MISSING":           2,
+	}
+)
+
+func (x ChunkNackReason) Enum() *ChunkNackReason {
+	p := new(ChunkNackReason)
+	*p = x
+	return p
+}
+
+func (x ChunkNackReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ChunkNackReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_media_v2_media_proto_enumTypes[0].Descriptor()
+}
+
+func (ChunkNackReason) Type() protoreflect.EnumType {
+	return &file_media_v2_media_proto_enumTypes[0]
+}
+
+func (x ChunkNackReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ChunkNackReason.Descriptor instead.
+func (ChunkNackReason) EnumDescriptor() ([]byte, []int) {
+	return file_media_v2_media_proto_rawDescGZIP(), []int{0}
+}
+
+// UploadVideoRequest is a chunk of an upload session. The video, its size,
+// tags and texts are given to CreateUploadSession instead.
+type UploadVideoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UploadId      string                 `protobuf:"bytes,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	Offset        int64                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"` // byte offset of data within the video
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Heartbeat     bool                   `protobuf:"varint,4,opt,name=heartbeat,proto3" json:"heartbeat,omitempty"` // a message without data that only shows the client is alive, see the first version
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadVideoRequest) Reset() {
+	*x = UploadVideoRequest{}
+	mi := &file_media_v2_media_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadVideoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadVideoRequest) ProtoMessage() {}
+
+func (x *UploadVideoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_v2_media_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadVideoRequest.ProtoReflect.Descriptor instead.
+func (*UploadVideoRequest) Descriptor() ([]byte, []int) {
+	return file_media_v2_media_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *UploadVideoRequest) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+func (x *UploadVideoRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *UploadVideoRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *UploadVideoRequest) GetHeartbeat() bool {
+	if x != nil {
+		return x.Heartbeat
+	}
+	return false
+}
+
+// UploadChunksRequest is a chunk of an upload session. The client sends
+// chunks without waiting for their acknowledgements, and closes its side of
+// the stream once every byte is acknowledged to receive the result.
+type UploadChunksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UploadId      string                 `protobuf:"bytes,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	Offset        int64                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"` // byte offset of data within the video
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Crc32C        uint32                 `protobuf:"varint,4,opt,name=crc32c,proto3" json:"crc32c,omitempty"`       // CRC32C (Castagnoli) of data
+	Heartbeat     bool                   `protobuf:"varint,5,opt,name=heartbeat,proto3" json:"heartbeat,omitempty"` // a message without data that only shows the client is alive, see the first version
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadChunksRequest) Reset() {
+	*x = UploadChunksRequest{}
+	mi := &file_media_v2_media_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadChunksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadChunksRequest) ProtoMessage() {}
+
+func (x *UploadChunksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_v2_media_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadChunksRequest.ProtoReflect.Descriptor instead.
+func (*UploadChunksRequest) Descriptor() ([]byte, []int) {
+	return file_media_v2_media_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UploadChunksRequest) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+func (x *UploadChunksRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *UploadChunksRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *UploadChunksRequest) GetCrc32C() uint32 {
+	if x != nil {
+		return x.Crc32C
+	}
+	return 0
+}
+
+func (x *UploadChunksRequest) GetHeartbeat() bool {
+	if x != nil {
+		return x.Heartbeat
+	}
+	return false
+}
+
+// UploadChunksResponse is a frame of an upload: acknowledgements and
+// retransmission requests while the chunks arrive, then the result once the
+// client closed its side.
+type UploadChunksResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Frame:
+	//
+	//	*UploadChunksResponse_Ack
+	//	*UploadChunksResponse_Nack
+	//	*UploadChunksResponse_Result
+	Frame         isUploadChunksResponse_Frame `protobuf_oneof:"frame"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadChunksResponse) Reset() {
+	*x = UploadChunksResponse{}
+	mi := &file_media_v2_media_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadChunksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadChunksResponse) ProtoMessage() {}
+
+func (x *UploadChunksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_v2_media_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadChunksResponse.ProtoReflect.Descriptor instead.
+func (*UploadChunksResponse) Descriptor() ([]byte, []int) {
+	return file_media_v2_media_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *UploadChunksResponse) GetFrame() isUploadChunksResponse_Frame {
+	if x != nil {
+		return x.Frame
+	}
+	return nil
+}
+
+func (x *UploadChunksResponse) GetAck() *ChunkAck {
+	if x != nil {
+		if x, ok := x.Frame.(*UploadChunksResponse_Ack); ok {
+			return x.Ack
+		}
+	}
+	return nil
+}
+
+func (x *UploadChunksResponse) GetNack() *ChunkNack {
+	if x != nil {
+		if x, ok := x.Frame.(*UploadChunksResponse_Nack); ok {
+			return x.Nack
+		}
+	}
+	return nil
+}
+
+func (x *UploadChunksResponse) GetResult() *media.UploadVideoResponse {
+	if x != nil {
+		if x, ok := x.Frame.(*UploadChunksResponse_Result); ok {
+			return x.Result
+		}
+	}
+	return nil
+}
+
+type isUploadChunksResponse_Frame interface {
+	isUploadChunksResponse_Frame()
+}
+
+type UploadChunksResponse_Ack struct {
+	Ack *ChunkAck `protobuf:"bytes,1,opt,name=ack,proto3,oneof"`
+}
+
+type UploadChunksResponse_Nack struct {
+	Nack *ChunkNack `protobuf:"bytes,2,opt,name=nack,proto3,oneof"`
+}
+
+type UploadChunksResponse_Result struct {
+	Result *media.UploadVideoResponse `protobuf:"bytes,3,opt,name=result,proto3,oneof"`
+}
+
+func (*UploadChunksResponse_Ack) isUploadChunksResponse_Frame() {}
+
+func (*UploadChunksResponse_Nack) isUploadChunksResponse_Frame() {}
+
+func (*UploadChunksResponse_Result) isUploadChunksResponse_Frame() {}
+
+type ChunkAck struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	CommittedBytes int64                  `protobuf:"varint,1,opt,name=committed_bytes,json=committedBytes,proto3" json:"committed_bytes,omitempty"` // every byte before this offset is stored
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ChunkAck) Reset() {
+	*x = ChunkAck{}
+	mi := &file_media_v2_media_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChunkAck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChunkAck) ProtoMessage() {}
+
+func (x *ChunkAck) ProtoReflect() protoreflect.Message {
+	mi := &file_media_v2_media_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChunkAck.ProtoReflect.Descriptor instead.
+func (*ChunkAck) Descriptor() ([]byte, []int) {
+	return file_media_v2_media_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ChunkAck) GetCommittedBytes() int64 {
+	if x != nil {
+		return x.CommittedBytes
+	}
+	return 0
+}
+
+// ChunkNack asks for the chunks from offset on again. The server drops the
+// chunks past offset that are already in flight, so the client sends on from
+// offset rather than only the rejected chunk.
+type ChunkNack struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Offset        int64                  `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	Reason        ChunkNackReason        `protobuf:"varint,2,opt,name=reason,proto3,enum=media.v2.ChunkNackReason" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChunkNack) Reset() {
+	*x = ChunkNack{}
+	mi := &file_media_v2_media_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChunkNack) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChunkNack) ProtoMessage() {}
+
+func (x *ChunkNack) ProtoReflect() protoreflect.Message {
+	mi := &file_media_v2_media_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChunkNack.ProtoReflect.Descriptor instead.
+func (*ChunkNack) Descriptor() ([]byte, []int) {
+	return file_media_v2_media_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ChunkNack) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ChunkNack) GetReason() ChunkNackReason {
+	if x != nil {
+		return x.Reason
+	}
+	return ChunkNackReason_CHUNK_NACK_REASON_UNSPECIFIED
+}
+
+// DownloadVideoResponse is a frame of a download: one header, then the
+// chunks, then one trailer. A stream without a trailer was cut short.
+type DownloadVideoResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Frame:
+	//
+	//	*DownloadVideoResponse_Header
+	//	*DownloadVideoResponse_Chunk
+	//	*DownloadVideoResponse_Trailer
+	Frame         isDownloadVideoResponse_Frame `protobuf_oneof:"frame"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadVideoResponse) Reset() {
+	*x = DownloadVideoResponse{}
+	mi := &file_media_v2_media_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadVideoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadVideoResponse) ProtoMessage() {}
+
+func (x *DownloadVideoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_v2_media_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadVideoResponse.ProtoReflect.Descriptor instead.
+func (*DownloadVideoResponse) Descriptor() ([]byte, []int) {
+	return file_media_v2_media_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DownloadVideoResponse) GetFrame() isDownloadVideoResponse_Frame {
+	if x != nil {
+		return x.Frame
+	}
+	return nil
+}
+
+func (x *DownloadVideoResponse) GetHeader() *DownloadHeader {
+	if x != nil {
+		if x, ok := x.Frame.(*DownloadVideoResponse_Header); ok {
+			return x.Header
+		}
+	}
+	return nil
+}
+
+func (x *DownloadVideoResponse) GetChunk() *DownloadChunk {
+	if x != nil {
+		if x, ok := x.Frame.(*DownloadVideoResponse_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+func (x *DownloadVideoResponse) GetTrailer() *DownloadTrailer {
+	if x != nil {
+		if x, ok := x.Frame.(*DownloadVideoResponse_Trailer); ok {
+			return x.Trailer
+		}
+	}
+	return nil
+}
+
+type isDownloadVideoResponse_Frame interface {
+	isDownloadVideoResponse_Frame()
+}
+
+type DownloadVideoResponse_Header struct {
+	Header *DownloadHeader `protobuf:"bytes,1,opt,name=header,proto3,oneof"`
+}
+
+type DownloadVideoResponse_Chunk struct {
+	Chunk *DownloadChunk `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+type DownloadVideoResponse_Trailer struct {
+	Trailer *DownloadTrailer `protobuf:"bytes,3,opt,name=trailer,proto3,oneof"`
+}
+
+func (*DownloadVideoResponse_Header) isDownloadVideoResponse_Frame() {}
+
+func (*DownloadVideoResponse_Chunk) isDownloadVideoResponse_Frame() {}
+
+func (*DownloadVideoResponse_Trailer) isDownloadVideoResponse_Frame() {}
+
+type DownloadHeader struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	Metadata      *media.VideoMetadata   `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Offset        int64                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"` // position of the first chunk in the video
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadHeader) Reset() {
+	*x = DownloadHeader{}
+	mi := &file_media_v2_media_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadHeader) ProtoMessage() {}
+
+func (x *DownloadHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_media_v2_media_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadHeader.ProtoReflect.Descriptor instead.
+func (*DownloadHeader) Descriptor() ([]byte, []int) {
+	return file_media_v2_media_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DownloadHeader) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *DownloadHeader) GetMetadata() *media.VideoMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *DownloadHeader) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type DownloadChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Offset        int64                  `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"` // position of data in the video
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadChunk) Reset() {
+	*x = DownloadChunk{}
+	mi := &file_media_v2_media_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadChunk) ProtoMessage() {}
+
+func (x *DownloadChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_media_v2_media_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadChunk.ProtoReflect.Descriptor instead.
+func (*DownloadChunk) Descriptor() ([]byte, []int) {
+	return file_media_v2_media_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DownloadChunk) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *DownloadChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type DownloadTrailer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BytesSent     int64                  `protobuf:"varint,1,opt,name=bytes_sent,json=bytesSent,proto3" json:"bytes_sent,omitempty"` // total size of the chunks
+	Stats         *media.TransferStats   `protobuf:"bytes,2,opt,name=stats,proto3" json:"stats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadTrailer) Reset() {
+	*x = DownloadTrailer{}
+	mi := &file_media_v2_media_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadTrailer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadTrailer) ProtoMessage() {}
+
+func (x *DownloadTrailer) ProtoReflect() protoreflect.Message {
+	mi := &file_media_v2_media_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadTrailer.ProtoReflect.Descriptor instead.
+func (*DownloadTrailer) Descriptor() ([]byte, []int) {
+	return file_media_v2_media_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DownloadTrailer) GetBytesSent() int64 {
+	if x != nil {
+		return x.BytesSent
+	}
+	return 0
+}
+
+func (x *DownloadTrailer) GetStats() *media.TransferStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+var File_media_v2_media_proto protoreflect.FileDescriptor
+
+const file_media_v2_media_proto_rawDesc = "" +
+	"\n" +
+	"\x14media/v2/media.proto\x12\bmedia.v2\x1a\x1bbuf/validate/validate.proto\x1a\x1cgoogle/api/annotations.proto\x1a\x11media/media.proto\"\x9c\x01\n" +
+	"\x12UploadVideoRequest\x12'\n" +
+	"\tupload_id\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x01R\buploadId\x12\x1f\n" +
+	"\x06offset\x18\x02 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\x06offset\x12\x1e\n" +
+	"\x04data\x18\x03 \x01(\fB\n" +
+	"\xbaH\az\x05\x18\x80\x80\x80\bR\x04data\x12\x1c\n" +
+	"\theartbeat\x18\x04 \x01(\bR\theartbeat\"\xb5\x01\n" +
+	"\x13UploadChunksRequest\x12'\n" +
+	"\tupload_id\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x01R\buploadId\x12\x1f\n" +
+	"\x06offset\x18\x02 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\x06offset\x12\x1e\n" +
+	"\x04data\x18\x03 \x01(\fB\n" +
+	"\xbaH\az\x05\x18\x80\x80\x80\bR\x04data\x12\x16\n" +
+	"\x06crc32c\x18\x04 \x01(\rR\x06crc32c\x12\x1c\n" +
+	"\theartbeat\x18\x05 \x01(\bR\theartbeat\"\xa8\x01\n" +
+	"\x14UploadChunksResponse\x12&\n" +
+	"\x03ack\x18\x01 \x01(\v2\x12.media.v2.ChunkAckH\x00R\x03ack\x12)\n" +
+	"\x04nack\x18\x02 \x01(\v2\x13.media.v2.ChunkNackH\x00R\x04nack\x124\n" +
+	"\x06result\x18\x03 \x01(\v2\x1a.media.UploadVideoResponseH\x00R\x06resultB\a\n" +
+	"\x05frame\"3\n" +
+	"\bChunkAck\x12'\n" +
+	"\x0fcommitted_bytes\x18\x01 \x01(\x03R\x0ecommittedBytes\"V\n" +
+	"\tChunkNack\x12\x16\n" +
+	"\x06offset\x18\x01 \x01(\x03R\x06offset\x121\n" +
+	"\x06reason\x18\x02 \x01(\x0e2\x19.media.v2.ChunkNackReasonR\x06reason\"\xbc\x01\n" +
+	"\x15DownloadVideoResponse\x122\n" +
+	"\x06header\x18\x01 \x01(\v2\x18.media.v2.DownloadHeaderH\x00R\x06header\x12/\n" +
+	"\x05chunk\x18\x02 \x01(\v2\x17.media.v2.DownloadChunkH\x00R\x05chunk\x125\n" +
+	"\atrailer\x18\x03 \x01(\v2\x19.media.v2.DownloadTrailerH\x00R\atrailerB\a\n" +
+	"\x05frame\"u\n" +
+	"\x0eDownloadHeader\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x120\n" +
+	"\bmetadata\x18\x02 \x01(\v2\x14.media.VideoMetadataR\bmetadata\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x03R\x06offset\";\n" +
+	"\rDownloadChunk\x12\x16\n" +
+	"\x06offset\x18\x01 \x01(\x03R\x06offset\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\"\\\n" +
+	"\x0fDownloadTrailer\x12\x1d\n" +
+	"\n" +
+	"bytes_sent\x18\x01 \x01(\x03R\tbytesSent\x12*\n" +
+	"\x05stats\x18\x02 \x01(\v2\x14.media.TransferStatsR\x05stats*|\n" +
+	"\x0fChunkNackReason\x12!\n" +
+	"\x1dCHUNK_NACK_REASON_UNSPECIFIED\x10\x00\x12'\n" +
+	"#CHUNK_NACK_REASON_CHECKSUM_MISMATCH\x10\x01\x12\x1d\n" +
+	"\x19CHUNK_NACK_REASON_MISSING\x10\x022\x97\x05\n" +
+	"\fMediaService\x12t\n" +
+	"\x13CreateUploadSession\x12!.media.CreateUploadSessionRequest\x1a\".media.CreateUploadSessionResponse\"\x16\x82\xd3\xe4\x93\x02\x10:\x01*\"\v/v2/uploads\x12h\n" +
+	"\vUploadVideo\x12\x1c.media.v2.UploadVideoRequest\x1a\x1a.media.UploadVideoResponse\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/v2/uploads/chunks(\x01\x12Q\n" +
+	"\fUploadChunks\x12\x1d.media.v2.UploadChunksRequest\x1a\x1e.media.v2.UploadChunksResponse(\x010\x01\x12t\n" +
+	"\x10GetUploadSession\x12\x1e.media.GetUploadSessionRequest\x1a\x1f.media.GetUploadSessionResponse\"\x1f\x82\xd3\xe4\x93\x02\x19\x12\x17/v2/uploads/{upload_id}\x12e\n" +
+	"\vAbortUpload\x12\x19.media.AbortUploadRequest\x1a\x1a.media.AbortUploadResponse\"\x1f\x82\xd3\xe4\x93\x02\x19*\x17/v2/uploads/{upload_id}\x12w\n" +
+	"\rDownloadVideo\x12\x1b.media.DownloadVideoRequest\x1a\x1f.media.v2.DownloadVideoResponse\"&\x82\xd3\xe4\x93\x02 \x12\x1e/v2/videos/{video_id}/download0\x01B#Z!coscup2025/proto/media/v2;mediav2b\x06proto3"
+
+var (
+	file_media_v2_media_proto_rawDescOnce sync.Once
+	file_media_v2_media_proto_rawDescData []byte
+)
+
+func file_media_v2_media_proto_rawDescGZIP() []byte {
+	file_media_v2_media_proto_rawDescOnce.Do(func() {
+		file_media_v2_media_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_media_v2_media_proto_rawDesc), len(file_media_v2_media_proto_rawDesc)))
+	})
+	return file_media_v2_media_proto_rawDescData
+}
+
+var file_media_v2_media_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_media_v2_media_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_media_v2_media_proto_goTypes = []any{
+	(ChunkNackReason)(0),                      // 0: media.v2.ChunkNackReason
+	(*UploadVideoRequest)(nil),                // 1: media.v2.UploadVideoRequest
+	(*UploadChunksRequest)(nil),               // 2: media.v2.UploadChunksRequest
+	(*UploadChunksResponse)(nil),              // 3: media.v2.UploadChunksResponse
+	(*ChunkAck)(nil),                          // 4: media.v2.ChunkAck
+	(*ChunkNack)(nil),                         // 5: media.v2.ChunkNack
+	(*DownloadVideoResponse)(nil),             // 6: media.v2.DownloadVideoResponse
+	(*DownloadHeader)(nil),                    // 7: media.v2.DownloadHeader
+	(*DownloadChunk)(nil),                     // 8: media.v2.DownloadChunk
+	(*DownloadTrailer)(nil),                   // 9: media.v2.DownloadTrailer
+	(*media.UploadVideoResponse)(nil),         // 10: media.UploadVideoResponse
+	(*media.VideoMetadata)(nil),               // 11: media.VideoMetadata
+	(*media.TransferStats)(nil),               // 12: media.TransferStats
+	(*media.CreateUploadSessionRequest)(nil),  // 13: media.CreateUploadSessionRequest
+	(*media.GetUploadSessionRequest)(nil),     // 14: media.GetUploadSessionRequest
+	(*media.AbortUploadRequest)(nil),          // 15: media.AbortUploadRequest
+	(*media.DownloadVideoRequest)(nil),        // 16: media.DownloadVideoRequest
+	(*media.CreateUploadSessionResponse)(nil), // 17: media.CreateUploadSessionResponse
+	(*media.GetUploadSessionResponse)(nil),    // 18: media.GetUploadSessionResponse
+	(*media.AbortUploadResponse)(nil),         // 19: media.AbortUploadResponse
+}
+var file_media_v2_media_proto_depIdxs = []int32{
+	4,  // 0: media.v2.UploadChunksResponse.ack:type_name -> media.v2.ChunkAck
+	5,  // 1: media.v2.UploadChunksResponse.nack:type_name -> media.v2.ChunkNack
+	10, // 2: media.v2.UploadChunksResponse.result:type_name -> media.UploadVideoResponse
+	0,  // 3: media.v2.ChunkNack.reason:type_name -> media.v2.ChunkNackReason
+	7,  // 4: media.v2.DownloadVideoResponse.header:type_name -> media.v2.DownloadHeader
+	8,  // 5: media.v2.DownloadVideoResponse.chunk:type_name -> media.v2.DownloadChunk
+	9,  // 6: media.v2.DownloadVideoResponse.trailer:type_name -> media.v2.DownloadTrailer
+	11, // 7: media.v2.DownloadHeader.metadata:type_name -> media.VideoMetadata
+	12, // 8: media.v2.DownloadTrailer.stats:type_name -> media.TransferStats
+	13, // 9: media.v2.MediaService.CreateUploadSession:input_type -> media.CreateUploadSessionRequest
+	1,  // 10: media.v2.MediaService.UploadVideo:input_type -> media.v2.UploadVideoRequest
+	2,  // 11: media.v2.MediaService.UploadChunks:input_type -> media.v2.UploadChunksRequest
+	14, // 12: media.v2.MediaService.GetUploadSession:input_type -> media.GetUploadSessionRequest
+	15, // 13: media.v2.MediaService.AbortUpload:input_type -> media.AbortUploadRequest
+	16, // 14: media.v2.MediaService.DownloadVideo:input_type -> media.DownloadVideoRequest
+	17, // 15: media.v2.MediaService.CreateUploadSession:output_type -> media.CreateUploadSessionResponse
+	10, // 16: media.v2.MediaService.UploadVideo:output_type -> media.UploadVideoResponse
+	3,  // 17: media.v2.MediaService.UploadChunks:output_type -> media.v2.UploadChunksResponse
+	18, // 18: media.v2.MediaService.GetUploadSession:output_type -> media.GetUploadSessionResponse
+	19, // 19: media.v2.MediaService.AbortUpload:output_type -> media.AbortUploadResponse
+	6,  // 20: media.v2.MediaService.DownloadVideo:output_type -> media.v2.DownloadVideoResponse
+	15, // [15:21] is the sub-list for method output_type
+	9,  // [9:15] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_media_v2_media_proto_init() }
+func file_media_v2_media_proto_init() {
+	if File_media_v2_media_proto != nil {
+		return
+	}
+	file_media_v2_media_proto_msgTypes[2].OneofWrappers = []any{
+		(*UploadChunksResponse_Ack)(nil),
+		(*UploadChunksResponse_Nack)(nil),
+		(*UploadChunksResponse_Result)(nil),
+	}
+	file_media_v2_media_proto_msgTypes[5].OneofWrappers = []any{
+		(*DownloadVideoResponse_Header)(nil),
+		(*DownloadVideoResponse_Chunk)(nil),
+		(*DownloadVideoResponse_Trailer)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_media_v2_media_proto_rawDesc), len(file_media_v2_media_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_media_v2_media_proto_goTypes,
+		DependencyIndexes: file_media_v2_media_proto_depIdxs,
+		EnumInfos:         file_media_v2_media_proto_enumTypes,
+		MessageInfos:      file_media_v2_media_proto_msgTypes,
+	}.Build()
+	File_media_v2_media_proto = out.File
+	file_media_v2_media_proto_goTypes = nil
+	file_media_v2_media_proto_depIdxs = nil
+}
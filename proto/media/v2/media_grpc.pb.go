@@ -0,0 +1,339 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: media/v2/media.proto
+
+package mediav2
+
+import (
+	context "context"
+	media "coscup2025/proto/media"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	MediaService_CreateUploadSession_FullMethodName = "/media.v2.MediaService/CreateUploadSession"
+	MediaService_UploadVideo_FullMethodName         = "/media.v2.MediaService/UploadVideo"
+	MediaService_UploadChunks_FullMethodName        = "/media.v2.MediaService/UploadChunks"
+	MediaService_GetUploadSession_FullMethodName    = "/media.v2.MediaService/GetUploadSession"
+	MediaService_AbortUpload_FullMethodName         = "/media.v2.MediaService/AbortUpload"
+	MediaService_DownloadVideo_FullMethodName       = "/media.v2.MediaService/DownloadVideo"
+)
+
+// MediaServiceClient is the client API for MediaService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// MediaService is version 2 of the transfer RPCs of media.MediaService, the
+// first version, which keeps its unversioned package name for existing
+// clients. RPCs that did not change are only served by the first version,
+// and this version reuses its messages where they are the same.
+type MediaServiceClient interface {
+	// CreateUploadSession is the first phase of an upload: it announces the
+	// video and returns the upload_id that UploadVideo writes to
+	CreateUploadSession(ctx context.Context, in *media.CreateUploadSessionRequest, opts ...grpc.CallOption) (*media.CreateUploadSessionResponse, error)
+	// UploadVideo is the second phase of an upload: it streams the bytes of a
+	// session, starting at its committed_bytes when resuming
+	UploadVideo(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadVideoRequest, media.UploadVideoResponse], error)
+	// UploadChunks streams the bytes of a session like UploadVideo, with the
+	// CRC32C of every chunk. The server acknowledges committed chunks and asks
+	// for those that arrived corrupted or out of order again, so that a bad
+	// chunk costs its retransmission rather than a new stream. It is only
+	// served over gRPC.
+	UploadChunks(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[UploadChunksRequest, UploadChunksResponse], error)
+	// GetUploadSession reports how far an upload has progressed
+	GetUploadSession(ctx context.Context, in *media.GetUploadSessionRequest, opts ...grpc.CallOption) (*media.GetUploadSessionResponse, error)
+	// AbortUpload cancels an upload and frees its stored bytes
+	AbortUpload(ctx context.Context, in *media.AbortUploadRequest, opts ...grpc.CallOption) (*media.AbortUploadResponse, error)
+	// DownloadVideo streams a header frame, the chunks of the requested range
+	// and a trailer frame
+	DownloadVideo(ctx context.Context, in *media.DownloadVideoRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadVideoResponse], error)
+}
+
+type mediaServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMediaServiceClient(cc grpc.ClientConnInterface) MediaServiceClient {
+	return &mediaServiceClient{cc}
+}
+
+func (c *mediaServiceClient) CreateUploadSession(ctx context.Context, in *media.CreateUploadSessionRequest, opts ...grpc.CallOption) (*media.CreateUploadSessionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(media.CreateUploadSessionResponse)
+	err := c.cc.Invoke(ctx, MediaService_CreateUploadSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) UploadVideo(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadVideoRequest, media.UploadVideoResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MediaService_ServiceDesc.Streams[0], MediaService_UploadVideo_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[UploadVideoRequest, media.UploadVideoResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MediaService_UploadVideoClient = grpc.ClientStreamingClient[UploadVideoRequest, media.UploadVideoResponse]
+
+func (c *mediaServiceClient) UploadChunks(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[UploadChunksRequest, UploadChunksResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MediaService_ServiceDesc.Streams[1], MediaService_UploadChunks_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[UploadChunksRequest, UploadChunksResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MediaService_UploadChunksClient = grpc.BidiStreamingClient[UploadChunksRequest, UploadChunksResponse]
+
+func (c *mediaServiceClient) GetUploadSession(ctx context.Context, in *media.GetUploadSessionRequest, opts ...grpc.CallOption) (*media.GetUploadSessionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(media.GetUploadSessionResponse)
+	err := c.cc.Invoke(ctx, MediaService_GetUploadSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) AbortUpload(ctx context.Context, in *media.AbortUploadRequest, opts ...grpc.CallOption) (*media.AbortUploadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(media.AbortUploadResponse)
+	err := c.cc.Invoke(ctx, MediaService_AbortUpload_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) DownloadVideo(ctx context.Context, in *media.DownloadVideoRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadVideoResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MediaService_ServiceDesc.Streams[2], MediaService_DownloadVideo_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[media.DownloadVideoRequest, DownloadVideoResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MediaService_DownloadVideoClient = grpc.ServerStreamingClient[DownloadVideoResponse]
+
+// MediaServiceServer is the server API for MediaService service.
+// All implementations must embed UnimplementedMediaServiceServer
+// for forward compatibility.
+//
+// MediaService is version 2 of the transfer RPCs of media.MediaService, the
+// first version, which keeps its unversioned package name for existing
+// clients. RPCs that did not change are only served by the first version,
+// and this version reuses its messages where they are the same.
+type MediaServiceServer interface {
+	// CreateUploadSession is the first phase of an upload: it announces the
+	// video and returns the upload_id that UploadVideo writes to
+	CreateUploadSession(context.Context, *media.CreateUploadSessionRequest) (*media.CreateUploadSessionResponse, error)
+	// UploadVideo is the second phase of an upload: it streams the bytes of a
+	// session, starting at its committed_bytes when resuming
+	UploadVideo(grpc.ClientStreamingServer[UploadVideoRequest, media.UploadVideoResponse]) error
+	// UploadChunks streams the bytes of a session like UploadVideo, with the
+	// CRC32C of every chunk. The server acknowledges committed chunks and asks
+	// for those that arrived corrupted or out of order again, so that a bad
+	// chunk costs its retransmission rather than a new stream. It is only
+	// served over gRPC.
+	UploadChunks(grpc.BidiStreamingServer[UploadChunksRequest, UploadChunksResponse]) error
+	// GetUploadSession reports how far an upload has progressed
+	GetUploadSession(context.Context, *media.GetUploadSessionRequest) (*media.GetUploadSessionResponse, error)
+	// AbortUpload cancels an upload and frees its stored bytes
+	AbortUpload(context.Context, *media.AbortUploadRequest) (*media.AbortUploadResponse, error)
+	// DownloadVideo streams a header frame, the chunks of the requested range
+	// and a trailer frame
+	DownloadVideo(*media.DownloadVideoRequest, grpc.ServerStreamingServer[DownloadVideoResponse]) error
+	mustEmbedUnimplementedMediaServiceServer()
+}
+
+// UnimplementedMediaServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMediaServiceServer struct{}
+
+func (UnimplementedMediaServiceServer) CreateUploadSession(context.Context, *media.CreateUploadSessionRequest) (*media.CreateUploadSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUploadSession not implemented")
+}
+func (UnimplementedMediaServiceServer) UploadVideo(grpc.ClientStreamingServer[UploadVideoRequest, media.UploadVideoResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method UploadVideo not implemented")
+}
+func (UnimplementedMediaServiceServer) UploadChunks(grpc.BidiStreamingServer[UploadChunksRequest, UploadChunksResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method UploadChunks not implemented")
+}
+func (UnimplementedMediaServiceServer) GetUploadSession(context.Context, *media.GetUploadSessionRequest) (*media.GetUploadSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUploadSession not implemented")
+}
+func (UnimplementedMediaServiceServer) AbortUpload(context.Context, *media.AbortUploadRequest) (*media.AbortUploadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AbortUpload not implemented")
+}
+func (UnimplementedMediaServiceServer) DownloadVideo(*media.DownloadVideoRequest, grpc.ServerStreamingServer[DownloadVideoResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadVideo not implemented")
+}
+func (UnimplementedMediaServiceServer) mustEmbedUnimplementedMediaServiceServer() {}
+func (UnimplementedMediaServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeMediaServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MediaServiceServer will
+// result in compilation errors.
+type UnsafeMediaServiceServer interface {
+	mustEmbedUnimplementedMediaServiceServer()
+}
+
+func RegisterMediaServiceServer(s grpc.ServiceRegistrar, srv MediaServiceServer) {
+	// If the following call pancis, it indicates UnimplementedMediaServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&MediaService_ServiceDesc, srv)
+}
+
+func _MediaService_CreateUploadSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(media.CreateUploadSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).CreateUploadSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_CreateUploadSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).CreateUploadSession(ctx, req.(*media.CreateUploadSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_UploadVideo_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(MediaServiceServer).UploadVideo(&grpc.GenericServerStream[UploadVideoRequest, media.UploadVideoResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MediaService_UploadVideoServer = grpc.ClientStreamingServer[UploadVideoRequest, media.UploadVideoResponse]
+
+func _MediaService_UploadChunks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(MediaServiceServer).UploadChunks(&grpc.GenericServerStream[UploadChunksRequest, UploadChunksResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MediaService_UploadChunksServer = grpc.BidiStreamingServer[UploadChunksRequest, UploadChunksResponse]
+
+func _MediaService_GetUploadSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(media.GetUploadSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).GetUploadSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_GetUploadSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).GetUploadSession(ctx, req.(*media.GetUploadSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_AbortUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(media.AbortUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).AbortUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_AbortUpload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).AbortUpload(ctx, req.(*media.AbortUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_DownloadVideo_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(media.DownloadVideoRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MediaServiceServer).DownloadVideo(m, &grpc.GenericServerStream[media.DownloadVideoRequest, DownloadVideoResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MediaService_DownloadVideoServer = grpc.ServerStreamingServer[DownloadVideoResponse]
+
+// MediaService_ServiceDesc is the grpc.ServiceDesc for MediaService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MediaService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "media.v2.MediaService",
+	HandlerType: (*MediaServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateUploadSession",
+			Handler:    _MediaService_CreateUploadSession_Handler,
+		},
+		{
+			MethodName: "GetUploadSession",
+			Handler:    _MediaService_GetUploadSession_Handler,
+		},
+		{
+			MethodName: "AbortUpload",
+			Handler:    _MediaService_AbortUpload_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadVideo",
+			Handler:       _MediaService_UploadVideo_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "UploadChunks",
+			Handler:       _MediaService_UploadChunks_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "DownloadVideo",
+			Handler:       _MediaService_DownloadVideo_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "media/v2/media.proto",
+}
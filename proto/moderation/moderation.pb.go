@@ -0,0 +1,747 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: moderation/moderation.proto
+
+package moderation
+
+import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ReportReason int32
+
+const (
+	ReportReason_REPORT_REASON_UNSPECIFIED   ReportReason = 0
+	ReportReason_REPORT_REASON_SPAM          ReportReason = 1
+	ReportReason_REPORT_REASON_HARASSMENT    ReportReason = 2
+	ReportReason_REPORT_REASON_COPYRIGHT     ReportReason = 3
+	ReportReason_REPORT_REASON_INAPPROPRIATE ReportReason = 4
+	ReportReason_REPORT_REASON_OTHER         ReportReason = 5 // explain in details
+)
+
+// Enum value maps for ReportReason.
+var (
+	ReportReason_name = map[int32]string{
+		0: "REPORT_REASON_UNSPECIFIED",
+		1: "REPORT_REASON_SPAM",
+		2: "REPORT_REASON_HARASSMENT",
+		3: "REPORT_REASON_COPYRIGHT",
+		4: "REPORT_REASON_INAPPROPRIATE",
+		5: "REPORT_REASON_OTHER",
+	}
+	ReportReason_value = map[string]int32{
+		"REPORT_REASON_UNSPECIFIED":   0,
+		"REPORT_REASON_SPAM":          1,
+		"REPORT_REASON_HARASSMENT":    2,
+		"REPORT_REASON_COPYRIGHT":     3,
+		"REPORT_REASON_INAPPROPRIATE": 4,
+		"REPORT_REASON_OTHER":         5,
+	}
+)
+
+func (x ReportReason) Enum() *ReportReason {
+	p := new(ReportReason)
+	*p = x
+	return p
+}
+
+func (x ReportReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReportReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_moderation_moderation_proto_enumTypes[0].Descriptor()
+}
+
+func (ReportReason) Type() protoreflect.EnumType {
+	return &file_moderation_moderation_proto_enumTypes[0]
+}
+
+func (x ReportReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReportReason.Descriptor instead.
+func (ReportReason) EnumDescriptor() ([]byte, []int) {
+	return file_moderation_moderation_proto_rawDescGZIP(), []int{0}
+}
+
+// CaseState is the step of the takedown workflow a case is in. Cases move
+// from reported to under review, then to taken down or back to reinstated.
+// Uploaders may appeal a takedown, after which the appeal is reviewed.
+type CaseState int32
+
+const (
+	CaseState_CASE_STATE_UNSPECIFIED  CaseState = 0
+	CaseState_CASE_STATE_REPORTED     CaseState = 1
+	CaseState_CASE_STATE_UNDER_REVIEW CaseState = 2
+	CaseState_CASE_STATE_TAKEN_DOWN   CaseState = 3 // the video is hidden from everyone but its uploader
+	CaseState_CASE_STATE_APPEALED     CaseState = 4
+	CaseState_CASE_STATE_REINSTATED   CaseState = 5 // reports after this reopen the case
+)
+
+// Enum value maps for CaseState.
+var (
+	CaseState_name = map[int32]string{
+		0: "CASE_STATE_UNSPECIFIED",
+		1: "CASE_STATE_REPORTED",
+		2: "CASE_STATE_UNDER_REVIEW",
+		3: "CASE_STATE_TAKEN_DOWN",
+		4: "CASE_STATE_APPEALED",
+		5: "CASE_STATE_REINSTATED",
+	}
+	CaseState_value = map[string]int32{
+		"CASE_STATE_UNSPECIFIED":  0,
+		"CASE_STATE_REPORTED":     1,
+		"CASE_STATE_UNDER_REVIEW": 2,
+		"CASE_STATE_TAKEN_DOWN":   3,
+		"CASE_STATE_APPEALED":     4,
+		"CASE_STATE_REINSTATED":   5,
+	}
+)
+
+func (x CaseState) Enum() *CaseState {
+	p := new(CaseState)
+	*p = x
+	return p
+}
+
+func (x CaseState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CaseState) Descriptor() protoreflect.EnumDescriptor {
+	return file_moderation_moderation_proto_enumTypes[1].Descriptor()
+}
+
+func (CaseState) Type() protoreflect.EnumType {
+	return &file_moderation_moderation_proto_enumTypes[1]
+}
+
+func (x CaseState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CaseState.Descriptor instead.
+func (CaseState) EnumDescriptor() ([]byte, []int) {
+	return file_moderation_moderation_proto_rawDescGZIP(), []int{1}
+}
+
+type ReportVideoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	Reason        ReportReason           `protobuf:"varint,2,opt,name=reason,proto3,enum=moderation.ReportReason" json:"reason,omitempty"`
+	Details       string                 `protobuf:"bytes,3,opt,name=details,proto3" json:"details,omitempty"` // optional, at most 2000 characters
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportVideoRequest) Reset() {
+	*x = ReportVideoRequest{}
+	mi := &file_moderation_moderation_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportVideoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportVideoRequest) ProtoMessage() {}
+
+func (x *ReportVideoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_moderation_moderation_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportVideoRequest.ProtoReflect.Descriptor instead.
+func (*ReportVideoRequest) Descriptor() ([]byte, []int) {
+	return file_moderation_moderation_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ReportVideoRequest) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *ReportVideoRequest) GetReason() ReportReason {
+	if x != nil {
+		return x.Reason
+	}
+	return ReportReason_REPORT_REASON_UNSPECIFIED
+}
+
+func (x *ReportVideoRequest) GetDetails() string {
+	if x != nil {
+		return x.Details
+	}
+	return ""
+}
+
+type Report struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReporterId    string                 `protobuf:"bytes,1,opt,name=reporter_id,json=reporterId,proto3" json:"reporter_id,omitempty"`
+	Reason        ReportReason           `protobuf:"varint,2,opt,name=reason,proto3,enum=moderation.ReportReason" json:"reason,omitempty"`
+	Details       string                 `protobuf:"bytes,3,opt,name=details,proto3" json:"details,omitempty"`
+	ReportedAt    int64                  `protobuf:"varint,4,opt,name=reported_at,json=reportedAt,proto3" json:"reported_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Report) Reset() {
+	*x = Report{}
+	mi := &file_moderation_moderation_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Report) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Report) ProtoMessage() {}
+
+func (x *Report) ProtoReflect() protoreflect.Message {
+	mi := &file_moderation_moderation_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Report.ProtoReflect.Descriptor instead.
+func (*Report) Descriptor() ([]byte, []int) {
+	return file_moderation_moderation_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Report) GetReporterId() string {
+	if x != nil {
+		return x.ReporterId
+	}
+	return ""
+}
+
+func (x *Report) GetReason() ReportReason {
+	if x != nil {
+		return x.Reason
+	}
+	return ReportReason_REPORT_REASON_UNSPECIFIED
+}
+
+func (x *Report) GetDetails() string {
+	if x != nil {
+		return x.Details
+	}
+	return ""
+}
+
+func (x *Report) GetReportedAt() int64 {
+	if x != nil {
+		return x.ReportedAt
+	}
+	return 0
+}
+
+type Transition struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          CaseState              `protobuf:"varint,1,opt,name=from,proto3,enum=moderation.CaseState" json:"from,omitempty"`
+	To            CaseState              `protobuf:"varint,2,opt,name=to,proto3,enum=moderation.CaseState" json:"to,omitempty"`
+	ActorId       string                 `protobuf:"bytes,3,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"` // the moderator, uploader or reporter who caused it
+	Note          string                 `protobuf:"bytes,4,opt,name=note,proto3" json:"note,omitempty"`
+	At            int64                  `protobuf:"varint,5,opt,name=at,proto3" json:"at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Transition) Reset() {
+	*x = Transition{}
+	mi := &file_moderation_moderation_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transition) ProtoMessage() {}
+
+func (x *Transition) ProtoReflect() protoreflect.Message {
+	mi := &file_moderation_moderation_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transition.ProtoReflect.Descriptor instead.
+func (*Transition) Descriptor() ([]byte, []int) {
+	return file_moderation_moderation_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Transition) GetFrom() CaseState {
+	if x != nil {
+		return x.From
+	}
+	return CaseState_CASE_STATE_UNSPECIFIED
+}
+
+func (x *Transition) GetTo() CaseState {
+	if x != nil {
+		return x.To
+	}
+	return CaseState_CASE_STATE_UNSPECIFIED
+}
+
+func (x *Transition) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *Transition) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *Transition) GetAt() int64 {
+	if x != nil {
+		return x.At
+	}
+	return 0
+}
+
+type Case struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CaseId        string                 `protobuf:"bytes,1,opt,name=case_id,json=caseId,proto3" json:"case_id,omitempty"`
+	VideoId       string                 `protobuf:"bytes,2,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	UploaderId    string                 `protobuf:"bytes,3,opt,name=uploader_id,json=uploaderId,proto3" json:"uploader_id,omitempty"`
+	State         CaseState              `protobuf:"varint,4,opt,name=state,proto3,enum=moderation.CaseState" json:"state,omitempty"`
+	Reports       []*Report              `protobuf:"bytes,5,rep,name=reports,proto3" json:"reports,omitempty"`
+	History       []*Transition          `protobuf:"bytes,6,rep,name=history,proto3" json:"history,omitempty"` // oldest first
+	OpenedAt      int64                  `protobuf:"varint,7,opt,name=opened_at,json=openedAt,proto3" json:"opened_at,omitempty"`
+	UpdatedAt     int64                  `protobuf:"varint,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Case) Reset() {
+	*x = Case{}
+	mi := &file_moderation_moderation_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Case) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Case) ProtoMessage() {}
+
+func (x *Case) ProtoReflect() protoreflect.Message {
+	mi := &file_moderation_moderation_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Case.ProtoReflect.Descriptor instead.
+func (*Case) Descriptor() ([]byte, []int) {
+	return file_moderation_moderation_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Case) GetCaseId() string {
+	if x != nil {
+		return x.CaseId
+	}
+	return ""
+}
+
+func (x *Case) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *Case) GetUploaderId() string {
+	if x != nil {
+		return x.UploaderId
+	}
+	return ""
+}
+
+func (x *Case) GetState() CaseState {
+	if x != nil {
+		return x.State
+	}
+	return CaseState_CASE_STATE_UNSPECIFIED
+}
+
+func (x *Case) GetReports() []*Report {
+	if x != nil {
+		return x.Reports
+	}
+	return nil
+}
+
+func (x *Case) GetHistory() []*Transition {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+func (x *Case) GetOpenedAt() int64 {
+	if x != nil {
+		return x.OpenedAt
+	}
+	return 0
+}
+
+func (x *Case) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+type AppealTakedownRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"` // why the takedown is wrong, at most 2000 characters
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AppealTakedownRequest) Reset() {
+	*x = AppealTakedownRequest{}
+	mi := &file_moderation_moderation_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AppealTakedownRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppealTakedownRequest) ProtoMessage() {}
+
+func (x *AppealTakedownRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_moderation_moderation_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppealTakedownRequest.ProtoReflect.Descriptor instead.
+func (*AppealTakedownRequest) Descriptor() ([]byte, []int) {
+	return file_moderation_moderation_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AppealTakedownRequest) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *AppealTakedownRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type AppealTakedownResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CaseId        string                 `protobuf:"bytes,1,opt,name=case_id,json=caseId,proto3" json:"case_id,omitempty"`
+	State         CaseState              `protobuf:"varint,2,opt,name=state,proto3,enum=moderation.CaseState" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AppealTakedownResponse) Reset() {
+	*x = AppealTakedownResponse{}
+	mi := &file_moderation_moderation_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AppealTakedownResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppealTakedownResponse) ProtoMessage() {}
+
+func (x *AppealTakedownResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_moderation_moderation_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppealTakedownResponse.ProtoReflect.Descriptor instead.
+func (*AppealTakedownResponse) Descriptor() ([]byte, []int) {
+	return file_moderation_moderation_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AppealTakedownResponse) GetCaseId() string {
+	if x != nil {
+		return x.CaseId
+	}
+	return ""
+}
+
+func (x *AppealTakedownResponse) GetState() CaseState {
+	if x != nil {
+		return x.State
+	}
+	return CaseState_CASE_STATE_UNSPECIFIED
+}
+
+type ReportVideoResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CaseId        string                 `protobuf:"bytes,1,opt,name=case_id,json=caseId,proto3" json:"case_id,omitempty"`                 // the moderation case collecting all reports of the video
+	Duplicate     bool                   `protobuf:"varint,2,opt,name=duplicate,proto3" json:"duplicate,omitempty"`                        // the caller had reported the video before
+	ReportCount   int32                  `protobuf:"varint,3,opt,name=report_count,json=reportCount,proto3" json:"report_count,omitempty"` // distinct users who reported the video
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportVideoResponse) Reset() {
+	*x = ReportVideoResponse{}
+	mi := &file_moderation_moderation_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportVideoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportVideoResponse) ProtoMessage() {}
+
+func (x *ReportVideoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_moderation_moderation_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportVideoResponse.ProtoReflect.Descriptor instead.
+func (*ReportVideoResponse) Descriptor() ([]byte, []int) {
+	return file_moderation_moderation_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ReportVideoResponse) GetCaseId() string {
+	if x != nil {
+		return x.CaseId
+	}
+	return ""
+}
+
+func (x *ReportVideoResponse) GetDuplicate() bool {
+	if x != nil {
+		return x.Duplicate
+	}
+	return false
+}
+
+func (x *ReportVideoResponse) GetReportCount() int32 {
+	if x != nil {
+		return x.ReportCount
+	}
+	return 0
+}
+
+var File_moderation_moderation_proto protoreflect.FileDescriptor
+
+const file_moderation_moderation_proto_rawDesc = "" +
+	"\n" +
+	"\x1bmoderation/moderation.proto\x12\n" +
+	"moderation\x1a\x1bbuf/validate/validate.proto\x1a\x1cgoogle/api/annotations.proto\"\x9d\x01\n" +
+	"\x12ReportVideoRequest\x12%\n" +
+	"\bvideo_id\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x01R\avideoId\x12<\n" +
+	"\x06reason\x18\x02 \x01(\x0e2\x18.moderation.ReportReasonB\n" +
+	"\xbaH\a\x82\x01\x04\x10\x01 \x00R\x06reason\x12\"\n" +
+	"\adetails\x18\x03 \x01(\tB\b\xbaH\x05r\x03\x18\xd0\x0fR\adetails\"\x96\x01\n" +
+	"\x06Report\x12\x1f\n" +
+	"\vreporter_id\x18\x01 \x01(\tR\n" +
+	"reporterId\x120\n" +
+	"\x06reason\x18\x02 \x01(\x0e2\x18.moderation.ReportReasonR\x06reason\x12\x18\n" +
+	"\adetails\x18\x03 \x01(\tR\adetails\x12\x1f\n" +
+	"\vreported_at\x18\x04 \x01(\x03R\n" +
+	"reportedAt\"\x9d\x01\n" +
+	"\n" +
+	"Transition\x12)\n" +
+	"\x04from\x18\x01 \x01(\x0e2\x15.moderation.CaseStateR\x04from\x12%\n" +
+	"\x02to\x18\x02 \x01(\x0e2\x15.moderation.CaseStateR\x02to\x12\x19\n" +
+	"\bactor_id\x18\x03 \x01(\tR\aactorId\x12\x12\n" +
+	"\x04note\x18\x04 \x01(\tR\x04note\x12\x0e\n" +
+	"\x02at\x18\x05 \x01(\x03R\x02at\"\xa4\x02\n" +
+	"\x04Case\x12\x17\n" +
+	"\acase_id\x18\x01 \x01(\tR\x06caseId\x12\x19\n" +
+	"\bvideo_id\x18\x02 \x01(\tR\avideoId\x12\x1f\n" +
+	"\vuploader_id\x18\x03 \x01(\tR\n" +
+	"uploaderId\x12+\n" +
+	"\x05state\x18\x04 \x01(\x0e2\x15.moderation.CaseStateR\x05state\x12,\n" +
+	"\areports\x18\x05 \x03(\v2\x12.moderation.ReportR\areports\x120\n" +
+	"\ahistory\x18\x06 \x03(\v2\x16.moderation.TransitionR\ahistory\x12\x1b\n" +
+	"\topened_at\x18\a \x01(\x03R\bopenedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\x03R\tupdatedAt\"b\n" +
+	"\x15AppealTakedownRequest\x12%\n" +
+	"\bvideo_id\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x01R\avideoId\x12\"\n" +
+	"\amessage\x18\x02 \x01(\tB\b\xbaH\x05r\x03\x18\xd0\x0fR\amessage\"^\n" +
+	"\x16AppealTakedownResponse\x12\x17\n" +
+	"\acase_id\x18\x01 \x01(\tR\x06caseId\x12+\n" +
+	"\x05state\x18\x02 \x01(\x0e2\x15.moderation.CaseStateR\x05state\"o\n" +
+	"\x13ReportVideoResponse\x12\x17\n" +
+	"\acase_id\x18\x01 \x01(\tR\x06caseId\x12\x1c\n" +
+	"\tduplicate\x18\x02 \x01(\bR\tduplicate\x12!\n" +
+	"\freport_count\x18\x03 \x01(\x05R\vreportCount*\xba\x01\n" +
+	"\fReportReason\x12\x1d\n" +
+	"\x19REPORT_REASON_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12REPORT_REASON_SPAM\x10\x01\x12\x1c\n" +
+	"\x18REPORT_REASON_HARASSMENT\x10\x02\x12\x1b\n" +
+	"\x17REPORT_REASON_COPYRIGHT\x10\x03\x12\x1f\n" +
+	"\x1bREPORT_REASON_INAPPROPRIATE\x10\x04\x12\x17\n" +
+	"\x13REPORT_REASON_OTHER\x10\x05*\xac\x01\n" +
+	"\tCaseState\x12\x1a\n" +
+	"\x16CASE_STATE_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13CASE_STATE_REPORTED\x10\x01\x12\x1b\n" +
+	"\x17CASE_STATE_UNDER_REVIEW\x10\x02\x12\x19\n" +
+	"\x15CASE_STATE_TAKEN_DOWN\x10\x03\x12\x17\n" +
+	"\x13CASE_STATE_APPEALED\x10\x04\x12\x19\n" +
+	"\x15CASE_STATE_REINSTATED\x10\x052\x90\x02\n" +
+	"\x11ModerationService\x12x\n" +
+	"\vReportVideo\x12\x1e.moderation.ReportVideoRequest\x1a\x1f.moderation.ReportVideoResponse\"(\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/v1/videos/{video_id}/reports\x12\x80\x01\n" +
+	"\x0eAppealTakedown\x12!.moderation.AppealTakedownRequest\x1a\".moderation.AppealTakedownResponse\"'\x82\xd3\xe4\x93\x02!:\x01*\"\x1c/v1/videos/{video_id}/appealB(Z&coscup2025/proto/moderation;moderationb\x06proto3"
+
+var (
+	file_moderation_moderation_proto_rawDescOnce sync.Once
+	file_moderation_moderation_proto_rawDescData []byte
+)
+
+func file_moderation_moderation_proto_rawDescGZIP() []byte {
+	file_moderation_moderation_proto_rawDescOnce.Do(func() {
+		file_moderation_moderation_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_moderation_moderation_proto_rawDesc), len(file_moderation_moderation_proto_rawDesc)))
+	})
+	return file_moderation_moderation_proto_rawDescData
+}
+
+var file_moderation_moderation_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_moderation_moderation_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_moderation_moderation_proto_goTypes = []any{
+	(ReportReason)(0),              // 0: moderation.ReportReason
+	(CaseState)(0),                 // 1: moderation.CaseState
+	(*ReportVideoRequest)(nil),     // 2: moderation.ReportVideoRequest
+	(*Report)(nil),                 // 3: moderation.Report
+	(*Transition)(nil),             // 4: moderation.Transition
+	(*Case)(nil),                   // 5: moderation.Case
+	(*AppealTakedownRequest)(nil),  // 6: moderation.AppealTakedownRequest
+	(*AppealTakedownResponse)(nil), // 7: moderation.AppealTakedownResponse
+	(*ReportVideoResponse)(nil),    // 8: moderation.ReportVideoResponse
+}
+var file_moderation_moderation_proto_depIdxs = []int32{
+	0,  // 0: moderation.ReportVideoRequest.reason:type_name -> moderation.ReportReason
+	0,  // 1: moderation.Report.reason:type_name -> moderation.ReportReason
+	1,  // 2: moderation.Transition.from:type_name -> moderation.CaseState
+	1,  // 3: moderation.Transition.to:type_name -> moderation.CaseState
+	1,  // 4: moderation.Case.state:type_name -> moderation.CaseState
+	3,  // 5: moderation.Case.reports:type_name -> moderation.Report
+	4,  // 6: moderation.Case.history:type_name -> moderation.Transition
+	1,  // 7: moderation.AppealTakedownResponse.state:type_name -> moderation.CaseState
+	2,  // 8: moderation.ModerationService.ReportVideo:input_type -> moderation.ReportVideoRequest
+	6,  // 9: moderation.ModerationService.AppealTakedown:input_type -> moderation.AppealTakedownRequest
+	8,  // 10: moderation.ModerationService.ReportVideo:output_type -> moderation.ReportVideoResponse
+	7,  // 11: moderation.ModerationService.AppealTakedown:output_type -> moderation.AppealTakedownResponse
+	10, // [10:12] is the sub-list for method output_type
+	8,  // [8:10] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_moderation_moderation_proto_init() }
+func file_moderation_moderation_proto_init() {
+	if File_moderation_moderation_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_moderation_moderation_proto_rawDesc), len(file_moderation_moderation_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_moderation_moderation_proto_goTypes,
+		DependencyIndexes: file_moderation_moderation_proto_depIdxs,
+		EnumInfos:         file_moderation_moderation_proto_enumTypes,
+		MessageInfos:      file_moderation_moderation_proto_msgTypes,
+	}.Build()
+	File_moderation_moderation_proto = out.File
+	file_moderation_moderation_proto_goTypes = nil
+	file_moderation_moderation_proto_depIdxs = nil
+}
@@ -0,0 +1,331 @@
+package s3store
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// emptyHash is the SHA-256 of an empty payload.
+const emptyHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// client makes signed requests to one bucket of an S3-compatible service.
+type client struct {
+	endpoint  *url.URL
+	region    string
+	bucket    string
+	pathStyle bool
+	accessKey string
+	secretKey string
+	http      *http.Client
+	now       func() time.Time
+}
+
+// s3Error is the error document S3 answers failed requests with.
+type s3Error struct {
+	Status  int    `xml:"-"`
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+func (e *s3Error) Error() string {
+	return fmt.Sprintf("s3: %d %s: %s", e.Status, e.Code, e.Message)
+}
+
+// Unwrap lets errors.Is match missing objects with fs.ErrNotExist.
+func (e *s3Error) Unwrap() error {
+	if e.Status == http.StatusNotFound {
+		return fs.ErrNotExist
+	}
+	return nil
+}
+
+// objectURL returns the URL of key, in the bucket's virtual host or, with
+// pathStyle as MinIO expects by default, under the bucket's path.
+func (c *client) objectURL(key string, query url.Values) *url.URL {
+	u := *c.endpoint
+	if c.pathStyle {
+		u.Path = strings.TrimSuffix(u.Path, "/") + "/" + c.bucket + "/" + key
+	} else {
+		u.Host = c.bucket + "." + u.Host
+		u.Path = strings.TrimSuffix(u.Path, "/") + "/" + key
+	}
+	u.RawQuery = query.Encode()
+	return &u
+}
+
+// do sends a request for key with body, which is signed along with it, and
+// fails unless the response has a 2xx status. The caller must close the
+// body of the response.
+func (c *client) do(ctx context.Context, method, key string, query url.Values, header http.Header, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.objectURL(key, query).String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	payloadHash := emptyHash
+	if len(body) > 0 {
+		sum := sha256.Sum256(body)
+		payloadHash = hex.EncodeToString(sum[:])
+	}
+	c.sign(req, payloadHash, c.now())
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode/100 != 2 {
+		defer resp.Body.Close()
+		return nil, readError(resp)
+	}
+	return resp, nil
+}
+
+// readError returns the error of a failed response.
+func readError(resp *http.Response) error {
+	e := &s3Error{Status: resp.StatusCode}
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if xml.Unmarshal(b, e) != nil || e.Code == "" {
+		e.Code = http.StatusText(resp.StatusCode)
+	}
+	return e
+}
+
+// sign adds the AWS Signature Version 4 of req to it. It signs the host, the
+// Range, Content-Type and Content-MD5 headers and every x-amz-* header.
+func (c *client) sign(req *http.Request, payloadHash string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		name = strings.ToLower(name)
+		if strings.HasPrefix(name, "x-amz-") || name == "range" || name == "content-type" || name == "content-md5" {
+			headers[name] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := date + "/" + c.region + "/s3/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+c.secretKey), date)
+	key = hmacSHA256(key, c.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s,SignedHeaders=%s,Signature=%s",
+		c.accessKey, scope, signedHeaders, signature))
+}
+
+// canonicalQuery encodes query sorted by name, with spaces as %20 rather
+// than +.
+func canonicalQuery(query url.Values) string {
+	return strings.ReplaceAll(query.Encode(), "+", "%20")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// putObject stores body as key.
+func (c *client) putObject(ctx context.Context, key string, body []byte) error {
+	resp, err := c.do(ctx, http.MethodPut, key, nil, nil, body)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// getObject returns the content of key.
+func (c *client) getObject(ctx context.Context, key string) ([]byte, error) {
+	resp, err := c.do(ctx, http.MethodGet, key, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return io.ReadAll(resp.Body)
+}
+
+// exists reports whether key exists.
+func (c *client) exists(ctx context.Context, key string) (bool, error) {
+	resp, err := c.do(ctx, http.MethodHead, key, nil, nil, nil)
+	if err == nil {
+		resp.Body.Close()
+		return true, nil
+	}
+	if e, ok := err.(*s3Error); ok && e.Status == http.StatusNotFound {
+		return false, nil
+	}
+	return false, err
+}
+
+// deleteObject removes key. Removing a missing key succeeds.
+func (c *client) deleteObject(ctx context.Context, key string) error {
+	resp, err := c.do(ctx, http.MethodDelete, key, nil, nil, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// copyObject copies src to dst within the bucket, which S3 does for objects
+// of up to 5 GiB.
+func (c *client) copyObject(ctx context.Context, src, dst string) error {
+	header := http.Header{"X-Amz-Copy-Source": {"/" + c.bucket + "/" + (&url.URL{Path: src}).EscapedPath()}}
+	resp, err := c.do(ctx, http.MethodPut, dst, nil, header, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return checkBody(resp)
+}
+
+// checkBody fails when a successful response carries an error document,
+// which S3 sends for copies and multipart completions that fail after it
+// started answering.
+func checkBody(resp *http.Response) error {
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	e := &s3Error{Status: resp.StatusCode}
+	if xml.Unmarshal(b, e) == nil && e.Code != "" {
+		return e
+	}
+	return nil
+}
+
+// getRange reads length bytes of key starting at offset, fewer at its end.
+// It returns the size of the object, and no bytes when offset is past its
+// end.
+func (c *client) getRange(ctx context.Context, key string, offset, length int64) ([]byte, int64, error) {
+	header := http.Header{"Range": {fmt.Sprintf("bytes=%d-%d", offset, offset+length-1)}}
+	resp, err := c.do(ctx, http.MethodGet, key, nil, header, nil)
+	if e, ok := err.(*s3Error); ok && e.Status == http.StatusRequestedRangeNotSatisfiable {
+		// Only offsets at or past the end are not satisfiable
+		return nil, offset, nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	size := resp.ContentLength
+	if resp.StatusCode == http.StatusOK {
+		// The whole object, as sent by services that ignore ranges
+		if _, err := io.CopyN(io.Discard, resp.Body, offset); err != nil {
+			return nil, size, nil
+		}
+	} else {
+		var first, last int64
+		if _, err := fmt.Sscanf(resp.Header.Get("Content-Range"), "bytes %d-%d/%d", &first, &last, &size); err != nil || first != offset {
+			return nil, 0, fmt.Errorf("s3: unexpected Content-Range %q", resp.Header.Get("Content-Range"))
+		}
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, length))
+	if err != nil {
+		return nil, 0, err
+	}
+	return data, size, nil
+}
+
+// createMultipartUpload starts a multipart upload of key and returns its ID.
+func (c *client) createMultipartUpload(ctx context.Context, key string) (string, error) {
+	resp, err := c.do(ctx, http.MethodPost, key, url.Values{"uploads": {""}}, nil, nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	var result struct {
+		UploadID string `xml:"UploadId"`
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("s3: invalid multipart upload: %v", err)
+	}
+	return result.UploadID, nil
+}
+
+// completedPart is a part of a multipart upload, as CompleteMultipartUpload
+// lists it.
+type completedPart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+// uploadPart uploads part number of a multipart upload.
+func (c *client) uploadPart(ctx context.Context, key, uploadID string, number int, body []byte) (completedPart, error) {
+	query := url.Values{"partNumber": {fmt.Sprint(number)}, "uploadId": {uploadID}}
+	resp, err := c.do(ctx, http.MethodPut, key, query, nil, body)
+	if err != nil {
+		return completedPart{}, err
+	}
+	resp.Body.Close()
+	return completedPart{PartNumber: number, ETag: resp.Header.Get("ETag")}, nil
+}
+
+// completeMultipartUpload assembles the parts of a multipart upload into
+// key.
+func (c *client) completeMultipartUpload(ctx context.Context, key, uploadID string, parts []completedPart) error {
+	body, err := xml.Marshal(struct {
+		XMLName xml.Name        `xml:"CompleteMultipartUpload"`
+		Parts   []completedPart `xml:"Part"`
+	}{Parts: parts})
+	if err != nil {
+		return err
+	}
+	resp, err := c.do(ctx, http.MethodPost, key, url.Values{"uploadId": {uploadID}}, nil, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return checkBody(resp)
+}
+
+// abortMultipartUpload drops the parts of a multipart upload.
+func (c *client) abortMultipartUpload(ctx context.Context, key, uploadID string) error {
+	resp, err := c.do(ctx, http.MethodDelete, key, url.Values{"uploadId": {uploadID}}, nil, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
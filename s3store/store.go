@@ -0,0 +1,417 @@
+// Package s3store keeps blobs in a bucket of Amazon S3 or an S3-compatible
+// service such as MinIO, with the same layout and API as package storage:
+// blobs are addressed by the SHA-256 of their content, under
+// <prefix>blobs/ab/cd/abcd…, and an index object maps keys, such as video
+// IDs, to them. A CDN can serve the blobs straight from the bucket.
+//
+// Large blobs are uploaded in parts, and blobs are read in ranges, so neither
+// is held in memory in full. Only the index is, as in package storage: run a
+// single server per prefix.
+package s3store
+
+import (
+	"cmp"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"coscup2025/env"
+	"coscup2025/storage"
+)
+
+// formatVersion is the version of the layout under a prefix, as in package
+// storage.
+const formatVersion = 1
+
+const (
+	// minPartSize is the smallest part S3 accepts, but for the last one.
+	minPartSize = 5 << 20
+	// readAhead is how much a read of a blob fetches at least, so that a
+	// download reading it in small chunks makes few requests.
+	readAhead = 4 << 20
+	// requestTimeout bounds each request, including reading its response,
+	// of which none is larger than a part or a range of readAhead bytes.
+	requestTimeout = 5 * time.Minute
+)
+
+// Store is a content-addressable blob store with an index, kept in a bucket.
+type Store struct {
+	client   *client
+	prefix   string
+	partSize int
+
+	mu    sync.Mutex
+	index map[string]storage.Record
+	// pending counts the blobs returned by Put that no Set has referred to
+	// yet, as in package storage.
+	pending map[string]int
+}
+
+// Open opens the store under prefix in the bucket configured in cfg,
+// stamping an empty prefix with the format version.
+func Open(cfg *env.Config, prefix string) (*Store, error) {
+	if cfg.S3Bucket == "" {
+		return nil, errors.New("no bucket configured")
+	}
+	endpoint, err := url.Parse(cmp.Or(cfg.S3Endpoint, "https://s3."+cfg.S3Region+".amazonaws.com"))
+	if err != nil || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid endpoint %q", cfg.S3Endpoint)
+	}
+	if cfg.S3PartSize < minPartSize {
+		return nil, fmt.Errorf("part size %d is below the minimum of %d", cfg.S3PartSize, minPartSize)
+	}
+	s := &Store{
+		client: &client{
+			endpoint:  endpoint,
+			region:    cfg.S3Region,
+			bucket:    cfg.S3Bucket,
+			pathStyle: cfg.S3PathStyle,
+			accessKey: cfg.S3AccessKeyID,
+			secretKey: cfg.S3SecretAccessKey,
+			http:      &http.Client{Timeout: requestTimeout},
+			now:       time.Now,
+		},
+		prefix:   cfg.S3Prefix + prefix,
+		partSize: cfg.S3PartSize,
+		index:    make(map[string]storage.Record),
+		pending:  make(map[string]int),
+	}
+
+	ctx := context.Background()
+	if err := s.checkFormat(ctx); err != nil {
+		return nil, err
+	}
+	b, err := s.client.getObject(ctx, s.prefix+"index.json")
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
+		return nil, err
+	default:
+		if err := json.Unmarshal(b, &s.index); err != nil {
+			return nil, fmt.Errorf("invalid index: %v", err)
+		}
+	}
+	return s, nil
+}
+
+// checkFormat stamps a new prefix with formatVersion and refuses a prefix of
+// another version.
+func (s *Store) checkFormat(ctx context.Context) error {
+	key := s.prefix + "format"
+	b, err := s.client.getObject(ctx, key)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s.client.putObject(ctx, key, []byte(strconv.Itoa(formatVersion)+"\n"))
+	}
+	if err != nil {
+		return err
+	}
+	v, err := strconv.Atoi(strings.TrimSpace(string(b)))
+	if err != nil {
+		return fmt.Errorf("invalid format object: %v", err)
+	}
+	if v != formatVersion {
+		return fmt.Errorf("store has format version %d, this build supports %d", v, formatVersion)
+	}
+	return nil
+}
+
+// blobKey returns the object key of the blob with the given hex hash.
+func (s *Store) blobKey(hash string) string {
+	return s.prefix + storage.BlobKey(hash)
+}
+
+// Put stores the content of r as a blob and returns its hash and size. The
+// content of an io.ReadSeeker is hashed first and uploaded only if the blob
+// does not exist yet; other content is uploaded to a temporary object and
+// copied into place, which S3 supports for blobs of up to 5 GiB. The blob is
+// kept until a Set refers to it and is collected once no record does.
+func (s *Store) Put(r io.Reader) (string, int64, error) {
+	ctx := context.Background()
+
+	rs, ok := r.(io.ReadSeeker)
+	if !ok {
+		return s.putCopy(ctx, r)
+	}
+	h := sha256.New()
+	size, err := io.Copy(h, rs)
+	if err != nil {
+		return "", 0, err
+	}
+	if _, err := rs.Seek(0, io.SeekStart); err != nil {
+		return "", 0, err
+	}
+	hash := hex.EncodeToString(h.Sum(nil))
+
+	// Pending before the blob is looked up, so that it is not collected
+	// once found.
+	s.hold(hash)
+	exists, err := s.client.exists(ctx, s.blobKey(hash))
+	if err == nil && !exists {
+		_, err = s.upload(ctx, s.blobKey(hash), rs)
+	}
+	if err != nil {
+		s.Discard(hash)
+		return "", 0, err
+	}
+	return hash, size, nil
+}
+
+// putCopy stores a blob whose hash is only known once it is uploaded.
+func (s *Store) putCopy(ctx context.Context, r io.Reader) (string, int64, error) {
+	b := make([]byte, 16)
+	rand.Read(b)
+	tmp := s.prefix + "tmp/" + hex.EncodeToString(b)
+	h := sha256.New()
+	size, err := s.upload(ctx, tmp, io.TeeReader(r, h))
+	if err != nil {
+		return "", 0, err
+	}
+	defer s.client.deleteObject(ctx, tmp)
+
+	hash := hex.EncodeToString(h.Sum(nil))
+	s.hold(hash)
+	exists, err := s.client.exists(ctx, s.blobKey(hash))
+	if err == nil && !exists {
+		err = s.client.copyObject(ctx, tmp, s.blobKey(hash))
+	}
+	if err != nil {
+		s.Discard(hash)
+		return "", 0, err
+	}
+	return hash, size, nil
+}
+
+// hold marks a blob pending.
+func (s *Store) hold(hash string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pending[hash]++
+}
+
+// upload stores the content of r as key, in a single request when it fits
+// in one part and in a multipart upload otherwise. It returns the size of
+// the content.
+func (s *Store) upload(ctx context.Context, key string, r io.Reader) (int64, error) {
+	buf := make([]byte, s.partSize)
+	n, err := io.ReadFull(r, buf)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return int64(n), s.client.putObject(ctx, key, buf[:n])
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	uploadID, err := s.client.createMultipartUpload(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+	var parts []completedPart
+	size := int64(0)
+	for n > 0 {
+		part, err := s.client.uploadPart(ctx, key, uploadID, len(parts)+1, buf[:n])
+		if err != nil {
+			s.client.abortMultipartUpload(ctx, key, uploadID)
+			return 0, err
+		}
+		parts = append(parts, part)
+		size += int64(n)
+
+		n, err = io.ReadFull(r, buf)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			s.client.abortMultipartUpload(ctx, key, uploadID)
+			return 0, err
+		}
+	}
+	if err := s.client.completeMultipartUpload(ctx, key, uploadID, parts); err != nil {
+		s.client.abortMultipartUpload(ctx, key, uploadID)
+		return 0, err
+	}
+	return size, nil
+}
+
+// OpenBlob opens a blob for reading. Opening makes no request: a missing
+// blob fails its first read with an error matching fs.ErrNotExist.
+func (s *Store) OpenBlob(hash string) (storage.Blob, error) {
+	b, err := hex.DecodeString(hash)
+	if err != nil || len(b) != sha256.Size {
+		return nil, fmt.Errorf("invalid blob hash %q", hash)
+	}
+	return &blob{client: s.client, key: s.blobKey(hash), size: -1}, nil
+}
+
+// Records returns a copy of the index.
+func (s *Store) Records() map[string]storage.Record {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	records := make(map[string]storage.Record, len(s.index))
+	for key, rec := range s.index {
+		records[key] = rec
+	}
+	return records
+}
+
+// Record returns the index entry of key.
+func (s *Store) Record(key string) (storage.Record, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rec, ok := s.index[key]
+	return rec, ok
+}
+
+// Discard gives up a blob stored with Put that no Set will refer to,
+// deleting it unless something else refers to it.
+func (s *Store) Discard(hash string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.pending[hash] > 1 {
+		s.pending[hash]--
+	} else {
+		delete(s.pending, hash)
+	}
+	s.collectLocked(hash)
+}
+
+// Set points key at rec, whose blob must have been stored with Put. A blob
+// that no key refers to any more is deleted.
+func (s *Store) Set(key string, rec storage.Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	old, existed := s.index[key]
+	s.index[key] = rec
+	if err := s.saveIndexLocked(); err != nil {
+		if existed {
+			s.index[key] = old
+		} else {
+			delete(s.index, key)
+		}
+		return err
+	}
+	if s.pending[rec.Blob] > 1 {
+		s.pending[rec.Blob]--
+	} else {
+		delete(s.pending, rec.Blob)
+	}
+	if existed {
+		s.collectLocked(old.Blob)
+	}
+	return nil
+}
+
+// Delete removes key from the index, and its blob when no other key refers
+// to it.
+func (s *Store) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	old, existed := s.index[key]
+	if !existed {
+		return nil
+	}
+	delete(s.index, key)
+	if err := s.saveIndexLocked(); err != nil {
+		s.index[key] = old
+		return err
+	}
+	s.collectLocked(old.Blob)
+	return nil
+}
+
+// collectLocked deletes a blob that nothing refers to. A blob that fails to
+// be deleted is left behind unreferenced. The caller must hold s.mu.
+func (s *Store) collectLocked(hash string) {
+	if s.pending[hash] > 0 {
+		return
+	}
+	for _, rec := range s.index {
+		if rec.Blob == hash {
+			return
+		}
+	}
+	s.client.deleteObject(context.Background(), s.blobKey(hash))
+}
+
+// saveIndexLocked replaces the index object, which S3 does atomically. The
+// caller must hold s.mu.
+func (s *Store) saveIndexLocked() error {
+	b, err := json.Marshal(s.index)
+	if err != nil {
+		return err
+	}
+	return s.client.putObject(context.Background(), s.prefix+"index.json", b)
+}
+
+// blob reads an object in ranges of at least readAhead bytes, and keeps the
+// last range so that sequential reads in small chunks hit it.
+type blob struct {
+	client *client
+	key    string
+
+	mu     sync.Mutex
+	size   int64 // -1 until a response told it
+	offset int64 // of the next Read
+	buf    []byte
+	bufOff int64
+}
+
+func (b *blob) Read(p []byte) (int, error) {
+	b.mu.Lock()
+	offset := b.offset
+	b.mu.Unlock()
+	n, err := b.ReadAt(p, offset)
+	b.mu.Lock()
+	b.offset += int64(n)
+	b.mu.Unlock()
+	if err == io.EOF && n > 0 {
+		err = nil
+	}
+	return n, err
+}
+
+func (b *blob) ReadAt(p []byte, off int64) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	n := 0
+	for n < len(p) {
+		pos := off + int64(n)
+		if b.size >= 0 && pos >= b.size {
+			return n, io.EOF
+		}
+		if pos < b.bufOff || pos >= b.bufOff+int64(len(b.buf)) {
+			data, size, err := b.client.getRange(context.Background(), b.key, pos, max(int64(len(p)-n), readAhead))
+			if err != nil {
+				return n, err
+			}
+			b.buf, b.bufOff, b.size = data, pos, size
+			if len(data) == 0 {
+				return n, io.EOF
+			}
+		}
+		n += copy(p[n:], b.buf[pos-b.bufOff:])
+	}
+	return n, nil
+}
+
+// Close releases the buffered range.
+func (b *blob) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.buf = nil
+	return nil
+}
@@ -0,0 +1,288 @@
+// Package s3test stands in for an S3-compatible service in tests. It serves
+// one bucket in memory over path-style requests, with the operations package
+// s3store uses, and records the requests it answered:
+//
+//	bucket := s3test.New(t)
+//	cfg := env.DefaultConfig()
+//	bucket.Configure(cfg)
+//	st, err := s3store.Open(cfg, "videos/")
+package s3test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"coscup2025/env"
+)
+
+const (
+	// Bucket is the name of the bucket.
+	Bucket = "coscup-test"
+	// AccessKeyID is the access key requests must be signed with.
+	AccessKeyID = "test-access-key"
+	// SecretAccessKey is its secret. The server checks that requests are
+	// signed with the access key and carry the hash of their payload, not
+	// the signature itself.
+	SecretAccessKey = "test-secret-key"
+)
+
+// minPartSize is the smallest part S3 accepts but for the last one.
+const minPartSize = 5 << 20
+
+// Request is a request the server answered.
+type Request struct {
+	Method string
+	Key    string
+	Query  string
+	Range  string
+	Status int
+}
+
+// Server is an S3 bucket in memory.
+type Server struct {
+	srv *httptest.Server
+
+	mu       sync.Mutex
+	objects  map[string][]byte
+	uploads  map[string]map[int][]byte // parts by upload ID
+	nextID   int
+	requests []Request
+}
+
+// New starts a server that is closed when t ends.
+func New(t testing.TB) *Server {
+	s := &Server{objects: make(map[string][]byte), uploads: make(map[string]map[int][]byte)}
+	s.srv = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.srv.Close)
+	return s
+}
+
+// URL returns the endpoint of the server.
+func (s *Server) URL() string {
+	return s.srv.URL
+}
+
+// Configure points the S3 settings of cfg at the server.
+func (s *Server) Configure(cfg *env.Config) {
+	cfg.S3Endpoint = s.URL()
+	cfg.S3Bucket = Bucket
+	cfg.S3PathStyle = true
+	cfg.S3AccessKeyID = AccessKeyID
+	cfg.S3SecretAccessKey = SecretAccessKey
+	cfg.S3PartSize = minPartSize
+}
+
+// Object returns the content of key.
+func (s *Server) Object(key string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, ok := s.objects[key]
+	return b, ok
+}
+
+// Keys returns the keys of all objects, sorted.
+func (s *Server) Keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	keys := make([]string, 0, len(s.objects))
+	for key := range s.objects {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// Uploads returns how many multipart uploads are in progress.
+func (s *Server) Uploads() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.uploads)
+}
+
+// Requests returns the requests answered so far, oldest first.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.requests)
+}
+
+// errorResponse is the error document of S3.
+type errorResponse struct {
+	XMLName xml.Name `xml:"Error"`
+	Code    string   `xml:"Code"`
+	Message string   `xml:"Message"`
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	key, ok := strings.CutPrefix(r.URL.Path, "/"+Bucket+"/")
+	req := Request{Method: r.Method, Key: key, Query: r.URL.RawQuery, Range: r.Header.Get("Range")}
+	rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+	defer func() {
+		req.Status = rec.status
+		s.mu.Lock()
+		s.requests = append(s.requests, req)
+		s.mu.Unlock()
+	}()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		fail(rec, http.StatusBadRequest, "IncompleteBody", err.Error())
+		return
+	}
+	sum := sha256.Sum256(body)
+	switch {
+	case !ok || key == "":
+		fail(rec, http.StatusNotFound, "NoSuchBucket", "only "+Bucket+" exists")
+	case !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential="+AccessKeyID+"/"):
+		fail(rec, http.StatusForbidden, "InvalidAccessKeyId", "unknown access key")
+	case r.Header.Get("X-Amz-Content-Sha256") != hex.EncodeToString(sum[:]):
+		fail(rec, http.StatusBadRequest, "XAmzContentSHA256Mismatch", "payload hash mismatch")
+	default:
+		s.handle(rec, r, key, body)
+	}
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request, key string, body []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	query := r.URL.Query()
+	uploadID := query.Get("uploadId")
+	switch {
+	case r.Method == http.MethodPost && query.Has("uploads"):
+		s.nextID++
+		id := fmt.Sprintf("upload-%d", s.nextID)
+		s.uploads[id] = make(map[int][]byte)
+		fmt.Fprintf(w, "<InitiateMultipartUploadResult><Bucket>%s</Bucket><Key>%s</Key><UploadId>%s</UploadId></InitiateMultipartUploadResult>", Bucket, key, id)
+
+	case r.Method == http.MethodPut && uploadID != "":
+		parts, ok := s.uploads[uploadID]
+		if !ok {
+			fail(w, http.StatusNotFound, "NoSuchUpload", uploadID)
+			return
+		}
+		var number int
+		fmt.Sscan(query.Get("partNumber"), &number)
+		parts[number] = body
+		w.Header().Set("ETag", etag(body))
+
+	case r.Method == http.MethodPost && uploadID != "":
+		parts, ok := s.uploads[uploadID]
+		if !ok {
+			fail(w, http.StatusNotFound, "NoSuchUpload", uploadID)
+			return
+		}
+		var complete struct {
+			Parts []struct {
+				PartNumber int
+				ETag       string
+			} `xml:"Part"`
+		}
+		if err := xml.Unmarshal(body, &complete); err != nil || len(complete.Parts) == 0 {
+			fail(w, http.StatusBadRequest, "MalformedXML", "no parts")
+			return
+		}
+		var content []byte
+		for i, p := range complete.Parts {
+			part, ok := parts[p.PartNumber]
+			if !ok || p.PartNumber != i+1 || p.ETag != etag(part) {
+				fail(w, http.StatusBadRequest, "InvalidPart", fmt.Sprint(p.PartNumber))
+				return
+			}
+			if len(part) < minPartSize && i < len(complete.Parts)-1 {
+				fail(w, http.StatusBadRequest, "EntityTooSmall", fmt.Sprint(p.PartNumber))
+				return
+			}
+			content = append(content, part...)
+		}
+		s.objects[key] = content
+		delete(s.uploads, uploadID)
+		fmt.Fprintf(w, "<CompleteMultipartUploadResult><Key>%s</Key><ETag>%s</ETag></CompleteMultipartUploadResult>", key, etag(content))
+
+	case r.Method == http.MethodDelete && uploadID != "":
+		delete(s.uploads, uploadID)
+		w.WriteHeader(http.StatusNoContent)
+
+	case r.Method == http.MethodPut && r.Header.Get("X-Amz-Copy-Source") != "":
+		src, _ := strings.CutPrefix(r.Header.Get("X-Amz-Copy-Source"), "/"+Bucket+"/")
+		content, ok := s.objects[src]
+		if !ok {
+			fail(w, http.StatusNotFound, "NoSuchKey", src)
+			return
+		}
+		s.objects[key] = slices.Clone(content)
+		fmt.Fprintf(w, "<CopyObjectResult><ETag>%s</ETag></CopyObjectResult>", etag(content))
+
+	case r.Method == http.MethodPut:
+		s.objects[key] = body
+		w.Header().Set("ETag", etag(body))
+
+	case r.Method == http.MethodGet || r.Method == http.MethodHead:
+		content, ok := s.objects[key]
+		if !ok {
+			fail(w, http.StatusNotFound, "NoSuchKey", key)
+			return
+		}
+		w.Header().Set("ETag", etag(content))
+		if rng := r.Header.Get("Range"); rng != "" {
+			var first, last int
+			if _, err := fmt.Sscanf(rng, "bytes=%d-%d", &first, &last); err != nil || first > last {
+				fail(w, http.StatusBadRequest, "InvalidArgument", rng)
+				return
+			}
+			if first >= len(content) {
+				w.Header().Set("Content-Range", fmt.Sprintf("bytes */%d", len(content)))
+				fail(w, http.StatusRequestedRangeNotSatisfiable, "InvalidRange", rng)
+				return
+			}
+			last = min(last, len(content)-1)
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", first, last, len(content)))
+			content = content[first : last+1]
+			w.Header().Set("Content-Length", fmt.Sprint(len(content)))
+			w.WriteHeader(http.StatusPartialContent)
+		} else {
+			w.Header().Set("Content-Length", fmt.Sprint(len(content)))
+		}
+		if r.Method == http.MethodGet {
+			w.Write(content)
+		}
+
+	case r.Method == http.MethodDelete:
+		delete(s.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		fail(w, http.StatusMethodNotAllowed, "MethodNotAllowed", r.Method)
+	}
+}
+
+func etag(b []byte) string {
+	sum := sha256.Sum256(b)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+func fail(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	xml.NewEncoder(w).Encode(errorResponse{Code: code, Message: message})
+}
+
+// statusRecorder keeps the status of a response.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
@@ -0,0 +1,355 @@
+// Package servertest runs the whole server in memory for tests: the gRPC
+// services on an in-process listener and the gateway in front of them, wired
+// as in production. It is meant for this repository's tests and for
+// integrators who want to test their clients against a real server.
+package servertest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+
+	"coscup2025/account"
+	"coscup2025/auth"
+	"coscup2025/cdn"
+	"coscup2025/chaos"
+	"coscup2025/comment"
+	"coscup2025/deprecation"
+	"coscup2025/env"
+	"coscup2025/gateway"
+	"coscup2025/jobs"
+	"coscup2025/keyset"
+	"coscup2025/mailer"
+	"coscup2025/media"
+	"coscup2025/mesh"
+	"coscup2025/moderation"
+	"coscup2025/notification"
+	"coscup2025/policy"
+	"coscup2025/s3store"
+	"coscup2025/stall"
+	"coscup2025/storage"
+	"coscup2025/validation"
+
+	pbAccount "coscup2025/proto/account"
+	pbAdmin "coscup2025/proto/admin"
+	pbAuth "coscup2025/proto/auth"
+	pbComment "coscup2025/proto/comment"
+	pbMedia "coscup2025/proto/media"
+	pbMediaV2 "coscup2025/proto/media/v2"
+	pbModeration "coscup2025/proto/moderation"
+	pbNotification "coscup2025/proto/notification"
+)
+
+// Target is the address clients dial with Server.DialOption.
+const Target = "passthrough:///bufnet"
+
+// Server is a running in-memory server.
+type Server struct {
+	// Conn is a client connection to the gRPC services.
+	Conn *grpc.ClientConn
+	// Gateway serves the HTTP API, as the gateway does on :8080.
+	Gateway http.Handler
+	// Keys is the server secret, which tests may rotate.
+	Keys *keyset.Set
+
+	lis *bufconn.Listener
+}
+
+// Option customizes a Server.
+type Option func(*options)
+
+type options struct {
+	backend media.Backend
+}
+
+// WithBackend routes video bytes through b, e.g. a faultstore.Backend.
+func WithBackend(b media.Backend) Option {
+	return func(o *options) { o.backend = b }
+}
+
+// New starts a server with cfg, or env.DefaultConfig when cfg is nil. It is
+// stopped when the test ends.
+func New(t testing.TB, cfg *env.Config, opts ...Option) *Server {
+	t.Helper()
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if cfg == nil {
+		cfg = env.DefaultConfig()
+	}
+	lis := bufconn.Listen(1024 * 1024)
+
+	keys := keyset.New([]byte(cfg.JWTSecret))
+	authSrv := auth.NewAuthServer(cfg, keys)
+	mediaSrv := media.NewMediaServer(cfg, keys)
+	notificationSrv := notification.NewNotificationServer(cfg, keys)
+	mediaSrv.SetNotifier(notificationSrv)
+	alerter := notification.NewAlerter(notificationSrv, authSrv)
+	if len(cfg.AlertEmails) > 0 {
+		m, err := mailer.New(cfg)
+		if err != nil {
+			t.Fatalf("failed to start mailer: %v", err)
+		}
+		t.Cleanup(m.Close)
+		alerter.MailTo(m, cfg.AlertEmails)
+	}
+	mediaSrv.SetAlerter(alerter)
+	healthSrv := health.NewServer()
+	mediaSrv.SetHealth(healthSrv)
+	mediaSrv.SetTokenSigner(authSrv)
+	if o.backend != nil {
+		mediaSrv.SetBackend(o.backend)
+	}
+	// Videos, avatars and upload sessions are stored where cfg says, as
+	// by main.
+	if cfg.UploadSessionDir != "" {
+		if _, err := mediaSrv.PersistUploadSessions(cfg.UploadSessionDir); err != nil {
+			t.Fatalf("failed to open upload session directory: %v", err)
+		}
+	}
+	if cfg.S3Bucket != "" {
+		st, err := s3store.Open(cfg, "videos/")
+		if err == nil {
+			_, err = mediaSrv.OffloadVideos(st)
+		}
+		var avatars *s3store.Store
+		if err == nil {
+			avatars, err = s3store.Open(cfg, "avatars/")
+		}
+		if err == nil {
+			_, err = mediaSrv.PersistAvatars(avatars)
+		}
+		if err != nil {
+			t.Fatalf("failed to open S3 storage: %v", err)
+		}
+	} else if cfg.StorageDir != "" {
+		st, err := storage.Open(cfg.StorageDir)
+		if err == nil {
+			_, err = mediaSrv.PersistVideos(st)
+		}
+		var avatars *storage.Store
+		if err == nil {
+			avatars, err = storage.Open(filepath.Join(cfg.StorageDir, "avatars"))
+		}
+		if err == nil {
+			_, err = mediaSrv.PersistAvatars(avatars)
+		}
+		if err != nil {
+			t.Fatalf("failed to open storage: %v", err)
+		}
+	}
+	if cfg.CDNProvider != "" {
+		signer, err := cdnSigner(cfg)
+		if err != nil {
+			t.Fatalf("invalid CDN configuration: %v", err)
+		}
+		mediaSrv.SetCDN(signer)
+	}
+	moderationSrv := moderation.NewModerationServer(cfg, mediaSrv)
+	moderationSrv.SetNotifier(notificationSrv)
+	jobRunner := jobs.NewRunner(cfg.JobWorkers, cfg.JobResultTTL)
+	t.Cleanup(jobRunner.Close)
+	mediaSrv.SetJobRunner(jobRunner)
+	commentSrv := comment.NewCommentServer(cfg, keys, mediaSrv)
+	accountSrv := account.NewAccountServer(cfg, authSrv, mediaSrv, jobRunner)
+	accountSrv.AddEraser(commentSrv)
+	accountSrv.AddEraser(moderationSrv)
+	accountSrv.AddEraser(notificationSrv)
+	if cfg.DeletionReceiptKeyFile != "" {
+		key, err := account.ReadReceiptKey(cfg.DeletionReceiptKeyFile)
+		if err != nil {
+			t.Fatalf("failed to read deletion receipt key: %v", err)
+		}
+		accountSrv.SetReceiptKey(key)
+	}
+	if cfg.DeletionDir != "" {
+		if _, err := accountSrv.PersistDeletions(cfg.DeletionDir); err != nil {
+			t.Fatalf("failed to open deletion directory: %v", err)
+		}
+	}
+	mediaSrv.SetConsents(accountSrv)
+	if cfg.RetentionInterval > 0 {
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+		go mediaSrv.EnforceRetentionPeriodically(ctx, cfg.RetentionInterval)
+	}
+
+	var unary []grpc.UnaryServerInterceptor
+	var stream []grpc.StreamServerInterceptor
+	if identities := mesh.New(cfg); identities.Enabled() {
+		unary = append(unary, identities.UnaryServerInterceptor)
+		stream = append(stream, identities.StreamServerInterceptor)
+	}
+	unary = append(unary, authSrv.UnaryInterceptor, validation.UnaryServerInterceptor, deprecation.UnaryServerInterceptor)
+	stream = append(stream, authSrv.StreamInterceptor, validation.StreamServerInterceptor, deprecation.StreamServerInterceptor)
+	policies, err := policy.Load(cfg)
+	if err != nil {
+		t.Fatalf("invalid authorization policies: %v", err)
+	}
+	if policies.Enabled() {
+		policies.SetVideos(mediaSrv)
+		unary = append(unary, policies.UnaryServerInterceptor)
+		stream = append(stream, policies.StreamServerInterceptor)
+	}
+	if watchdog := stall.New(cfg); watchdog.Enabled() {
+		stream = append(stream, watchdog.StreamServerInterceptor)
+	}
+	if faults := chaos.New(cfg); faults.Enabled() {
+		unary = append(unary, faults.UnaryServerInterceptor)
+		stream = append(stream, faults.StreamServerInterceptor)
+	}
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	)
+	pbAuth.RegisterAuthServiceServer(server, authSrv)
+	pbMedia.RegisterMediaServiceServer(server, mediaSrv)
+	pbMediaV2.RegisterMediaServiceServer(server, mediaSrv.V2())
+	pbNotification.RegisterNotificationServiceServer(server, notificationSrv)
+	pbComment.RegisterCommentServiceServer(server, commentSrv)
+	pbModeration.RegisterModerationServiceServer(server, moderationSrv)
+	adminSrv := moderation.NewAdminServer(cfg, keys, moderationSrv)
+	adminSrv.SetRetainer(mediaSrv)
+	adminSrv.SetAccounts(authSrv)
+	pbAdmin.RegisterAdminServiceServer(server, adminSrv)
+	pbAccount.RegisterAccountServiceServer(server, accountSrv)
+	healthpb.RegisterHealthServer(server, healthSrv)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	s := &Server{Keys: keys, lis: lis}
+	conn, err := grpc.NewClient(Target, grpc.WithTransportCredentials(insecure.NewCredentials()), s.DialOption())
+	if err != nil {
+		t.Fatalf("failed to dial test server: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	var player *gateway.PlayerScript
+	if cfg.HLSPlayerFile != "" {
+		if player, err = gateway.ReadPlayerScript(cfg.HLSPlayerFile); err != nil {
+			t.Fatalf("failed to read HLS player: %v", err)
+		}
+	}
+	mux, err := gateway.NewMux(context.Background(), conn, player)
+	if err != nil {
+		t.Fatalf("failed to set up gateway: %v", err)
+	}
+	s.Conn, s.Gateway = conn, mux
+	return s
+}
+
+// cdnSigner returns the URL signer of the configured CDN.
+func cdnSigner(cfg *env.Config) (media.URLSigner, error) {
+	switch cfg.CDNProvider {
+	case "cloudfront":
+		key, err := os.ReadFile(cfg.CDNPrivateKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		return cdn.NewCloudFront(cfg.CDNBaseURL, cfg.CDNKeyPairID, key)
+	case "fastly":
+		return cdn.NewFastly(cfg.CDNBaseURL, []byte(cfg.CDNSigningKey))
+	}
+	return nil, fmt.Errorf("unknown CDN provider %q", cfg.CDNProvider)
+}
+
+// DialOption connects a client that dials Target to the server, such as the
+// client package:
+//
+//	c, err := client.New(servertest.Target, client.WithDialOptions(srv.DialOption()))
+func (s *Server) DialOption() grpc.DialOption {
+	return grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+		return s.lis.Dial()
+	})
+}
+
+// Auth returns a client of the auth service.
+func (s *Server) Auth() pbAuth.AuthServiceClient {
+	return pbAuth.NewAuthServiceClient(s.Conn)
+}
+
+// Media returns a client of the media service.
+func (s *Server) Media() pbMedia.MediaServiceClient {
+	return pbMedia.NewMediaServiceClient(s.Conn)
+}
+
+// Health returns a client of the gRPC health service.
+func (s *Server) Health() healthpb.HealthClient {
+	return healthpb.NewHealthClient(s.Conn)
+}
+
+// MediaV2 returns a client of version 2 of the media service.
+func (s *Server) MediaV2() pbMediaV2.MediaServiceClient {
+	return pbMediaV2.NewMediaServiceClient(s.Conn)
+}
+
+// CreateUser signs up username, accepting the current terms, and returns a
+// token to act as them.
+func (s *Server) CreateUser(t testing.TB, username, password string) string {
+	t.Helper()
+	ctx := context.Background()
+	terms, err := s.Auth().GetTerms(ctx, &pbAuth.GetTermsRequest{})
+	if err != nil {
+		t.Fatalf("GetTerms: %v", err)
+	}
+	_, err = s.Auth().SignUp(ctx, &pbAuth.SignUpRequest{
+		Username:             username,
+		Password:             password,
+		AcceptedTermsVersion: terms.Version,
+	})
+	if err != nil {
+		t.Fatalf("SignUp %s: %v", username, err)
+	}
+	return s.Token(t, username, password)
+}
+
+// Token signs in and returns a fresh token.
+func (s *Server) Token(t testing.TB, username, password string) string {
+	t.Helper()
+	resp, err := s.Auth().SignIn(context.Background(), &pbAuth.SignInRequest{Username: username, Password: password})
+	if err != nil {
+		t.Fatalf("SignIn %s: %v", username, err)
+	}
+	return resp.Token
+}
+
+// Context returns a context whose gRPC calls carry token.
+func Context(token string) context.Context {
+	return metadata.NewOutgoingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+}
+
+// Do sends an HTTP request to the gateway, with token as the bearer token
+// unless it is empty, and returns the recorded response. A non-nil body is
+// sent as JSON.
+func (s *Server) Do(t testing.TB, method, path, token string, body []byte) *http.Response {
+	t.Helper()
+	var r io.Reader
+	if body != nil {
+		r = bytes.NewReader(body)
+	}
+	req := httptest.NewRequest(method, path, r)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rr := httptest.NewRecorder()
+	s.Gateway.ServeHTTP(rr, req)
+	return rr.Result()
+}
@@ -0,0 +1,165 @@
+// Package smtptest stands in for an SMTP server in tests. It accepts every
+// message over plain SMTP, without TLS or authentication, and records it, so
+// that tests can check what the server mails:
+//
+//	server := smtptest.New(t)
+//	cfg.SMTPAddr = server.Addr()
+//	mail := server.RequireMail(t)
+package smtptest
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// WaitTimeout is how long RequireMail waits for a message, since mailers
+// deliver in the background.
+var WaitTimeout = 5 * time.Second
+
+// Mail is a received message.
+type Mail struct {
+	From   string   // envelope sender
+	To     []string // envelope recipients
+	Header mail.Header
+	Body   string // with CRLF line endings turned into LF
+}
+
+// Server is a running SMTP server.
+type Server struct {
+	lis net.Listener
+
+	mu       sync.Mutex
+	mails    []Mail
+	next     int           // index of the mail RequireMail returns next
+	received chan struct{} // signalled when a mail arrives
+}
+
+// New starts a server on a free local port. It is stopped when the test
+// ends.
+func New(t testing.TB) *Server {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	s := &Server{lis: lis, received: make(chan struct{}, 1)}
+	go s.serve()
+	t.Cleanup(func() { lis.Close() })
+	return s
+}
+
+// Addr is the host:port to send mail to.
+func (s *Server) Addr() string {
+	return s.lis.Addr().String()
+}
+
+// Mails returns the messages received so far, oldest first.
+func (s *Server) Mails() []Mail {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Mail(nil), s.mails...)
+}
+
+// RequireMail waits for the next message not yet returned by RequireMail
+// and returns it, failing the test when none arrives in time.
+func (s *Server) RequireMail(t testing.TB) Mail {
+	t.Helper()
+	timeout := time.After(WaitTimeout)
+	for {
+		s.mu.Lock()
+		if s.next < len(s.mails) {
+			m := s.mails[s.next]
+			s.next++
+			s.mu.Unlock()
+			return m
+		}
+		s.mu.Unlock()
+		select {
+		case <-s.received:
+		case <-timeout:
+			t.Fatalf("no mail received within %s", WaitTimeout)
+		}
+	}
+}
+
+func (s *Server) serve() {
+	for {
+		conn, err := s.lis.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+// handle speaks just enough SMTP for net/smtp clients.
+func (s *Server) handle(conn net.Conn) {
+	defer conn.Close()
+	tp := textproto.NewConn(conn)
+	tp.PrintfLine("220 smtptest ready")
+
+	var from string
+	var to []string
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			tp.PrintfLine("250 smtptest")
+		case "MAIL":
+			from = address(arg)
+			to = nil
+			tp.PrintfLine("250 OK")
+		case "RCPT":
+			to = append(to, address(arg))
+			tp.PrintfLine("250 OK")
+		case "DATA":
+			tp.PrintfLine("354 end data with <CR><LF>.<CR><LF>")
+			data, err := io.ReadAll(tp.DotReader())
+			if err != nil {
+				return
+			}
+			msg, err := mail.ReadMessage(bufio.NewReader(strings.NewReader(string(data))))
+			if err != nil {
+				tp.PrintfLine("554 invalid message: %v", err)
+				continue
+			}
+			body, _ := io.ReadAll(msg.Body)
+			s.mu.Lock()
+			s.mails = append(s.mails, Mail{From: from, To: to, Header: msg.Header, Body: string(body)})
+			s.mu.Unlock()
+			select {
+			case s.received <- struct{}{}:
+			default:
+			}
+			tp.PrintfLine("250 OK")
+		case "RSET":
+			from, to = "", nil
+			tp.PrintfLine("250 OK")
+		case "NOOP":
+			tp.PrintfLine("250 OK")
+		case "QUIT":
+			tp.PrintfLine("221 bye")
+			return
+		default:
+			tp.PrintfLine("502 command not implemented")
+		}
+	}
+}
+
+// address returns the address in "FROM:<a@b>" or "TO:<a@b>".
+func address(arg string) string {
+	_, addr, _ := strings.Cut(arg, ":")
+	addr, _, _ = strings.Cut(strings.TrimSpace(addr), " ")
+	return strings.Trim(addr, "<>")
+}
@@ -0,0 +1,102 @@
+// Package stall aborts gRPC streams that stop making progress, so that a
+// client that went silent, or stopped reading, does not hold an upload
+// session, a download slot or the locks behind them until its connection
+// times out.
+package stall
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"coscup2025/env"
+)
+
+// Watchdog aborts a stream when a message it receives or sends does not move
+// for Timeout. Only waiting on the peer counts: a handler that waits for
+// something else between messages, such as WatchProgress for events, is not
+// stalled. The zero value watches nothing.
+type Watchdog struct {
+	Timeout time.Duration
+}
+
+// New returns the watchdog configured in cfg.
+func New(cfg *env.Config) Watchdog {
+	return Watchdog{Timeout: cfg.StreamStallTimeout}
+}
+
+// Enabled reports whether w aborts anything.
+func (w Watchdog) Enabled() bool {
+	return w.Timeout > 0
+}
+
+// StreamServerInterceptor aborts stalled streams with Aborted, which clients
+// treat as transient, so that they resume.
+func (w Watchdog) StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	gs := &guardedStream{ServerStream: ss, timeout: w.Timeout}
+	err := handler(srv, gs)
+	// Whatever the handler made of the failed message, the stream ended
+	// because it stalled.
+	if stalled := gs.err(); stalled != nil {
+		log.Printf("Aborted stalled stream %s: %v", info.FullMethod, stalled)
+		return stalled
+	}
+	return err
+}
+
+// guardedStream fails a message that does not move for timeout, and every
+// message after it.
+type guardedStream struct {
+	grpc.ServerStream
+	timeout time.Duration
+
+	mu      sync.Mutex // SendMsg and RecvMsg may run concurrently
+	stalled error
+}
+
+func (s *guardedStream) err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stalled
+}
+
+func (s *guardedStream) SendMsg(m interface{}) error {
+	return s.guard("peer stopped reading", func() error { return s.ServerStream.SendMsg(m) })
+}
+
+func (s *guardedStream) RecvMsg(m interface{}) error {
+	return s.guard("no message received", func() error { return s.ServerStream.RecvMsg(m) })
+}
+
+// guard runs op, giving up on it after the timeout with the reason. The
+// handler then returns the error, which ends the stream and with it the
+// abandoned op.
+func (s *guardedStream) guard(reason string, op func() error) error {
+	if err := s.err(); err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- op() }()
+	t := time.NewTimer(s.timeout)
+	defer t.Stop()
+	select {
+	case err := <-done:
+		return err
+	case <-t.C:
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stalled == nil {
+		s.stalled = status.Errorf(codes.Aborted, "stream stalled: %s for %s", reason, s.timeout)
+		trace.SpanFromContext(s.Context()).SetAttributes(attribute.String("stream.stalled", reason))
+	}
+	return s.stalled
+}
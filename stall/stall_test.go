@@ -0,0 +1,99 @@
+package stall_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"coscup2025/env"
+	pbMedia "coscup2025/proto/media"
+	"coscup2025/servertest"
+	"coscup2025/stall"
+)
+
+func setup(t *testing.T) (pbMedia.MediaServiceClient, context.Context) {
+	cfg := env.DefaultConfig()
+	cfg.StreamStallTimeout = 200 * time.Millisecond
+	srv := servertest.New(t, cfg)
+	return srv.Media(), servertest.Context(srv.CreateUser(t, "speaker", "secret"))
+}
+
+func TestStalledUploadIsAborted(t *testing.T) {
+	client, ctx := setup(t)
+	video := bytes.Repeat([]byte("coscup"), 1000)
+	created, err := client.CreateUploadSession(ctx, &pbMedia.CreateUploadSessionRequest{VideoId: "talk", TotalSize: int64(len(video))})
+	require.NoError(t, err)
+	uploadID := created.Session.UploadId
+
+	// Step 1: a client that stops sending is cut off
+	stream, err := client.UploadVideo(ctx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&pbMedia.UploadVideoRequest{VideoId: "talk", UploadId: uploadID, Data: video[:3000]}))
+	err = stream.RecvMsg(&pbMedia.UploadVideoResponse{})
+	assert.Equal(t, codes.Aborted, status.Code(err))
+
+	// Step 2: the session is released with what it committed, for a resume
+	resumed, err := client.UploadVideo(ctx)
+	require.NoError(t, err)
+	require.NoError(t, resumed.Send(&pbMedia.UploadVideoRequest{VideoId: "talk", UploadId: uploadID, Offset: 3000, Data: video[3000:]}))
+	resp, err := resumed.CloseAndRecv()
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(video)), resp.TotalBytes)
+}
+
+func TestHeartbeatsKeepUploadAlive(t *testing.T) {
+	client, ctx := setup(t)
+	video := bytes.Repeat([]byte("coscup"), 1000)
+	created, err := client.CreateUploadSession(ctx, &pbMedia.CreateUploadSessionRequest{VideoId: "talk", TotalSize: int64(len(video))})
+	require.NoError(t, err)
+	uploadID := created.Session.UploadId
+
+	stream, err := client.UploadVideo(ctx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&pbMedia.UploadVideoRequest{VideoId: "talk", UploadId: uploadID, Data: video[:3000]}))
+	// Waiting for input for longer than the timeout, with heartbeats
+	for range 10 {
+		time.Sleep(50 * time.Millisecond)
+		require.NoError(t, stream.Send(&pbMedia.UploadVideoRequest{VideoId: "talk", UploadId: uploadID, Offset: 3000, Heartbeat: true}))
+	}
+	require.NoError(t, stream.Send(&pbMedia.UploadVideoRequest{VideoId: "talk", UploadId: uploadID, Offset: 3000, Data: video[3000:]}))
+	resp, err := stream.CloseAndRecv()
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(video)), resp.TotalBytes)
+}
+
+// blockedStream is a stream whose peer never reads.
+type blockedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *blockedStream) Context() context.Context { return s.ctx }
+
+func (s *blockedStream) SendMsg(m interface{}) error {
+	<-s.ctx.Done()
+	return s.ctx.Err()
+}
+
+func TestStalledSendIsAborted(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	w := stall.Watchdog{Timeout: 50 * time.Millisecond}
+
+	var sendErr error
+	err := w.StreamServerInterceptor(nil, &blockedStream{ctx: ctx}, &grpc.StreamServerInfo{FullMethod: "/media.MediaService/DownloadVideo"},
+		func(srv interface{}, ss grpc.ServerStream) error {
+			sendErr = ss.SendMsg(&pbMedia.DownloadVideoResponse{})
+			return status.Error(codes.Internal, "failed to send chunk")
+		})
+	assert.Equal(t, codes.Aborted, status.Code(sendErr))
+	// The handler's error is replaced with the reason the stream ended
+	assert.Equal(t, codes.Aborted, status.Code(err))
+}
@@ -0,0 +1,149 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ScrubReport is the outcome of a Scrub.
+type ScrubReport struct {
+	Checked int   // blobs rehashed
+	Bytes   int64 // bytes rehashed
+	// Corrupt lists the hashes of blobs that no longer matched their
+	// content. They were moved to quarantine/, so records referring to them
+	// are left without a blob until it is stored again.
+	Corrupt []string
+	Orphans int // unreferenced blobs removed
+	Temp    int // abandoned temporary files removed
+	Dirs    int // empty fan-out directories removed
+}
+
+// Scrub rehashes every blob and quarantines those that changed on disk. It
+// also compacts the store: blobs no record refers to, temporary files older
+// than tempAge and empty fan-out directories are removed. Writes may go on
+// while it runs.
+func (s *Store) Scrub(ctx context.Context, tempAge time.Duration) (ScrubReport, error) {
+	var report ScrubReport
+	blobs := filepath.Join(s.dir, "blobs")
+	err := filepath.WalkDir(blobs, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		hash := d.Name()
+		if d.IsDir() || !validHash(hash) || path != s.blobPath(hash) {
+			return nil
+		}
+
+		s.mu.Lock()
+		orphan := s.collectLocked(hash)
+		s.mu.Unlock()
+		if orphan {
+			report.Orphans++
+			return nil
+		}
+
+		switch err := s.Verify(hash); {
+		case errors.Is(err, ErrCorrupt):
+			if err := s.quarantine(hash); err != nil {
+				return err
+			}
+			report.Corrupt = append(report.Corrupt, hash)
+		case errors.Is(err, fs.ErrNotExist):
+			// Collected since the walk listed it.
+			return nil
+		case err != nil:
+			return err
+		}
+		report.Checked++
+		report.Bytes += fileSize(path)
+		return nil
+	})
+	if err != nil {
+		return report, err
+	}
+
+	report.Dirs, err = s.removeEmptyDirs(blobs)
+	if err != nil {
+		return report, err
+	}
+	report.Temp, err = s.removeTemp(time.Now().Add(-tempAge))
+	return report, err
+}
+
+// quarantine moves a corrupt blob out of the way, keeping it for inspection.
+// A later Put of the same content stores a good copy again.
+func (s *Store) quarantine(hash string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	dst := filepath.Join(s.dir, "quarantine", hash+"."+time.Now().UTC().Format("20060102T150405Z"))
+	return os.Rename(s.blobPath(hash), dst)
+}
+
+// removeEmptyDirs removes the empty fan-out directories under blobs. The
+// lock keeps Put from renaming a blob into a directory being removed.
+func (s *Store) removeEmptyDirs(blobs string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	removed := 0
+	outer, err := os.ReadDir(blobs)
+	if err != nil {
+		return 0, err
+	}
+	for _, o := range outer {
+		if !o.IsDir() {
+			continue
+		}
+		dir := filepath.Join(blobs, o.Name())
+		inner, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		// Removing a directory fails unless it is empty.
+		for _, i := range inner {
+			if i.IsDir() && os.Remove(filepath.Join(dir, i.Name())) == nil {
+				removed++
+			}
+		}
+		if os.Remove(dir) == nil {
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+// removeTemp removes temporary files last written before cutoff. Writes in
+// progress keep their files fresh, so these were abandoned by a crash.
+func (s *Store) removeTemp(cutoff time.Time) (int, error) {
+	dir := filepath.Join(s.dir, "tmp")
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, err
+	}
+	removed := 0
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil || !info.ModTime().Before(cutoff) {
+			continue
+		}
+		if os.Remove(filepath.Join(dir, e.Name())) == nil {
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+func fileSize(path string) int64 {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0
+	}
+	return info.Size()
+}
@@ -0,0 +1,357 @@
+// Package storage keeps blobs on disk addressed by the SHA-256 of their
+// content, with a separate index that maps keys, such as video IDs, to blobs.
+//
+// Blobs live in a fan-out layout, blobs/ab/cd/abcd…, so that no directory
+// grows too large. Identical content is stored once however many keys refer
+// to it. Blobs are written to a temporary file and renamed into place once
+// complete, so concurrent writers of the same content cannot expose a partial
+// blob, and a blob's name is its checksum, which makes scrubbing a matter of
+// rehashing it.
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ErrCorrupt is returned by Verify when a blob no longer matches its hash.
+var ErrCorrupt = errors.New("blob content does not match its hash")
+
+// formatVersion is the version of the directory layout and index format. It
+// is written to the store's format file, so that a binary refuses a store
+// written by a newer one instead of misreading it. Bump it, and teach Open to
+// upgrade older stores, when the layout changes.
+const formatVersion = 1
+
+// Blob is an open blob. ReadAt reads ranges without loading the whole blob.
+type Blob interface {
+	io.ReadCloser
+	io.ReaderAt
+}
+
+// Record is an index entry.
+type Record struct {
+	Blob string `json:"blob"` // hex SHA-256 of the content
+	Size int64  `json:"size"`
+	// Meta is stored as given; its format is up to the caller.
+	Meta json.RawMessage `json:"meta,omitempty"`
+}
+
+// Store is a content-addressable blob store with an index.
+type Store struct {
+	dir string
+
+	mu    sync.Mutex
+	index map[string]Record
+	// pending counts the blobs returned by Put that no Set has referred to
+	// yet. They are not collected, even though no record refers to them.
+	pending map[string]int
+}
+
+// Open opens the store in dir, creating it when needed.
+func Open(dir string) (*Store, error) {
+	for _, sub := range []string{"blobs", "tmp", "quarantine"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o700); err != nil {
+			return nil, err
+		}
+	}
+	s := &Store{dir: dir, index: make(map[string]Record), pending: make(map[string]int)}
+	if err := s.checkFormat(); err != nil {
+		return nil, err
+	}
+
+	b, err := os.ReadFile(s.indexPath())
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, err
+	default:
+		if err := json.Unmarshal(b, &s.index); err != nil {
+			return nil, fmt.Errorf("invalid index: %v", err)
+		}
+	}
+
+	// Temporary files are only left by writes that never finished.
+	tmp, err := os.ReadDir(filepath.Join(dir, "tmp"))
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range tmp {
+		os.Remove(filepath.Join(dir, "tmp", e.Name()))
+	}
+	return s, nil
+}
+
+// checkFormat stamps a new store with formatVersion and refuses a store of
+// another version.
+func (s *Store) checkFormat() error {
+	path := filepath.Join(s.dir, "format")
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return os.WriteFile(path, []byte(strconv.Itoa(formatVersion)+"\n"), 0o600)
+	}
+	if err != nil {
+		return err
+	}
+	v, err := strconv.Atoi(strings.TrimSpace(string(b)))
+	if err != nil {
+		return fmt.Errorf("invalid format file: %v", err)
+	}
+	if v != formatVersion {
+		return fmt.Errorf("store has format version %d, this build supports %d", v, formatVersion)
+	}
+	return nil
+}
+
+func (s *Store) indexPath() string {
+	return filepath.Join(s.dir, "index.json")
+}
+
+// BlobKey returns the slash-separated path of a blob relative to the store
+// directory, under which a CDN fronting the directory serves it.
+func BlobKey(hash string) string {
+	return "blobs/" + hash[0:2] + "/" + hash[2:4] + "/" + hash
+}
+
+// blobPath returns where the blob with the given hex hash is kept.
+func (s *Store) blobPath(hash string) string {
+	return filepath.Join(s.dir, filepath.FromSlash(BlobKey(hash)))
+}
+
+func validHash(hash string) bool {
+	b, err := hex.DecodeString(hash)
+	return err == nil && len(b) == sha256.Size
+}
+
+// Put stores the content of r as a blob and returns its hash and size. When
+// the blob already exists, the new copy is discarded. The blob is kept until
+// a Set refers to it and is collected once no record does.
+func (s *Store) Put(r io.Reader) (string, int64, error) {
+	return s.put(r, true)
+}
+
+// Repair stores the content of r again as the blob with the given hash,
+// after Scrub quarantined it.
+func (s *Store) Repair(hash string, r io.Reader) error {
+	got, _, err := s.put(r, false)
+	if err != nil {
+		return err
+	}
+	if got != hash {
+		s.mu.Lock()
+		s.collectLocked(got)
+		s.mu.Unlock()
+		return fmt.Errorf("content hashes to %s", got)
+	}
+	return nil
+}
+
+// put stores a blob, marking it pending when a Set is to refer to it.
+func (s *Store) put(r io.Reader, pending bool) (string, int64, error) {
+	tmp, err := os.CreateTemp(filepath.Join(s.dir, "tmp"), "blob-*")
+	if err != nil {
+		return "", 0, err
+	}
+	defer os.Remove(tmp.Name()) // fails harmlessly once renamed
+
+	h := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, h), r)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", 0, err
+	}
+
+	hash := hex.EncodeToString(h.Sum(nil))
+	path := s.blobPath(hash)
+
+	// Under the lock, so that the blob or its directory is not collected
+	// before it is marked pending.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := os.Stat(path); err != nil {
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			return "", 0, err
+		}
+		if err := os.Rename(tmp.Name(), path); err != nil {
+			return "", 0, err
+		}
+	}
+	if pending {
+		s.pending[hash]++
+	}
+	return hash, size, nil
+}
+
+// OpenBlob opens a blob for reading.
+func (s *Store) OpenBlob(hash string) (Blob, error) {
+	if !validHash(hash) {
+		return nil, fmt.Errorf("invalid blob hash %q", hash)
+	}
+	f, err := os.Open(s.blobPath(hash))
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Verify rehashes a blob and returns ErrCorrupt when it changed on disk.
+func (s *Store) Verify(hash string) error {
+	f, err := s.OpenBlob(hash)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return err
+	}
+	if hex.EncodeToString(h.Sum(nil)) != hash {
+		return ErrCorrupt
+	}
+	return nil
+}
+
+// Records returns a copy of the index.
+func (s *Store) Records() map[string]Record {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	records := make(map[string]Record, len(s.index))
+	for key, rec := range s.index {
+		records[key] = rec
+	}
+	return records
+}
+
+// Record returns the index entry of key.
+func (s *Store) Record(key string) (Record, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rec, ok := s.index[key]
+	return rec, ok
+}
+
+// Discard gives up a blob stored with Put that no Set will refer to, such as
+// that of a failed upload, deleting it unless something else refers to it.
+func (s *Store) Discard(hash string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.pending[hash] > 1 {
+		s.pending[hash]--
+	} else {
+		delete(s.pending, hash)
+	}
+	s.collectLocked(hash)
+}
+
+// Set points key at rec, whose blob must have been stored with Put. A blob
+// that no key refers to any more is deleted.
+func (s *Store) Set(key string, rec Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	old, existed := s.index[key]
+	s.index[key] = rec
+	if err := s.saveIndexLocked(); err != nil {
+		if existed {
+			s.index[key] = old
+		} else {
+			delete(s.index, key)
+		}
+		return err
+	}
+	if s.pending[rec.Blob] > 1 {
+		s.pending[rec.Blob]--
+	} else {
+		delete(s.pending, rec.Blob)
+	}
+	if existed {
+		s.collectLocked(old.Blob)
+	}
+	return nil
+}
+
+// Delete removes key from the index, and its blob when no other key refers
+// to it.
+func (s *Store) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	old, existed := s.index[key]
+	if !existed {
+		return nil
+	}
+	delete(s.index, key)
+	if err := s.saveIndexLocked(); err != nil {
+		s.index[key] = old
+		return err
+	}
+	s.collectLocked(old.Blob)
+	return nil
+}
+
+// collectLocked deletes a blob that nothing refers to and reports whether it
+// did. The caller must hold s.mu.
+func (s *Store) collectLocked(hash string) bool {
+	if s.referencedLocked(hash) {
+		return false
+	}
+	return os.Remove(s.blobPath(hash)) == nil
+}
+
+// referencedLocked reports whether a record or a pending Put refers to a
+// blob. The caller must hold s.mu.
+func (s *Store) referencedLocked(hash string) bool {
+	if s.pending[hash] > 0 {
+		return true
+	}
+	for _, rec := range s.index {
+		if rec.Blob == hash {
+			return true
+		}
+	}
+	return false
+}
+
+// saveIndexLocked replaces the index file atomically. The caller must hold
+// s.mu.
+func (s *Store) saveIndexLocked() error {
+	b, err := json.Marshal(s.index)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Join(s.dir, "tmp"), "index-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.indexPath())
+}
@@ -0,0 +1,126 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreDeduplicatesAndCollects(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Open(dir)
+	require.NoError(t, err)
+
+	hash, size, err := s.Put(strings.NewReader("recording"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(9), size)
+	assert.FileExists(t, s.blobPath(hash))
+
+	again, _, err := s.Put(strings.NewReader("recording"))
+	require.NoError(t, err)
+	assert.Equal(t, hash, again)
+
+	require.NoError(t, s.Set("talk", Record{Blob: hash, Size: size}))
+	require.NoError(t, s.Set("copy", Record{Blob: hash, Size: size}))
+
+	// The index survives reopening the store.
+	s, err = Open(dir)
+	require.NoError(t, err)
+	assert.Len(t, s.Records(), 2)
+
+	// The blob stays while any key refers to it.
+	require.NoError(t, s.Delete("talk"))
+	assert.FileExists(t, s.blobPath(hash))
+	require.NoError(t, s.Delete("copy"))
+	assert.NoFileExists(t, s.blobPath(hash))
+}
+
+func TestStoreDiscard(t *testing.T) {
+	s, err := Open(t.TempDir())
+	require.NoError(t, err)
+
+	hash, size, err := s.Put(strings.NewReader("recording"))
+	require.NoError(t, err)
+	again, _, err := s.Put(strings.NewReader("recording"))
+	require.NoError(t, err)
+
+	// The blob stays for the other pending Put, until its Set.
+	s.Discard(again)
+	assert.FileExists(t, s.blobPath(hash))
+	require.NoError(t, s.Set("talk", Record{Blob: hash, Size: size}))
+	assert.FileExists(t, s.blobPath(hash))
+
+	other, _, err := s.Put(strings.NewReader("other"))
+	require.NoError(t, err)
+	s.Discard(other)
+	assert.NoFileExists(t, s.blobPath(other))
+}
+
+func TestStoreVerify(t *testing.T) {
+	s, err := Open(t.TempDir())
+	require.NoError(t, err)
+
+	hash, _, err := s.Put(strings.NewReader("recording"))
+	require.NoError(t, err)
+	require.NoError(t, s.Verify(hash))
+
+	require.NoError(t, os.WriteFile(s.blobPath(hash), []byte("rec0rding"), 0o600))
+	assert.ErrorIs(t, s.Verify(hash), ErrCorrupt)
+}
+
+func TestStoreRefusesOtherFormat(t *testing.T) {
+	dir := t.TempDir()
+	_, err := Open(dir)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "format"), []byte("2\n"), 0o600))
+	_, err = Open(dir)
+	assert.ErrorContains(t, err, "format version 2")
+}
+
+func TestStoreScrub(t *testing.T) {
+	s, err := Open(t.TempDir())
+	require.NoError(t, err)
+
+	good, size, err := s.Put(strings.NewReader("good"))
+	require.NoError(t, err)
+	require.NoError(t, s.Set("good", Record{Blob: good, Size: size}))
+	bad, size, err := s.Put(strings.NewReader("bad"))
+	require.NoError(t, err)
+	require.NoError(t, s.Set("bad", Record{Blob: bad, Size: size}))
+	require.NoError(t, os.WriteFile(s.blobPath(bad), []byte("b4d"), 0o600))
+
+	// A blob left by a crash between Put and Set, and a stale temporary file.
+	orphan := strings.Repeat("0", 64)
+	require.NoError(t, os.MkdirAll(filepath.Dir(s.blobPath(orphan)), 0o700))
+	require.NoError(t, os.WriteFile(s.blobPath(orphan), []byte("lost"), 0o600))
+	tmp := filepath.Join(s.dir, "tmp", "blob-1")
+	require.NoError(t, os.WriteFile(tmp, []byte("partial"), 0o600))
+	old := time.Now().Add(-2 * time.Hour)
+	require.NoError(t, os.Chtimes(tmp, old, old))
+
+	report, err := s.Scrub(context.Background(), time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Checked)
+	assert.Equal(t, []string{bad}, report.Corrupt)
+	assert.Equal(t, 1, report.Orphans)
+	assert.Equal(t, 1, report.Temp)
+	assert.Equal(t, 4, report.Dirs) // emptied by the orphan and the quarantine
+
+	assert.FileExists(t, s.blobPath(good))
+	assert.NoFileExists(t, s.blobPath(bad))
+	quarantined, err := os.ReadDir(filepath.Join(s.dir, "quarantine"))
+	require.NoError(t, err)
+	assert.Len(t, quarantined, 1)
+
+	// Storing the content again repairs the blob.
+	assert.Error(t, s.Repair(bad, strings.NewReader("b4d")))
+	require.NoError(t, s.Repair(bad, strings.NewReader("bad")))
+	assert.NoError(t, s.Verify(bad))
+}
@@ -0,0 +1,210 @@
+// Package synthmedia generates deterministic pseudo-random files of any size
+// from a seed, for end-to-end tests of large uploads and downloads without
+// fixtures in the repository.
+//
+// Every BlockSize bytes the content starts with a marker recording the seed,
+// the file size and the marker's own offset, so that a copy can be checked
+// with Verify alone, and a misplaced block tells where it came from.
+package synthmedia
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math/rand/v2"
+)
+
+const (
+	// BlockSize is the distance between markers.
+	BlockSize = 1 << 20
+	// MarkerSize is the length of a marker.
+	MarkerSize = 36
+
+	// pageSize is the unit generated from one keystream, so that reads at
+	// any offset only generate the pages they touch.
+	pageSize = 64 << 10
+)
+
+var magic = [8]byte{'S', 'Y', 'N', 'T', 'H', 'M', 'E', 'D'}
+
+// ErrMismatch is returned by Verify when the content differs from what the
+// generator produces.
+var ErrMismatch = errors.New("synthmedia: content mismatch")
+
+// Marker is the header at the start of every block.
+type Marker struct {
+	Seed   uint64
+	Size   int64 // size of the whole file
+	Offset int64 // offset of the marker in the file
+}
+
+func (m Marker) bytes() []byte {
+	b := make([]byte, MarkerSize)
+	copy(b, magic[:])
+	binary.BigEndian.PutUint64(b[8:], m.Seed)
+	binary.BigEndian.PutUint64(b[16:], uint64(m.Size))
+	binary.BigEndian.PutUint64(b[24:], uint64(m.Offset))
+	binary.BigEndian.PutUint32(b[32:], crc32.ChecksumIEEE(b[:32]))
+	return b
+}
+
+// ParseMarker decodes the marker at the start of b.
+func ParseMarker(b []byte) (Marker, error) {
+	if len(b) < MarkerSize || !bytes.Equal(b[:8], magic[:]) {
+		return Marker{}, errors.New("synthmedia: no marker")
+	}
+	if crc32.ChecksumIEEE(b[:32]) != binary.BigEndian.Uint32(b[32:]) {
+		return Marker{}, errors.New("synthmedia: corrupt marker")
+	}
+	return Marker{
+		Seed:   binary.BigEndian.Uint64(b[8:]),
+		Size:   int64(binary.BigEndian.Uint64(b[16:])),
+		Offset: int64(binary.BigEndian.Uint64(b[24:])),
+	}, nil
+}
+
+// Reader reads a generated file. ReadAt may be called concurrently, unlike
+// Read and Seek.
+type Reader struct {
+	seed uint64
+	size int64
+	off  int64
+}
+
+// New returns a reader of the size bytes generated from seed.
+func New(seed uint64, size int64) *Reader {
+	return &Reader{seed: seed, size: size}
+}
+
+// Size returns the size of the file.
+func (r *Reader) Size() int64 { return r.size }
+
+func (r *Reader) Read(p []byte) (int, error) {
+	n, err := r.ReadAt(p, r.off)
+	r.off += int64(n)
+	if err == io.EOF && n > 0 {
+		err = nil
+	}
+	return n, err
+}
+
+func (r *Reader) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, errors.New("synthmedia: negative offset")
+	}
+	if off >= r.size {
+		return 0, io.EOF
+	}
+	var page []byte
+	n := 0
+	for n < len(p) && off < r.size {
+		index := off / pageSize
+		page = r.page(index, page)
+		c := copy(p[n:], page[off-index*pageSize:])
+		n += c
+		off += int64(c)
+	}
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+func (r *Reader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.off
+	case io.SeekEnd:
+		offset += r.size
+	default:
+		return 0, errors.New("synthmedia: invalid whence")
+	}
+	if offset < 0 {
+		return 0, errors.New("synthmedia: negative offset")
+	}
+	r.off = offset
+	return offset, nil
+}
+
+// page generates page index into buf, reusing its storage.
+func (r *Reader) page(index int64, buf []byte) []byte {
+	start := index * pageSize
+	n := min(pageSize, r.size-start)
+	if cap(buf) < pageSize {
+		buf = make([]byte, pageSize)
+	}
+	buf = buf[:n]
+
+	var key [32]byte
+	binary.BigEndian.PutUint64(key[:], r.seed)
+	binary.BigEndian.PutUint64(key[8:], uint64(index))
+	rand.NewChaCha8(key).Read(buf)
+
+	if start%BlockSize == 0 {
+		copy(buf, Marker{Seed: r.seed, Size: r.size, Offset: start}.bytes())
+	}
+	return buf
+}
+
+// Verify reads a generated file from r and checks it byte for byte against
+// the generator, using the seed and size of its first marker. It returns that
+// marker, and an error wrapping ErrMismatch at the first difference.
+func Verify(r io.Reader) (Marker, error) {
+	head := make([]byte, MarkerSize)
+	if _, err := io.ReadFull(r, head); err != nil {
+		return Marker{}, fmt.Errorf("synthmedia: reading first marker: %w", err)
+	}
+	m, err := ParseMarker(head)
+	if err != nil {
+		return Marker{}, err
+	}
+	if m.Offset != 0 {
+		return m, fmt.Errorf("%w: file starts with the block at offset %d", ErrMismatch, m.Offset)
+	}
+
+	want := New(m.Seed, m.Size)
+	r = io.MultiReader(bytes.NewReader(head), r)
+	got := make([]byte, pageSize)
+	exp := make([]byte, pageSize)
+	for off := int64(0); off < m.Size; {
+		n := int(min(pageSize, m.Size-off))
+		if k, err := io.ReadFull(r, got[:n]); err != nil {
+			return m, fmt.Errorf("%w: file ends at byte %d of %d", ErrMismatch, off+int64(k), m.Size)
+		}
+		want.ReadAt(exp[:n], off)
+		if i := firstDiff(got[:n], exp[:n]); i >= 0 {
+			return m, mismatchAt(off+int64(i), got[:n], off)
+		}
+		off += int64(n)
+	}
+	if n, _ := r.Read(got[:1]); n > 0 {
+		return m, fmt.Errorf("%w: file is longer than %d bytes", ErrMismatch, m.Size)
+	}
+	return m, nil
+}
+
+func firstDiff(a, b []byte) int {
+	for i := range a {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	return -1
+}
+
+// mismatchAt describes a difference at offset, found in page, which starts
+// at pageOff. A block boundary in the page holding another block's marker
+// tells where the data came from.
+func mismatchAt(offset int64, page []byte, pageOff int64) error {
+	boundary := offset / BlockSize * BlockSize
+	if boundary >= pageOff {
+		if m, err := ParseMarker(page[boundary-pageOff:]); err == nil && m.Offset != boundary {
+			return fmt.Errorf("%w at byte %d: block at %d holds the data of offset %d", ErrMismatch, offset, boundary, m.Offset)
+		}
+	}
+	return fmt.Errorf("%w at byte %d", ErrMismatch, offset)
+}
@@ -0,0 +1,102 @@
+package synthmedia_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"coscup2025/client"
+	"coscup2025/servertest"
+	"coscup2025/synthmedia"
+)
+
+func TestDeterministic(t *testing.T) {
+	const size = 3*synthmedia.BlockSize + 12345
+	a, err := io.ReadAll(synthmedia.New(7, size))
+	require.NoError(t, err)
+	b, err := io.ReadAll(synthmedia.New(7, size))
+	require.NoError(t, err)
+	assert.Len(t, a, size)
+	assert.Equal(t, a, b)
+
+	other, err := io.ReadAll(synthmedia.New(8, size))
+	require.NoError(t, err)
+	assert.NotEqual(t, a, other)
+
+	// Reads at any offset agree with reading from the start.
+	part := make([]byte, 100_000)
+	_, err = synthmedia.New(7, size).ReadAt(part, 2*synthmedia.BlockSize-50_000)
+	require.NoError(t, err)
+	assert.Equal(t, a[2*synthmedia.BlockSize-50_000:][:100_000], part)
+
+	m, err := synthmedia.ParseMarker(a[2*synthmedia.BlockSize:])
+	require.NoError(t, err)
+	assert.Equal(t, synthmedia.Marker{Seed: 7, Size: size, Offset: 2 * synthmedia.BlockSize}, m)
+}
+
+func TestVerify(t *testing.T) {
+	const size = 3 * synthmedia.BlockSize
+	data, err := io.ReadAll(synthmedia.New(1, size))
+	require.NoError(t, err)
+
+	m, err := synthmedia.Verify(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, int64(size), m.Size)
+
+	corrupt := bytes.Clone(data)
+	corrupt[size-1] ^= 1
+	_, err = synthmedia.Verify(bytes.NewReader(corrupt))
+	assert.ErrorIs(t, err, synthmedia.ErrMismatch)
+	assert.ErrorContains(t, err, "at byte 3145727")
+
+	swapped := bytes.Clone(data)
+	copy(swapped[synthmedia.BlockSize:], data[2*synthmedia.BlockSize:])
+	_, err = synthmedia.Verify(bytes.NewReader(swapped))
+	assert.ErrorContains(t, err, "holds the data of offset 2097152")
+
+	_, err = synthmedia.Verify(bytes.NewReader(data[:size-10]))
+	assert.ErrorContains(t, err, "file ends at byte 3145718")
+	_, err = synthmedia.Verify(bytes.NewReader(append(bytes.Clone(data), 0)))
+	assert.ErrorContains(t, err, "longer than")
+}
+
+// TestRoundTrip uploads and downloads a generated video through the server.
+// COSCUP_SYNTH_SIZE sets its size in bytes, e.g. to run a multi-GB transfer;
+// the server keeps the video in memory.
+func TestRoundTrip(t *testing.T) {
+	size := int64(5*synthmedia.BlockSize + 1)
+	if v := os.Getenv("COSCUP_SYNTH_SIZE"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		require.NoError(t, err)
+		size = n
+	}
+
+	srv := servertest.New(t, nil)
+	token := srv.CreateUser(t, "speaker", "secret")
+	c, err := client.New(servertest.Target,
+		client.WithDialOptions(srv.DialOption()),
+		client.WithToken(client.Token{AccessToken: token}),
+	)
+	require.NoError(t, err)
+	defer c.Close()
+
+	ctx := context.Background()
+	_, err = c.Upload(ctx, "synthetic", synthmedia.New(42, size), size, nil)
+	require.NoError(t, err)
+
+	pr, pw := io.Pipe()
+	defer pr.Close()
+	go func() {
+		_, err := c.Download(ctx, "synthetic", pw, nil)
+		pw.CloseWithError(err)
+	}()
+	m, err := synthmedia.Verify(pr)
+	require.NoError(t, err)
+	assert.Equal(t, synthmedia.Marker{Seed: 42, Size: size}, m)
+}
@@ -0,0 +1,90 @@
+// Package validation rejects requests that break the rules declared on their
+// messages with buf.validate options, such as non-empty IDs and bounded
+// string lengths, before they reach the handlers:
+//
+//	string video_id = 1 [(buf.validate.field).string = {min_len: 1, max_len: 128}];
+//
+// Handlers only check what the rules cannot express, like whether a video
+// exists.
+package validation
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"buf.build/go/protovalidate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// UnaryServerInterceptor validates the request of unary calls.
+func UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamServerInterceptor validates every message clients send on streams.
+func StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &validatingStream{ServerStream: ss})
+}
+
+// validatingStream fails RecvMsg for messages that break their rules.
+type validatingStream struct {
+	grpc.ServerStream
+}
+
+func (s *validatingStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return validate(m)
+}
+
+// Validate checks a message that is not a request itself, such as a payload
+// that a request carries as bytes, the way requests are checked.
+func Validate(m proto.Message) error {
+	return validate(m)
+}
+
+// validate returns an InvalidArgument error listing the fields of m that
+// break their rules, with a BadRequest detail for clients that show them by
+// field.
+func validate(m interface{}) error {
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return nil
+	}
+	err := protovalidate.Validate(msg)
+	if err == nil {
+		return nil
+	}
+	var verr *protovalidate.ValidationError
+	if !errors.As(err, &verr) {
+		return status.Errorf(codes.Internal, "failed to validate request: %v", err)
+	}
+
+	var (
+		messages   []string
+		violations []*errdetails.BadRequest_FieldViolation
+	)
+	for _, v := range verr.Violations {
+		field := protovalidate.FieldPathString(v.Proto.GetField())
+		messages = append(messages, field+": "+v.Proto.GetMessage())
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       field,
+			Description: v.Proto.GetMessage(),
+		})
+	}
+	text := "invalid request: " + strings.Join(messages, "; ")
+	st, err := status.New(codes.InvalidArgument, text).WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if err != nil {
+		return status.Error(codes.InvalidArgument, text)
+	}
+	return st.Err()
+}
@@ -0,0 +1,73 @@
+package validation
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"coscup2025/proto/comment"
+	"coscup2025/proto/media"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	called := false
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		called = true
+		return nil, nil
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: "/comment.CommentService/PostComment"}
+
+	_, err := UnaryServerInterceptor(context.Background(), &comment.PostCommentRequest{Body: strings.Repeat("a", 2001)}, info, handler)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.False(t, called)
+	var fields []string
+	for _, d := range status.Convert(err).Details() {
+		for _, v := range d.(*errdetails.BadRequest).FieldViolations {
+			fields = append(fields, v.Field)
+		}
+	}
+	assert.ElementsMatch(t, []string{"video_id", "body"}, fields)
+
+	_, err = UnaryServerInterceptor(context.Background(), &comment.PostCommentRequest{VideoId: "talk", Body: "hi"}, info, handler)
+	require.NoError(t, err)
+	assert.True(t, called)
+}
+
+// chunkStream receives the given chunks.
+type chunkStream struct {
+	grpc.ServerStream
+	chunks []*media.UploadVideoRequest
+}
+
+func (s *chunkStream) RecvMsg(m interface{}) error {
+	proto.Merge(m.(proto.Message), s.chunks[0])
+	s.chunks = s.chunks[1:]
+	return nil
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	ss := &chunkStream{chunks: []*media.UploadVideoRequest{
+		{VideoId: "talk", Data: []byte("coscup")},
+		{VideoId: "talk", Offset: -1},
+	}}
+	var errs []error
+	handler := func(srv interface{}, stream grpc.ServerStream) error {
+		for range 2 {
+			errs = append(errs, stream.RecvMsg(&media.UploadVideoRequest{}))
+		}
+		return nil
+	}
+	require.NoError(t, StreamServerInterceptor(nil, ss, &grpc.StreamServerInfo{IsClientStream: true}, handler))
+	require.Len(t, errs, 2)
+	assert.NoError(t, errs[0])
+	assert.Equal(t, codes.InvalidArgument, status.Code(errs[1]))
+	assert.ErrorContains(t, errs[1], "offset")
+}